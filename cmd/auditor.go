@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon/auditor"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/diagnostics"
+)
+
+// AuditorCommand contains the definition of the auditor command-line
+// subcommand.
+var AuditorCommand = &cobra.Command{
+	Use:   "auditor",
+	Short: "Starts the random beacon auditor",
+	Long:  auditorDescription,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if err := clientConfig.ReadConfig(
+			configFilePath,
+			cmd.Flags(),
+			config.General,
+			config.Diagnostics,
+		); err != nil {
+			logger.Fatalf("error reading config: %v", err)
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := startAuditor(cmd); err != nil {
+			logger.Fatal(err)
+		}
+	},
+}
+
+const auditorDescription = `Starts the random beacon auditor in the foreground.
+   The auditor does not require staking nor group membership and it does not
+   need an operator key. It follows the random beacon on the chain and
+   verifies every submitted relay entry against the previous entry and
+   the public key of the group registered on the chain. The history of
+   audited entries is exposed in JSON format on the diagnostics server,
+   under the ` + auditor.EntriesPath + ` path.`
+
+func init() {
+	initFlags(
+		AuditorCommand,
+		&configFilePath,
+		clientConfig,
+		config.Ethereum,
+		config.Diagnostics,
+	)
+}
+
+// startAuditor starts the random beacon auditor.
+func startAuditor(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	if clientConfig.Ethereum.URL == "" {
+		return fmt.Errorf(
+			"missing value for ethereum.url; " +
+				"see ethereum section in configuration",
+		)
+	}
+
+	logger.Infof(
+		"Starting the random beacon auditor against [%s] ethereum network...",
+		clientConfig.Ethereum.Network,
+	)
+
+	beaconChain, _, err := ethereum.ConnectBeaconObserver(
+		ctx,
+		clientConfig.Ethereum,
+	)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	if _, isConfigured := diagnostics.Initialize(
+		clientConfig.Diagnostics.Port,
+	); !isConfigured {
+		return fmt.Errorf(
+			"missing value for diagnostics.port; the auditor exposes " +
+				"audited entries on the diagnostics server",
+		)
+	}
+
+	beaconAuditor := auditor.NewAuditor(beaconChain, auditor.DefaultHistorySize)
+	beaconAuditor.Start(ctx)
+
+	http.Handle(auditor.EntriesPath, beaconAuditor)
+
+	logger.Infof(
+		"exposing audited relay entries on port [%v] under [%s]",
+		clientConfig.Diagnostics.Port,
+		auditor.EntriesPath,
+	)
+
+	<-ctx.Done()
+
+	return ctx.Err()
+}
@@ -34,7 +34,11 @@ func init() {
 	RootCmd.AddCommand(
 		StartCommand,
 		PingCommand,
+		NetworkCommand,
 		EthereumCommand,
+		KeystoreCommand,
+		EvidenceCommand,
+		AuditorCommand,
 	)
 }
 
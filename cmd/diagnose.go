@@ -0,0 +1,410 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	commonEthereum "github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/remotesigner"
+	"github.com/keep-network/keep-core/pkg/diagnostics"
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/diagnosis"
+	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/net/retransmission"
+)
+
+// adminRequestTimeout is the maximum time of waiting for the diagnosis
+// run by the running client.
+const adminRequestTimeout = 5 * time.Minute
+
+var (
+	// URL of the diagnostics server of the running client. The value is
+	// set with `--admin` command-line flag.
+	diagnoseAdminURL string
+	// Port the diagnosing client listens on. The value is set with `--port`
+	// command-line flag.
+	diagnosePort int
+	// Time of waiting for echoes of the probe. The value is set with
+	// `--probeTimeout` command-line flag.
+	diagnoseProbeTimeout time.Duration
+	// Determines if the report is printed in JSON format. The value is set
+	// with `--json` command-line flag.
+	diagnoseJSON bool
+)
+
+// NetworkCommand contains the definition of the network command-line
+// subcommand and its own subcommands.
+var NetworkCommand = &cobra.Command{
+	Use:   "network",
+	Short: "Diagnoses the client's connectivity with the network",
+	Long:  "Diagnoses the client's connectivity with the network",
+}
+
+// DiagnoseCommand contains the definition of the network diagnose
+// command-line subcommand.
+var DiagnoseCommand = &cobra.Command{
+	Use:   "diagnose",
+	Short: "Diagnoses the client's connectivity with the network",
+	Long:  diagnoseDescription,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// The running client diagnoses itself with its own configuration.
+		if diagnoseAdminURL != "" {
+			return
+		}
+
+		if err := clientConfig.ReadConfig(
+			configFilePath,
+			cmd.Flags(),
+			config.General,
+			config.Ethereum,
+			config.Network,
+		); err != nil {
+			logger.Fatalf("error reading config: %v", err)
+		}
+	},
+	RunE: diagnose,
+}
+
+const diagnoseDescription = `The diagnose command checks the client's
+   connectivity with the network, using the operator key and the network
+   configuration of the client. It connects to the configured peers and
+   reports the stage at which the connection failed, along with the reason,
+   including peers' firewall rejections. It measures the latency to connected
+   peers and the propagation of a probe published to the test topic, checks
+   the reachability of announced addresses, and reports the health of the DHT
+   routing table. As the diagnosing client uses the operator's network
+   identity, it should not be run alongside the client. Use --admin flag
+   pointing to the diagnostics server of the running client to make the client
+   diagnose itself instead.`
+
+func init() {
+	initFlags(
+		DiagnoseCommand,
+		&configFilePath,
+		clientConfig,
+		config.General,
+		config.Ethereum,
+		config.Network,
+	)
+
+	DiagnoseCommand.Flags().StringVar(
+		&diagnoseAdminURL,
+		"admin",
+		"",
+		"URL of the diagnostics server of the running client, e.g. http://localhost:9601.",
+	)
+
+	DiagnoseCommand.Flags().IntVar(
+		&diagnosePort,
+		"port",
+		0,
+		"Port the diagnosing client listens on. Random if not set.",
+	)
+
+	DiagnoseCommand.Flags().DurationVar(
+		&diagnoseProbeTimeout,
+		"probeTimeout",
+		diagnosis.DefaultProbeTimeout,
+		"Time of waiting for echoes of the probe published to the test topic.",
+	)
+
+	DiagnoseCommand.Flags().BoolVar(
+		&diagnoseJSON,
+		"json",
+		false,
+		"Print the report in JSON format.",
+	)
+
+	NetworkCommand.AddCommand(DiagnoseCommand)
+}
+
+// diagnose diagnoses the client's connectivity with the network and prints
+// the report.
+func diagnose(cmd *cobra.Command, args []string) error {
+	var (
+		report *diagnosis.Report
+		err    error
+	)
+
+	if diagnoseAdminURL != "" {
+		report, err = fetchDiagnosis(diagnoseAdminURL, diagnoseProbeTimeout)
+	} else {
+		report, err = runDiagnosis(context.Background(), diagnoseProbeTimeout)
+	}
+	if err != nil {
+		return err
+	}
+
+	if diagnoseJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	printDiagnosis(os.Stdout, report)
+
+	return nil
+}
+
+// fetchDiagnosis requests the running client with the given diagnostics
+// server URL to diagnose itself.
+func fetchDiagnosis(
+	adminURL string,
+	probeTimeout time.Duration,
+) (*diagnosis.Report, error) {
+	requestURL, err := url.Parse(
+		strings.TrimSuffix(adminURL, "/") + diagnostics.NetworkDiagnosisPath,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid admin URL: [%v]", err)
+	}
+
+	query := requestURL.Query()
+	query.Set("probeTimeout", probeTimeout.String())
+	requestURL.RawQuery = query.Encode()
+
+	client := &http.Client{Timeout: adminRequestTimeout}
+
+	response, err := client.Get(requestURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("could not request diagnosis: [%v]", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return nil, fmt.Errorf(
+			"diagnosis failed with status [%v]: [%v]",
+			response.Status,
+			strings.TrimSpace(string(body)),
+		)
+	}
+
+	report := &diagnosis.Report{}
+	if err := json.NewDecoder(response.Body).Decode(report); err != nil {
+		return nil, fmt.Errorf("could not decode diagnosis: [%v]", err)
+	}
+
+	return report, nil
+}
+
+// runDiagnosis starts a network provider with the operator key and
+// the network configuration of the client and diagnoses its connectivity
+// with the network. The provider does not bootstrap with the configured
+// peers so that connections with them are diagnosed by the diagnosis.
+func runDiagnosis(
+	ctx context.Context,
+	probeTimeout time.Duration,
+) (*diagnosis.Report, error) {
+	ctx, cancelCtx := context.WithCancel(ctx)
+	defer cancelCtx()
+
+	networkConfig := clientConfig.LibP2P
+	networkConfig.Peers = nil
+	networkConfig.Port = diagnosePort
+	networkConfig.NATPortMap = false
+	networkConfig.RelayService = false
+
+	ticker := retransmission.NewTimeTicker(ctx, 1*time.Second)
+
+	var (
+		netProvider     net.Provider
+		networkFirewall net.Firewall
+	)
+
+	if clientConfig.RemoteSigner.IsEnabled() {
+		signerClient, err := remotesigner.Dial(ctx, clientConfig.RemoteSigner)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error connecting to remote signer: [%v]",
+				err,
+			)
+		}
+
+		beaconChain, tbtcChain, _, _, err := ethereum.ConnectWithRemoteSigner(
+			ctx,
+			clientConfig.Ethereum,
+			signerClient,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error connecting to Ethereum node: [%v]",
+				err,
+			)
+		}
+
+		networkFirewall = firewall.AnyApplicationPolicy(
+			[]firewall.Application{beaconChain, tbtcChain},
+		)
+
+		netProvider, err = libp2p.ConnectWithIdentitySigner(
+			ctx,
+			networkConfig,
+			signerClient.NetworkSigner(),
+			networkFirewall,
+			ticker,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed while creating the network provider: [%v]",
+				err,
+			)
+		}
+	} else {
+		operatorChains, _, err := ethereum.ConnectOperators(
+			ctx,
+			clientConfig.Ethereum,
+			[]commonEthereum.Account{clientConfig.Ethereum.Account},
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error connecting to Ethereum node: [%v]",
+				err,
+			)
+		}
+
+		networkFirewall = firewall.AnyApplicationPolicy(
+			[]firewall.Application{
+				operatorChains[0].BeaconChain,
+				operatorChains[0].TbtcChain,
+			},
+		)
+
+		netProvider, err = libp2p.Connect(
+			ctx,
+			networkConfig,
+			operatorChains[0].PrivateKey,
+			networkFirewall,
+			ticker,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed while creating the network provider: [%v]",
+				err,
+			)
+		}
+	}
+
+	return diagnosis.Diagnose(
+		ctx,
+		netProvider,
+		diagnosis.Options{
+			Peers:        clientConfig.LibP2P.Peers,
+			Addresses:    clientConfig.LibP2P.AnnouncedAddresses,
+			Firewall:     networkFirewall,
+			ProbeTimeout: probeTimeout,
+		},
+	)
+}
+
+// printDiagnosis prints the report in a human-readable form.
+func printDiagnosis(writer io.Writer, report *diagnosis.Report) {
+	fmt.Fprintf(writer, "Network ID: %v\n", report.PeerID)
+	if report.ReachabilityStatus != "" {
+		fmt.Fprintf(writer, "Reachability: %v\n", report.ReachabilityStatus)
+	}
+	fmt.Fprintf(writer, "Connected peers: %v\n", report.ConnectedPeers)
+
+	if report.Operator != nil {
+		if report.Operator.Admitted {
+			fmt.Fprintf(writer, "Operator: admitted by the firewall\n")
+		} else {
+			fmt.Fprintf(
+				writer,
+				"Operator: NOT admitted by the firewall: %v\n",
+				report.Operator.FailureReason,
+			)
+		}
+	}
+
+	fmt.Fprintf(writer, "\nPeers:\n")
+	if len(report.Peers) == 0 {
+		fmt.Fprintf(writer, "  no peers configured\n")
+	}
+	for _, peer := range report.Peers {
+		if peer.Connected {
+			fmt.Fprintf(
+				writer,
+				"  [OK]   %v latency %v\n",
+				peer.Address,
+				peer.Latency,
+			)
+		} else {
+			fmt.Fprintf(
+				writer,
+				"  [FAIL] %v %v: %v\n",
+				peer.Address,
+				peer.Failure,
+				peer.FailureReason,
+			)
+		}
+	}
+
+	fmt.Fprintf(writer, "\nAnnounced addresses:\n")
+	if len(report.Addresses) == 0 {
+		fmt.Fprintf(writer, "  no addresses announced\n")
+	}
+	for _, address := range report.Addresses {
+		if address.Reachable {
+			fmt.Fprintf(writer, "  [OK]   %v\n", address.Address)
+		} else {
+			fmt.Fprintf(
+				writer,
+				"  [FAIL] %v: %v\n",
+				address.Address,
+				address.FailureReason,
+			)
+		}
+	}
+
+	fmt.Fprintf(writer, "\nPropagation:\n")
+	if report.Propagation.FailureReason != "" {
+		fmt.Fprintf(
+			writer,
+			"  [FAIL] %v\n",
+			report.Propagation.FailureReason,
+		)
+	} else {
+		fmt.Fprintf(
+			writer,
+			"  probe %v echoed by %v peers\n",
+			report.Propagation.ProbeID,
+			len(report.Propagation.Echoes),
+		)
+		for _, echo := range report.Propagation.Echoes {
+			fmt.Fprintf(
+				writer,
+				"  %v round trip %v\n",
+				echo.PeerID,
+				echo.RoundTrip,
+			)
+		}
+	}
+
+	routingTable := report.RoutingTable
+	health := "healthy"
+	if !routingTable.Healthy {
+		health = "UNHEALTHY"
+	}
+
+	fmt.Fprintf(writer, "\nRouting table: %v\n", health)
+	fmt.Fprintf(
+		writer,
+		"  %v peers, %v connected, %v stale\n",
+		routingTable.Size,
+		routingTable.ConnectedPeers,
+		routingTable.StalePeers,
+	)
+}
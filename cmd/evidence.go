@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/gjkr"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/storage"
+)
+
+var (
+	// Seed of the DKG the evidence was recorded for. The value is set with
+	// `--seed` command-line flag.
+	evidenceSeed string
+	// Index of the member who recorded the evidence. The value is set with
+	// `--member` command-line flag.
+	evidenceMemberIndex uint8
+	// Path to the file the evidence bundle is written to. The value is set
+	// with `--output` command-line flag.
+	evidenceOutputFile string
+)
+
+// EvidenceCommand contains the definition of the evidence command-line
+// subcommand and its own subcommands.
+var EvidenceCommand = &cobra.Command{
+	Use:   "evidence",
+	Short: "Manages the evidence recorded during DKG",
+	Long: "Manages the evidence recorded by the client during the random " +
+		"beacon distributed key generation",
+}
+
+// EvidenceListCommand contains the definition of the evidence list
+// command-line subcommand.
+var EvidenceListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the evidence kept in the client's storage",
+	Long:  "Lists the evidence kept in the client's storage",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if err := clientConfig.ReadConfig(
+			configFilePath,
+			cmd.Flags(),
+			config.General,
+			config.Storage,
+		); err != nil {
+			logger.Fatalf("error reading config: %v", err)
+		}
+	},
+	RunE: listEvidence,
+}
+
+// EvidenceExportCommand contains the definition of the evidence export
+// command-line subcommand.
+var EvidenceExportCommand = &cobra.Command{
+	Use:   "export",
+	Short: "Exports the evidence as a verifiable bundle",
+	Long:  evidenceExportDescription,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if err := clientConfig.ReadConfig(
+			configFilePath,
+			cmd.Flags(),
+			config.General,
+			config.Storage,
+		); err != nil {
+			logger.Fatalf("error reading config: %v", err)
+		}
+	},
+	RunE: exportEvidence,
+}
+
+// EvidenceResolveCommand contains the definition of the evidence resolve
+// command-line subcommand.
+var EvidenceResolveCommand = &cobra.Command{
+	Use:   "resolve <bundle>",
+	Short: "Resolves DKG accusations from the evidence bundle",
+	Long:  evidenceResolveDescription,
+	Args:  cobra.ExactArgs(1),
+	RunE:  resolveEvidence,
+}
+
+const evidenceExportDescription = `The export command exports the evidence
+   recorded by the given member during the DKG with the given seed. The
+   evidence is exported as a self-contained bundle holding all the messages
+   exchanged in the DKG phases relevant for the accusations resolution, along
+   with signatures and operator public keys of their senders. The evidence is
+   verified before it is exported.`
+
+const evidenceResolveDescription = `The resolve command verifies the evidence
+   bundle produced by the export command and resolves secret shares
+   accusations based on the messages kept in the bundle. The command does not
+   require access to the client's storage nor to the chain. It prints the
+   operator public keys of the members who signed the messages, so they can be
+   compared with the group selection result, and the members marked as
+   inactive or disqualified once the accusations are resolved.`
+
+func init() {
+	for _, command := range []*cobra.Command{
+		EvidenceListCommand,
+		EvidenceExportCommand,
+	} {
+		initFlags(
+			command,
+			&configFilePath,
+			clientConfig,
+			config.General,
+			config.Storage,
+		)
+	}
+
+	EvidenceExportCommand.Flags().StringVar(
+		&evidenceSeed,
+		"seed",
+		"",
+		"Seed of the DKG the evidence was recorded for, e.g. 0x1f.",
+	)
+
+	EvidenceExportCommand.Flags().Uint8Var(
+		&evidenceMemberIndex,
+		"member",
+		0,
+		"Index of the group member who recorded the evidence.",
+	)
+
+	EvidenceExportCommand.Flags().StringVar(
+		&evidenceOutputFile,
+		"output",
+		"",
+		"Path to the file the evidence bundle is written to.",
+	)
+
+	for _, flag := range []string{"seed", "member", "output"} {
+		if err := EvidenceExportCommand.MarkFlagRequired(flag); err != nil {
+			logger.Fatalf("could not mark flag [%s] as required: %v", flag, err)
+		}
+	}
+
+	EvidenceCommand.AddCommand(
+		EvidenceListCommand,
+		EvidenceExportCommand,
+		EvidenceResolveCommand,
+	)
+}
+
+// listEvidence prints the evidence kept in the client's storage.
+func listEvidence(cmd *cobra.Command, args []string) error {
+	evidenceStore, err := initializeEvidenceStore()
+	if err != nil {
+		return err
+	}
+
+	for _, evidence := range evidenceStore.List() {
+		fmt.Printf(
+			"seed: 0x%x member: %v recorded at: %v messages: %v\n",
+			evidence.Seed,
+			evidence.MemberIndex,
+			evidence.RecordedAt,
+			len(evidence.Messages),
+		)
+	}
+
+	return nil
+}
+
+// exportEvidence writes the evidence bundle to the output file.
+func exportEvidence(cmd *cobra.Command, args []string) error {
+	seed, ok := new(big.Int).SetString(evidenceSeed, 0)
+	if !ok {
+		return fmt.Errorf("invalid seed [%s]", evidenceSeed)
+	}
+
+	evidenceStore, err := initializeEvidenceStore()
+	if err != nil {
+		return err
+	}
+
+	bundle, err := evidenceStore.Export(
+		seed,
+		group.MemberIndex(evidenceMemberIndex),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot export evidence: [%w]", err)
+	}
+
+	if err := os.WriteFile(evidenceOutputFile, bundle, 0600); err != nil {
+		return fmt.Errorf("cannot write evidence bundle: [%w]", err)
+	}
+
+	logger.Infof("evidence exported to [%s]", evidenceOutputFile)
+
+	return nil
+}
+
+// resolveEvidence resolves accusations from the evidence bundle and prints
+// the resolution.
+func resolveEvidence(cmd *cobra.Command, args []string) error {
+	bundle, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("cannot read evidence bundle: [%w]", err)
+	}
+
+	evidence, err := dkg.ImportEvidence(bundle)
+	if err != nil {
+		return fmt.Errorf("cannot import evidence bundle: [%w]", err)
+	}
+
+	senderPublicKeys, err := evidence.SenderPublicKeys()
+	if err != nil {
+		return fmt.Errorf("cannot read sender public keys: [%w]", err)
+	}
+
+	resolution, err := evidence.ResolveSecretSharesAccusations(logger)
+	if err != nil {
+		return fmt.Errorf("cannot resolve accusations: [%w]", err)
+	}
+
+	printEvidenceResolution(os.Stdout, evidence, senderPublicKeys, resolution)
+
+	return nil
+}
+
+func printEvidenceResolution(
+	writer io.Writer,
+	evidence *gjkr.Evidence,
+	senderPublicKeys map[group.MemberIndex][]byte,
+	resolution *gjkr.AccusationsResolution,
+) {
+	fmt.Fprintf(writer, "DKG seed:     0x%x\n", evidence.Seed)
+	fmt.Fprintf(writer, "Recorded by:  member %v\n", evidence.MemberIndex)
+	fmt.Fprintf(writer, "Recorded at:  %v\n", evidence.RecordedAt)
+	fmt.Fprintf(writer, "Messages:     %v\n", len(evidence.Messages))
+
+	fmt.Fprintf(writer, "\nSender public keys:\n")
+	senders := make([]group.MemberIndex, 0, len(senderPublicKeys))
+	for sender := range senderPublicKeys {
+		senders = append(senders, sender)
+	}
+	sort.Slice(senders, func(i, j int) bool {
+		return senders[i] < senders[j]
+	})
+	for _, sender := range senders {
+		fmt.Fprintf(
+			writer,
+			"  member %v: 0x%s\n",
+			sender,
+			hex.EncodeToString(senderPublicKeys[sender]),
+		)
+	}
+
+	fmt.Fprintf(writer, "\nInactive members:     %v\n", resolution.InactiveMembers)
+	fmt.Fprintf(writer, "Disqualified members: %v\n", resolution.DisqualifiedMembers)
+}
+
+// initializeEvidenceStore opens the evidence store kept in the client's
+// storage.
+func initializeEvidenceStore() (*dkg.EvidenceStore, error) {
+	storage, err := storage.Initialize(
+		clientConfig.Storage,
+		clientConfig.Storage.Password,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize storage: [%w]", err)
+	}
+
+	beaconDataPersistence, err := storage.InitializeWorkPersistence("beacon")
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot initialize beacon data persistence: [%w]",
+			err,
+		)
+	}
+
+	return dkg.NewEvidenceStore(
+		logger,
+		beaconDataPersistence,
+		dkg.DefaultEvidenceRetentionPeriod,
+	), nil
+}
@@ -11,9 +11,12 @@ import (
 	"github.com/keep-network/keep-common/pkg/cmd/flag"
 	"github.com/keep-network/keep-common/pkg/rate"
 	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/beacon"
 	chainEthereum "github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/remotesigner"
 	"github.com/keep-network/keep-core/pkg/metrics"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
 	"github.com/keep-network/keep-core/pkg/tbtc"
 )
 
@@ -43,6 +46,8 @@ func initFlags(
 			initMetricsFlags(cmd, cfg)
 		case config.Diagnostics:
 			initDiagnosticsFlags(cmd, cfg)
+		case config.Beacon:
+			initBeaconFlags(cmd, cfg)
 		case config.Tbtc:
 			initTbtcFlags(cmd, cfg)
 		case config.Developer:
@@ -114,6 +119,20 @@ func initEthereumFlags(cmd *cobra.Command, cfg *config.Config) {
 		"The local filesystem path to Keep operator account keyfile.",
 	)
 
+	cmd.Flags().StringVar(
+		&cfg.RemoteSigner.URL,
+		"remoteSigner.url",
+		"",
+		"HTTP URL of the remote signer holding the Keep operator key. If set, the operator account keyfile is not used.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.RemoteSigner.Timeout,
+		"remoteSigner.timeout",
+		remotesigner.DefaultTimeout,
+		"The timeout of a single remote signer request.",
+	)
+
 	cmd.Flags().DurationVar(
 		&cfg.Ethereum.MiningCheckInterval,
 		"ethereum.miningCheckInterval",
@@ -129,6 +148,20 @@ func initEthereumFlags(cmd *cobra.Command, cfg *config.Config) {
 		"The maximum gas fee the client is willing to pay for the transaction to be mined. If reached, no resubmission attempts are performed.",
 	)
 
+	cmd.Flags().Uint64Var(
+		&cfg.Submission.GasBumpInterval,
+		"submission.gasBumpInterval",
+		chainEthereum.DefaultGasBumpInterval,
+		"The number of blocks after which a relay entry or DKG result transaction not yet mined is replaced with a transaction offering a higher gas price.",
+	)
+
+	cmd.Flags().Uint64Var(
+		&cfg.Submission.GasBumpPercent,
+		"submission.gasBumpPercent",
+		chainEthereum.DefaultGasBumpPercent,
+		"The percentage by which the gas price of a relay entry or DKG result transaction is increased with each replacement. Must be at least 10.",
+	)
+
 	cmd.Flags().IntVar(
 		&cfg.Ethereum.RequestsPerSecondLimit,
 		"ethereum.requestPerSecondLimit",
@@ -182,6 +215,76 @@ func initNetworkFlags(cmd *cobra.Command, cfg *config.Config) {
 		0,
 		"Specifies courtesy message dissemination time in seconds for topics the node is not subscribed to. Should be used only on selected bootstrap nodes. (0 = none)",
 	)
+
+	cmd.Flags().StringSliceVar(
+		&cfg.LibP2P.Relays,
+		"network.relays",
+		[]string{},
+		"Addresses of the circuit relays used when the Keep client is not publicly reachable, for example, when it is behind NAT.",
+	)
+
+	cmd.Flags().BoolVar(
+		&cfg.LibP2P.RelayService,
+		"network.relayService",
+		false,
+		"Enables acting as a circuit relay and AutoNAT service for other peers. The services are started only if the Keep client is publicly reachable.",
+	)
+
+	cmd.Flags().BoolVar(
+		&cfg.LibP2P.HolePunching,
+		"network.holePunching",
+		false,
+		"Enables establishing direct connections with peers behind NAT using hole punching.",
+	)
+
+	cmd.Flags().BoolVar(
+		&cfg.LibP2P.NATPortMap,
+		"network.natPortMap",
+		false,
+		"Enables opening the listening port in the NAT device using UPnP or NAT-PMP.",
+	)
+
+	cmd.Flags().Float64Var(
+		&cfg.LibP2P.MessageRateLimit,
+		"network.messageRateLimit",
+		libp2p.DefaultMessageRateLimit,
+		"Sustained number of messages per second a single peer can publish to a broadcast channel. Messages exceeding the limit are rejected. (negative = no limit)",
+	)
+
+	cmd.Flags().IntVar(
+		&cfg.LibP2P.MessageRateBurst,
+		"network.messageRateBurst",
+		libp2p.DefaultMessageRateBurst,
+		"Maximum number of messages a single peer can publish to a broadcast channel at once.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.LibP2P.ReputationHalfLife,
+		"network.reputationHalfLife",
+		reputation.DefaultHalfLife,
+		"Time after which the reputation score of a peer decays by half.",
+	)
+
+	cmd.Flags().Float64Var(
+		&cfg.LibP2P.ReputationBanThreshold,
+		"network.reputationBanThreshold",
+		reputation.DefaultBanThreshold,
+		"Negative reputation score at or below which a peer gets banned. Peers below a quarter of the threshold are excluded from gossip and peers below a half of the threshold do not receive messages published by the Keep client.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.LibP2P.ReputationBanDuration,
+		"network.reputationBanDuration",
+		reputation.DefaultBanDuration,
+		"Duration of a ban of a peer with a bad reputation.",
+	)
+
+	cmd.Flags().BoolVar(
+		&cfg.LibP2P.DisableMessageSignatures,
+		"network.disableMessageSignatures",
+		false,
+		"Disables operator signatures of published broadcast messages. With the remote signer, every signature delays the message by a round trip to the signer.",
+	)
 }
 
 // Initialize flags for Storage configuration.
@@ -192,6 +295,13 @@ func initStorageFlags(cmd *cobra.Command, cfg *config.Config) {
 		"",
 		"Location to store the Keep client key shares and other sensitive data.",
 	)
+
+	cmd.Flags().StringVar(
+		&cfg.Storage.PasswordFile,
+		"storage.passwordFile",
+		"",
+		"Path to a file holding the password used to encrypt the storage.",
+	)
 }
 
 // Initialize flags for Metrics configuration.
@@ -228,6 +338,16 @@ func initDiagnosticsFlags(cmd *cobra.Command, cfg *config.Config) {
 	)
 }
 
+// Initialize flags for Beacon configuration.
+func initBeaconFlags(cmd *cobra.Command, cfg *config.Config) {
+	cmd.Flags().Uint32Var(
+		&cfg.Beacon.ProtocolVersion,
+		"beacon.protocolVersion",
+		beacon.DefaultProtocolVersion,
+		"Version of the relay entry signing protocol. Version 2 sends compressed signature shares.",
+	)
+}
+
 func initTbtcFlags(cmd *cobra.Command, cfg *config.Config) {
 	cmd.Flags().IntVar(
 		&cfg.Tbtc.PreParamsPoolSize,
@@ -263,6 +383,34 @@ func initTbtcFlags(cmd *cobra.Command, cfg *config.Config) {
 		tbtc.DefaultKeyGenerationConcurrency,
 		"tECDSA key generation concurrency.",
 	)
+
+	cmd.Flags().UintVar(
+		&cfg.Tbtc.DKGMaxAttempts,
+		"tbtc.dkgMaxAttempts",
+		tbtc.DefaultDKGMaxAttempts,
+		"Maximum number of tECDSA DKG attempts. Zero means no limit.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Tbtc.DKGTimeout,
+		"tbtc.dkgTimeout",
+		tbtc.DefaultDKGTimeout,
+		"Timeout of the tECDSA DKG retry loop.",
+	)
+
+	cmd.Flags().UintVar(
+		&cfg.Tbtc.SigningMaxAttempts,
+		"tbtc.signingMaxAttempts",
+		tbtc.DefaultSigningMaxAttempts,
+		"Maximum number of tECDSA signing attempts. Zero means no limit.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Tbtc.SigningTimeout,
+		"tbtc.signingTimeout",
+		tbtc.DefaultSigningTimeout,
+		"Timeout of the tECDSA signing retry loop.",
+	)
 }
 
 // Initialize flags for Developer configuration.
@@ -44,6 +44,19 @@ var cmdFlagsTests = map[string]struct {
 		flagValue:     "/tmp/UTC--2018-03-11T01-37-33.202765887Z--c2a56884538778bacd91aa5bf343bf882c5fb18b",
 		defaultValue:  "",
 	},
+	"remoteSigner.url": {
+		readValueFunc: func(c *config.Config) interface{} { return c.RemoteSigner.URL },
+		flagName:      "--remoteSigner.url",
+		flagValue:     "http://127.0.0.1:9801",
+		defaultValue:  "",
+	},
+	"remoteSigner.timeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.RemoteSigner.Timeout },
+		flagName:              "--remoteSigner.timeout",
+		flagValue:             "25s",
+		expectedValueFromFlag: 25 * time.Second,
+		defaultValue:          10 * time.Second,
+	},
 	"ethereum.miningCheckInterval": {
 		readValueFunc:         func(c *config.Config) interface{} { return c.Ethereum.MiningCheckInterval },
 		flagName:              "--ethereum.miningCheckInterval",
@@ -51,6 +64,20 @@ var cmdFlagsTests = map[string]struct {
 		expectedValueFromFlag: 105 * time.Second,
 		defaultValue:          60 * time.Second,
 	},
+	"submission.gasBumpInterval": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Submission.GasBumpInterval },
+		flagName:              "--submission.gasBumpInterval",
+		flagValue:             "5",
+		expectedValueFromFlag: uint64(5),
+		defaultValue:          uint64(3),
+	},
+	"submission.gasBumpPercent": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Submission.GasBumpPercent },
+		flagName:              "--submission.gasBumpPercent",
+		flagValue:             "35",
+		expectedValueFromFlag: uint64(35),
+		defaultValue:          uint64(20),
+	},
 	"ethereum.requestPerSecondLimit": {
 		readValueFunc:         func(c *config.Config) interface{} { return c.Ethereum.RequestsPerSecondLimit },
 		flagName:              "--ethereum.requestPerSecondLimit",
@@ -113,6 +140,78 @@ var cmdFlagsTests = map[string]struct {
 		expectedValueFromFlag: 486,
 		defaultValue:          0,
 	},
+	"network.relays": {
+		readValueFunc: func(c *config.Config) interface{} { return c.LibP2P.Relays },
+		flagName:      "--network.relays",
+		flagValue:     `"/ip4/80.70.69.15/tcp/3919/ipfs/16Uiu2HAmVZGi9bgF3w6C4TFVo9HjbCDyuecsQbzQnXQJvP5wBjkd"`,
+		expectedValueFromFlag: []string{
+			"/ip4/80.70.69.15/tcp/3919/ipfs/16Uiu2HAmVZGi9bgF3w6C4TFVo9HjbCDyuecsQbzQnXQJvP5wBjkd",
+		},
+		defaultValue: []string{},
+	},
+	"network.relayService": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.RelayService },
+		flagName:              "--network.relayService",
+		flagValue:             "", // don't provide any value
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
+	"network.holePunching": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.HolePunching },
+		flagName:              "--network.holePunching",
+		flagValue:             "", // don't provide any value
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
+	"network.natPortMap": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.NATPortMap },
+		flagName:              "--network.natPortMap",
+		flagValue:             "", // don't provide any value
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
+	"network.messageRateLimit": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.MessageRateLimit },
+		flagName:              "--network.messageRateLimit",
+		flagValue:             "12.5",
+		expectedValueFromFlag: 12.5,
+		defaultValue:          float64(100),
+	},
+	"network.messageRateBurst": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.MessageRateBurst },
+		flagName:              "--network.messageRateBurst",
+		flagValue:             "250",
+		expectedValueFromFlag: 250,
+		defaultValue:          1000,
+	},
+	"network.reputationHalfLife": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.ReputationHalfLife },
+		flagName:              "--network.reputationHalfLife",
+		flagValue:             "30m",
+		expectedValueFromFlag: 30 * time.Minute,
+		defaultValue:          1 * time.Hour,
+	},
+	"network.reputationBanThreshold": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.ReputationBanThreshold },
+		flagName:              "--network.reputationBanThreshold",
+		flagValue:             "-60",
+		expectedValueFromFlag: float64(-60),
+		defaultValue:          float64(-100),
+	},
+	"network.reputationBanDuration": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.ReputationBanDuration },
+		flagName:              "--network.reputationBanDuration",
+		flagValue:             "3h",
+		expectedValueFromFlag: 3 * time.Hour,
+		defaultValue:          1 * time.Hour,
+	},
+	"network.disableMessageSignatures": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.DisableMessageSignatures },
+		flagName:              "--network.disableMessageSignatures",
+		flagValue:             "", // don't provide any value
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
 	"storage.dir": {
 		readValueFunc: func(c *config.Config) interface{} { return c.Storage.Dir },
 		flagName:      "--storage.dir",
@@ -182,6 +281,34 @@ var cmdFlagsTests = map[string]struct {
 		expectedValueFromFlag: 101,
 		defaultValue:          runtime.GOMAXPROCS(0),
 	},
+	"tbtc.dkgMaxAttempts": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.DKGMaxAttempts },
+		flagName:              "--tbtc.dkgMaxAttempts",
+		flagValue:             "10",
+		expectedValueFromFlag: uint(10),
+		defaultValue:          uint(0),
+	},
+	"tbtc.dkgTimeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.DKGTimeout },
+		flagName:              "--tbtc.dkgTimeout",
+		flagValue:             "48h",
+		expectedValueFromFlag: 48 * time.Hour,
+		defaultValue:          168 * time.Hour,
+	},
+	"tbtc.signingMaxAttempts": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.SigningMaxAttempts },
+		flagName:              "--tbtc.signingMaxAttempts",
+		flagValue:             "15",
+		expectedValueFromFlag: uint(15),
+		defaultValue:          uint(0),
+	},
+	"tbtc.signingTimeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.SigningTimeout },
+		flagName:              "--tbtc.signingTimeout",
+		flagValue:             "6h",
+		expectedValueFromFlag: 6 * time.Hour,
+		defaultValue:          24 * time.Hour,
+	},
 	"developer.randomBeaconAddress": {
 		readValueFunc: func(c *config.Config) interface{} {
 			address, _ := c.Ethereum.ContractAddress(chainEthereum.RandomBeaconContractName)
@@ -343,6 +470,10 @@ func initTestCommand() (*cobra.Command, *config.Config, *string) {
 		panic(err)
 	}
 
+	if err := os.Setenv(config.StoragePasswordEnvVariable, "storage password from env var"); err != nil {
+		panic(err)
+	}
+
 	var testConfigFilePath string
 	var testConfig = &config.Config{}
 
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/storage"
+)
+
+const (
+	// #nosec G101 (look for hardcoded credentials)
+	// This line doesn't contain any credentials.
+	// It's just the name of the environment variable.
+	storageOldPasswordEnvVariable = "KEEP_STORAGE_OLD_PASSWORD"
+)
+
+var (
+	// Path to a file holding the password the storage is currently encrypted
+	// with. The value is set with `--oldPasswordFile` command-line flag.
+	rekeyOldPasswordFile string
+	// Determines if the storage is encrypted with the legacy scheme, using
+	// the Ethereum key file password. The value is set with `--legacy`
+	// command-line flag.
+	rekeyLegacy bool
+)
+
+// KeystoreCommand contains the definition of the keystore command-line
+// subcommand and its own subcommands.
+var KeystoreCommand = &cobra.Command{
+	Use:   "keystore",
+	Short: "Manages the client's storage",
+	Long:  "Manages the client's storage holding key shares and other protected data",
+}
+
+// RekeyCommand contains the definition of the keystore rekey command-line
+// subcommand.
+var RekeyCommand = &cobra.Command{
+	Use:   "rekey",
+	Short: "Re-encrypts the storage under a new password",
+	Long:  rekeyDescription,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if err := clientConfig.ReadConfig(
+			configFilePath,
+			cmd.Flags(),
+			config.General,
+			config.Storage,
+		); err != nil {
+			logger.Fatalf("error reading config: %v", err)
+		}
+	},
+	RunE: rekey,
+}
+
+const rekeyDescription = `The rekey command re-encrypts all the data kept in the
+   client's storage under a new password. The new password is resolved the same
+   way as for the start command. The current password is read from the
+   ` + storageOldPasswordEnvVariable + ` environment variable, from the file
+   pointed by --oldPasswordFile flag, or from the prompt. Use --legacy flag to
+   migrate a storage encrypted with the Ethereum key file password by the
+   client versions preceding the separate storage password.
+   The client must not be running while the storage is rekeyed.`
+
+func init() {
+	initFlags(
+		RekeyCommand,
+		&configFilePath,
+		clientConfig,
+		config.General,
+		config.Storage,
+	)
+
+	RekeyCommand.Flags().StringVar(
+		&rekeyOldPasswordFile,
+		"oldPasswordFile",
+		"",
+		"Path to a file holding the password the storage is currently encrypted with.",
+	)
+
+	RekeyCommand.Flags().BoolVar(
+		&rekeyLegacy,
+		"legacy",
+		false,
+		"Migrate a storage encrypted with the Ethereum key file password.",
+	)
+
+	KeystoreCommand.AddCommand(RekeyCommand)
+}
+
+// rekey re-encrypts the storage under the configured storage password.
+func rekey(cmd *cobra.Command, args []string) error {
+	oldPassword, err := readOldStoragePassword()
+	if err != nil {
+		return err
+	}
+
+	if err := storage.Rekey(
+		clientConfig.Storage,
+		oldPassword,
+		clientConfig.Storage.Password,
+	); err != nil {
+		return fmt.Errorf("cannot rekey storage: [%w]", err)
+	}
+
+	logger.Infof("storage [%s] rekeyed successfully", clientConfig.Storage.Dir)
+
+	return nil
+}
+
+// readOldStoragePassword resolves the password the storage is currently
+// encrypted with.
+func readOldStoragePassword() (string, error) {
+	if rekeyLegacy {
+		return clientConfig.Ethereum.Account.KeyFilePassword, nil
+	}
+
+	if rekeyOldPasswordFile != "" {
+		return config.ReadPasswordFile(rekeyOldPasswordFile)
+	}
+
+	if password := os.Getenv(storageOldPasswordEnvVariable); strings.TrimSpace(password) != "" {
+		return password, nil
+	}
+
+	return config.ReadPasswordPrompt("Enter Current Storage Password: ")
+}
@@ -6,18 +6,26 @@ import (
 
 	"github.com/spf13/cobra"
 
+	commonEthereum "github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/build"
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/pkg/beacon"
+	"github.com/keep-network/keep-core/pkg/beacon/auditor"
+	"github.com/keep-network/keep-core/pkg/beacon/dkg"
+	beaconregistry "github.com/keep-network/keep-core/pkg/beacon/registry"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/remotesigner"
 	"github.com/keep-network/keep-core/pkg/diagnostics"
 	"github.com/keep-network/keep-core/pkg/firewall"
 	"github.com/keep-network/keep-core/pkg/generator"
 	"github.com/keep-network/keep-core/pkg/metrics"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/diagnosis"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
+	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-core/pkg/storage"
 	"github.com/keep-network/keep-core/pkg/tbtc"
 )
@@ -46,10 +54,12 @@ func init() {
 		fmt.Sprintf(`%s
 Environment variables:
     %s    Password for Keep operator account keyfile decryption.
+    %s     Password for the client's storage encryption.
     %s                 Space-delimited set of log level directives; set to "help" for help.
 `,
 			StartCommand.UsageString(),
 			config.EthereumPasswordEnvVariable,
+			config.StoragePasswordEnvVariable,
 			config.LogLevelEnvVariable,
 		),
 	)
@@ -64,80 +74,192 @@ func start(cmd *cobra.Command) error {
 		clientConfig.Ethereum.Network,
 	)
 
-	beaconChain, tbtcChain, blockCounter, signing, operatorPrivateKey, err :=
-		ethereum.Connect(ctx, clientConfig.Ethereum)
+	storage, err := storage.Initialize(
+		clientConfig.Storage,
+		clientConfig.Storage.Password,
+	)
 	if err != nil {
-		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+		return fmt.Errorf("cannot initialize storage: [%w]", err)
 	}
 
-	firewall := firewall.AnyApplicationPolicy(
-		[]firewall.Application{beaconChain, tbtcChain},
-	)
+	networkPersistence, err := storage.InitializeWorkPersistence("network")
+	if err != nil {
+		return fmt.Errorf("cannot initialize network data persistence: [%w]", err)
+	}
 
-	netProvider, err := libp2p.Connect(
-		ctx,
-		clientConfig.LibP2P,
-		operatorPrivateKey,
-		firewall,
-		retransmission.NewTicker(blockCounter.WatchBlocks(ctx)),
-	)
+	operators, blockCounter, err := connect(ctx, networkPersistence)
 	if err != nil {
-		return fmt.Errorf("failed while creating the network provider: [%v]", err)
+		return err
 	}
 
+	// The first operator is the one configured in the Ethereum section. It
+	// is used for the client-wide diagnostics and metrics.
+	primary := operators[0]
+
 	nodeHeader(
-		netProvider.ConnectionManager().AddrStrings(),
-		beaconChain.Signing().Address().String(),
+		primary.netProvider.ConnectionManager().AddrStrings(),
+		primary.signing.Address().String(),
 		clientConfig.LibP2P.Port,
 		clientConfig.Ethereum,
 	)
 
-	storage, err := storage.Initialize(
-		clientConfig.Storage,
-		clientConfig.Ethereum.KeyFilePassword,
-	)
-	if err != nil {
-		return fmt.Errorf("cannot initialize storage: [%w]", err)
+	for _, operator := range operators[1:] {
+		logger.Infof(
+			"running additional operator [%s] with network addresses [%v]",
+			operator.signing.Address().String(),
+			operator.netProvider.ConnectionManager().AddrStrings(),
+		)
 	}
 
-	beaconKeyStorePersistence, err := storage.InitializeKeyStorePersistence("beacon")
+	tbtcDataPersistence, err := storage.InitializeWorkPersistence("tbtc")
 	if err != nil {
-		return fmt.Errorf("cannot initialize beacon keystore persistence: [%w]", err)
+		return fmt.Errorf("cannot initialize tbtc data persistence: [%w]", err)
 	}
 
-	tbtcKeyStorePersistence, err := storage.InitializeKeyStorePersistence("tbtc")
+	beaconDataPersistence, err := storage.InitializeWorkPersistence("beacon")
 	if err != nil {
-		return fmt.Errorf("cannot initialize tbtc keystore persistence: [%w]", err)
+		return fmt.Errorf("cannot initialize beacon data persistence: [%w]", err)
 	}
 
-	tbtcDataPersistence, err := storage.InitializeWorkPersistence("tbtc")
-	if err != nil {
-		return fmt.Errorf("cannot initialize tbtc data persistence: [%w]", err)
-	}
+	dkgEvidenceStore := dkg.NewEvidenceStore(
+		logger,
+		beaconDataPersistence,
+		dkg.DefaultEvidenceRetentionPeriod,
+	)
 
 	scheduler := generator.StartScheduler()
 
-	err = beacon.Initialize(
-		ctx,
-		beaconChain,
-		netProvider,
-		beaconKeyStorePersistence,
-		scheduler,
-	)
-	if err != nil {
-		return fmt.Errorf("error initializing beacon: [%v]", err)
+	tbtcOperators := make([]*tbtc.Operator, len(operators))
+	groupLifecycles := make([]*beaconregistry.LifecycleManager, len(operators))
+	for i, operator := range operators {
+		operatorStorage := storage
+		if i > 0 {
+			// Additional operators keep their key shares in separate
+			// namespaces. The primary operator uses the top-level key store
+			// to stay compatible with the single-operator setup.
+			operatorStorage, err = storage.ForOperator(
+				operator.signing.Address().String(),
+			)
+			if err != nil {
+				return fmt.Errorf(
+					"cannot initialize storage for operator [%s]: [%w]",
+					operator.signing.Address().String(),
+					err,
+				)
+			}
+		}
+
+		beaconKeyStorePersistence, err := operatorStorage.InitializeKeyStorePersistence("beacon")
+		if err != nil {
+			return fmt.Errorf("cannot initialize beacon keystore persistence: [%w]", err)
+		}
+
+		tbtcKeyStorePersistence, err := operatorStorage.InitializeKeyStorePersistence("tbtc")
+		if err != nil {
+			return fmt.Errorf("cannot initialize tbtc keystore persistence: [%w]", err)
+		}
+
+		if err := operator.beaconChain.ConfigureSubmissions(
+			clientConfig.Submission,
+		); err != nil {
+			return fmt.Errorf("invalid submission config: [%w]", err)
+		}
+
+		groupLifecycles[i], err = beacon.Initialize(
+			ctx,
+			operator.beaconChain,
+			operator.netProvider,
+			beaconKeyStorePersistence,
+			dkgEvidenceStore,
+			scheduler,
+			clientConfig.Beacon,
+		)
+		if err != nil {
+			return fmt.Errorf("error initializing beacon: [%v]", err)
+		}
+
+		tbtcOperators[i] = &tbtc.Operator{
+			Chain:               operator.tbtcChain,
+			NetProvider:         operator.netProvider,
+			KeyStorePersistence: tbtcKeyStorePersistence,
+		}
+	}
+
+	if err := diagnosis.ServeProbes(ctx, primary.netProvider); err != nil {
+		return fmt.Errorf("error serving network probes: [%v]", err)
 	}
 
-	initializeMetrics(ctx, clientConfig, netProvider, blockCounter)
+	initializeMetrics(ctx, clientConfig, primary.netProvider, blockCounter)
 	registry := initializeDiagnostics(clientConfig)
-	registry.RegisterConnectedPeersSource(netProvider, signing)
-	registry.RegisterClientInfoSource(netProvider, signing, build.Version, build.Revision)
+	registry.RegisterConnectedPeersSource(primary.netProvider, primary.signing)
+	registry.RegisterPeersReputationSource(primary.netProvider, primary.signing)
+	registry.RegisterBroadcastTrafficSource(primary.netProvider)
+	registry.RegisterDroppedMessagesSource(primary.netProvider)
+	registry.RegisterReachabilitySource(primary.netProvider)
+	registry.RegisterNetworkDiagnosisHandler(
+		primary.netProvider,
+		diagnosis.Options{
+			Peers:     clientConfig.LibP2P.Peers,
+			Addresses: clientConfig.LibP2P.AnnouncedAddresses,
+			Firewall:  primary.firewall,
+		},
+	)
+	registry.RegisterClientInfoSource(
+		primary.netProvider,
+		primary.signing,
+		build.Version,
+		build.Revision,
+	)
+
+	// The relay entry history is kept by the client regardless of the
+	// diagnostics configuration but it is queryable only if diagnostics are
+	// enabled.
+	entryStore := auditor.NewEntryStore(beaconDataPersistence)
+	entryAuditor := auditor.NewAuditor(
+		primary.beaconChain,
+		auditor.DefaultHistorySize,
+	)
+	entryAuditor.OnEntryAudited(func(entry *auditor.AuditedEntry) {
+		if err := entryStore.Append(entry); err != nil {
+			logger.Errorf(
+				"could not store relay entry [0x%x]: [%v]",
+				entry.Entry,
+				err,
+			)
+		}
+	})
+	entryAuditor.Start(ctx)
+	if registry != nil {
+		registry.RegisterHandler(auditor.HistoryPath, entryStore)
+		registry.RegisterApplicationSource(
+			"beacon",
+			func() map[string]interface{} {
+				activeGroupsCount, archivedGroupsCount := 0, uint64(0)
+				for _, groupLifecycle := range groupLifecycles {
+					activeGroupsCount += groupLifecycle.ActiveGroupsCount()
+					archivedGroupsCount += groupLifecycle.ArchivedGroupsCount()
+				}
 
-	err = tbtc.Initialize(
+				// Relay entry and DKG result submissions are kept per
+				// operator as every operator has its own account.
+				submissions := make(map[string][]ethereum.SubmissionRecord)
+				for _, operator := range operators {
+					submissions[operator.signing.Address().String()] =
+						operator.beaconChain.Submissions()
+				}
+
+				return map[string]interface{}{
+					"activeGroupsCount":   activeGroupsCount,
+					"archivedGroupsCount": archivedGroupsCount,
+					"submissions":         submissions,
+				}
+			},
+		)
+	}
+
+	err = tbtc.InitializeOperators(
 		ctx,
-		tbtcChain,
-		netProvider,
-		tbtcKeyStorePersistence,
+		tbtcOperators,
 		tbtcDataPersistence,
 		scheduler,
 		clientConfig.Tbtc,
@@ -157,6 +279,167 @@ func start(cmd *cobra.Command) error {
 	}
 }
 
+// operatorHandle groups the chain and network handles of a single operator
+// run by the client.
+type operatorHandle struct {
+	beaconChain *ethereum.BeaconChain
+	tbtcChain   *ethereum.TbtcChain
+	signing     chain.Signing
+	firewall    net.Firewall
+	netProvider net.Provider
+}
+
+// connect connects to the Ethereum node and the libp2p network. If the remote
+// signer is configured, the operator key is held by the remote signer.
+// Otherwise, the keys of all the configured operators are read from their
+// key files. All the operators share the Ethereum client and the block
+// counter but every operator has its own libp2p host. Known peers of all the
+// operators are kept in the provided network persistence.
+func connect(
+	ctx context.Context,
+	networkPersistence persistence.BasicHandle,
+) (
+	[]*operatorHandle,
+	chain.BlockCounter,
+	error,
+) {
+	if clientConfig.RemoteSigner.IsEnabled() {
+		return connectWithRemoteSigner(ctx, networkPersistence)
+	}
+
+	accounts := []commonEthereum.Account{clientConfig.Ethereum.Account}
+	networkConfigs := []libp2p.Config{clientConfig.LibP2P}
+
+	for _, operator := range clientConfig.AdditionalOperators {
+		accounts = append(accounts, commonEthereum.Account{
+			KeyFile:         operator.KeyFile,
+			KeyFilePassword: operator.KeyFilePassword,
+		})
+
+		networkConfig := clientConfig.LibP2P
+		networkConfig.Port = operator.Port
+		networkConfig.AnnouncedAddresses = operator.AnnouncedAddresses
+		networkConfigs = append(networkConfigs, networkConfig)
+	}
+
+	operatorChains, blockCounter, err := ethereum.ConnectOperators(
+		ctx,
+		clientConfig.Ethereum,
+		accounts,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error connecting to Ethereum node: [%v]",
+			err,
+		)
+	}
+
+	operators := make([]*operatorHandle, len(operatorChains))
+	for i, operatorChain := range operatorChains {
+		firewall := firewall.AnyApplicationPolicy(
+			[]firewall.Application{
+				operatorChain.BeaconChain,
+				operatorChain.TbtcChain,
+			},
+		)
+
+		netProvider, err := libp2p.Connect(
+			ctx,
+			networkConfigs[i],
+			operatorChain.PrivateKey,
+			firewall,
+			retransmission.NewTicker(blockCounter.WatchBlocks(ctx)),
+			libp2p.WithPeerPersistence(networkPersistence),
+			withOperatorPeerLookup(operatorChain.Signing),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"failed while creating the network provider: [%v]",
+				err,
+			)
+		}
+
+		operators[i] = &operatorHandle{
+			beaconChain: operatorChain.BeaconChain,
+			tbtcChain:   operatorChain.TbtcChain,
+			signing:     operatorChain.Signing,
+			firewall:    firewall,
+			netProvider: netProvider,
+		}
+	}
+
+	return operators, blockCounter, nil
+}
+
+func connectWithRemoteSigner(
+	ctx context.Context,
+	networkPersistence persistence.BasicHandle,
+) (
+	[]*operatorHandle,
+	chain.BlockCounter,
+	error,
+) {
+	signerClient, err := remotesigner.Dial(ctx, clientConfig.RemoteSigner)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error connecting to remote signer: [%v]",
+			err,
+		)
+	}
+
+	beaconChain, tbtcChain, blockCounter, signing, err :=
+		ethereum.ConnectWithRemoteSigner(ctx, clientConfig.Ethereum, signerClient)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error connecting to Ethereum node: [%v]",
+			err,
+		)
+	}
+
+	firewall := firewall.AnyApplicationPolicy(
+		[]firewall.Application{beaconChain, tbtcChain},
+	)
+
+	netProvider, err := libp2p.ConnectWithIdentitySigner(
+		ctx,
+		clientConfig.LibP2P,
+		signerClient.NetworkSigner(),
+		firewall,
+		retransmission.NewTicker(blockCounter.WatchBlocks(ctx)),
+		libp2p.WithPeerPersistence(networkPersistence),
+		withOperatorPeerLookup(signing),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed while creating the network provider: [%v]",
+			err,
+		)
+	}
+
+	return []*operatorHandle{{
+		beaconChain: beaconChain,
+		tbtcChain:   tbtcChain,
+		signing:     signing,
+		firewall:    firewall,
+		netProvider: netProvider,
+	}}, blockCounter, nil
+}
+
+// withOperatorPeerLookup enables resolving chain addresses of operators into
+// peers, with addresses derived by the given chain signing.
+func withOperatorPeerLookup(signing chain.Signing) libp2p.ConnectOption {
+	return libp2p.WithOperatorPeerLookup(
+		func(publicKey *operator.PublicKey) (string, error) {
+			address, err := signing.PublicKeyToAddress(publicKey)
+			if err != nil {
+				return "", err
+			}
+
+			return address.String(), nil
+		},
+	)
+}
+
 func initializeMetrics(
 	ctx context.Context,
 	config *config.Config,
@@ -191,6 +474,20 @@ func initializeMetrics(
 		config.Metrics.NetworkMetricsTick,
 	)
 
+	metrics.ObserveBroadcastTraffic(
+		ctx,
+		registry,
+		netProvider,
+		config.Metrics.NetworkMetricsTick,
+	)
+
+	metrics.ObserveDroppedMessages(
+		ctx,
+		registry,
+		netProvider,
+		config.Metrics.NetworkMetricsTick,
+	)
+
 	metrics.ObserveEthConnectivity(
 		ctx,
 		registry,
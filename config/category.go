@@ -9,6 +9,7 @@ const (
 	Storage
 	Metrics
 	Diagnostics
+	Beacon
 	Tbtc
 	Developer
 )
@@ -20,6 +21,7 @@ var AllCategories = []Category{
 	Storage,
 	Metrics,
 	Diagnostics,
+	Beacon,
 	Tbtc,
 	Developer,
 }
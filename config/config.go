@@ -12,8 +12,12 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"golang.org/x/crypto/ssh/terminal"
+	"golang.org/x/exp/slices"
 
 	commonEthereum "github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/beacon"
+	chainEthereum "github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/remotesigner"
 	"github.com/keep-network/keep-core/pkg/diagnostics"
 	"github.com/keep-network/keep-core/pkg/metrics"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
@@ -29,18 +33,46 @@ const (
 	// It's just the name of the environment variable.
 	EthereumPasswordEnvVariable = "KEEP_ETHEREUM_PASSWORD"
 
+	// #nosec G101 (look for hardcoded credentials)
+	// This line doesn't contain any credentials.
+	// It's just the name of the environment variable.
+	StoragePasswordEnvVariable = "KEEP_STORAGE_PASSWORD"
+
 	// LogLevelEnvVariable can be used to define logging configuration.
 	LogLevelEnvVariable = "LOG_LEVEL"
 )
 
 // Config is the top level config structure.
 type Config struct {
-	Ethereum    commonEthereum.Config
-	LibP2P      libp2p.Config `mapstructure:"network"`
-	Storage     storage.Config
-	Metrics     metrics.Config
-	Diagnostics diagnostics.Config
-	Tbtc        tbtc.Config
+	Ethereum     commonEthereum.Config
+	RemoteSigner remotesigner.Config
+	// Submission holds the gas price strategy of time-critical transactions,
+	// i.e. relay entries and DKG results.
+	Submission chainEthereum.SubmissionConfig
+	// AdditionalOperators holds operators run by the client along with the
+	// operator configured in the Ethereum section.
+	AdditionalOperators []Operator
+	LibP2P              libp2p.Config `mapstructure:"network"`
+	Storage             storage.Config
+	Metrics             metrics.Config
+	Diagnostics         diagnostics.Config
+	Beacon              beacon.Config
+	Tbtc                tbtc.Config
+}
+
+// Operator holds the configuration of an additional operator run by the
+// client. All the operators share the Ethereum client and the storage but
+// every operator has its own libp2p host.
+type Operator struct {
+	// Path to the operator account keyfile.
+	KeyFile string
+	// Password used to unlock the operator account keyfile. If not set, the
+	// password of the operator configured in the Ethereum section is used.
+	KeyFilePassword string
+	// Port on which the operator's libp2p host listens.
+	Port int
+	// Addresses announced by the operator's libp2p host.
+	AnnouncedAddresses []string
 }
 
 // Bind the flags to the viper configuration. Viper reads configuration from
@@ -124,6 +156,35 @@ func (c *Config) ReadConfig(configFilePath string, flagSet *pflag.FlagSet, categ
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	// The key file password is not needed if the operator key is held by
+	// the remote signer or if no key file is configured, for example, when
+	// the client only follows the chain.
+	if !c.RemoteSigner.IsEnabled() && c.Ethereum.Account.KeyFile != "" {
+		if err := c.resolveEthereumPassword(); err != nil {
+			return err
+		}
+
+		for i := range c.AdditionalOperators {
+			if c.AdditionalOperators[i].KeyFilePassword == "" {
+				c.AdditionalOperators[i].KeyFilePassword =
+					c.Ethereum.Account.KeyFilePassword
+			}
+		}
+	}
+
+	if slices.Contains(categories, Storage) {
+		if err := c.resolveStoragePassword(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveEthereumPassword resolves the password used to decrypt the Ethereum
+// key file. The password is taken from the config file, from the environment
+// variable, or read from the prompt, in that order.
+func (c *Config) resolveEthereumPassword() error {
 	// Don't use viper.BindEnv for password reading as it's too sensitive value
 	// to read it with an external library.
 	if c.Ethereum.Account.KeyFilePassword == "" {
@@ -131,20 +192,15 @@ func (c *Config) ReadConfig(configFilePath string, flagSet *pflag.FlagSet, categ
 	}
 
 	if strings.TrimSpace(c.Ethereum.Account.KeyFilePassword) == "" {
-		var (
-			password string
-			err      error
-		)
 		fmt.Printf(
 			"Ethereum Account Password has to be set for the configured Ethereum Key File.\n"+
 				"Please set %s environment variable, or set it in the config file, or provide it in the prompt below.\n",
 			EthereumPasswordEnvVariable,
 		)
 
-		for strings.TrimSpace(password) == "" {
-			if password, err = readPassword("Enter Ethereum Account Password: "); err != nil {
-				return err
-			}
+		password, err := ReadPasswordPrompt("Enter Ethereum Account Password: ")
+		if err != nil {
+			return err
 		}
 
 		c.Ethereum.Account.KeyFilePassword = password
@@ -153,6 +209,43 @@ func (c *Config) ReadConfig(configFilePath string, flagSet *pflag.FlagSet, categ
 	return nil
 }
 
+// resolveStoragePassword resolves the password used to encrypt the storage.
+// The password is taken from the config file, from the password file, from
+// the environment variable, or read from the prompt, in that order.
+func (c *Config) resolveStoragePassword() error {
+	if c.Storage.Password == "" && c.Storage.PasswordFile != "" {
+		password, err := ReadPasswordFile(c.Storage.PasswordFile)
+		if err != nil {
+			return fmt.Errorf("cannot read storage password file: [%w]", err)
+		}
+
+		c.Storage.Password = password
+	}
+
+	// Don't use viper.BindEnv for password reading as it's too sensitive value
+	// to read it with an external library.
+	if c.Storage.Password == "" {
+		c.Storage.Password = os.Getenv(StoragePasswordEnvVariable)
+	}
+
+	if strings.TrimSpace(c.Storage.Password) == "" {
+		fmt.Printf(
+			"Storage Password has to be set to encrypt the client's storage.\n"+
+				"Please set %s environment variable, or set it in the config file, or provide it in the prompt below.\n",
+			StoragePasswordEnvVariable,
+		)
+
+		password, err := ReadPasswordPrompt("Enter Storage Password: ")
+		if err != nil {
+			return err
+		}
+
+		c.Storage.Password = password
+	}
+
+	return nil
+}
+
 func validateConfig(config *Config, categories ...Category) error {
 	var result *multierror.Error
 
@@ -165,9 +258,27 @@ func validateConfig(config *Config, categories ...Category) error {
 				))
 			}
 
-			if config.Ethereum.Account.KeyFile == "" {
+			if config.Ethereum.Account.KeyFile == "" &&
+				!config.RemoteSigner.IsEnabled() {
 				result = multierror.Append(result, fmt.Errorf(
-					"missing value for ethereum.keyFile; see ethereum section in configuration",
+					"missing value for ethereum.keyFile or remoteSigner.url; see ethereum and remoteSigner sections in configuration",
+				))
+			}
+
+			for i, operator := range config.AdditionalOperators {
+				if operator.KeyFile == "" {
+					result = multierror.Append(result, fmt.Errorf(
+						"missing value for keyFile of additional operator [%d]; "+
+							"see additionalOperators section in configuration",
+						i,
+					))
+				}
+			}
+
+			if len(config.AdditionalOperators) > 0 &&
+				config.RemoteSigner.IsEnabled() {
+				result = multierror.Append(result, fmt.Errorf(
+					"additional operators are not supported with the remote signer",
 				))
 			}
 		case Network:
@@ -176,6 +287,19 @@ func validateConfig(config *Config, categories ...Category) error {
 					"missing value for network.port; see network section in configuration",
 				))
 			}
+
+			ports := map[int]bool{config.LibP2P.Port: true}
+			for i, operator := range config.AdditionalOperators {
+				if operator.Port == 0 || ports[operator.Port] {
+					result = multierror.Append(result, fmt.Errorf(
+						"missing or duplicated value for port of additional "+
+							"operator [%d]; see additionalOperators section "+
+							"in configuration",
+						i,
+					))
+				}
+				ports[operator.Port] = true
+			}
 		case Storage:
 			if config.Storage.Dir == "" {
 				result = multierror.Append(result, fmt.Errorf(
@@ -225,6 +349,41 @@ func unmarshalConfig(config *Config) error {
 	return nil
 }
 
+// ReadPasswordPrompt prompts a user to enter a password until a non-empty
+// password is provided.
+func ReadPasswordPrompt(prompt string) (string, error) {
+	var (
+		password string
+		err      error
+	)
+
+	for strings.TrimSpace(password) == "" {
+		if password, err = readPassword(prompt); err != nil {
+			return "", err
+		}
+	}
+
+	return password, nil
+}
+
+// ReadPasswordFile reads a password from the file under the given path.
+// Leading and trailing white space is trimmed.
+func ReadPasswordFile(path string) (string, error) {
+	// #nosec G304 (file path provided as taint input)
+	// This line opens a file configured by the operator.
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	password := strings.TrimSpace(string(content))
+	if password == "" {
+		return "", fmt.Errorf("password file [%s] is empty", path)
+	}
+
+	return password, nil
+}
+
 // readPassword prompts a user to enter a password. The read password uses
 // the system password reading call that helps to prevent key loggers from
 // capturing the password.
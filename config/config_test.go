@@ -122,10 +122,63 @@ func TestReadConfigFromFile(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.LibP2P.DisseminationTime },
 			expectedValue: 76,
 		},
+		"Network.Relays": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.Relays },
+			expectedValue: []string{
+				"/ip4/80.70.60.51/tcp/3919/ipfs/16Uiu2HAmFRJtCWfdXhZEZHWb4tUpH1QMMgzH1oiamCfUuK6NgqWX",
+			},
+		},
+		"Network.RelayService": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.RelayService },
+			expectedValue: true,
+		},
+		"Network.HolePunching": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.HolePunching },
+			expectedValue: true,
+		},
+		"Network.NATPortMap": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.NATPortMap },
+			expectedValue: true,
+		},
+		"Network.MessageRateLimit": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.MessageRateLimit },
+			expectedValue: 12.5,
+		},
+		"Network.MessageRateBurst": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.MessageRateBurst },
+			expectedValue: 250,
+		},
+		"Network.ReputationHalfLife": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.ReputationHalfLife },
+			expectedValue: 30 * time.Minute,
+		},
+		"Network.ReputationBanThreshold": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.ReputationBanThreshold },
+			expectedValue: float64(-60),
+		},
+		"Network.ReputationBanDuration": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.ReputationBanDuration },
+			expectedValue: 3 * time.Hour,
+		},
+		"Network.ReputationEventWeights": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.ReputationEventWeights },
+			expectedValue: map[string]float64{
+				"invalid_message": -20,
+				"sender_spoofing": -80,
+			},
+		},
+		"Network.DisableMessageSignatures": {
+			readValueFunc: func(c *Config) interface{} { return c.LibP2P.DisableMessageSignatures },
+			expectedValue: true,
+		},
 		"Storage.Dir": {
 			readValueFunc: func(c *Config) interface{} { return c.Storage.Dir },
 			expectedValue: "/my/secure/location",
 		},
+		"Storage.Password": {
+			readValueFunc: func(c *Config) interface{} { return c.Storage.Password },
+			expectedValue: "THIS IS TEST! Storage password should be defined in env variable or prompt",
+		},
 		"Metrics.Port": {
 			readValueFunc: func(c *Config) interface{} { return c.Metrics.Port },
 			expectedValue: 3498,
@@ -194,6 +247,10 @@ func TestReadConfig_ReadPassword(t *testing.T) {
 		},
 	}
 
+	if err := os.Setenv(StoragePasswordEnvVariable, "storage password from env var"); err != nil {
+		t.Fatal(err)
+	}
+
 	for testName, test := range configReadTests {
 		t.Run(testName, func(t *testing.T) {
 			if err := os.Setenv(EthereumPasswordEnvVariable, test.envVariablePassword); err != nil {
@@ -239,11 +296,75 @@ func TestReadConfig_ReadPassword(t *testing.T) {
 	}
 }
 
+func TestReadConfig_ReadStoragePassword(t *testing.T) {
+	if err := os.Setenv(EthereumPasswordEnvVariable, "password from env var"); err != nil {
+		t.Fatal(err)
+	}
+
+	passwordFile := filepath.Join(t.TempDir(), "storage-password")
+	err := os.WriteFile(passwordFile, []byte("password from file\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var configReadTests = map[string]struct {
+		configFilePath      string
+		passwordFile        string
+		envVariablePassword string
+		expectedPassword    string
+	}{
+		"password in config file": {
+			configFilePath:      "../test/config.toml",
+			passwordFile:        passwordFile,
+			envVariablePassword: "password from env var",
+			expectedPassword:    "THIS IS TEST! Storage password should be defined in env variable or prompt",
+		},
+		"password in password file": {
+			configFilePath:      "../test/config_no_password.toml",
+			passwordFile:        passwordFile,
+			envVariablePassword: "password from env var",
+			expectedPassword:    "password from file",
+		},
+		"password in environment variable": {
+			configFilePath:      "../test/config_no_password.toml",
+			envVariablePassword: "password from env var",
+			expectedPassword:    "password from env var",
+		},
+	}
+
+	for testName, test := range configReadTests {
+		t.Run(testName, func(t *testing.T) {
+			if err := os.Setenv(StoragePasswordEnvVariable, test.envVariablePassword); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := &Config{}
+			cfg.Storage.PasswordFile = test.passwordFile
+
+			if err := cfg.ReadConfig(test.configFilePath, nil, AllCategories...); err != nil {
+				t.Fatalf("failed to read test config: [%v]", err)
+			}
+
+			if cfg.Storage.Password != test.expectedPassword {
+				t.Errorf(
+					"\nexpected: %s\nactual:   %s",
+					test.expectedPassword,
+					cfg.Storage.Password,
+				)
+			}
+		})
+	}
+}
+
 func TestReadConfig_ReadContracts(t *testing.T) {
 	if err := os.Setenv(EthereumPasswordEnvVariable, "password from env var"); err != nil {
 		t.Fatal(err)
 	}
 
+	if err := os.Setenv(StoragePasswordEnvVariable, "storage password from env var"); err != nil {
+		t.Fatal(err)
+	}
+
 	ethereumBeacon.RandomBeaconAddress = "0xd1640b381327c2d5425d6d3d605539a3db72f857"
 	ethereumEcdsa.WalletRegistryAddress = "0xdb3dd6d4f43d39c996d0afeb6fbabc284f9ffb1a"
 	ethereumThreshold.TokenStakingAddress = "0xaa7b41039ea8f9ec2d89bbe96e19f97b6c267a27"
@@ -313,3 +434,39 @@ func TestReadConfig_ReadContracts(t *testing.T) {
 		})
 	}
 }
+
+func TestReadConfig_AdditionalOperators(t *testing.T) {
+	if err := os.Setenv(EthereumPasswordEnvVariable, "password from env var"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Setenv(StoragePasswordEnvVariable, "storage password from env var"); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{}
+	if err := cfg.ReadConfig("../test/config_operators.toml", nil, AllCategories...); err != nil {
+		t.Fatalf("failed to read test config: [%v]", err)
+	}
+
+	expected := []Operator{
+		{
+			KeyFile:         "/tmp/UTC--2018-03-11T01-37-33.202765887Z--6299496199d99941193fdd2d717ef585f431ea05",
+			KeyFilePassword: "password from env var",
+			Port:            27002,
+		},
+		{
+			KeyFile:            "/tmp/UTC--2018-03-11T01-37-33.202765887Z--1f9090aae28b8a3dceadf281b0f12828e676c326",
+			KeyFilePassword:    "THIS IS TEST! Password of the additional operator",
+			Port:               27003,
+			AnnouncedAddresses: []string{"/dns4/example.com/tcp/27003"},
+		},
+	}
+
+	if !reflect.DeepEqual(expected, cfg.AdditionalOperators) {
+		t.Errorf(
+			"unexpected additional operators\nexpected: %+v\nactual:   %+v",
+			expected,
+			cfg.AdditionalOperators,
+		)
+	}
+}
@@ -39,6 +39,26 @@ func TestDecompressG1(t *testing.T) {
 	}
 }
 
+func TestDecompressG2(t *testing.T) {
+	errorSeen := false
+	for i := 0; i < 100; i++ {
+		buffer := make([]byte, 64)
+		_, err := rand.Read(buffer)
+		if err == nil {
+			// Random bytes which are not a square must not make the
+			// decompression hang.
+			_, err2 := DecompressToG2(buffer)
+
+			if err2 != nil {
+				errorSeen = true
+			}
+		}
+	}
+	if !errorSeen {
+		t.Errorf("No errors seen decompressing random points on G2. Highly unlikely")
+	}
+}
+
 func TestCompressDecompressGivesSameG1Point(t *testing.T) {
 	for i := 0; i < 100; i++ {
 		_, p1, err1 := bn256.RandomG1(rand.Reader)
@@ -0,0 +1,317 @@
+// Package auditor implements a third-party auditor of the random beacon. The
+// auditor does not need to stake nor to be a member of any group. It follows
+// the beacon on the chain and verifies every submitted relay entry against
+// the previous entry and the public key of the group registered on the chain.
+package auditor
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sync"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-core/pkg/beacon/entry"
+	"github.com/keep-network/keep-core/pkg/beacon/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+var logger = log.Logger("keep-beacon-auditor")
+
+// DefaultHistorySize is the default number of the most recent relay entries
+// kept in the history of the auditor.
+const DefaultHistorySize = 1000
+
+// Chain defines the subset of the beacon chain interface the auditor follows.
+type Chain interface {
+	// OnRelayEntryRequested is a callback that is invoked when an on-chain
+	// notification of a new, valid relay request is seen.
+	OnRelayEntryRequested(
+		func(request *event.RelayEntryRequested),
+	) subscription.EventSubscription
+	// OnRelayEntrySubmitted is a callback that is invoked when an on-chain
+	// notification of a new, valid relay entry is seen.
+	OnRelayEntrySubmitted(
+		func(entry *event.RelayEntrySubmitted),
+	) subscription.EventSubscription
+	// OnGroupRegistered is a callback that is invoked when an on-chain
+	// notification of a new, valid group being registered is seen.
+	OnGroupRegistered(
+		func(groupRegistration *event.GroupRegistration),
+	) subscription.EventSubscription
+	// IsGroupRegistered checks if group with the given public key is
+	// registered on-chain.
+	IsGroupRegistered(groupPublicKey []byte) (bool, error)
+}
+
+// EntryStatus is the outcome of the relay entry verification.
+type EntryStatus int
+
+const (
+	// EntryValid denotes the entry is a valid signature of the previous entry
+	// made by the registered group.
+	EntryValid EntryStatus = iota
+	// EntryInvalid denotes the entry is not a valid signature of the previous
+	// entry or the group is not registered.
+	EntryInvalid
+	// EntryUnverified denotes the entry could not be verified, for example,
+	// because the auditor did not observe the relay request.
+	EntryUnverified
+)
+
+func (es EntryStatus) String() string {
+	switch es {
+	case EntryValid:
+		return "valid"
+	case EntryInvalid:
+		return "invalid"
+	case EntryUnverified:
+		return "unverified"
+	default:
+		return "unknown"
+	}
+}
+
+// AuditedEntry is a relay entry observed on the chain along with
+// the outcome of its verification.
+type AuditedEntry struct {
+	RequestID      *big.Int
+	Entry          []byte
+	PreviousEntry  []byte
+	GroupPublicKey []byte
+	Submitter      chain.Address
+	// RequestBlockNumber is the block at which the relay entry was requested.
+	RequestBlockNumber uint64
+	// BlockNumber is the block at which the relay entry was submitted.
+	BlockNumber uint64
+	Status      EntryStatus
+	// Reason explains why the entry is invalid or could not be verified.
+	Reason string
+}
+
+// Auditor follows the random beacon on the chain and verifies every
+// submitted relay entry. The history of the most recent audited entries is
+// kept in memory.
+type Auditor struct {
+	chain       Chain
+	historySize int
+
+	mutex sync.Mutex
+	// Public keys of groups known to be registered on the chain, hex-encoded.
+	registeredGroups map[string]bool
+	// The most recent relay request observed on the chain. The beacon
+	// processes one relay request at a time so a new request supersedes
+	// the previous one.
+	currentRequest *event.RelayEntryRequested
+	history        []*AuditedEntry
+	handlers       []func(entry *AuditedEntry)
+}
+
+// NewAuditor creates a new auditor following the given chain and keeping
+// at most historySize of the most recent entries in the history.
+func NewAuditor(chain Chain, historySize int) *Auditor {
+	return &Auditor{
+		chain:            chain,
+		historySize:      historySize,
+		registeredGroups: make(map[string]bool),
+		history:          make([]*AuditedEntry, 0),
+	}
+}
+
+// Start subscribes the auditor to the chain events. The auditor follows
+// the chain until the context is done.
+func (a *Auditor) Start(ctx context.Context) {
+	subscriptions := []subscription.EventSubscription{
+		a.chain.OnGroupRegistered(a.onGroupRegistered),
+		a.chain.OnRelayEntryRequested(a.onRelayEntryRequested),
+		a.chain.OnRelayEntrySubmitted(a.onRelayEntrySubmitted),
+	}
+
+	go func() {
+		<-ctx.Done()
+		for _, subscription := range subscriptions {
+			subscription.Unsubscribe()
+		}
+	}()
+}
+
+// OnEntryAudited registers a handler that is invoked with every relay entry
+// once it is audited. Handlers are invoked synchronously, in the order of
+// the entry submission, so they must not call the auditor.
+func (a *Auditor) OnEntryAudited(handler func(entry *AuditedEntry)) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.handlers = append(a.handlers, handler)
+}
+
+// Entries returns the history of audited entries, ordered from the oldest to
+// the most recent one.
+func (a *Auditor) Entries() []*AuditedEntry {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	entries := make([]*AuditedEntry, len(a.history))
+	copy(entries, a.history)
+
+	return entries
+}
+
+func (a *Auditor) onGroupRegistered(registration *event.GroupRegistration) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	logger.Infof(
+		"group with public key [0x%x] registered at block [%v]",
+		registration.GroupPublicKey,
+		registration.BlockNumber,
+	)
+
+	a.registeredGroups[hex.EncodeToString(registration.GroupPublicKey)] = true
+}
+
+func (a *Auditor) onRelayEntryRequested(request *event.RelayEntryRequested) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	logger.Infof(
+		"relay entry requested at block [%v] from group [0x%x] "+
+			"using previous entry [0x%x]",
+		request.BlockNumber,
+		request.GroupPublicKey,
+		request.PreviousEntry,
+	)
+
+	a.currentRequest = request
+}
+
+func (a *Auditor) onRelayEntrySubmitted(submitted *event.RelayEntrySubmitted) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for _, audited := range a.history {
+		if bytes.Equal(audited.Entry, submitted.Entry) {
+			// The event has already been processed.
+			return
+		}
+	}
+
+	audited := a.audit(submitted)
+
+	switch audited.Status {
+	case EntryValid:
+		logger.Infof(
+			"verified relay entry [0x%x] submitted at block [%v]",
+			audited.Entry,
+			audited.BlockNumber,
+		)
+	case EntryInvalid:
+		logger.Errorf(
+			"invalid relay entry [0x%x] submitted at block [%v] "+
+				"by group [0x%x] for previous entry [0x%x]: [%v]",
+			audited.Entry,
+			audited.BlockNumber,
+			audited.GroupPublicKey,
+			audited.PreviousEntry,
+			audited.Reason,
+		)
+	case EntryUnverified:
+		logger.Warningf(
+			"could not verify relay entry [0x%x] submitted at block [%v]: [%v]",
+			audited.Entry,
+			audited.BlockNumber,
+			audited.Reason,
+		)
+	}
+
+	a.history = append(a.history, audited)
+	if len(a.history) > a.historySize {
+		a.history = a.history[len(a.history)-a.historySize:]
+	}
+
+	for _, handler := range a.handlers {
+		handler(audited)
+	}
+}
+
+// audit verifies the submitted entry against the current relay request.
+// Must be called with the auditor's mutex held.
+func (a *Auditor) audit(submitted *event.RelayEntrySubmitted) *AuditedEntry {
+	audited := &AuditedEntry{
+		RequestID:   submitted.RequestID,
+		Entry:       submitted.Entry,
+		Submitter:   submitted.Submitter,
+		BlockNumber: submitted.BlockNumber,
+	}
+
+	request := a.currentRequest
+	if request == nil || !sameRequest(request.RequestID, submitted.RequestID) {
+		audited.Status = EntryUnverified
+		audited.Reason = "relay request was not observed"
+		return audited
+	}
+
+	audited.PreviousEntry = request.PreviousEntry
+	audited.GroupPublicKey = request.GroupPublicKey
+	audited.RequestBlockNumber = request.BlockNumber
+
+	isRegistered, err := a.isGroupRegistered(request.GroupPublicKey)
+	if err != nil {
+		audited.Status = EntryUnverified
+		audited.Reason = err.Error()
+		return audited
+	}
+	if !isRegistered {
+		audited.Status = EntryInvalid
+		audited.Reason = "group is not registered"
+		return audited
+	}
+
+	err = entry.Verify(
+		request.GroupPublicKey,
+		request.PreviousEntry,
+		submitted.Entry,
+	)
+	if err != nil {
+		audited.Status = EntryInvalid
+		audited.Reason = err.Error()
+		return audited
+	}
+
+	audited.Status = EntryValid
+	return audited
+}
+
+// isGroupRegistered checks whether the group with the given public key has
+// been registered on the chain. Groups registered before the auditor started
+// are looked up on the chain. Must be called with the auditor's mutex held.
+func (a *Auditor) isGroupRegistered(groupPublicKey []byte) (bool, error) {
+	groupPublicKeyHex := hex.EncodeToString(groupPublicKey)
+	if a.registeredGroups[groupPublicKeyHex] {
+		return true, nil
+	}
+
+	isRegistered, err := a.chain.IsGroupRegistered(groupPublicKey)
+	if err != nil {
+		return false, err
+	}
+
+	if isRegistered {
+		a.registeredGroups[groupPublicKeyHex] = true
+	}
+
+	return isRegistered, nil
+}
+
+// sameRequest checks whether the request IDs denote the same relay request.
+// Chains which do not identify relay requests leave the request ID unset.
+func sameRequest(requestID1 *big.Int, requestID2 *big.Int) bool {
+	if requestID1 == nil || requestID2 == nil {
+		return requestID1 == nil && requestID2 == nil
+	}
+
+	return requestID1.Cmp(requestID2) == 0
+}
@@ -0,0 +1,369 @@
+package auditor
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+
+	"github.com/keep-network/keep-core/pkg/beacon/event"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+var (
+	submitter      = chain.Address("0x65ea55c1f10491038425725dc00dffeab2a1e28a")
+	groupSecretKey = big.NewInt(1410)
+	groupPublicKey = new(bn256.G2).ScalarBaseMult(groupSecretKey).Marshal()
+	previousEntry  = new(bn256.G1).ScalarBaseMult(big.NewInt(1920))
+	validEntry     = bls.SignG1(groupSecretKey, previousEntry).Marshal()
+)
+
+func TestAuditor_ValidEntry(t *testing.T) {
+	chain := newMockChain()
+	auditor := startTestAuditor(chain, DefaultHistorySize)
+
+	chain.registerGroup(groupPublicKey, 10)
+	chain.requestRelayEntry(big.NewInt(1), groupPublicKey, previousEntry.Marshal(), 20)
+	chain.submitRelayEntry(big.NewInt(1), validEntry, 25)
+
+	assertEntries(t, auditor, []*AuditedEntry{
+		{
+			RequestID:          big.NewInt(1),
+			Entry:              validEntry,
+			PreviousEntry:      previousEntry.Marshal(),
+			GroupPublicKey:     groupPublicKey,
+			Submitter:          submitter,
+			RequestBlockNumber: 20,
+			BlockNumber:        25,
+			Status:             EntryValid,
+		},
+	})
+}
+
+func TestAuditor_GroupRegisteredBeforeStart(t *testing.T) {
+	chain := newMockChain()
+	chain.registeredGroups[hex.EncodeToString(groupPublicKey)] = true
+	auditor := startTestAuditor(chain, DefaultHistorySize)
+
+	chain.requestRelayEntry(big.NewInt(1), groupPublicKey, previousEntry.Marshal(), 20)
+	chain.submitRelayEntry(big.NewInt(1), validEntry, 25)
+
+	assertStatuses(t, auditor, EntryValid)
+}
+
+func TestAuditor_InvalidEntry(t *testing.T) {
+	chain := newMockChain()
+	auditor := startTestAuditor(chain, DefaultHistorySize)
+
+	invalidEntry := bls.SignG1(big.NewInt(1683), previousEntry).Marshal()
+
+	chain.registerGroup(groupPublicKey, 10)
+	chain.requestRelayEntry(big.NewInt(1), groupPublicKey, previousEntry.Marshal(), 20)
+	chain.submitRelayEntry(big.NewInt(1), invalidEntry, 25)
+
+	assertStatuses(t, auditor, EntryInvalid)
+}
+
+func TestAuditor_GroupNotRegistered(t *testing.T) {
+	chain := newMockChain()
+	auditor := startTestAuditor(chain, DefaultHistorySize)
+
+	chain.requestRelayEntry(big.NewInt(1), groupPublicKey, previousEntry.Marshal(), 20)
+	chain.submitRelayEntry(big.NewInt(1), validEntry, 25)
+
+	assertStatuses(t, auditor, EntryInvalid)
+}
+
+func TestAuditor_RequestNotObserved(t *testing.T) {
+	chain := newMockChain()
+	auditor := startTestAuditor(chain, DefaultHistorySize)
+
+	chain.registerGroup(groupPublicKey, 10)
+	chain.requestRelayEntry(big.NewInt(1), groupPublicKey, previousEntry.Marshal(), 20)
+	chain.submitRelayEntry(big.NewInt(2), validEntry, 25)
+
+	assertStatuses(t, auditor, EntryUnverified)
+}
+
+func TestAuditor_DuplicatedEvent(t *testing.T) {
+	chain := newMockChain()
+	auditor := startTestAuditor(chain, DefaultHistorySize)
+
+	chain.registerGroup(groupPublicKey, 10)
+	chain.requestRelayEntry(big.NewInt(1), groupPublicKey, previousEntry.Marshal(), 20)
+	chain.submitRelayEntry(big.NewInt(1), validEntry, 25)
+	chain.submitRelayEntry(big.NewInt(1), validEntry, 25)
+
+	assertStatuses(t, auditor, EntryValid)
+}
+
+func TestAuditor_HistorySize(t *testing.T) {
+	chain := newMockChain()
+	auditor := startTestAuditor(chain, 2)
+
+	chain.registerGroup(groupPublicKey, 10)
+
+	entry := previousEntry
+	for i := 1; i <= 3; i++ {
+		nextEntry := bls.SignG1(groupSecretKey, entry)
+		chain.requestRelayEntry(big.NewInt(int64(i)), groupPublicKey, entry.Marshal(), uint64(20*i))
+		chain.submitRelayEntry(big.NewInt(int64(i)), nextEntry.Marshal(), uint64(20*i+5))
+		entry = nextEntry
+	}
+
+	entries := auditor.Entries()
+	if len(entries) != 2 {
+		t.Fatalf(
+			"unexpected number of entries\nexpected: [%v]\nactual:   [%v]",
+			2,
+			len(entries),
+		)
+	}
+
+	expectedRequestIDs := []*big.Int{big.NewInt(2), big.NewInt(3)}
+	actualRequestIDs := []*big.Int{entries[0].RequestID, entries[1].RequestID}
+	if !reflect.DeepEqual(expectedRequestIDs, actualRequestIDs) {
+		t.Errorf(
+			"unexpected request IDs\nexpected: [%v]\nactual:   [%v]",
+			expectedRequestIDs,
+			actualRequestIDs,
+		)
+	}
+}
+
+func TestAuditor_OnEntryAudited(t *testing.T) {
+	chain := newMockChain()
+	auditor := startTestAuditor(chain, DefaultHistorySize)
+
+	var handledEntries []*AuditedEntry
+	auditor.OnEntryAudited(func(entry *AuditedEntry) {
+		handledEntries = append(handledEntries, entry)
+	})
+
+	chain.registerGroup(groupPublicKey, 10)
+	chain.requestRelayEntry(big.NewInt(1), groupPublicKey, previousEntry.Marshal(), 20)
+	chain.submitRelayEntry(big.NewInt(1), validEntry, 25)
+	chain.submitRelayEntry(big.NewInt(1), validEntry, 25)
+
+	if !reflect.DeepEqual(auditor.Entries(), handledEntries) {
+		t.Errorf(
+			"unexpected handled entries\nexpected: [%+v]\nactual:   [%+v]",
+			auditor.Entries(),
+			handledEntries,
+		)
+	}
+}
+
+func TestAuditor_ServeHTTP(t *testing.T) {
+	chain := newMockChain()
+	auditor := startTestAuditor(chain, DefaultHistorySize)
+
+	chain.registerGroup(groupPublicKey, 10)
+	chain.requestRelayEntry(big.NewInt(1), groupPublicKey, previousEntry.Marshal(), 20)
+	chain.submitRelayEntry(big.NewInt(1), validEntry, 25)
+	chain.submitRelayEntry(big.NewInt(2), []byte{0x01}, 45)
+	chain.submitRelayEntry(big.NewInt(3), []byte{0x02}, 65)
+
+	var tests = map[string]struct {
+		query              string
+		expectedStatusCode int
+		expectedRequestIDs []string
+	}{
+		"all entries": {
+			query:              "",
+			expectedStatusCode: http.StatusOK,
+			expectedRequestIDs: []string{"3", "2", "1"},
+		},
+		"limited entries": {
+			query:              "?limit=2",
+			expectedStatusCode: http.StatusOK,
+			expectedRequestIDs: []string{"3", "2"},
+		},
+		"entries with status": {
+			query:              "?status=valid",
+			expectedStatusCode: http.StatusOK,
+			expectedRequestIDs: []string{"1"},
+		},
+		"invalid limit": {
+			query:              "?limit=-1",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			auditor.ServeHTTP(
+				recorder,
+				httptest.NewRequest(http.MethodGet, EntriesPath+test.query, nil),
+			)
+
+			if recorder.Code != test.expectedStatusCode {
+				t.Fatalf(
+					"unexpected status code\nexpected: [%v]\nactual:   [%v]",
+					test.expectedStatusCode,
+					recorder.Code,
+				)
+			}
+
+			if test.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var entries []map[string]interface{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &entries); err != nil {
+				t.Fatal(err)
+			}
+
+			actualRequestIDs := make([]string, len(entries))
+			for i, entry := range entries {
+				actualRequestIDs[i] = entry["request_id"].(string)
+			}
+
+			if !reflect.DeepEqual(test.expectedRequestIDs, actualRequestIDs) {
+				t.Errorf(
+					"unexpected request IDs\nexpected: [%v]\nactual:   [%v]",
+					test.expectedRequestIDs,
+					actualRequestIDs,
+				)
+			}
+		})
+	}
+}
+
+func startTestAuditor(chain *mockChain, historySize int) *Auditor {
+	auditor := NewAuditor(chain, historySize)
+	auditor.Start(context.Background())
+	return auditor
+}
+
+func assertEntries(
+	t *testing.T,
+	auditor *Auditor,
+	expectedEntries []*AuditedEntry,
+) {
+	actualEntries := auditor.Entries()
+	if !reflect.DeepEqual(expectedEntries, actualEntries) {
+		t.Errorf(
+			"unexpected entries\nexpected: [%+v]\nactual:   [%+v]",
+			expectedEntries,
+			actualEntries,
+		)
+	}
+}
+
+func assertStatuses(
+	t *testing.T,
+	auditor *Auditor,
+	expectedStatuses ...EntryStatus,
+) {
+	entries := auditor.Entries()
+
+	actualStatuses := make([]EntryStatus, len(entries))
+	for i, entry := range entries {
+		actualStatuses[i] = entry.Status
+	}
+
+	if !reflect.DeepEqual(expectedStatuses, actualStatuses) {
+		t.Errorf(
+			"unexpected statuses\nexpected: [%v]\nactual:   [%v]",
+			expectedStatuses,
+			actualStatuses,
+		)
+	}
+}
+
+// mockChain delivers events to the handlers synchronously.
+type mockChain struct {
+	registeredGroups map[string]bool
+
+	groupRegisteredHandlers     []func(*event.GroupRegistration)
+	relayEntryRequestedHandlers []func(*event.RelayEntryRequested)
+	relayEntrySubmittedHandlers []func(*event.RelayEntrySubmitted)
+}
+
+func newMockChain() *mockChain {
+	return &mockChain{
+		registeredGroups: make(map[string]bool),
+	}
+}
+
+func (mc *mockChain) registerGroup(groupPublicKey []byte, blockNumber uint64) {
+	mc.registeredGroups[hex.EncodeToString(groupPublicKey)] = true
+	for _, handler := range mc.groupRegisteredHandlers {
+		handler(&event.GroupRegistration{
+			GroupPublicKey: groupPublicKey,
+			BlockNumber:    blockNumber,
+		})
+	}
+}
+
+func (mc *mockChain) requestRelayEntry(
+	requestID *big.Int,
+	groupPublicKey []byte,
+	previousEntry []byte,
+	blockNumber uint64,
+) {
+	for _, handler := range mc.relayEntryRequestedHandlers {
+		handler(&event.RelayEntryRequested{
+			RequestID:      requestID,
+			PreviousEntry:  previousEntry,
+			GroupPublicKey: groupPublicKey,
+			BlockNumber:    blockNumber,
+		})
+	}
+}
+
+func (mc *mockChain) submitRelayEntry(
+	requestID *big.Int,
+	entry []byte,
+	blockNumber uint64,
+) {
+	for _, handler := range mc.relayEntrySubmittedHandlers {
+		handler(&event.RelayEntrySubmitted{
+			RequestID:   requestID,
+			Entry:       entry,
+			Submitter:   submitter,
+			BlockNumber: blockNumber,
+		})
+	}
+}
+
+func (mc *mockChain) OnRelayEntryRequested(
+	handler func(request *event.RelayEntryRequested),
+) subscription.EventSubscription {
+	mc.relayEntryRequestedHandlers = append(
+		mc.relayEntryRequestedHandlers,
+		handler,
+	)
+	return subscription.NewEventSubscription(func() {})
+}
+
+func (mc *mockChain) OnRelayEntrySubmitted(
+	handler func(entry *event.RelayEntrySubmitted),
+) subscription.EventSubscription {
+	mc.relayEntrySubmittedHandlers = append(
+		mc.relayEntrySubmittedHandlers,
+		handler,
+	)
+	return subscription.NewEventSubscription(func() {})
+}
+
+func (mc *mockChain) OnGroupRegistered(
+	handler func(groupRegistration *event.GroupRegistration),
+) subscription.EventSubscription {
+	mc.groupRegisteredHandlers = append(mc.groupRegisteredHandlers, handler)
+	return subscription.NewEventSubscription(func() {})
+}
+
+func (mc *mockChain) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
+	return mc.registeredGroups[hex.EncodeToString(groupPublicKey)], nil
+}
@@ -0,0 +1,224 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: pkg/beacon/auditor/gen/pb/message.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AuditedEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RequestID          []byte `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	Entry              []byte `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	PreviousEntry      []byte `protobuf:"bytes,3,opt,name=previousEntry,proto3" json:"previousEntry,omitempty"`
+	GroupPublicKey     []byte `protobuf:"bytes,4,opt,name=groupPublicKey,proto3" json:"groupPublicKey,omitempty"`
+	Submitter          string `protobuf:"bytes,5,opt,name=submitter,proto3" json:"submitter,omitempty"`
+	RequestBlockNumber uint64 `protobuf:"varint,6,opt,name=requestBlockNumber,proto3" json:"requestBlockNumber,omitempty"`
+	BlockNumber        uint64 `protobuf:"varint,7,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	Status             uint32 `protobuf:"varint,8,opt,name=status,proto3" json:"status,omitempty"`
+	Reason             string `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *AuditedEntry) Reset() {
+	*x = AuditedEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_beacon_auditor_gen_pb_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditedEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditedEntry) ProtoMessage() {}
+
+func (x *AuditedEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_beacon_auditor_gen_pb_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditedEntry.ProtoReflect.Descriptor instead.
+func (*AuditedEntry) Descriptor() ([]byte, []int) {
+	return file_pkg_beacon_auditor_gen_pb_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditedEntry) GetRequestID() []byte {
+	if x != nil {
+		return x.RequestID
+	}
+	return nil
+}
+
+func (x *AuditedEntry) GetEntry() []byte {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *AuditedEntry) GetPreviousEntry() []byte {
+	if x != nil {
+		return x.PreviousEntry
+	}
+	return nil
+}
+
+func (x *AuditedEntry) GetGroupPublicKey() []byte {
+	if x != nil {
+		return x.GroupPublicKey
+	}
+	return nil
+}
+
+func (x *AuditedEntry) GetSubmitter() string {
+	if x != nil {
+		return x.Submitter
+	}
+	return ""
+}
+
+func (x *AuditedEntry) GetRequestBlockNumber() uint64 {
+	if x != nil {
+		return x.RequestBlockNumber
+	}
+	return 0
+}
+
+func (x *AuditedEntry) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *AuditedEntry) GetStatus() uint32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *AuditedEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+var File_pkg_beacon_auditor_gen_pb_message_proto protoreflect.FileDescriptor
+
+var file_pkg_beacon_auditor_gen_pb_message_proto_rawDesc = []byte{
+	0x0a, 0x27, 0x70, 0x6b, 0x67, 0x2f, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2f, 0x61, 0x75, 0x64,
+	0x69, 0x74, 0x6f, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x6f, 0x72, 0x22, 0xb0, 0x02, 0x0a, 0x0c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x65, 0x64, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49,
+	0x44, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d,
+	0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x26, 0x0a,
+	0x0e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x74,
+	0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74,
+	0x74, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_beacon_auditor_gen_pb_message_proto_rawDescOnce sync.Once
+	file_pkg_beacon_auditor_gen_pb_message_proto_rawDescData = file_pkg_beacon_auditor_gen_pb_message_proto_rawDesc
+)
+
+func file_pkg_beacon_auditor_gen_pb_message_proto_rawDescGZIP() []byte {
+	file_pkg_beacon_auditor_gen_pb_message_proto_rawDescOnce.Do(func() {
+		file_pkg_beacon_auditor_gen_pb_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_beacon_auditor_gen_pb_message_proto_rawDescData)
+	})
+	return file_pkg_beacon_auditor_gen_pb_message_proto_rawDescData
+}
+
+var file_pkg_beacon_auditor_gen_pb_message_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_pkg_beacon_auditor_gen_pb_message_proto_goTypes = []interface{}{
+	(*AuditedEntry)(nil), // 0: auditor.AuditedEntry
+}
+var file_pkg_beacon_auditor_gen_pb_message_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_pkg_beacon_auditor_gen_pb_message_proto_init() }
+func file_pkg_beacon_auditor_gen_pb_message_proto_init() {
+	if File_pkg_beacon_auditor_gen_pb_message_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_beacon_auditor_gen_pb_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditedEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_beacon_auditor_gen_pb_message_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_beacon_auditor_gen_pb_message_proto_goTypes,
+		DependencyIndexes: file_pkg_beacon_auditor_gen_pb_message_proto_depIdxs,
+		MessageInfos:      file_pkg_beacon_auditor_gen_pb_message_proto_msgTypes,
+	}.Build()
+	File_pkg_beacon_auditor_gen_pb_message_proto = out.File
+	file_pkg_beacon_auditor_gen_pb_message_proto_rawDesc = nil
+	file_pkg_beacon_auditor_gen_pb_message_proto_goTypes = nil
+	file_pkg_beacon_auditor_gen_pb_message_proto_depIdxs = nil
+}
@@ -0,0 +1,184 @@
+package auditor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// EntriesPath is the path under which the history of entries audited
+	// recently is exposed over HTTP.
+	EntriesPath = "/beacon/entries"
+	// HistoryPath is the path under which the entries kept in the entry
+	// store are exposed over HTTP.
+	HistoryPath = "/beacon/history"
+
+	// DefaultHistoryPageSize is the default number of entries returned on
+	// a single page of the entry store history.
+	DefaultHistoryPageSize = 100
+	// MaxHistoryPageSize is the maximum number of entries returned on
+	// a single page of the entry store history.
+	MaxHistoryPageSize = 1000
+)
+
+// ServeHTTP exposes the history of audited entries in JSON format, ordered
+// from the most recent to the oldest one. The optional limit query parameter
+// caps the number of returned entries and the optional status query
+// parameter filters entries by the verification status.
+func (a *Auditor) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		http.Error(response, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := request.URL.Query()
+
+	limit, err := parseIntParam(query, "limit", a.historySize)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	status := query.Get("status")
+
+	entries := a.Entries()
+
+	entriesList := make([]map[string]interface{}, 0, len(entries))
+	for i := len(entries) - 1; i >= 0 && len(entriesList) < limit; i-- {
+		audited := entries[i]
+
+		if status != "" && status != audited.Status.String() {
+			continue
+		}
+
+		entriesList = append(entriesList, entryInfo(audited))
+	}
+
+	writeJSON(response, entriesList)
+}
+
+// ServeHTTP exposes the entries kept in the store in JSON format, ordered by
+// the block at which they were submitted. The optional fromBlock and toBlock
+// query parameters narrow down the entries to the ones submitted in the given,
+// inclusive, block range. The entries are paginated with the optional offset
+// and limit query parameters.
+func (es *EntryStore) ServeHTTP(
+	response http.ResponseWriter,
+	request *http.Request,
+) {
+	if request.Method != http.MethodGet {
+		http.Error(response, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := request.URL.Query()
+
+	fromBlock, err := parseUintParam(query, "fromBlock", 0)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	toBlock, err := parseUintParam(query, "toBlock", math.MaxUint64)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	offset, err := parseIntParam(query, "offset", 0)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limit, err := parseIntParam(query, "limit", DefaultHistoryPageSize)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if limit > MaxHistoryPageSize {
+		limit = MaxHistoryPageSize
+	}
+
+	entries, total := es.Entries(fromBlock, toBlock, offset, limit)
+
+	entriesList := make([]map[string]interface{}, len(entries))
+	for i, audited := range entries {
+		entriesList[i] = entryInfo(audited)
+	}
+
+	writeJSON(response, map[string]interface{}{
+		"total":   total,
+		"offset":  offset,
+		"limit":   limit,
+		"entries": entriesList,
+	})
+}
+
+func entryInfo(audited *AuditedEntry) map[string]interface{} {
+	info := map[string]interface{}{
+		"entry":                fmt.Sprintf("0x%x", audited.Entry),
+		"previous_entry":       fmt.Sprintf("0x%x", audited.PreviousEntry),
+		"group_public_key":     fmt.Sprintf("0x%x", audited.GroupPublicKey),
+		"request_block_number": audited.RequestBlockNumber,
+		"block_number":         audited.BlockNumber,
+		"status":               audited.Status.String(),
+	}
+
+	if audited.RequestID != nil {
+		info["request_id"] = audited.RequestID.String()
+	}
+
+	if audited.Submitter != "" {
+		info["submitter"] = audited.Submitter.String()
+	}
+
+	if audited.Reason != "" {
+		info["reason"] = audited.Reason
+	}
+
+	return info
+}
+
+func parseIntParam(query url.Values, name string, defaultValue int) (int, error) {
+	param := query.Get(name)
+	if param == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.Atoi(param)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid %s [%s]", name, param)
+	}
+
+	return value, nil
+}
+
+func parseUintParam(
+	query url.Values,
+	name string,
+	defaultValue uint64,
+) (uint64, error) {
+	param := query.Get(name)
+	if param == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s [%s]", name, param)
+	}
+
+	return value, nil
+}
+
+func writeJSON(response http.ResponseWriter, value interface{}) {
+	response.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(response).Encode(value); err != nil {
+		logger.Errorf("error on serializing audited entries to JSON: [%v]", err)
+	}
+}
@@ -0,0 +1,63 @@
+package auditor
+
+import (
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/keep-network/keep-core/pkg/beacon/auditor/gen/pb"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// Marshal converts this AuditedEntry to a byte array suitable for storage.
+func (ae *AuditedEntry) Marshal() ([]byte, error) {
+	var requestID []byte
+	if ae.RequestID != nil {
+		requestID = ae.RequestID.Bytes()
+	}
+
+	return proto.Marshal(&pb.AuditedEntry{
+		RequestID:          requestID,
+		Entry:              ae.Entry,
+		PreviousEntry:      ae.PreviousEntry,
+		GroupPublicKey:     ae.GroupPublicKey,
+		Submitter:          ae.Submitter.String(),
+		RequestBlockNumber: ae.RequestBlockNumber,
+		BlockNumber:        ae.BlockNumber,
+		Status:             uint32(ae.Status),
+		Reason:             ae.Reason,
+	})
+}
+
+// Unmarshal converts a byte array produced by Marshal to an AuditedEntry.
+// Relay requests are identified starting from 1 so an empty request ID is
+// unmarshaled as an unset one.
+func (ae *AuditedEntry) Unmarshal(bytes []byte) error {
+	pbAuditedEntry := pb.AuditedEntry{}
+	if err := proto.Unmarshal(bytes, &pbAuditedEntry); err != nil {
+		return err
+	}
+
+	status := EntryStatus(pbAuditedEntry.Status)
+	if status != EntryValid && status != EntryInvalid && status != EntryUnverified {
+		return fmt.Errorf("invalid entry status [%v]", pbAuditedEntry.Status)
+	}
+
+	var requestID *big.Int
+	if len(pbAuditedEntry.RequestID) > 0 {
+		requestID = new(big.Int).SetBytes(pbAuditedEntry.RequestID)
+	}
+
+	ae.RequestID = requestID
+	ae.Entry = pbAuditedEntry.Entry
+	ae.PreviousEntry = pbAuditedEntry.PreviousEntry
+	ae.GroupPublicKey = pbAuditedEntry.GroupPublicKey
+	ae.Submitter = chain.Address(pbAuditedEntry.Submitter)
+	ae.RequestBlockNumber = pbAuditedEntry.RequestBlockNumber
+	ae.BlockNumber = pbAuditedEntry.BlockNumber
+	ae.Status = status
+	ae.Reason = pbAuditedEntry.Reason
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package auditor
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestAuditedEntryRoundtrip(t *testing.T) {
+	var tests = map[string]struct {
+		entry *AuditedEntry
+	}{
+		"verified entry": {
+			entry: &AuditedEntry{
+				RequestID:          big.NewInt(1),
+				Entry:              validEntry,
+				PreviousEntry:      previousEntry.Marshal(),
+				GroupPublicKey:     groupPublicKey,
+				Submitter:          submitter,
+				RequestBlockNumber: 20,
+				BlockNumber:        25,
+				Status:             EntryValid,
+			},
+		},
+		"unverified entry": {
+			entry: &AuditedEntry{
+				Entry:       validEntry,
+				BlockNumber: 25,
+				Status:      EntryUnverified,
+				Reason:      "relay request was not observed",
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			marshaled, err := test.entry.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			unmarshaled := &AuditedEntry{}
+			if err := unmarshaled.Unmarshal(marshaled); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.entry, unmarshaled) {
+				t.Errorf(
+					"unexpected content of unmarshaled entry\n"+
+						"expected: [%+v]\nactual:   [%+v]",
+					test.entry,
+					unmarshaled,
+				)
+			}
+		})
+	}
+}
+
+func TestAuditedEntryUnmarshal_InvalidStatus(t *testing.T) {
+	entry := &AuditedEntry{Entry: validEntry, Status: EntryStatus(7)}
+
+	marshaled, err := entry.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (&AuditedEntry{}).Unmarshal(marshaled); err == nil {
+		t.Errorf("expected error for invalid status")
+	}
+}
@@ -0,0 +1,181 @@
+package auditor
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+// entryStoreDirectory is the storage directory keeping audited relay entries.
+const entryStoreDirectory = "relay_entries"
+
+// EntryStore is an append-only store of audited relay entries. Entries are
+// persisted once they are appended and are never removed so the store keeps
+// the full history of the beacon randomness observed by the client. Entries
+// are kept in memory as well, ordered by the block at which they were
+// submitted.
+type EntryStore struct {
+	mutex       sync.RWMutex
+	persistence persistence.BasicHandle
+	entries     []*AuditedEntry
+}
+
+// NewEntryStore creates a new entry store backed by the given persistence
+// handle and loads all the entries persisted so far.
+func NewEntryStore(persistence persistence.BasicHandle) *EntryStore {
+	entries := readEntries(persistence)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].BlockNumber < entries[j].BlockNumber
+	})
+
+	logger.Infof("loaded [%v] relay entries from the store", len(entries))
+
+	return &EntryStore{
+		persistence: persistence,
+		entries:     entries,
+	}
+}
+
+// Append persists the given audited entry. Entries already in the store are
+// ignored.
+func (es *EntryStore) Append(entry *AuditedEntry) error {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	for _, stored := range es.entries {
+		if bytes.Equal(stored.Entry, entry.Entry) {
+			return nil
+		}
+	}
+
+	entryBytes, err := entry.Marshal()
+	if err != nil {
+		return fmt.Errorf("could not marshal relay entry: [%v]", err)
+	}
+
+	if err := es.persistence.Save(
+		entryBytes,
+		entryStoreDirectory,
+		entryFileName(entry),
+	); err != nil {
+		return fmt.Errorf("could not save relay entry: [%w]", err)
+	}
+
+	// Entries are usually appended in the order of submission so the new
+	// entry is inserted right before the first entry submitted later.
+	index := sort.Search(len(es.entries), func(i int) bool {
+		return es.entries[i].BlockNumber > entry.BlockNumber
+	})
+	es.entries = append(es.entries, nil)
+	copy(es.entries[index+1:], es.entries[index:])
+	es.entries[index] = entry
+
+	return nil
+}
+
+// Entries returns entries submitted in the given, inclusive, block range,
+// ordered by the block at which they were submitted. The returned entries are
+// paginated: offset entries are skipped and at most limit entries are
+// returned. The total number of entries in the block range is returned as
+// well.
+func (es *EntryStore) Entries(
+	fromBlock uint64,
+	toBlock uint64,
+	offset int,
+	limit int,
+) ([]*AuditedEntry, int) {
+	es.mutex.RLock()
+	defer es.mutex.RUnlock()
+
+	first := sort.Search(len(es.entries), func(i int) bool {
+		return es.entries[i].BlockNumber >= fromBlock
+	})
+	last := sort.Search(len(es.entries), func(i int) bool {
+		return es.entries[i].BlockNumber > toBlock
+	})
+	if last < first {
+		last = first
+	}
+
+	inRange := es.entries[first:last]
+	total := len(inRange)
+
+	if offset >= total {
+		return []*AuditedEntry{}, total
+	}
+
+	end := total
+	if limit < total-offset {
+		end = offset + limit
+	}
+
+	page := make([]*AuditedEntry, end-offset)
+	copy(page, inRange[offset:end])
+
+	return page, total
+}
+
+func readEntries(handle persistence.BasicHandle) []*AuditedEntry {
+	entries := make([]*AuditedEntry, 0)
+
+	descriptorsChan, errorsChan := handle.ReadAll()
+
+	// Both channels are unbuffered and we do not know in which order they
+	// are written so they are read concurrently.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range descriptorsChan {
+			if descriptor.Directory() != entryStoreDirectory {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"could not read relay entry from file [%s]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			entry := &AuditedEntry{}
+			if err := entry.Unmarshal(content); err != nil {
+				logger.Errorf(
+					"could not unmarshal relay entry from file [%s]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			entries = append(entries, entry)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChan {
+			logger.Errorf("could not load relay entries from disk: [%v]", err)
+		}
+	}()
+
+	wg.Wait()
+
+	return entries
+}
+
+func entryFileName(entry *AuditedEntry) string {
+	entryHash := sha256.Sum256(entry.Entry)
+	return fmt.Sprintf("entry_%d_%x", entry.BlockNumber, entryHash[:8])
+}
@@ -0,0 +1,362 @@
+package auditor
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/pkg/bls"
+)
+
+func TestEntryStore_AppendAndReload(t *testing.T) {
+	persistenceMock := newPersistenceMock()
+	store := NewEntryStore(persistenceMock)
+
+	entries := newTestAuditedEntries(3)
+
+	// Append out of order to make sure entries are ordered by the block at
+	// which they were submitted.
+	for _, i := range []int{1, 0, 2, 1} {
+		if err := store.Append(entries[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertStoredEntries(t, store, entries)
+
+	// Persisted entries must be loaded by a new store.
+	assertStoredEntries(t, NewEntryStore(persistenceMock), entries)
+}
+
+func TestEntryStore_Entries(t *testing.T) {
+	store := NewEntryStore(newPersistenceMock())
+
+	// Entries submitted at blocks 10, 20, 30, 40, and 50.
+	entries := newTestAuditedEntries(5)
+	for _, entry := range entries {
+		if err := store.Append(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var tests = map[string]struct {
+		fromBlock       uint64
+		toBlock         uint64
+		offset          int
+		limit           int
+		expectedEntries []*AuditedEntry
+		expectedTotal   int
+	}{
+		"all entries": {
+			fromBlock:       0,
+			toBlock:         100,
+			offset:          0,
+			limit:           10,
+			expectedEntries: entries,
+			expectedTotal:   5,
+		},
+		"first page": {
+			fromBlock:       0,
+			toBlock:         100,
+			offset:          0,
+			limit:           2,
+			expectedEntries: entries[0:2],
+			expectedTotal:   5,
+		},
+		"last page": {
+			fromBlock:       0,
+			toBlock:         100,
+			offset:          4,
+			limit:           2,
+			expectedEntries: entries[4:5],
+			expectedTotal:   5,
+		},
+		"offset beyond entries": {
+			fromBlock:       0,
+			toBlock:         100,
+			offset:          5,
+			limit:           2,
+			expectedEntries: []*AuditedEntry{},
+			expectedTotal:   5,
+		},
+		"block range": {
+			fromBlock:       20,
+			toBlock:         40,
+			offset:          0,
+			limit:           10,
+			expectedEntries: entries[1:4],
+			expectedTotal:   3,
+		},
+		"paginated block range": {
+			fromBlock:       15,
+			toBlock:         45,
+			offset:          1,
+			limit:           1,
+			expectedEntries: entries[2:3],
+			expectedTotal:   3,
+		},
+		"empty block range": {
+			fromBlock:       41,
+			toBlock:         49,
+			offset:          0,
+			limit:           10,
+			expectedEntries: []*AuditedEntry{},
+			expectedTotal:   0,
+		},
+		"inverted block range": {
+			fromBlock:       40,
+			toBlock:         20,
+			offset:          0,
+			limit:           10,
+			expectedEntries: []*AuditedEntry{},
+			expectedTotal:   0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actualEntries, actualTotal := store.Entries(
+				test.fromBlock,
+				test.toBlock,
+				test.offset,
+				test.limit,
+			)
+
+			if !reflect.DeepEqual(test.expectedEntries, actualEntries) {
+				t.Errorf(
+					"unexpected entries\nexpected: [%+v]\nactual:   [%+v]",
+					test.expectedEntries,
+					actualEntries,
+				)
+			}
+
+			if test.expectedTotal != actualTotal {
+				t.Errorf(
+					"unexpected total\nexpected: [%v]\nactual:   [%v]",
+					test.expectedTotal,
+					actualTotal,
+				)
+			}
+		})
+	}
+}
+
+func TestEntryStore_ServeHTTP(t *testing.T) {
+	store := NewEntryStore(newPersistenceMock())
+
+	for _, entry := range newTestAuditedEntries(5) {
+		if err := store.Append(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var tests = map[string]struct {
+		query                string
+		expectedStatusCode   int
+		expectedTotal        float64
+		expectedBlockNumbers []float64
+	}{
+		"default page": {
+			query:                "",
+			expectedStatusCode:   http.StatusOK,
+			expectedTotal:        5,
+			expectedBlockNumbers: []float64{10, 20, 30, 40, 50},
+		},
+		"paginated block range": {
+			query:                "?fromBlock=20&toBlock=50&offset=1&limit=2",
+			expectedStatusCode:   http.StatusOK,
+			expectedTotal:        4,
+			expectedBlockNumbers: []float64{30, 40},
+		},
+		"invalid block": {
+			query:              "?fromBlock=-1",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"invalid offset": {
+			query:              "?offset=first",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			store.ServeHTTP(
+				recorder,
+				httptest.NewRequest(http.MethodGet, HistoryPath+test.query, nil),
+			)
+
+			if recorder.Code != test.expectedStatusCode {
+				t.Fatalf(
+					"unexpected status code\nexpected: [%v]\nactual:   [%v]",
+					test.expectedStatusCode,
+					recorder.Code,
+				)
+			}
+
+			if test.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var page struct {
+				Total   float64
+				Entries []map[string]interface{}
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &page); err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectedTotal != page.Total {
+				t.Errorf(
+					"unexpected total\nexpected: [%v]\nactual:   [%v]",
+					test.expectedTotal,
+					page.Total,
+				)
+			}
+
+			actualBlockNumbers := make([]float64, len(page.Entries))
+			for i, entry := range page.Entries {
+				actualBlockNumbers[i] = entry["block_number"].(float64)
+			}
+
+			if !reflect.DeepEqual(test.expectedBlockNumbers, actualBlockNumbers) {
+				t.Errorf(
+					"unexpected block numbers\nexpected: [%v]\nactual:   [%v]",
+					test.expectedBlockNumbers,
+					actualBlockNumbers,
+				)
+			}
+		})
+	}
+}
+
+// newTestAuditedEntries creates a chain of valid entries, submitted every
+// 10 blocks, starting from block 10.
+func newTestAuditedEntries(count int) []*AuditedEntry {
+	entries := make([]*AuditedEntry, count)
+
+	entry := previousEntry
+	for i := 0; i < count; i++ {
+		nextEntry := bls.SignG1(groupSecretKey, entry)
+
+		entries[i] = &AuditedEntry{
+			RequestID:          big.NewInt(int64(i + 1)),
+			Entry:              nextEntry.Marshal(),
+			PreviousEntry:      entry.Marshal(),
+			GroupPublicKey:     groupPublicKey,
+			Submitter:          submitter,
+			RequestBlockNumber: uint64(10*i + 5),
+			BlockNumber:        uint64(10*i + 10),
+			Status:             EntryValid,
+		}
+
+		entry = nextEntry
+	}
+
+	return entries
+}
+
+func assertStoredEntries(
+	t *testing.T,
+	store *EntryStore,
+	expectedEntries []*AuditedEntry,
+) {
+	actualEntries, _ := store.Entries(0, ^uint64(0), 0, len(expectedEntries)+1)
+	if !reflect.DeepEqual(expectedEntries, actualEntries) {
+		t.Errorf(
+			"unexpected entries\nexpected: [%+v]\nactual:   [%+v]",
+			expectedEntries,
+			actualEntries,
+		)
+	}
+}
+
+type persistenceMock struct {
+	mutex sync.Mutex
+	// directory -> name -> content
+	files map[string]map[string][]byte
+}
+
+func newPersistenceMock() *persistenceMock {
+	return &persistenceMock{
+		files: make(map[string]map[string][]byte),
+	}
+}
+
+func (pm *persistenceMock) Save(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	if _, ok := pm.files[directory]; !ok {
+		pm.files[directory] = make(map[string][]byte)
+	}
+	pm.files[directory][name] = data
+
+	return nil
+}
+
+func (pm *persistenceMock) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	descriptors := make([]persistence.DataDescriptor, 0)
+	for directory, files := range pm.files {
+		for name, content := range files {
+			descriptors = append(
+				descriptors,
+				&testDataDescriptor{directory, name, content},
+			)
+		}
+	}
+
+	dataChan := make(chan persistence.DataDescriptor, len(descriptors))
+	errorChan := make(chan error)
+
+	for _, descriptor := range descriptors {
+		dataChan <- descriptor
+	}
+
+	close(dataChan)
+	close(errorChan)
+
+	return dataChan, errorChan
+}
+
+func (pm *persistenceMock) Delete(directory string, name string) error {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+
+	delete(pm.files[directory], name)
+	return nil
+}
+
+type testDataDescriptor struct {
+	directory string
+	name      string
+	content   []byte
+}
+
+func (tdd *testDataDescriptor) Name() string {
+	return tdd.name
+}
+
+func (tdd *testDataDescriptor) Directory() string {
+	return tdd.directory
+}
+
+func (tdd *testDataDescriptor) Content() ([]byte, error) {
+	return tdd.content, nil
+}
@@ -13,6 +13,8 @@ import (
 
 	"github.com/keep-network/keep-common/pkg/persistence"
 	beaconchain "github.com/keep-network/keep-core/pkg/beacon/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/dkg"
+	"github.com/keep-network/keep-core/pkg/beacon/entry"
 	"github.com/keep-network/keep-core/pkg/beacon/event"
 	"github.com/keep-network/keep-core/pkg/beacon/registry"
 	"github.com/keep-network/keep-core/pkg/net"
@@ -23,28 +25,72 @@ var logger = log.Logger("keep-beacon")
 // ProtocolName denotes the name of the protocol defined by this package.
 const ProtocolName = "beacon"
 
+// maxMessageSize is the maximum size in bytes of messages sent over the
+// group broadcast channel used for both DKG and relay entry signing. The
+// largest messages are GJKR peer shares messages carrying encrypted shares
+// for every other group member.
+const maxMessageSize = 128 * 1024
+
+// DefaultProtocolVersion is the default version of the relay entry signing
+// protocol.
+const DefaultProtocolVersion = uint32(entry.DefaultProtocolVersion)
+
+// Config carries the config for the random beacon protocol.
+type Config struct {
+	// Version of the relay entry signing protocol determining the encoding
+	// of signature shares sent by the client. Signature shares of all the
+	// supported versions are accepted regardless of this setting.
+	ProtocolVersion uint32
+}
+
 // Initialize kicks off the random beacon by initializing internal state,
 // ensuring preconditions like staking are met, and then kicking off the
 // internal random beacon implementation. Returns an error if this failed,
-// otherwise enters a blocked loop.
+// otherwise enters a blocked loop. The evidence recorded during distributed
+// key generations is kept in the provided evidence store. The returned
+// lifecycle manager archives memberships of stale groups in the background
+// and reports the number of active and archived groups.
 func Initialize(
 	ctx context.Context,
 	beaconChain beaconchain.Interface,
 	netProvider net.Provider,
 	persistence persistence.ProtectedHandle,
+	evidenceStore *dkg.EvidenceStore,
 	scheduler *generator.Scheduler,
-) error {
+	config Config,
+) (*registry.LifecycleManager, error) {
+	protocolVersion := entry.ProtocolVersion(config.ProtocolVersion)
+	if err := protocolVersion.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid relay entry protocol version: [%v]", err)
+	}
+
+	blockCounter, err := beaconChain.BlockCounter()
+	if err != nil {
+		return nil, fmt.Errorf("could not get block counter: [%v]", err)
+	}
+
 	groupRegistry := registry.NewGroupRegistry(logger, beaconChain, persistence)
 	groupRegistry.LoadExistingGroups()
 
+	groupLifecycle := registry.NewLifecycleManager(
+		logger,
+		groupRegistry,
+		beaconChain,
+		blockCounter,
+		netProvider,
+		registry.DefaultStaleGroupsCheckInterval,
+	)
+
 	node := newNode(
 		beaconChain,
 		netProvider,
 		groupRegistry,
 		scheduler,
+		evidenceStore,
+		protocolVersion,
 	)
 
-	err := sortition.MonitorPool(
+	err = sortition.MonitorPool(
 		ctx,
 		logger,
 		beaconChain,
@@ -52,7 +98,7 @@ func Initialize(
 		sortition.UnconditionalJoinPolicy,
 	)
 	if err != nil {
-		return fmt.Errorf("could not set up sortition pool monitoring: [%v]", err)
+		return nil, fmt.Errorf("could not set up sortition pool monitoring: [%v]", err)
 	}
 
 	eventDeduplicator := event.NewDeduplicator(beaconChain)
@@ -108,9 +154,7 @@ func Initialize(
 				go node.ForwardSignatureShares(request.GroupPublicKey)
 			}
 
-			go node.MonitorRelayEntry(
-				request.BlockNumber,
-			)
+			go node.MonitorRelayEntry(request)
 		}
 
 		currentRelayRequestConfirmationRetries := 30
@@ -159,10 +203,11 @@ func Initialize(
 			registration.GroupPublicKey,
 			registration.BlockNumber,
 		)
-		go groupRegistry.UnregisterStaleGroups(registration.GroupPublicKey)
 	})
 
-	return nil
+	groupLifecycle.Start(ctx)
+
+	return groupLifecycle, nil
 }
 
 // Before we start relay entry signing process we need to confirm the current
@@ -17,6 +17,16 @@ import (
 // Maximum value accepted by the chain is 255.
 type GroupMemberIndex = uint8
 
+// DKGState represents the state of the group creation on the chain.
+type DKGState int
+
+const (
+	Idle DKGState = iota
+	AwaitingSeed
+	AwaitingResult
+	Challenge
+)
+
 // RelayEntryInterface defines the subset of the beacon chain interface that
 // pertains specifically to submission and retrieval of relay requests and
 // entries.
@@ -105,6 +115,26 @@ type DistributedKeyGenerationInterface interface {
 	OnDKGResultSubmitted(
 		func(event *event.DKGResultSubmission),
 	) subscription.EventSubscription
+	// OnDKGResultChallenged registers a callback that is invoked when an
+	// on-chain notification of a successful DKG result challenge is seen.
+	OnDKGResultChallenged(
+		func(event *event.DKGResultChallenged),
+	) subscription.EventSubscription
+	// OnDKGResultApproved registers a callback that is invoked when an
+	// on-chain notification of the DKG result approval is seen.
+	OnDKGResultApproved(
+		func(event *event.DKGResultApproved),
+	) subscription.EventSubscription
+	// ChallengeDKGResult challenges the submitted DKG result identified by
+	// the given result hash, as seen in the DKG result submission event.
+	// The challenge fails if the chain considers the result valid.
+	ChallengeDKGResult(resultHash [32]byte) error
+	// ApproveDKGResult approves the submitted DKG result identified by
+	// the given result hash, as seen in the DKG result submission event.
+	// Once approved, the group is registered on the chain.
+	ApproveDKGResult(resultHash [32]byte) error
+	// GetDKGState returns the current state of the DKG procedure.
+	GetDKGState() (DKGState, error)
 	// CalculateDKGResultHash calculates 256-bit hash of DKG result in standard
 	// specific for the chain. Operation is performed off-chain.
 	CalculateDKGResultHash(dkgResult *DKGResult) (DKGResultHash, error)
@@ -120,7 +150,8 @@ type Interface interface {
 	// Signing returns the chain's signer.
 	Signing() chain.Signing
 	// OperatorKeyPair returns the key pair of the operator assigned to this
-	// chain handle.
+	// chain handle. The private key is nil if the operator key is held by
+	// a remote signer.
 	OperatorKeyPair() (*operator.PrivateKey, *operator.PublicKey, error)
 
 	sortition.Chain
@@ -130,7 +161,6 @@ type Interface interface {
 }
 
 // Config contains the config data needed for the random beacon to operate.
-// TODO: Adjust to the random beacon v2 requirements.
 type Config struct {
 	// GroupSize is the size of a group in the random beacon.
 	GroupSize int
@@ -149,6 +179,14 @@ type Config struct {
 	// entry to be published by the selected group. Blocks are
 	// counted from the moment relay request occur.
 	RelayEntryTimeout uint64
+	// DKGResultChallengePeriodLength is the number of blocks following
+	// the DKG result submission during which the result can be challenged.
+	// The result can be approved only once the challenge period is over.
+	DKGResultChallengePeriodLength uint64
+	// DKGResultSubmitterPrecedencePeriodLength is the number of blocks
+	// following the end of the challenge period during which only
+	// the submitter of the result is eligible to approve it.
+	DKGResultSubmitterPrecedencePeriodLength uint64
 }
 
 // DishonestThreshold is the maximum number of misbehaving participants for
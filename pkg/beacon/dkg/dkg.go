@@ -17,7 +17,17 @@ import (
 	"github.com/keep-network/keep-core/pkg/protocol/group"
 )
 
-// ExecuteDKG runs the full distributed key generation lifecycle.
+// MisbehaviorHandler is notified about group members marked as inactive or
+// disqualified during the key generation.
+type MisbehaviorHandler func(
+	inactiveMembers []group.MemberIndex,
+	disqualifiedMembers []group.MemberIndex,
+)
+
+// ExecuteDKG runs the full distributed key generation lifecycle. If the
+// evidence store is provided, the evidence recorded during the key generation
+// is saved in the store. If the misbehavior handler is provided, it is
+// notified about members that were inactive or got disqualified.
 func ExecuteDKG(
 	logger log.StandardLogger,
 	seed *big.Int,
@@ -27,6 +37,8 @@ func ExecuteDKG(
 	channel net.BroadcastChannel,
 	membershipValidator *group.MembershipValidator,
 	selectedOperators []chain.Address,
+	evidenceStore *EvidenceStore,
+	misbehaviorHandler MisbehaviorHandler,
 ) (*ThresholdSigner, error) {
 	beaconConfig := beaconChain.GetConfig()
 
@@ -38,6 +50,19 @@ func ExecuteDKG(
 	gjkr.RegisterUnmarshallers(channel)
 	dkgResult.RegisterUnmarshallers(channel)
 
+	var evidenceHandler func(evidence *gjkr.Evidence)
+	if evidenceStore != nil {
+		evidenceHandler = func(evidence *gjkr.Evidence) {
+			if err := evidenceStore.Save(evidence); err != nil {
+				logger.Errorf(
+					"[member:%v] could not save DKG evidence: [%v]",
+					memberIndex,
+					err,
+				)
+			}
+		}
+	}
+
 	gjkrResult, gjkrEndBlockHeight, err := gjkr.Execute(
 		logger,
 		memberIndex,
@@ -48,6 +73,7 @@ func ExecuteDKG(
 		seed,
 		membershipValidator,
 		startBlockHeight,
+		evidenceHandler,
 	)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -57,6 +83,15 @@ func ExecuteDKG(
 		)
 	}
 
+	if misbehaviorHandler != nil {
+		inactiveMembers := gjkrResult.Group.InactiveMemberIDs()
+		disqualifiedMembers := gjkrResult.Group.DisqualifiedMemberIDs()
+
+		if len(inactiveMembers) > 0 || len(disqualifiedMembers) > 0 {
+			misbehaviorHandler(inactiveMembers, disqualifiedMembers)
+		}
+	}
+
 	startPublicationBlockHeight := gjkrEndBlockHeight
 
 	operatingMemberIDs := gjkrResult.Group.OperatingMemberIDs()
@@ -69,7 +104,7 @@ func ExecuteDKG(
 	)
 	defer dkgResultSubscription.Unsubscribe()
 
-	err = dkgResult.Publish(
+	publicationErr := dkgResult.Publish(
 		logger,
 		memberIndex,
 		gjkrResult.Group,
@@ -80,7 +115,7 @@ func ExecuteDKG(
 		blockCounter,
 		startPublicationBlockHeight,
 	)
-	if err != nil {
+	if publicationErr != nil {
 		// Result publication failed. It means that either the result this
 		// member proposed is not supported by the majority of group members or
 		// that the chain interaction failed. In either case, we observe the
@@ -90,16 +125,54 @@ func ExecuteDKG(
 		logger.Warningf(
 			"[member:%v] DKG result publication process failed [%v]",
 			memberIndex,
+			publicationErr,
+		)
+	}
+
+	dkgResultEvent, err := waitForDkgResultEvent(
+		dkgResultChannel,
+		startPublicationBlockHeight,
+		beaconChain,
+		blockCounter,
+	)
+	if err != nil {
+		if publicationErr != nil {
+			return nil, err
+		}
+
+		logger.Warningf(
+			"[member:%v] could not observe submitted DKG result [%v]",
+			memberIndex,
 			err,
 		)
+	} else {
+		// The submitted result must be approved after the challenge period
+		// or challenged if it is invalid. Members do it in the background
+		// as it does not affect their final group membership.
+		go func() {
+			if err := dkgResult.ApproveOrChallenge(
+				logger,
+				memberIndex,
+				gjkrResult,
+				dkgResultEvent,
+				beaconChain,
+				blockCounter,
+			); err != nil {
+				logger.Errorf(
+					"[member:%v] could not approve or challenge "+
+						"DKG result [%v]",
+					memberIndex,
+					err,
+				)
+			}
+		}()
+	}
 
+	if publicationErr != nil {
 		if operatingMemberIDs, err = decideMemberFate(
 			memberIndex,
 			gjkrResult,
-			dkgResultChannel,
-			startPublicationBlockHeight,
-			beaconChain,
-			blockCounter,
+			dkgResultEvent,
 		); err != nil {
 			return nil, err
 		}
@@ -130,21 +203,8 @@ func ExecuteDKG(
 func decideMemberFate(
 	playerIndex group.MemberIndex,
 	gjkrResult *gjkr.Result,
-	dkgResultChannel chan *event.DKGResultSubmission,
-	startPublicationBlockHeight uint64,
-	beaconChain beaconchain.Interface,
-	blockCounter chain.BlockCounter,
+	dkgResultEvent *event.DKGResultSubmission,
 ) ([]group.MemberIndex, error) {
-	dkgResultEvent, err := waitForDkgResultEvent(
-		dkgResultChannel,
-		startPublicationBlockHeight,
-		beaconChain,
-		blockCounter,
-	)
-	if err != nil {
-		return nil, err
-	}
-
 	groupPublicKey, err := gjkrResult.GroupPublicKeyBytes()
 	if err != nil {
 		return nil, err
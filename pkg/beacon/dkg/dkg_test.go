@@ -44,7 +44,7 @@ func setup() {
 func TestDecideMemberFate_HappyPath(t *testing.T) {
 	setup()
 
-	dkgResultChannel <- &event.DKGResultSubmission{
+	dkgResultEvent := &event.DKGResultSubmission{
 		GroupPublicKey: groupPublicKey.Marshal(),
 		Misbehaved:     []byte{7, 10},
 	}
@@ -52,10 +52,7 @@ func TestDecideMemberFate_HappyPath(t *testing.T) {
 	operatingMemberIDs, err := decideMemberFate(
 		playerIndex,
 		gjkrResult,
-		dkgResultChannel,
-		startPublicationBlockHeight,
-		beaconChain,
-		blockCounter,
+		dkgResultEvent,
 	)
 	if err != nil {
 		t.Errorf(
@@ -79,7 +76,7 @@ func TestDecideMemberFate_NotSameGroupPublicKey(t *testing.T) {
 	setup()
 
 	otherGroupPublicKey := new(bn256.G2).ScalarBaseMult(big.NewInt(11))
-	dkgResultChannel <- &event.DKGResultSubmission{
+	dkgResultEvent := &event.DKGResultSubmission{
 		GroupPublicKey: otherGroupPublicKey.Marshal(),
 		Misbehaved:     []byte{},
 	}
@@ -87,10 +84,7 @@ func TestDecideMemberFate_NotSameGroupPublicKey(t *testing.T) {
 	_, err := decideMemberFate(
 		playerIndex,
 		gjkrResult,
-		dkgResultChannel,
-		startPublicationBlockHeight,
-		beaconChain,
-		blockCounter,
+		dkgResultEvent,
 	)
 
 	expectedError := fmt.Errorf(
@@ -110,7 +104,7 @@ func TestDecideMemberFate_NotSameGroupPublicKey(t *testing.T) {
 func TestDecideMemberFate_MemberIsMisbehaved(t *testing.T) {
 	setup()
 
-	dkgResultChannel <- &event.DKGResultSubmission{
+	dkgResultEvent := &event.DKGResultSubmission{
 		GroupPublicKey: groupPublicKey.Marshal(),
 		Misbehaved:     []byte{playerIndex},
 	}
@@ -118,10 +112,7 @@ func TestDecideMemberFate_MemberIsMisbehaved(t *testing.T) {
 	_, err := decideMemberFate(
 		playerIndex,
 		gjkrResult,
-		dkgResultChannel,
-		startPublicationBlockHeight,
-		beaconChain,
-		blockCounter,
+		dkgResultEvent,
 	)
 
 	expectedError := fmt.Errorf(
@@ -138,12 +129,37 @@ func TestDecideMemberFate_MemberIsMisbehaved(t *testing.T) {
 	}
 }
 
-func TestDecideMemberFate_Timeout(t *testing.T) {
+func TestWaitForDkgResultEvent(t *testing.T) {
 	setup()
 
-	_, err := decideMemberFate(
-		playerIndex,
-		gjkrResult,
+	expectedEvent := &event.DKGResultSubmission{
+		GroupPublicKey: groupPublicKey.Marshal(),
+	}
+	dkgResultChannel <- expectedEvent
+
+	dkgResultEvent, err := waitForDkgResultEvent(
+		dkgResultChannel,
+		startPublicationBlockHeight,
+		beaconChain,
+		blockCounter,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expectedEvent != dkgResultEvent {
+		t.Errorf(
+			"unexpected event\nexpected: %v\nactual:   %v\n",
+			expectedEvent,
+			dkgResultEvent,
+		)
+	}
+}
+
+func TestWaitForDkgResultEvent_Timeout(t *testing.T) {
+	setup()
+
+	_, err := waitForDkgResultEvent(
 		dkgResultChannel,
 		startPublicationBlockHeight,
 		beaconChain,
@@ -0,0 +1,260 @@
+package dkg
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/beacon/gjkr"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// DefaultEvidenceRetentionPeriod is the default period of time for which
+// the DKG evidence is kept in the storage.
+const DefaultEvidenceRetentionPeriod = 30 * 24 * time.Hour
+
+// evidenceDirPrefix is the prefix of the storage directories keeping the
+// evidence. Each DKG seed has its own directory.
+const evidenceDirPrefix = "evidence_"
+
+// EvidenceStore keeps the evidence recorded during DKG executions so that it
+// can be used to challenge a DKG result or to audit the resolution of
+// accusations once the DKG completes. The evidence is kept per DKG seed and
+// removed once it exceeds the retention period.
+type EvidenceStore struct {
+	// mutex is a single struct-wide lock that ensures all functions
+	// of the store are thread-safe.
+	mutex sync.Mutex
+
+	logger          log.StandardLogger
+	persistence     persistence.BasicHandle
+	retentionPeriod time.Duration
+}
+
+// NewEvidenceStore creates a new evidence store backed by the given
+// persistence handle.
+func NewEvidenceStore(
+	logger log.StandardLogger,
+	persistence persistence.BasicHandle,
+	retentionPeriod time.Duration,
+) *EvidenceStore {
+	return &EvidenceStore{
+		logger:          logger,
+		persistence:     persistence,
+		retentionPeriod: retentionPeriod,
+	}
+}
+
+// Save persists the given evidence and removes all the evidence exceeding
+// the retention period.
+func (es *EvidenceStore) Save(evidence *gjkr.Evidence) error {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	evidenceBytes, err := evidence.Marshal()
+	if err != nil {
+		return fmt.Errorf("could not marshal evidence: [%v]", err)
+	}
+
+	if err := es.persistence.Save(
+		evidenceBytes,
+		evidenceDirName(evidence.Seed),
+		evidenceFileName(evidence.MemberIndex),
+	); err != nil {
+		return fmt.Errorf("could not save evidence: [%w]", err)
+	}
+
+	es.prune(time.Now().Add(-es.retentionPeriod))
+
+	return nil
+}
+
+// Get returns the evidence recorded by the given member during the DKG with
+// the given seed.
+func (es *EvidenceStore) Get(
+	seed *big.Int,
+	memberIndex group.MemberIndex,
+) (*gjkr.Evidence, error) {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	for _, storedEvidence := range es.readAll() {
+		if storedEvidence.evidence.Seed.Cmp(seed) == 0 &&
+			storedEvidence.evidence.MemberIndex == memberIndex {
+			return storedEvidence.evidence, nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"no evidence recorded by member [%v] for DKG with seed [0x%x]",
+		memberIndex,
+		seed,
+	)
+}
+
+// List returns all the evidence kept in the store, ordered by the time it
+// was recorded at.
+func (es *EvidenceStore) List() []*gjkr.Evidence {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	storedEvidence := es.readAll()
+
+	evidence := make([]*gjkr.Evidence, len(storedEvidence))
+	for i, stored := range storedEvidence {
+		evidence[i] = stored.evidence
+	}
+
+	return evidence
+}
+
+// Export returns a self-contained bundle with the evidence recorded by the
+// given member during the DKG with the given seed. The bundle contains all
+// the recorded messages along with signatures and operator public keys of
+// their senders. The evidence is verified before it is exported.
+func (es *EvidenceStore) Export(
+	seed *big.Int,
+	memberIndex group.MemberIndex,
+) ([]byte, error) {
+	evidence, err := es.Get(seed, memberIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := evidence.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid evidence: [%v]", err)
+	}
+
+	return evidence.Marshal()
+}
+
+// ImportEvidence reads the evidence from the bundle produced by Export and
+// verifies it.
+func ImportEvidence(bundle []byte) (*gjkr.Evidence, error) {
+	evidence := &gjkr.Evidence{}
+	if err := evidence.Unmarshal(bundle); err != nil {
+		return nil, fmt.Errorf("could not unmarshal evidence: [%v]", err)
+	}
+
+	if err := evidence.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid evidence: [%v]", err)
+	}
+
+	return evidence, nil
+}
+
+// prune removes all the evidence recorded before the given time.
+func (es *EvidenceStore) prune(threshold time.Time) {
+	for _, storedEvidence := range es.readAll() {
+		if !storedEvidence.evidence.RecordedAt.Before(threshold) {
+			continue
+		}
+
+		es.logger.Infof(
+			"removing evidence recorded by member [%v] for DKG with "+
+				"seed [0x%x] at [%v]",
+			storedEvidence.evidence.MemberIndex,
+			storedEvidence.evidence.Seed,
+			storedEvidence.evidence.RecordedAt,
+		)
+
+		if err := es.persistence.Delete(
+			storedEvidence.directory,
+			storedEvidence.name,
+		); err != nil {
+			es.logger.Errorf(
+				"could not remove evidence [%s] from directory [%s]: [%v]",
+				storedEvidence.name,
+				storedEvidence.directory,
+				err,
+			)
+		}
+	}
+}
+
+type storedEvidence struct {
+	directory string
+	name      string
+	evidence  *gjkr.Evidence
+}
+
+func (es *EvidenceStore) readAll() []*storedEvidence {
+	allEvidence := make([]*storedEvidence, 0)
+
+	descriptorsChan, errorsChan := es.persistence.ReadAll()
+
+	// Both channels are unbuffered and we do not know in which order they
+	// are written so they are read concurrently.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range descriptorsChan {
+			if !strings.HasPrefix(descriptor.Directory(), evidenceDirPrefix) {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				es.logger.Errorf(
+					"could not read evidence from file [%s] in directory [%s]: [%v]",
+					descriptor.Name(),
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			evidence := &gjkr.Evidence{}
+			if err := evidence.Unmarshal(content); err != nil {
+				es.logger.Errorf(
+					"could not unmarshal evidence from file [%s] in directory [%s]: [%v]",
+					descriptor.Name(),
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			allEvidence = append(allEvidence, &storedEvidence{
+				directory: descriptor.Directory(),
+				name:      descriptor.Name(),
+				evidence:  evidence,
+			})
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChan {
+			es.logger.Errorf("could not load evidence from disk: [%v]", err)
+		}
+	}()
+
+	wg.Wait()
+
+	sort.SliceStable(allEvidence, func(i, j int) bool {
+		return allEvidence[i].evidence.RecordedAt.Before(
+			allEvidence[j].evidence.RecordedAt,
+		)
+	})
+
+	return allEvidence
+}
+
+func evidenceDirName(seed *big.Int) string {
+	return evidenceDirPrefix + seed.Text(16)
+}
+
+func evidenceFileName(memberIndex group.MemberIndex) string {
+	return fmt.Sprintf("member_%v", memberIndex)
+}
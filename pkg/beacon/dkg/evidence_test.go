@@ -0,0 +1,285 @@
+package dkg
+
+import (
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/keep-network/keep-core/pkg/beacon/gjkr"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+func TestEvidenceStore_SaveAndGet(t *testing.T) {
+	evidenceStore := NewEvidenceStore(
+		&testutils.MockLogger{},
+		newEvidencePersistenceMock(),
+		DefaultEvidenceRetentionPeriod,
+	)
+
+	evidence := newTestEvidence(big.NewInt(1), 2, time.Now())
+	if err := evidenceStore.Save(evidence); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := evidenceStore.Get(big.NewInt(1), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(evidence, stored) {
+		t.Errorf(
+			"unexpected evidence\nexpected: [%+v]\nactual:   [%+v]",
+			evidence,
+			stored,
+		)
+	}
+
+	if _, err := evidenceStore.Get(big.NewInt(1), 3); err == nil {
+		t.Errorf("expected error for evidence of another member")
+	}
+
+	if _, err := evidenceStore.Get(big.NewInt(2), 2); err == nil {
+		t.Errorf("expected error for evidence of another DKG")
+	}
+}
+
+func TestEvidenceStore_List(t *testing.T) {
+	evidenceStore := NewEvidenceStore(
+		&testutils.MockLogger{},
+		newEvidencePersistenceMock(),
+		DefaultEvidenceRetentionPeriod,
+	)
+
+	now := time.Now()
+	evidence1 := newTestEvidence(big.NewInt(1), 1, now.Add(-2*time.Hour))
+	evidence2 := newTestEvidence(big.NewInt(2), 1, now)
+	evidence3 := newTestEvidence(big.NewInt(1), 2, now.Add(-time.Hour))
+
+	for _, evidence := range []*gjkr.Evidence{evidence1, evidence2, evidence3} {
+		if err := evidenceStore.Save(evidence); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectedEvidence := []*gjkr.Evidence{evidence1, evidence3, evidence2}
+	actualEvidence := evidenceStore.List()
+	if !reflect.DeepEqual(expectedEvidence, actualEvidence) {
+		t.Errorf(
+			"unexpected evidence\nexpected: [%+v]\nactual:   [%+v]",
+			expectedEvidence,
+			actualEvidence,
+		)
+	}
+}
+
+func TestEvidenceStore_RetentionPeriod(t *testing.T) {
+	persistence := newEvidencePersistenceMock()
+	evidenceStore := NewEvidenceStore(
+		&testutils.MockLogger{},
+		persistence,
+		24*time.Hour,
+	)
+
+	now := time.Now()
+	expiredEvidence := newTestEvidence(big.NewInt(1), 1, now.Add(-25*time.Hour))
+	retainedEvidence := newTestEvidence(big.NewInt(2), 1, now.Add(-23*time.Hour))
+
+	for _, evidence := range []*gjkr.Evidence{expiredEvidence, retainedEvidence} {
+		if err := evidenceStore.Save(evidence); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectedEvidence := []*gjkr.Evidence{retainedEvidence}
+	actualEvidence := evidenceStore.List()
+	if !reflect.DeepEqual(expectedEvidence, actualEvidence) {
+		t.Errorf(
+			"unexpected evidence\nexpected: [%+v]\nactual:   [%+v]",
+			expectedEvidence,
+			actualEvidence,
+		)
+	}
+
+	if persistence.isPresent(
+		evidenceDirName(expiredEvidence.Seed),
+		evidenceFileName(expiredEvidence.MemberIndex),
+	) {
+		t.Errorf("expired evidence should be removed from persistence")
+	}
+}
+
+func TestEvidenceStore_ExportAndImport(t *testing.T) {
+	evidenceStore := NewEvidenceStore(
+		&testutils.MockLogger{},
+		newEvidencePersistenceMock(),
+		DefaultEvidenceRetentionPeriod,
+	)
+
+	evidence := newTestEvidence(big.NewInt(1), 2, time.Now())
+	if err := evidenceStore.Save(evidence); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, err := evidenceStore.Export(big.NewInt(1), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imported, err := ImportEvidence(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(evidence, imported) {
+		t.Errorf(
+			"unexpected evidence\nexpected: [%+v]\nactual:   [%+v]",
+			evidence,
+			imported,
+		)
+	}
+}
+
+func TestEvidenceStore_ExportInvalidEvidence(t *testing.T) {
+	evidenceStore := NewEvidenceStore(
+		&testutils.MockLogger{},
+		newEvidencePersistenceMock(),
+		DefaultEvidenceRetentionPeriod,
+	)
+
+	evidence := newTestEvidence(big.NewInt(1), 2, time.Now())
+	evidence.Messages = []*net.MessageSignature{
+		{
+			Channel:         "test-channel",
+			Type:            "test-type",
+			Payload:         []byte{0x01},
+			SenderPublicKey: []byte{0x02},
+			Signature:       []byte{0x03},
+		},
+	}
+	if err := evidenceStore.Save(evidence); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := evidenceStore.Export(big.NewInt(1), 2); err == nil {
+		t.Errorf("expected error for invalid evidence")
+	}
+}
+
+func TestImportEvidence_InvalidBundle(t *testing.T) {
+	if _, err := ImportEvidence([]byte{0xff, 0xff, 0xff}); err == nil {
+		t.Errorf("expected error for invalid bundle")
+	}
+}
+
+func newTestEvidence(
+	seed *big.Int,
+	memberIndex group.MemberIndex,
+	recordedAt time.Time,
+) *gjkr.Evidence {
+	return &gjkr.Evidence{
+		Seed:               seed,
+		MemberIndex:        memberIndex,
+		GroupSize:          5,
+		DishonestThreshold: 2,
+		// The evidence is persisted with a second precision.
+		RecordedAt: time.Unix(recordedAt.Unix(), 0),
+		Messages:   []*net.MessageSignature{},
+	}
+}
+
+type evidencePersistenceMock struct {
+	mutex sync.Mutex
+	// directory -> name -> content
+	files map[string]map[string][]byte
+}
+
+func newEvidencePersistenceMock() *evidencePersistenceMock {
+	return &evidencePersistenceMock{
+		files: make(map[string]map[string][]byte),
+	}
+}
+
+func (epm *evidencePersistenceMock) isPresent(directory, name string) bool {
+	epm.mutex.Lock()
+	defer epm.mutex.Unlock()
+
+	_, ok := epm.files[directory][name]
+	return ok
+}
+
+func (epm *evidencePersistenceMock) Save(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	epm.mutex.Lock()
+	defer epm.mutex.Unlock()
+
+	if _, ok := epm.files[directory]; !ok {
+		epm.files[directory] = make(map[string][]byte)
+	}
+	epm.files[directory][name] = data
+
+	return nil
+}
+
+func (epm *evidencePersistenceMock) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	epm.mutex.Lock()
+	defer epm.mutex.Unlock()
+
+	descriptors := make([]persistence.DataDescriptor, 0)
+	for directory, files := range epm.files {
+		for name, content := range files {
+			descriptors = append(
+				descriptors,
+				&testDataDescriptor{directory, name, content},
+			)
+		}
+	}
+
+	dataChan := make(chan persistence.DataDescriptor, len(descriptors))
+	errorChan := make(chan error)
+
+	for _, descriptor := range descriptors {
+		dataChan <- descriptor
+	}
+
+	close(dataChan)
+	close(errorChan)
+
+	return dataChan, errorChan
+}
+
+func (epm *evidencePersistenceMock) Delete(directory string, name string) error {
+	epm.mutex.Lock()
+	defer epm.mutex.Unlock()
+
+	delete(epm.files[directory], name)
+	return nil
+}
+
+type testDataDescriptor struct {
+	directory string
+	name      string
+	content   []byte
+}
+
+func (tdd *testDataDescriptor) Name() string {
+	return tdd.name
+}
+
+func (tdd *testDataDescriptor) Directory() string {
+	return tdd.directory
+}
+
+func (tdd *testDataDescriptor) Content() ([]byte, error) {
+	return tdd.content, nil
+}
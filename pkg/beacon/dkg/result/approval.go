@@ -0,0 +1,124 @@
+package result
+
+import (
+	"fmt"
+
+	"github.com/ipfs/go-log"
+
+	beaconchain "github.com/keep-network/keep-core/pkg/beacon/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/event"
+	"github.com/keep-network/keep-core/pkg/beacon/gjkr"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// ApproveOrChallenge decides on the DKG result submitted to the chain.
+//
+// If the submitted result is different from the result produced by the
+// member's GJKR execution, the member challenges the submitted result.
+// Otherwise, the member waits until it is eligible to approve the result and
+// approves it, unless the result was approved or challenged in the meantime.
+//
+// The member who submitted the result is eligible to approve it once the
+// challenge period is over. Other members become eligible once the submitter
+// precedence period is over, each following member after the result
+// publication block step.
+func ApproveOrChallenge(
+	logger log.StandardLogger,
+	memberIndex group.MemberIndex,
+	gjkrResult *gjkr.Result,
+	submission *event.DKGResultSubmission,
+	beaconChain beaconchain.Interface,
+	blockCounter chain.BlockCounter,
+) error {
+	return approveOrChallenge(
+		logger,
+		memberIndex,
+		convertGjkrResult(gjkrResult),
+		submission,
+		beaconChain,
+		blockCounter,
+	)
+}
+
+func approveOrChallenge(
+	logger log.StandardLogger,
+	memberIndex group.MemberIndex,
+	result *beaconchain.DKGResult,
+	submission *event.DKGResultSubmission,
+	beaconChain beaconchain.Interface,
+	blockCounter chain.BlockCounter,
+) error {
+	submittedResult := &beaconchain.DKGResult{
+		GroupPublicKey: submission.GroupPublicKey,
+		Misbehaved:     submission.Misbehaved,
+	}
+
+	if !result.Equals(submittedResult) {
+		logger.Warningf(
+			"[member:%v] challenging DKG result [0x%x] submitted by "+
+				"member [%v] at block [%v]; the result is different "+
+				"from the one produced by the member",
+			memberIndex,
+			submission.ResultHash,
+			submission.MemberIndex,
+			submission.BlockNumber,
+		)
+
+		if err := beaconChain.ChallengeDKGResult(
+			submission.ResultHash,
+		); err != nil {
+			return fmt.Errorf("could not challenge DKG result: [%v]", err)
+		}
+
+		return nil
+	}
+
+	config := beaconChain.GetConfig()
+
+	// The result can be approved in the first block after the challenge
+	// period.
+	approvalBlock := submission.BlockNumber +
+		config.DKGResultChallengePeriodLength + 1
+	if uint32(memberIndex) != submission.MemberIndex {
+		approvalBlock += config.DKGResultSubmitterPrecedencePeriodLength +
+			uint64(memberIndex-1)*config.ResultPublicationBlockStep
+	}
+
+	logger.Infof(
+		"[member:%v] waiting for block [%v] to approve DKG result [0x%x]",
+		memberIndex,
+		approvalBlock,
+		submission.ResultHash,
+	)
+
+	if err := blockCounter.WaitForBlockHeight(approvalBlock); err != nil {
+		return fmt.Errorf("could not wait for approval block: [%v]", err)
+	}
+
+	state, err := beaconChain.GetDKGState()
+	if err != nil {
+		return fmt.Errorf("could not check DKG state: [%v]", err)
+	}
+
+	if state != beaconchain.Challenge {
+		logger.Infof(
+			"[member:%v] DKG result [0x%x] does not await approval",
+			memberIndex,
+			submission.ResultHash,
+		)
+		return nil
+	}
+
+	if err := beaconChain.ApproveDKGResult(submission.ResultHash); err != nil {
+		return fmt.Errorf("could not approve DKG result: [%v]", err)
+	}
+
+	logger.Infof(
+		"[member:%v] approved DKG result [0x%x]",
+		memberIndex,
+		submission.ResultHash,
+	)
+
+	return nil
+}
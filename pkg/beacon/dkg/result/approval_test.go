@@ -0,0 +1,137 @@
+package result
+
+import (
+	"reflect"
+	"testing"
+
+	beaconchain "github.com/keep-network/keep-core/pkg/beacon/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/event"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+func TestApproveOrChallenge_ChallengesDifferentResult(t *testing.T) {
+	localChain, blockCounter, _, err := initChainHandle(3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	beaconChain := &challengeRecordingChain{Interface: localChain}
+
+	submission := submitResult(t, beaconChain, &beaconchain.DKGResult{
+		GroupPublicKey: []byte{201},
+	})
+
+	err = approveOrChallenge(
+		&testutils.MockLogger{},
+		group.MemberIndex(2),
+		&beaconchain.DKGResult{GroupPublicKey: []byte{202}},
+		submission,
+		beaconChain,
+		blockCounter,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedChallenges := [][32]byte{submission.ResultHash}
+	if !reflect.DeepEqual(expectedChallenges, beaconChain.challenges) {
+		t.Errorf(
+			"unexpected challenged results\nexpected: %x\nactual:   %x\n",
+			expectedChallenges,
+			beaconChain.challenges,
+		)
+	}
+}
+
+func TestApproveOrChallenge_DoesNotChallengeSameResult(t *testing.T) {
+	beaconChain, blockCounter, _, err := initChainHandle(3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := &beaconchain.DKGResult{
+		GroupPublicKey: []byte{101},
+		Misbehaved:     []byte{4},
+	}
+	submission := submitResult(t, beaconChain, result)
+
+	err = approveOrChallenge(
+		&testutils.MockLogger{},
+		group.MemberIndex(2),
+		result,
+		submission,
+		beaconChain,
+		blockCounter,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Member 2 is not the submitter so it has to wait for the end of the
+	// challenge and precedence periods plus its own block step.
+	config := beaconChain.GetConfig()
+	expectedBlock := submission.BlockNumber +
+		config.DKGResultChallengePeriodLength + 1 +
+		config.DKGResultSubmitterPrecedencePeriodLength +
+		config.ResultPublicationBlockStep
+	if currentBlock < expectedBlock {
+		t.Errorf(
+			"invalid current block\nexpected: >= %v\nactual:      %v\n",
+			expectedBlock,
+			currentBlock,
+		)
+	}
+
+	isRegistered, err := beaconChain.IsGroupRegistered(result.GroupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isRegistered {
+		t.Error("group should be registered")
+	}
+}
+
+func submitResult(
+	t *testing.T,
+	beaconChain beaconchain.Interface,
+	result *beaconchain.DKGResult,
+) *event.DKGResultSubmission {
+	submissionChan := make(chan *event.DKGResultSubmission, 1)
+	subscription := beaconChain.OnDKGResultSubmitted(
+		func(event *event.DKGResultSubmission) {
+			submissionChan <- event
+		},
+	)
+	defer subscription.Unsubscribe()
+
+	signatures := map[beaconchain.GroupMemberIndex][]byte{
+		1: {101},
+		2: {102},
+		3: {103},
+	}
+	if err := beaconChain.SubmitDKGResult(1, result, signatures); err != nil {
+		t.Fatal(err)
+	}
+
+	return <-submissionChan
+}
+
+// challengeRecordingChain records DKG result challenges instead of submitting
+// them to the underlying chain. The local chain treats all submitted results
+// as valid and rejects their challenges.
+type challengeRecordingChain struct {
+	beaconchain.Interface
+
+	challenges [][32]byte
+}
+
+func (crc *challengeRecordingChain) ChallengeDKGResult(
+	resultHash [32]byte,
+) error {
+	crc.challenges = append(crc.challenges, resultHash)
+	return nil
+}
@@ -105,3 +105,6 @@ func (msm *mockSignatureMessage) SenderPublicKey() []byte {
 func (msm *mockSignatureMessage) Seqno() uint64 {
 	panic("not implemented")
 }
+func (msm *mockSignatureMessage) Signature() *net.MessageSignature {
+	panic("not implemented")
+}
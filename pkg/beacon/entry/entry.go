@@ -37,6 +37,7 @@ func SignAndSubmit(
 	honestThreshold int,
 	signer *dkg.ThresholdSigner,
 	startBlockHeight uint64,
+	protocolVersion ProtocolVersion,
 ) error {
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	defer cancelCtx()
@@ -66,7 +67,14 @@ func SignAndSubmit(
 
 	selfShare := signer.CalculateSignatureShare(previousEntry)
 
-	go broadcastShare(ctx, logger, signer.MemberID(), selfShare, channel)
+	go broadcastShare(
+		ctx,
+		logger,
+		signer.MemberID(),
+		selfShare,
+		protocolVersion,
+		channel,
+	)
 
 	receiveChannel := make(chan net.Message, 64)
 	channel.Recv(ctx, func(netMessage net.Message) {
@@ -77,6 +85,11 @@ func SignAndSubmit(
 		signer.MemberID(): selfShare,
 	}
 
+	// Received shares are not validated one by one as every validation costs
+	// a pairing check. They are collected until there are enough of them to
+	// produce the signature and then validated in a batch.
+	pendingShares := make(map[group.MemberIndex]*bn256.G1)
+
 	// Run the message loop until the number of received and valid signature
 	// shares is equal to the honest threshold. Message loop will be also
 	// terminated if an other member submits the result or the relay entry
@@ -89,10 +102,9 @@ func SignAndSubmit(
 				continue
 			}
 
-			share, err := extractAndValidateShare(
-				message,
-				signer.GroupPublicKeyShares(),
-				previousEntry,
+			share, err := unmarshalShare(
+				message.shareBytes,
+				message.protocolVersion,
 			)
 			if err != nil {
 				logger.Warningf(
@@ -105,13 +117,39 @@ func SignAndSubmit(
 				continue
 			}
 
-			logger.Debugf(
-				"[member:%v] accepting signature share from member [%v]",
-				signer.MemberID(),
-				message.senderID,
+			pendingShares[message.senderID] = share
+
+			if len(receivedValidShares)+len(pendingShares) < honestThreshold {
+				continue
+			}
+
+			invalidShares := validateShares(
+				pendingShares,
+				signer.GroupPublicKeyShares(),
+				previousEntry,
 			)
 
-			receivedValidShares[message.senderID] = share
+			for senderID, share := range pendingShares {
+				if _, invalid := invalidShares[senderID]; invalid {
+					logger.Warningf(
+						"[member:%v] rejecting signature share from "+
+							"member [%v]: [invalid signature share]",
+						signer.MemberID(),
+						senderID,
+					)
+					continue
+				}
+
+				logger.Debugf(
+					"[member:%v] accepting signature share from member [%v]",
+					signer.MemberID(),
+					senderID,
+				)
+
+				receivedValidShares[senderID] = share
+			}
+
+			pendingShares = make(map[group.MemberIndex]*bn256.G1)
 		case blockNumber := <-relayEntrySubmittedChannel:
 			logger.Infof(
 				"[member:%v] leaving message loop; "+
@@ -160,11 +198,23 @@ func broadcastShare(
 	logger log.StandardLogger,
 	memberID group.MemberIndex,
 	share *bn256.G1,
+	protocolVersion ProtocolVersion,
 	channel net.BroadcastChannel,
 ) {
+	shareBytes, err := marshalShare(share, protocolVersion)
+	if err != nil {
+		logger.Errorf(
+			"[member:%v] could not marshal signature share: [%v]",
+			memberID,
+			err,
+		)
+		return
+	}
+
 	message := &SignatureShareMessage{
 		memberID,
-		share.Marshal(),
+		shareBytes,
+		protocolVersion,
 	}
 
 	if err := channel.Send(ctx, message); err != nil {
@@ -176,33 +226,41 @@ func broadcastShare(
 	}
 }
 
-func extractAndValidateShare(
-	message *SignatureShareMessage,
+// validateShares validates signature shares of the previous entry in a batch
+// and returns shares which are invalid or whose senders have no group public
+// key share.
+func validateShares(
+	shares map[group.MemberIndex]*bn256.G1,
 	groupPublicKeyShares map[group.MemberIndex]*bn256.G2,
 	previousEntry *bn256.G1,
-) (*bn256.G1, error) {
-	share := new(bn256.G1)
-	_, err := share.Unmarshal(message.shareBytes)
-	if err != nil {
-		return nil, fmt.Errorf(
-			"could not unmarshal signature share: [%v]",
-			err,
-		)
-	}
+) map[group.MemberIndex]*bn256.G1 {
+	publicKeyShares := make([]*bls.PublicKeyShare, 0, len(shares))
+	signatureShares := make([]*bls.SignatureShare, 0, len(shares))
 
-	publicKeyShare, ok := groupPublicKeyShares[message.senderID]
-	if !ok {
-		return nil, fmt.Errorf(
-			"could not validate signature share; " +
-				"group public key share for sender not found",
+	for memberID, share := range shares {
+		if publicKeyShare, ok := groupPublicKeyShares[memberID]; ok {
+			publicKeyShares = append(
+				publicKeyShares,
+				&bls.PublicKeyShare{I: int(memberID), V: publicKeyShare},
+			)
+		}
+
+		signatureShares = append(
+			signatureShares,
+			&bls.SignatureShare{I: int(memberID), V: share},
 		)
 	}
 
-	if !bls.VerifyG1(publicKeyShare, previousEntry, share) {
-		return nil, fmt.Errorf("invalid signature share")
+	invalidShares := make(map[group.MemberIndex]*bn256.G1)
+	for _, invalidShare := range bls.BatchVerifySignatureShares(
+		publicKeyShares,
+		previousEntry,
+		signatureShares,
+	) {
+		invalidShares[group.MemberIndex(invalidShare.I)] = invalidShare.V
 	}
 
-	return share, nil
+	return invalidShares
 }
 
 func completeSignature(
@@ -30,8 +30,9 @@ func (*SignatureShareMessage) Type() string {
 // network communication.
 func (ssm *SignatureShareMessage) Marshal() ([]byte, error) {
 	pbSignatureShare := pb.SignatureShare{
-		SenderID: uint32(ssm.senderID),
-		Share:    ssm.shareBytes,
+		SenderID:        uint32(ssm.senderID),
+		Share:           ssm.shareBytes,
+		ProtocolVersion: uint32(ssm.protocolVersion),
 	}
 
 	return proto.Marshal(&pbSignatureShare)
@@ -49,8 +50,19 @@ func (ssm *SignatureShareMessage) Unmarshal(bytes []byte) error {
 	if err := validateMemberIndex(pbSignatureShare.SenderID); err != nil {
 		return err
 	}
+
+	// Clients not aware of protocol versions do not set the version.
+	protocolVersion := ProtocolVersion(pbSignatureShare.ProtocolVersion)
+	if protocolVersion == 0 {
+		protocolVersion = ProtocolVersion1
+	}
+	if err := protocolVersion.Validate(); err != nil {
+		return err
+	}
+
 	ssm.senderID = group.MemberIndex(pbSignatureShare.SenderID)
 	ssm.shareBytes = pbSignatureShare.Share
+	ssm.protocolVersion = protocolVersion
 
 	return nil
 }
@@ -1,9 +1,14 @@
 package entry
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 
 	fuzz "github.com/google/gofuzz"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/keep-network/keep-core/pkg/beacon/entry/gen/pb"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
 
 	"github.com/keep-network/keep-core/pkg/internal/pbutils"
@@ -11,7 +16,7 @@ import (
 )
 
 func TestSignatureShareMessageRoundTrip(t *testing.T) {
-	msg := &SignatureShareMessage{123, make([]byte, 0)}
+	msg := &SignatureShareMessage{123, make([]byte, 0), ProtocolVersion2}
 	unmarshaled := &SignatureShareMessage{}
 
 	err := pbutils.RoundTrip(msg, unmarshaled)
@@ -28,6 +33,71 @@ func TestSignatureShareMessageRoundTrip(t *testing.T) {
 	}
 
 	testutils.AssertBytesEqual(t, msg.shareBytes, unmarshaled.shareBytes)
+
+	if msg.protocolVersion != unmarshaled.protocolVersion {
+		t.Errorf(
+			"unexpected protocol version\nexpected: [%v]\nactual:   [%v]",
+			msg.protocolVersion,
+			unmarshaled.protocolVersion,
+		)
+	}
+}
+
+func TestSignatureShareMessageUnmarshal_ProtocolVersion(t *testing.T) {
+	var tests = map[string]struct {
+		protocolVersion         uint32
+		expectedProtocolVersion ProtocolVersion
+		expectedError           error
+	}{
+		"version not set": {
+			protocolVersion:         0,
+			expectedProtocolVersion: ProtocolVersion1,
+		},
+		"version 1": {
+			protocolVersion:         1,
+			expectedProtocolVersion: ProtocolVersion1,
+		},
+		"version 2": {
+			protocolVersion:         2,
+			expectedProtocolVersion: ProtocolVersion2,
+		},
+		"unsupported version": {
+			protocolVersion: 3,
+			expectedError:   fmt.Errorf("unsupported protocol version [3]"),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			bytes, err := proto.Marshal(&pb.SignatureShare{
+				SenderID:        1,
+				Share:           []byte{1, 2, 3},
+				ProtocolVersion: test.protocolVersion,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			message := &SignatureShareMessage{}
+			err = message.Unmarshal(bytes)
+
+			if !reflect.DeepEqual(test.expectedError, err) {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			if test.expectedProtocolVersion != message.protocolVersion {
+				t.Errorf(
+					"unexpected protocol version\nexpected: [%v]\nactual:   [%v]",
+					test.expectedProtocolVersion,
+					message.protocolVersion,
+				)
+			}
+		})
+	}
 }
 
 func TestFuzzSignatureShareMessageRoundtrip(t *testing.T) {
@@ -43,8 +113,9 @@ func TestFuzzSignatureShareMessageRoundtrip(t *testing.T) {
 		f.Fuzz(&shareBytes)
 
 		message := &SignatureShareMessage{
-			senderID:   senderID,
-			shareBytes: shareBytes,
+			senderID:        senderID,
+			shareBytes:      shareBytes,
+			protocolVersion: ProtocolVersion2,
 		}
 
 		_ = pbutils.RoundTrip(message, &SignatureShareMessage{})
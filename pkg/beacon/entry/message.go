@@ -7,19 +7,27 @@ import (
 // SignatureShareMessage is a message payload that carries the sender's
 // signature share for the given message.
 type SignatureShareMessage struct {
-	senderID   group.MemberIndex
-	shareBytes []byte
+	senderID        group.MemberIndex
+	shareBytes      []byte
+	protocolVersion ProtocolVersion
 }
 
 // NewSignatureShareMessage creates new SignatureShareMessage.
 func NewSignatureShareMessage(
 	senderID group.MemberIndex,
 	shareBytes []byte,
+	protocolVersion ProtocolVersion,
 ) *SignatureShareMessage {
-	return &SignatureShareMessage{senderID, shareBytes}
+	return &SignatureShareMessage{senderID, shareBytes, protocolVersion}
 }
 
 // SenderID returns protocol-level identifier of the message sender.
 func (ssm *SignatureShareMessage) SenderID() group.MemberIndex {
 	return ssm.senderID
 }
+
+// ProtocolVersion returns the version of the protocol determining
+// the encoding of the signature share.
+func (ssm *SignatureShareMessage) ProtocolVersion() ProtocolVersion {
+	return ssm.protocolVersion
+}
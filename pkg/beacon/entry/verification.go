@@ -0,0 +1,40 @@
+package entry
+
+import (
+	"fmt"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+
+	"github.com/keep-network/keep-core/pkg/bls"
+)
+
+// Verify checks whether the relay entry is a valid BLS signature of
+// the previous entry made with the private key of the group with the given
+// public key. The group public key is expected to be a marshaled G2 point and
+// both entries are expected to be marshaled G1 points, as they are kept on
+// the chain.
+func Verify(groupPublicKey []byte, previousEntry []byte, entry []byte) error {
+	publicKey := new(bn256.G2)
+	if _, err := publicKey.Unmarshal(groupPublicKey); err != nil {
+		return fmt.Errorf("could not unmarshal group public key: [%v]", err)
+	}
+
+	message := new(bn256.G1)
+	if _, err := message.Unmarshal(previousEntry); err != nil {
+		return fmt.Errorf("could not unmarshal previous entry: [%v]", err)
+	}
+
+	signature := new(bn256.G1)
+	if _, err := signature.Unmarshal(entry); err != nil {
+		return fmt.Errorf("could not unmarshal entry: [%v]", err)
+	}
+
+	if !bls.VerifyG1(publicKey, message, signature) {
+		return fmt.Errorf(
+			"entry is not a signature of the previous entry " +
+				"made with the group private key",
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package entry
+
+import (
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+
+	"github.com/keep-network/keep-core/pkg/bls"
+)
+
+func TestVerify(t *testing.T) {
+	secretKey := big.NewInt(1410)
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(secretKey).Marshal()
+
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(1920))
+	entry := bls.SignG1(secretKey, previousEntry)
+
+	otherSecretKey := big.NewInt(1683)
+	otherGroupPublicKey := new(bn256.G2).ScalarBaseMult(otherSecretKey).Marshal()
+
+	var tests = map[string]struct {
+		groupPublicKey []byte
+		previousEntry  []byte
+		entry          []byte
+		expectedError  bool
+	}{
+		"valid entry": {
+			groupPublicKey: groupPublicKey,
+			previousEntry:  previousEntry.Marshal(),
+			entry:          entry.Marshal(),
+			expectedError:  false,
+		},
+		"entry signed by another group": {
+			groupPublicKey: otherGroupPublicKey,
+			previousEntry:  previousEntry.Marshal(),
+			entry:          entry.Marshal(),
+			expectedError:  true,
+		},
+		"entry of another previous entry": {
+			groupPublicKey: groupPublicKey,
+			previousEntry:  entry.Marshal(),
+			entry:          entry.Marshal(),
+			expectedError:  true,
+		},
+		"malformed entry": {
+			groupPublicKey: groupPublicKey,
+			previousEntry:  previousEntry.Marshal(),
+			entry:          big.NewInt(1).Bytes(),
+			expectedError:  true,
+		},
+		"malformed group public key": {
+			groupPublicKey: big.NewInt(1).Bytes(),
+			previousEntry:  previousEntry.Marshal(),
+			entry:          entry.Marshal(),
+			expectedError:  true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := Verify(test.groupPublicKey, test.previousEntry, test.entry)
+			if test.expectedError != (err != nil) {
+				t.Errorf(
+					"unexpected verification result\n"+
+						"expected error: [%v]\nactual error:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
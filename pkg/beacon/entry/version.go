@@ -0,0 +1,97 @@
+package entry
+
+import (
+	"fmt"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+
+	"github.com/keep-network/keep-core/pkg/bls"
+)
+
+// ProtocolVersion determines the encoding of signature shares exchanged by
+// members of the group during the relay entry signing protocol.
+type ProtocolVersion uint32
+
+const (
+	// ProtocolVersion1 transfers signature shares as uncompressed G1 points.
+	// Messages of clients not aware of protocol versions are considered to be
+	// version 1 messages.
+	ProtocolVersion1 ProtocolVersion = 1
+	// ProtocolVersion2 transfers signature shares as compressed G1 points,
+	// half the size of the uncompressed ones.
+	ProtocolVersion2 ProtocolVersion = 2
+
+	// DefaultProtocolVersion is the protocol version used by default.
+	// Signature shares of all the supported versions are accepted no matter
+	// the version used by the client to send shares so the version can be
+	// bumped once all the clients of the network support the new version.
+	DefaultProtocolVersion = ProtocolVersion1
+)
+
+// Validate checks whether the protocol version is supported.
+func (pv ProtocolVersion) Validate() error {
+	switch pv {
+	case ProtocolVersion1, ProtocolVersion2:
+		return nil
+	default:
+		return fmt.Errorf("unsupported protocol version [%v]", pv)
+	}
+}
+
+// marshalShare encodes the signature share value according to the protocol
+// version.
+func marshalShare(
+	share *bn256.G1,
+	protocolVersion ProtocolVersion,
+) ([]byte, error) {
+	signatureShare := &bls.SignatureShare{V: share}
+
+	switch protocolVersion {
+	case ProtocolVersion1:
+		return signatureShare.MarshalUncompressed()
+	case ProtocolVersion2:
+		return signatureShare.Marshal()
+	default:
+		return nil, fmt.Errorf(
+			"unsupported protocol version [%v]",
+			protocolVersion,
+		)
+	}
+}
+
+// unmarshalShare decodes the signature share value according to the protocol
+// version.
+func unmarshalShare(
+	shareBytes []byte,
+	protocolVersion ProtocolVersion,
+) (*bn256.G1, error) {
+	var expectedLength int
+	switch protocolVersion {
+	case ProtocolVersion1:
+		expectedLength = bls.G1UncompressedSize
+	case ProtocolVersion2:
+		expectedLength = bls.G1CompressedSize
+	default:
+		return nil, fmt.Errorf(
+			"unsupported protocol version [%v]",
+			protocolVersion,
+		)
+	}
+
+	if len(shareBytes) != expectedLength {
+		return nil, fmt.Errorf(
+			"invalid length of signature share for protocol version [%v]; "+
+				"expected [%v] bytes, has [%v] bytes",
+			protocolVersion,
+			expectedLength,
+			len(shareBytes),
+		)
+	}
+
+	signatureShare := &bls.SignatureShare{}
+	if err := signatureShare.Unmarshal(shareBytes); err != nil {
+		return nil, err
+	}
+
+	return signatureShare.V, nil
+}
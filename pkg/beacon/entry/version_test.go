@@ -0,0 +1,84 @@
+package entry
+
+import (
+	"crypto/rand"
+	"fmt"
+	"reflect"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestShareRoundtrip(t *testing.T) {
+	_, share, err := bn256.RandomG1(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		protocolVersion ProtocolVersion
+		expectedLength  int
+	}{
+		"version 1": {
+			protocolVersion: ProtocolVersion1,
+			expectedLength:  bls.G1UncompressedSize,
+		},
+		"version 2": {
+			protocolVersion: ProtocolVersion2,
+			expectedLength:  bls.G1CompressedSize,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			shareBytes, err := marshalShare(share, test.protocolVersion)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectedLength != len(shareBytes) {
+				t.Errorf(
+					"unexpected share length\nexpected: [%v]\nactual:   [%v]",
+					test.expectedLength,
+					len(shareBytes),
+				)
+			}
+
+			unmarshaled, err := unmarshalShare(shareBytes, test.protocolVersion)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBytesEqual(t, share.Marshal(), unmarshaled.Marshal())
+		})
+	}
+}
+
+func TestUnmarshalShare_VersionMismatch(t *testing.T) {
+	_, share, err := bn256.RandomG1(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shareBytes, err := marshalShare(share, ProtocolVersion1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = unmarshalShare(shareBytes, ProtocolVersion2)
+
+	expectedError := fmt.Errorf(
+		"invalid length of signature share for protocol version [2]; " +
+			"expected [32] bytes, has [64] bytes",
+	)
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
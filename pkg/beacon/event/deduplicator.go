@@ -16,7 +16,7 @@ const (
 )
 
 // Local chain interface to avoid import cycles.
-type chain interface {
+type deduplicatorChain interface {
 	CurrentRequestStartBlock() (*big.Int, error)
 	CurrentRequestPreviousEntry() ([]byte, error)
 }
@@ -35,7 +35,7 @@ type chain interface {
 // - DKG started
 // - relay entry requested
 type Deduplicator struct {
-	chain chain
+	chain deduplicatorChain
 
 	dkgSeedCache *cache.TimeCache
 
@@ -45,7 +45,7 @@ type Deduplicator struct {
 }
 
 // NewDeduplicator constructs a new Deduplicator instance.
-func NewDeduplicator(chain chain) *Deduplicator {
+func NewDeduplicator(chain deduplicatorChain) *Deduplicator {
 	return &Deduplicator{
 		chain:        chain,
 		dkgSeedCache: cache.NewTimeCache(DKGSeedCachePeriod),
@@ -5,22 +5,28 @@ package event
 
 import (
 	"math/big"
+
+	"github.com/keep-network/keep-core/pkg/chain"
 )
 
 // RelayEntrySubmitted indicates that valid relay entry has been submitted to
 // the chain for the currently processed relay request. This event is intended
 // to be used by operators for tracking entry generation and submission progress.
-// TODO: Adjust to the v2 RandomBeacon contract.
 type RelayEntrySubmitted struct {
+	RequestID *big.Int
+	Entry     []byte
+	Submitter chain.Address
+
 	BlockNumber uint64
 }
 
 // RelayEntryRequested represents a request for an entry in the threshold relay.
-// TODO: Adjust to the v2 RandomBeacon contract.
 type RelayEntryRequested struct {
+	RequestID      *big.Int
 	PreviousEntry  []byte
 	GroupPublicKey []byte
-	BlockNumber    uint64
+
+	BlockNumber uint64
 }
 
 // DKGStarted represents a DKG start event.
@@ -31,7 +37,7 @@ type DKGStarted struct {
 
 // GroupRegistration represents an event of registering a new group with the
 // given public key.
-// TODO: Adjust to the v2 RandomBeacon contract and rename to GroupRegistered.
+// TODO: Rename to GroupRegistered.
 type GroupRegistration struct {
 	GroupPublicKey []byte
 
@@ -39,14 +45,34 @@ type GroupRegistration struct {
 }
 
 // DKGResultSubmission represents a DKG result submission event. It is emitted
-// after a submitted DKG result is positively validated on the chain. It contains
-// the index of the member who submitted the result and a final public key of
-// the group.
-// TODO: Adjust to the v2 RandomBeacon contract and rename to DKGResultSubmitted.
+// after a DKG result is submitted to the chain. It contains the index of
+// the member who submitted the result and a final public key of the group.
+// The result hash identifies the submitted result in the challenge and
+// approval of the result.
+// TODO: Rename to DKGResultSubmitted.
 type DKGResultSubmission struct {
+	ResultHash     [32]byte
+	Seed           *big.Int
 	MemberIndex    uint32
 	GroupPublicKey []byte
 	Misbehaved     []uint8
 
 	BlockNumber uint64
 }
+
+// DKGResultChallenged represents a successful challenge of the submitted DKG
+// result. The challenged result is discarded and a new result can be submitted.
+type DKGResultChallenged struct {
+	ResultHash [32]byte
+	Reason     string
+
+	BlockNumber uint64
+}
+
+// DKGResultApproved represents an approval of the submitted DKG result. Once
+// the result is approved, the group is registered on the chain.
+type DKGResultApproved struct {
+	ResultHash [32]byte
+
+	BlockNumber uint64
+}
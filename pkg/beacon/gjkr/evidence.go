@@ -0,0 +1,358 @@
+package gjkr
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// evidenceMessageTypes are the types of messages kept as evidence, in the
+// order of the protocol phases they are exchanged in.
+var evidenceMessageTypes = []string{
+	(&EphemeralPublicKeyMessage{}).Type(),
+	(&PeerSharesMessage{}).Type(),
+	(&MemberCommitmentsMessage{}).Type(),
+	(&SecretSharesAccusationsMessage{}).Type(),
+}
+
+// evidenceObserverIndex is the member index used to resolve accusations from
+// the evidence. It does not belong to any group member so the resolution is
+// performed from the point of view of an outside observer.
+const evidenceObserverIndex = group.MemberIndex(0)
+
+// Evidence is a self-contained record of messages exchanged in the protocol
+// phases relevant for the resolution of secret shares accusations: ephemeral
+// public keys (phase 1), peer shares and commitments (phase 3), and secret
+// shares accusations (phase 4). Each message is kept along with the operator
+// public key and signature of its sender so the evidence can be verified and
+// the accusations can be resolved again offline.
+type Evidence struct {
+	// Seed is the seed of the DKG the evidence was recorded for.
+	Seed *big.Int
+	// MemberIndex is the index of the member who recorded the evidence.
+	MemberIndex group.MemberIndex
+	// GroupSize is the size of the group generating the key.
+	GroupSize int
+	// DishonestThreshold is the maximum number of misbehaving members for
+	// which the protocol is still able to generate the key.
+	DishonestThreshold int
+	// RecordedAt is the time at which the evidence was recorded.
+	RecordedAt time.Time
+	// Messages are signed messages ordered by the protocol phase and sender.
+	Messages []*net.MessageSignature
+}
+
+// AccusationsResolution is the outcome of secret shares accusations
+// resolution performed based on the evidence.
+type AccusationsResolution struct {
+	InactiveMembers     []group.MemberIndex
+	DisqualifiedMembers []group.MemberIndex
+}
+
+// evidencePayload is a protocol message kept as evidence.
+type evidencePayload interface {
+	net.TaggedUnmarshaler
+	SenderID() group.MemberIndex
+}
+
+// evidenceMessages are the protocol messages unmarshaled from the evidence.
+type evidenceMessages struct {
+	ephemeralPublicKeyMessages []*EphemeralPublicKeyMessage
+	peerSharesMessages         []*PeerSharesMessage
+	commitmentsMessages        []*MemberCommitmentsMessage
+	accusationsMessages        []*SecretSharesAccusationsMessage
+
+	senderPublicKeys map[group.MemberIndex][]byte
+}
+
+// Verify checks whether the evidence is consistent. It verifies signatures of
+// all messages and makes sure all of them were sent to the same broadcast
+// channel by group members, that each member sent at most one message of each
+// type, and that all messages of the given member were signed with the same
+// operator key.
+//
+// Verify does not check whether the operator keys belong to the operators
+// selected to the group. SenderPublicKeys can be used to compare them with
+// the group selection result.
+func (e *Evidence) Verify() error {
+	_, err := e.unmarshalMessages()
+	return err
+}
+
+// SenderPublicKeys returns operator public keys used by group members to sign
+// the messages kept in the evidence.
+func (e *Evidence) SenderPublicKeys() (map[group.MemberIndex][]byte, error) {
+	messages, err := e.unmarshalMessages()
+	if err != nil {
+		return nil, err
+	}
+
+	return messages.senderPublicKeys, nil
+}
+
+func (e *Evidence) unmarshalMessages() (*evidenceMessages, error) {
+	if e.GroupSize <= 0 || e.GroupSize > group.MaxMemberIndex {
+		return nil, fmt.Errorf("invalid group size [%v]", e.GroupSize)
+	}
+
+	messages := &evidenceMessages{
+		senderPublicKeys: make(map[group.MemberIndex][]byte),
+	}
+
+	// message type -> senders
+	senders := make(map[string]map[group.MemberIndex]bool)
+
+	for i, signature := range e.Messages {
+		if signature.Channel != e.Messages[0].Channel {
+			return nil, fmt.Errorf(
+				"message [%v] was sent to channel [%v] instead of [%v]",
+				i,
+				signature.Channel,
+				e.Messages[0].Channel,
+			)
+		}
+
+		if err := signature.Verify(); err != nil {
+			return nil, fmt.Errorf(
+				"could not verify signature of message [%v]: [%v]",
+				i,
+				err,
+			)
+		}
+
+		payload, err := unmarshalEvidencePayload(
+			signature.Type,
+			signature.Payload,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not unmarshal message [%v]: [%v]",
+				i,
+				err,
+			)
+		}
+
+		senderID := payload.SenderID()
+		if senderID < 1 || int(senderID) > e.GroupSize {
+			return nil, fmt.Errorf(
+				"message [%v] was sent by invalid member [%v]",
+				i,
+				senderID,
+			)
+		}
+
+		if senders[signature.Type] == nil {
+			senders[signature.Type] = make(map[group.MemberIndex]bool)
+		}
+		if senders[signature.Type][senderID] {
+			return nil, fmt.Errorf(
+				"member [%v] sent more than one message of type [%v]",
+				senderID,
+				signature.Type,
+			)
+		}
+		senders[signature.Type][senderID] = true
+
+		if publicKey, ok := messages.senderPublicKeys[senderID]; ok {
+			if !bytes.Equal(publicKey, signature.SenderPublicKey) {
+				return nil, fmt.Errorf(
+					"member [%v] signed messages with different keys",
+					senderID,
+				)
+			}
+		} else {
+			messages.senderPublicKeys[senderID] = signature.SenderPublicKey
+		}
+
+		switch message := payload.(type) {
+		case *EphemeralPublicKeyMessage:
+			messages.ephemeralPublicKeyMessages = append(
+				messages.ephemeralPublicKeyMessages,
+				message,
+			)
+		case *PeerSharesMessage:
+			messages.peerSharesMessages = append(
+				messages.peerSharesMessages,
+				message,
+			)
+		case *MemberCommitmentsMessage:
+			messages.commitmentsMessages = append(
+				messages.commitmentsMessages,
+				message,
+			)
+		case *SecretSharesAccusationsMessage:
+			messages.accusationsMessages = append(
+				messages.accusationsMessages,
+				message,
+			)
+		}
+	}
+
+	return messages, nil
+}
+
+func unmarshalEvidencePayload(
+	messageType string,
+	bytes []byte,
+) (evidencePayload, error) {
+	var payload evidencePayload
+	switch messageType {
+	case (&EphemeralPublicKeyMessage{}).Type():
+		payload = &EphemeralPublicKeyMessage{}
+	case (&PeerSharesMessage{}).Type():
+		payload = &PeerSharesMessage{}
+	case (&MemberCommitmentsMessage{}).Type():
+		payload = &MemberCommitmentsMessage{}
+	case (&SecretSharesAccusationsMessage{}).Type():
+		payload = &SecretSharesAccusationsMessage{}
+	default:
+		return nil, fmt.Errorf(
+			"message type [%v] is not kept as evidence",
+			messageType,
+		)
+	}
+
+	if err := payload.Unmarshal(bytes); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// ResolveSecretSharesAccusations verifies the evidence and re-executes the
+// protocol phases up to the resolution of secret shares accusations (phase 5)
+// based on the messages kept in the evidence. It returns members marked as
+// inactive or disqualified once the accusations are resolved.
+//
+// The resolution is performed from the point of view of an outside observer.
+// Unlike group members, the observer can not decrypt shares so members who
+// sent invalid shares are disqualified only if they were accused by the share
+// receiver and the accusation has been confirmed.
+func (e *Evidence) ResolveSecretSharesAccusations(
+	logger log.StandardLogger,
+) (*AccusationsResolution, error) {
+	messages, err := e.unmarshalMessages()
+	if err != nil {
+		return nil, fmt.Errorf("invalid evidence: [%v]", err)
+	}
+
+	member, err := NewMember(
+		logger,
+		evidenceObserverIndex,
+		e.GroupSize,
+		e.DishonestThreshold,
+		nil,
+		e.Seed,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a new member: [%v]", err)
+	}
+
+	// Phases 1 and 2.
+	symmetricKeyGeneratingMember := member.
+		InitializeEphemeralKeysGeneration().
+		InitializeSymmetricKeyGeneration()
+
+	var ephemeralPublicKeyMessages []*EphemeralPublicKeyMessage
+	for _, message := range messages.ephemeralPublicKeyMessages {
+		if member.group.IsOperating(message.senderID) {
+			ephemeralPublicKeyMessages = append(
+				ephemeralPublicKeyMessages,
+				message,
+			)
+		}
+	}
+
+	symmetricKeyGeneratingMember.MarkInactiveMembers(ephemeralPublicKeyMessages)
+	for _, message := range ephemeralPublicKeyMessages {
+		if !symmetricKeyGeneratingMember.isValidEphemeralPublicKeyMessage(
+			message,
+		) {
+			member.group.MarkMemberAsDisqualified(message.senderID)
+			continue
+		}
+
+		if err := member.evidenceLog.PutEphemeralMessage(message); err != nil {
+			return nil, err
+		}
+	}
+
+	// Phases 3 and 4.
+	commitmentsVerifyingMember := symmetricKeyGeneratingMember.
+		InitializeCommitting().
+		InitializeCommitmentsVerification()
+
+	var peerSharesMessages []*PeerSharesMessage
+	for _, message := range messages.peerSharesMessages {
+		if member.group.IsOperating(message.senderID) {
+			peerSharesMessages = append(peerSharesMessages, message)
+		}
+	}
+
+	var commitmentsMessages []*MemberCommitmentsMessage
+	for _, message := range messages.commitmentsMessages {
+		if member.group.IsOperating(message.senderID) {
+			commitmentsMessages = append(commitmentsMessages, message)
+		}
+	}
+
+	commitmentsVerifyingMember.MarkInactiveMembers(
+		peerSharesMessages,
+		commitmentsMessages,
+	)
+	for _, message := range peerSharesMessages {
+		if err := member.evidenceLog.PutPeerSharesMessage(message); err != nil {
+			return nil, err
+		}
+	}
+	for _, commitmentsMessage := range commitmentsMessages {
+		if !commitmentsVerifyingMember.isValidMemberCommitmentsMessage(
+			commitmentsMessage,
+		) {
+			member.group.MarkMemberAsDisqualified(commitmentsMessage.senderID)
+			continue
+		}
+
+		commitmentsVerifyingMember.receivedPeerCommitments[commitmentsMessage.senderID] =
+			commitmentsMessage.commitments
+
+		for _, sharesMessage := range peerSharesMessages {
+			if sharesMessage.senderID == commitmentsMessage.senderID &&
+				!commitmentsVerifyingMember.isValidPeerSharesMessage(
+					sharesMessage,
+				) {
+				member.group.MarkMemberAsDisqualified(sharesMessage.senderID)
+			}
+		}
+	}
+
+	// Phase 5.
+	sharesJustifyingMember := commitmentsVerifyingMember.
+		InitializeSharesJustification()
+
+	var accusationsMessages []*SecretSharesAccusationsMessage
+	for _, message := range messages.accusationsMessages {
+		if member.group.IsOperating(message.senderID) {
+			accusationsMessages = append(accusationsMessages, message)
+		}
+	}
+
+	sharesJustifyingMember.MarkInactiveMembers(accusationsMessages)
+	err = sharesJustifyingMember.ResolveSecretSharesAccusationsMessages(
+		accusationsMessages,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve accusations: [%v]", err)
+	}
+
+	return &AccusationsResolution{
+		InactiveMembers:     member.group.InactiveMemberIDs(),
+		DisqualifiedMembers: member.group.DisqualifiedMemberIDs(),
+	}, nil
+}
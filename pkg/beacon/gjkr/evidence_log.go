@@ -2,8 +2,10 @@ package gjkr
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
+	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
 )
 
@@ -29,6 +31,10 @@ import (
 // sent by the accused party. To do this, they read the round 3 message from the
 // log, and decrypt it using the symmetric key used between the accuser and
 // accused party. The key is publicly revealed by the accuser.
+//
+// Apart from that, the evidence log keeps the signed network messages exchanged
+// in the phases relevant for the accusations resolution so that the resolution
+// can be verified and re-executed offline, after the protocol completes.
 type evidenceLog interface {
 	// ephemeralPublicKeyMessage returns the `EphemeralPublicKeyMessage`
 	// broadcast in the first protocol round by the given sender.
@@ -49,6 +55,19 @@ type evidenceLog interface {
 	// accusation trials for a given (sender, receiver) pair. If a message
 	// already exists for the given sender, we return an error to the user.
 	PutPeerSharesMessage(sharesMessage *PeerSharesMessage) error
+
+	// putSignedMessage stores the signed network message sent by the given
+	// sender. Only the first message of the given type is stored for the
+	// given sender. If a message of the same type already exists for the
+	// sender, we return an error to the user.
+	putSignedMessage(
+		sender group.MemberIndex,
+		signature *net.MessageSignature,
+	) error
+
+	// signedMessages returns all signed network messages stored in the log
+	// ordered by the protocol phase and the sender.
+	signedMessages() []*net.MessageSignature
 }
 
 // dkgEvidenceLog is an implementation of an evidenceLog.
@@ -58,14 +77,23 @@ type dkgEvidenceLog struct {
 
 	// senderID -> *PeerSharesMessage
 	peerSharesMessageLog *messageStorage
+
+	// message type -> senderID -> *net.MessageSignature
+	signedMessageLogs map[string]*messageStorage
 }
 
 // NewDkgEvidenceLog returns a dkgEvidenceLog with backing stores for future
 // accusations against EphemeralPublicKeyMessages and PeerShareMessages.
 func newDkgEvidenceLog() *dkgEvidenceLog {
+	signedMessageLogs := make(map[string]*messageStorage)
+	for _, messageType := range evidenceMessageTypes {
+		signedMessageLogs[messageType] = newMessageStorage()
+	}
+
 	return &dkgEvidenceLog{
 		pubKeyMessageLog:     newMessageStorage(),
 		peerSharesMessageLog: newMessageStorage(),
+		signedMessageLogs:    signedMessageLogs,
 	}
 }
 
@@ -109,6 +137,46 @@ func (d *dkgEvidenceLog) peerSharesMessage(
 	return nil
 }
 
+func (d *dkgEvidenceLog) putSignedMessage(
+	sender group.MemberIndex,
+	signature *net.MessageSignature,
+) error {
+	signedMessageLog, ok := d.signedMessageLogs[signature.Type]
+	if !ok {
+		return fmt.Errorf(
+			"message type [%v] is not kept as evidence",
+			signature.Type,
+		)
+	}
+
+	return signedMessageLog.putMessage(sender, signature)
+}
+
+func (d *dkgEvidenceLog) signedMessages() []*net.MessageSignature {
+	signatures := make([]*net.MessageSignature, 0)
+
+	for _, messageType := range evidenceMessageTypes {
+		messages := d.signedMessageLogs[messageType].getAllMessages()
+
+		senders := make([]group.MemberIndex, 0, len(messages))
+		for sender := range messages {
+			senders = append(senders, sender)
+		}
+		sort.Slice(senders, func(i, j int) bool {
+			return senders[i] < senders[j]
+		})
+
+		for _, sender := range senders {
+			signature, ok := messages[sender].(*net.MessageSignature)
+			if ok {
+				signatures = append(signatures, signature)
+			}
+		}
+	}
+
+	return signatures
+}
+
 // messageStorage is the underlying cache used by our evidenceLog implementation
 // it implements a generic get and put of messages through a mapping of a
 // sender.
@@ -135,6 +203,18 @@ func (ms *messageStorage) getMessage(sender group.MemberIndex) interface{} {
 	return message
 }
 
+func (ms *messageStorage) getAllMessages() map[group.MemberIndex]interface{} {
+	ms.cacheLock.Lock()
+	defer ms.cacheLock.Unlock()
+
+	messages := make(map[group.MemberIndex]interface{}, len(ms.cache))
+	for sender, message := range ms.cache {
+		messages[sender] = message
+	}
+
+	return messages
+}
+
 func (ms *messageStorage) putMessage(
 	sender group.MemberIndex, message interface{},
 ) error {
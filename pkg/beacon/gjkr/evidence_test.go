@@ -0,0 +1,229 @@
+package gjkr
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+
+	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+const evidenceTestChannel = "test-channel"
+
+func TestEvidenceRoundtrip(t *testing.T) {
+	evidence := newTestEvidence(t)
+
+	marshaled, err := evidence.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unmarshaled := &Evidence{}
+	if err := unmarshaled.Unmarshal(marshaled); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(evidence, unmarshaled) {
+		t.Fatalf(
+			"unexpected content of unmarshaled evidence\n"+
+				"expected: [%+v]\nactual:   [%+v]",
+			evidence,
+			unmarshaled,
+		)
+	}
+}
+
+func TestEvidenceVerify(t *testing.T) {
+	var tests = map[string]struct {
+		modifyEvidence func(t *testing.T, evidence *Evidence)
+		expectedError  bool
+	}{
+		"valid evidence": {
+			modifyEvidence: func(t *testing.T, evidence *Evidence) {},
+			expectedError:  false,
+		},
+		"tampered payload": {
+			modifyEvidence: func(t *testing.T, evidence *Evidence) {
+				payload := append([]byte{}, evidence.Messages[0].Payload...)
+				payload[len(payload)-1] ^= 0xff
+				evidence.Messages[0].Payload = payload
+			},
+			expectedError: true,
+		},
+		"duplicate message": {
+			modifyEvidence: func(t *testing.T, evidence *Evidence) {
+				evidence.Messages = append(
+					evidence.Messages,
+					evidence.Messages[0],
+				)
+			},
+			expectedError: true,
+		},
+		"messages of one member signed with different keys": {
+			modifyEvidence: func(t *testing.T, evidence *Evidence) {
+				evidence.Messages[1] = signEvidencePayload(
+					t,
+					newTestOperatorKey(t),
+					evidenceTestChannel,
+					&SecretSharesAccusationsMessage{
+						senderID:           1,
+						accusedMembersKeys: map[group.MemberIndex]*ephemeral.PrivateKey{},
+					},
+				)
+			},
+			expectedError: true,
+		},
+		"message sent to a different channel": {
+			modifyEvidence: func(t *testing.T, evidence *Evidence) {
+				evidence.Messages[2] = signEvidencePayload(
+					t,
+					newTestOperatorKey(t),
+					"other-channel",
+					&EphemeralPublicKeyMessage{
+						senderID:            2,
+						ephemeralPublicKeys: map[group.MemberIndex]*ephemeral.PublicKey{},
+					},
+				)
+			},
+			expectedError: true,
+		},
+		"message sent by a member outside the group": {
+			modifyEvidence: func(t *testing.T, evidence *Evidence) {
+				evidence.GroupSize = 1
+			},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			evidence := newTestEvidence(t)
+			test.modifyEvidence(t, evidence)
+
+			err := evidence.Verify()
+			if test.expectedError != (err != nil) {
+				t.Errorf(
+					"unexpected verification result\n"+
+						"expected error: [%v]\nactual error:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestEvidenceSenderPublicKeys(t *testing.T) {
+	evidence := newTestEvidence(t)
+
+	senderPublicKeys, err := evidence.SenderPublicKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSenderPublicKeys := map[group.MemberIndex][]byte{
+		1: evidence.Messages[0].SenderPublicKey,
+		2: evidence.Messages[2].SenderPublicKey,
+	}
+	if !reflect.DeepEqual(expectedSenderPublicKeys, senderPublicKeys) {
+		t.Errorf(
+			"unexpected sender public keys\nexpected: [%v]\nactual:   [%v]",
+			expectedSenderPublicKeys,
+			senderPublicKeys,
+		)
+	}
+}
+
+func newTestEvidence(t *testing.T) *Evidence {
+	keyPair, err := ephemeral.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operator1 := newTestOperatorKey(t)
+	operator2 := newTestOperatorKey(t)
+
+	return &Evidence{
+		Seed:               big.NewInt(1410),
+		MemberIndex:        1,
+		GroupSize:          3,
+		DishonestThreshold: 1,
+		RecordedAt:         time.Unix(1667000000, 0),
+		Messages: []*net.MessageSignature{
+			signEvidencePayload(
+				t,
+				operator1,
+				evidenceTestChannel,
+				&EphemeralPublicKeyMessage{
+					senderID: 1,
+					ephemeralPublicKeys: map[group.MemberIndex]*ephemeral.PublicKey{
+						2: keyPair.PublicKey,
+					},
+				},
+			),
+			signEvidencePayload(
+				t,
+				operator1,
+				evidenceTestChannel,
+				&SecretSharesAccusationsMessage{
+					senderID: 1,
+					accusedMembersKeys: map[group.MemberIndex]*ephemeral.PrivateKey{
+						2: keyPair.PrivateKey,
+					},
+				},
+			),
+			signEvidencePayload(
+				t,
+				operator2,
+				evidenceTestChannel,
+				&EphemeralPublicKeyMessage{
+					senderID: 2,
+					ephemeralPublicKeys: map[group.MemberIndex]*ephemeral.PublicKey{
+						1: keyPair.PublicKey,
+					},
+				},
+			),
+		},
+	}
+}
+
+func newTestOperatorKey(t *testing.T) *btcec.PrivateKey {
+	privateKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return privateKey
+}
+
+func signEvidencePayload(
+	t *testing.T,
+	privateKey *btcec.PrivateKey,
+	channel string,
+	payload net.TaggedMarshaler,
+) *net.MessageSignature {
+	payloadBytes, err := payload.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messageSignature := &net.MessageSignature{
+		Channel:         channel,
+		Type:            payload.Type(),
+		Seqno:           1,
+		Payload:         payloadBytes,
+		SenderPublicKey: privateKey.PubKey().SerializeUncompressed(),
+	}
+
+	hash := sha256.Sum256(messageSignature.SignedBytes())
+	messageSignature.Signature = ecdsa.Sign(privateKey, hash[:]).Serialize()
+
+	return messageSignature
+}
@@ -405,6 +405,93 @@ func (x *MisbehavedEphemeralKeys) GetPrivateKeys() map[uint32][]byte {
 	return nil
 }
 
+type Evidence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seed               []byte                    `protobuf:"bytes,1,opt,name=seed,proto3" json:"seed,omitempty"`
+	MemberIndex        uint32                    `protobuf:"varint,2,opt,name=memberIndex,proto3" json:"memberIndex,omitempty"`
+	GroupSize          uint32                    `protobuf:"varint,3,opt,name=groupSize,proto3" json:"groupSize,omitempty"`
+	DishonestThreshold uint32                    `protobuf:"varint,4,opt,name=dishonestThreshold,proto3" json:"dishonestThreshold,omitempty"`
+	RecordedAt         int64                     `protobuf:"varint,5,opt,name=recordedAt,proto3" json:"recordedAt,omitempty"`
+	Messages           []*Evidence_SignedMessage `protobuf:"bytes,6,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (x *Evidence) Reset() {
+	*x = Evidence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Evidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Evidence) ProtoMessage() {}
+
+func (x *Evidence) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Evidence.ProtoReflect.Descriptor instead.
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return file_pkg_beacon_gjkr_gen_pb_message_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Evidence) GetSeed() []byte {
+	if x != nil {
+		return x.Seed
+	}
+	return nil
+}
+
+func (x *Evidence) GetMemberIndex() uint32 {
+	if x != nil {
+		return x.MemberIndex
+	}
+	return 0
+}
+
+func (x *Evidence) GetGroupSize() uint32 {
+	if x != nil {
+		return x.GroupSize
+	}
+	return 0
+}
+
+func (x *Evidence) GetDishonestThreshold() uint32 {
+	if x != nil {
+		return x.DishonestThreshold
+	}
+	return 0
+}
+
+func (x *Evidence) GetRecordedAt() int64 {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return 0
+}
+
+func (x *Evidence) GetMessages() []*Evidence_SignedMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
 type PeerShares_Shares struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -417,7 +504,7 @@ type PeerShares_Shares struct {
 func (x *PeerShares_Shares) Reset() {
 	*x = PeerShares_Shares{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes[8]
+		mi := &file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -430,7 +517,7 @@ func (x *PeerShares_Shares) String() string {
 func (*PeerShares_Shares) ProtoMessage() {}
 
 func (x *PeerShares_Shares) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes[8]
+	mi := &file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -460,6 +547,93 @@ func (x *PeerShares_Shares) GetEncryptedShareT() []byte {
 	return nil
 }
 
+type Evidence_SignedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Channel         string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Type            string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Seqno           uint64 `protobuf:"varint,3,opt,name=seqno,proto3" json:"seqno,omitempty"`
+	Payload         []byte `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	SenderPublicKey []byte `protobuf:"bytes,5,opt,name=senderPublicKey,proto3" json:"senderPublicKey,omitempty"`
+	Signature       []byte `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *Evidence_SignedMessage) Reset() {
+	*x = Evidence_SignedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Evidence_SignedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Evidence_SignedMessage) ProtoMessage() {}
+
+func (x *Evidence_SignedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Evidence_SignedMessage.ProtoReflect.Descriptor instead.
+func (*Evidence_SignedMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_beacon_gjkr_gen_pb_message_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *Evidence_SignedMessage) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *Evidence_SignedMessage) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Evidence_SignedMessage) GetSeqno() uint64 {
+	if x != nil {
+		return x.Seqno
+	}
+	return 0
+}
+
+func (x *Evidence_SignedMessage) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Evidence_SignedMessage) GetSenderPublicKey() []byte {
+	if x != nil {
+		return x.SenderPublicKey
+	}
+	return nil
+}
+
+func (x *Evidence_SignedMessage) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 var File_pkg_beacon_gjkr_gen_pb_message_proto protoreflect.FileDescriptor
 
 var file_pkg_beacon_gjkr_gen_pb_message_proto_rawDesc = []byte{
@@ -549,8 +723,34 @@ var file_pkg_beacon_gjkr_gen_pb_message_proto_rawDesc = []byte{
 	0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
 	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79,
 	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f,
-	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa0, 0x03, 0x0a, 0x08, 0x45,
+	0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x6d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1c, 0x0a,
+	0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x12, 0x64,
+	0x69, 0x73, 0x68, 0x6f, 0x6e, 0x65, 0x73, 0x74, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x64, 0x69, 0x73, 0x68, 0x6f, 0x6e, 0x65,
+	0x73, 0x74, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x38, 0x0a, 0x08, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x67, 0x6a, 0x6b, 0x72, 0x2e, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x2e, 0x53, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x1a, 0xb5, 0x01, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x65, 0x71, 0x6e, 0x6f, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x6e, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f,
+	0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x06, 0x5a,
+	0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -565,7 +765,7 @@ func file_pkg_beacon_gjkr_gen_pb_message_proto_rawDescGZIP() []byte {
 	return file_pkg_beacon_gjkr_gen_pb_message_proto_rawDescData
 }
 
-var file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_pkg_beacon_gjkr_gen_pb_message_proto_goTypes = []interface{}{
 	(*EphemeralPublicKey)(nil),         // 0: gjkr.EphemeralPublicKey
 	(*MemberCommitments)(nil),          // 1: gjkr.MemberCommitments
@@ -574,25 +774,28 @@ var file_pkg_beacon_gjkr_gen_pb_message_proto_goTypes = []interface{}{
 	(*MemberPublicKeySharePoints)(nil), // 4: gjkr.MemberPublicKeySharePoints
 	(*PointsAccusations)(nil),          // 5: gjkr.PointsAccusations
 	(*MisbehavedEphemeralKeys)(nil),    // 6: gjkr.MisbehavedEphemeralKeys
-	nil,                                // 7: gjkr.EphemeralPublicKey.EphemeralPublicKeysEntry
-	(*PeerShares_Shares)(nil),          // 8: gjkr.PeerShares.Shares
-	nil,                                // 9: gjkr.PeerShares.SharesEntry
-	nil,                                // 10: gjkr.SecretSharesAccusations.AccusedMembersKeysEntry
-	nil,                                // 11: gjkr.PointsAccusations.AccusedMembersKeysEntry
-	nil,                                // 12: gjkr.MisbehavedEphemeralKeys.PrivateKeysEntry
+	(*Evidence)(nil),                   // 7: gjkr.Evidence
+	nil,                                // 8: gjkr.EphemeralPublicKey.EphemeralPublicKeysEntry
+	(*PeerShares_Shares)(nil),          // 9: gjkr.PeerShares.Shares
+	nil,                                // 10: gjkr.PeerShares.SharesEntry
+	nil,                                // 11: gjkr.SecretSharesAccusations.AccusedMembersKeysEntry
+	nil,                                // 12: gjkr.PointsAccusations.AccusedMembersKeysEntry
+	nil,                                // 13: gjkr.MisbehavedEphemeralKeys.PrivateKeysEntry
+	(*Evidence_SignedMessage)(nil),     // 14: gjkr.Evidence.SignedMessage
 }
 var file_pkg_beacon_gjkr_gen_pb_message_proto_depIdxs = []int32{
-	7,  // 0: gjkr.EphemeralPublicKey.ephemeralPublicKeys:type_name -> gjkr.EphemeralPublicKey.EphemeralPublicKeysEntry
-	9,  // 1: gjkr.PeerShares.shares:type_name -> gjkr.PeerShares.SharesEntry
-	10, // 2: gjkr.SecretSharesAccusations.accusedMembersKeys:type_name -> gjkr.SecretSharesAccusations.AccusedMembersKeysEntry
-	11, // 3: gjkr.PointsAccusations.accusedMembersKeys:type_name -> gjkr.PointsAccusations.AccusedMembersKeysEntry
-	12, // 4: gjkr.MisbehavedEphemeralKeys.privateKeys:type_name -> gjkr.MisbehavedEphemeralKeys.PrivateKeysEntry
-	8,  // 5: gjkr.PeerShares.SharesEntry.value:type_name -> gjkr.PeerShares.Shares
-	6,  // [6:6] is the sub-list for method output_type
-	6,  // [6:6] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	8,  // 0: gjkr.EphemeralPublicKey.ephemeralPublicKeys:type_name -> gjkr.EphemeralPublicKey.EphemeralPublicKeysEntry
+	10, // 1: gjkr.PeerShares.shares:type_name -> gjkr.PeerShares.SharesEntry
+	11, // 2: gjkr.SecretSharesAccusations.accusedMembersKeys:type_name -> gjkr.SecretSharesAccusations.AccusedMembersKeysEntry
+	12, // 3: gjkr.PointsAccusations.accusedMembersKeys:type_name -> gjkr.PointsAccusations.AccusedMembersKeysEntry
+	13, // 4: gjkr.MisbehavedEphemeralKeys.privateKeys:type_name -> gjkr.MisbehavedEphemeralKeys.PrivateKeysEntry
+	14, // 5: gjkr.Evidence.messages:type_name -> gjkr.Evidence.SignedMessage
+	9,  // 6: gjkr.PeerShares.SharesEntry.value:type_name -> gjkr.PeerShares.Shares
+	7,  // [7:7] is the sub-list for method output_type
+	7,  // [7:7] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_pkg_beacon_gjkr_gen_pb_message_proto_init() }
@@ -685,7 +888,19 @@ func file_pkg_beacon_gjkr_gen_pb_message_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Evidence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PeerShares_Shares); i {
 			case 0:
 				return &v.state
@@ -697,6 +912,18 @@ func file_pkg_beacon_gjkr_gen_pb_message_proto_init() {
 				return nil
 			}
 		}
+		file_pkg_beacon_gjkr_gen_pb_message_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Evidence_SignedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -704,7 +931,7 @@ func file_pkg_beacon_gjkr_gen_pb_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_beacon_gjkr_gen_pb_message_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
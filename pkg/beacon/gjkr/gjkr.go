@@ -3,6 +3,7 @@ package gjkr
 import (
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ipfs/go-log"
 
@@ -53,6 +54,8 @@ func RegisterUnmarshallers(channel net.BroadcastChannel) {
 // If the generation is successful, it returns a threshold group member which
 // can participate in the signing group; if the generation fails, it returns an
 // error.
+// If the evidence handler is provided, it is called with the evidence recorded
+// during the execution, no matter if the generation was successful or not.
 func Execute(
 	logger log.StandardLogger,
 	memberIndex group.MemberIndex,
@@ -63,6 +66,7 @@ func Execute(
 	seed *big.Int,
 	membershipValidator *group.MembershipValidator,
 	startBlockHeight uint64,
+	evidenceHandler func(evidence *Evidence),
 ) (*Result, uint64, error) {
 	logger.Debugf("[member:%v] initializing member", memberIndex)
 
@@ -86,6 +90,18 @@ func Execute(
 	stateMachine := state.NewMachine(logger, channel, blockCounter, initialState)
 
 	lastState, endBlockHeight, err := stateMachine.Execute(startBlockHeight)
+
+	if evidenceHandler != nil {
+		evidenceHandler(&Evidence{
+			Seed:               seed,
+			MemberIndex:        memberIndex,
+			GroupSize:          groupSize,
+			DishonestThreshold: dishonestThreshold,
+			RecordedAt:         time.Now(),
+			Messages:           member.evidenceLog.signedMessages(),
+		})
+	}
+
 	if err != nil {
 		return nil, 0, err
 	}
@@ -38,6 +38,7 @@ func TestExecute_HappyPath(t *testing.T) {
 	dkgtest.AssertSamePublicKey(t, result)
 	dkgtest.AssertNoMisbehavingMembers(t, result)
 	dkgtest.AssertValidGroupPublicKey(t, result)
+	dkgtest.AssertEvidenceResolution(t, result, groupSize)
 }
 
 func TestExecute_IA_member1_phase1(t *testing.T) {
@@ -461,6 +462,7 @@ func TestExecute_DQ_member5_inconsistentShares_phase5(t *testing.T) {
 	dkgtest.AssertMisbehavingMembers(t, result, group.MemberIndex(5))
 	dkgtest.AssertValidGroupPublicKey(t, result)
 	dkgtest.AssertResultSupportingMembers(t, result, []group.MemberIndex{1, 2, 3, 4}...)
+	dkgtest.AssertEvidenceResolution(t, result, groupSize, group.MemberIndex(5))
 }
 
 // Phase 5 test case - a member misbehaved by performing a false accusation
@@ -515,6 +517,7 @@ func TestExecute_DQ_member4_falseAccusation_phase5(t *testing.T) {
 	dkgtest.AssertMisbehavingMembers(t, result, group.MemberIndex(4))
 	dkgtest.AssertValidGroupPublicKey(t, result)
 	dkgtest.AssertResultSupportingMembers(t, result, []group.MemberIndex{1, 2, 3, 5}...)
+	dkgtest.AssertEvidenceResolution(t, result, groupSize, group.MemberIndex(4))
 }
 
 // Phase 5 test case - a member misbehaved by performing an accusation against
@@ -2,12 +2,15 @@ package gjkr
 
 import (
 	"fmt"
+	"math/big"
+	"time"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/keep-network/keep-core/pkg/beacon/gjkr/gen/pb"
 	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
+	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
 )
 
@@ -356,6 +359,67 @@ func (mekm *MisbehavedEphemeralKeysMessage) Unmarshal(bytes []byte) error {
 	return nil
 }
 
+// Marshal converts this Evidence to a byte array suitable for storage.
+func (e *Evidence) Marshal() ([]byte, error) {
+	if e.Seed == nil {
+		return nil, fmt.Errorf("nil seed")
+	}
+
+	messages := make([]*pb.Evidence_SignedMessage, len(e.Messages))
+	for i, message := range e.Messages {
+		messages[i] = &pb.Evidence_SignedMessage{
+			Channel:         message.Channel,
+			Type:            message.Type,
+			Seqno:           message.Seqno,
+			Payload:         message.Payload,
+			SenderPublicKey: message.SenderPublicKey,
+			Signature:       message.Signature,
+		}
+	}
+
+	return proto.Marshal(&pb.Evidence{
+		Seed:               e.Seed.Bytes(),
+		MemberIndex:        uint32(e.MemberIndex),
+		GroupSize:          uint32(e.GroupSize),
+		DishonestThreshold: uint32(e.DishonestThreshold),
+		RecordedAt:         e.RecordedAt.Unix(),
+		Messages:           messages,
+	})
+}
+
+// Unmarshal converts a byte array produced by Marshal to an Evidence.
+func (e *Evidence) Unmarshal(bytes []byte) error {
+	pbEvidence := pb.Evidence{}
+	if err := proto.Unmarshal(bytes, &pbEvidence); err != nil {
+		return err
+	}
+
+	if err := validateMemberIndex(pbEvidence.MemberIndex); err != nil {
+		return err
+	}
+
+	messages := make([]*net.MessageSignature, len(pbEvidence.Messages))
+	for i, message := range pbEvidence.Messages {
+		messages[i] = &net.MessageSignature{
+			Channel:         message.Channel,
+			Type:            message.Type,
+			Seqno:           message.Seqno,
+			Payload:         message.Payload,
+			SenderPublicKey: message.SenderPublicKey,
+			Signature:       message.Signature,
+		}
+	}
+
+	e.Seed = new(big.Int).SetBytes(pbEvidence.Seed)
+	e.MemberIndex = group.MemberIndex(pbEvidence.MemberIndex)
+	e.GroupSize = int(pbEvidence.GroupSize)
+	e.DishonestThreshold = int(pbEvidence.DishonestThreshold)
+	e.RecordedAt = time.Unix(pbEvidence.RecordedAt, 0)
+	e.Messages = messages
+
+	return nil
+}
+
 func marshalPublicKeyMap(
 	publicKeys map[group.MemberIndex]*ephemeral.PublicKey,
 ) (map[uint32][]byte, error) {
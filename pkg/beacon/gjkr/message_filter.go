@@ -1,6 +1,9 @@
 package gjkr
 
-import "github.com/keep-network/keep-core/pkg/protocol/group"
+import (
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
 
 // MarkInactiveMembers takes all messages from the previous DKG protocol
 // execution phase and marks all member who did not send a message as IA.
@@ -106,3 +109,31 @@ func (mc *memberCore) shouldAcceptMessage(
 
 	return !isMessageFromSelf && isSenderValid && isSenderAccepted
 }
+
+// recordEvidence stores the given signed message in the evidence log if it was
+// sent by a valid group member. Messages sent by the current member are stored
+// as well so that the evidence reflects the full view of the member.
+func (mc *memberCore) recordEvidence(
+	senderID group.MemberIndex,
+	message net.Message,
+) {
+	signature := message.Signature()
+	if signature == nil {
+		return
+	}
+
+	if !mc.membershipValidator.IsValidMembership(
+		senderID,
+		message.SenderPublicKey(),
+	) {
+		return
+	}
+
+	if err := mc.evidenceLog.putSignedMessage(senderID, signature); err != nil {
+		mc.logger.Debugf(
+			"[member:%v] could not put signed message to the evidence log: [%v]",
+			mc.ID,
+			err,
+		)
+	}
+}
@@ -90,6 +90,8 @@ func (ekpgs *ephemeralKeyPairGenerationState) Initiate(ctx context.Context) erro
 func (ekpgs *ephemeralKeyPairGenerationState) Receive(msg net.Message) error {
 	switch phaseMessage := msg.Payload().(type) {
 	case *EphemeralPublicKeyMessage:
+		ekpgs.member.recordEvidence(phaseMessage.SenderID(), msg)
+
 		if ekpgs.member.shouldAcceptMessage(
 			phaseMessage.SenderID(),
 			msg.SenderPublicKey(),
@@ -195,6 +197,8 @@ func (cs *commitmentState) Initiate(ctx context.Context) error {
 func (cs *commitmentState) Receive(msg net.Message) error {
 	switch phaseMessage := msg.Payload().(type) {
 	case *PeerSharesMessage:
+		cs.member.recordEvidence(phaseMessage.SenderID(), msg)
+
 		if cs.member.shouldAcceptMessage(
 			phaseMessage.SenderID(),
 			msg.SenderPublicKey(),
@@ -203,6 +207,8 @@ func (cs *commitmentState) Receive(msg net.Message) error {
 		}
 
 	case *MemberCommitmentsMessage:
+		cs.member.recordEvidence(phaseMessage.SenderID(), msg)
+
 		if cs.member.shouldAcceptMessage(
 			phaseMessage.SenderID(),
 			msg.SenderPublicKey(),
@@ -277,6 +283,8 @@ func (cvs *commitmentsVerificationState) Initiate(ctx context.Context) error {
 func (cvs *commitmentsVerificationState) Receive(msg net.Message) error {
 	switch phaseMessage := msg.Payload().(type) {
 	case *SecretSharesAccusationsMessage:
+		cvs.member.recordEvidence(phaseMessage.SenderID(), msg)
+
 		if cvs.member.shouldAcceptMessage(
 			phaseMessage.SenderID(),
 			msg.SenderPublicKey(),
@@ -233,6 +233,7 @@ func TestSigningWithInvalidSignatureShare(t *testing.T) {
 			return entry.NewSignatureShareMessage(
 				signatureShareMessage.SenderID(),
 				[]byte{0, 1},
+				signatureShareMessage.ProtocolVersion(),
 			)
 		}
 
@@ -247,6 +248,7 @@ func TestSigningWithInvalidSignatureShare(t *testing.T) {
 			return entry.NewSignatureShareMessage(
 				signatureShareMessage.SenderID(),
 				randomG1.Marshal(),
+				signatureShareMessage.ProtocolVersion(),
 			)
 		}
 
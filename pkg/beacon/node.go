@@ -13,6 +13,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/beacon/event"
 	"github.com/keep-network/keep-core/pkg/generator"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/threshold"
 
 	"github.com/keep-network/keep-core/pkg/beacon/registry"
 	"github.com/keep-network/keep-core/pkg/net"
@@ -24,6 +25,9 @@ type node struct {
 	netProvider   net.Provider
 	groupRegistry *registry.Groups
 	protocolLatch *generator.ProtocolLatch
+	evidenceStore *dkg.EvidenceStore
+	// Version of the relay entry signing protocol used by the node.
+	protocolVersion entry.ProtocolVersion
 }
 
 // newNode returns an empty node with no group, zero group count, and a nil last
@@ -33,15 +37,19 @@ func newNode(
 	netProvider net.Provider,
 	groupRegistry *registry.Groups,
 	scheduler *generator.Scheduler,
+	evidenceStore *dkg.EvidenceStore,
+	protocolVersion entry.ProtocolVersion,
 ) *node {
 	latch := generator.NewProtocolLatch()
 	scheduler.RegisterProtocol(latch)
 
 	return &node{
-		beaconChain:   beaconChain,
-		netProvider:   netProvider,
-		groupRegistry: groupRegistry,
-		protocolLatch: latch,
+		beaconChain:     beaconChain,
+		netProvider:     netProvider,
+		groupRegistry:   groupRegistry,
+		protocolLatch:   latch,
+		evidenceStore:   evidenceStore,
+		protocolVersion: protocolVersion,
 	}
 }
 
@@ -107,7 +115,7 @@ func (n *node) JoinDKGIfEligible(
 
 	// Create temporary broadcast channel name for DKG using the
 	// group selection seed with the protocol name as prefix.
-	channelName := fmt.Sprintf("%s-%s", ProtocolName, dkgSeed.Text(16))
+	channelName := threshold.ChannelName(ProtocolName, dkgSeed.Text(16))
 
 	if len(indexes) > 0 {
 		logger.Infof(
@@ -116,25 +124,17 @@ func (n *node) JoinDKGIfEligible(
 			len(indexes),
 		)
 
-		broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
-		if err != nil {
-			logger.Errorf("failed to get broadcast channel: [%v]", err)
-			return
-		}
-
-		membershipValidator := group.NewMembershipValidator(
+		broadcastChannel, membershipValidator, err := threshold.JoinBroadcastChannel(
 			logger,
+			n.netProvider,
+			channelName,
+			maxMessageSize,
 			selectedOperators,
 			signing,
 		)
-
-		err = broadcastChannel.SetFilter(membershipValidator.IsInGroup)
 		if err != nil {
-			logger.Errorf(
-				"could not set filter for channel [%v]: [%v]",
-				broadcastChannel.Name(),
-				err,
-			)
+			logger.Errorf("cannot join broadcast channel: [%v]", err)
+			return
 		}
 
 		for _, index := range indexes {
@@ -155,6 +155,32 @@ func (n *node) JoinDKGIfEligible(
 					broadcastChannel,
 					membershipValidator,
 					selectedOperators,
+					n.evidenceStore,
+					func(inactiveMembers, disqualifiedMembers []group.MemberIndex) {
+						// All members controlled by this client observe
+						// the same misbehaving members so report them only
+						// once.
+						if memberIndex != indexes[0]+1 {
+							return
+						}
+
+						threshold.ReportMembers(
+							logger,
+							n.netProvider,
+							signing,
+							selectedOperators,
+							inactiveMembers,
+							net.ProtocolInactivityEvent,
+						)
+						threshold.ReportMembers(
+							logger,
+							n.netProvider,
+							signing,
+							selectedOperators,
+							disqualifiedMembers,
+							net.ProtocolMisbehaviorEvent,
+						)
+					},
 				)
 				if err != nil {
 					logger.Errorf("failed to execute dkg: [%v]", err)
@@ -257,12 +283,16 @@ func (n *node) ResumeSigningIfEligible() {
 // MonitorRelayEntry is listetning to the chain for a new relay entry.
 // When a processing group which is supposed to deliver a relay entry does not
 // fulfill its work, then this node notifies the chain about it. In the case of
-// delivering a relay entry by a processing group, this node does nothing.
+// delivering a relay entry by a processing group, this node verifies the
+// entry against the group public key and the previous entry of the request
+// and raises an alert if the entry is invalid.
 func (n *node) MonitorRelayEntry(
-	relayRequestBlockNumber uint64,
+	request *event.RelayEntryRequested,
 ) {
 	logger.Infof("monitoring chain for a new relay entry")
 
+	relayRequestBlockNumber := request.BlockNumber
+
 	blockCounter, err := n.beaconChain.BlockCounter()
 	if err != nil {
 		logger.Errorf("failed to get block counter: [%v]", err)
@@ -301,11 +331,28 @@ func (n *node) MonitorRelayEntry(
 				logger.Errorf("could not report a relay entry timeout: [%v]", err)
 			}
 			return
-		case entry := <-onEntrySubmittedChannel:
+		case submittedEntry := <-onEntrySubmittedChannel:
 			logger.Infof(
 				"relay entry was submitted by the selected group on time at block [%v]",
-				entry.BlockNumber,
+				submittedEntry.BlockNumber,
+			)
+
+			err := entry.Verify(
+				request.GroupPublicKey,
+				request.PreviousEntry,
+				submittedEntry.Entry,
 			)
+			if err != nil {
+				logger.Errorf(
+					"invalid relay entry [0x%x] submitted at block [%v] "+
+						"by group [0x%x] for previous entry [0x%x]: [%v]",
+					submittedEntry.Entry,
+					submittedEntry.BlockNumber,
+					request.GroupPublicKey,
+					request.PreviousEntry,
+					err,
+				)
+			}
 			return
 		}
 	}
@@ -329,36 +376,26 @@ func (n *node) GenerateRelayEntry(
 		return
 	}
 
-	channel, err := n.netProvider.BroadcastChannelFor(memberships[0].ChannelName)
-	if err != nil {
-		logger.Errorf("could not create broadcast channel: [%v]", err)
-		return
-	}
-
-	entry.RegisterUnmarshallers(channel)
-
 	// Each signer of the given group should have the same picture of other
 	// group operators. Otherwise, they would not be in the group registry.
 	// That said, take the group operators from the first signer.
-	groupMembers := n.groupRegistry.GetGroup(groupPublicKey)[0].
-		Signer.
-		GroupOperators()
+	groupMembers := memberships[0].Signer.GroupOperators()
 
-	membershipValidator := group.NewMembershipValidator(
+	channel, _, err := threshold.JoinBroadcastChannel(
 		logger,
+		n.netProvider,
+		memberships[0].ChannelName,
+		maxMessageSize,
 		groupMembers,
 		n.beaconChain.Signing(),
 	)
-
-	err = channel.SetFilter(membershipValidator.IsInGroup)
 	if err != nil {
-		logger.Errorf(
-			"could not set filter for channel [%v]: [%v]",
-			channel.Name(),
-			err,
-		)
+		logger.Errorf("cannot join broadcast channel: [%v]", err)
+		return
 	}
 
+	entry.RegisterUnmarshallers(channel)
+
 	blockCounter, err := n.beaconChain.BlockCounter()
 	if err != nil {
 		logger.Errorf("failed to get block counter: [%v]", err)
@@ -381,6 +418,7 @@ func (n *node) GenerateRelayEntry(
 				chainConfig.HonestThreshold,
 				member.Signer,
 				startBlockHeight,
+				n.protocolVersion,
 			)
 			if err != nil {
 				logger.Errorf(
@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/keep-network/keep-core/pkg/beacon/event"
 	"github.com/keep-network/keep-core/pkg/chain/local_v1"
 )
 
@@ -28,7 +29,9 @@ func TestMonitorRelayEntryOnChain_EntrySubmitted(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	go node.MonitorRelayEntry(startBlockHeight)
+	go node.MonitorRelayEntry(
+		&event.RelayEntryRequested{BlockNumber: startBlockHeight},
+	)
 
 	// the window to get a relay entry is from currentBlock to (currentBlock+relayEntryTimeout)
 	// we subtract arbitarly 5 blocks to be within this window. Ex. 0 + 15 - 5
@@ -80,7 +83,9 @@ func TestMonitorRelayEntryOnChain_EntryNotSubmitted(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	go node.MonitorRelayEntry(startBlockHeight)
+	go node.MonitorRelayEntry(
+		&event.RelayEntryRequested{BlockNumber: startBlockHeight},
+	)
 
 	relayEntryTimeoutFromStart := startBlockHeight + relayEntryTimeout
 
@@ -89,11 +89,15 @@ func (g *Groups) GetGroup(groupPublicKey []byte) []*Membership {
 // after the group expiration. This guarantees the group will not be selected to
 // a new operation and it cannot have an ongoing operation for which it could be
 // selected before it expired. Such a group can be safely removed from the registry
-// and archived in the underlying storage.
-func (g *Groups) UnregisterStaleGroups(latestGroupPublicKey []byte) {
+// and archived in the underlying storage. The stale check is skipped for the
+// latest registered group; the latest group public key may be nil if it is not
+// known. Returns memberships of the archived groups.
+func (g *Groups) UnregisterStaleGroups(latestGroupPublicKey []byte) []*Membership {
 	g.mutex.Lock()
 	defer g.mutex.Unlock()
 
+	archivedMemberships := make([]*Membership, 0)
+
 	for publicKey, memberships := range g.myGroups {
 		publicKeyBytes, err := groupKeyFromString(publicKey)
 		if err != nil {
@@ -144,9 +148,21 @@ func (g *Groups) UnregisterStaleGroups(latestGroupPublicKey []byte) {
 				)
 
 				delete(g.myGroups, publicKey)
+				archivedMemberships = append(archivedMemberships, memberships...)
 			}
 		}
 	}
+
+	return archivedMemberships
+}
+
+// GroupsCount returns the number of registered groups the client is
+// a member of.
+func (g *Groups) GroupsCount() int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return len(g.myGroups)
 }
 
 // LoadExistingGroups iterates over all stored memberships on disk and loads them
@@ -139,7 +139,18 @@ func TestUnregisterStaleGroups(t *testing.T) {
 
 	mockChain.markAsStale(signer2.GroupPublicKeyBytes())
 
-	gr.UnregisterStaleGroups(signer3.GroupPublicKeyBytes())
+	archivedMemberships := gr.UnregisterStaleGroups(signer3.GroupPublicKeyBytes())
+
+	expectedArchivedMemberships := []*Membership{
+		{Signer: signer2, ChannelName: channelName1},
+	}
+	if !reflect.DeepEqual(expectedArchivedMemberships, archivedMemberships) {
+		t.Errorf(
+			"unexpected archived memberships\nexpected: [%v]\nactual:   [%v]",
+			expectedArchivedMemberships,
+			archivedMemberships,
+		)
+	}
 
 	group1 := gr.GetGroup(signer1.GroupPublicKeyBytes())
 	if group1 == nil {
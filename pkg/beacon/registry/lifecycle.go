@@ -0,0 +1,155 @@
+package registry
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ipfs/go-log"
+
+	beaconchain "github.com/keep-network/keep-core/pkg/beacon/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/event"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// DefaultStaleGroupsCheckInterval is the default number of blocks between
+// two subsequent checks for stale groups.
+const DefaultStaleGroupsCheckInterval = 100
+
+// LifecycleManager keeps the group registry in line with the on-chain state
+// of groups. It looks for stale groups whenever a new group is registered
+// on-chain and every check interval blocks. Memberships of stale groups are
+// unregistered and archived, and broadcast channels of those groups are
+// closed.
+type LifecycleManager struct {
+	// manager-scoped atomic counter of archived groups
+	//
+	// Must be declared at the top of the struct!
+	// See: https://golang.org/pkg/sync/atomic/#pkg-note-BUG
+	archivedGroupsCount uint64
+
+	logger log.StandardLogger
+
+	groups            *Groups
+	groupRegistration beaconchain.GroupRegistrationInterface
+	blockCounter      chain.BlockCounter
+	netProvider       net.Provider
+
+	checkInterval uint64
+
+	// checkMutex ensures there is only one stale groups check at a time.
+	checkMutex sync.Mutex
+
+	latestGroupMutex     sync.Mutex
+	latestGroupPublicKey []byte
+}
+
+// NewLifecycleManager creates a new lifecycle manager of the given group
+// registry. The manager looks for stale groups every checkInterval blocks
+// counted by the given block counter.
+func NewLifecycleManager(
+	logger log.StandardLogger,
+	groups *Groups,
+	groupRegistration beaconchain.GroupRegistrationInterface,
+	blockCounter chain.BlockCounter,
+	netProvider net.Provider,
+	checkInterval uint64,
+) *LifecycleManager {
+	return &LifecycleManager{
+		logger:            logger,
+		groups:            groups,
+		groupRegistration: groupRegistration,
+		blockCounter:      blockCounter,
+		netProvider:       netProvider,
+		checkInterval:     checkInterval,
+	}
+}
+
+// Start starts looking for stale groups in the background until the given
+// context is done.
+func (lm *LifecycleManager) Start(ctx context.Context) {
+	subscription := lm.groupRegistration.OnGroupRegistered(
+		func(registration *event.GroupRegistration) {
+			lm.latestGroupMutex.Lock()
+			lm.latestGroupPublicKey = registration.GroupPublicKey
+			lm.latestGroupMutex.Unlock()
+
+			go lm.UnregisterStaleGroups()
+		},
+	)
+
+	blocksChan := lm.blockCounter.WatchBlocks(ctx)
+
+	go func() {
+		defer subscription.Unsubscribe()
+
+		for {
+			select {
+			case block, ok := <-blocksChan:
+				if !ok {
+					return
+				}
+
+				if lm.checkInterval != 0 && block%lm.checkInterval == 0 {
+					lm.UnregisterStaleGroups()
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// UnregisterStaleGroups unregisters and archives memberships of groups that
+// became stale and closes broadcast channels of those groups. The broadcast
+// channels are not closed if the network provider does not support it.
+func (lm *LifecycleManager) UnregisterStaleGroups() {
+	lm.checkMutex.Lock()
+	defer lm.checkMutex.Unlock()
+
+	lm.latestGroupMutex.Lock()
+	latestGroupPublicKey := lm.latestGroupPublicKey
+	lm.latestGroupMutex.Unlock()
+
+	archivedMemberships := lm.groups.UnregisterStaleGroups(latestGroupPublicKey)
+	if len(archivedMemberships) == 0 {
+		return
+	}
+
+	archivedGroups := make(map[string]bool)
+	channelsToClose := make(map[string]bool)
+	for _, membership := range archivedMemberships {
+		archivedGroups[groupKeyToString(membership.Signer.GroupPublicKeyBytes())] = true
+		channelsToClose[membership.ChannelName] = true
+	}
+
+	atomic.AddUint64(&lm.archivedGroupsCount, uint64(len(archivedGroups)))
+
+	channelCloser, ok := lm.netProvider.(net.BroadcastChannelCloser)
+	if !ok {
+		return
+	}
+
+	for channelName := range channelsToClose {
+		if err := channelCloser.CloseBroadcastChannel(channelName); err != nil {
+			lm.logger.Warningf(
+				"could not close broadcast channel [%v] of archived group: [%v]",
+				channelName,
+				err,
+			)
+		}
+	}
+}
+
+// ActiveGroupsCount returns the number of groups the client is a member of
+// which are not archived.
+func (lm *LifecycleManager) ActiveGroupsCount() int {
+	return lm.groups.GroupsCount()
+}
+
+// ArchivedGroupsCount returns the number of groups archived by the manager
+// since it has been created.
+func (lm *LifecycleManager) ArchivedGroupsCount() uint64 {
+	return atomic.LoadUint64(&lm.archivedGroupsCount)
+}
@@ -0,0 +1,155 @@
+package registry
+
+import (
+	"context"
+	"encoding/hex"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	beaconchain "github.com/keep-network/keep-core/pkg/beacon/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/dkg"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestLifecycleManager_ArchivesStaleGroups(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	localChain := local_v1.Connect(5, 3)
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	netProvider := &channelCloserMock{Provider: local.Connect()}
+	persistence := &persistenceHandleMock{}
+
+	gr := NewGroupRegistry(&testutils.MockLogger{}, localChain, persistence)
+	if err := gr.RegisterGroup(signer1, channelName1); err != nil {
+		t.Fatal(err)
+	}
+	if err := gr.RegisterGroup(signer2, channelName2); err != nil {
+		t.Fatal(err)
+	}
+
+	lifecycleManager := NewLifecycleManager(
+		&testutils.MockLogger{},
+		gr,
+		localChain,
+		blockCounter,
+		netProvider,
+		1,
+	)
+	lifecycleManager.Start(ctx)
+
+	submitTestGroup(t, localChain, signer1)
+
+	// The first group becomes stale once the group active time and the relay
+	// request timeout pass. The second group is registered after that so it
+	// is not stale.
+	localChain.AdvanceBlocks(20)
+	submitTestGroup(t, localChain, signer2)
+
+	waitCtx, cancelWait := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelWait()
+
+	for lifecycleManager.ArchivedGroupsCount() == 0 {
+		select {
+		case <-waitCtx.Done():
+			t.Fatal("stale group has not been archived")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	if gr.GetGroup(signer1.GroupPublicKeyBytes()) != nil {
+		t.Errorf("stale group was expected to be unregistered")
+	}
+	if gr.GetGroup(signer2.GroupPublicKeyBytes()) == nil {
+		t.Errorf("active group was expected to be registered")
+	}
+
+	expectedArchivedGroups := []string{
+		hex.EncodeToString(signer1.GroupPublicKeyBytesCompressed()),
+	}
+	if !reflect.DeepEqual(expectedArchivedGroups, persistence.archivedGroups) {
+		t.Errorf(
+			"unexpected archived groups\nexpected: [%v]\nactual:   [%v]",
+			expectedArchivedGroups,
+			persistence.archivedGroups,
+		)
+	}
+
+	expectedClosedChannels := []string{channelName1}
+	if closedChannels := netProvider.getClosedChannels(); !reflect.DeepEqual(
+		expectedClosedChannels,
+		closedChannels,
+	) {
+		t.Errorf(
+			"unexpected closed channels\nexpected: [%v]\nactual:   [%v]",
+			expectedClosedChannels,
+			closedChannels,
+		)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"active groups count",
+		1,
+		lifecycleManager.ActiveGroupsCount(),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"archived groups count",
+		1,
+		int(lifecycleManager.ArchivedGroupsCount()),
+	)
+}
+
+func submitTestGroup(
+	t *testing.T,
+	localChain beaconchain.Interface,
+	signer *dkg.ThresholdSigner,
+) {
+	err := localChain.SubmitDKGResult(
+		beaconchain.GroupMemberIndex(signer.MemberID()),
+		&beaconchain.DKGResult{
+			GroupPublicKey: signer.GroupPublicKeyBytes(),
+		},
+		map[beaconchain.GroupMemberIndex][]byte{
+			1: {101},
+			2: {102},
+			3: {103},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+type channelCloserMock struct {
+	net.Provider
+
+	closedChannelsMutex sync.Mutex
+	closedChannels      []string
+}
+
+func (ccm *channelCloserMock) CloseBroadcastChannel(name string) error {
+	ccm.closedChannelsMutex.Lock()
+	defer ccm.closedChannelsMutex.Unlock()
+
+	ccm.closedChannels = append(ccm.closedChannels, name)
+
+	return nil
+}
+
+func (ccm *channelCloserMock) getClosedChannels() []string {
+	ccm.closedChannelsMutex.Lock()
+	defer ccm.closedChannelsMutex.Unlock()
+
+	return ccm.closedChannels
+}
@@ -0,0 +1,182 @@
+package bls
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// batchRandomnessBits is the bit length of random coefficients used to combine
+// signatures in the batch verification. An invalid signature passes the batch
+// verification with probability at most 2^-batchRandomnessBits.
+const batchRandomnessBits = 128
+
+// BatchVerifyG1 checks if all the signatures are correct for the provided
+// G1 point messages and the corresponding public keys. Instead of performing
+// a pairing check for every signature separately, signatures are verified in
+// one multi-pairing using a random linear combination:
+//
+//	e(-Σ r_i * signature_i, P2) * Π e(r_i * message_i, publicKey_i) == 1
+//
+// where r_i are random coefficients. The multi-pairing shares the final
+// exponentiation between all the pairs so it is considerably cheaper than
+// separate checks. The function returns false if at least one signature is
+// invalid but it does not tell which one.
+func BatchVerifyG1(
+	publicKeys []*bn256.G2,
+	messages []*bn256.G1,
+	signatures []*bn256.G1,
+) (bool, error) {
+	if len(publicKeys) != len(signatures) || len(messages) != len(signatures) {
+		return false, fmt.Errorf(
+			"number of public keys [%v], messages [%v], and signatures [%v] "+
+				"does not match",
+			len(publicKeys),
+			len(messages),
+			len(signatures),
+		)
+	}
+
+	if len(signatures) == 0 {
+		return true, nil
+	}
+
+	a := make([]*bn256.G1, 0, len(signatures)+1)
+	b := make([]*bn256.G2, 0, len(signatures)+1)
+
+	combinedSignature := new(bn256.G1)
+	for i := range signatures {
+		r, err := randomBatchCoefficient()
+		if err != nil {
+			return false, err
+		}
+
+		combinedSignature.Add(
+			combinedSignature,
+			new(bn256.G1).ScalarMult(signatures[i], r),
+		)
+
+		a = append(a, new(bn256.G1).ScalarMult(messages[i], r))
+		b = append(b, publicKeys[i])
+	}
+
+	// Generator point of G2 group.
+	p2 := new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+
+	a = append(a, new(bn256.G1).Neg(combinedSignature))
+	b = append(b, p2)
+
+	return bn256.PairingCheck(a, b), nil
+}
+
+// BatchVerifySignatureShares checks if the signature shares are correct for
+// the provided G1 point message and the public key shares with the same
+// indexes. Shares are verified in a batch and only if the batch verification
+// fails, every share is verified separately. The function returns signature
+// shares which are not correct or for which the public key share is missing.
+func BatchVerifySignatureShares(
+	publicKeyShares []*PublicKeyShare,
+	message *bn256.G1,
+	signatureShares []*SignatureShare,
+) []*SignatureShare {
+	publicKeysByIndex := make(map[int]*bn256.G2)
+	for _, publicKeyShare := range publicKeyShares {
+		publicKeysByIndex[publicKeyShare.I] = publicKeyShare.V
+	}
+
+	var invalidShares []*SignatureShare
+
+	publicKeys := make([]*bn256.G2, 0, len(signatureShares))
+	signatures := make([]*bn256.G1, 0, len(signatureShares))
+	verifiedShares := make([]*SignatureShare, 0, len(signatureShares))
+
+	for _, signatureShare := range signatureShares {
+		publicKey, ok := publicKeysByIndex[signatureShare.I]
+		if !ok || publicKey == nil || signatureShare.V == nil {
+			invalidShares = append(invalidShares, signatureShare)
+			continue
+		}
+
+		publicKeys = append(publicKeys, publicKey)
+		signatures = append(signatures, signatureShare.V)
+		verifiedShares = append(verifiedShares, signatureShare)
+	}
+
+	valid, err := batchVerifySameMessageG1(publicKeys, message, signatures)
+	if err == nil && valid {
+		return invalidShares
+	}
+
+	// The batch contains at least one invalid share, we need to find out
+	// which ones.
+	for i, signatureShare := range verifiedShares {
+		if !VerifyG1(publicKeys[i], message, signatures[i]) {
+			invalidShares = append(invalidShares, signatureShare)
+		}
+	}
+
+	return invalidShares
+}
+
+// batchVerifySameMessageG1 is a variant of BatchVerifyG1 for signatures of
+// the same message. Since all the pairings share the message, they are
+// combined into a single one so the check requires only two pairings no matter
+// the number of signatures:
+//
+//	e(-Σ r_i * signature_i, P2) * e(message, Σ r_i * publicKey_i) == 1
+func batchVerifySameMessageG1(
+	publicKeys []*bn256.G2,
+	message *bn256.G1,
+	signatures []*bn256.G1,
+) (bool, error) {
+	if len(signatures) == 0 {
+		return true, nil
+	}
+
+	combinedSignature := new(bn256.G1)
+	combinedPublicKey := new(bn256.G2)
+	for i := range signatures {
+		r, err := randomBatchCoefficient()
+		if err != nil {
+			return false, err
+		}
+
+		combinedSignature.Add(
+			combinedSignature,
+			new(bn256.G1).ScalarMult(signatures[i], r),
+		)
+		combinedPublicKey.Add(
+			combinedPublicKey,
+			new(bn256.G2).ScalarMult(publicKeys[i], r),
+		)
+	}
+
+	// Generator point of G2 group.
+	p2 := new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+
+	return bn256.PairingCheck(
+		[]*bn256.G1{new(bn256.G1).Neg(combinedSignature), message},
+		[]*bn256.G2{p2, combinedPublicKey},
+	), nil
+}
+
+func randomBatchCoefficient() (*big.Int, error) {
+	max := new(big.Int).Lsh(big.NewInt(1), batchRandomnessBits)
+
+	for {
+		r, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not generate random coefficient: [%v]",
+				err,
+			)
+		}
+
+		// Zero coefficient would remove the signature from the batch.
+		if r.Sign() != 0 {
+			return r, nil
+		}
+	}
+}
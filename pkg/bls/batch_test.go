@@ -0,0 +1,305 @@
+package bls
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/altbn128"
+)
+
+func TestBatchVerifyG1(t *testing.T) {
+	publicKeys, messages, signatures := newTestSignatures(t, 5)
+
+	forgedSignatures := make([]*bn256.G1, len(signatures))
+	copy(forgedSignatures, signatures)
+	forgedSignatures[3] = SignG1(big.NewInt(1683), messages[3])
+
+	// Each signature is invalid but the sum of them is equal to the sum of
+	// valid signatures. Random coefficients must catch it.
+	offset := new(bn256.G1).ScalarBaseMult(big.NewInt(1410))
+	offsetSignatures := make([]*bn256.G1, len(signatures))
+	copy(offsetSignatures, signatures)
+	offsetSignatures[0] = new(bn256.G1).Add(signatures[0], offset)
+	offsetSignatures[1] = new(bn256.G1).Add(
+		signatures[1],
+		new(bn256.G1).Neg(offset),
+	)
+
+	var tests = map[string]struct {
+		publicKeys    []*bn256.G2
+		messages      []*bn256.G1
+		signatures    []*bn256.G1
+		expectedValid bool
+		expectedError error
+	}{
+		"all signatures valid": {
+			publicKeys:    publicKeys,
+			messages:      messages,
+			signatures:    signatures,
+			expectedValid: true,
+		},
+		"one signature invalid": {
+			publicKeys:    publicKeys,
+			messages:      messages,
+			signatures:    forgedSignatures,
+			expectedValid: false,
+		},
+		"invalid signatures cancelling out": {
+			publicKeys:    publicKeys,
+			messages:      messages,
+			signatures:    offsetSignatures,
+			expectedValid: false,
+		},
+		"signatures swapped": {
+			publicKeys: publicKeys[0:2],
+			messages:   messages[0:2],
+			signatures: []*bn256.G1{
+				signatures[1],
+				signatures[0],
+			},
+			expectedValid: false,
+		},
+		"empty batch": {
+			publicKeys:    []*bn256.G2{},
+			messages:      []*bn256.G1{},
+			signatures:    []*bn256.G1{},
+			expectedValid: true,
+		},
+		"lengths mismatch": {
+			publicKeys:    publicKeys[0:4],
+			messages:      messages,
+			signatures:    signatures,
+			expectedValid: false,
+			expectedError: fmt.Errorf(
+				"number of public keys [4], messages [5], and signatures [5] " +
+					"does not match",
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			valid, err := BatchVerifyG1(
+				test.publicKeys,
+				test.messages,
+				test.signatures,
+			)
+
+			if !reflect.DeepEqual(test.expectedError, err) {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			if test.expectedValid != valid {
+				t.Errorf(
+					"unexpected verification result\nexpected: [%v]\nactual:   [%v]",
+					test.expectedValid,
+					valid,
+				)
+			}
+		})
+	}
+}
+
+func TestBatchVerifySignatureShares(t *testing.T) {
+	message := altbn128.G1HashToPoint([]byte("relay entry"))
+	publicKeyShares, signatureShares := newTestSignatureShares(t, message, 5)
+
+	invalidShare := &SignatureShare{
+		I: 2,
+		V: SignG1(big.NewInt(1683), message),
+	}
+	unknownShare := &SignatureShare{
+		I: 6,
+		V: SignG1(big.NewInt(1683), message),
+	}
+
+	var tests = map[string]struct {
+		signatureShares       []*SignatureShare
+		expectedInvalidShares []*SignatureShare
+	}{
+		"all shares valid": {
+			signatureShares:       signatureShares,
+			expectedInvalidShares: nil,
+		},
+		"one share invalid": {
+			signatureShares: []*SignatureShare{
+				signatureShares[0],
+				invalidShare,
+				signatureShares[2],
+			},
+			expectedInvalidShares: []*SignatureShare{invalidShare},
+		},
+		"public key share missing": {
+			signatureShares: []*SignatureShare{
+				signatureShares[0],
+				unknownShare,
+			},
+			expectedInvalidShares: []*SignatureShare{unknownShare},
+		},
+		"all shares invalid": {
+			signatureShares: []*SignatureShare{
+				invalidShare,
+				unknownShare,
+			},
+			expectedInvalidShares: []*SignatureShare{unknownShare, invalidShare},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			invalidShares := BatchVerifySignatureShares(
+				publicKeyShares,
+				message,
+				test.signatureShares,
+			)
+
+			if !reflect.DeepEqual(test.expectedInvalidShares, invalidShares) {
+				t.Errorf(
+					"unexpected invalid shares\nexpected: [%v]\nactual:   [%v]",
+					test.expectedInvalidShares,
+					invalidShares,
+				)
+			}
+		})
+	}
+}
+
+func BenchmarkVerifySignatureShares(b *testing.B) {
+	for _, groupSize := range []int{16, 64} {
+		message := altbn128.G1HashToPoint([]byte("relay entry"))
+		publicKeyShares, signatureShares := newTestSignatureShares(
+			b,
+			message,
+			groupSize,
+		)
+
+		b.Run(fmt.Sprintf("individual/%v", groupSize), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j, signatureShare := range signatureShares {
+					if !VerifyG1(publicKeyShares[j].V, message, signatureShare.V) {
+						b.Fatal("invalid signature share")
+					}
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("batch/%v", groupSize), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				invalidShares := BatchVerifySignatureShares(
+					publicKeyShares,
+					message,
+					signatureShares,
+				)
+				if len(invalidShares) != 0 {
+					b.Fatal("invalid signature share")
+				}
+			}
+		})
+
+		// One invalid share makes the batch fail so the worst case is
+		// the batch verification followed by individual checks.
+		invalidShares := make([]*SignatureShare, len(signatureShares))
+		copy(invalidShares, signatureShares)
+		invalidShares[0] = &SignatureShare{
+			I: signatureShares[0].I,
+			V: SignG1(big.NewInt(1683), message),
+		}
+
+		b.Run(fmt.Sprintf("batch-fallback/%v", groupSize), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				BatchVerifySignatureShares(
+					publicKeyShares,
+					message,
+					invalidShares,
+				)
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyG1(b *testing.B) {
+	for _, count := range []int{16, 64} {
+		publicKeys, messages, signatures := newTestSignatures(b, count)
+
+		b.Run(fmt.Sprintf("individual/%v", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := range signatures {
+					if !VerifyG1(publicKeys[j], messages[j], signatures[j]) {
+						b.Fatal("invalid signature")
+					}
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("batch/%v", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				valid, err := BatchVerifyG1(publicKeys, messages, signatures)
+				if err != nil || !valid {
+					b.Fatal("invalid signature")
+				}
+			}
+		})
+	}
+}
+
+func newTestSignatures(
+	t testing.TB,
+	count int,
+) ([]*bn256.G2, []*bn256.G1, []*bn256.G1) {
+	publicKeys := make([]*bn256.G2, count)
+	messages := make([]*bn256.G1, count)
+	signatures := make([]*bn256.G1, count)
+
+	for i := 0; i < count; i++ {
+		secretKey, publicKey, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		publicKeys[i] = publicKey
+		messages[i] = altbn128.G1HashToPoint([]byte(fmt.Sprintf("message %v", i)))
+		signatures[i] = SignG1(secretKey, messages[i])
+	}
+
+	return publicKeys, messages, signatures
+}
+
+func newTestSignatureShares(
+	t testing.TB,
+	message *bn256.G1,
+	groupSize int,
+) ([]*PublicKeyShare, []*SignatureShare) {
+	threshold := groupSize/2 + 1
+
+	masterSecretKey := make([]*big.Int, threshold)
+	for i := range masterSecretKey {
+		secretKey, _, err := bn256.RandomG1(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		masterSecretKey[i] = secretKey
+	}
+
+	publicKeyShares := make([]*PublicKeyShare, groupSize)
+	signatureShares := make([]*SignatureShare, groupSize)
+
+	// Shares are 1-indexed.
+	for i := 1; i <= groupSize; i++ {
+		secretKeyShare := GetSecretKeyShare(masterSecretKey, i)
+		publicKeyShares[i-1] = secretKeyShare.PublicKeyShare()
+		signatureShares[i-1] = &SignatureShare{
+			I: i,
+			V: SignG1(secretKeyShare.V, message),
+		}
+	}
+
+	return publicKeyShares, signatureShares
+}
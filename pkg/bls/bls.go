@@ -1,6 +1,7 @@
 package bls
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"math/big"
@@ -9,6 +10,15 @@ import (
 	"github.com/keep-network/keep-core/pkg/altbn128"
 )
 
+const (
+	// maxDomainSeparationTagLength is the maximum length of the domain
+	// separation tag which is used as is. Longer tags are hashed.
+	maxDomainSeparationTagLength = 255
+	// oversizeDomainSeparationTagPrefix is the prefix of domain separation
+	// tags longer than maxDomainSeparationTagLength, as defined in RFC 9380.
+	oversizeDomainSeparationTagPrefix = "H2C-OVERSIZE-DST-"
+)
+
 // SecretKeyShare represents secret key share and its index.
 type SecretKeyShare struct {
 	I int      // Index of secret key share
@@ -51,6 +61,17 @@ func Sign(secretKey *big.Int, message []byte) *bn256.G1 {
 	return SignG1(secretKey, altbn128.G1HashToPoint(message))
 }
 
+// SignWithDomain creates a point on a curve G1 by hashing the provided message
+// with the given domain separation tag and signing it using the provided
+// secret key.
+func SignWithDomain(
+	secretKey *big.Int,
+	domainSeparationTag []byte,
+	message []byte,
+) *bn256.G1 {
+	return SignG1(secretKey, HashToG1(domainSeparationTag, message))
+}
+
 // SignG1 creates a point on a curve G1 by signing the provided
 // G1 point message using the provided secret key.
 func SignG1(secretKey *big.Int, message *bn256.G1) *bn256.G1 {
@@ -63,6 +84,22 @@ func Verify(publicKey *bn256.G2, message []byte, signature *bn256.G1) bool {
 	return VerifyG1(publicKey, altbn128.G1HashToPoint(message), signature)
 }
 
+// VerifyWithDomain performs the pairing operation to check if the signature is
+// correct for the provided message hashed with the given domain separation tag
+// and the corresponding public key.
+func VerifyWithDomain(
+	publicKey *bn256.G2,
+	domainSeparationTag []byte,
+	message []byte,
+	signature *bn256.G1,
+) bool {
+	return VerifyG1(
+		publicKey,
+		HashToG1(domainSeparationTag, message),
+		signature,
+	)
+}
+
 // VerifyG1 performs the pairing operation to check if the signature is correct
 // for the provided G1 point message and the corresponding public key.
 func VerifyG1(publicKey *bn256.G2, message *bn256.G1, signature *bn256.G1) bool {
@@ -75,6 +112,34 @@ func VerifyG1(publicKey *bn256.G2, message *bn256.G1, signature *bn256.G1) bool
 	return bn256.PairingCheck(a, b)
 }
 
+// HashToG1 hashes the provided message to a point on G1 using the given domain
+// separation tag. Signatures of the same message produced for different
+// domains are different so a signature produced for one purpose can not be
+// replayed for another one. The domain separation tag is appended to the
+// message along with its length, following the construction of RFC 9380;
+// tags longer than 255 bytes are hashed first. An empty domain separation tag
+// yields the same point as altbn128.G1HashToPoint so it is compatible with
+// Sign and Verify.
+func HashToG1(domainSeparationTag []byte, message []byte) *bn256.G1 {
+	if len(domainSeparationTag) == 0 {
+		return altbn128.G1HashToPoint(message)
+	}
+
+	if len(domainSeparationTag) > maxDomainSeparationTagLength {
+		hashedTag := sha256.Sum256(
+			append([]byte(oversizeDomainSeparationTagPrefix), domainSeparationTag...),
+		)
+		domainSeparationTag = hashedTag[:]
+	}
+
+	taggedMessage := make([]byte, 0, len(message)+len(domainSeparationTag)+1)
+	taggedMessage = append(taggedMessage, message...)
+	taggedMessage = append(taggedMessage, domainSeparationTag...)
+	taggedMessage = append(taggedMessage, byte(len(domainSeparationTag)))
+
+	return altbn128.G1HashToPoint(taggedMessage)
+}
+
 // RecoverSignature reconstructs the full BLS signature from a threshold number of
 // signature shares using Lagrange interpolation.
 func RecoverSignature(shares []*SignatureShare, threshold int) (*bn256.G1, error) {
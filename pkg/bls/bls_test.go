@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/altbn128"
 	"github.com/keep-network/keep-core/pkg/internal/testutils"
 )
 
@@ -37,6 +38,83 @@ func TestSignAndVerifyG1(t *testing.T) {
 	}
 }
 
+func TestSignAndVerifyWithDomain(t *testing.T) {
+	message := []byte("relay entry")
+
+	secretKey := big.NewInt(123)
+	publicKey := new(bn256.G2).ScalarBaseMult(secretKey)
+
+	longDomain := make([]byte, 300)
+	for i := range longDomain {
+		longDomain[i] = byte(i)
+	}
+
+	var tests = map[string]struct {
+		signingDomain      []byte
+		verificationDomain []byte
+		expectedValid      bool
+	}{
+		"the same domain": {
+			signingDomain:      []byte("KEEP-BEACON-V1"),
+			verificationDomain: []byte("KEEP-BEACON-V1"),
+			expectedValid:      true,
+		},
+		"different domains": {
+			signingDomain:      []byte("KEEP-BEACON-V1"),
+			verificationDomain: []byte("KEEP-BEACON-V2"),
+			expectedValid:      false,
+		},
+		"domain and no domain": {
+			signingDomain:      []byte("KEEP-BEACON-V1"),
+			verificationDomain: []byte{},
+			expectedValid:      false,
+		},
+		"the same long domain": {
+			signingDomain:      longDomain,
+			verificationDomain: longDomain,
+			expectedValid:      true,
+		},
+		"different long domains": {
+			signingDomain:      longDomain,
+			verificationDomain: longDomain[:299],
+			expectedValid:      false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			signature := SignWithDomain(secretKey, test.signingDomain, message)
+
+			valid := VerifyWithDomain(
+				publicKey,
+				test.verificationDomain,
+				message,
+				signature,
+			)
+
+			if test.expectedValid != valid {
+				t.Errorf(
+					"unexpected verification result\nexpected: [%v]\nactual:   [%v]",
+					test.expectedValid,
+					valid,
+				)
+			}
+		})
+	}
+}
+
+func TestHashToG1_NoDomain(t *testing.T) {
+	message := []byte("relay entry")
+
+	// Messages signed without a domain must stay compatible with Sign and
+	// Verify.
+	testutils.AssertBytesEqual(
+		t,
+		altbn128.G1HashToPoint(message).Marshal(),
+		HashToG1(nil, message).Marshal(),
+	)
+}
+
 // Test verifying BLS aggregated signature.
 func TestAggregateBLS(t *testing.T) {
 	// Public keys and signatures to aggregate.
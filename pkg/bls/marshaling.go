@@ -0,0 +1,189 @@
+package bls
+
+import (
+	"bytes"
+	"fmt"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/altbn128"
+)
+
+const (
+	// G1CompressedSize is the length of the compressed G1 point in bytes.
+	G1CompressedSize = 32
+	// G1UncompressedSize is the length of the uncompressed G1 point in bytes.
+	G1UncompressedSize = 64
+	// G2CompressedSize is the length of the compressed G2 point in bytes.
+	G2CompressedSize = 64
+	// G2UncompressedSize is the length of the uncompressed G2 point in bytes.
+	G2UncompressedSize = 128
+)
+
+// PublicKey represents BLS public key, a point on G2.
+type PublicKey struct {
+	V *bn256.G2
+}
+
+// Signature represents BLS signature, a point on G1.
+type Signature struct {
+	V *bn256.G1
+}
+
+// Marshal converts the public key to its compressed form.
+func (pk *PublicKey) Marshal() ([]byte, error) {
+	return compressG2(pk.V)
+}
+
+// MarshalUncompressed converts the public key to its uncompressed form.
+func (pk *PublicKey) MarshalUncompressed() ([]byte, error) {
+	if pk.V == nil {
+		return nil, fmt.Errorf("public key is not set")
+	}
+
+	return pk.V.Marshal(), nil
+}
+
+// Unmarshal converts the public key from its compressed or uncompressed form.
+// The form is determined by the length of the provided bytes.
+func (pk *PublicKey) Unmarshal(bytes []byte) error {
+	point, err := unmarshalG2(bytes)
+	if err != nil {
+		return fmt.Errorf("could not unmarshal public key: [%v]", err)
+	}
+
+	pk.V = point
+	return nil
+}
+
+// Marshal converts the signature to its compressed form.
+func (s *Signature) Marshal() ([]byte, error) {
+	return compressG1(s.V)
+}
+
+// MarshalUncompressed converts the signature to its uncompressed form.
+func (s *Signature) MarshalUncompressed() ([]byte, error) {
+	if s.V == nil {
+		return nil, fmt.Errorf("signature is not set")
+	}
+
+	return s.V.Marshal(), nil
+}
+
+// Unmarshal converts the signature from its compressed or uncompressed form.
+// The form is determined by the length of the provided bytes.
+func (s *Signature) Unmarshal(bytes []byte) error {
+	point, err := unmarshalG1(bytes)
+	if err != nil {
+		return fmt.Errorf("could not unmarshal signature: [%v]", err)
+	}
+
+	s.V = point
+	return nil
+}
+
+// Marshal converts the value of the signature share to its compressed form.
+// The index of the share is not a part of the result and has to be
+// transferred separately.
+func (s *SignatureShare) Marshal() ([]byte, error) {
+	return compressG1(s.V)
+}
+
+// MarshalUncompressed converts the value of the signature share to its
+// uncompressed form. The index of the share is not a part of the result and
+// has to be transferred separately.
+func (s *SignatureShare) MarshalUncompressed() ([]byte, error) {
+	if s.V == nil {
+		return nil, fmt.Errorf("signature share is not set")
+	}
+
+	return s.V.Marshal(), nil
+}
+
+// Unmarshal converts the value of the signature share from its compressed or
+// uncompressed form. The form is determined by the length of the provided
+// bytes. The index of the share is left untouched.
+func (s *SignatureShare) Unmarshal(bytes []byte) error {
+	point, err := unmarshalG1(bytes)
+	if err != nil {
+		return fmt.Errorf("could not unmarshal signature share: [%v]", err)
+	}
+
+	s.V = point
+	return nil
+}
+
+func compressG1(point *bn256.G1) ([]byte, error) {
+	if point == nil {
+		return nil, fmt.Errorf("point is not set")
+	}
+
+	marshalled := point.Marshal()
+
+	// The compression encodes the point with its X coordinate and the parity
+	// of its Y coordinate so the point at infinity, with both coordinates
+	// equal to zero, can not be compressed.
+	if isZero(marshalled[G1CompressedSize:]) {
+		return nil, fmt.Errorf("point at infinity can not be compressed")
+	}
+
+	return altbn128.G1Point{G1: point}.Compress(), nil
+}
+
+func compressG2(point *bn256.G2) ([]byte, error) {
+	if point == nil {
+		return nil, fmt.Errorf("point is not set")
+	}
+
+	marshalled := point.Marshal()
+
+	// See compressG1.
+	if isZero(marshalled[G2CompressedSize:]) {
+		return nil, fmt.Errorf("point at infinity can not be compressed")
+	}
+
+	return altbn128.G2Point{G2: point}.Compress(), nil
+}
+
+func unmarshalG1(bytes []byte) (*bn256.G1, error) {
+	switch len(bytes) {
+	case G1CompressedSize:
+		return altbn128.DecompressToG1(bytes)
+	case G1UncompressedSize:
+		point := new(bn256.G1)
+		if _, err := point.Unmarshal(bytes); err != nil {
+			return nil, err
+		}
+		return point, nil
+	default:
+		return nil, fmt.Errorf(
+			"invalid length [%v]; expected [%v] or [%v] bytes",
+			len(bytes),
+			G1CompressedSize,
+			G1UncompressedSize,
+		)
+	}
+}
+
+func unmarshalG2(bytes []byte) (*bn256.G2, error) {
+	switch len(bytes) {
+	case G2CompressedSize:
+		return altbn128.DecompressToG2(bytes)
+	case G2UncompressedSize:
+		point := new(bn256.G2)
+		if _, err := point.Unmarshal(bytes); err != nil {
+			return nil, err
+		}
+		return point, nil
+	default:
+		return nil, fmt.Errorf(
+			"invalid length [%v]; expected [%v] or [%v] bytes",
+			len(bytes),
+			G2CompressedSize,
+			G2UncompressedSize,
+		)
+	}
+}
+
+func isZero(value []byte) bool {
+	return bytes.Equal(value, make([]byte, len(value)))
+}
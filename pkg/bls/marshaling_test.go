@@ -0,0 +1,184 @@
+package bls
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestPublicKeyRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		_, point, err := bn256.RandomG2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		publicKey := &PublicKey{point}
+
+		compressed, err := publicKey.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertLength(t, compressed, G2CompressedSize)
+
+		uncompressed, err := publicKey.MarshalUncompressed()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertLength(t, uncompressed, G2UncompressedSize)
+
+		for _, marshaled := range [][]byte{compressed, uncompressed} {
+			unmarshaled := &PublicKey{}
+			if err := unmarshaled.Unmarshal(marshaled); err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBytesEqual(
+				t,
+				point.Marshal(),
+				unmarshaled.V.Marshal(),
+			)
+		}
+	}
+}
+
+func TestSignatureRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		_, point, err := bn256.RandomG1(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signature := &Signature{point}
+
+		compressed, err := signature.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertLength(t, compressed, G1CompressedSize)
+
+		uncompressed, err := signature.MarshalUncompressed()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertLength(t, uncompressed, G1UncompressedSize)
+
+		for _, marshaled := range [][]byte{compressed, uncompressed} {
+			unmarshaled := &Signature{}
+			if err := unmarshaled.Unmarshal(marshaled); err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBytesEqual(
+				t,
+				point.Marshal(),
+				unmarshaled.V.Marshal(),
+			)
+		}
+	}
+}
+
+func TestSignatureShareRoundtrip(t *testing.T) {
+	_, point, err := bn256.RandomG1(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	share := &SignatureShare{I: 3, V: point}
+
+	compressed, err := share.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertLength(t, compressed, G1CompressedSize)
+
+	unmarshaled := &SignatureShare{I: 3}
+	if err := unmarshaled.Unmarshal(compressed); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(share, unmarshaled) {
+		t.Errorf(
+			"unexpected signature share\nexpected: [%v]\nactual:   [%v]",
+			share,
+			unmarshaled,
+		)
+	}
+}
+
+func TestMarshal_PointAtInfinity(t *testing.T) {
+	_, err := (&Signature{new(bn256.G1)}).Marshal()
+
+	expectedError := fmt.Errorf("point at infinity can not be compressed")
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+
+	if _, err := (&PublicKey{new(bn256.G2)}).Marshal(); err == nil {
+		t.Errorf("expected error on compressing point at infinity")
+	}
+}
+
+func TestUnmarshal_InvalidLength(t *testing.T) {
+	err := (&Signature{}).Unmarshal(make([]byte, 48))
+
+	expectedError := fmt.Errorf(
+		"could not unmarshal signature: " +
+			"[invalid length [48]; expected [32] or [64] bytes]",
+	)
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestUnmarshal_NotOnCurve(t *testing.T) {
+	// Both X and Y are equal to 1.
+	uncompressedG1 := append(
+		padTo32Bytes(big.NewInt(1)),
+		padTo32Bytes(big.NewInt(1))...,
+	)
+	if err := (&Signature{}).Unmarshal(uncompressedG1); err == nil {
+		t.Errorf("expected error on unmarshaling point not on the curve")
+	}
+
+	// Random bytes do not decompress to a valid G2 point with overwhelming
+	// probability and must never crash the decompression.
+	for i := 0; i < 20; i++ {
+		compressedG2 := make([]byte, G2CompressedSize)
+		if _, err := rand.Read(compressedG2); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := (&PublicKey{}).Unmarshal(compressedG2); err == nil {
+			t.Errorf("expected error on decompressing random bytes")
+		}
+	}
+}
+
+func padTo32Bytes(value *big.Int) []byte {
+	bytes := make([]byte, 32)
+	return value.FillBytes(bytes)
+}
+
+func assertLength(t *testing.T, bytes []byte, expectedLength int) {
+	if len(bytes) != expectedLength {
+		t.Errorf(
+			"unexpected length\nexpected: [%v]\nactual:   [%v]",
+			expectedLength,
+			len(bytes),
+		)
+	}
+}
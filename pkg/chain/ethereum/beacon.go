@@ -1,26 +1,27 @@
 package ethereum
 
 import (
-	"context"
-	"encoding/binary"
-	"encoding/hex"
 	"fmt"
 	"math/big"
-	"math/rand"
-	"sync"
+	"sort"
 
+	hostchainabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
 
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	beaconchain "github.com/keep-network/keep-core/pkg/beacon/chain"
 	"github.com/keep-network/keep-core/pkg/beacon/event"
-	"github.com/keep-network/keep-core/pkg/subscription"
-
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/bls"
 	"github.com/keep-network/keep-core/pkg/chain"
+	beaconabi "github.com/keep-network/keep-core/pkg/chain/ethereum/beacon/gen/abi"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum/beacon/gen/contract"
 	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/subscription"
 )
 
 // Definitions of contract names.
@@ -28,6 +29,28 @@ const (
 	RandomBeaconContractName = "RandomBeacon"
 )
 
+const (
+	// beaconGroupSize is the size of a group in the random beacon, as set in
+	// the RandomBeacon contract.
+	beaconGroupSize = 64
+	// beaconHonestThreshold is the minimum number of active group members
+	// needed to generate a relay entry, as set in the RandomBeacon contract.
+	beaconHonestThreshold = 33
+	// beaconResultPublicationBlockStep is the number of blocks between
+	// the eligibility of subsequent group members to submit a result.
+	beaconResultPublicationBlockStep = 1
+	// beaconEventsLookbackBlocks is the number of blocks searched back for
+	// past events describing the state of the DKG and the relay request in
+	// progress, not exposed by the RandomBeacon contract directly. Both the
+	// DKG and the relay request are expected to complete well within that
+	// period.
+	beaconEventsLookbackBlocks = 20000
+	// beaconOffchainDkgTime is the number of blocks the off-chain part of
+	// the DKG takes, after which the result submission period starts, as set
+	// in the RandomBeacon contract.
+	beaconOffchainDkgTime = 72
+)
+
 // BeaconChain represents a beacon-specific chain handle.
 type BeaconChain struct {
 	*baseChain
@@ -35,7 +58,18 @@ type BeaconChain struct {
 	randomBeacon  *contract.RandomBeacon
 	sortitionPool *contract.BeaconSortitionPool
 
-	mockRandomBeacon *mockRandomBeacon
+	// randomBeaconTransactor and sortitionPoolTransactor submit
+	// transactions signed with the operator's transactor options. Apart from
+	// the time-critical transactions sent by the submission manager, they are
+	// submitted with baseChain.transact.
+	randomBeaconTransactor  *beaconabi.RandomBeaconTransactor
+	sortitionPoolTransactor *beaconabi.BeaconSortitionPoolTransactor
+	submissionManager       *submissionManager
+
+	config                     *beaconchain.Config
+	groupLifetime              uint64
+	relayEntrySoftTimeout      uint64
+	dkgResultSubmissionTimeout uint64
 }
 
 // newBeaconChain construct a new instance of the beacon-specific Ethereum
@@ -71,6 +105,17 @@ func newBeaconChain(
 		)
 	}
 
+	return attachBeaconChain(baseChain, randomBeaconAddress, randomBeacon)
+}
+
+// attachBeaconChain constructs the beacon-specific chain handle for
+// the given RandomBeacon contract. The parameters of the group creation and
+// relay entries are read from the contract once, during the construction.
+func attachBeaconChain(
+	baseChain *baseChain,
+	randomBeaconAddress common.Address,
+	randomBeacon *contract.RandomBeacon,
+) (*BeaconChain, error) {
 	sortitionPoolAddress, err := randomBeacon.SortitionPool()
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -97,28 +142,91 @@ func newBeaconChain(
 		)
 	}
 
+	groupCreationParameters, err := randomBeacon.GroupCreationParameters()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get group creation parameters: [%v]",
+			err,
+		)
+	}
+
+	relayEntryParameters, err := randomBeacon.RelayEntryParameters()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get relay entry parameters: [%v]",
+			err,
+		)
+	}
+
+	randomBeaconTransactor, err := beaconabi.NewRandomBeaconTransactor(
+		randomBeaconAddress,
+		baseChain.client,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to instantiate RandomBeacon transactor: [%v]",
+			err,
+		)
+	}
+
+	sortitionPoolTransactor, err := beaconabi.NewBeaconSortitionPoolTransactor(
+		sortitionPoolAddress,
+		baseChain.client,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to instantiate BeaconSortitionPool transactor: [%v]",
+			err,
+		)
+	}
+
+	submissionManager := newSubmissionManager(baseChain)
+
+	relayEntrySoftTimeout := relayEntryParameters.RelayEntrySoftTimeout.Uint64()
+	relayEntryHardTimeout := relayEntryParameters.RelayEntryHardTimeout.Uint64()
+
 	return &BeaconChain{
-		baseChain:        baseChain,
-		randomBeacon:     randomBeacon,
-		sortitionPool:    sortitionPool,
-		mockRandomBeacon: newMockRandomBeacon(baseChain.blockCounter),
+		baseChain:               baseChain,
+		randomBeacon:            randomBeacon,
+		sortitionPool:           sortitionPool,
+		randomBeaconTransactor:  randomBeaconTransactor,
+		sortitionPoolTransactor: sortitionPoolTransactor,
+		submissionManager:       submissionManager,
+		config: &beaconchain.Config{
+			GroupSize:                  beaconGroupSize,
+			HonestThreshold:            beaconHonestThreshold,
+			ResultPublicationBlockStep: beaconResultPublicationBlockStep,
+			// The relay entry timeout can be reported once both the soft
+			// and the hard timeout passed.
+			RelayEntryTimeout: relayEntrySoftTimeout + relayEntryHardTimeout,
+			DKGResultChallengePeriodLength: groupCreationParameters.
+				DkgResultChallengePeriodLength.Uint64(),
+			DKGResultSubmitterPrecedencePeriodLength: groupCreationParameters.
+				DkgSubmitterPrecedencePeriodLength.Uint64(),
+		},
+		groupLifetime:         groupCreationParameters.GroupLifetime.Uint64(),
+		relayEntrySoftTimeout: relayEntrySoftTimeout,
+		dkgResultSubmissionTimeout: groupCreationParameters.
+			DkgResultSubmissionTimeout.Uint64(),
 	}, nil
 }
 
+// ConfigureSubmissions sets the gas price strategy of the relay entry and
+// DKG result submissions.
+func (bc *BeaconChain) ConfigureSubmissions(config SubmissionConfig) error {
+	return bc.submissionManager.configure(config)
+}
+
+// Submissions returns the most recent relay entry and DKG result
+// submissions, along with the transactions sent for them, from the oldest
+// to the newest one.
+func (bc *BeaconChain) Submissions() []SubmissionRecord {
+	return bc.submissionManager.submissions()
+}
+
 // GetConfig returns the expected configuration of the random beacon.
-// TODO: Adjust to the random beacon v2 requirements.
 func (bc *BeaconChain) GetConfig() *beaconchain.Config {
-	groupSize := 64
-	honestThreshold := 33
-	resultPublicationBlockStep := 1
-	relayEntryTimeout := groupSize * resultPublicationBlockStep
-
-	return &beaconchain.Config{
-		GroupSize:                  groupSize,
-		HonestThreshold:            honestThreshold,
-		ResultPublicationBlockStep: uint64(resultPublicationBlockStep),
-		RelayEntryTimeout:          uint64(relayEntryTimeout),
-	}
+	return bc.config
 }
 
 // Staking returns address of the TokenStaking contract the RandomBeacon is
@@ -201,14 +309,27 @@ func (bc *BeaconChain) IsOperatorUpToDate() (bool, error) {
 // JoinSortitionPool executes a transaction to have the operator join the
 // sortition pool.
 func (bc *BeaconChain) JoinSortitionPool() error {
-	_, err := bc.randomBeacon.JoinSortitionPool()
+	_, err := bc.transact(
+		"joinSortitionPool",
+		func(transactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			return bc.randomBeaconTransactor.JoinSortitionPool(transactorOptions)
+		},
+	)
 	return err
 }
 
 // UpdateOperatorStatus executes a transaction to update the operator's state in
 // the sortition pool.
 func (bc *BeaconChain) UpdateOperatorStatus() error {
-	_, err := bc.randomBeacon.UpdateOperatorStatus(bc.key.Address)
+	_, err := bc.transact(
+		"updateOperatorStatus",
+		func(transactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			return bc.randomBeaconTransactor.UpdateOperatorStatus(
+				transactorOptions,
+				bc.key.Address,
+			)
+		},
+	)
 	return err
 }
 
@@ -226,23 +347,30 @@ func (bc *BeaconChain) CanRestoreRewardEligibility() (bool, error) {
 
 // Restores reward eligibility for the operator.
 func (bc *BeaconChain) RestoreRewardEligibility() error {
-	_, err := bc.sortitionPool.RestoreRewardEligibility(bc.key.Address)
+	_, err := bc.transact(
+		"restoreRewardEligibility",
+		func(transactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			return bc.sortitionPoolTransactor.RestoreRewardEligibility(
+				transactorOptions,
+				bc.key.Address,
+			)
+		},
+	)
 	return err
 }
 
 // SelectGroup returns the group members for the group generated by
 // the given seed. This function can return an error if the beacon chain's
 // state does not allow for group selection at the moment.
+//
+// The RandomBeacon contract selects the group using the seed of the DKG in
+// progress so the given seed is expected to be the seed of that DKG.
 func (bc *BeaconChain) SelectGroup(seed *big.Int) ([]chain.Address, error) {
-	groupSize := big.NewInt(int64(bc.GetConfig().GroupSize))
-	seedBytes := [32]byte{}
-	seed.FillBytes(seedBytes[:])
-
-	// TODO: Replace with a call to the RandomBeacon.selectGroup function.
-	operatorsIDs, err := bc.sortitionPool.SelectGroup(groupSize, seedBytes)
+	operatorsIDs, err := bc.randomBeacon.SelectGroup()
 	if err != nil {
 		return nil, fmt.Errorf(
-			"cannot select group in the sortition pool: [%v]",
+			"cannot select group for seed [0x%x]: [%v]",
+			seed,
 			err,
 		)
 	}
@@ -263,69 +391,575 @@ func (bc *BeaconChain) SelectGroup(seed *big.Int) ([]chain.Address, error) {
 	return result, nil
 }
 
-// TODO: Implement a real OnGroupRegistered function.
+// OnGroupRegistered registers a callback that is invoked when an on-chain
+// notification of a new group being registered is seen. As the event holds
+// only the hash of the group public key, the key is read from the group
+// registered on the chain.
 func (bc *BeaconChain) OnGroupRegistered(
 	handler func(groupRegistration *event.GroupRegistration),
 ) subscription.EventSubscription {
-	return subscription.NewEventSubscription(func() {})
+	onEvent := func(
+		groupID uint64,
+		groupPubKeyHash common.Hash,
+		blockNumber uint64,
+	) {
+		group, err := bc.randomBeacon.GetGroup(groupID)
+		if err != nil {
+			logger.Errorf(
+				"could not get group [%v] registered at block [%v]: [%v]",
+				groupID,
+				blockNumber,
+				err,
+			)
+			return
+		}
+
+		handler(&event.GroupRegistration{
+			GroupPublicKey: group.GroupPubKey,
+			BlockNumber:    blockNumber,
+		})
+	}
+
+	return bc.randomBeacon.
+		GroupRegisteredEvent(nil, nil, nil).
+		OnEvent(onEvent)
 }
 
-// TODO: Implement a real IsGroupRegistered function.
+// IsGroupRegistered checks if group with the given public key is registered
+// on-chain.
 func (bc *BeaconChain) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
-	return bc.mockRandomBeacon.IsGroupRegistered(groupPublicKey)
+	group, err := bc.randomBeacon.GetGroup0(groupPublicKey)
+	if err != nil {
+		return false, fmt.Errorf(
+			"cannot get group with public key [0x%x]: [%v]",
+			groupPublicKey,
+			err,
+		)
+	}
+
+	return isGroupRegistered(group), nil
 }
 
-// TODO: Implement a real IsStaleGroup function.
+// IsStaleGroup checks if a group with the given public key is considered
+// as stale on-chain. The group is stale if it was terminated or if it expired
+// and the relay entry timeout following the expiration passed. The group
+// is not stale if it is not registered.
 func (bc *BeaconChain) IsStaleGroup(groupPublicKey []byte) (bool, error) {
-	return false, nil
+	group, err := bc.randomBeacon.GetGroup0(groupPublicKey)
+	if err != nil {
+		return false, fmt.Errorf(
+			"cannot get group with public key [0x%x]: [%v]",
+			groupPublicKey,
+			err,
+		)
+	}
+
+	if !isGroupRegistered(group) {
+		return false, nil
+	}
+
+	if group.Terminated {
+		return true, nil
+	}
+
+	currentBlock, err := bc.blockCounter.CurrentBlock()
+	if err != nil {
+		return false, fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	staleBlock := group.RegistrationBlockNumber.Uint64() +
+		bc.groupLifetime +
+		bc.config.RelayEntryTimeout
+
+	return currentBlock > staleBlock, nil
+}
+
+// isGroupRegistered checks if the given group, as returned by
+// the RandomBeacon contract, is registered. The contract returns an empty
+// group for unknown groups.
+func isGroupRegistered(group beaconabi.GroupsGroup) bool {
+	return group.RegistrationBlockNumber != nil &&
+		group.RegistrationBlockNumber.Sign() > 0
 }
 
-// TODO: Implement a real OnDKGStarted event subscription. The current
-//       implementation generates a fake event every 500th block where the
-//       seed is the keccak256 of the block number.
+// OnDKGStarted registers a callback that is invoked when an on-chain
+// notification of the DKG process start is seen.
 func (bc *BeaconChain) OnDKGStarted(
 	handler func(event *event.DKGStarted),
 ) subscription.EventSubscription {
-	return bc.mockRandomBeacon.OnDKGStarted(handler)
+	onEvent := func(seed *big.Int, blockNumber uint64) {
+		handler(&event.DKGStarted{
+			Seed:        seed,
+			BlockNumber: blockNumber,
+		})
+	}
+
+	return bc.randomBeacon.DkgStartedEvent(nil, nil).OnEvent(onEvent)
 }
 
-// TODO: Implement a real SubmitDKGResult action. The current implementation
-//       just creates and pipes the DKG submission event to the handlers
-//       registered in the dkgResultSubmissionHandlers map.
+// GetDKGState returns the current state of the DKG procedure.
+func (bc *BeaconChain) GetDKGState() (beaconchain.DKGState, error) {
+	state, err := bc.randomBeacon.GetGroupCreationState()
+	if err != nil {
+		return 0, fmt.Errorf("cannot get group creation state: [%v]", err)
+	}
+
+	switch state {
+	case 0:
+		return beaconchain.Idle, nil
+	case 1:
+		return beaconchain.AwaitingSeed, nil
+	case 2:
+		return beaconchain.AwaitingResult, nil
+	case 3:
+		return beaconchain.Challenge, nil
+	default:
+		return 0, fmt.Errorf("unexpected group creation state [%v]", state)
+	}
+}
+
+// SubmitDKGResult sends DKG result to a chain, along with signatures over
+// result hash from group participants supporting the result. The members
+// of the group are the ones currently selected by the RandomBeacon contract.
+// The submission is replaced with a higher gas price until it is mined or
+// the result submission period ends. It is cancelled if another result is
+// submitted first.
 func (bc *BeaconChain) SubmitDKGResult(
 	participantIndex beaconchain.GroupMemberIndex,
 	dkgResult *beaconchain.DKGResult,
 	signatures map[beaconchain.GroupMemberIndex][]byte,
 ) error {
-	return bc.mockRandomBeacon.SubmitDKGResult(
+	members, err := bc.randomBeacon.SelectGroup()
+	if err != nil {
+		return fmt.Errorf("cannot get selected group members: [%v]", err)
+	}
+
+	result, err := convertDKGResultToChain(
 		participantIndex,
 		dkgResult,
 		signatures,
+		members,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot convert DKG result: [%v]", err)
+	}
+
+	deadlineBlock, err := bc.dkgResultSubmissionDeadline()
+	if err != nil {
+		return err
+	}
+
+	return bc.submissionManager.submit(
+		fmt.Sprintf("DKG result of member [%v]", participantIndex),
+		deadlineBlock,
+		func(transactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			transaction, err := bc.randomBeaconTransactor.SubmitDkgResult(
+				transactorOptions,
+				*result,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("cannot submit DKG result: [%v]", err)
+			}
+
+			return transaction, nil
+		},
+		func(handler func()) subscription.EventSubscription {
+			return bc.OnDKGResultSubmitted(
+				func(event *event.DKGResultSubmission) {
+					handler()
+				},
+			)
+		},
+	)
+}
+
+// dkgResultSubmissionDeadline returns the last block in which the result of
+// the most recent DKG can be submitted. The result submission period starts
+// once the off-chain part of the DKG completes and starts over each time
+// a submitted result is challenged.
+func (bc *BeaconChain) dkgResultSubmissionDeadline() (uint64, error) {
+	startBlock, err := bc.dkgStartBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	submissionStartBlock := startBlock + beaconOffchainDkgTime
+
+	challenges, err := bc.randomBeacon.PastDkgResultChallengedEvents(
+		startBlock,
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get DKG result challenges: [%v]", err)
+	}
+
+	if len(challenges) > 0 {
+		submissionStartBlock = challenges[len(challenges)-1].Raw.BlockNumber
+	}
+
+	return submissionStartBlock + bc.dkgResultSubmissionTimeout, nil
+}
+
+// convertDKGResultToChain converts the DKG result along with supporting
+// signatures to the format expected by the RandomBeacon contract. Signatures
+// are concatenated in the ascending order of signing members indexes.
+// The members hash is calculated over the members that did not misbehave.
+func convertDKGResultToChain(
+	participantIndex beaconchain.GroupMemberIndex,
+	dkgResult *beaconchain.DKGResult,
+	signatures map[beaconchain.GroupMemberIndex][]byte,
+	members []uint32,
+) (*beaconabi.BeaconDkgResult, error) {
+	signingMembersIndexes := make([]beaconchain.GroupMemberIndex, 0)
+	for memberIndex := range signatures {
+		signingMembersIndexes = append(signingMembersIndexes, memberIndex)
+	}
+	sort.Slice(signingMembersIndexes, func(i, j int) bool {
+		return signingMembersIndexes[i] < signingMembersIndexes[j]
+	})
+
+	signingMembersIndices := make([]*big.Int, len(signingMembersIndexes))
+	signaturesBytes := make([]byte, 0)
+	for i, memberIndex := range signingMembersIndexes {
+		signature := signatures[memberIndex]
+		if len(signature) != ethutil.SignatureSize {
+			return nil, fmt.Errorf(
+				"invalid signature size of member [%v]; "+
+					"expected [%v] bytes, got [%v] bytes",
+				memberIndex,
+				ethutil.SignatureSize,
+				len(signature),
+			)
+		}
+
+		signingMembersIndices[i] = big.NewInt(int64(memberIndex))
+		signaturesBytes = append(signaturesBytes, signature...)
+	}
+
+	membersHash, err := calculateMembersHash(
+		activeMembers(members, dkgResult.Misbehaved),
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &beaconabi.BeaconDkgResult{
+		SubmitterMemberIndex:     big.NewInt(int64(participantIndex)),
+		GroupPubKey:              dkgResult.GroupPublicKey,
+		MisbehavedMembersIndices: dkgResult.Misbehaved,
+		Signatures:               signaturesBytes,
+		SigningMembersIndices:    signingMembersIndices,
+		Members:                  members,
+		MembersHash:              membersHash,
+	}, nil
+}
+
+// activeMembers returns members that are not marked as misbehaved by
+// the given 1-based misbehaved members indexes.
+func activeMembers(members []uint32, misbehaved []uint8) []uint32 {
+	misbehavedSet := make(map[int]bool)
+	for _, memberIndex := range misbehaved {
+		misbehavedSet[int(memberIndex)] = true
+	}
+
+	active := make([]uint32, 0, len(members))
+	for i, member := range members {
+		if !misbehavedSet[i+1] {
+			active = append(active, member)
+		}
+	}
+
+	return active
+}
+
+// calculateMembersHash calculates the hash of the given group members
+// the same way as the RandomBeacon contract, i.e. as a Keccak-256 hash of
+// the ABI-encoded members array.
+func calculateMembersHash(members []uint32) ([32]byte, error) {
+	uint32SliceType, err := hostchainabi.NewType("uint32[]", "", nil)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	encodedMembers, err := hostchainabi.Arguments{
+		{Type: uint32SliceType},
+	}.Pack(members)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("cannot encode members: [%v]", err)
+	}
+
+	return crypto.Keccak256Hash(encodedMembers), nil
 }
 
-// TODO: Implement a real OnDKGResultSubmitted event subscription. The current
-//       implementation just pipes the DKG submission event generated within
-//       SubmitDKGResult to the handlers registered in the
-//       dkgResultSubmissionHandlers map.
+// OnDKGResultSubmitted registers a callback that is invoked when an on-chain
+// notification of a new, valid submitted result is seen.
 func (bc *BeaconChain) OnDKGResultSubmitted(
 	handler func(event *event.DKGResultSubmission),
 ) subscription.EventSubscription {
-	return bc.mockRandomBeacon.OnDKGResultSubmitted(handler)
+	onEvent := func(
+		resultHash [32]byte,
+		seed *big.Int,
+		result beaconabi.BeaconDkgResult,
+		blockNumber uint64,
+	) {
+		handler(&event.DKGResultSubmission{
+			ResultHash:     resultHash,
+			Seed:           seed,
+			MemberIndex:    uint32(result.SubmitterMemberIndex.Uint64()),
+			GroupPublicKey: result.GroupPubKey,
+			Misbehaved:     result.MisbehavedMembersIndices,
+			BlockNumber:    blockNumber,
+		})
+	}
+
+	return bc.randomBeacon.
+		DkgResultSubmittedEvent(nil, nil, nil).
+		OnEvent(onEvent)
+}
+
+// OnDKGResultChallenged registers a callback that is invoked when an
+// on-chain notification of a successful DKG result challenge is seen.
+func (bc *BeaconChain) OnDKGResultChallenged(
+	handler func(event *event.DKGResultChallenged),
+) subscription.EventSubscription {
+	onEvent := func(
+		resultHash [32]byte,
+		challenger common.Address,
+		reason string,
+		blockNumber uint64,
+	) {
+		handler(&event.DKGResultChallenged{
+			ResultHash:  resultHash,
+			Reason:      reason,
+			BlockNumber: blockNumber,
+		})
+	}
+
+	return bc.randomBeacon.
+		DkgResultChallengedEvent(nil, nil, nil).
+		OnEvent(onEvent)
+}
+
+// OnDKGResultApproved registers a callback that is invoked when an on-chain
+// notification of the DKG result approval is seen.
+func (bc *BeaconChain) OnDKGResultApproved(
+	handler func(event *event.DKGResultApproved),
+) subscription.EventSubscription {
+	onEvent := func(
+		resultHash [32]byte,
+		approver common.Address,
+		blockNumber uint64,
+	) {
+		handler(&event.DKGResultApproved{
+			ResultHash:  resultHash,
+			BlockNumber: blockNumber,
+		})
+	}
+
+	return bc.randomBeacon.
+		DkgResultApprovedEvent(nil, nil, nil).
+		OnEvent(onEvent)
+}
+
+// ChallengeDKGResult challenges the submitted DKG result identified by
+// the given result hash. The contract validates the result and the challenge
+// transaction fails if the result is valid.
+func (bc *BeaconChain) ChallengeDKGResult(resultHash [32]byte) error {
+	currentBlock, err := bc.blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	result, err := bc.submittedDKGResult(resultHash, currentBlock)
+	if err != nil {
+		return err
+	}
+
+	if _, err := bc.transact(
+		"challengeDkgResult",
+		func(transactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			return bc.randomBeaconTransactor.ChallengeDkgResult(
+				transactorOptions,
+				*result,
+			)
+		},
+	); err != nil {
+		return fmt.Errorf(
+			"cannot challenge DKG result [0x%x]: [%v]",
+			resultHash,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// ApproveDKGResult approves the submitted DKG result identified by the given
+// result hash. The result can be approved once the challenge period is over.
+// Only the submitter of the result can approve it during the submitter
+// precedence period.
+func (bc *BeaconChain) ApproveDKGResult(resultHash [32]byte) error {
+	currentBlock, err := bc.blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	result, err := bc.submittedDKGResult(resultHash, currentBlock)
+	if err != nil {
+		return err
+	}
+
+	if _, err := bc.transact(
+		"approveDkgResult",
+		func(transactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			return bc.randomBeaconTransactor.ApproveDkgResult(
+				transactorOptions,
+				*result,
+			)
+		},
+	); err != nil {
+		return fmt.Errorf(
+			"cannot approve DKG result [0x%x]: [%v]",
+			resultHash,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// submittedDKGResult returns the DKG result with the given hash, as
+// submitted to the RandomBeacon contract not later than at the given block.
+// The contract keeps only the hash of the submitted result so the result is
+// read from the submission event.
+func (bc *BeaconChain) submittedDKGResult(
+	resultHash [32]byte,
+	endBlock uint64,
+) (*beaconabi.BeaconDkgResult, error) {
+	startBlock := eventsLookbackStartBlock(endBlock)
+
+	events, err := bc.randomBeacon.PastDkgResultSubmittedEvents(
+		startBlock,
+		&endBlock,
+		[][32]byte{resultHash},
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get DKG result [0x%x] submission: [%v]",
+			resultHash,
+			err,
+		)
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf(
+			"DKG result [0x%x] was not submitted since block [%v]",
+			resultHash,
+			startBlock,
+		)
+	}
+
+	return &events[len(events)-1].Result, nil
 }
 
 // CalculateDKGResultHash calculates Keccak-256 hash of the DKG result. Operation
 // is performed off-chain.
 //
-// It first encodes the result using solidity ABI and then calculates Keccak-256
-// hash over it. This corresponds to the DKG result hash calculation on-chain.
-// Hashes calculated off-chain and on-chain must always match.
+// It first encodes the chain ID, the group public key, misbehaved members
+// indexes and the DKG start block using solidity ABI and then calculates
+// Keccak-256 hash over it. This corresponds to the hash of the DKG result
+// signed by group members and verified on-chain. Hashes calculated off-chain
+// and on-chain must always match.
 func (bc *BeaconChain) CalculateDKGResultHash(
 	dkgResult *beaconchain.DKGResult,
 ) (beaconchain.DKGResultHash, error) {
-	// Encode DKG result to the format matched with Solidity keccak256(abi.encodePacked(...))
-	hash := crypto.Keccak256(dkgResult.GroupPublicKey, dkgResult.Misbehaved)
-	return beaconchain.DKGResultHashFromBytes(hash)
+	startBlock, err := bc.dkgStartBlock()
+	if err != nil {
+		return beaconchain.DKGResultHash{}, err
+	}
+
+	return calculateDKGResultHash(bc.chainID, dkgResult, startBlock)
+}
+
+// calculateDKGResultHash calculates the hash of the DKG result for the DKG
+// started at the given block on the chain with the given ID.
+func calculateDKGResultHash(
+	chainID *big.Int,
+	dkgResult *beaconchain.DKGResult,
+	startBlock uint64,
+) (beaconchain.DKGResultHash, error) {
+	uint256Type, err := hostchainabi.NewType("uint256", "", nil)
+	if err != nil {
+		return beaconchain.DKGResultHash{}, err
+	}
+	bytesType, err := hostchainabi.NewType("bytes", "", nil)
+	if err != nil {
+		return beaconchain.DKGResultHash{}, err
+	}
+	uint8SliceType, err := hostchainabi.NewType("uint8[]", "", nil)
+	if err != nil {
+		return beaconchain.DKGResultHash{}, err
+	}
+
+	misbehaved := dkgResult.Misbehaved
+	if misbehaved == nil {
+		misbehaved = []uint8{}
+	}
+
+	encodedResult, err := hostchainabi.Arguments{
+		{Type: uint256Type},
+		{Type: bytesType},
+		{Type: uint8SliceType},
+		{Type: uint256Type},
+	}.Pack(
+		chainID,
+		dkgResult.GroupPublicKey,
+		misbehaved,
+		new(big.Int).SetUint64(startBlock),
+	)
+	if err != nil {
+		return beaconchain.DKGResultHash{}, fmt.Errorf(
+			"cannot encode DKG result: [%v]",
+			err,
+		)
+	}
+
+	return beaconchain.DKGResultHashFromBytes(crypto.Keccak256(encodedResult))
+}
+
+// dkgStartBlock returns the start block of the most recent DKG.
+func (bc *BeaconChain) dkgStartBlock() (uint64, error) {
+	currentBlock, err := bc.blockCounter.CurrentBlock()
+	if err != nil {
+		return 0, fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	startBlock := eventsLookbackStartBlock(currentBlock)
+
+	events, err := bc.randomBeacon.PastDkgStartedEvents(startBlock, nil, nil)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get DKG start events: [%v]", err)
+	}
+
+	if len(events) == 0 {
+		return 0, fmt.Errorf("DKG was not started since block [%v]", startBlock)
+	}
+
+	return events[len(events)-1].Raw.BlockNumber, nil
+}
+
+// eventsLookbackStartBlock returns the block from which the past events
+// preceding the given block are searched for the state of the DKG and
+// the relay request.
+func eventsLookbackStartBlock(endBlock uint64) uint64 {
+	if endBlock < beaconEventsLookbackBlocks {
+		return 0
+	}
+
+	return endBlock - beaconEventsLookbackBlocks
 }
 
 // IsRecognized checks whether the given operator is recognized by the BeaconChain
@@ -375,342 +1009,407 @@ func (bc *BeaconChain) IsRecognized(operatorPublicKey *operator.PublicKey) (bool
 	return true, nil
 }
 
-// TODO: Implement a real SubmitRelayEntry function.
-func (bc *BeaconChain) SubmitRelayEntry(
-	entry []byte,
-) error {
-	return bc.mockRandomBeacon.SubmitRelayEntry(entry)
+// relayRequest holds the state of the relay request in progress, not exposed
+// by the RandomBeacon contract directly.
+type relayRequest struct {
+	requestID     *big.Int
+	groupID       uint64
+	previousEntry []byte
+	startBlock    uint64
 }
 
-// TODO: Implement a real OnRelayEntrySubmitted function.
-func (bc *BeaconChain) OnRelayEntrySubmitted(
-	handler func(entry *event.RelayEntrySubmitted),
-) subscription.EventSubscription {
-	return bc.mockRandomBeacon.OnRelayEntrySubmitted(handler)
-}
+// currentRelayRequest returns the relay request in progress, read from
+// the most recent relay entry request event. Returns nil if there is no
+// relay request in progress.
+func (bc *BeaconChain) currentRelayRequest() (*relayRequest, error) {
+	inProgress, err := bc.randomBeacon.IsRelayRequestInProgress()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot check if relay request is in progress: [%v]",
+			err,
+		)
+	}
 
-// TODO: Implement a real OnRelayEntryRequested function.
-func (bc *BeaconChain) OnRelayEntryRequested(
-	handler func(request *event.RelayEntryRequested),
-) subscription.EventSubscription {
-	return bc.mockRandomBeacon.OnRelayEntryRequested(handler)
-}
+	if !inProgress {
+		return nil, nil
+	}
 
-// TODO: Implement a real ReportRelayEntryTimeout function.
-func (bc *BeaconChain) ReportRelayEntryTimeout() error {
-	return bc.mockRandomBeacon.ReportRelayEntryTimeout()
-}
+	currentBlock, err := bc.blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get current block: [%v]", err)
+	}
 
-// TODO: Implement a real IsEntryInProgress function.
-func (bc *BeaconChain) IsEntryInProgress() (bool, error) {
-	return bc.mockRandomBeacon.IsEntryInProgress()
-}
+	startBlock := eventsLookbackStartBlock(currentBlock)
 
-// TODO: Implement a real CurrentRequestStartBlock function.
-func (bc *BeaconChain) CurrentRequestStartBlock() (*big.Int, error) {
-	return bc.mockRandomBeacon.CurrentRequestStartBlock()
-}
+	events, err := bc.randomBeacon.PastRelayEntryRequestedEvents(
+		startBlock,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get relay entry requests: [%v]", err)
+	}
 
-// TODO: Implement a real CurrentRequestPreviousEntry function.
-func (bc *BeaconChain) CurrentRequestPreviousEntry() ([]byte, error) {
-	return bc.mockRandomBeacon.CurrentRequestPreviousEntry()
-}
+	if len(events) == 0 {
+		return nil, fmt.Errorf(
+			"relay request in progress was not requested since block [%v]",
+			startBlock,
+		)
+	}
 
-// TODO: Implement a real CurrentRequestGroupPublicKey function.
-func (bc *BeaconChain) CurrentRequestGroupPublicKey() ([]byte, error) {
-	return bc.mockRandomBeacon.CurrentRequestGroupPublicKey()
-}
+	request := events[len(events)-1]
 
-// TODO: Temporary mock that simulates the behavior of the RandomBeacon
-//       contract. Should be removed eventually.
-type mockRandomBeacon struct {
-	blockCounter chain.BlockCounter
+	return &relayRequest{
+		requestID:     request.RequestId,
+		groupID:       request.GroupId,
+		previousEntry: request.PreviousEntry,
+		startBlock:    request.Raw.BlockNumber,
+	}, nil
+}
 
-	dkgResultSubmissionHandlersMutex sync.Mutex
-	dkgResultSubmissionHandlers      map[int]func(submission *event.DKGResultSubmission)
+// groupMembers returns identifiers of operators being members of the group
+// with the given ID. The members are read from the result registering
+// the group, submitted before the group registration.
+func (bc *BeaconChain) groupMembers(groupID uint64) ([]uint32, error) {
+	group, err := bc.randomBeacon.GetGroup(groupID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get group [%v]: [%v]", groupID, err)
+	}
 
-	currentDkgMutex      sync.RWMutex
-	currentDkgStartBlock *big.Int
+	if !isGroupRegistered(group) {
+		return nil, fmt.Errorf("group [%v] is not registered", groupID)
+	}
 
-	activeGroupMutex         sync.RWMutex
-	activeGroup              []byte
-	activeGroupOperableBlock *big.Int
+	registrationBlock := group.RegistrationBlockNumber.Uint64()
 
-	groupsMutex sync.RWMutex
-	groups      map[string]bool
+	approvals, err := bc.randomBeacon.PastDkgResultApprovedEvents(
+		registrationBlock,
+		&registrationBlock,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get DKG result approvals for group [%v]: [%v]",
+			groupID,
+			err,
+		)
+	}
 
-	currentRequestMutex         sync.RWMutex
-	currentRequestStartBlock    *big.Int
-	currentRequestPreviousEntry []byte
-	currentRequestGroup         []byte
+	for _, approval := range approvals {
+		result, err := bc.submittedDKGResult(
+			approval.ResultHash,
+			registrationBlock,
+		)
+		if err != nil {
+			return nil, err
+		}
 
-	relayEntrySubmissionHandlersMutex sync.Mutex
-	relayEntrySubmissionHandlers      map[int]func(submission *event.RelayEntrySubmitted)
-}
+		members := activeMembers(result.Members, result.MisbehavedMembersIndices)
 
-func newMockRandomBeacon(blockCounter chain.BlockCounter) *mockRandomBeacon {
-	return &mockRandomBeacon{
-		blockCounter:                 blockCounter,
-		dkgResultSubmissionHandlers:  make(map[int]func(submission *event.DKGResultSubmission)),
-		groups:                       make(map[string]bool),
-		relayEntrySubmissionHandlers: make(map[int]func(submission *event.RelayEntrySubmitted)),
-	}
-}
+		membersHash, err := calculateMembersHash(members)
+		if err != nil {
+			return nil, err
+		}
 
-func (mrb *mockRandomBeacon) OnDKGStarted(
-	handler func(event *event.DKGStarted),
-) subscription.EventSubscription {
-	ctx, cancelCtx := context.WithCancel(context.Background())
-	blocksChan := mrb.blockCounter.WatchBlocks(ctx)
-
-	go func() {
-		for {
-			select {
-			case block := <-blocksChan:
-				// Generate an event every 500th block.
-				if block%500 == 0 {
-					mrb.currentDkgMutex.Lock()
-					// The seed is keccak256(block).
-					blockBytes := make([]byte, 8)
-					binary.BigEndian.PutUint64(blockBytes, block)
-					seedBytes := crypto.Keccak256(blockBytes)
-					seed := new(big.Int).SetBytes(seedBytes)
-
-					mrb.currentDkgStartBlock = big.NewInt(int64(block))
-
-					go handler(&event.DKGStarted{
-						Seed:        seed,
-						BlockNumber: block,
-					})
-					mrb.currentDkgMutex.Unlock()
-				}
-			case <-ctx.Done():
-				return
-			}
+		if membersHash == group.MembersHash {
+			return members, nil
 		}
-	}()
+	}
 
-	return subscription.NewEventSubscription(func() {
-		cancelCtx()
-	})
+	return nil, fmt.Errorf(
+		"cannot find DKG result registering group [%v]",
+		groupID,
+	)
 }
 
-func (mrb *mockRandomBeacon) OnDKGResultSubmitted(
-	handler func(event *event.DKGResultSubmission),
-) subscription.EventSubscription {
-	mrb.dkgResultSubmissionHandlersMutex.Lock()
-	defer mrb.dkgResultSubmissionHandlersMutex.Unlock()
+// SubmitRelayEntry submits a newly created relay entry to the chain. The
+// entry is verified against the previous entry and the public key of
+// the group processing the current request before the submission so that
+// invalid entries are not submitted. Once the relay entry soft timeout
+// passed, members of the group are submitted along with the entry so that
+// the group can be punished for the delay. The submission is replaced with
+// a higher gas price until it is mined or the relay entry times out. It is
+// cancelled if another member submits the entry first.
+func (bc *BeaconChain) SubmitRelayEntry(
+	entry []byte,
+) error {
+	request, err := bc.currentRelayRequest()
+	if err != nil {
+		return err
+	}
 
-	// #nosec G404 (insecure random number source (rand))
-	// Temporary test implementation doesn't require secure randomness.
-	handlerID := rand.Int()
+	if request == nil {
+		return fmt.Errorf("there is no relay request in progress")
+	}
 
-	mrb.dkgResultSubmissionHandlers[handlerID] = handler
+	group, err := bc.randomBeacon.GetGroup(request.groupID)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot get group [%v]: [%v]",
+			request.groupID,
+			err,
+		)
+	}
 
-	return subscription.NewEventSubscription(func() {
-		mrb.dkgResultSubmissionHandlersMutex.Lock()
-		defer mrb.dkgResultSubmissionHandlersMutex.Unlock()
+	if err := verifyRelayEntry(
+		group.GroupPubKey,
+		request.previousEntry,
+		entry,
+	); err != nil {
+		return fmt.Errorf(
+			"invalid relay entry for request [%v]: [%v]",
+			request.requestID,
+			err,
+		)
+	}
 
-		delete(mrb.dkgResultSubmissionHandlers, handlerID)
-	})
+	return bc.submissionManager.submit(
+		fmt.Sprintf("relay entry for request [%v]", request.requestID),
+		request.startBlock+bc.config.RelayEntryTimeout,
+		func(transactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			return bc.submitRelayEntryTransaction(
+				transactorOptions,
+				request,
+				entry,
+			)
+		},
+		func(handler func()) subscription.EventSubscription {
+			return bc.OnRelayEntrySubmitted(
+				func(event *event.RelayEntrySubmitted) {
+					if event.RequestID.Cmp(request.requestID) == 0 {
+						handler()
+					}
+				},
+			)
+		},
+	)
 }
 
-func (mrb *mockRandomBeacon) SubmitDKGResult(
-	participantIndex beaconchain.GroupMemberIndex,
-	dkgResult *beaconchain.DKGResult,
-	signatures map[beaconchain.GroupMemberIndex][]byte,
-) error {
-	mrb.dkgResultSubmissionHandlersMutex.Lock()
-	defer mrb.dkgResultSubmissionHandlersMutex.Unlock()
-
-	mrb.currentDkgMutex.Lock()
-	defer mrb.currentDkgMutex.Unlock()
-
-	mrb.activeGroupMutex.Lock()
-	defer mrb.activeGroupMutex.Unlock()
+// submitRelayEntryTransaction sends the relay entry transaction for
+// the given request. The variant of the transaction depends on the current
+// block so that replacements sent after the soft timeout include members
+// of the group.
+func (bc *BeaconChain) submitRelayEntryTransaction(
+	transactorOptions *bind.TransactOpts,
+	request *relayRequest,
+	entry []byte,
+) (*types.Transaction, error) {
+	currentBlock, err := bc.blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get current block: [%v]", err)
+	}
 
-	mrb.groupsMutex.Lock()
-	defer mrb.groupsMutex.Unlock()
+	// The transaction is mined in one of the next blocks so the entry is
+	// submitted without group members only if the soft timeout does not
+	// pass in the next block.
+	if currentBlock < request.startBlock+bc.relayEntrySoftTimeout {
+		transaction, err := bc.randomBeaconTransactor.SubmitRelayEntry0(
+			transactorOptions,
+			entry,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("cannot submit relay entry: [%v]", err)
+		}
 
-	// Abort if there is no DKG in progress. This check is needed to handle a
-	// situation in which two operators of the same client attempt to submit
-	// the DKG result.
-	if mrb.currentDkgStartBlock == nil {
-		return nil
+		return transaction, nil
 	}
 
-	blockNumber, err := mrb.blockCounter.CurrentBlock()
+	members, err := bc.groupMembers(request.groupID)
 	if err != nil {
-		return fmt.Errorf("failed to get the current block")
+		return nil, err
 	}
 
-	for _, handler := range mrb.dkgResultSubmissionHandlers {
-		go func(handler func(*event.DKGResultSubmission)) {
-			handler(&event.DKGResultSubmission{
-				MemberIndex:    uint32(participantIndex),
-				GroupPublicKey: dkgResult.GroupPublicKey,
-				Misbehaved:     dkgResult.Misbehaved,
-				BlockNumber:    blockNumber,
-			})
-		}(handler)
-	}
-
-	mrb.activeGroup = dkgResult.GroupPublicKey
-	mrb.activeGroupOperableBlock = new(big.Int).Add(
-		mrb.currentDkgStartBlock,
-		big.NewInt(150),
+	transaction, err := bc.randomBeaconTransactor.SubmitRelayEntry(
+		transactorOptions,
+		entry,
+		members,
 	)
-	mrb.currentDkgStartBlock = nil
-	mrb.groups[hex.EncodeToString(dkgResult.GroupPublicKey)] = true
+	if err != nil {
+		return nil, fmt.Errorf("cannot submit relay entry: [%v]", err)
+	}
 
-	return nil
+	return transaction, nil
 }
 
-func (mrb *mockRandomBeacon) OnRelayEntryRequested(
-	handler func(request *event.RelayEntryRequested),
-) subscription.EventSubscription {
-	ctx, cancelCtx := context.WithCancel(context.Background())
-	blocksChan := mrb.blockCounter.WatchBlocks(ctx)
-
-	go func() {
-		for {
-			select {
-			case block := <-blocksChan:
-				// Generate an event every 50 block, if there is no other
-				// request in progress.
-				if block%50 == 0 {
-					mrb.activeGroupMutex.RLock()
-					mrb.currentRequestMutex.Lock()
-
-					if mrb.currentRequestStartBlock == nil && len(mrb.activeGroup) > 0 {
-						// If the active group is ready to receive the request.
-						if big.NewInt(int64(block)).Cmp(mrb.activeGroupOperableBlock) >= 0 {
-							blockBytes := make([]byte, 8)
-							binary.BigEndian.PutUint64(blockBytes, block)
-							blockHashBytes := crypto.Keccak256(blockBytes)
-							blockHash := new(big.Int).SetBytes(blockHashBytes)
-							previousEntry := new(bn256.G1).ScalarBaseMult(blockHash)
-
-							mrb.currentRequestStartBlock = big.NewInt(int64(block))
-							mrb.currentRequestPreviousEntry = previousEntry.Marshal()
-							mrb.currentRequestGroup = mrb.activeGroup
-
-							go handler(&event.RelayEntryRequested{
-								PreviousEntry:  mrb.currentRequestPreviousEntry,
-								GroupPublicKey: mrb.currentRequestGroup,
-								BlockNumber:    mrb.currentRequestStartBlock.Uint64(),
-							})
-						}
-					}
+// verifyRelayEntry checks whether the relay entry is a valid BLS signature
+// of the previous entry under the group public key.
+func verifyRelayEntry(groupPublicKey, previousEntry, entry []byte) error {
+	publicKey := new(bn256.G2)
+	if _, err := publicKey.Unmarshal(groupPublicKey); err != nil {
+		return fmt.Errorf("cannot unmarshal group public key: [%v]", err)
+	}
 
-					mrb.currentRequestMutex.Unlock()
-					mrb.activeGroupMutex.RUnlock()
-				}
-			case <-ctx.Done():
-				return
-			}
-		}
-	}()
+	message := new(bn256.G1)
+	if _, err := message.Unmarshal(previousEntry); err != nil {
+		return fmt.Errorf("cannot unmarshal previous entry: [%v]", err)
+	}
 
-	return subscription.NewEventSubscription(func() {
-		cancelCtx()
-	})
+	signature := new(bn256.G1)
+	if _, err := signature.Unmarshal(entry); err != nil {
+		return fmt.Errorf("cannot unmarshal entry: [%v]", err)
+	}
+
+	if !bls.VerifyG1(publicKey, message, signature) {
+		return fmt.Errorf(
+			"entry is not a signature of the previous entry " +
+				"under the group public key",
+		)
+	}
+
+	return nil
 }
 
-func (mrb *mockRandomBeacon) OnRelayEntrySubmitted(
+// OnRelayEntrySubmitted is a callback that is invoked when an on-chain
+// notification of a new, valid relay entry is seen.
+func (bc *BeaconChain) OnRelayEntrySubmitted(
 	handler func(entry *event.RelayEntrySubmitted),
 ) subscription.EventSubscription {
-	mrb.relayEntrySubmissionHandlersMutex.Lock()
-	defer mrb.relayEntrySubmissionHandlersMutex.Unlock()
+	onEvent := func(
+		requestID *big.Int,
+		submitter common.Address,
+		entry []byte,
+		blockNumber uint64,
+	) {
+		handler(&event.RelayEntrySubmitted{
+			RequestID:   requestID,
+			Entry:       entry,
+			Submitter:   chain.Address(submitter.Hex()),
+			BlockNumber: blockNumber,
+		})
+	}
 
-	// #nosec G404 (insecure random number source (rand))
-	// Temporary test implementation doesn't require secure randomness.
-	handlerID := rand.Int()
+	return bc.randomBeacon.RelayEntrySubmittedEvent(nil, nil).OnEvent(onEvent)
+}
 
-	mrb.relayEntrySubmissionHandlers[handlerID] = handler
+// OnRelayEntryRequested is a callback that is invoked when an on-chain
+// notification of a new, valid relay request is seen. As the event holds
+// only the ID of the group processing the request, the group public key
+// is read from the group registered on the chain.
+func (bc *BeaconChain) OnRelayEntryRequested(
+	handler func(request *event.RelayEntryRequested),
+) subscription.EventSubscription {
+	onEvent := func(
+		requestID *big.Int,
+		groupID uint64,
+		previousEntry []byte,
+		blockNumber uint64,
+	) {
+		group, err := bc.randomBeacon.GetGroup(groupID)
+		if err != nil {
+			logger.Errorf(
+				"could not get group [%v] for relay request [%v]: [%v]",
+				groupID,
+				requestID,
+				err,
+			)
+			return
+		}
 
-	return subscription.NewEventSubscription(func() {
-		mrb.relayEntrySubmissionHandlersMutex.Lock()
-		defer mrb.relayEntrySubmissionHandlersMutex.Unlock()
+		handler(&event.RelayEntryRequested{
+			RequestID:      requestID,
+			PreviousEntry:  previousEntry,
+			GroupPublicKey: group.GroupPubKey,
+			BlockNumber:    blockNumber,
+		})
+	}
 
-		delete(mrb.relayEntrySubmissionHandlers, handlerID)
-	})
+	return bc.randomBeacon.RelayEntryRequestedEvent(nil, nil).OnEvent(onEvent)
 }
 
-func (mrb *mockRandomBeacon) SubmitRelayEntry(
-	entry []byte,
-) error {
-	mrb.relayEntrySubmissionHandlersMutex.Lock()
-	defer mrb.relayEntrySubmissionHandlersMutex.Unlock()
-
-	mrb.currentRequestMutex.Lock()
-	defer mrb.currentRequestMutex.Unlock()
+// ReportRelayEntryTimeout notifies the chain when a selected group which was
+// supposed to submit a relay entry, did not deliver it within a specified
+// time frame (relayEntryTimeout) counted in blocks. Members of the group are
+// submitted along with the report so that the group can be punished.
+func (bc *BeaconChain) ReportRelayEntryTimeout() error {
+	request, err := bc.currentRelayRequest()
+	if err != nil {
+		return err
+	}
 
-	// Abort if there is no request in progress.
-	if mrb.currentRequestStartBlock == nil {
-		return nil
+	if request == nil {
+		return fmt.Errorf("there is no relay request in progress")
 	}
 
-	blockNumber, err := mrb.blockCounter.CurrentBlock()
+	members, err := bc.groupMembers(request.groupID)
 	if err != nil {
-		return fmt.Errorf("failed to get block counter: [%v]", err)
+		return err
 	}
 
-	for _, handler := range mrb.relayEntrySubmissionHandlers {
-		go func(handler func(submitted *event.RelayEntrySubmitted)) {
-			handler(&event.RelayEntrySubmitted{BlockNumber: blockNumber})
-		}(handler)
+	if _, err := bc.transact(
+		"reportRelayEntryTimeout",
+		func(transactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			return bc.randomBeaconTransactor.ReportRelayEntryTimeout(
+				transactorOptions,
+				members,
+			)
+		},
+	); err != nil {
+		return fmt.Errorf(
+			"cannot report relay entry timeout for request [%v]: [%v]",
+			request.requestID,
+			err,
+		)
 	}
 
-	mrb.currentRequestStartBlock = nil
-	mrb.currentRequestPreviousEntry = nil
-	mrb.currentRequestGroup = nil
-
 	return nil
 }
 
-func (mrb *mockRandomBeacon) ReportRelayEntryTimeout() error {
-	mrb.currentRequestMutex.Lock()
-	defer mrb.currentRequestMutex.Unlock()
-
-	// Set the current request start block to nil, so that a new relay entry
-	// request can begin.
-	mrb.currentRequestStartBlock = nil
-
-	return nil
+// IsEntryInProgress checks if a new relay entry is currently in progress.
+func (bc *BeaconChain) IsEntryInProgress() (bool, error) {
+	return bc.randomBeacon.IsRelayRequestInProgress()
 }
 
-func (mrb *mockRandomBeacon) IsEntryInProgress() (bool, error) {
-	mrb.currentRequestMutex.RLock()
-	defer mrb.currentRequestMutex.RUnlock()
-
-	return mrb.currentRequestStartBlock != nil, nil
-}
+// CurrentRequestStartBlock returns a start block of a current entry. Returns
+// zero if there is no relay request in progress.
+func (bc *BeaconChain) CurrentRequestStartBlock() (*big.Int, error) {
+	request, err := bc.currentRelayRequest()
+	if err != nil {
+		return nil, err
+	}
 
-func (mrb *mockRandomBeacon) CurrentRequestStartBlock() (*big.Int, error) {
-	mrb.currentRequestMutex.RLock()
-	defer mrb.currentRequestMutex.RUnlock()
+	if request == nil {
+		return big.NewInt(0), nil
+	}
 
-	return mrb.currentRequestStartBlock, nil
+	return new(big.Int).SetUint64(request.startBlock), nil
 }
 
-func (mrb *mockRandomBeacon) CurrentRequestPreviousEntry() ([]byte, error) {
-	mrb.currentRequestMutex.RLock()
-	defer mrb.currentRequestMutex.RUnlock()
+// CurrentRequestPreviousEntry returns previous entry of a current request.
+// Returns nil if there is no relay request in progress.
+func (bc *BeaconChain) CurrentRequestPreviousEntry() ([]byte, error) {
+	request, err := bc.currentRelayRequest()
+	if err != nil {
+		return nil, err
+	}
 
-	return mrb.currentRequestPreviousEntry, nil
+	if request == nil {
+		return nil, nil
+	}
+
+	return request.previousEntry, nil
 }
 
-func (mrb *mockRandomBeacon) CurrentRequestGroupPublicKey() ([]byte, error) {
-	mrb.currentRequestMutex.RLock()
-	defer mrb.currentRequestMutex.RUnlock()
+// CurrentRequestGroupPublicKey returns group public key for the current
+// request. Returns nil if there is no relay request in progress.
+func (bc *BeaconChain) CurrentRequestGroupPublicKey() ([]byte, error) {
+	request, err := bc.currentRelayRequest()
+	if err != nil {
+		return nil, err
+	}
 
-	return mrb.currentRequestGroup, nil
-}
+	if request == nil {
+		return nil, nil
+	}
 
-func (mrb *mockRandomBeacon) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
-	mrb.groupsMutex.RLock()
-	defer mrb.groupsMutex.RUnlock()
+	group, err := bc.randomBeacon.GetGroup(request.groupID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get group [%v]: [%v]",
+			request.groupID,
+			err,
+		)
+	}
 
-	return mrb.groups[hex.EncodeToString(groupPublicKey)], nil
+	return group.GroupPubKey, nil
 }
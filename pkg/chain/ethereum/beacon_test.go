@@ -0,0 +1,902 @@
+package ethereum
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+
+	beaconchain "github.com/keep-network/keep-core/pkg/beacon/chain"
+	"github.com/keep-network/keep-core/pkg/beacon/event"
+	"github.com/keep-network/keep-core/pkg/bls"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/beacon/gen/contract"
+)
+
+const beaconTestEventTimeout = 10 * time.Second
+
+var (
+	testRandomBeaconAddress  = common.HexToAddress("0x9D7f4E3bEe1A4E7d0fB2B8a5Bd1d52A4A7e86d01")
+	testSortitionPoolAddress = common.HexToAddress("0x9D7f4E3bEe1A4E7d0fB2B8a5Bd1d52A4A7e86d02")
+)
+
+// beaconTestEnvironment consists of the simulated chain with the fake
+// RandomBeacon contract and beacon chain handles of group members.
+// The handle with index N belongs to the operator of the member N+1.
+type beaconTestEnvironment struct {
+	chain        *simulatedChain
+	randomBeacon *fakeRandomBeacon
+	operatorKeys []*keystore.Key
+	beaconChains []*BeaconChain
+
+	groupSecretKey *big.Int
+	groupPublicKey []byte
+}
+
+func newBeaconTestEnvironment(t *testing.T, groupSize int) *beaconTestEnvironment {
+	operatorKeys := make([]*keystore.Key, groupSize)
+	operators := make(map[uint32]common.Address)
+	accounts := make([]common.Address, groupSize)
+	for i := range operatorKeys {
+		operatorKeys[i] = newTestKey(t)
+		operators[uint32(i+1)] = operatorKeys[i].Address
+		accounts[i] = operatorKeys[i].Address
+	}
+
+	sortitionPool, err := newFakeSortitionPool(operators)
+	if err != nil {
+		t.Fatal(err)
+	}
+	randomBeacon, err := newFakeRandomBeacon(
+		testSortitionPoolAddress,
+		sortitionPool,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	simulatedChain := newSimulatedChain(
+		t,
+		map[common.Address]fakeContract{
+			testRandomBeaconAddress:  randomBeacon,
+			testSortitionPoolAddress: sortitionPool,
+		},
+		accounts...,
+	)
+
+	beaconChains := make([]*BeaconChain, groupSize)
+	for i, key := range operatorKeys {
+		baseChain := simulatedChain.connect(&localAccount{key})
+
+		randomBeaconContract, err := contract.NewRandomBeacon(
+			testRandomBeaconAddress,
+			baseChain.chainID,
+			baseChain.key,
+			baseChain.client,
+			baseChain.nonceManager,
+			baseChain.miningWaiter,
+			baseChain.blockCounter,
+			baseChain.transactionMutex,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		beaconChains[i], err = attachBeaconChain(
+			baseChain,
+			testRandomBeaconAddress,
+			randomBeaconContract,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	groupSecretKey := big.NewInt(1234567)
+
+	return &beaconTestEnvironment{
+		chain:          simulatedChain,
+		randomBeacon:   randomBeacon,
+		operatorKeys:   operatorKeys,
+		beaconChains:   beaconChains,
+		groupSecretKey: groupSecretKey,
+		groupPublicKey: new(bn256.G2).ScalarBaseMult(groupSecretKey).Marshal(),
+	}
+}
+
+func (bte *beaconTestEnvironment) members() []uint32 {
+	members := make([]uint32, len(bte.operatorKeys))
+	for i := range members {
+		members[i] = uint32(i + 1)
+	}
+	return members
+}
+
+func (bte *beaconTestEnvironment) startDKG(t *testing.T, seed *big.Int) {
+	err := bte.chain.mineAction(
+		testRandomBeaconAddress,
+		func(call *fakeContractCall) error {
+			return bte.randomBeacon.startDKG(call, seed, bte.members())
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (bte *beaconTestEnvironment) requestRelayEntry(
+	t *testing.T,
+	groupID uint64,
+	previousEntry []byte,
+) {
+	err := bte.chain.mineAction(
+		testRandomBeaconAddress,
+		func(call *fakeContractCall) error {
+			return bte.randomBeacon.requestRelayEntry(call, groupID, previousEntry)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// signDKGResult signs the result by the given members. The signing key of
+// a member can be replaced with another key to produce an invalid signature.
+func (bte *beaconTestEnvironment) signDKGResult(
+	t *testing.T,
+	result *beaconchain.DKGResult,
+	signingKeys map[beaconchain.GroupMemberIndex]*keystore.Key,
+) map[beaconchain.GroupMemberIndex][]byte {
+	hash, err := bte.beaconChains[0].CalculateDKGResultHash(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signatures := make(map[beaconchain.GroupMemberIndex][]byte)
+	for memberIndex, key := range signingKeys {
+		signatures[memberIndex] = signWithKey(t, key.PrivateKey, hash[:])
+	}
+
+	return signatures
+}
+
+// registerGroup runs the DKG result submission and approval for a group
+// with the given misbehaved members. All members not marked as misbehaved
+// sign the result.
+func (bte *beaconTestEnvironment) registerGroup(
+	t *testing.T,
+	misbehaved []uint8,
+) {
+	bte.startDKG(t, big.NewInt(100))
+
+	misbehavedSet := make(map[uint8]bool)
+	for _, memberIndex := range misbehaved {
+		misbehavedSet[memberIndex] = true
+	}
+
+	signingKeys := make(map[beaconchain.GroupMemberIndex]*keystore.Key)
+	for i, key := range bte.operatorKeys {
+		if !misbehavedSet[uint8(i+1)] {
+			signingKeys[beaconchain.GroupMemberIndex(i+1)] = key
+		}
+	}
+
+	result := &beaconchain.DKGResult{
+		GroupPublicKey: bte.groupPublicKey,
+		Misbehaved:     misbehaved,
+	}
+	err := bte.beaconChains[0].SubmitDKGResult(
+		1,
+		result,
+		bte.signDKGResult(t, result, signingKeys),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bte.chain.mine(int(bte.randomBeacon.dkgResultChallengePeriodLength))
+
+	if err := bte.beaconChains[0].ApproveDKGResult(
+		bte.randomBeacon.submittedResultHash,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	isRegistered, err := bte.beaconChains[0].IsGroupRegistered(bte.groupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isRegistered {
+		t.Fatal("group is not registered")
+	}
+}
+
+func (bte *beaconTestEnvironment) relayEntry(previousEntry []byte) []byte {
+	message := new(bn256.G1)
+	if _, err := message.Unmarshal(previousEntry); err != nil {
+		panic(err)
+	}
+
+	return bls.SignG1(bte.groupSecretKey, message).Marshal()
+}
+
+func assertDKGState(
+	t *testing.T,
+	beaconChain *BeaconChain,
+	expectedState beaconchain.DKGState,
+) {
+	state, err := beaconChain.GetDKGState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expectedState != state {
+		t.Errorf(
+			"unexpected DKG state\nexpected: %v\nactual:   %v\n",
+			expectedState,
+			state,
+		)
+	}
+}
+
+func assertPoolLocked(t *testing.T, beaconChain *BeaconChain, expected bool) {
+	isLocked, err := beaconChain.IsPoolLocked()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected != isLocked {
+		t.Errorf(
+			"unexpected pool lock state\nexpected: %v\nactual:   %v\n",
+			expected,
+			isLocked,
+		)
+	}
+}
+
+func TestBeaconChain_DKGResultChallengeAndApproval(t *testing.T) {
+	environment := newBeaconTestEnvironment(t, 3)
+
+	submitter := environment.beaconChains[0]
+	otherMember := environment.beaconChains[1]
+
+	dkgStartedChan := make(chan *event.DKGStarted, 1)
+	submitter.OnDKGStarted(func(event *event.DKGStarted) {
+		dkgStartedChan <- event
+	})
+	dkgResultSubmittedChan := make(chan *event.DKGResultSubmission, 2)
+	submitter.OnDKGResultSubmitted(func(event *event.DKGResultSubmission) {
+		dkgResultSubmittedChan <- event
+	})
+	dkgResultChallengedChan := make(chan *event.DKGResultChallenged, 1)
+	submitter.OnDKGResultChallenged(func(event *event.DKGResultChallenged) {
+		dkgResultChallengedChan <- event
+	})
+	dkgResultApprovedChan := make(chan *event.DKGResultApproved, 1)
+	submitter.OnDKGResultApproved(func(event *event.DKGResultApproved) {
+		dkgResultApprovedChan <- event
+	})
+	groupRegisteredChan := make(chan *event.GroupRegistration, 1)
+	submitter.OnGroupRegistered(func(event *event.GroupRegistration) {
+		groupRegisteredChan <- event
+	})
+
+	environment.chain.waitForSubscriptions(5)
+
+	seed := big.NewInt(987)
+	environment.startDKG(t, seed)
+
+	select {
+	case dkgStarted := <-dkgStartedChan:
+		if dkgStarted.Seed.Cmp(seed) != 0 {
+			t.Errorf(
+				"unexpected seed\nexpected: %v\nactual:   %v\n",
+				seed,
+				dkgStarted.Seed,
+			)
+		}
+	case <-time.After(beaconTestEventTimeout):
+		t.Fatal("DKG start event was not received")
+	}
+
+	assertDKGState(t, submitter, beaconchain.AwaitingResult)
+
+	selectedOperators, err := submitter.SelectGroup(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedOperators := make([]chain.Address, len(environment.operatorKeys))
+	for i, key := range environment.operatorKeys {
+		expectedOperators[i] = chain.Address(key.Address.String())
+	}
+	if !reflect.DeepEqual(expectedOperators, selectedOperators) {
+		t.Errorf(
+			"unexpected selected operators\nexpected: %v\nactual:   %v\n",
+			expectedOperators,
+			selectedOperators,
+		)
+	}
+
+	result := &beaconchain.DKGResult{
+		GroupPublicKey: environment.groupPublicKey,
+		Misbehaved:     []uint8{},
+	}
+
+	// Member 3 signature is produced with a key of another operator so
+	// the result is invalid and can be challenged.
+	invalidSignatures := environment.signDKGResult(
+		t,
+		result,
+		map[beaconchain.GroupMemberIndex]*keystore.Key{
+			1: environment.operatorKeys[0],
+			2: environment.operatorKeys[1],
+			3: newTestKey(t),
+		},
+	)
+	if err := submitter.SubmitDKGResult(1, result, invalidSignatures); err != nil {
+		t.Fatal(err)
+	}
+
+	var invalidSubmission *event.DKGResultSubmission
+	select {
+	case invalidSubmission = <-dkgResultSubmittedChan:
+	case <-time.After(beaconTestEventTimeout):
+		t.Fatal("DKG result submission event was not received")
+	}
+
+	if !bytes.Equal(environment.groupPublicKey, invalidSubmission.GroupPublicKey) {
+		t.Errorf("unexpected group public key in the submission event")
+	}
+	if invalidSubmission.MemberIndex != 1 {
+		t.Errorf(
+			"unexpected submitter member index\nexpected: %v\nactual:   %v\n",
+			1,
+			invalidSubmission.MemberIndex,
+		)
+	}
+
+	assertDKGState(t, submitter, beaconchain.Challenge)
+
+	if err := otherMember.ChallengeDKGResult(
+		invalidSubmission.ResultHash,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case challenged := <-dkgResultChallengedChan:
+		if challenged.ResultHash != invalidSubmission.ResultHash {
+			t.Errorf(
+				"unexpected challenged result hash\n"+
+					"expected: 0x%x\nactual:   0x%x\n",
+				invalidSubmission.ResultHash,
+				challenged.ResultHash,
+			)
+		}
+		if challenged.Reason != "invalid signature" {
+			t.Errorf("unexpected challenge reason [%v]", challenged.Reason)
+		}
+	case <-time.After(beaconTestEventTimeout):
+		t.Fatal("DKG result challenge event was not received")
+	}
+
+	assertDKGState(t, submitter, beaconchain.AwaitingResult)
+
+	validSignatures := environment.signDKGResult(
+		t,
+		result,
+		map[beaconchain.GroupMemberIndex]*keystore.Key{
+			1: environment.operatorKeys[0],
+			2: environment.operatorKeys[1],
+			3: environment.operatorKeys[2],
+		},
+	)
+	if err := submitter.SubmitDKGResult(1, result, validSignatures); err != nil {
+		t.Fatal(err)
+	}
+
+	var validSubmission *event.DKGResultSubmission
+	select {
+	case validSubmission = <-dkgResultSubmittedChan:
+	case <-time.After(beaconTestEventTimeout):
+		t.Fatal("DKG result submission event was not received")
+	}
+
+	if err := otherMember.ChallengeDKGResult(
+		validSubmission.ResultHash,
+	); err == nil {
+		t.Error("expected challenge of a valid result to fail")
+	}
+
+	if err := submitter.ApproveDKGResult(validSubmission.ResultHash); err == nil {
+		t.Error("expected approval during the challenge period to fail")
+	}
+
+	environment.chain.mine(
+		int(environment.randomBeacon.dkgResultChallengePeriodLength),
+	)
+
+	if err := otherMember.ApproveDKGResult(validSubmission.ResultHash); err == nil {
+		t.Error("expected approval by a non-submitter during the submitter " +
+			"precedence period to fail")
+	}
+
+	if err := submitter.ApproveDKGResult(validSubmission.ResultHash); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case approved := <-dkgResultApprovedChan:
+		if approved.ResultHash != validSubmission.ResultHash {
+			t.Errorf(
+				"unexpected approved result hash\n"+
+					"expected: 0x%x\nactual:   0x%x\n",
+				validSubmission.ResultHash,
+				approved.ResultHash,
+			)
+		}
+	case <-time.After(beaconTestEventTimeout):
+		t.Fatal("DKG result approval event was not received")
+	}
+
+	select {
+	case registered := <-groupRegisteredChan:
+		if !bytes.Equal(environment.groupPublicKey, registered.GroupPublicKey) {
+			t.Errorf("unexpected group public key in the registration event")
+		}
+	case <-time.After(beaconTestEventTimeout):
+		t.Fatal("group registration event was not received")
+	}
+
+	assertDKGState(t, submitter, beaconchain.Idle)
+
+	isRegistered, err := submitter.IsGroupRegistered(environment.groupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isRegistered {
+		t.Error("group should be registered")
+	}
+
+	isStale, err := submitter.IsStaleGroup(environment.groupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isStale {
+		t.Error("group should not be stale")
+	}
+}
+
+func TestBeaconChain_SubmitRelayEntry(t *testing.T) {
+	environment := newBeaconTestEnvironment(t, 3)
+	// Member 3 is misbehaving so it is not a member of the registered group.
+	environment.registerGroup(t, []uint8{3})
+
+	beaconChain := environment.beaconChains[1]
+
+	relayEntryRequestedChan := make(chan *event.RelayEntryRequested, 2)
+	beaconChain.OnRelayEntryRequested(func(event *event.RelayEntryRequested) {
+		relayEntryRequestedChan <- event
+	})
+	relayEntrySubmittedChan := make(chan *event.RelayEntrySubmitted, 2)
+	beaconChain.OnRelayEntrySubmitted(func(event *event.RelayEntrySubmitted) {
+		relayEntrySubmittedChan <- event
+	})
+
+	environment.chain.waitForSubscriptions(2)
+
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(5)).Marshal()
+	environment.requestRelayEntry(t, 0, previousEntry)
+
+	var request *event.RelayEntryRequested
+	select {
+	case request = <-relayEntryRequestedChan:
+	case <-time.After(beaconTestEventTimeout):
+		t.Fatal("relay entry request event was not received")
+	}
+
+	if !bytes.Equal(previousEntry, request.PreviousEntry) {
+		t.Errorf("unexpected previous entry in the request event")
+	}
+	if !bytes.Equal(environment.groupPublicKey, request.GroupPublicKey) {
+		t.Errorf("unexpected group public key in the request event")
+	}
+
+	isInProgress, err := beaconChain.IsEntryInProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isInProgress {
+		t.Fatal("relay entry should be in progress")
+	}
+
+	currentPreviousEntry, err := beaconChain.CurrentRequestPreviousEntry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(previousEntry, currentPreviousEntry) {
+		t.Errorf("unexpected previous entry of the current request")
+	}
+
+	currentGroupPublicKey, err := beaconChain.CurrentRequestGroupPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(environment.groupPublicKey, currentGroupPublicKey) {
+		t.Errorf("unexpected group public key of the current request")
+	}
+
+	startBlock, err := beaconChain.CurrentRequestStartBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if startBlock.Uint64() != request.BlockNumber {
+		t.Errorf(
+			"unexpected start block of the current request\n"+
+				"expected: %v\nactual:   %v\n",
+			request.BlockNumber,
+			startBlock,
+		)
+	}
+
+	invalidEntry := bls.SignG1(
+		big.NewInt(7654321),
+		new(bn256.G1).ScalarBaseMult(big.NewInt(5)),
+	).Marshal()
+	if err := beaconChain.SubmitRelayEntry(invalidEntry); err == nil {
+		t.Error("expected invalid relay entry submission to fail")
+	}
+
+	entry := environment.relayEntry(previousEntry)
+	if err := beaconChain.SubmitRelayEntry(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case submitted := <-relayEntrySubmittedChan:
+		if !bytes.Equal(entry, submitted.Entry) {
+			t.Errorf("unexpected entry in the submission event")
+		}
+		if submitted.RequestID.Cmp(request.RequestID) != 0 {
+			t.Errorf(
+				"unexpected request ID\nexpected: %v\nactual:   %v\n",
+				request.RequestID,
+				submitted.RequestID,
+			)
+		}
+	case <-time.After(beaconTestEventTimeout):
+		t.Fatal("relay entry submission event was not received")
+	}
+
+	if len(environment.randomBeacon.punishedMembers) != 0 {
+		t.Errorf("group should not be punished for a timely relay entry")
+	}
+
+	// Submit the next entry after the soft timeout.
+	environment.requestRelayEntry(t, 0, entry)
+	environment.chain.mine(int(environment.randomBeacon.relayEntrySoftTimeout))
+
+	if err := beaconChain.SubmitRelayEntry(
+		environment.relayEntry(entry),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPunishedMembers := [][]uint32{{1, 2}}
+	if !reflect.DeepEqual(
+		expectedPunishedMembers,
+		environment.randomBeacon.punishedMembers,
+	) {
+		t.Errorf(
+			"unexpected punished members\nexpected: %v\nactual:   %v\n",
+			expectedPunishedMembers,
+			environment.randomBeacon.punishedMembers,
+		)
+	}
+}
+
+func TestBeaconChain_ReportRelayEntryTimeout(t *testing.T) {
+	environment := newBeaconTestEnvironment(t, 3)
+	environment.registerGroup(t, []uint8{})
+
+	beaconChain := environment.beaconChains[2]
+
+	previousEntry := new(bn256.G1).ScalarBaseMult(big.NewInt(5)).Marshal()
+	environment.requestRelayEntry(t, 0, previousEntry)
+
+	if err := beaconChain.ReportRelayEntryTimeout(); err == nil {
+		t.Error("expected relay entry timeout report to fail")
+	}
+
+	environment.chain.mine(
+		int(environment.randomBeacon.relayEntrySoftTimeout +
+			environment.randomBeacon.relayEntryHardTimeout),
+	)
+
+	if err := beaconChain.ReportRelayEntryTimeout(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPunishedMembers := [][]uint32{{1, 2, 3}}
+	if !reflect.DeepEqual(
+		expectedPunishedMembers,
+		environment.randomBeacon.punishedMembers,
+	) {
+		t.Errorf(
+			"unexpected punished members\nexpected: %v\nactual:   %v\n",
+			expectedPunishedMembers,
+			environment.randomBeacon.punishedMembers,
+		)
+	}
+
+	isInProgress, err := beaconChain.IsEntryInProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isInProgress {
+		t.Error("relay entry should not be in progress")
+	}
+
+	isStale, err := beaconChain.IsStaleGroup(environment.groupPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isStale {
+		t.Error("terminated group should be stale")
+	}
+}
+
+// TestBeaconChain_RandomBeaconContract runs the beacon chain handle against
+// the RandomBeacon contract compiled from solidity/random-beacon, deployed on
+// the simulated chain along with the sortition pool and the other contracts
+// it depends on. Flows requiring staked operators in the sortition pool are
+// covered by tests using the fake RandomBeacon contract.
+func TestBeaconChain_RandomBeaconContract(t *testing.T) {
+	artifactsDir := randomBeaconArtifactsDir(t)
+
+	deployerKey := newTestKey(t)
+	operatorKey := newTestKey(t)
+
+	simulatedChain := newSimulatedChain(
+		t,
+		nil,
+		deployerKey.Address,
+		operatorKey.Address,
+	)
+
+	randomBeaconAddress, randomBeacon := deployRandomBeacon(
+		t,
+		simulatedChain,
+		deployerKey,
+		artifactsDir,
+	)
+
+	baseChain := simulatedChain.connect(&localAccount{operatorKey})
+
+	randomBeaconContract, err := contract.NewRandomBeacon(
+		randomBeaconAddress,
+		baseChain.chainID,
+		baseChain.key,
+		baseChain.client,
+		baseChain.nonceManager,
+		baseChain.miningWaiter,
+		baseChain.blockCounter,
+		baseChain.transactionMutex,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	beaconChain, err := attachBeaconChain(
+		baseChain,
+		randomBeaconAddress,
+		randomBeaconContract,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Initial parameters set by the RandomBeacon constructor.
+	expectedConfig := &beaconchain.Config{
+		GroupSize:                                beaconGroupSize,
+		HonestThreshold:                          beaconHonestThreshold,
+		ResultPublicationBlockStep:               beaconResultPublicationBlockStep,
+		RelayEntryTimeout:                        1280 + 5760,
+		DKGResultChallengePeriodLength:           11520,
+		DKGResultSubmitterPrecedencePeriodLength: 20,
+	}
+	if !reflect.DeepEqual(expectedConfig, beaconChain.GetConfig()) {
+		t.Errorf(
+			"unexpected config\nexpected: %+v\nactual:   %+v\n",
+			expectedConfig,
+			beaconChain.GetConfig(),
+		)
+	}
+	if beaconChain.groupLifetime != 259200 {
+		t.Errorf("unexpected group lifetime [%v]", beaconChain.groupLifetime)
+	}
+	if beaconChain.dkgResultSubmissionTimeout != 1280 {
+		t.Errorf(
+			"unexpected DKG result submission timeout [%v]",
+			beaconChain.dkgResultSubmissionTimeout,
+		)
+	}
+
+	assertDKGState(t, beaconChain, beaconchain.Idle)
+	assertPoolLocked(t, beaconChain, false)
+
+	isInProgress, err := beaconChain.IsEntryInProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isInProgress {
+		t.Error("relay entry should not be in progress")
+	}
+
+	isRegistered, err := beaconChain.IsGroupRegistered(
+		new(bn256.G2).ScalarBaseMult(big.NewInt(1)).Marshal(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isRegistered {
+		t.Error("group should not be registered")
+	}
+
+	simulatedChain.transact(operatorKey, randomBeacon, "genesis")
+	genesisBlock := simulatedChain.currentBlock()
+
+	assertDKGState(t, beaconChain, beaconchain.AwaitingResult)
+	assertPoolLocked(t, beaconChain, true)
+
+	dkgStartBlock, err := beaconChain.dkgStartBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dkgStartBlock != genesisBlock {
+		t.Errorf(
+			"unexpected DKG start block\nexpected: %v\nactual:   %v\n",
+			genesisBlock,
+			dkgStartBlock,
+		)
+	}
+}
+
+// randomBeaconArtifactsDir returns the directory of the Hardhat artifacts of
+// the RandomBeacon contract and its dependencies, built with `yarn build` in
+// solidity/random-beacon. The directory can be overridden with
+// the KEEP_RANDOM_BEACON_ARTIFACTS environment variable. The test fails if
+// the artifacts are not built, unless tests are run in the short mode.
+func randomBeaconArtifactsDir(t *testing.T) string {
+	artifactsDir, ok := os.LookupEnv("KEEP_RANDOM_BEACON_ARTIFACTS")
+	if !ok {
+		artifactsDir = filepath.Join(
+			"..", "..", "..", "solidity", "random-beacon", "build",
+		)
+	}
+
+	if _, err := os.Stat(artifactsDir); err != nil {
+		if testing.Short() {
+			t.Skipf("RandomBeacon artifacts not available: [%v]", err)
+		}
+
+		t.Fatalf(
+			"RandomBeacon artifacts not available: [%v]; build them with "+
+				"`yarn build` in solidity/random-beacon or set "+
+				"KEEP_RANDOM_BEACON_ARTIFACTS",
+			err,
+		)
+	}
+
+	return artifactsDir
+}
+
+// deployRandomBeacon deploys the RandomBeacon contract along with the
+// contracts it depends on the same way the solidity/random-beacon deployment
+// scripts do. The staking contract is not deployed as RandomBeacon does not
+// call it unless operators are authorized; the deployer's address is used
+// instead.
+func deployRandomBeacon(
+	t *testing.T,
+	simulatedChain *simulatedChain,
+	deployerKey *keystore.Key,
+	artifactsDir string,
+) (common.Address, *bind.BoundContract) {
+	artifact := func(contractName string) *contractArtifact {
+		artifact, err := loadContractArtifact(artifactsDir, contractName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return artifact
+	}
+
+	deploy := func(
+		contractName string,
+		libraries map[string]common.Address,
+		args ...interface{},
+	) (common.Address, *bind.BoundContract) {
+		return simulatedChain.deployContract(
+			deployerKey,
+			artifact(contractName),
+			libraries,
+			args...,
+		)
+	}
+
+	tTokenAddress, _ := deploy("T", nil)
+
+	reimbursementPoolAddress, reimbursementPool := deploy(
+		"ReimbursementPool",
+		nil,
+		big.NewInt(40800),
+		big.NewInt(500000000000),
+	)
+
+	sortitionPoolAddress, sortitionPool := deploy(
+		"SortitionPool",
+		nil,
+		tTokenAddress,
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil),
+	)
+
+	dkgValidatorAddress, _ := deploy(
+		"BeaconDkgValidator",
+		nil,
+		sortitionPoolAddress,
+	)
+
+	libraries := make(map[string]common.Address)
+	for _, library := range []string{
+		"BLS",
+		"BeaconAuthorization",
+		"BeaconDkg",
+		"BeaconInactivity",
+	} {
+		libraries[library], _ = deploy(library, nil)
+	}
+
+	randomBeaconAddress, randomBeacon := deploy(
+		"RandomBeacon",
+		libraries,
+		sortitionPoolAddress,
+		tTokenAddress,
+		deployerKey.Address,
+		dkgValidatorAddress,
+		reimbursementPoolAddress,
+	)
+
+	simulatedChain.transact(
+		deployerKey,
+		sortitionPool,
+		"transferOwnership",
+		randomBeaconAddress,
+	)
+	simulatedChain.transact(
+		deployerKey,
+		reimbursementPool,
+		"authorize",
+		randomBeaconAddress,
+	)
+
+	return randomBeaconAddress, randomBeacon
+}
+
+func TestConvertDKGResultToChain_InvalidSignatureSize(t *testing.T) {
+	_, err := convertDKGResultToChain(
+		1,
+		&beaconchain.DKGResult{GroupPublicKey: []byte{1}},
+		map[beaconchain.GroupMemberIndex][]byte{1: {1, 2, 3}},
+		[]uint32{1},
+	)
+
+	if err == nil {
+		t.Fatal("expected conversion to fail")
+	}
+}
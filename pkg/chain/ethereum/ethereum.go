@@ -8,7 +8,10 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ipfs/go-log"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
 	"github.com/keep-network/keep-common/pkg/rate"
 	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/remotesigner"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum/threshold/gen/contract"
 	"github.com/keep-network/keep-core/pkg/operator"
 )
@@ -32,12 +36,19 @@ var logger = log.Logger("keep-ethereum")
 // block counter and similar.
 type baseChain struct {
 	key     *keystore.Key
+	account operatorAccount
 	client  ethutil.EthereumClient
 	chainID *big.Int
 
 	blockCounter *ethereum.BlockCounter
 	nonceManager *ethereum.NonceManager
 	miningWaiter *ethutil.MiningWaiter
+	// transactorOptions sign transactions with the operator's key, whether
+	// held locally or by the remote signer.
+	transactorOptions *bind.TransactOpts
+	// maxGasFeeCap is the maximum gas fee cap the client is willing to pay
+	// for a transaction to be mined.
+	maxGasFeeCap *big.Int
 
 	// transactionMutex allows interested parties to forcibly serialize
 	// transaction submission.
@@ -56,6 +67,51 @@ type baseChain struct {
 	tokenStaking *contract.TokenStaking
 }
 
+// operatorAccount provides the chain handle with the access to the
+// operator's key. The key may be held locally or by a remote signer.
+type operatorAccount interface {
+	// bindingKey returns the key passed to the contract bindings. The
+	// transaction methods of the bindings must not be used; all transactions
+	// are submitted with the transactor options of the account.
+	bindingKey() *keystore.Key
+	// transactorOptions returns the options signing transactions with
+	// the operator's key.
+	transactorOptions(chainID *big.Int) (*bind.TransactOpts, error)
+	// signing returns the signer using the operator's key.
+	signing() chain.Signing
+	// operatorKeyPair returns the operator's key pair. The private key is nil
+	// if the key is not held locally.
+	operatorKeyPair() (*operator.PrivateKey, *operator.PublicKey, error)
+}
+
+// localAccount is the operator account whose key is decrypted from the key
+// file and held in memory.
+type localAccount struct {
+	key *keystore.Key
+}
+
+func (la *localAccount) bindingKey() *keystore.Key {
+	return la.key
+}
+
+func (la *localAccount) transactorOptions(
+	chainID *big.Int,
+) (*bind.TransactOpts, error) {
+	return bind.NewKeyedTransactorWithChainID(la.key.PrivateKey, chainID)
+}
+
+func (la *localAccount) signing() chain.Signing {
+	return newSigner(la.key)
+}
+
+func (la *localAccount) operatorKeyPair() (
+	*operator.PrivateKey,
+	*operator.PublicKey,
+	error,
+) {
+	return ChainPrivateKeyToOperatorKeyPair(la.key.PrivateKey)
+}
+
 // Connect creates Random Beacon and TBTC Ethereum chain handles.
 func Connect(
 	ctx context.Context,
@@ -68,59 +124,273 @@ func Connect(
 	*operator.PrivateKey,
 	error,
 ) {
+	operatorChains, blockCounter, err := ConnectOperators(
+		ctx,
+		config,
+		[]ethereum.Account{config.Account},
+	)
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+
+	operatorChain := operatorChains[0]
+
+	return operatorChain.BeaconChain,
+		operatorChain.TbtcChain,
+		blockCounter,
+		operatorChain.Signing,
+		operatorChain.PrivateKey,
+		nil
+}
+
+// OperatorChain holds the chain handles of a single operator run by the
+// client.
+type OperatorChain struct {
+	BeaconChain *BeaconChain
+	TbtcChain   *TbtcChain
+	Signing     chain.Signing
+	PrivateKey  *operator.PrivateKey
+}
+
+// ConnectOperators creates Random Beacon and TBTC Ethereum chain handles for
+// each of the given operator accounts. All the operators share the same
+// Ethereum client, including its rate limits, and the same block counter.
+// Every operator has its own nonce manager and transaction mutex so the
+// transactions of different operators do not block each other.
+func ConnectOperators(
+	ctx context.Context,
+	config ethereum.Config,
+	accounts []ethereum.Account,
+) ([]*OperatorChain, chain.BlockCounter, error) {
+	if len(accounts) == 0 {
+		return nil, nil, fmt.Errorf("at least one operator account is required")
+	}
+
+	connection, err := dial(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	operatorChains := make([]*OperatorChain, len(accounts))
+	for i, account := range accounts {
+		key, err := decryptKey(account)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"failed to decrypt Ethereum key [%s]: [%v]",
+				account.KeyFile,
+				err,
+			)
+		}
+
+		beaconChain, tbtcChain, baseChain, err := connectOperator(
+			config,
+			connection,
+			&localAccount{key},
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"could not connect operator [%s]: [%w]",
+				key.Address.Hex(),
+				err,
+			)
+		}
+
+		operatorPrivateKey, _, err := baseChain.OperatorKeyPair()
+		if err != nil {
+			return nil, nil, fmt.Errorf(
+				"could not get operator key pair: [%v]",
+				err,
+			)
+		}
+
+		operatorChains[i] = &OperatorChain{
+			BeaconChain: beaconChain,
+			TbtcChain:   tbtcChain,
+			Signing:     baseChain.Signing(),
+			PrivateKey:  operatorPrivateKey,
+		}
+	}
+
+	return operatorChains, connection.blockCounter, nil
+}
+
+// ConnectWithRemoteSigner creates Random Beacon and TBTC Ethereum chain
+// handles using the operator key held by the remote signer. Chain
+// transactions and signatures are produced by the remote signer and the
+// operator's private key never enters the process.
+func ConnectWithRemoteSigner(
+	ctx context.Context,
+	config ethereum.Config,
+	signerClient *remotesigner.Client,
+) (
+	*BeaconChain,
+	*TbtcChain,
+	chain.BlockCounter,
+	chain.Signing,
+	error,
+) {
+	account := newRemoteAccount(signerClient)
+
+	connection, err := dial(ctx, config)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	beaconChain, tbtcChain, baseChain, err := connectOperator(
+		config,
+		connection,
+		account,
+	)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return beaconChain,
+		tbtcChain,
+		connection.blockCounter,
+		baseChain.Signing(),
+		nil
+}
+
+// ConnectBeaconObserver creates a Random Beacon Ethereum chain handle that
+// only follows the chain. The handle does not use any operator key; it is
+// backed by a random, throwaway key so all transactions submitted with it
+// fail. The handle is meant for parties that observe the beacon without
+// staking and without being a member of any group.
+func ConnectBeaconObserver(
+	ctx context.Context,
+	config ethereum.Config,
+) (*BeaconChain, chain.BlockCounter, error) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate key: [%v]", err)
+	}
+
+	account := &localAccount{
+		key: &keystore.Key{
+			Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+			PrivateKey: privateKey,
+		},
+	}
+
+	connection, err := dial(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	baseChain, err := newBaseChain(config, connection, account)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"could not create base chain handle: [%v]",
+			err,
+		)
+	}
+
+	beaconChain, err := newBeaconChain(config, baseChain)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"could not create beacon chain handle: [%v]",
+			err,
+		)
+	}
+
+	return beaconChain, connection.blockCounter, nil
+}
+
+// connection holds the Ethereum connection resources shared by all the
+// operators run by the client.
+type connection struct {
+	client       ethutil.EthereumClient
+	chainID      *big.Int
+	blockCounter *ethereum.BlockCounter
+}
+
+// dial connects to the Ethereum node and validates the chain ID.
+func dial(ctx context.Context, config ethereum.Config) (*connection, error) {
 	client, err := ethclient.Dial(config.URL)
 	if err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf(
+		return nil, fmt.Errorf(
 			"error Connecting to Ethereum Server: %s [%v]",
 			config.URL,
 			err,
 		)
 	}
 
-	baseChain, err := newBaseChain(ctx, config, client)
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to resolve Ethereum chain id: [%v]",
+			err,
+		)
+	}
+
+	if config.Network != ethereum.Developer &&
+		big.NewInt(config.Network.ChainID()).Cmp(chainID) != 0 {
+		return nil, fmt.Errorf(
+			"chain id returned from ethereum api [%s] "+
+				"doesn't match the expected chain id [%d] for [%s] network; "+
+				"please verify the configured ethereum.url",
+			chainID.String(),
+			config.Network.ChainID(),
+			config.Network,
+		)
+	}
+
+	clientWithAddons := wrapClientAddons(config, client)
+
+	blockCounter, err := ethutil.NewBlockCounter(clientWithAddons)
 	if err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf(
-			"could not create base chain handle: [%v]",
+		return nil, fmt.Errorf(
+			"failed to create Ethereum blockcounter: [%v]",
 			err,
 		)
 	}
 
-	beaconChain, err := newBeaconChain(config, baseChain)
+	return &connection{
+		client:       clientWithAddons,
+		chainID:      chainID,
+		blockCounter: blockCounter,
+	}, nil
+}
+
+// connectOperator creates Random Beacon and TBTC Ethereum chain handles for
+// the given operator account using the shared connection.
+func connectOperator(
+	config ethereum.Config,
+	connection *connection,
+	account operatorAccount,
+) (*BeaconChain, *TbtcChain, *baseChain, error) {
+	baseChain, err := newBaseChain(config, connection, account)
 	if err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf(
-			"could not create beacon chain handle: [%v]",
+		return nil, nil, nil, fmt.Errorf(
+			"could not create base chain handle: [%v]",
 			err,
 		)
 	}
 
-	tbtcChain, err := newTbtcChain(config, baseChain)
+	beaconChain, err := newBeaconChain(config, baseChain)
 	if err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf(
-			"could not create TBTC chain handle: [%v]",
+		return nil, nil, nil, fmt.Errorf(
+			"could not create beacon chain handle: [%v]",
 			err,
 		)
 	}
 
-	operatorPrivateKey, _, err := baseChain.OperatorKeyPair()
+	tbtcChain, err := newTbtcChain(config, baseChain)
 	if err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf(
-			"could not get operator key pair: [%v]",
+		return nil, nil, nil, fmt.Errorf(
+			"could not create TBTC chain handle: [%v]",
 			err,
 		)
 	}
 
 	if err := validateContractsAddresses(config, beaconChain, tbtcChain); err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf(
+		return nil, nil, nil, fmt.Errorf(
 			"contracts addresses validation failed: [%w]", err,
 		)
 	}
 
-	return beaconChain,
-		tbtcChain,
-		baseChain.blockCounter,
-		baseChain.Signing(),
-		operatorPrivateKey,
-		nil
+	return beaconChain, tbtcChain, baseChain, nil
 }
 
 func validateContractsAddresses(
@@ -170,46 +440,20 @@ func validateContractsAddresses(
 
 // newChain construct a new instance of the Ethereum chain handle.
 func newBaseChain(
-	ctx context.Context,
 	config ethereum.Config,
-	client *ethclient.Client,
+	connection *connection,
+	account operatorAccount,
 ) (*baseChain, error) {
-	chainID, err := client.ChainID(ctx)
-	if err != nil {
-		return nil, fmt.Errorf(
-			"failed to resolve Ethereum chain id: [%v]",
-			err,
-		)
-	}
+	chainID := connection.chainID
+	blockCounter := connection.blockCounter
 
-	if config.Network != ethereum.Developer &&
-		big.NewInt(config.Network.ChainID()).Cmp(chainID) != 0 {
-		return nil, fmt.Errorf(
-			"chain id returned from ethereum api [%s] "+
-				"doesn't match the expected chain id [%d] for [%s] network; "+
-				"please verify the configured ethereum.url",
-			chainID.String(),
-			config.Network.ChainID(),
-			config.Network,
-		)
-	}
+	key := account.bindingKey()
 
-	key, err := decryptKey(config)
-	if err != nil {
-		return nil, fmt.Errorf(
-			"failed to decrypt Ethereum key: [%v]",
-			err,
-		)
-	}
-
-	clientWithAddons := wrapClientAddons(config, client)
+	clientWithAddons := connection.client
 
-	blockCounter, err := ethutil.NewBlockCounter(clientWithAddons)
+	transactorOptions, err := account.transactorOptions(chainID)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"failed to create Ethereum blockcounter: [%v]",
-			err,
-		)
+		return nil, fmt.Errorf("failed to instantiate transactor: [%v]", err)
 	}
 
 	nonceManager := ethutil.NewNonceManager(
@@ -219,6 +463,11 @@ func newBaseChain(
 
 	miningWaiter := ethutil.NewMiningWaiter(clientWithAddons, config)
 
+	maxGasFeeCap := ethutil.DefaultMaxGasFeeCap.Int
+	if config.MaxGasFeeCap.Int != nil {
+		maxGasFeeCap = config.MaxGasFeeCap.Int
+	}
+
 	transactionMutex := &sync.Mutex{}
 
 	// TODO: Consider adding the balance monitoring.
@@ -237,7 +486,7 @@ func newBaseChain(
 			tokenStakingAddress,
 			chainID,
 			key,
-			client,
+			clientWithAddons,
 			nonceManager,
 			miningWaiter,
 			blockCounter,
@@ -251,27 +500,29 @@ func newBaseChain(
 	}
 
 	return &baseChain{
-		key:              key,
-		client:           clientWithAddons,
-		chainID:          chainID,
-		blockCounter:     blockCounter,
-		nonceManager:     nonceManager,
-		miningWaiter:     miningWaiter,
-		transactionMutex: transactionMutex,
-		tokenStaking:     tokenStaking,
+		key:               key,
+		account:           account,
+		client:            clientWithAddons,
+		chainID:           chainID,
+		blockCounter:      blockCounter,
+		nonceManager:      nonceManager,
+		miningWaiter:      miningWaiter,
+		transactorOptions: transactorOptions,
+		maxGasFeeCap:      maxGasFeeCap,
+		transactionMutex:  transactionMutex,
+		tokenStaking:      tokenStaking,
 	}, nil
 }
 
 // OperatorKeyPair returns the key pair of the operator assigned to this
-// chain handle.
+// chain handle. The private key is nil if the operator key is held by
+// a remote signer.
 func (bc *baseChain) OperatorKeyPair() (
 	*operator.PrivateKey,
 	*operator.PublicKey,
 	error,
 ) {
-	privateKey, publicKey, err := ChainPrivateKeyToOperatorKeyPair(
-		bc.key.PrivateKey,
-	)
+	privateKey, publicKey, err := bc.account.operatorKeyPair()
 	if err != nil {
 		return nil, nil, fmt.Errorf(
 			"cannot convert chain private key to operator key pair: [%v]",
@@ -282,6 +533,73 @@ func (bc *baseChain) OperatorKeyPair() (
 	return privateKey, publicKey, nil
 }
 
+// transact submits the transaction of the given contract method, signed with
+// the operator's transactor options, and makes sure it is mined by
+// resubmitting it with a higher gas price if needed. The transaction methods
+// of the contract bindings sign with the binding key so all the operator's
+// transactions must be submitted this way.
+func (bc *baseChain) transact(
+	method string,
+	submitFn submitTransactionFn,
+) (*types.Transaction, error) {
+	bc.transactionMutex.Lock()
+	defer bc.transactionMutex.Unlock()
+
+	transactorOptions := new(bind.TransactOpts)
+	*transactorOptions = *bc.transactorOptions
+
+	nonce, err := bc.nonceManager.CurrentNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve account nonce: [%v]", err)
+	}
+
+	transactorOptions.Nonce = new(big.Int).SetUint64(nonce)
+
+	transaction, err := submitFn(transactorOptions)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to submit transaction [%s]: [%w]",
+			method,
+			err,
+		)
+	}
+
+	logger.Infof(
+		"submitted transaction [%s] with id: [%s] and nonce [%v]",
+		method,
+		transaction.Hash(),
+		transaction.Nonce(),
+	)
+
+	go bc.miningWaiter.ForceMining(
+		transaction,
+		transactorOptions,
+		func(newTransactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			transaction, err := submitFn(newTransactorOptions)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"failed to resubmit transaction [%s]: [%w]",
+					method,
+					err,
+				)
+			}
+
+			logger.Infof(
+				"resubmitted transaction [%s] with id: [%s] and nonce [%v]",
+				method,
+				transaction.Hash(),
+				transaction.Nonce(),
+			)
+
+			return transaction, nil
+		},
+	)
+
+	bc.nonceManager.IncrementNonce()
+
+	return transaction, nil
+}
+
 // wrapClientAddons wraps the client instance with add-ons like logging, rate
 // limiting and so on.
 func wrapClientAddons(
@@ -311,10 +629,10 @@ func wrapClientAddons(
 	return loggingClient
 }
 
-// decryptKey decrypts the chain key pointed by the config.
-func decryptKey(config ethereum.Config) (*keystore.Key, error) {
+// decryptKey decrypts the chain key of the given account.
+func decryptKey(account ethereum.Account) (*keystore.Key, error) {
 	return ethutil.DecryptKeyFile(
-		config.Account.KeyFile,
-		config.Account.KeyFilePassword,
+		account.KeyFile,
+		account.KeyFilePassword,
 	)
 }
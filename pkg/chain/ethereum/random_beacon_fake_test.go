@@ -0,0 +1,532 @@
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+
+	hostchainabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	beaconchain "github.com/keep-network/keep-core/pkg/beacon/chain"
+	beaconabi "github.com/keep-network/keep-core/pkg/chain/ethereum/beacon/gen/abi"
+)
+
+// Group creation states of the RandomBeacon contract.
+const (
+	fakeStateIdle uint8 = iota
+	fakeStateAwaitingSeed
+	fakeStateAwaitingResult
+	fakeStateChallenge
+)
+
+// fakeRandomBeacon implements the subset of the RandomBeacon contract logic
+// used by the beacon chain handle. It lets unit tests exercise flows that
+// require operators staked and registered in the sortition pool. The handle
+// is run against the compiled contract in TestBeaconChain_RandomBeaconContract.
+type fakeRandomBeacon struct {
+	abi           *hostchainabi.ABI
+	sortitionPool common.Address
+	operators     *fakeSortitionPool
+
+	groupLifetime                      uint64
+	dkgResultChallengePeriodLength     uint64
+	dkgResultSubmissionTimeout         uint64
+	dkgSubmitterPrecedencePeriodLength uint64
+	relayEntrySoftTimeout              uint64
+	relayEntryHardTimeout              uint64
+
+	state               uint8
+	seed                *big.Int
+	dkgStartBlock       uint64
+	selectedMembers     []uint32
+	submittedResultHash [32]byte
+	submissionBlock     uint64
+
+	groups []beaconabi.GroupsGroup
+
+	requestCounter    *big.Int
+	requestInProgress bool
+	requestGroupID    uint64
+	requestStartBlock uint64
+
+	// punishedMembers holds members of groups punished for a late relay
+	// entry or a relay entry timeout.
+	punishedMembers [][]uint32
+}
+
+func newFakeRandomBeacon(
+	sortitionPool common.Address,
+	operators *fakeSortitionPool,
+) (*fakeRandomBeacon, error) {
+	contractABI, err := beaconabi.RandomBeaconMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return &fakeRandomBeacon{
+		abi:                                contractABI,
+		sortitionPool:                      sortitionPool,
+		operators:                          operators,
+		groupLifetime:                      100,
+		dkgResultChallengePeriodLength:     5,
+		dkgResultSubmissionTimeout:         30,
+		dkgSubmitterPrecedencePeriodLength: 3,
+		relayEntrySoftTimeout:              10,
+		relayEntryHardTimeout:              20,
+		requestCounter:                     big.NewInt(0),
+	}, nil
+}
+
+func (rb *fakeRandomBeacon) contractABI() *hostchainabi.ABI {
+	return rb.abi
+}
+
+// startDKG starts the DKG for the given members.
+func (rb *fakeRandomBeacon) startDKG(
+	call *fakeContractCall,
+	seed *big.Int,
+	members []uint32,
+) error {
+	if rb.state != fakeStateIdle {
+		return fmt.Errorf("current state is not IDLE")
+	}
+
+	rb.state = fakeStateAwaitingResult
+	rb.seed = seed
+	rb.dkgStartBlock = call.block
+	rb.selectedMembers = members
+
+	return call.emit("DkgStarted", seed)
+}
+
+// requestRelayEntry requests a new relay entry from the given group.
+func (rb *fakeRandomBeacon) requestRelayEntry(
+	call *fakeContractCall,
+	groupID uint64,
+	previousEntry []byte,
+) error {
+	if rb.requestInProgress {
+		return fmt.Errorf("another relay request in progress")
+	}
+
+	rb.requestCounter = new(big.Int).Add(rb.requestCounter, big.NewInt(1))
+	rb.requestInProgress = true
+	rb.requestGroupID = groupID
+	rb.requestStartBlock = call.block
+
+	return call.emit(
+		"RelayEntryRequested",
+		rb.requestCounter,
+		groupID,
+		previousEntry,
+	)
+}
+
+func (rb *fakeRandomBeacon) execute(
+	call *fakeContractCall,
+	method *hostchainabi.Method,
+	args []interface{},
+) ([]interface{}, error) {
+	switch method.Name {
+	case "sortitionPool":
+		return []interface{}{rb.sortitionPool}, nil
+	case "groupCreationParameters":
+		return []interface{}{
+			big.NewInt(0),
+			new(big.Int).SetUint64(rb.groupLifetime),
+			new(big.Int).SetUint64(rb.dkgResultChallengePeriodLength),
+			big.NewInt(0),
+			new(big.Int).SetUint64(rb.dkgResultSubmissionTimeout),
+			new(big.Int).SetUint64(rb.dkgSubmitterPrecedencePeriodLength),
+		}, nil
+	case "relayEntryParameters":
+		return []interface{}{
+			new(big.Int).SetUint64(rb.relayEntrySoftTimeout),
+			new(big.Int).SetUint64(rb.relayEntryHardTimeout),
+			big.NewInt(0),
+		}, nil
+	case "getGroupCreationState":
+		return []interface{}{rb.state}, nil
+	case "selectGroup":
+		if rb.state != fakeStateAwaitingResult {
+			return nil, fmt.Errorf("current state is not AWAITING_RESULT")
+		}
+		return []interface{}{rb.selectedMembers}, nil
+	case "isRelayRequestInProgress":
+		return []interface{}{rb.requestInProgress}, nil
+	case "getGroup":
+		groupID := args[0].(uint64)
+		if groupID >= uint64(len(rb.groups)) {
+			return nil, fmt.Errorf("group does not exist")
+		}
+		return []interface{}{rb.groups[groupID]}, nil
+	case "getGroup0":
+		groupPublicKey := args[0].([]byte)
+		for _, group := range rb.groups {
+			if string(group.GroupPubKey) == string(groupPublicKey) {
+				return []interface{}{group}, nil
+			}
+		}
+		return []interface{}{beaconabi.GroupsGroup{
+			GroupPubKey:             []byte{},
+			RegistrationBlockNumber: big.NewInt(0),
+		}}, nil
+	case "submitDkgResult":
+		return nil, rb.submitDkgResult(call, method, dkgResultArg(args))
+	case "challengeDkgResult":
+		return nil, rb.challengeDkgResult(call, method, dkgResultArg(args))
+	case "approveDkgResult":
+		return nil, rb.approveDkgResult(call, method, dkgResultArg(args))
+	case "submitRelayEntry0":
+		return nil, rb.submitRelayEntry(call, args[0].([]byte), nil)
+	case "submitRelayEntry":
+		return nil, rb.submitRelayEntry(
+			call,
+			args[0].([]byte),
+			args[1].([]uint32),
+		)
+	case "reportRelayEntryTimeout":
+		return nil, rb.reportRelayEntryTimeout(call, args[0].([]uint32))
+	default:
+		return nil, fmt.Errorf("unsupported method [%v]", method.Name)
+	}
+}
+
+func dkgResultArg(args []interface{}) beaconabi.BeaconDkgResult {
+	return *hostchainabi.ConvertType(
+		args[0],
+		new(beaconabi.BeaconDkgResult),
+	).(*beaconabi.BeaconDkgResult)
+}
+
+// dkgResultHash calculates the hash of the result the same way as
+// the contract, i.e. as a Keccak-256 hash of the ABI-encoded result.
+func dkgResultHash(
+	method *hostchainabi.Method,
+	result beaconabi.BeaconDkgResult,
+) ([32]byte, error) {
+	encodedResult, err := method.Inputs.Pack(result)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return crypto.Keccak256Hash(encodedResult), nil
+}
+
+func (rb *fakeRandomBeacon) submitDkgResult(
+	call *fakeContractCall,
+	method *hostchainabi.Method,
+	result beaconabi.BeaconDkgResult,
+) error {
+	if rb.state != fakeStateAwaitingResult {
+		return fmt.Errorf("current state is not AWAITING_RESULT")
+	}
+
+	resultHash, err := dkgResultHash(method, result)
+	if err != nil {
+		return err
+	}
+
+	if call.dryRun {
+		return nil
+	}
+
+	rb.state = fakeStateChallenge
+	rb.submittedResultHash = resultHash
+	rb.submissionBlock = call.block
+
+	return call.emit("DkgResultSubmitted", resultHash, rb.seed, result)
+}
+
+func (rb *fakeRandomBeacon) challengeDkgResult(
+	call *fakeContractCall,
+	method *hostchainabi.Method,
+	result beaconabi.BeaconDkgResult,
+) error {
+	if err := rb.requireSubmittedResult(method, result); err != nil {
+		return err
+	}
+
+	validationErr := rb.validateDkgResult(result)
+	if validationErr == nil {
+		return fmt.Errorf("unjustified challenge")
+	}
+
+	if call.dryRun {
+		return nil
+	}
+
+	rb.state = fakeStateAwaitingResult
+
+	return call.emit(
+		"DkgResultChallenged",
+		rb.submittedResultHash,
+		call.sender,
+		validationErr.Error(),
+	)
+}
+
+func (rb *fakeRandomBeacon) approveDkgResult(
+	call *fakeContractCall,
+	method *hostchainabi.Method,
+	result beaconabi.BeaconDkgResult,
+) error {
+	if err := rb.requireSubmittedResult(method, result); err != nil {
+		return err
+	}
+
+	challengePeriodEnd := rb.submissionBlock + rb.dkgResultChallengePeriodLength
+	if call.block <= challengePeriodEnd {
+		return fmt.Errorf("challenge period has not passed yet")
+	}
+
+	submitter := rb.operators.operator(
+		result.Members[result.SubmitterMemberIndex.Uint64()-1],
+	)
+	if call.sender != submitter &&
+		call.block <= challengePeriodEnd+rb.dkgSubmitterPrecedencePeriodLength {
+		return fmt.Errorf("only the DKG result submitter can approve the " +
+			"result at this moment")
+	}
+
+	if call.dryRun {
+		return nil
+	}
+
+	groupID := uint64(len(rb.groups))
+	rb.groups = append(rb.groups, beaconabi.GroupsGroup{
+		GroupPubKey:             result.GroupPubKey,
+		RegistrationBlockNumber: new(big.Int).SetUint64(call.block),
+		MembersHash:             result.MembersHash,
+	})
+	rb.state = fakeStateIdle
+
+	if err := call.emit(
+		"DkgResultApproved",
+		rb.submittedResultHash,
+		call.sender,
+	); err != nil {
+		return err
+	}
+
+	return call.emit("GroupRegistered", groupID, result.GroupPubKey)
+}
+
+func (rb *fakeRandomBeacon) requireSubmittedResult(
+	method *hostchainabi.Method,
+	result beaconabi.BeaconDkgResult,
+) error {
+	if rb.state != fakeStateChallenge {
+		return fmt.Errorf("current state is not CHALLENGE")
+	}
+
+	resultHash, err := dkgResultHash(method, result)
+	if err != nil {
+		return err
+	}
+
+	if resultHash != rb.submittedResultHash {
+		return fmt.Errorf("result under challenge is different than the " +
+			"submitted one")
+	}
+
+	return nil
+}
+
+// validateDkgResult validates the members hash and signatures of the result.
+// Signatures must be produced by operators of the signing members over
+// the hash of the result, as calculated off-chain.
+func (rb *fakeRandomBeacon) validateDkgResult(
+	result beaconabi.BeaconDkgResult,
+) error {
+	if len(result.GroupPubKey) != 128 {
+		return fmt.Errorf("malformed group public key")
+	}
+
+	membersHash, err := calculateMembersHash(
+		activeMembers(result.Members, result.MisbehavedMembersIndices),
+	)
+	if err != nil {
+		return err
+	}
+	if membersHash != result.MembersHash {
+		return fmt.Errorf("invalid members hash")
+	}
+
+	signatureSize := 65
+	if len(result.Signatures) !=
+		len(result.SigningMembersIndices)*signatureSize {
+		return fmt.Errorf("malformed signatures array")
+	}
+
+	hash, err := calculateDKGResultHash(
+		simulatedChainID,
+		&beaconchain.DKGResult{
+			GroupPublicKey: result.GroupPubKey,
+			Misbehaved:     result.MisbehavedMembersIndices,
+		},
+		rb.dkgStartBlock,
+	)
+	if err != nil {
+		return err
+	}
+	prefixedHash := crypto.Keccak256(
+		[]byte("\x19Ethereum Signed Message:\n32"),
+		hash[:],
+	)
+
+	for i, memberIndex := range result.SigningMembersIndices {
+		signature := make([]byte, signatureSize)
+		copy(signature, result.Signatures[i*signatureSize:])
+		signature[signatureSize-1] -= 27
+
+		publicKey, err := crypto.SigToPub(prefixedHash, signature)
+		if err != nil {
+			return fmt.Errorf("invalid signature")
+		}
+
+		operator := rb.operators.operator(
+			result.Members[memberIndex.Uint64()-1],
+		)
+		if crypto.PubkeyToAddress(*publicKey) != operator {
+			return fmt.Errorf("invalid signature")
+		}
+	}
+
+	return nil
+}
+
+func (rb *fakeRandomBeacon) submitRelayEntry(
+	call *fakeContractCall,
+	entry []byte,
+	groupMembers []uint32,
+) error {
+	if !rb.requestInProgress {
+		return fmt.Errorf("there is no relay request in progress")
+	}
+
+	group := rb.groups[rb.requestGroupID]
+
+	softTimeoutEnd := rb.requestStartBlock + rb.relayEntrySoftTimeout
+	if groupMembers == nil {
+		if call.block > softTimeoutEnd {
+			return fmt.Errorf("relay entry soft timeout passed")
+		}
+	} else {
+		if call.block <= softTimeoutEnd {
+			return fmt.Errorf("relay entry soft timeout has not passed yet")
+		}
+
+		if err := requireGroupMembers(group, groupMembers); err != nil {
+			return err
+		}
+	}
+
+	if call.dryRun {
+		return nil
+	}
+
+	if groupMembers != nil {
+		rb.punishedMembers = append(rb.punishedMembers, groupMembers)
+	}
+	rb.requestInProgress = false
+
+	return call.emit(
+		"RelayEntrySubmitted",
+		rb.requestCounter,
+		call.sender,
+		entry,
+	)
+}
+
+func (rb *fakeRandomBeacon) reportRelayEntryTimeout(
+	call *fakeContractCall,
+	groupMembers []uint32,
+) error {
+	if !rb.requestInProgress {
+		return fmt.Errorf("there is no relay request in progress")
+	}
+
+	if call.block <= rb.requestStartBlock+
+		rb.relayEntrySoftTimeout+rb.relayEntryHardTimeout {
+		return fmt.Errorf("relay entry did not time out")
+	}
+
+	if err := requireGroupMembers(
+		rb.groups[rb.requestGroupID],
+		groupMembers,
+	); err != nil {
+		return err
+	}
+
+	if call.dryRun {
+		return nil
+	}
+
+	rb.groups[rb.requestGroupID].Terminated = true
+	rb.punishedMembers = append(rb.punishedMembers, groupMembers)
+	rb.requestInProgress = false
+
+	return call.emit("RelayEntryTimedOut", rb.requestCounter, rb.requestGroupID)
+}
+
+func requireGroupMembers(
+	group beaconabi.GroupsGroup,
+	groupMembers []uint32,
+) error {
+	membersHash, err := calculateMembersHash(groupMembers)
+	if err != nil {
+		return err
+	}
+
+	if membersHash != group.MembersHash {
+		return fmt.Errorf("invalid group members")
+	}
+
+	return nil
+}
+
+// fakeSortitionPool implements the subset of the sortition pool contract
+// logic used by the beacon chain handle.
+type fakeSortitionPool struct {
+	abi       *hostchainabi.ABI
+	operators map[uint32]common.Address
+}
+
+func newFakeSortitionPool(
+	operators map[uint32]common.Address,
+) (*fakeSortitionPool, error) {
+	contractABI, err := beaconabi.BeaconSortitionPoolMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return &fakeSortitionPool{contractABI, operators}, nil
+}
+
+func (sp *fakeSortitionPool) contractABI() *hostchainabi.ABI {
+	return sp.abi
+}
+
+func (sp *fakeSortitionPool) operator(id uint32) common.Address {
+	return sp.operators[id]
+}
+
+func (sp *fakeSortitionPool) execute(
+	call *fakeContractCall,
+	method *hostchainabi.Method,
+	args []interface{},
+) ([]interface{}, error) {
+	switch method.Name {
+	case "getIDOperators":
+		ids := args[0].([]uint32)
+		operators := make([]common.Address, len(ids))
+		for i, id := range ids {
+			operators[i] = sp.operator(id)
+		}
+		return []interface{}{operators}, nil
+	default:
+		return nil, fmt.Errorf("unsupported method [%v]", method.Name)
+	}
+}
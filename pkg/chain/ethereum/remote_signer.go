@@ -0,0 +1,116 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/remotesigner"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+// remoteSigner is the chain.Signing implementation delegating signing to the
+// remote signer. Signature verification and address conversions are done
+// locally.
+type remoteSigner struct {
+	*signer
+
+	client *remotesigner.Client
+}
+
+func newRemoteSigner(client *remotesigner.Client) *remoteSigner {
+	return &remoteSigner{
+		// The embedded signer holds only the operator's public key. It must
+		// never be used for signing.
+		signer: &signer{
+			ethutil.NewSigner(&ecdsa.PrivateKey{PublicKey: *client.PublicKey()}),
+		},
+		client: client,
+	}
+}
+
+// Sign signs the provided message with the operator's key held by the
+// remote signer.
+func (rs *remoteSigner) Sign(message []byte) ([]byte, error) {
+	return rs.client.SignMessage(context.Background(), message)
+}
+
+// remoteAccount is the operator account whose key is held by the remote
+// signer. Transactions are signed by the remote signer through the signer
+// function of the transactor options.
+type remoteAccount struct {
+	client *remotesigner.Client
+}
+
+func newRemoteAccount(client *remotesigner.Client) *remoteAccount {
+	return &remoteAccount{client: client}
+}
+
+func (ra *remoteAccount) bindingKey() *keystore.Key {
+	// The bindings receive only the operator's public key; they never sign
+	// anything with it.
+	return &keystore.Key{
+		Address:    ra.client.Address(),
+		PrivateKey: &ecdsa.PrivateKey{PublicKey: *ra.client.PublicKey()},
+	}
+}
+
+func (ra *remoteAccount) transactorOptions(
+	chainID *big.Int,
+) (*bind.TransactOpts, error) {
+	operatorAddress := ra.client.Address()
+
+	return &bind.TransactOpts{
+		From: operatorAddress,
+		Signer: func(
+			address common.Address,
+			transaction *types.Transaction,
+		) (*types.Transaction, error) {
+			if address != operatorAddress {
+				return nil, bind.ErrNotAuthorized
+			}
+
+			signedTransaction, err := ra.client.SignTransaction(
+				context.Background(),
+				transaction,
+				chainID,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"remote signer failed to sign transaction: [%w]",
+					err,
+				)
+			}
+
+			return signedTransaction, nil
+		},
+		Context: context.Background(),
+	}, nil
+}
+
+func (ra *remoteAccount) signing() chain.Signing {
+	return newRemoteSigner(ra.client)
+}
+
+func (ra *remoteAccount) operatorKeyPair() (
+	*operator.PrivateKey,
+	*operator.PublicKey,
+	error,
+) {
+	publicKey := ra.client.PublicKey()
+
+	// The private key is held by the remote signer and never leaves it.
+	return nil, &operator.PublicKey{
+		Curve: operator.Secp256k1,
+		X:     publicKey.X,
+		Y:     publicKey.Y,
+	}, nil
+}
@@ -0,0 +1,171 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/remotesigner"
+)
+
+func TestRemoteAccount_TransactorOptions(t *testing.T) {
+	account, operatorAddress := newTestRemoteAccount(t)
+
+	chainID := big.NewInt(1101)
+
+	transactorOptions, err := account.transactorOptions(chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if transactorOptions.From != operatorAddress {
+		t.Errorf(
+			"unexpected transactor address\nexpected: %v\nactual:   %v",
+			operatorAddress,
+			transactorOptions.From,
+		)
+	}
+
+	to := common.HexToAddress("0x1f9090aaE28b8a3dCeaDf281B0F12828e676c326")
+	transaction := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     3,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       50000,
+		To:        &to,
+		Data:      []byte{0x01, 0x02},
+	})
+
+	signedTransaction, err := transactorOptions.Signer(
+		transactorOptions.From,
+		transaction,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender, err := types.Sender(
+		types.LatestSignerForChainID(chainID),
+		signedTransaction,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender != operatorAddress {
+		t.Errorf(
+			"unexpected sender\nexpected: %v\nactual:   %v",
+			operatorAddress,
+			sender,
+		)
+	}
+
+	if _, err := transactorOptions.Signer(to, transaction); err == nil {
+		t.Error("expected signing for another address to fail")
+	}
+}
+
+func TestRemoteAccount_Transact(t *testing.T) {
+	account, operatorAddress := newTestRemoteAccount(t)
+
+	simulatedChain := newSimulatedChain(
+		t,
+		map[common.Address]fakeContract{},
+		operatorAddress,
+	)
+	baseChain := simulatedChain.connect(account)
+
+	recipient := newTestKey(t).Address
+	transfer := bind.NewBoundContract(
+		recipient,
+		abi.ABI{},
+		nil,
+		simulatedChain,
+		nil,
+	)
+
+	transaction, err := baseChain.transact(
+		"transfer",
+		func(transactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			transactorOptions.GasLimit = 21000
+			transactorOptions.Value = big.NewInt(1)
+			return transfer.Transfer(transactorOptions)
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The hash returned to the caller is the hash of the transaction sent
+	// to the chain.
+	receipt, err := simulatedChain.TransactionReceipt(
+		context.Background(),
+		transaction.Hash(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Errorf("unexpected receipt status [%v]", receipt.Status)
+	}
+
+	balance, err := simulatedChain.BalanceAt(
+		context.Background(),
+		recipient,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("unexpected recipient balance [%v]", balance)
+	}
+}
+
+func TestRemoteSigner_Sign(t *testing.T) {
+	account, _ := newTestRemoteAccount(t)
+
+	signing := account.signing()
+
+	message := []byte("dkg result")
+
+	signature, err := signing.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := signing.Verify(message, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("signature verification failed")
+	}
+}
+
+func newTestRemoteAccount(t *testing.T) (*remoteAccount, common.Address) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(remotesigner.NewServer(privateKey))
+	t.Cleanup(server.Close)
+
+	signerClient, err := remotesigner.Dial(
+		context.Background(),
+		remotesigner.Config{URL: server.URL},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return newRemoteAccount(signerClient), crypto.PubkeyToAddress(privateKey.PublicKey)
+}
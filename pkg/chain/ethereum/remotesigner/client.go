@@ -0,0 +1,295 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+// maxResponseSize is the maximum accepted size of the remote signer response.
+const maxResponseSize = 1 << 20
+
+// Client talks to the remote signer over HTTP. The client verifies every
+// signature returned by the remote signer against the operator's public key
+// fetched when the client was created.
+type Client struct {
+	url        string
+	httpClient *http.Client
+	nextID     uint64
+
+	publicKey *ecdsa.PublicKey
+	address   common.Address
+}
+
+// Dial creates a new remote signer client and fetches the operator's public
+// key from the remote signer.
+func Dial(ctx context.Context, config Config) (*Client, error) {
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	client := &Client{
+		url:        config.URL,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+
+	result := &PublicKeyResult{}
+	if err := client.call(ctx, MethodPublicKey, nil, result); err != nil {
+		return nil, fmt.Errorf("cannot fetch operator public key: [%w]", err)
+	}
+
+	publicKey, err := crypto.UnmarshalPubkey(result.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid operator public key: [%w]", err)
+	}
+
+	client.publicKey = publicKey
+	client.address = crypto.PubkeyToAddress(*publicKey)
+
+	logger.Infof(
+		"connected to remote signer at [%s] for operator [%s]",
+		config.URL,
+		client.address.Hex(),
+	)
+
+	return client, nil
+}
+
+// PublicKey returns the operator's public key held by the remote signer.
+func (c *Client) PublicKey() *ecdsa.PublicKey {
+	return c.publicKey
+}
+
+// Address returns the operator's address.
+func (c *Client) Address() common.Address {
+	return c.address
+}
+
+// SignMessage signs the message using the Ethereum-specific format. The
+// returned signature has the recovery ID V in {27, 28} to conform with the
+// on-chain signature validation code.
+func (c *Client) SignMessage(
+	ctx context.Context,
+	message []byte,
+) ([]byte, error) {
+	signature, err := c.sign(
+		ctx,
+		MethodSignMessage,
+		&SignMessageParams{Message: message},
+		ethereumPrefixedHash(message),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	signature[SignatureSize-1] += 27
+
+	return signature, nil
+}
+
+// SignTransaction signs the unsigned transaction for the given chain ID and
+// returns the signed transaction.
+func (c *Client) SignTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+	chainID *big.Int,
+) (*types.Transaction, error) {
+	transactionBytes, err := transaction.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal transaction: [%w]", err)
+	}
+
+	signer := types.LatestSignerForChainID(chainID)
+
+	signature, err := c.sign(
+		ctx,
+		MethodSignTransaction,
+		newSignTransactionParams(transactionBytes, chainID),
+		signer.Hash(transaction).Bytes(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction.WithSignature(signer, signature)
+}
+
+// SignNetwork signs the network-level data. The returned signature is in
+// the [R || S || V] format with the recovery ID V in {0, 1}.
+func (c *Client) SignNetwork(
+	ctx context.Context,
+	data []byte,
+) ([]byte, error) {
+	digest := sha256.Sum256(data)
+
+	return c.sign(
+		ctx,
+		MethodSignNetwork,
+		&SignNetworkParams{Data: data},
+		digest[:],
+	)
+}
+
+// sign executes the signing method and verifies the returned signature
+// against the expected digest and the operator's public key.
+func (c *Client) sign(
+	ctx context.Context,
+	method string,
+	params interface{},
+	digest []byte,
+) ([]byte, error) {
+	result := &SignatureResult{}
+	if err := c.call(ctx, method, params, result); err != nil {
+		return nil, err
+	}
+
+	signature := []byte(result.Signature)
+	if len(signature) != SignatureSize {
+		return nil, fmt.Errorf(
+			"invalid signature length; expected [%v], has [%v]",
+			SignatureSize,
+			len(signature),
+		)
+	}
+
+	recoveredPublicKey, err := crypto.SigToPub(digest, signature)
+	if err != nil {
+		return nil, fmt.Errorf("cannot recover signer: [%w]", err)
+	}
+
+	if crypto.PubkeyToAddress(*recoveredPublicKey) != c.address {
+		return nil, fmt.Errorf(
+			"signature returned by remote signer does not match " +
+				"the operator's public key",
+		)
+	}
+
+	return signature, nil
+}
+
+func (c *Client) call(
+	ctx context.Context,
+	method string,
+	params interface{},
+	result interface{},
+) error {
+	request := &request{
+		JSONRPC: jsonRPCVersion,
+		ID:      atomic.AddUint64(&c.nextID, 1),
+		Method:  method,
+	}
+
+	if params != nil {
+		encodedParams, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("cannot marshal params: [%w]", err)
+		}
+		request.Params = encodedParams
+	}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("cannot marshal request: [%w]", err)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		c.url,
+		bytes.NewReader(requestBody),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot create request: [%w]", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("request [%s] failed: [%w]", method, err)
+	}
+	defer httpResponse.Body.Close()
+
+	responseBody, err := io.ReadAll(
+		io.LimitReader(httpResponse.Body, maxResponseSize),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot read response: [%w]", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"request [%s] failed with status [%s]",
+			method,
+			httpResponse.Status,
+		)
+	}
+
+	response := &response{}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return fmt.Errorf("cannot unmarshal response: [%w]", err)
+	}
+
+	if response.Error != nil {
+		return response.Error
+	}
+
+	if response.ID != request.ID {
+		return fmt.Errorf(
+			"unexpected response ID; expected [%v], has [%v]",
+			request.ID,
+			response.ID,
+		)
+	}
+
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("cannot unmarshal result: [%w]", err)
+	}
+
+	return nil
+}
+
+func ethereumPrefixedHash(message []byte) []byte {
+	return crypto.Keccak256(
+		[]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%v", len(message))),
+		message,
+	)
+}
+
+// NetworkSigner exposes the remote signer as the signer of the operator's
+// network identity.
+type NetworkSigner struct {
+	client *Client
+}
+
+// NetworkSigner returns the network identity signer backed by the client.
+func (c *Client) NetworkSigner() *NetworkSigner {
+	return &NetworkSigner{client: c}
+}
+
+// OperatorPublicKey returns the operator's public key.
+func (ns *NetworkSigner) OperatorPublicKey() *operator.PublicKey {
+	return &operator.PublicKey{
+		Curve: operator.Secp256k1,
+		X:     ns.client.publicKey.X,
+		Y:     ns.client.publicKey.Y,
+	}
+}
+
+// SignNetwork signs the network-level data with the operator's key.
+func (ns *NetworkSigner) SignNetwork(data []byte) ([]byte, error) {
+	return ns.client.SignNetwork(context.Background(), data)
+}
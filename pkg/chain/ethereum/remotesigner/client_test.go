@@ -0,0 +1,165 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestClient_SignMessage(t *testing.T) {
+	privateKey, client := newTestClient(t)
+
+	message := []byte("hello remote signer")
+
+	signature, err := client.SignMessage(context.Background(), message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := signature[SignatureSize-1]; v != 27 && v != 28 {
+		t.Errorf("unexpected recovery ID [%v]", v)
+	}
+
+	recoverable := append([]byte{}, signature...)
+	recoverable[SignatureSize-1] -= 27
+
+	publicKey, err := crypto.SigToPub(ethereumPrefixedHash(message), recoverable)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertPublicKey(t, &privateKey.PublicKey, publicKey)
+}
+
+func TestClient_SignTransaction(t *testing.T) {
+	privateKey, client := newTestClient(t)
+
+	chainID := big.NewInt(1337)
+	to := common.HexToAddress("0x1f9090aaE28b8a3dCeaDf281B0F12828e676c326")
+
+	transaction := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		GasTipCap: big.NewInt(1000),
+		GasFeeCap: big.NewInt(2000),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+
+	signedTransaction, err := client.SignTransaction(
+		context.Background(),
+		transaction,
+		chainID,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender, err := types.Sender(
+		types.LatestSignerForChainID(chainID),
+		signedTransaction,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := crypto.PubkeyToAddress(privateKey.PublicKey); sender != expected {
+		t.Errorf(
+			"unexpected sender\nexpected: %v\nactual:   %v",
+			expected,
+			sender,
+		)
+	}
+}
+
+func TestClient_SignNetwork(t *testing.T) {
+	privateKey, client := newTestClient(t)
+
+	data := []byte("network data")
+
+	signature, err := client.NetworkSigner().SignNetwork(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := sha256.Sum256(data)
+	publicKey, err := crypto.SigToPub(digest[:], signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertPublicKey(t, &privateKey.PublicKey, publicKey)
+}
+
+func TestClient_RejectsSignatureOfAnotherKey(t *testing.T) {
+	_, client := newTestClient(t)
+
+	otherKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherServer := httptest.NewServer(NewServer(otherKey))
+	defer otherServer.Close()
+
+	// Point the client to the signer holding another key.
+	client.url = otherServer.URL
+
+	_, err = client.SignMessage(context.Background(), []byte("message"))
+	if err == nil {
+		t.Fatal("expected error for a signature of another key")
+	}
+}
+
+func TestClient_UnknownMethod(t *testing.T) {
+	_, client := newTestClient(t)
+
+	err := client.call(context.Background(), "keep_unknown", nil, &SignatureResult{})
+
+	rpcErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("unexpected error type: [%v]", err)
+	}
+
+	if rpcErr.Code != errorCodeMethodNotFound {
+		t.Errorf(
+			"unexpected error code\nexpected: %v\nactual:   %v",
+			errorCodeMethodNotFound,
+			rpcErr.Code,
+		)
+	}
+}
+
+func newTestClient(t *testing.T) (*ecdsa.PrivateKey, *Client) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(NewServer(privateKey))
+	t.Cleanup(server.Close)
+
+	client, err := Dial(context.Background(), Config{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertPublicKey(t, &privateKey.PublicKey, client.PublicKey())
+
+	return privateKey, client
+}
+
+func assertPublicKey(t *testing.T, expected, actual *ecdsa.PublicKey) {
+	if !bytes.Equal(crypto.FromECDSAPub(expected), crypto.FromECDSAPub(actual)) {
+		t.Errorf("unexpected public key")
+	}
+}
@@ -0,0 +1,60 @@
+// Command reference-signer runs the reference remote signer holding the
+// operator key decrypted from an Ethereum key file. It is meant for tests and
+// local development only.
+package main
+
+import (
+	"flag"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-common/pkg/logging"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/remotesigner"
+)
+
+// #nosec G101 (look for hardcoded credentials)
+// This line doesn't contain any credentials.
+// It's just the name of the environment variable.
+const passwordEnvVariable = "KEEP_ETHEREUM_PASSWORD"
+
+var logger = log.Logger("keep-reference-signer")
+
+func main() {
+	if err := logging.Configure(os.Getenv("LOG_LEVEL")); err != nil {
+		logger.Errorf("failed to configure logging: [%v]", err)
+	}
+
+	keyFile := flag.String("keyFile", "", "Path to the operator key file.")
+	address := flag.String("address", "127.0.0.1:9801", "Listening address.")
+	flag.Parse()
+
+	if *keyFile == "" {
+		logger.Fatal("missing value for -keyFile")
+	}
+
+	key, err := ethutil.DecryptKeyFile(
+		*keyFile,
+		os.Getenv(passwordEnvVariable),
+	)
+	if err != nil {
+		logger.Fatalf("cannot decrypt key file: [%v]", err)
+	}
+
+	logger.Infof(
+		"serving remote signer for operator [%s] on [%s]",
+		key.Address.Hex(),
+		*address,
+	)
+
+	server := &http.Server{
+		Addr:              *address,
+		Handler:           remotesigner.NewServer(key.PrivateKey),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	logger.Fatal(server.ListenAndServe())
+}
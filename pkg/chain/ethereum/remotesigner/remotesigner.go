@@ -0,0 +1,139 @@
+// Package remotesigner implements the protocol allowing the client to sign
+// with the operator key held outside of the client process, for example by
+// a key management service.
+//
+// The protocol is JSON-RPC 2.0 over HTTP. The remote signer never signs
+// digests provided by the client directly. Instead, it receives the full
+// content to sign and computes the digest on its own, according to the
+// requested method. This way, the signer can apply its own policy to every
+// request and a signature produced for one purpose cannot be reused for
+// another one.
+//
+// Supported methods:
+//
+//   - keep_publicKey returns the operator's uncompressed secp256k1 public key,
+//   - keep_signMessage signs an arbitrary message using the Ethereum-specific
+//     format, that is, the Keccak-256 digest of the message prefixed with
+//     "\x19Ethereum Signed Message:\n" and the message length,
+//   - keep_signTransaction signs an unsigned Ethereum transaction for the
+//     given chain ID,
+//   - keep_signNetwork signs network-level data, that is, the SHA-256 digest
+//     of the data, as used by the libp2p secp256k1 keys.
+//
+// All signing methods return a 65-byte signature in the [R || S || V] format
+// with the recovery ID V in {0, 1}.
+package remotesigner
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ipfs/go-log"
+)
+
+var logger = log.Logger("keep-remotesigner")
+
+// Definitions of the protocol methods.
+const (
+	MethodPublicKey       = "keep_publicKey"
+	MethodSignMessage     = "keep_signMessage"
+	MethodSignTransaction = "keep_signTransaction"
+	MethodSignNetwork     = "keep_signNetwork"
+)
+
+// SignatureSize is the byte size of signatures returned by the remote signer.
+const SignatureSize = 65
+
+// DefaultTimeout is the default timeout of a single remote signer request.
+const DefaultTimeout = 10 * time.Second
+
+// jsonRPCVersion is the version of the JSON-RPC protocol in use.
+const jsonRPCVersion = "2.0"
+
+// JSON-RPC 2.0 error codes used by the protocol.
+const (
+	errorCodeParse          = -32700
+	errorCodeInvalidRequest = -32600
+	errorCodeMethodNotFound = -32601
+	errorCodeInvalidParams  = -32602
+	errorCodeInternal       = -32603
+)
+
+// Config holds the configuration of the remote signer client.
+type Config struct {
+	// URL of the remote signer HTTP endpoint. If empty, the remote signer
+	// is disabled and the operator key is read from the key file.
+	URL string
+	// Timeout of a single remote signer request.
+	Timeout time.Duration
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      uint64          `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      uint64          `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is the JSON-RPC error returned by the remote signer.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("remote signer error [%v]: [%s]", e.Code, e.Message)
+}
+
+// PublicKeyResult is the result of the keep_publicKey method.
+type PublicKeyResult struct {
+	PublicKey hexutil.Bytes `json:"publicKey"`
+}
+
+// SignMessageParams are the parameters of the keep_signMessage method.
+type SignMessageParams struct {
+	Message hexutil.Bytes `json:"message"`
+}
+
+// SignTransactionParams are the parameters of the keep_signTransaction
+// method. The transaction is the unsigned transaction in the binary format
+// as defined by EIP-2718.
+type SignTransactionParams struct {
+	Transaction hexutil.Bytes `json:"transaction"`
+	ChainID     *hexutil.Big  `json:"chainId"`
+}
+
+// SignNetworkParams are the parameters of the keep_signNetwork method.
+type SignNetworkParams struct {
+	Data hexutil.Bytes `json:"data"`
+}
+
+// SignatureResult is the result of all the signing methods.
+type SignatureResult struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// IsEnabled returns true if the remote signer is configured.
+func (c Config) IsEnabled() bool {
+	return c.URL != ""
+}
+
+func newSignTransactionParams(
+	transaction []byte,
+	chainID *big.Int,
+) *SignTransactionParams {
+	return &SignTransactionParams{
+		Transaction: transaction,
+		ChainID:     (*hexutil.Big)(chainID),
+	}
+}
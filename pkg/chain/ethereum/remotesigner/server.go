@@ -0,0 +1,177 @@
+package remotesigner
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// maxRequestSize is the maximum accepted size of the remote signer request.
+const maxRequestSize = 1 << 20
+
+// Server is the reference remote signer implementation holding the operator
+// key in memory. It is meant for tests and as a reference for integrations
+// with key management services. Production deployments should keep the key
+// in a dedicated signing service.
+type Server struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewServer creates a new reference remote signer for the given key.
+func NewServer(privateKey *ecdsa.PrivateKey) *Server {
+	return &Server{privateKey: privateKey}
+}
+
+// ServeHTTP handles a single JSON-RPC request.
+func (s *Server) ServeHTTP(writer http.ResponseWriter, httpRequest *http.Request) {
+	if httpRequest.Method != http.MethodPost {
+		http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(httpRequest.Body, maxRequestSize))
+	if err != nil {
+		http.Error(writer, "cannot read request", http.StatusBadRequest)
+		return
+	}
+
+	request := &request{}
+	if err := json.Unmarshal(body, request); err != nil {
+		s.writeError(writer, 0, errorCodeParse, "cannot parse request")
+		return
+	}
+
+	if request.JSONRPC != jsonRPCVersion {
+		s.writeError(
+			writer,
+			request.ID,
+			errorCodeInvalidRequest,
+			"unsupported JSON-RPC version",
+		)
+		return
+	}
+
+	result, rpcErr := s.handle(request)
+	if rpcErr != nil {
+		logger.Warnf(
+			"remote signer request [%s] failed: [%v]",
+			request.Method,
+			rpcErr,
+		)
+		s.writeError(writer, request.ID, rpcErr.Code, rpcErr.Message)
+		return
+	}
+
+	encodedResult, err := json.Marshal(result)
+	if err != nil {
+		s.writeError(writer, request.ID, errorCodeInternal, err.Error())
+		return
+	}
+
+	s.writeResponse(writer, &response{
+		JSONRPC: jsonRPCVersion,
+		ID:      request.ID,
+		Result:  encodedResult,
+	})
+}
+
+func (s *Server) handle(request *request) (interface{}, *Error) {
+	switch request.Method {
+	case MethodPublicKey:
+		return &PublicKeyResult{
+			PublicKey: crypto.FromECDSAPub(&s.privateKey.PublicKey),
+		}, nil
+
+	case MethodSignMessage:
+		params := &SignMessageParams{}
+		if err := unmarshalParams(request, params); err != nil {
+			return nil, err
+		}
+
+		return s.sign(ethereumPrefixedHash(params.Message))
+
+	case MethodSignTransaction:
+		params := &SignTransactionParams{}
+		if err := unmarshalParams(request, params); err != nil {
+			return nil, err
+		}
+
+		if params.ChainID == nil {
+			return nil, &Error{errorCodeInvalidParams, "missing chain ID"}
+		}
+
+		transaction := &types.Transaction{}
+		if err := transaction.UnmarshalBinary(params.Transaction); err != nil {
+			return nil, &Error{
+				errorCodeInvalidParams,
+				fmt.Sprintf("cannot unmarshal transaction: [%v]", err),
+			}
+		}
+
+		signer := types.LatestSignerForChainID(params.ChainID.ToInt())
+
+		return s.sign(signer.Hash(transaction).Bytes())
+
+	case MethodSignNetwork:
+		params := &SignNetworkParams{}
+		if err := unmarshalParams(request, params); err != nil {
+			return nil, err
+		}
+
+		digest := sha256.Sum256(params.Data)
+
+		return s.sign(digest[:])
+
+	default:
+		return nil, &Error{
+			errorCodeMethodNotFound,
+			fmt.Sprintf("unknown method [%s]", request.Method),
+		}
+	}
+}
+
+func (s *Server) sign(digest []byte) (*SignatureResult, *Error) {
+	signature, err := crypto.Sign(digest, s.privateKey)
+	if err != nil {
+		return nil, &Error{errorCodeInternal, err.Error()}
+	}
+
+	return &SignatureResult{Signature: signature}, nil
+}
+
+func (s *Server) writeError(
+	writer http.ResponseWriter,
+	id uint64,
+	code int,
+	message string,
+) {
+	s.writeResponse(writer, &response{
+		JSONRPC: jsonRPCVersion,
+		ID:      id,
+		Error:   &Error{Code: code, Message: message},
+	})
+}
+
+func (s *Server) writeResponse(writer http.ResponseWriter, response *response) {
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(response); err != nil {
+		logger.Errorf("cannot write remote signer response: [%v]", err)
+	}
+}
+
+func unmarshalParams(request *request, params interface{}) *Error {
+	if err := json.Unmarshal(request.Params, params); err != nil {
+		return &Error{
+			errorCodeInvalidParams,
+			fmt.Sprintf("cannot unmarshal params: [%v]", err),
+		}
+	}
+
+	return nil
+}
@@ -50,5 +50,5 @@ func (s *signer) PublicKeyBytesToAddress(publicKey []byte) chain.Address {
 }
 
 func (bc *baseChain) Signing() chain.Signing {
-	return newSigner(bc.key)
+	return bc.account.signing()
 }
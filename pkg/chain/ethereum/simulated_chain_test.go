@@ -0,0 +1,747 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	hostchainabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	hostchainevent "github.com/ethereum/go-ethereum/event"
+
+	commonethereum "github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+)
+
+// simulatedChainID is the chain ID used by the go-ethereum simulated backend.
+var simulatedChainID = big.NewInt(1337)
+
+// fakeContract is a contract deployed on the simulated chain whose logic is
+// implemented in Go. The simulated chain routes calls and transactions sent
+// to the contract address to the fake contract, according to the contract
+// ABI.
+type fakeContract interface {
+	contractABI() *hostchainabi.ABI
+	execute(
+		call *fakeContractCall,
+		method *hostchainabi.Method,
+		args []interface{},
+	) ([]interface{}, error)
+}
+
+// fakeContractCall holds the context of a single call or transaction
+// executed by a fake contract. Contract state must not be modified and
+// events are not emitted when the call is a dry run, i.e. a contract call
+// or a gas estimation.
+type fakeContractCall struct {
+	address common.Address
+	abi     *hostchainabi.ABI
+	sender  common.Address
+	block   uint64
+	dryRun  bool
+	logs    []*types.Log
+}
+
+// emit emits the event with the given name. Arguments are expected in
+// the order of the event inputs.
+func (c *fakeContractCall) emit(eventName string, args ...interface{}) error {
+	if c.dryRun {
+		return nil
+	}
+
+	event, ok := c.abi.Events[eventName]
+	if !ok {
+		return fmt.Errorf("unknown event [%v]", eventName)
+	}
+
+	if len(args) != len(event.Inputs) {
+		return fmt.Errorf("invalid number of [%v] event arguments", eventName)
+	}
+
+	topics := []common.Hash{event.ID}
+	nonIndexed := make([]interface{}, 0)
+	for i, input := range event.Inputs {
+		if !input.Indexed {
+			nonIndexed = append(nonIndexed, args[i])
+			continue
+		}
+
+		topic, err := hostchainabi.MakeTopics([]interface{}{args[i]})
+		if err != nil {
+			return err
+		}
+		topics = append(topics, topic[0][0])
+	}
+
+	data, err := event.Inputs.NonIndexed().Pack(nonIndexed...)
+	if err != nil {
+		return err
+	}
+
+	c.logs = append(c.logs, &types.Log{
+		Address: c.address,
+		Topics:  topics,
+		Data:    data,
+	})
+
+	return nil
+}
+
+// simulatedChain is an in-process Ethereum chain backed by the go-ethereum
+// simulated backend. Each transaction is mined in its own block. Logs of fake
+// contracts are kept by the simulated chain itself as fake contracts do not
+// execute on the EVM.
+type simulatedChain struct {
+	*backends.SimulatedBackend
+
+	t *testing.T
+
+	mutex         sync.Mutex
+	contracts     map[common.Address]fakeContract
+	logs          []types.Log
+	subscriptions map[*logSubscription]bool
+
+	blockCounter *commonethereum.BlockCounter
+}
+
+type logSubscription struct {
+	query ethereum.FilterQuery
+	sink  chan<- types.Log
+	quit  <-chan struct{}
+}
+
+// newSimulatedChain creates a simulated chain with the given fake contracts
+// deployed. Each of the given accounts is funded with ether.
+func newSimulatedChain(
+	t *testing.T,
+	contracts map[common.Address]fakeContract,
+	accounts ...common.Address,
+) *simulatedChain {
+	alloc := make(core.GenesisAlloc)
+	for _, account := range accounts {
+		alloc[account] = core.GenesisAccount{
+			Balance: new(big.Int).Exp(big.NewInt(10), big.NewInt(21), nil),
+		}
+	}
+	// Contract bindings refuse to transact with addresses without code.
+	for address := range contracts {
+		alloc[address] = core.GenesisAccount{
+			Code:    []byte{0x00}, // STOP
+			Balance: big.NewInt(0),
+		}
+	}
+
+	backend := backends.NewSimulatedBackend(alloc, 30000000)
+	t.Cleanup(func() { backend.Close() })
+
+	simulatedChain := &simulatedChain{
+		SimulatedBackend: backend,
+		t:                t,
+		contracts:        contracts,
+		subscriptions:    make(map[*logSubscription]bool),
+	}
+
+	blockCounter, err := ethutil.NewBlockCounter(simulatedChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	simulatedChain.blockCounter = blockCounter
+
+	return simulatedChain
+}
+
+// connect creates a base chain handle for the given operator account,
+// connected to the simulated chain.
+func (sc *simulatedChain) connect(account operatorAccount) *baseChain {
+	key := account.bindingKey()
+
+	transactorOptions, err := account.transactorOptions(simulatedChainID)
+	if err != nil {
+		sc.t.Fatal(err)
+	}
+
+	return &baseChain{
+		key:          key,
+		account:      account,
+		client:       sc,
+		chainID:      simulatedChainID,
+		blockCounter: sc.blockCounter,
+		nonceManager: ethutil.NewNonceManager(sc, key.Address),
+		miningWaiter: ethutil.NewMiningWaiter(
+			sc,
+			commonethereum.Config{MiningCheckInterval: time.Hour},
+		),
+		transactorOptions: transactorOptions,
+		maxGasFeeCap:      ethutil.DefaultMaxGasFeeCap.Int,
+		transactionMutex:  &sync.Mutex{},
+	}
+}
+
+// currentBlock returns the number of the latest block of the simulated chain.
+func (sc *simulatedChain) currentBlock() uint64 {
+	return sc.Blockchain().CurrentBlock().NumberU64()
+}
+
+// mine mines the given number of empty blocks.
+func (sc *simulatedChain) mine(blocks int) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	for i := 0; i < blocks; i++ {
+		sc.Commit()
+	}
+
+	sc.waitForBlockCounter()
+}
+
+// mineAction mines a block with the given action performed by the fake
+// contract. It is meant to trigger contract actions not performed by
+// the client, e.g. relay entry requests.
+func (sc *simulatedChain) mineAction(
+	contractAddress common.Address,
+	action func(call *fakeContractCall) error,
+) error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	call := &fakeContractCall{
+		address: contractAddress,
+		abi:     sc.contracts[contractAddress].contractABI(),
+		block:   sc.currentBlock() + 1,
+	}
+
+	if err := action(call); err != nil {
+		return err
+	}
+
+	sc.Commit()
+	sc.recordLogs(call.logs)
+	sc.waitForBlockCounter()
+
+	return nil
+}
+
+// waitForBlockCounter waits until the block counter observes the latest
+// block so that the client code sees the same block as the simulated chain.
+func (sc *simulatedChain) waitForBlockCounter() {
+	if sc.blockCounter == nil {
+		return
+	}
+
+	if err := sc.blockCounter.WaitForBlockHeight(sc.currentBlock()); err != nil {
+		sc.t.Fatal(err)
+	}
+}
+
+func (sc *simulatedChain) executeFake(
+	contractAddress common.Address,
+	sender common.Address,
+	data []byte,
+	block uint64,
+	dryRun bool,
+) ([]*types.Log, []byte, error) {
+	contract := sc.contracts[contractAddress]
+	contractABI := contract.contractABI()
+
+	if len(data) < 4 {
+		return nil, nil, fmt.Errorf("execution reverted")
+	}
+
+	method, err := contractABI.MethodById(data[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	call := &fakeContractCall{
+		address: contractAddress,
+		abi:     contractABI,
+		sender:  sender,
+		block:   block,
+		dryRun:  dryRun,
+	}
+
+	results, err := contract.execute(call, method, args)
+	if err != nil {
+		return nil, nil, fmt.Errorf("execution reverted: %v", err)
+	}
+
+	output, err := method.Outputs.Pack(results...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return call.logs, output, nil
+}
+
+// recordLogs records logs emitted in the latest block and delivers them to
+// subscribers.
+func (sc *simulatedChain) recordLogs(logs []*types.Log) {
+	block := sc.Blockchain().CurrentBlock()
+
+	for _, log := range logs {
+		log.BlockNumber = block.NumberU64()
+		log.BlockHash = block.Hash()
+		log.Index = uint(len(sc.logs))
+		sc.logs = append(sc.logs, *log)
+
+		for subscription := range sc.subscriptions {
+			if matchesQuery(*log, subscription.query) {
+				go func(subscription *logSubscription, log types.Log) {
+					select {
+					case subscription.sink <- log:
+					case <-subscription.quit:
+					}
+				}(subscription, *log)
+			}
+		}
+	}
+}
+
+func (sc *simulatedChain) isFake(address *common.Address) bool {
+	if address == nil {
+		return false
+	}
+
+	_, ok := sc.contracts[*address]
+	return ok
+}
+
+func (sc *simulatedChain) queriesFake(query ethereum.FilterQuery) bool {
+	for _, address := range query.Addresses {
+		if sc.isFake(&address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (sc *simulatedChain) CallContract(
+	ctx context.Context,
+	call ethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	if !sc.isFake(call.To) {
+		return sc.SimulatedBackend.CallContract(ctx, call, blockNumber)
+	}
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	_, output, err := sc.executeFake(
+		*call.To,
+		call.From,
+		call.Data,
+		sc.currentBlock()+1,
+		true,
+	)
+	return output, err
+}
+
+func (sc *simulatedChain) EstimateGas(
+	ctx context.Context,
+	call ethereum.CallMsg,
+) (uint64, error) {
+	if sc.isFake(call.To) {
+		if _, err := sc.CallContract(ctx, call, nil); err != nil {
+			return 0, err
+		}
+	}
+
+	return sc.SimulatedBackend.EstimateGas(ctx, call)
+}
+
+func (sc *simulatedChain) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	var logs []*types.Log
+	if sc.isFake(transaction.To()) {
+		sender, err := types.Sender(
+			types.LatestSignerForChainID(transaction.ChainId()),
+			transaction,
+		)
+		if err != nil {
+			return err
+		}
+
+		logs, _, err = sc.executeFake(
+			*transaction.To(),
+			sender,
+			transaction.Data(),
+			sc.currentBlock()+1,
+			false,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := sc.SimulatedBackend.SendTransaction(ctx, transaction); err != nil {
+		return err
+	}
+
+	sc.Commit()
+
+	for _, log := range logs {
+		log.TxHash = transaction.Hash()
+	}
+	sc.recordLogs(logs)
+
+	sc.waitForBlockCounter()
+
+	return nil
+}
+
+func (sc *simulatedChain) FilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+) ([]types.Log, error) {
+	if !sc.queriesFake(query) {
+		return sc.SimulatedBackend.FilterLogs(ctx, query)
+	}
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	logs := make([]types.Log, 0)
+	for _, log := range sc.logs {
+		if matchesQuery(log, query) {
+			logs = append(logs, log)
+		}
+	}
+
+	return logs, nil
+}
+
+func (sc *simulatedChain) SubscribeFilterLogs(
+	ctx context.Context,
+	query ethereum.FilterQuery,
+	sink chan<- types.Log,
+) (ethereum.Subscription, error) {
+	if !sc.queriesFake(query) {
+		return sc.SimulatedBackend.SubscribeFilterLogs(ctx, query, sink)
+	}
+
+	return hostchainevent.NewSubscription(
+		func(quit <-chan struct{}) error {
+			subscription := &logSubscription{query, sink, quit}
+
+			sc.mutex.Lock()
+			sc.subscriptions[subscription] = true
+			sc.mutex.Unlock()
+
+			<-quit
+
+			sc.mutex.Lock()
+			delete(sc.subscriptions, subscription)
+			sc.mutex.Unlock()
+
+			return nil
+		},
+	), nil
+}
+
+// waitForSubscriptions waits until the given number of log subscriptions
+// is established. Contract bindings subscribe for logs asynchronously.
+func (sc *simulatedChain) waitForSubscriptions(count int) {
+	timeout := time.After(10 * time.Second)
+	for {
+		sc.mutex.Lock()
+		established := len(sc.subscriptions)
+		sc.mutex.Unlock()
+
+		if established >= count {
+			return
+		}
+
+		select {
+		case <-timeout:
+			sc.t.Fatalf(
+				"[%v] out of [%v] log subscriptions established",
+				established,
+				count,
+			)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// matchesQuery checks whether the log matches the address, the block range
+// and the topics of the filter query.
+func matchesQuery(log types.Log, query ethereum.FilterQuery) bool {
+	if len(query.Addresses) > 0 {
+		matches := false
+		for _, address := range query.Addresses {
+			if address == log.Address {
+				matches = true
+			}
+		}
+		if !matches {
+			return false
+		}
+	}
+
+	if query.FromBlock != nil && log.BlockNumber < query.FromBlock.Uint64() {
+		return false
+	}
+	if query.ToBlock != nil && log.BlockNumber > query.ToBlock.Uint64() {
+		return false
+	}
+
+	if len(query.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, topics := range query.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+
+		matches := false
+		for _, topic := range topics {
+			if topic == log.Topics[i] {
+				matches = true
+			}
+		}
+		if !matches {
+			return false
+		}
+	}
+
+	return true
+}
+
+// contractArtifact is a contract compiled by Hardhat, as stored in
+// the artifacts directory of the contracts project.
+type contractArtifact struct {
+	ContractName   string                                `json:"contractName"`
+	ABI            json.RawMessage                       `json:"abi"`
+	Bytecode       string                                `json:"bytecode"`
+	LinkReferences map[string]map[string][]linkReference `json:"linkReferences"`
+}
+
+// linkReference is the position of the placeholder of a library address in
+// the contract bytecode.
+type linkReference struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// loadContractArtifact finds the artifact of the contract with the given
+// name in the given Hardhat artifacts directory.
+func loadContractArtifact(
+	artifactsDir string,
+	contractName string,
+) (*contractArtifact, error) {
+	var artifactPath string
+	err := filepath.WalkDir(
+		artifactsDir,
+		func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() && entry.Name() == "build-info" {
+				return filepath.SkipDir
+			}
+			if !entry.IsDir() && entry.Name() == contractName+".json" {
+				if artifactPath != "" {
+					return fmt.Errorf(
+						"ambiguous artifacts of contract [%v]: [%v] and [%v]",
+						contractName,
+						artifactPath,
+						path,
+					)
+				}
+				artifactPath = path
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if artifactPath == "" {
+		return nil, fmt.Errorf("no artifact of contract [%v]", contractName)
+	}
+
+	artifactJSON, err := os.ReadFile(artifactPath)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact := &contractArtifact{}
+	if err := json.Unmarshal(artifactJSON, artifact); err != nil {
+		return nil, fmt.Errorf(
+			"cannot unmarshal artifact [%v]: [%v]",
+			artifactPath,
+			err,
+		)
+	}
+
+	return artifact, nil
+}
+
+// linkedBytecode returns the creation bytecode of the contract with
+// placeholders of libraries replaced with the given library addresses.
+func (ca *contractArtifact) linkedBytecode(
+	libraries map[string]common.Address,
+) ([]byte, error) {
+	bytecode := []byte(strings.TrimPrefix(ca.Bytecode, "0x"))
+
+	for _, sourceLibraries := range ca.LinkReferences {
+		for libraryName, references := range sourceLibraries {
+			address, ok := libraries[libraryName]
+			if !ok {
+				return nil, fmt.Errorf(
+					"contract [%v] requires library [%v]",
+					ca.ContractName,
+					libraryName,
+				)
+			}
+
+			addressHex := hex.EncodeToString(address.Bytes())
+			for _, reference := range references {
+				if reference.Length != common.AddressLength ||
+					2*(reference.Start+reference.Length) > len(bytecode) {
+					return nil, fmt.Errorf(
+						"invalid reference of library [%v] in contract [%v]",
+						libraryName,
+						ca.ContractName,
+					)
+				}
+
+				copy(bytecode[2*reference.Start:], addressHex)
+			}
+		}
+	}
+
+	return hex.DecodeString(string(bytecode))
+}
+
+// deployContract deploys the contract from the given artifact on
+// the simulated chain. The contract is deployed by the account of the given
+// key, with the given libraries linked and the given constructor arguments.
+func (sc *simulatedChain) deployContract(
+	deployer *keystore.Key,
+	artifact *contractArtifact,
+	libraries map[string]common.Address,
+	args ...interface{},
+) (common.Address, *bind.BoundContract) {
+	contractABI, err := hostchainabi.JSON(bytes.NewReader(artifact.ABI))
+	if err != nil {
+		sc.t.Fatal(err)
+	}
+
+	bytecode, err := artifact.linkedBytecode(libraries)
+	if err != nil {
+		sc.t.Fatal(err)
+	}
+
+	transactorOptions, err := bind.NewKeyedTransactorWithChainID(
+		deployer.PrivateKey,
+		simulatedChainID,
+	)
+	if err != nil {
+		sc.t.Fatal(err)
+	}
+
+	address, _, boundContract, err := bind.DeployContract(
+		transactorOptions,
+		contractABI,
+		bytecode,
+		sc,
+		args...,
+	)
+	if err != nil {
+		sc.t.Fatalf(
+			"cannot deploy contract [%v]: [%v]",
+			artifact.ContractName,
+			err,
+		)
+	}
+
+	return address, boundContract
+}
+
+// transact sends the transaction calling the given contract method from
+// the account of the given key.
+func (sc *simulatedChain) transact(
+	sender *keystore.Key,
+	contract *bind.BoundContract,
+	method string,
+	args ...interface{},
+) {
+	transactorOptions, err := bind.NewKeyedTransactorWithChainID(
+		sender.PrivateKey,
+		simulatedChainID,
+	)
+	if err != nil {
+		sc.t.Fatal(err)
+	}
+
+	transaction, err := contract.Transact(transactorOptions, method, args...)
+	if err != nil {
+		sc.t.Fatalf("cannot call [%v]: [%v]", method, err)
+	}
+
+	receipt, err := sc.TransactionReceipt(
+		context.Background(),
+		transaction.Hash(),
+	)
+	if err != nil {
+		sc.t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		sc.t.Fatalf("transaction calling [%v] failed", method)
+	}
+}
+
+// newTestKey generates a new random chain key.
+func newTestKey(t *testing.T) *keystore.Key {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+}
+
+// signWithKey signs the hash the same way operators sign DKG results, i.e.
+// as an Ethereum-prefixed message.
+func signWithKey(t *testing.T, privateKey *ecdsa.PrivateKey, hash []byte) []byte {
+	signature, err := ethutil.NewSigner(privateKey).Sign(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signature
+}
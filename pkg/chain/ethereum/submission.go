@@ -0,0 +1,747 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+const (
+	// DefaultGasBumpInterval is the default number of blocks after which
+	// a time-critical transaction that has not been mined is replaced with
+	// a transaction offering a higher gas price.
+	DefaultGasBumpInterval = 3
+	// DefaultGasBumpPercent is the default percentage by which the gas price
+	// of a time-critical transaction is increased with each replacement.
+	DefaultGasBumpPercent = 20
+
+	// minGasBumpPercent is the minimum increase of the gas price, in percent,
+	// required for a replacement transaction to be accepted by the Ethereum
+	// nodes.
+	minGasBumpPercent = 10
+	// cancellationGasLimit is the gas limit of the transaction cancelling
+	// a pending submission. The cancellation is a plain ether transfer.
+	cancellationGasLimit = 21000
+	// maxSubmissionRecords is the maximum number of the most recent
+	// submissions kept for diagnostics.
+	maxSubmissionRecords = 50
+)
+
+// SubmissionConfig holds the configuration of time-critical transaction
+// submissions, i.e. relay entries and DKG results that must be mined before
+// a deadline.
+type SubmissionConfig struct {
+	// GasBumpInterval is the number of blocks after which a transaction that
+	// has not been mined is replaced with a transaction offering a higher gas
+	// price. The transaction is also replaced in each of the last
+	// GasBumpInterval blocks before the deadline.
+	GasBumpInterval uint64
+	// GasBumpPercent is the percentage by which the gas price is increased
+	// with each replacement. It must be at least 10 percent for
+	// the replacement to be accepted by the Ethereum nodes.
+	GasBumpPercent uint64
+}
+
+// validate checks whether the submission configuration is valid.
+func (sc SubmissionConfig) validate() error {
+	if sc.GasBumpInterval == 0 {
+		return fmt.Errorf("gas bump interval must be greater than zero")
+	}
+
+	if sc.GasBumpPercent < minGasBumpPercent {
+		return fmt.Errorf(
+			"gas bump percent [%v] must be at least [%v]",
+			sc.GasBumpPercent,
+			minGasBumpPercent,
+		)
+	}
+
+	return nil
+}
+
+// SubmissionStatus is the status of a time-critical transaction submission.
+type SubmissionStatus string
+
+const (
+	// SubmissionPending means none of the submission transactions has been
+	// mined yet.
+	SubmissionPending SubmissionStatus = "pending"
+	// SubmissionMined means one of the submission transactions has been
+	// mined successfully.
+	SubmissionMined SubmissionStatus = "mined"
+	// SubmissionReverted means one of the submission transactions has been
+	// mined but reverted.
+	SubmissionReverted SubmissionStatus = "reverted"
+	// SubmissionCancelled means the submission has been cancelled because
+	// it has been superseded by another party, e.g. another group member
+	// submitted the same relay entry first.
+	SubmissionCancelled SubmissionStatus = "cancelled"
+	// SubmissionExpired means the submission has been cancelled because its
+	// deadline passed before any of its transactions has been mined.
+	SubmissionExpired SubmissionStatus = "expired"
+)
+
+// SubmissionAttempt describes a single transaction sent as a part of
+// a time-critical submission.
+type SubmissionAttempt struct {
+	TransactionHash string `json:"transactionHash"`
+	Block           uint64 `json:"block"`
+	GasPrice        string `json:"gasPrice,omitempty"`
+	GasTipCap       string `json:"gasTipCap,omitempty"`
+	GasFeeCap       string `json:"gasFeeCap,omitempty"`
+	Cancellation    bool   `json:"cancellation"`
+}
+
+// SubmissionRecord describes a time-critical submission along with all
+// the transactions sent for it.
+type SubmissionRecord struct {
+	Name          string              `json:"name"`
+	Nonce         uint64              `json:"nonce"`
+	DeadlineBlock uint64              `json:"deadlineBlock"`
+	Status        SubmissionStatus    `json:"status"`
+	Attempts      []SubmissionAttempt `json:"attempts"`
+}
+
+// submitTransactionFn sends the transaction of the submission with the given
+// transactor options. It is called for the first transaction and for each
+// replacement, with the nonce and the gas price set by the caller.
+type submitTransactionFn func(
+	transactorOptions *bind.TransactOpts,
+) (*types.Transaction, error)
+
+// subscribeSupersededFn subscribes for the on-chain event indicating that
+// the submission is no longer needed, e.g. a relay entry for the same
+// request has been submitted. The given handler must be called for each such
+// event.
+type subscribeSupersededFn func(
+	handler func(),
+) subscription.EventSubscription
+
+// submissionManager submits time-critical transactions and makes sure they
+// are mined before their deadlines. Unlike ethutil.MiningWaiter, checking
+// the mining status in a fixed time interval, the manager follows the chain
+// and replaces a pending transaction with a transaction offering a higher
+// gas price every configured number of blocks and in each of the last blocks
+// before the deadline. Once the deadline passes or the submission is
+// superseded, the pending transaction is cancelled so that it does not revert
+// on-chain at the operator's expense.
+type submissionManager struct {
+	client            ethutil.EthereumClient
+	chainID           *big.Int
+	blockCounter      *ethereum.BlockCounter
+	nonceManager      *ethereum.NonceManager
+	transactionMutex  *sync.Mutex
+	transactorOptions *bind.TransactOpts
+	maxGasFeeCap      *big.Int
+
+	configMutex sync.RWMutex
+	config      SubmissionConfig
+
+	recordsMutex sync.RWMutex
+	records      []*SubmissionRecord
+}
+
+// newSubmissionManager creates a submission manager sending transactions
+// signed with the transactor options of the given chain handle so that
+// submissions, replacements and cancellations are signed with the operator's
+// key, whether held locally or by the remote signer.
+func newSubmissionManager(baseChain *baseChain) *submissionManager {
+	maxGasFeeCap := baseChain.maxGasFeeCap
+	if maxGasFeeCap == nil {
+		maxGasFeeCap = ethutil.DefaultMaxGasFeeCap.Int
+	}
+
+	return &submissionManager{
+		client:            baseChain.client,
+		chainID:           baseChain.chainID,
+		blockCounter:      baseChain.blockCounter,
+		nonceManager:      baseChain.nonceManager,
+		transactionMutex:  baseChain.transactionMutex,
+		transactorOptions: baseChain.transactorOptions,
+		maxGasFeeCap:      maxGasFeeCap,
+		config: SubmissionConfig{
+			GasBumpInterval: DefaultGasBumpInterval,
+			GasBumpPercent:  DefaultGasBumpPercent,
+		},
+	}
+}
+
+// configure sets the configuration used by subsequent submissions.
+func (sm *submissionManager) configure(config SubmissionConfig) error {
+	if err := config.validate(); err != nil {
+		return err
+	}
+
+	sm.configMutex.Lock()
+	defer sm.configMutex.Unlock()
+
+	sm.config = config
+
+	return nil
+}
+
+func (sm *submissionManager) currentConfig() SubmissionConfig {
+	sm.configMutex.RLock()
+	defer sm.configMutex.RUnlock()
+
+	return sm.config
+}
+
+// submit sends the first transaction of the submission and returns once it
+// is sent. The submission is monitored in the background until one of its
+// transactions is mined, the deadline block passes, or the submission is
+// superseded.
+func (sm *submissionManager) submit(
+	name string,
+	deadlineBlock uint64,
+	submitFn submitTransactionFn,
+	subscribeSuperseded subscribeSupersededFn,
+) error {
+	supersededChan := make(chan struct{}, 1)
+	supersededSubscription := subscribeSuperseded(func() {
+		select {
+		case supersededChan <- struct{}{}:
+		default:
+		}
+	})
+
+	transaction, err := sm.submitFirst(submitFn)
+	if err != nil {
+		supersededSubscription.Unsubscribe()
+		return err
+	}
+
+	currentBlock, err := sm.blockCounter.CurrentBlock()
+	if err != nil {
+		supersededSubscription.Unsubscribe()
+		return fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	record := &SubmissionRecord{
+		Name:          name,
+		Nonce:         transaction.Nonce(),
+		DeadlineBlock: deadlineBlock,
+		Status:        SubmissionPending,
+	}
+	sm.addRecord(record)
+	sm.recordAttempt(record, transaction, currentBlock, false)
+
+	logger.Infof(
+		"submitted [%s] transaction [%s] with nonce [%v]; "+
+			"deadline block [%v]",
+		name,
+		transaction.Hash().Hex(),
+		transaction.Nonce(),
+		deadlineBlock,
+	)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	blocksChan := sm.blockCounter.WatchBlocks(ctx)
+
+	go func() {
+		defer cancelCtx()
+		defer supersededSubscription.Unsubscribe()
+
+		sm.monitor(
+			record,
+			transaction,
+			currentBlock,
+			submitFn,
+			blocksChan,
+			supersededChan,
+		)
+	}()
+
+	return nil
+}
+
+// submitFirst sends the first transaction of the submission using the next
+// nonce of the operator account.
+func (sm *submissionManager) submitFirst(
+	submitFn submitTransactionFn,
+) (*types.Transaction, error) {
+	sm.transactionMutex.Lock()
+	defer sm.transactionMutex.Unlock()
+
+	nonce, err := sm.nonceManager.CurrentNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve account nonce: [%v]", err)
+	}
+
+	transactorOptions := sm.newTransactorOptions()
+	transactorOptions.Nonce = new(big.Int).SetUint64(nonce)
+
+	transaction, err := submitFn(transactorOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	sm.nonceManager.IncrementNonce()
+
+	return transaction, nil
+}
+
+// monitor follows the chain until one of the submission transactions is
+// mined, the deadline block passes, or the submission is superseded. Pending
+// transactions are replaced according to the bump schedule.
+func (sm *submissionManager) monitor(
+	record *SubmissionRecord,
+	transaction *types.Transaction,
+	submissionBlock uint64,
+	submitFn submitTransactionFn,
+	blocksChan <-chan uint64,
+	supersededChan <-chan struct{},
+) {
+	// All transactions sent for the submission share the nonce so only one
+	// of them can be mined.
+	transactions := []*types.Transaction{transaction}
+
+	for {
+		select {
+		case block, ok := <-blocksChan:
+			if !ok {
+				return
+			}
+
+			if sm.checkMined(record, transactions) {
+				return
+			}
+
+			if block >= record.DeadlineBlock {
+				logger.Warningf(
+					"[%s] transaction with nonce [%v] not mined "+
+						"before deadline block [%v]; cancelling",
+					record.Name,
+					record.Nonce,
+					record.DeadlineBlock,
+				)
+				sm.cancel(record, transaction, block, SubmissionExpired)
+				return
+			}
+
+			if !sm.currentConfig().shouldBump(
+				block,
+				submissionBlock,
+				record.DeadlineBlock,
+			) {
+				continue
+			}
+
+			replacement, err := sm.replace(transaction, submitFn)
+			if err != nil {
+				logger.Warningf(
+					"could not replace [%s] transaction [%s]: [%v]",
+					record.Name,
+					transaction.Hash().Hex(),
+					err,
+				)
+				continue
+			}
+
+			logger.Infof(
+				"replaced [%s] transaction [%s] with [%s] at block [%v]",
+				record.Name,
+				transaction.Hash().Hex(),
+				replacement.Hash().Hex(),
+				block,
+			)
+
+			transaction = replacement
+			transactions = append(transactions, replacement)
+			submissionBlock = block
+			sm.recordAttempt(record, replacement, block, false)
+		case <-supersededChan:
+			if sm.checkMined(record, transactions) {
+				return
+			}
+
+			currentBlock, err := sm.blockCounter.CurrentBlock()
+			if err != nil {
+				logger.Errorf("cannot get current block: [%v]", err)
+			}
+
+			logger.Infof(
+				"[%s] transaction with nonce [%v] superseded; cancelling",
+				record.Name,
+				record.Nonce,
+			)
+			sm.cancel(record, transaction, currentBlock, SubmissionCancelled)
+			return
+		}
+	}
+}
+
+// shouldBump determines whether the pending transaction submitted at
+// the submission block should be replaced at the given block. The transaction
+// is replaced every gas bump interval and in each of the last gas bump
+// interval blocks before the deadline.
+func (sc SubmissionConfig) shouldBump(
+	block uint64,
+	submissionBlock uint64,
+	deadlineBlock uint64,
+) bool {
+	if block <= submissionBlock {
+		return false
+	}
+
+	if block-submissionBlock >= sc.GasBumpInterval {
+		return true
+	}
+
+	return block >= deadlineBlock ||
+		deadlineBlock-block <= sc.GasBumpInterval
+}
+
+// checkMined checks whether any of the submission transactions has been
+// mined and updates the submission status accordingly.
+func (sm *submissionManager) checkMined(
+	record *SubmissionRecord,
+	transactions []*types.Transaction,
+) bool {
+	for _, transaction := range transactions {
+		receipt, _ := sm.client.TransactionReceipt(
+			context.Background(),
+			transaction.Hash(),
+		)
+		if receipt == nil {
+			continue
+		}
+
+		status := SubmissionMined
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			status = SubmissionReverted
+		}
+
+		logger.Infof(
+			"[%s] transaction [%s] mined with status [%v] at block [%v]",
+			record.Name,
+			transaction.Hash().Hex(),
+			receipt.Status,
+			receipt.BlockNumber,
+		)
+
+		sm.setStatus(record, status)
+		return true
+	}
+
+	return false
+}
+
+// replace sends the replacement of the given transaction, with the same
+// nonce and a higher gas price.
+func (sm *submissionManager) replace(
+	transaction *types.Transaction,
+	submitFn submitTransactionFn,
+) (*types.Transaction, error) {
+	fees, err := sm.bumpedFees(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	transactorOptions := sm.newTransactorOptions()
+	transactorOptions.Nonce = new(big.Int).SetUint64(transaction.Nonce())
+	fees.apply(transactorOptions)
+
+	return submitFn(transactorOptions)
+}
+
+// cancel replaces the given pending transaction with an empty transfer to
+// the operator's own account, with the same nonce and a higher gas price.
+func (sm *submissionManager) cancel(
+	record *SubmissionRecord,
+	transaction *types.Transaction,
+	block uint64,
+	status SubmissionStatus,
+) {
+	sm.setStatus(record, status)
+
+	fees, err := sm.bumpedFees(transaction)
+	if err != nil {
+		logger.Warningf(
+			"could not cancel [%s] transaction [%s]: [%v]",
+			record.Name,
+			transaction.Hash().Hex(),
+			err,
+		)
+		return
+	}
+
+	from := sm.transactorOptions.From
+
+	var cancellation *types.Transaction
+	if fees.gasPrice != nil {
+		cancellation = types.NewTx(&types.LegacyTx{
+			Nonce:    transaction.Nonce(),
+			GasPrice: fees.gasPrice,
+			Gas:      cancellationGasLimit,
+			To:       &from,
+			Value:    big.NewInt(0),
+		})
+	} else {
+		cancellation = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   sm.chainID,
+			Nonce:     transaction.Nonce(),
+			GasTipCap: fees.gasTipCap,
+			GasFeeCap: fees.gasFeeCap,
+			Gas:       cancellationGasLimit,
+			To:        &from,
+			Value:     big.NewInt(0),
+		})
+	}
+
+	signedCancellation, err := sm.transactorOptions.Signer(from, cancellation)
+	if err != nil {
+		logger.Warningf(
+			"could not sign cancellation of [%s] transaction [%s]: [%v]",
+			record.Name,
+			transaction.Hash().Hex(),
+			err,
+		)
+		return
+	}
+
+	if err := sm.client.SendTransaction(
+		context.Background(),
+		signedCancellation,
+	); err != nil {
+		logger.Warningf(
+			"could not cancel [%s] transaction [%s]: [%v]",
+			record.Name,
+			transaction.Hash().Hex(),
+			err,
+		)
+		return
+	}
+
+	logger.Infof(
+		"cancelled [%s] transaction [%s] with [%s]",
+		record.Name,
+		transaction.Hash().Hex(),
+		signedCancellation.Hash().Hex(),
+	)
+
+	sm.recordAttempt(record, signedCancellation, block, true)
+}
+
+// bumpedFees returns the fees of the transaction replacing the given one.
+// The latest base fee is taken into account for dynamic fee transactions.
+func (sm *submissionManager) bumpedFees(
+	transaction *types.Transaction,
+) (*transactionFees, error) {
+	var baseFee *big.Int
+	if transaction.Type() == types.DynamicFeeTxType {
+		header, err := sm.client.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the latest block: [%v]", err)
+		}
+
+		baseFee = header.BaseFee
+	}
+
+	return feesOf(transaction).bump(
+		sm.currentConfig().GasBumpPercent,
+		baseFee,
+		sm.maxGasFeeCap,
+	)
+}
+
+func (sm *submissionManager) newTransactorOptions() *bind.TransactOpts {
+	transactorOptions := new(bind.TransactOpts)
+	*transactorOptions = *sm.transactorOptions
+
+	return transactorOptions
+}
+
+func (sm *submissionManager) addRecord(record *SubmissionRecord) {
+	sm.recordsMutex.Lock()
+	defer sm.recordsMutex.Unlock()
+
+	sm.records = append(sm.records, record)
+	if len(sm.records) > maxSubmissionRecords {
+		sm.records = sm.records[len(sm.records)-maxSubmissionRecords:]
+	}
+}
+
+func (sm *submissionManager) setStatus(
+	record *SubmissionRecord,
+	status SubmissionStatus,
+) {
+	sm.recordsMutex.Lock()
+	defer sm.recordsMutex.Unlock()
+
+	record.Status = status
+}
+
+func (sm *submissionManager) recordAttempt(
+	record *SubmissionRecord,
+	transaction *types.Transaction,
+	block uint64,
+	cancellation bool,
+) {
+	attempt := SubmissionAttempt{
+		TransactionHash: transaction.Hash().Hex(),
+		Block:           block,
+		Cancellation:    cancellation,
+	}
+
+	if transaction.Type() == types.DynamicFeeTxType {
+		attempt.GasTipCap = transaction.GasTipCap().String()
+		attempt.GasFeeCap = transaction.GasFeeCap().String()
+	} else {
+		attempt.GasPrice = transaction.GasPrice().String()
+	}
+
+	sm.recordsMutex.Lock()
+	defer sm.recordsMutex.Unlock()
+
+	record.Attempts = append(record.Attempts, attempt)
+}
+
+// submissions returns copies of the most recent submission records, from
+// the oldest to the newest one.
+func (sm *submissionManager) submissions() []SubmissionRecord {
+	sm.recordsMutex.RLock()
+	defer sm.recordsMutex.RUnlock()
+
+	records := make([]SubmissionRecord, len(sm.records))
+	for i, record := range sm.records {
+		records[i] = *record
+		records[i].Attempts = append(
+			[]SubmissionAttempt{},
+			record.Attempts...,
+		)
+	}
+
+	return records
+}
+
+// transactionFees holds the gas price of a legacy transaction or the gas tip
+// and fee caps of a dynamic fee transaction.
+type transactionFees struct {
+	gasPrice  *big.Int
+	gasTipCap *big.Int
+	gasFeeCap *big.Int
+}
+
+func feesOf(transaction *types.Transaction) *transactionFees {
+	if transaction.Type() == types.DynamicFeeTxType {
+		return &transactionFees{
+			gasTipCap: transaction.GasTipCap(),
+			gasFeeCap: transaction.GasFeeCap(),
+		}
+	}
+
+	return &transactionFees{gasPrice: transaction.GasPrice()}
+}
+
+// bump returns the fees increased by the given percentage. The new values
+// must be at least 10% higher than the current ones for the replacement
+// transaction to be accepted by the Ethereum nodes and can not be higher than
+// the maximum gas fee cap. The gas fee cap of a dynamic fee transaction is
+// additionally kept at the `2 * baseFee + gasTipCap` level, the same way
+// go-ethereum estimates it.
+func (tf *transactionFees) bump(
+	percent uint64,
+	baseFee *big.Int,
+	maxGasFeeCap *big.Int,
+) (*transactionFees, error) {
+	if tf.gasPrice != nil {
+		gasPrice, err := bumpValue(tf.gasPrice, percent, maxGasFeeCap)
+		if err != nil {
+			return nil, fmt.Errorf("cannot bump gas price: [%v]", err)
+		}
+
+		return &transactionFees{gasPrice: gasPrice}, nil
+	}
+
+	gasTipCap, err := bumpValue(tf.gasTipCap, percent, maxGasFeeCap)
+	if err != nil {
+		return nil, fmt.Errorf("cannot bump gas tip cap: [%v]", err)
+	}
+
+	gasFeeCap, err := bumpValue(tf.gasFeeCap, percent, maxGasFeeCap)
+	if err != nil {
+		return nil, fmt.Errorf("cannot bump gas fee cap: [%v]", err)
+	}
+
+	if baseFee != nil {
+		estimatedGasFeeCap := new(big.Int).Add(
+			new(big.Int).Mul(baseFee, big.NewInt(2)),
+			gasTipCap,
+		)
+		if estimatedGasFeeCap.Cmp(gasFeeCap) > 0 {
+			gasFeeCap = estimatedGasFeeCap
+		}
+		if gasFeeCap.Cmp(maxGasFeeCap) > 0 {
+			gasFeeCap = maxGasFeeCap
+		}
+	}
+
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		return nil, fmt.Errorf(
+			"gas tip cap [%v] exceeds gas fee cap [%v]",
+			gasTipCap,
+			gasFeeCap,
+		)
+	}
+
+	return &transactionFees{
+		gasTipCap: gasTipCap,
+		gasFeeCap: gasFeeCap,
+	}, nil
+}
+
+// apply sets the fees in the given transactor options.
+func (tf *transactionFees) apply(transactorOptions *bind.TransactOpts) {
+	transactorOptions.GasPrice = tf.gasPrice
+	transactorOptions.GasTipCap = tf.gasTipCap
+	transactorOptions.GasFeeCap = tf.gasFeeCap
+}
+
+// bumpValue increases the value by the given percentage, but not less than
+// by the minimum required for a replacement transaction. The result is
+// capped at the given maximum. An error is returned if the capped result is
+// not enough for a replacement transaction.
+func bumpValue(
+	value *big.Int,
+	percent uint64,
+	maxValue *big.Int,
+) (*big.Int, error) {
+	increase := func(percent uint64) *big.Int {
+		result := new(big.Int).Mul(
+			value,
+			new(big.Int).SetUint64(100+percent),
+		)
+		// Round up so that small values are increased as well.
+		return result.Add(result, big.NewInt(99)).Div(result, big.NewInt(100))
+	}
+
+	required := increase(minGasBumpPercent)
+
+	bumped := increase(percent)
+	if bumped.Cmp(required) < 0 {
+		bumped = required
+	}
+
+	if bumped.Cmp(maxValue) > 0 {
+		bumped = new(big.Int).Set(maxValue)
+	}
+
+	if bumped.Cmp(required) < 0 {
+		return nil, fmt.Errorf(
+			"required value [%v] exceeds the maximum [%v]",
+			required,
+			maxValue,
+		)
+	}
+
+	return bumped, nil
+}
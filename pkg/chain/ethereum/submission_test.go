@@ -0,0 +1,565 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/keep-network/keep-core/pkg/subscription"
+)
+
+func TestSubmissionConfig_Validate(t *testing.T) {
+	var tests = map[string]struct {
+		config        SubmissionConfig
+		expectedError bool
+	}{
+		"default config": {
+			config: SubmissionConfig{
+				GasBumpInterval: DefaultGasBumpInterval,
+				GasBumpPercent:  DefaultGasBumpPercent,
+			},
+		},
+		"minimum gas bump percent": {
+			config: SubmissionConfig{GasBumpInterval: 1, GasBumpPercent: 10},
+		},
+		"zero gas bump interval": {
+			config:        SubmissionConfig{GasBumpInterval: 0, GasBumpPercent: 20},
+			expectedError: true,
+		},
+		"gas bump percent too low": {
+			config:        SubmissionConfig{GasBumpInterval: 3, GasBumpPercent: 9},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := test.config.validate()
+			if test.expectedError != (err != nil) {
+				t.Errorf(
+					"unexpected validation result\nexpected error: %v\nactual:   %v\n",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestSubmissionConfig_ShouldBump(t *testing.T) {
+	config := SubmissionConfig{GasBumpInterval: 3, GasBumpPercent: 20}
+
+	var tests = map[string]struct {
+		block           uint64
+		submissionBlock uint64
+		deadlineBlock   uint64
+		expectedBump    bool
+	}{
+		"submission block": {
+			block:           100,
+			submissionBlock: 100,
+			deadlineBlock:   101,
+			expectedBump:    false,
+		},
+		"before bump interval": {
+			block:           102,
+			submissionBlock: 100,
+			deadlineBlock:   150,
+			expectedBump:    false,
+		},
+		"bump interval passed": {
+			block:           103,
+			submissionBlock: 100,
+			deadlineBlock:   150,
+			expectedBump:    true,
+		},
+		"close to deadline": {
+			block:           147,
+			submissionBlock: 146,
+			deadlineBlock:   150,
+			expectedBump:    true,
+		},
+		"just before close to deadline": {
+			block:           146,
+			submissionBlock: 145,
+			deadlineBlock:   150,
+			expectedBump:    false,
+		},
+		"deadline passed": {
+			block:           151,
+			submissionBlock: 150,
+			deadlineBlock:   150,
+			expectedBump:    true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			bump := config.shouldBump(
+				test.block,
+				test.submissionBlock,
+				test.deadlineBlock,
+			)
+			if test.expectedBump != bump {
+				t.Errorf(
+					"unexpected bump decision\nexpected: %v\nactual:   %v\n",
+					test.expectedBump,
+					bump,
+				)
+			}
+		})
+	}
+}
+
+func TestTransactionFees_Bump(t *testing.T) {
+	var tests = map[string]struct {
+		fees          *transactionFees
+		percent       uint64
+		baseFee       *big.Int
+		maxGasFeeCap  *big.Int
+		expectedFees  *transactionFees
+		expectedError bool
+	}{
+		"legacy transaction": {
+			fees:         &transactionFees{gasPrice: big.NewInt(1000)},
+			percent:      20,
+			maxGasFeeCap: big.NewInt(10000),
+			expectedFees: &transactionFees{gasPrice: big.NewInt(1200)},
+		},
+		"legacy transaction with small gas price": {
+			fees:         &transactionFees{gasPrice: big.NewInt(1)},
+			percent:      20,
+			maxGasFeeCap: big.NewInt(10000),
+			expectedFees: &transactionFees{gasPrice: big.NewInt(2)},
+		},
+		"legacy transaction capped at max gas fee cap": {
+			fees:         &transactionFees{gasPrice: big.NewInt(1000)},
+			percent:      50,
+			maxGasFeeCap: big.NewInt(1200),
+			expectedFees: &transactionFees{gasPrice: big.NewInt(1200)},
+		},
+		"legacy transaction exceeding max gas fee cap": {
+			fees:          &transactionFees{gasPrice: big.NewInt(1000)},
+			percent:       20,
+			maxGasFeeCap:  big.NewInt(1050),
+			expectedError: true,
+		},
+		"dynamic fee transaction": {
+			fees: &transactionFees{
+				gasTipCap: big.NewInt(100),
+				gasFeeCap: big.NewInt(1000),
+			},
+			percent:      20,
+			baseFee:      big.NewInt(400),
+			maxGasFeeCap: big.NewInt(10000),
+			expectedFees: &transactionFees{
+				gasTipCap: big.NewInt(120),
+				gasFeeCap: big.NewInt(1200),
+			},
+		},
+		"dynamic fee transaction with increased base fee": {
+			fees: &transactionFees{
+				gasTipCap: big.NewInt(100),
+				gasFeeCap: big.NewInt(1000),
+			},
+			percent:      20,
+			baseFee:      big.NewInt(800),
+			maxGasFeeCap: big.NewInt(10000),
+			expectedFees: &transactionFees{
+				gasTipCap: big.NewInt(120),
+				gasFeeCap: big.NewInt(1720),
+			},
+		},
+		"dynamic fee transaction capped at max gas fee cap": {
+			fees: &transactionFees{
+				gasTipCap: big.NewInt(100),
+				gasFeeCap: big.NewInt(1000),
+			},
+			percent:      20,
+			baseFee:      big.NewInt(800),
+			maxGasFeeCap: big.NewInt(1500),
+			expectedFees: &transactionFees{
+				gasTipCap: big.NewInt(120),
+				gasFeeCap: big.NewInt(1500),
+			},
+		},
+		"dynamic fee transaction exceeding max gas fee cap": {
+			fees: &transactionFees{
+				gasTipCap: big.NewInt(100),
+				gasFeeCap: big.NewInt(1000),
+			},
+			percent:       20,
+			baseFee:       big.NewInt(400),
+			maxGasFeeCap:  big.NewInt(1050),
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			fees, err := test.fees.bump(
+				test.percent,
+				test.baseFee,
+				test.maxGasFeeCap,
+			)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected bump to fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedFees, fees) {
+				t.Errorf(
+					"unexpected fees\nexpected: %+v\nactual:   %+v\n",
+					test.expectedFees,
+					fees,
+				)
+			}
+		})
+	}
+}
+
+func TestSubmissionManager_ReplacesPendingTransaction(t *testing.T) {
+	environment := newSubmissionTestEnvironment(
+		t,
+		&localAccount{newTestKey(t)},
+		func(sent int) bool {
+			// Drop the first transaction and its first replacement.
+			return sent < 2
+		},
+	)
+
+	if err := environment.submit(
+		environment.chain.currentBlock() + 100,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	record := environment.waitForSubmission(func(record SubmissionRecord) bool {
+		return record.Status != SubmissionPending
+	})
+
+	if record.Status != SubmissionMined {
+		t.Errorf(
+			"unexpected status\nexpected: %v\nactual:   %v\n",
+			SubmissionMined,
+			record.Status,
+		)
+	}
+
+	if len(record.Attempts) != 3 {
+		t.Fatalf(
+			"unexpected number of attempts\nexpected: %v\nactual:   %v\n",
+			3,
+			len(record.Attempts),
+		)
+	}
+
+	for i := 1; i < len(record.Attempts); i++ {
+		previous, _ := new(big.Int).SetString(record.Attempts[i-1].GasTipCap, 10)
+		current, _ := new(big.Int).SetString(record.Attempts[i].GasTipCap, 10)
+		if current.Cmp(previous) <= 0 {
+			t.Errorf(
+				"attempt [%v] gas tip cap [%v] not higher than previous [%v]",
+				i,
+				current,
+				previous,
+			)
+		}
+		if record.Attempts[i].Cancellation {
+			t.Errorf("attempt [%v] should not be a cancellation", i)
+		}
+	}
+}
+
+func TestSubmissionManager_CancelsSupersededSubmission(t *testing.T) {
+	environment := newSubmissionTestEnvironment(
+		t,
+		&localAccount{newTestKey(t)},
+		func(sent int) bool {
+			// Drop the submission transaction so it stays pending.
+			return sent == 0
+		},
+	)
+
+	if err := environment.submit(
+		environment.chain.currentBlock() + 100,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	environment.supersede()
+
+	record := environment.waitForSubmission(func(record SubmissionRecord) bool {
+		return len(record.Attempts) == 2
+	})
+
+	assertCancelled(t, record, SubmissionCancelled)
+}
+
+func TestSubmissionManager_CancelsExpiredSubmission(t *testing.T) {
+	var environment *submissionTestEnvironment
+	environment = newSubmissionTestEnvironment(
+		t,
+		&localAccount{newTestKey(t)},
+		func(sent int) bool {
+			// Drop all transactions except the cancellation.
+			return !environment.lastSentCancellation()
+		},
+	)
+
+	if err := environment.submit(
+		environment.chain.currentBlock() + 5,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	record := environment.waitForSubmission(func(record SubmissionRecord) bool {
+		return record.Status != SubmissionPending &&
+			record.Attempts[len(record.Attempts)-1].Cancellation
+	})
+
+	assertCancelled(t, record, SubmissionExpired)
+}
+
+func TestSubmissionManager_RemoteSignerAccount(t *testing.T) {
+	account, operatorAddress := newTestRemoteAccount(t)
+
+	environment := newSubmissionTestEnvironment(
+		t,
+		account,
+		func(sent int) bool {
+			// Drop the submission transaction so it stays pending.
+			return sent == 0
+		},
+	)
+
+	if err := environment.submit(
+		environment.chain.currentBlock() + 100,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	environment.supersede()
+
+	record := environment.waitForSubmission(func(record SubmissionRecord) bool {
+		return record.Status != SubmissionPending
+	})
+
+	assertCancelled(t, record, SubmissionCancelled)
+
+	// The cancellation is signed by the remote signer and the recorded hash
+	// is the hash of the transaction mined on-chain.
+	cancellationHash := common.HexToHash(
+		record.Attempts[len(record.Attempts)-1].TransactionHash,
+	)
+
+	cancellation, _, err := environment.chain.TransactionByHash(
+		context.Background(),
+		cancellationHash,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender, err := types.Sender(
+		types.LatestSignerForChainID(simulatedChainID),
+		cancellation,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender != operatorAddress {
+		t.Errorf(
+			"unexpected sender\nexpected: %v\nactual:   %v\n",
+			operatorAddress,
+			sender,
+		)
+	}
+
+	if _, err := environment.chain.TransactionReceipt(
+		context.Background(),
+		cancellationHash,
+	); err != nil {
+		t.Fatalf("cancellation has not been mined: [%v]", err)
+	}
+}
+
+func assertCancelled(
+	t *testing.T,
+	record SubmissionRecord,
+	expectedStatus SubmissionStatus,
+) {
+	if record.Status != expectedStatus {
+		t.Errorf(
+			"unexpected status\nexpected: %v\nactual:   %v\n",
+			expectedStatus,
+			record.Status,
+		)
+	}
+
+	cancellation := record.Attempts[len(record.Attempts)-1]
+	if !cancellation.Cancellation {
+		t.Fatal("the last attempt should be a cancellation")
+	}
+}
+
+// submissionTestEnvironment runs the submission manager against the simulated
+// chain, with the client dropping selected transactions so that they stay
+// pending.
+type submissionTestEnvironment struct {
+	t       *testing.T
+	chain   *simulatedChain
+	client  *droppingClient
+	manager *submissionManager
+
+	recipient common.Address
+
+	supersededMutex   sync.Mutex
+	supersededHandler func()
+}
+
+func newSubmissionTestEnvironment(
+	t *testing.T,
+	account operatorAccount,
+	drop func(sent int) bool,
+) *submissionTestEnvironment {
+	simulatedChain := newSimulatedChain(
+		t,
+		map[common.Address]fakeContract{},
+		account.bindingKey().Address,
+	)
+
+	baseChain := simulatedChain.connect(account)
+	client := &droppingClient{
+		simulatedChain: simulatedChain,
+		drop:           drop,
+	}
+	baseChain.client = client
+
+	manager := newSubmissionManager(baseChain)
+	if err := manager.configure(SubmissionConfig{
+		GasBumpInterval: 2,
+		GasBumpPercent:  20,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	return &submissionTestEnvironment{
+		t:         t,
+		chain:     simulatedChain,
+		client:    client,
+		manager:   manager,
+		recipient: newTestKey(t).Address,
+	}
+}
+
+// submit submits an ether transfer to the recipient with the given deadline.
+func (ste *submissionTestEnvironment) submit(deadlineBlock uint64) error {
+	transfer := bind.NewBoundContract(
+		ste.recipient,
+		abi.ABI{},
+		nil,
+		ste.client,
+		nil,
+	)
+
+	return ste.manager.submit(
+		"transfer",
+		deadlineBlock,
+		func(transactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			transactorOptions.GasLimit = cancellationGasLimit
+			transactorOptions.Value = big.NewInt(1)
+			return transfer.Transfer(transactorOptions)
+		},
+		func(handler func()) subscription.EventSubscription {
+			ste.supersededMutex.Lock()
+			ste.supersededHandler = handler
+			ste.supersededMutex.Unlock()
+
+			return subscription.NewEventSubscription(func() {})
+		},
+	)
+}
+
+func (ste *submissionTestEnvironment) supersede() {
+	ste.supersededMutex.Lock()
+	defer ste.supersededMutex.Unlock()
+
+	ste.supersededHandler()
+}
+
+func (ste *submissionTestEnvironment) lastSentCancellation() bool {
+	ste.client.mutex.Lock()
+	defer ste.client.mutex.Unlock()
+
+	return ste.client.lastTransaction != nil &&
+		ste.client.lastTransaction.Value().Sign() == 0
+}
+
+// waitForSubmission mines blocks until the only submission recorded by
+// the manager fulfills the given condition.
+func (ste *submissionTestEnvironment) waitForSubmission(
+	condition func(record SubmissionRecord) bool,
+) SubmissionRecord {
+	timeout := time.After(10 * time.Second)
+	for {
+		submissions := ste.manager.submissions()
+		if len(submissions) != 1 {
+			ste.t.Fatalf("unexpected number of submissions: [%v]", len(submissions))
+		}
+
+		if condition(submissions[0]) {
+			return submissions[0]
+		}
+
+		select {
+		case <-timeout:
+			ste.t.Fatalf("unexpected submission state: [%+v]", submissions[0])
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		ste.chain.mine(1)
+	}
+}
+
+// droppingClient is the simulated chain client dropping selected
+// transactions instead of sending them.
+type droppingClient struct {
+	*simulatedChain
+
+	drop func(sent int) bool
+
+	mutex           sync.Mutex
+	sent            int
+	lastTransaction *types.Transaction
+}
+
+func (dc *droppingClient) SendTransaction(
+	ctx context.Context,
+	transaction *types.Transaction,
+) error {
+	dc.mutex.Lock()
+	sent := dc.sent
+	dc.sent++
+	dc.lastTransaction = transaction
+	dc.mutex.Unlock()
+
+	if dc.drop(sent) {
+		return nil
+	}
+
+	return dc.simulatedChain.SendTransaction(ctx, transaction)
+}
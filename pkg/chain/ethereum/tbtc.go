@@ -8,10 +8,13 @@ import (
 	"math/rand"
 	"sync"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/chain"
+	ecdsaabi "github.com/keep-network/keep-core/pkg/chain/ethereum/ecdsa/gen/abi"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum/ecdsa/gen/contract"
 	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
@@ -34,6 +37,12 @@ type TbtcChain struct {
 
 	mockWalletRegistry *mockWalletRegistry
 	sortitionPool      *contract.EcdsaSortitionPool
+
+	// walletRegistryTransactor and sortitionPoolTransactor submit
+	// transactions signed with the operator's transactor options using
+	// baseChain.transact.
+	walletRegistryTransactor *ecdsaabi.WalletRegistryTransactor
+	sortitionPoolTransactor  *ecdsaabi.EcdsaSortitionPoolTransactor
 }
 
 // NewTbtcChain construct a new instance of the TBTC-specific Ethereum
@@ -95,11 +104,35 @@ func newTbtcChain(
 		)
 	}
 
+	walletRegistryTransactor, err := ecdsaabi.NewWalletRegistryTransactor(
+		walletRegistryAddress,
+		baseChain.client,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to instantiate WalletRegistry transactor: [%v]",
+			err,
+		)
+	}
+
+	sortitionPoolTransactor, err := ecdsaabi.NewEcdsaSortitionPoolTransactor(
+		sortitionPoolAddress,
+		baseChain.client,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to instantiate EcdsaSortitionPool transactor: [%v]",
+			err,
+		)
+	}
+
 	return &TbtcChain{
-		baseChain:          baseChain,
-		walletRegistry:     walletRegistry,
-		mockWalletRegistry: newMockWalletRegistry(baseChain.blockCounter),
-		sortitionPool:      sortitionPool,
+		baseChain:                baseChain,
+		walletRegistry:           walletRegistry,
+		mockWalletRegistry:       newMockWalletRegistry(baseChain.blockCounter),
+		sortitionPool:            sortitionPool,
+		walletRegistryTransactor: walletRegistryTransactor,
+		sortitionPoolTransactor:  sortitionPoolTransactor,
 	}, nil
 }
 
@@ -247,14 +280,27 @@ func (tc *TbtcChain) IsOperatorUpToDate() (bool, error) {
 // JoinSortitionPool executes a transaction to have the operator join the
 // sortition pool.
 func (tc *TbtcChain) JoinSortitionPool() error {
-	_, err := tc.walletRegistry.JoinSortitionPool()
+	_, err := tc.transact(
+		"joinSortitionPool",
+		func(transactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			return tc.walletRegistryTransactor.JoinSortitionPool(transactorOptions)
+		},
+	)
 	return err
 }
 
 // UpdateOperatorStatus executes a transaction to update the operator's
 // state in the sortition pool.
 func (tc *TbtcChain) UpdateOperatorStatus() error {
-	_, err := tc.walletRegistry.UpdateOperatorStatus(tc.key.Address)
+	_, err := tc.transact(
+		"updateOperatorStatus",
+		func(transactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			return tc.walletRegistryTransactor.UpdateOperatorStatus(
+				transactorOptions,
+				tc.key.Address,
+			)
+		},
+	)
 	return err
 }
 
@@ -272,7 +318,15 @@ func (tc *TbtcChain) CanRestoreRewardEligibility() (bool, error) {
 
 // Restores reward eligibility for the operator.
 func (tc *TbtcChain) RestoreRewardEligibility() error {
-	_, err := tc.sortitionPool.RestoreRewardEligibility(tc.key.Address)
+	_, err := tc.transact(
+		"restoreRewardEligibility",
+		func(transactorOptions *bind.TransactOpts) (*types.Transaction, error) {
+			return tc.sortitionPoolTransactor.RestoreRewardEligibility(
+				transactorOptions,
+				tc.key.Address,
+			)
+		},
+	)
 	return err
 }
 
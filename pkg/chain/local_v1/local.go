@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 
 	"github.com/ipfs/go-log"
 
@@ -35,6 +36,7 @@ type localChain struct {
 	groups []localGroup
 
 	lastSubmittedDKGResult           *beaconchain.DKGResult
+	lastSubmittedDKGResultHash       [32]byte
 	lastSubmittedDKGResultSignatures map[beaconchain.GroupMemberIndex][]byte
 	lastSubmittedRelayEntry          []byte
 
@@ -44,7 +46,12 @@ type localChain struct {
 	groupRegisteredHandlers  map[int]func(groupRegistration *event.GroupRegistration)
 	dkgStartedHandlers       map[int]func(submission *event.DKGStarted)
 	resultSubmissionHandlers map[int]func(submission *event.DKGResultSubmission)
+	resultChallengedHandlers map[int]func(challenge *event.DKGResultChallenged)
+	resultApprovedHandlers   map[int]func(approval *event.DKGResultApproved)
 
+	// simulatedHeight is the number of blocks the chain has been advanced
+	// by on top of the blocks produced by the block counter. Must be
+	// accessed atomically.
 	simulatedHeight uint64
 	blockCounter    chain.BlockCounter
 
@@ -77,6 +84,7 @@ func (c *localChain) SubmitRelayEntry(newEntry []byte) error {
 	}
 
 	entry := &event.RelayEntrySubmitted{
+		Entry:       newEntry,
 		BlockNumber: currentBlock,
 	}
 
@@ -196,6 +204,8 @@ func ConnectWithKey(
 		groupRegisteredHandlers:  make(map[int]func(groupRegistration *event.GroupRegistration)),
 		dkgStartedHandlers:       make(map[int]func(submission *event.DKGStarted)),
 		resultSubmissionHandlers: make(map[int]func(submission *event.DKGResultSubmission)),
+		resultChallengedHandlers: make(map[int]func(challenge *event.DKGResultChallenged)),
+		resultApprovedHandlers:   make(map[int]func(approval *event.DKGResultApproved)),
 		blockCounter:             bc,
 		groups:                   []localGroup{group},
 		operatorPrivateKey:       operatorPrivateKey,
@@ -214,15 +224,7 @@ func (c *localChain) IsStaleGroup(groupPublicKey []byte) (bool, error) {
 	c.handlerMutex.Lock()
 	defer c.handlerMutex.Unlock()
 
-	bc, _ := BlockCounter()
-
-	err := bc.WaitForBlockHeight(c.simulatedHeight)
-	if err != nil {
-		logger.Errorf("could not wait for block height: [%v]", err)
-	}
-
-	currentBlock, err := bc.CurrentBlock()
-
+	currentBlock, err := c.currentBlock()
 	if err != nil {
 		return false, fmt.Errorf("could not determine current block: [%v]", err)
 	}
@@ -236,6 +238,32 @@ func (c *localChain) IsStaleGroup(groupPublicKey []byte) (bool, error) {
 	return true, nil
 }
 
+// AdvanceBlocks moves the chain forward by the given number of blocks without
+// waiting for the block counter to produce them. The advanced blocks are
+// taken into account when registering groups and determining whether they
+// are stale so tests can simulate the expiration of groups. The block counter
+// of the chain is not affected.
+func (c *localChain) AdvanceBlocks(blocks uint64) {
+	atomic.AddUint64(&c.simulatedHeight, blocks)
+}
+
+// currentBlock returns the current block of the block counter moved forward
+// by the number of blocks the chain has been advanced by.
+func (c *localChain) currentBlock() (uint64, error) {
+	simulatedHeight := atomic.LoadUint64(&c.simulatedHeight)
+
+	if c.blockCounter == nil {
+		return simulatedHeight, nil
+	}
+
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	return currentBlock + simulatedHeight, nil
+}
+
 func (c *localChain) IsGroupRegistered(groupPublicKey []byte) (bool, error) {
 	for _, group := range c.groups {
 		if bytes.Compare(group.groupPublicKey, groupPublicKey) == 0 {
@@ -259,12 +287,18 @@ func (c *localChain) SubmitDKGResult(
 		)
 	}
 
-	currentBlock, err := c.blockCounter.CurrentBlock()
+	currentBlock, err := c.currentBlock()
 	if err != nil {
 		return fmt.Errorf("cannot read current block: [%v]", err)
 	}
 
+	resultHash, err := c.CalculateDKGResultHash(resultToPublish)
+	if err != nil {
+		return fmt.Errorf("cannot calculate result hash: [%v]", err)
+	}
+
 	dkgResultPublicationEvent := &event.DKGResultSubmission{
+		ResultHash:     resultHash,
 		MemberIndex:    uint32(participantIndex),
 		GroupPublicKey: resultToPublish.GroupPublicKey[:],
 		Misbehaved:     resultToPublish.Misbehaved,
@@ -277,6 +311,7 @@ func (c *localChain) SubmitDKGResult(
 	}
 	c.groups = append(c.groups, myGroup)
 	c.lastSubmittedDKGResult = resultToPublish
+	c.lastSubmittedDKGResultHash = resultHash
 	c.lastSubmittedDKGResultSignatures = signatures
 
 	groupRegistrationEvent := &event.GroupRegistration{
@@ -335,6 +370,94 @@ func (c *localChain) OnDKGResultSubmitted(
 	})
 }
 
+func (c *localChain) OnDKGResultChallenged(
+	handler func(challenge *event.DKGResultChallenged),
+) subscription.EventSubscription {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	handlerID := generateHandlerID()
+	c.resultChallengedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		c.handlerMutex.Lock()
+		defer c.handlerMutex.Unlock()
+
+		delete(c.resultChallengedHandlers, handlerID)
+	})
+}
+
+func (c *localChain) OnDKGResultApproved(
+	handler func(approval *event.DKGResultApproved),
+) subscription.EventSubscription {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	handlerID := generateHandlerID()
+	c.resultApprovedHandlers[handlerID] = handler
+
+	return subscription.NewEventSubscription(func() {
+		c.handlerMutex.Lock()
+		defer c.handlerMutex.Unlock()
+
+		delete(c.resultApprovedHandlers, handlerID)
+	})
+}
+
+// ChallengeDKGResult challenges the last submitted DKG result. The local
+// chain accepts only results supported by at least the honest threshold of
+// signatures and it treats all of them as valid, so the challenge always
+// fails, the same way the challenge of a valid result reverts on-chain.
+func (c *localChain) ChallengeDKGResult(resultHash [32]byte) error {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	if c.lastSubmittedDKGResult == nil ||
+		c.lastSubmittedDKGResultHash != resultHash {
+		return fmt.Errorf("result with hash [0x%x] is not submitted", resultHash)
+	}
+
+	return fmt.Errorf("result with hash [0x%x] is valid", resultHash)
+}
+
+// ApproveDKGResult approves the last submitted DKG result. The local chain
+// registers the group once the result is submitted so the approval only
+// notifies the handlers.
+func (c *localChain) ApproveDKGResult(resultHash [32]byte) error {
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+
+	if c.lastSubmittedDKGResult == nil ||
+		c.lastSubmittedDKGResultHash != resultHash {
+		return fmt.Errorf("result with hash [0x%x] is not submitted", resultHash)
+	}
+
+	currentBlock, err := c.blockCounter.CurrentBlock()
+	if err != nil {
+		return fmt.Errorf("cannot read current block: [%v]", err)
+	}
+
+	approvalEvent := &event.DKGResultApproved{
+		ResultHash:  resultHash,
+		BlockNumber: currentBlock,
+	}
+
+	for _, handler := range c.resultApprovedHandlers {
+		go func(handler func(*event.DKGResultApproved)) {
+			handler(approvalEvent)
+		}(handler)
+	}
+
+	return nil
+}
+
+// GetDKGState returns the state of the DKG procedure. The local chain
+// registers groups as soon as results are submitted so it never awaits
+// the approval of a result.
+func (c *localChain) GetDKGState() (beaconchain.DKGState, error) {
+	return beaconchain.Idle, nil
+}
+
 func (c *localChain) GetLastDKGResult() (
 	*beaconchain.DKGResult,
 	map[beaconchain.GroupMemberIndex][]byte,
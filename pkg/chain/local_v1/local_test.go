@@ -200,7 +200,13 @@ func TestLocalOnDKGResultSubmitted(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	expectedResultHash, err := chainHandle.CalculateDKGResultHash(dkgResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	expectedResultSubmissionEvent := &event.DKGResultSubmission{
+		ResultHash:     expectedResultHash,
 		MemberIndex:    uint32(memberIndex),
 		GroupPublicKey: groupPublicKey,
 	}
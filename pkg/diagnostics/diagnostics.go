@@ -2,16 +2,25 @@ package diagnostics
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/chain"
 
 	"github.com/ipfs/go-log"
 	"github.com/keep-network/keep-common/pkg/diagnostics"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/diagnosis"
 )
 
 var logger = log.Logger("keep-diagnostics")
 
+// NetworkDiagnosisPath is the path of the diagnostics server under which
+// the diagnosis of the client's connectivity with the network is exposed.
+const NetworkDiagnosisPath = "/diagnostics/network"
+
 // Config stores diagnostics-related configuration.
 type Config struct {
 	Port int
@@ -50,6 +59,8 @@ func (r *Registry) RegisterConnectedPeersSource(
 		connectionManager := netProvider.ConnectionManager()
 		connectedPeers := connectionManager.ConnectedPeers()
 
+		versionProvider, hasVersions := connectionManager.(net.PeerVersionProvider)
+
 		peersList := make([]map[string]interface{}, len(connectedPeers))
 		for i := 0; i < len(connectedPeers); i++ {
 			peer := connectedPeers[i]
@@ -71,6 +82,14 @@ func (r *Registry) RegisterConnectedPeersSource(
 				"network_id":    peer,
 				"chain_address": peerChainAddress.String(),
 			}
+
+			if hasVersions {
+				if version, ok := versionProvider.GetPeerVersion(peer); ok {
+					peersList[i]["protocol_version"] = version.ProtocolVersion
+					peersList[i]["min_protocol_version"] = version.MinProtocolVersion
+					peersList[i]["capabilities"] = version.Capabilities
+				}
+			}
 		}
 
 		bytes, err := json.Marshal(peersList)
@@ -83,6 +102,153 @@ func (r *Registry) RegisterConnectedPeersSource(
 	})
 }
 
+// RegisterPeersReputationSource registers the diagnostics source providing
+// information about the reputation of peers. The source is not registered if
+// the network provider does not maintain the reputation of peers.
+func (r *Registry) RegisterPeersReputationSource(
+	netProvider net.Provider,
+	signing chain.Signing,
+) {
+	reputationManager, ok := netProvider.ConnectionManager().(net.ReputationManager)
+	if !ok {
+		return
+	}
+
+	r.Registry.RegisterSource("peers_reputation", func() string {
+		peersReputation := reputationManager.PeersReputation()
+
+		peersList := make([]map[string]interface{}, 0, len(peersReputation))
+		for _, peerReputation := range peersReputation {
+			peerInfo := map[string]interface{}{
+				"network_id": peerReputation.Peer,
+				"score":      peerReputation.Score,
+				"banned":     !peerReputation.BannedUntil.IsZero(),
+			}
+
+			if !peerReputation.BannedUntil.IsZero() {
+				peerInfo["banned_until"] = peerReputation.BannedUntil
+			}
+
+			peerPublicKey, err := netProvider.ConnectionManager().GetPeerPublicKey(
+				peerReputation.Peer,
+			)
+			if err == nil {
+				peerChainAddress, err := signing.PublicKeyToAddress(
+					peerPublicKey,
+				)
+				if err == nil {
+					peerInfo["chain_address"] = peerChainAddress.String()
+				}
+			}
+
+			peersList = append(peersList, peerInfo)
+		}
+
+		bytes, err := json.Marshal(peersList)
+		if err != nil {
+			logger.Error("error on serializing peers reputation to JSON: [%v]", err)
+			return ""
+		}
+
+		return string(bytes)
+	})
+}
+
+// RegisterBroadcastTrafficSource registers the diagnostics source providing
+// statistics of the broadcast channels traffic, per channel and message type.
+// The source is not registered if the network provider does not gather
+// statistics of the broadcast channels traffic.
+func (r *Registry) RegisterBroadcastTrafficSource(netProvider net.Provider) {
+	trafficProvider, ok := netProvider.(net.BroadcastTrafficProvider)
+	if !ok {
+		return
+	}
+
+	r.Registry.RegisterSource("broadcast_traffic", func() string {
+		broadcastTraffic := trafficProvider.BroadcastTraffic()
+
+		trafficList := make([]map[string]interface{}, 0, len(broadcastTraffic))
+		for _, traffic := range broadcastTraffic {
+			trafficList = append(trafficList, map[string]interface{}{
+				"channel":           traffic.Channel,
+				"message_type":      traffic.MessageType,
+				"messages_sent":     traffic.MessagesSent,
+				"bytes_sent":        traffic.BytesSent,
+				"messages_received": traffic.MessagesReceived,
+				"bytes_received":    traffic.BytesReceived,
+			})
+		}
+
+		bytes, err := json.Marshal(trafficList)
+		if err != nil {
+			logger.Error("error on serializing broadcast traffic to JSON: [%v]", err)
+			return ""
+		}
+
+		return string(bytes)
+	})
+}
+
+// RegisterDroppedMessagesSource registers the diagnostics source providing
+// the number of messages dropped by every broadcast channel. The source is
+// not registered if the network provider does not keep track of dropped
+// messages.
+func (r *Registry) RegisterDroppedMessagesSource(netProvider net.Provider) {
+	droppedMessagesProvider, ok := netProvider.(net.DroppedMessagesProvider)
+	if !ok {
+		return
+	}
+
+	r.Registry.RegisterSource("broadcast_dropped_messages", func() string {
+		bytes, err := json.Marshal(
+			droppedMessagesProvider.DroppedBroadcastMessages(),
+		)
+		if err != nil {
+			logger.Error(
+				"error on serializing dropped messages to JSON: [%v]",
+				err,
+			)
+			return ""
+		}
+
+		return string(bytes)
+	})
+}
+
+// RegisterReachabilitySource registers the diagnostics source providing
+// information about the reachability of the client from the outside network
+// and the enabled NAT traversal features. The source is not registered if
+// the network provider does not detect its reachability.
+func (r *Registry) RegisterReachabilitySource(netProvider net.Provider) {
+	reachabilityProvider, ok := netProvider.(net.ReachabilityProvider)
+	if !ok {
+		return
+	}
+
+	r.Registry.RegisterSource("network.reachability", func() string {
+		reachability := reachabilityProvider.Reachability()
+
+		reachabilityInfo := map[string]interface{}{
+			"status":              reachability.Status,
+			"listen_addresses":    reachability.ListenAddresses,
+			"announced_addresses": reachability.AnnouncedAddresses,
+			"relay_addresses":     reachability.RelayAddresses,
+			"relays":              reachability.Relays,
+			"relay_service":       reachability.RelayService,
+			"hole_punching":       reachability.HolePunching,
+			"nat_port_map":        reachability.NATPortMap,
+		}
+
+		bytes, err := json.Marshal(reachabilityInfo)
+		if err != nil {
+			logger.Error("error on serializing reachability to JSON: [%v]", err)
+			return ""
+		}
+
+		return string(bytes)
+	})
+}
+
 // RegisterClientInfoSource registers the diagnostics source providing
 // information about the client itself.
 func (r *Registry) RegisterClientInfoSource(
@@ -139,3 +305,70 @@ func (r *Registry) RegisterApplicationSource(application string, fetchApplicatio
 		return string(bytes)
 	})
 }
+
+// RegisterNetworkDiagnosisHandler registers the handler diagnosing the
+// connectivity of the client with the network on request. The diagnosis is
+// exposed on NetworkDiagnosisPath of the diagnostics server in JSON format.
+// The optional probeTimeout query parameter overrides the probe timeout of
+// the given options. Only one diagnosis is run at a time; concurrent
+// requests are rejected.
+func (r *Registry) RegisterNetworkDiagnosisHandler(
+	netProvider net.Provider,
+	options diagnosis.Options,
+) {
+	diagnosisMutex := sync.Mutex{}
+
+	http.HandleFunc(
+		NetworkDiagnosisPath,
+		func(response http.ResponseWriter, request *http.Request) {
+			if !diagnosisMutex.TryLock() {
+				http.Error(
+					response,
+					"network diagnosis already in progress",
+					http.StatusTooManyRequests,
+				)
+				return
+			}
+			defer diagnosisMutex.Unlock()
+
+			requestOptions := options
+			if probeTimeout := request.URL.Query().Get("probeTimeout"); probeTimeout != "" {
+				timeout, err := time.ParseDuration(probeTimeout)
+				if err != nil {
+					http.Error(
+						response,
+						fmt.Sprintf("invalid probe timeout: [%v]", err),
+						http.StatusBadRequest,
+					)
+					return
+				}
+				requestOptions.ProbeTimeout = timeout
+			}
+
+			report, err := diagnosis.Diagnose(
+				request.Context(),
+				netProvider,
+				requestOptions,
+			)
+			if err != nil {
+				logger.Errorf("error on diagnosing the network: [%v]", err)
+				http.Error(response, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			response.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(response).Encode(report); err != nil {
+				logger.Errorf(
+					"error on serializing network diagnosis to JSON: [%v]",
+					err,
+				)
+			}
+		},
+	)
+}
+
+// RegisterHandler exposes the given read-only handler on the given path of
+// the diagnostics server.
+func (r *Registry) RegisterHandler(path string, handler http.Handler) {
+	http.Handle(path, handler)
+}
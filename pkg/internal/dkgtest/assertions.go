@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/keep-network/keep-core/pkg/altbn128"
+	"github.com/keep-network/keep-core/pkg/beacon/dkg"
 	"github.com/keep-network/keep-core/pkg/internal/testutils"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
 )
@@ -212,3 +213,73 @@ func AssertResultSupportingMembers(
 		}
 	}
 }
+
+// AssertEvidenceResolution checks if the evidence has been recorded by all
+// group members and if secret shares accusations resolved offline, based on
+// the evidence exported by each member, disqualify exactly the expected
+// members.
+func AssertEvidenceResolution(
+	t *testing.T,
+	testResult *Result,
+	groupSize int,
+	expectedDisqualifiedMembers ...group.MemberIndex,
+) {
+	if len(testResult.evidence) != groupSize {
+		t.Errorf(
+			"unexpected number of recorded evidence\nexpected: [%v]\nactual:   [%v]",
+			groupSize,
+			len(testResult.evidence),
+		)
+	}
+
+	for _, evidence := range testResult.evidence {
+		bundle, err := evidence.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		importedEvidence, err := dkg.ImportEvidence(bundle)
+		if err != nil {
+			t.Errorf(
+				"could not import evidence of member [%v]: [%v]",
+				evidence.MemberIndex,
+				err,
+			)
+			continue
+		}
+
+		resolution, err := importedEvidence.ResolveSecretSharesAccusations(
+			&testutils.MockLogger{},
+		)
+		if err != nil {
+			t.Errorf(
+				"could not resolve accusations from evidence of member [%v]: [%v]",
+				evidence.MemberIndex,
+				err,
+			)
+			continue
+		}
+
+		for _, memberIndex := range resolution.DisqualifiedMembers {
+			if !containsMemberIndex(memberIndex, expectedDisqualifiedMembers) {
+				t.Errorf(
+					"member [%v] should not be disqualified based on "+
+						"evidence of member [%v]",
+					memberIndex,
+					evidence.MemberIndex,
+				)
+			}
+		}
+
+		for _, memberIndex := range expectedDisqualifiedMembers {
+			if !containsMemberIndex(memberIndex, resolution.DisqualifiedMembers) {
+				t.Errorf(
+					"member [%v] should be disqualified based on "+
+						"evidence of member [%v]",
+					memberIndex,
+					evidence.MemberIndex,
+				)
+			}
+		}
+	}
+}
@@ -33,6 +33,7 @@ type Result struct {
 	dkgResultSignatures map[group.MemberIndex][]byte
 	signers             []*dkg.ThresholdSigner
 	memberFailures      []error
+	evidence            []*gjkr.Evidence
 }
 
 // GetSigners returns all signers created from DKG protocol execution.
@@ -69,7 +70,7 @@ func RunTest(
 	}
 
 	network := interception.NewNetwork(
-		netLocal.ConnectWithKey(operatorPublicKey),
+		netLocal.ConnectWithPrivateKey(operatorPrivateKey),
 		rules,
 	)
 
@@ -150,6 +151,12 @@ func executeDKG(
 	gjkr.RegisterUnmarshallers(broadcastChannel)
 	dkgResult.RegisterUnmarshallers(broadcastChannel)
 
+	evidenceStore := dkg.NewEvidenceStore(
+		&testutils.MockLogger{},
+		newMemoryPersistence(),
+		dkg.DefaultEvidenceRetentionPeriod,
+	)
+
 	membershipValidator := group.NewMembershipValidator(
 		&testutils.MockLogger{},
 		selectedOperators,
@@ -168,6 +175,8 @@ func executeDKG(
 				broadcastChannel,
 				membershipValidator,
 				selectedOperators,
+				evidenceStore,
+				nil,
 			)
 			if signer != nil {
 				signersMutex.Lock()
@@ -198,6 +207,7 @@ func executeDKG(
 			dkgResultSignatures,
 			signers,
 			memberFailures,
+			evidenceStore.List(),
 		}, nil
 
 	case <-ctx.Done():
@@ -207,6 +217,7 @@ func executeDKG(
 			nil,
 			signers,
 			memberFailures,
+			evidenceStore.List(),
 		}, nil
 	}
 }
@@ -0,0 +1,93 @@
+package dkgtest
+
+import (
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+// memoryPersistence is an in-memory persistence handle used to keep the DKG
+// evidence recorded during the test execution.
+type memoryPersistence struct {
+	mutex sync.Mutex
+	// directory -> name -> content
+	files map[string]map[string][]byte
+}
+
+func newMemoryPersistence() *memoryPersistence {
+	return &memoryPersistence{
+		files: make(map[string]map[string][]byte),
+	}
+}
+
+func (mp *memoryPersistence) Save(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+
+	if _, ok := mp.files[directory]; !ok {
+		mp.files[directory] = make(map[string][]byte)
+	}
+	mp.files[directory][name] = data
+
+	return nil
+}
+
+func (mp *memoryPersistence) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+
+	descriptors := make([]persistence.DataDescriptor, 0)
+	for directory, files := range mp.files {
+		for name, content := range files {
+			descriptors = append(
+				descriptors,
+				&memoryDataDescriptor{directory, name, content},
+			)
+		}
+	}
+
+	dataChan := make(chan persistence.DataDescriptor, len(descriptors))
+	errorChan := make(chan error)
+
+	for _, descriptor := range descriptors {
+		dataChan <- descriptor
+	}
+
+	close(dataChan)
+	close(errorChan)
+
+	return dataChan, errorChan
+}
+
+func (mp *memoryPersistence) Delete(directory string, name string) error {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+
+	delete(mp.files[directory], name)
+	return nil
+}
+
+type memoryDataDescriptor struct {
+	directory string
+	name      string
+	content   []byte
+}
+
+func (mdd *memoryDataDescriptor) Name() string {
+	return mdd.name
+}
+
+func (mdd *memoryDataDescriptor) Directory() string {
+	return mdd.directory
+}
+
+func (mdd *memoryDataDescriptor) Content() ([]byte, error) {
+	return mdd.content, nil
+}
@@ -149,6 +149,7 @@ func executeSigning(
 				threshold,
 				signer,
 				startBlockHeight,
+				entry.DefaultProtocolVersion,
 			)
 			if err != nil {
 				fmt.Printf("[signer:%v %v] failed with: [%v]\n", signer.MemberID(), previousEntry, err)
@@ -66,8 +66,12 @@ func (c *channel) Send(ctx context.Context, m net.TaggedMarshaler) error {
 	return c.delegate.Send(ctx, c.rules(m))
 }
 
-func (c *channel) Recv(ctx context.Context, handler func(m net.Message)) {
-	c.delegate.Recv(ctx, handler)
+func (c *channel) Recv(
+	ctx context.Context,
+	handler func(m net.Message),
+	options ...net.RecvOption,
+) {
+	c.delegate.Recv(ctx, handler, options...)
 }
 
 func (c *channel) SetUnmarshaler(unmarshaler func() net.TaggedUnmarshaler) {
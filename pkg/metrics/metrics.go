@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/ipfs/go-log"
@@ -122,6 +123,140 @@ func ObserveEthConnectivity(
 	)
 }
 
+// ObserveDroppedMessages triggers an observation process of the
+// broadcast_dropped_messages metric holding the number of messages dropped
+// by all broadcast channels. The observation is not started if the network
+// provider does not keep track of dropped messages.
+func ObserveDroppedMessages(
+	ctx context.Context,
+	registry *metrics.Registry,
+	netProvider net.Provider,
+	tick time.Duration,
+) {
+	droppedMessagesProvider, ok := netProvider.(net.DroppedMessagesProvider)
+	if !ok {
+		return
+	}
+
+	input := func() float64 {
+		total := uint64(0)
+		for _, dropped := range droppedMessagesProvider.DroppedBroadcastMessages() {
+			total += dropped
+		}
+
+		return float64(total)
+	}
+
+	observe(
+		ctx,
+		"broadcast_dropped_messages",
+		input,
+		registry,
+		validateTick(tick, DefaultNetworkMetricsTick),
+	)
+}
+
+// ObserveBroadcastTraffic triggers an observation process of the
+// broadcast_bytes_sent and broadcast_bytes_received metrics for each message
+// type transmitted over broadcast channels. Metrics are aggregated over all
+// channels. The observation is not started if the network provider does not
+// gather statistics of the broadcast channels traffic.
+func ObserveBroadcastTraffic(
+	ctx context.Context,
+	registry *metrics.Registry,
+	netProvider net.Provider,
+	tick time.Duration,
+) {
+	trafficProvider, ok := netProvider.(net.BroadcastTrafficProvider)
+	if !ok {
+		return
+	}
+
+	tick = validateTick(tick, DefaultNetworkMetricsTick)
+
+	type messageTypeGauges struct {
+		bytesSent     *metrics.Gauge
+		bytesReceived *metrics.Gauge
+	}
+
+	gauges := make(map[string]*messageTypeGauges)
+
+	observeTraffic := func() {
+		bytesSent := make(map[string]uint64)
+		bytesReceived := make(map[string]uint64)
+
+		for _, traffic := range trafficProvider.BroadcastTraffic() {
+			bytesSent[traffic.MessageType] += traffic.BytesSent
+			bytesReceived[traffic.MessageType] += traffic.BytesReceived
+		}
+
+		for messageType := range bytesSent {
+			typeGauges, ok := gauges[messageType]
+			if !ok {
+				typeGauges = &messageTypeGauges{}
+
+				// The metrics registry does not allow to register several
+				// metrics of the same name so the message type is a part
+				// of the name.
+				suffix := metricNameSuffix(messageType)
+				label := metrics.NewLabel("message_type", messageType)
+
+				var err error
+				typeGauges.bytesSent, err = registry.NewGauge(
+					"broadcast_bytes_sent_"+suffix,
+					label,
+				)
+				if err != nil {
+					logger.Warningf("could not create gauge: [%v]", err)
+					continue
+				}
+				typeGauges.bytesReceived, err = registry.NewGauge(
+					"broadcast_bytes_received_"+suffix,
+					label,
+				)
+				if err != nil {
+					logger.Warningf("could not create gauge: [%v]", err)
+					continue
+				}
+
+				gauges[messageType] = typeGauges
+			}
+
+			typeGauges.bytesSent.Set(float64(bytesSent[messageType]))
+			typeGauges.bytesReceived.Set(float64(bytesReceived[messageType]))
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				observeTraffic()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	logger.Infof("observing broadcast traffic with [%s] tick", tick)
+}
+
+// metricNameSuffix converts the given string to a form that can be used
+// as a part of a metric name.
+func metricNameSuffix(value string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') ||
+			(r >= 'A' && r <= 'Z') ||
+			(r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, value)
+}
+
 func observe(
 	ctx context.Context,
 	name string,
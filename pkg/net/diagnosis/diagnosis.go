@@ -0,0 +1,281 @@
+// Package diagnosis implements the diagnosis of the client's connectivity
+// with the network. The diagnosis covers connections with the given peers,
+// reachability of the client's announced addresses, propagation of messages
+// published to the network, and the health of the DHT routing table.
+package diagnosis
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+var logger = log.Logger("keep-net-diagnosis")
+
+// routingPeerStalePeriod is the period after which a peer in the routing
+// table is considered stale if it was not successfully queried.
+const routingPeerStalePeriod = 1 * time.Hour
+
+// Options determine the scope of the diagnosis.
+type Options struct {
+	// Peers are multiaddresses of peers whose connectivity is checked.
+	Peers []string
+	// Addresses are announced multiaddresses of the client whose
+	// reachability is checked. If not set, addresses currently announced by
+	// the network provider are checked, if the provider exposes them.
+	Addresses []string
+	// Firewall, if set, is used to check whether the client's operator is
+	// admitted by peers running the same firewall rules.
+	Firewall net.Firewall
+	// ProbeTimeout is the time of waiting for echoes of the probe. If not
+	// set, DefaultProbeTimeout is used.
+	ProbeTimeout time.Duration
+}
+
+// OperatorCheck holds the result of checking whether the client's operator is
+// admitted by the firewall of peers.
+type OperatorCheck struct {
+	Admitted      bool   `json:"admitted"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+// PeerCheck holds the result of checking the connectivity with a peer.
+type PeerCheck struct {
+	Address       string                `json:"address"`
+	PeerID        string                `json:"network_id,omitempty"`
+	Connected     bool                  `json:"connected"`
+	Failure       net.ConnectionFailure `json:"failure,omitempty"`
+	FailureReason string                `json:"failure_reason,omitempty"`
+	Latency       time.Duration         `json:"latency,omitempty"`
+}
+
+// AddressCheck holds the result of checking the reachability of an announced
+// address of the client.
+type AddressCheck struct {
+	Address       string `json:"address"`
+	Reachable     bool   `json:"reachable"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+// RoutingPeer holds information about a peer in the routing table.
+type RoutingPeer struct {
+	PeerID                string    `json:"network_id"`
+	Connected             bool      `json:"connected"`
+	Stale                 bool      `json:"stale"`
+	LastSuccessfulQueryAt time.Time `json:"last_successful_query_at"`
+}
+
+// RoutingTableCheck holds the health of the DHT routing table. The routing
+// table is considered healthy if it is not empty and at most half of its
+// peers are stale.
+type RoutingTableCheck struct {
+	Healthy        bool          `json:"healthy"`
+	Size           int           `json:"size"`
+	ConnectedPeers int           `json:"connected_peers"`
+	StalePeers     int           `json:"stale_peers"`
+	Peers          []RoutingPeer `json:"peers"`
+}
+
+// Report is the result of the diagnosis.
+type Report struct {
+	PeerID             string                 `json:"network_id"`
+	ConnectedPeers     int                    `json:"connected_peers"`
+	ReachabilityStatus net.ReachabilityStatus `json:"reachability_status,omitempty"`
+	Operator           *OperatorCheck         `json:"operator,omitempty"`
+	Peers              []PeerCheck            `json:"peers"`
+	Addresses          []AddressCheck         `json:"addresses"`
+	Propagation        PropagationCheck       `json:"propagation"`
+	RoutingTable       RoutingTableCheck      `json:"routing_table"`
+}
+
+// Diagnose diagnoses the connectivity of the network provider with
+// the network. The network provider must be able to diagnose its
+// connectivity.
+func Diagnose(
+	ctx context.Context,
+	netProvider net.Provider,
+	options Options,
+) (*Report, error) {
+	diagnoser, ok := netProvider.(net.ConnectivityDiagnoser)
+	if !ok {
+		return nil, fmt.Errorf(
+			"network provider [%v] can not diagnose its connectivity",
+			netProvider.Type(),
+		)
+	}
+
+	probeTimeout := options.ProbeTimeout
+	if probeTimeout <= 0 {
+		probeTimeout = DefaultProbeTimeout
+	}
+
+	report := &Report{
+		PeerID: netProvider.ID().String(),
+	}
+
+	if options.Firewall != nil {
+		operatorCheck, err := checkOperator(netProvider, options.Firewall)
+		if err != nil {
+			return nil, err
+		}
+		report.Operator = operatorCheck
+	}
+
+	addresses := options.Addresses
+
+	if reachabilityProvider, ok := netProvider.(net.ReachabilityProvider); ok {
+		reachability := reachabilityProvider.Reachability()
+
+		report.ReachabilityStatus = reachability.Status
+		if len(addresses) == 0 {
+			addresses = reachability.AnnouncedAddresses
+		}
+	}
+
+	report.Peers = checkPeers(ctx, diagnoser, options.Peers)
+	report.Addresses = checkAddresses(ctx, diagnoser, addresses)
+	report.Propagation = measurePropagation(ctx, netProvider, probeTimeout)
+	report.RoutingTable = checkRoutingTable(diagnoser.RoutingTable(), time.Now())
+	report.ConnectedPeers = len(netProvider.ConnectionManager().ConnectedPeers())
+
+	return report, nil
+}
+
+// checkOperator checks whether the operator of the network provider is
+// admitted by the given firewall.
+func checkOperator(
+	netProvider net.Provider,
+	firewall net.Firewall,
+) (*OperatorCheck, error) {
+	operatorPublicKey, err := netProvider.ConnectionManager().GetPeerPublicKey(
+		netProvider.ID().String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not get public key of the client: [%v]",
+			err,
+		)
+	}
+
+	if err := firewall.Validate(operatorPublicKey); err != nil {
+		return &OperatorCheck{Admitted: false, FailureReason: err.Error()}, nil
+	}
+
+	return &OperatorCheck{Admitted: true}, nil
+}
+
+// checkPeers checks the connectivity with the given peers concurrently.
+// Results are returned in the order of peers.
+func checkPeers(
+	ctx context.Context,
+	diagnoser net.ConnectivityDiagnoser,
+	peers []string,
+) []PeerCheck {
+	checks := make([]PeerCheck, len(peers))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(peers))
+
+	for i, address := range peers {
+		i := i
+		address := address
+
+		go func() {
+			defer wg.Done()
+
+			diagnosis := diagnoser.DiagnosePeer(ctx, address)
+
+			checks[i] = PeerCheck{
+				Address:       diagnosis.Address,
+				PeerID:        diagnosis.PeerID,
+				Connected:     diagnosis.Connected,
+				Failure:       diagnosis.Failure,
+				FailureReason: diagnosis.FailureReason,
+				Latency:       diagnosis.Latency,
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return checks
+}
+
+// checkAddresses checks the reachability of the given addresses
+// concurrently. Results are returned in the order of addresses.
+func checkAddresses(
+	ctx context.Context,
+	diagnoser net.ConnectivityDiagnoser,
+	addresses []string,
+) []AddressCheck {
+	checks := make([]AddressCheck, len(addresses))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(addresses))
+
+	for i, address := range addresses {
+		i := i
+		address := address
+
+		go func() {
+			defer wg.Done()
+
+			check := diagnoser.CheckAddress(ctx, address)
+
+			checks[i] = AddressCheck{
+				Address:       check.Address,
+				Reachable:     check.Reachable,
+				FailureReason: check.FailureReason,
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return checks
+}
+
+// checkRoutingTable determines the health of the routing table with the given
+// peers, as of the given time.
+func checkRoutingTable(
+	peers []net.RoutingTablePeer,
+	now time.Time,
+) RoutingTableCheck {
+	check := RoutingTableCheck{
+		Size:  len(peers),
+		Peers: make([]RoutingPeer, 0, len(peers)),
+	}
+
+	for _, peer := range peers {
+		// Peers never queried are considered stale only once they stay in
+		// the routing table for the stale period.
+		lastSeen := peer.LastSuccessfulQueryAt
+		if lastSeen.IsZero() {
+			lastSeen = peer.AddedAt
+		}
+		stale := now.Sub(lastSeen) > routingPeerStalePeriod
+
+		if peer.Connected {
+			check.ConnectedPeers++
+		}
+		if stale {
+			check.StalePeers++
+		}
+
+		check.Peers = append(check.Peers, RoutingPeer{
+			PeerID:                peer.PeerID,
+			Connected:             peer.Connected,
+			Stale:                 stale,
+			LastSuccessfulQueryAt: peer.LastSuccessfulQueryAt,
+		})
+	}
+
+	check.Healthy = check.Size > 0 && 2*check.StalePeers <= check.Size
+
+	return check
+}
@@ -0,0 +1,211 @@
+package diagnosis
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/local"
+)
+
+func TestCheckRoutingTable(t *testing.T) {
+	now := time.Now()
+
+	var tests = map[string]struct {
+		peers         []net.RoutingTablePeer
+		expectedCheck RoutingTableCheck
+	}{
+		"empty routing table": {
+			peers: []net.RoutingTablePeer{},
+			expectedCheck: RoutingTableCheck{
+				Healthy: false,
+				Peers:   []RoutingPeer{},
+			},
+		},
+		"at most half of peers stale": {
+			peers: []net.RoutingTablePeer{
+				{
+					PeerID:                "peer-1",
+					Connected:             true,
+					AddedAt:               now.Add(-2 * time.Hour),
+					LastSuccessfulQueryAt: now.Add(-time.Minute),
+				},
+				{
+					PeerID:                "peer-2",
+					Connected:             false,
+					AddedAt:               now.Add(-3 * time.Hour),
+					LastSuccessfulQueryAt: now.Add(-2 * time.Hour),
+				},
+				{
+					PeerID:    "peer-3",
+					Connected: false,
+					AddedAt:   now.Add(-time.Minute),
+				},
+				{
+					PeerID:    "peer-4",
+					Connected: true,
+					AddedAt:   now.Add(-2 * time.Hour),
+				},
+			},
+			expectedCheck: RoutingTableCheck{
+				Healthy:        true,
+				Size:           4,
+				ConnectedPeers: 2,
+				StalePeers:     2,
+				Peers: []RoutingPeer{
+					{
+						PeerID:                "peer-1",
+						Connected:             true,
+						Stale:                 false,
+						LastSuccessfulQueryAt: now.Add(-time.Minute),
+					},
+					{
+						PeerID:                "peer-2",
+						Connected:             false,
+						Stale:                 true,
+						LastSuccessfulQueryAt: now.Add(-2 * time.Hour),
+					},
+					{
+						PeerID:    "peer-3",
+						Connected: false,
+						Stale:     false,
+					},
+					{
+						PeerID:    "peer-4",
+						Connected: true,
+						Stale:     true,
+					},
+				},
+			},
+		},
+		"most of peers stale": {
+			peers: []net.RoutingTablePeer{
+				{
+					PeerID:  "peer-1",
+					AddedAt: now.Add(-2 * time.Hour),
+				},
+				{
+					PeerID:                "peer-2",
+					AddedAt:               now.Add(-2 * time.Hour),
+					LastSuccessfulQueryAt: now,
+				},
+				{
+					PeerID:  "peer-3",
+					AddedAt: now.Add(-2 * time.Hour),
+				},
+			},
+			expectedCheck: RoutingTableCheck{
+				Healthy:        false,
+				Size:           3,
+				ConnectedPeers: 0,
+				StalePeers:     2,
+				Peers: []RoutingPeer{
+					{PeerID: "peer-1", Stale: true},
+					{PeerID: "peer-2", LastSuccessfulQueryAt: now},
+					{PeerID: "peer-3", Stale: true},
+				},
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			check := checkRoutingTable(test.peers, now)
+
+			if !reflect.DeepEqual(test.expectedCheck, check) {
+				t.Errorf(
+					"unexpected routing table check\nexpected: [%+v]\nactual:   [%+v]",
+					test.expectedCheck,
+					check,
+				)
+			}
+		})
+	}
+}
+
+func TestProbeResponder(t *testing.T) {
+	responder := newProbeResponder()
+	now := time.Now()
+
+	var tests = []struct {
+		sender          string
+		at              time.Time
+		expectedRespond bool
+	}{
+		{sender: "peer-1", at: now, expectedRespond: true},
+		{sender: "peer-2", at: now, expectedRespond: true},
+		{sender: "peer-1", at: now.Add(time.Second), expectedRespond: false},
+		{
+			sender:          "peer-2",
+			at:              now.Add(probeResponsePeriod - time.Second),
+			expectedRespond: false,
+		},
+		{
+			sender:          "peer-1",
+			at:              now.Add(probeResponsePeriod),
+			expectedRespond: true,
+		},
+	}
+
+	for i, test := range tests {
+		respond := responder.shouldRespond(test.sender, test.at)
+		if respond != test.expectedRespond {
+			t.Errorf(
+				"unexpected response decision for probe [%v] of [%v]\n"+
+					"expected: [%v]\nactual:   [%v]",
+				i,
+				test.sender,
+				test.expectedRespond,
+				respond,
+			)
+		}
+	}
+}
+
+func TestMeasurePropagation(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	prober := local.Connect()
+	responders := []net.Provider{local.Connect(), local.Connect()}
+
+	for _, responder := range responders {
+		if err := ServeProbes(ctx, responder); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	check := measurePropagation(ctx, prober, 500*time.Millisecond)
+
+	if check.FailureReason != "" {
+		t.Fatalf("unexpected failure: [%v]", check.FailureReason)
+	}
+	if len(check.ProbeID) == 0 {
+		t.Errorf("expected probe id")
+	}
+
+	// The local provider identifies senders with identifiers of their
+	// channels so only the number of distinct responders is checked.
+	echoed := make(map[string]bool)
+	for _, echo := range check.Echoes {
+		echoed[echo.PeerID] = true
+	}
+
+	if len(echoed) != len(responders) || len(check.Echoes) != len(responders) {
+		t.Errorf(
+			"unexpected echoes\nexpected: [%v] echoes from distinct peers\n"+
+				"actual:   [%+v]",
+			len(responders),
+			check.Echoes,
+		)
+	}
+}
+
+func TestDiagnose_UnsupportedProvider(t *testing.T) {
+	_, err := Diagnose(context.Background(), local.Connect(), Options{})
+	if err == nil {
+		t.Fatal("expected error for provider not diagnosing its connectivity")
+	}
+}
@@ -0,0 +1,206 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: pkg/net/diagnosis/gen/pb/message.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ProbeMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProbeID string `protobuf:"bytes,1,opt,name=probeID,proto3" json:"probeID,omitempty"`
+}
+
+func (x *ProbeMessage) Reset() {
+	*x = ProbeMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_net_diagnosis_gen_pb_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeMessage) ProtoMessage() {}
+
+func (x *ProbeMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_net_diagnosis_gen_pb_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeMessage.ProtoReflect.Descriptor instead.
+func (*ProbeMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_net_diagnosis_gen_pb_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProbeMessage) GetProbeID() string {
+	if x != nil {
+		return x.ProbeID
+	}
+	return ""
+}
+
+type EchoMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProbeID string `protobuf:"bytes,1,opt,name=probeID,proto3" json:"probeID,omitempty"`
+}
+
+func (x *EchoMessage) Reset() {
+	*x = EchoMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_net_diagnosis_gen_pb_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoMessage) ProtoMessage() {}
+
+func (x *EchoMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_net_diagnosis_gen_pb_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoMessage.ProtoReflect.Descriptor instead.
+func (*EchoMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_net_diagnosis_gen_pb_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EchoMessage) GetProbeID() string {
+	if x != nil {
+		return x.ProbeID
+	}
+	return ""
+}
+
+var File_pkg_net_diagnosis_gen_pb_message_proto protoreflect.FileDescriptor
+
+var file_pkg_net_diagnosis_gen_pb_message_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x70, 0x6b, 0x67, 0x2f, 0x6e, 0x65, 0x74, 0x2f, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x69, 0x73, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x69, 0x73, 0x22, 0x28, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x49, 0x44, 0x22, 0x27, 0x0a,
+	0x0b, 0x45, 0x63, 0x68, 0x6f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x49, 0x44, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_net_diagnosis_gen_pb_message_proto_rawDescOnce sync.Once
+	file_pkg_net_diagnosis_gen_pb_message_proto_rawDescData = file_pkg_net_diagnosis_gen_pb_message_proto_rawDesc
+)
+
+func file_pkg_net_diagnosis_gen_pb_message_proto_rawDescGZIP() []byte {
+	file_pkg_net_diagnosis_gen_pb_message_proto_rawDescOnce.Do(func() {
+		file_pkg_net_diagnosis_gen_pb_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_net_diagnosis_gen_pb_message_proto_rawDescData)
+	})
+	return file_pkg_net_diagnosis_gen_pb_message_proto_rawDescData
+}
+
+var file_pkg_net_diagnosis_gen_pb_message_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pkg_net_diagnosis_gen_pb_message_proto_goTypes = []interface{}{
+	(*ProbeMessage)(nil), // 0: diagnosis.ProbeMessage
+	(*EchoMessage)(nil),  // 1: diagnosis.EchoMessage
+}
+var file_pkg_net_diagnosis_gen_pb_message_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_pkg_net_diagnosis_gen_pb_message_proto_init() }
+func file_pkg_net_diagnosis_gen_pb_message_proto_init() {
+	if File_pkg_net_diagnosis_gen_pb_message_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_net_diagnosis_gen_pb_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProbeMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_net_diagnosis_gen_pb_message_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_net_diagnosis_gen_pb_message_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_net_diagnosis_gen_pb_message_proto_goTypes,
+		DependencyIndexes: file_pkg_net_diagnosis_gen_pb_message_proto_depIdxs,
+		MessageInfos:      file_pkg_net_diagnosis_gen_pb_message_proto_msgTypes,
+	}.Build()
+	File_pkg_net_diagnosis_gen_pb_message_proto = out.File
+	file_pkg_net_diagnosis_gen_pb_message_proto_rawDesc = nil
+	file_pkg_net_diagnosis_gen_pb_message_proto_goTypes = nil
+	file_pkg_net_diagnosis_gen_pb_message_proto_depIdxs = nil
+}
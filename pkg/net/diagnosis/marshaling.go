@@ -0,0 +1,47 @@
+package diagnosis
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/keep-network/keep-core/pkg/net/diagnosis/gen/pb"
+)
+
+// Marshal converts this probeMessage to a byte array suitable for network
+// communication.
+func (pm *probeMessage) Marshal() ([]byte, error) {
+	return proto.Marshal(&pb.ProbeMessage{
+		ProbeID: pm.probeID,
+	})
+}
+
+// Unmarshal converts a byte array produced by Marshal to a probeMessage.
+func (pm *probeMessage) Unmarshal(bytes []byte) error {
+	pbMsg := pb.ProbeMessage{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return err
+	}
+
+	pm.probeID = pbMsg.ProbeID
+
+	return nil
+}
+
+// Marshal converts this echoMessage to a byte array suitable for network
+// communication.
+func (em *echoMessage) Marshal() ([]byte, error) {
+	return proto.Marshal(&pb.EchoMessage{
+		ProbeID: em.probeID,
+	})
+}
+
+// Unmarshal converts a byte array produced by Marshal to an echoMessage.
+func (em *echoMessage) Unmarshal(bytes []byte) error {
+	pbMsg := pb.EchoMessage{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return err
+	}
+
+	em.probeID = pbMsg.ProbeID
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package diagnosis
+
+const messageTypePrefix = "diagnosis/"
+
+// probeMessage is a message published to the probe channel to measure
+// the propagation of messages in the network. Peers receiving the probe
+// respond with an echoMessage.
+type probeMessage struct {
+	probeID string
+}
+
+// Type returns a string describing a probeMessage type for marshaling
+// purposes.
+func (pm *probeMessage) Type() string {
+	return messageTypePrefix + "probe_message"
+}
+
+// echoMessage is a message published to the probe channel in response to
+// the probe with the given identifier.
+type echoMessage struct {
+	probeID string
+}
+
+// Type returns a string describing an echoMessage type for marshaling
+// purposes.
+func (em *echoMessage) Type() string {
+	return messageTypePrefix + "echo_message"
+}
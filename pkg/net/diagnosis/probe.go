@@ -0,0 +1,222 @@
+package diagnosis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+const (
+	// ProbeChannel is the name of the broadcast channel used to measure
+	// the propagation of messages in the network.
+	ProbeChannel = "keep-network-probe"
+	// DefaultProbeTimeout is the default time of waiting for echoes of
+	// the probe.
+	DefaultProbeTimeout = 10 * time.Second
+	// echoLifetime is the time for which echoes are retransmitted.
+	echoLifetime = 10 * time.Second
+	// probeResponsePeriod is the minimum period between echoes sent in
+	// response to probes of the same peer. It prevents the probe channel
+	// from being used to amplify the traffic in the network.
+	probeResponsePeriod = 1 * time.Minute
+)
+
+func registerUnmarshallers(channel net.BroadcastChannel) {
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &probeMessage{}
+	})
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &echoMessage{}
+	})
+}
+
+// ServeProbes responds to probes published to the probe channel by other
+// peers, until the context is done. Every peer is responded at most once per
+// probeResponsePeriod.
+func ServeProbes(ctx context.Context, netProvider net.Provider) error {
+	channel, err := netProvider.BroadcastChannelFor(ProbeChannel)
+	if err != nil {
+		return fmt.Errorf("could not get probe channel: [%v]", err)
+	}
+
+	registerUnmarshallers(channel)
+
+	responder := newProbeResponder()
+
+	channel.Recv(ctx, func(message net.Message) {
+		probe, ok := message.Payload().(*probeMessage)
+		if !ok {
+			return
+		}
+
+		sender := message.TransportSenderID().String()
+		if sender == netProvider.ID().String() {
+			return
+		}
+
+		if !responder.shouldRespond(sender, time.Now()) {
+			logger.Debugf("ignoring probe [%v] from [%v]", probe.probeID, sender)
+			return
+		}
+
+		echoCtx, cancelEchoCtx := context.WithTimeout(ctx, echoLifetime)
+		time.AfterFunc(echoLifetime, cancelEchoCtx)
+
+		if err := channel.Send(
+			echoCtx,
+			&echoMessage{probeID: probe.probeID},
+		); err != nil {
+			logger.Warnf(
+				"could not respond to probe [%v] from [%v]: [%v]",
+				probe.probeID,
+				sender,
+				err,
+			)
+		}
+	})
+
+	return nil
+}
+
+// probeResponder determines whether a probe of the given peer should be
+// responded.
+type probeResponder struct {
+	mutex         sync.Mutex
+	lastResponses map[string]time.Time
+}
+
+func newProbeResponder() *probeResponder {
+	return &probeResponder{
+		lastResponses: make(map[string]time.Time),
+	}
+}
+
+func (pr *probeResponder) shouldRespond(sender string, now time.Time) bool {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	for peer, lastResponse := range pr.lastResponses {
+		if now.Sub(lastResponse) >= probeResponsePeriod {
+			delete(pr.lastResponses, peer)
+		}
+	}
+
+	if _, responded := pr.lastResponses[sender]; responded {
+		return false
+	}
+
+	pr.lastResponses[sender] = now
+
+	return true
+}
+
+// Echo holds the echo of the probe received from a peer.
+type Echo struct {
+	// PeerID is the transport identifier of the responding peer.
+	PeerID string `json:"network_id"`
+	// RoundTrip is the time elapsed between publishing the probe and
+	// receiving the echo.
+	RoundTrip time.Duration `json:"round_trip"`
+}
+
+// PropagationCheck holds the result of measuring the propagation of
+// messages in the network.
+type PropagationCheck struct {
+	// ProbeID is the identifier of the published probe.
+	ProbeID string `json:"probe_id"`
+	// Echoes are echoes of the probe received from peers, in the order
+	// they were received.
+	Echoes []Echo `json:"echoes"`
+	// FailureReason describes why the probe could not be published.
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+// measurePropagation publishes a probe to the probe channel and collects
+// echoes of the probe received from peers until the timeout elapses.
+func measurePropagation(
+	ctx context.Context,
+	netProvider net.Provider,
+	timeout time.Duration,
+) PropagationCheck {
+	check := PropagationCheck{Echoes: make([]Echo, 0)}
+
+	probeID, err := newProbeID()
+	if err != nil {
+		check.FailureReason = err.Error()
+		return check
+	}
+	check.ProbeID = probeID
+
+	channel, err := netProvider.BroadcastChannelFor(ProbeChannel)
+	if err != nil {
+		check.FailureReason = fmt.Sprintf(
+			"could not get probe channel: [%v]",
+			err,
+		)
+		return check
+	}
+
+	registerUnmarshallers(channel)
+
+	probeCtx, cancelProbeCtx := context.WithTimeout(ctx, timeout)
+	defer cancelProbeCtx()
+
+	echoesMutex := sync.Mutex{}
+	echoes := make([]Echo, 0)
+	responders := make(map[string]bool)
+
+	sentAt := time.Now()
+
+	channel.Recv(probeCtx, func(message net.Message) {
+		echo, ok := message.Payload().(*echoMessage)
+		if !ok || echo.probeID != probeID {
+			return
+		}
+
+		echoesMutex.Lock()
+		defer echoesMutex.Unlock()
+
+		responder := message.TransportSenderID().String()
+		if responders[responder] {
+			return
+		}
+		responders[responder] = true
+
+		echoes = append(echoes, Echo{
+			PeerID:    responder,
+			RoundTrip: time.Since(sentAt),
+		})
+	})
+
+	// The probe is retransmitted until the timeout elapses so that peers
+	// which have not yet learned the client is subscribed receive it.
+	if err := channel.Send(probeCtx, &probeMessage{probeID}); err != nil {
+		check.FailureReason = fmt.Sprintf("could not send probe: [%v]", err)
+		return check
+	}
+
+	<-probeCtx.Done()
+
+	// Echoes can still be delivered to the handler being unregistered so
+	// they are copied.
+	echoesMutex.Lock()
+	check.Echoes = make([]Echo, len(echoes))
+	copy(check.Echoes, echoes)
+	echoesMutex.Unlock()
+
+	return check
+}
+
+func newProbeID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("could not generate probe id: [%v]", err)
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,340 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: pkg/net/gen/pb/address_book.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PeerRecord holds the information about a known peer persisted across
+// client restarts.
+type PeerRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Peer id of the known peer.
+	PeerID []byte `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	// Multiaddresses the peer was reachable at.
+	Multiaddrs [][]byte `protobuf:"bytes,2,rep,name=multiaddrs,proto3" json:"multiaddrs,omitempty"`
+	// The uncompressed operator public key of the peer.
+	OperatorPublicKey []byte `protobuf:"bytes,3,opt,name=operatorPublicKey,proto3" json:"operatorPublicKey,omitempty"`
+	// Unix timestamp of the moment the peer was last seen connected.
+	LastSeen int64 `protobuf:"varint,4,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	// Reputation score of the peer.
+	ReputationScore float64 `protobuf:"fixed64,5,opt,name=reputationScore,proto3" json:"reputationScore,omitempty"`
+	// Unix timestamp of the moment the peer ban expires. Zero if the peer is
+	// not banned.
+	BannedUntil int64 `protobuf:"varint,6,opt,name=bannedUntil,proto3" json:"bannedUntil,omitempty"`
+}
+
+func (x *PeerRecord) Reset() {
+	*x = PeerRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_net_gen_pb_address_book_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerRecord) ProtoMessage() {}
+
+func (x *PeerRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_net_gen_pb_address_book_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerRecord.ProtoReflect.Descriptor instead.
+func (*PeerRecord) Descriptor() ([]byte, []int) {
+	return file_pkg_net_gen_pb_address_book_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PeerRecord) GetPeerID() []byte {
+	if x != nil {
+		return x.PeerID
+	}
+	return nil
+}
+
+func (x *PeerRecord) GetMultiaddrs() [][]byte {
+	if x != nil {
+		return x.Multiaddrs
+	}
+	return nil
+}
+
+func (x *PeerRecord) GetOperatorPublicKey() []byte {
+	if x != nil {
+		return x.OperatorPublicKey
+	}
+	return nil
+}
+
+func (x *PeerRecord) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *PeerRecord) GetReputationScore() float64 {
+	if x != nil {
+		return x.ReputationScore
+	}
+	return 0
+}
+
+func (x *PeerRecord) GetBannedUntil() int64 {
+	if x != nil {
+		return x.BannedUntil
+	}
+	return 0
+}
+
+// AddressBook holds all the peers known by the client.
+type AddressBook struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Peers []*PeerRecord `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+}
+
+func (x *AddressBook) Reset() {
+	*x = AddressBook{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_net_gen_pb_address_book_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressBook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressBook) ProtoMessage() {}
+
+func (x *AddressBook) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_net_gen_pb_address_book_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressBook.ProtoReflect.Descriptor instead.
+func (*AddressBook) Descriptor() ([]byte, []int) {
+	return file_pkg_net_gen_pb_address_book_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddressBook) GetPeers() []*PeerRecord {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+// DatastoreSnapshot holds all the entries of a key-value datastore.
+type DatastoreSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries map[string][]byte `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *DatastoreSnapshot) Reset() {
+	*x = DatastoreSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_net_gen_pb_address_book_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatastoreSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatastoreSnapshot) ProtoMessage() {}
+
+func (x *DatastoreSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_net_gen_pb_address_book_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatastoreSnapshot.ProtoReflect.Descriptor instead.
+func (*DatastoreSnapshot) Descriptor() ([]byte, []int) {
+	return file_pkg_net_gen_pb_address_book_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DatastoreSnapshot) GetEntries() map[string][]byte {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+var File_pkg_net_gen_pb_address_book_proto protoreflect.FileDescriptor
+
+var file_pkg_net_gen_pb_address_book_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x70, 0x6b, 0x67, 0x2f, 0x6e, 0x65, 0x74, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x62,
+	0x2f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x62, 0x6f, 0x6f, 0x6b, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x03, 0x6e, 0x65, 0x74, 0x22, 0xda, 0x01, 0x0a, 0x0a, 0x50, 0x65, 0x65,
+	0x72, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x12,
+	0x1e, 0x0a, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x61, 0x64, 0x64, 0x72, 0x73, 0x12,
+	0x2c, 0x0a, 0x11, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x70,
+	0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0f, 0x72, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x55, 0x6e, 0x74,
+	0x69, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64,
+	0x55, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0x34, 0x0a, 0x0b, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x25, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x11,
+	0x44, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x12, 0x3d, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x1a, 0x3a, 0x0a, 0x0c, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x06, 0x5a, 0x04,
+	0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_net_gen_pb_address_book_proto_rawDescOnce sync.Once
+	file_pkg_net_gen_pb_address_book_proto_rawDescData = file_pkg_net_gen_pb_address_book_proto_rawDesc
+)
+
+func file_pkg_net_gen_pb_address_book_proto_rawDescGZIP() []byte {
+	file_pkg_net_gen_pb_address_book_proto_rawDescOnce.Do(func() {
+		file_pkg_net_gen_pb_address_book_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_net_gen_pb_address_book_proto_rawDescData)
+	})
+	return file_pkg_net_gen_pb_address_book_proto_rawDescData
+}
+
+var file_pkg_net_gen_pb_address_book_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_pkg_net_gen_pb_address_book_proto_goTypes = []interface{}{
+	(*PeerRecord)(nil),        // 0: net.PeerRecord
+	(*AddressBook)(nil),       // 1: net.AddressBook
+	(*DatastoreSnapshot)(nil), // 2: net.DatastoreSnapshot
+	nil,                       // 3: net.DatastoreSnapshot.EntriesEntry
+}
+var file_pkg_net_gen_pb_address_book_proto_depIdxs = []int32{
+	0, // 0: net.AddressBook.peers:type_name -> net.PeerRecord
+	3, // 1: net.DatastoreSnapshot.entries:type_name -> net.DatastoreSnapshot.EntriesEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_pkg_net_gen_pb_address_book_proto_init() }
+func file_pkg_net_gen_pb_address_book_proto_init() {
+	if File_pkg_net_gen_pb_address_book_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_net_gen_pb_address_book_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_net_gen_pb_address_book_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressBook); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_net_gen_pb_address_book_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatastoreSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_net_gen_pb_address_book_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_net_gen_pb_address_book_proto_goTypes,
+		DependencyIndexes: file_pkg_net_gen_pb_address_book_proto_depIdxs,
+		MessageInfos:      file_pkg_net_gen_pb_address_book_proto_msgTypes,
+	}.Build()
+	File_pkg_net_gen_pb_address_book_proto = out.File
+	file_pkg_net_gen_pb_address_book_proto_rawDesc = nil
+	file_pkg_net_gen_pb_address_book_proto_goTypes = nil
+	file_pkg_net_gen_pb_address_book_proto_depIdxs = nil
+}
@@ -12,6 +12,7 @@ func BasicMessage(
 	messageType string,
 	senderPublicKey []byte,
 	seqno uint64,
+	signature *net.MessageSignature,
 ) net.Message {
 	return &basicMessage{
 		transportSenderID,
@@ -19,6 +20,7 @@ func BasicMessage(
 		messageType,
 		senderPublicKey,
 		seqno,
+		signature,
 	}
 }
 
@@ -30,6 +32,7 @@ type basicMessage struct {
 	messageType       string
 	senderPublicKey   []byte
 	seqno             uint64
+	signature         *net.MessageSignature
 }
 
 func (m *basicMessage) TransportSenderID() net.TransportIdentifier {
@@ -51,3 +54,7 @@ func (m *basicMessage) SenderPublicKey() []byte {
 func (m *basicMessage) Seqno() uint64 {
 	return m.seqno
 }
+
+func (m *basicMessage) Signature() *net.MessageSignature {
+	return m.signature
+}
@@ -0,0 +1,512 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	dstore "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/libp2p/go-libp2p-core/host"
+	libp2pnet "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/gen/pb"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+const (
+	// AddressBookPersistTick is the amount of time between subsequent
+	// persists of the address book and the DHT datastore.
+	AddressBookPersistTick = 5 * time.Minute
+	// MaxKnownPeerAge is the maximum time since a peer was last seen
+	// connected for the peer to be kept in the address book.
+	MaxKnownPeerAge = 7 * 24 * time.Hour
+	// MaxKnownPeers is the maximum number of peers kept in the address book.
+	// The most recently seen peers are kept.
+	MaxKnownPeers = 200
+	// MaxLastKnownGoodPeers is the maximum number of last-known-good peers
+	// the client connects to on startup.
+	MaxLastKnownGoodPeers = 20
+)
+
+const (
+	addressBookFileName = "address_book"
+	datastoreFileName   = "dht_datastore"
+)
+
+// knownPeer holds the information about a peer the client was connected to.
+type knownPeer struct {
+	id                peer.ID
+	addrs             []ma.Multiaddr
+	operatorPublicKey []byte
+	lastSeen          time.Time
+}
+
+// addressBook keeps track of the peers the client was connected to and
+// persists them along with their reputation and the DHT datastore so that
+// the client can rejoin the network after a restart without relying solely
+// on the bootstrap peers. The data are persisted in a directory named after
+// the client's peer ID so that several clients can share the persistence.
+type addressBook struct {
+	persistence persistence.BasicHandle
+	directory   string
+
+	peersMutex sync.Mutex
+	peers      map[peer.ID]*knownPeer
+
+	now func() time.Time
+}
+
+func newAddressBook(
+	persistence persistence.BasicHandle,
+	id peer.ID,
+) *addressBook {
+	return &addressBook{
+		persistence: persistence,
+		directory:   id.String(),
+		peers:       make(map[peer.ID]*knownPeer),
+		now:         time.Now,
+	}
+}
+
+// load reads the persisted address book. The persisted reputation of peers
+// is restored in the given reputation store and the persisted DHT entries
+// are put into the given datastore.
+func (ab *addressBook) load(
+	ctx context.Context,
+	reputationStore *reputation.Store,
+	datastore dstore.Datastore,
+) error {
+	files, err := ab.readFiles()
+	if err != nil {
+		return err
+	}
+
+	if content, ok := files[addressBookFileName]; ok {
+		if err := ab.unmarshalAddressBook(content, reputationStore); err != nil {
+			return fmt.Errorf("cannot unmarshal address book: [%v]", err)
+		}
+	}
+
+	if content, ok := files[datastoreFileName]; ok {
+		if err := unmarshalDatastore(ctx, content, datastore); err != nil {
+			return fmt.Errorf("cannot unmarshal DHT datastore: [%v]", err)
+		}
+	}
+
+	logger.Infof("loaded [%v] known peers from the address book", len(ab.peers))
+
+	return nil
+}
+
+func (ab *addressBook) readFiles() (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	descriptorsChan, errorsChan := ab.persistence.ReadAll()
+
+	// Both channels are unbuffered and we do not know in which order they
+	// are written so they are read concurrently.
+	var readErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range descriptorsChan {
+			if descriptor.Directory() != ab.directory {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				readErr = fmt.Errorf(
+					"could not read file [%v]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			files[descriptor.Name()] = content
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChan {
+			logger.Warnf("could not read address book: [%v]", err)
+		}
+	}()
+
+	wg.Wait()
+
+	return files, readErr
+}
+
+func (ab *addressBook) unmarshalAddressBook(
+	content []byte,
+	reputationStore *reputation.Store,
+) error {
+	pbAddressBook := &pb.AddressBook{}
+	if err := proto.Unmarshal(content, pbAddressBook); err != nil {
+		return err
+	}
+
+	ab.peersMutex.Lock()
+	defer ab.peersMutex.Unlock()
+
+	for _, pbPeer := range pbAddressBook.Peers {
+		peerID, err := peer.IDFromBytes(pbPeer.PeerID)
+		if err != nil {
+			return fmt.Errorf("invalid peer ID: [%v]", err)
+		}
+
+		addrs := make([]ma.Multiaddr, 0, len(pbPeer.Multiaddrs))
+		for _, addrBytes := range pbPeer.Multiaddrs {
+			addr, err := ma.NewMultiaddrBytes(addrBytes)
+			if err != nil {
+				return fmt.Errorf(
+					"invalid multiaddress of peer [%v]: [%v]",
+					peerID,
+					err,
+				)
+			}
+			addrs = append(addrs, addr)
+		}
+
+		ab.peers[peerID] = &knownPeer{
+			id:                peerID,
+			addrs:             addrs,
+			operatorPublicKey: pbPeer.OperatorPublicKey,
+			lastSeen:          time.Unix(pbPeer.LastSeen, 0),
+		}
+
+		if pbPeer.ReputationScore != 0 || pbPeer.BannedUntil != 0 {
+			peerReputation := net.PeerReputation{
+				Peer:  peerID.String(),
+				Score: pbPeer.ReputationScore,
+			}
+			if pbPeer.BannedUntil != 0 {
+				peerReputation.BannedUntil = time.Unix(pbPeer.BannedUntil, 0)
+			}
+
+			reputationStore.Restore(peerReputation)
+		}
+	}
+
+	return nil
+}
+
+// update records all peers the host is currently connected to as seen now.
+// Peers not seen for longer than MaxKnownPeerAge are removed and only
+// MaxKnownPeers most recently seen peers are kept.
+func (ab *addressBook) update(host host.Host) {
+	ab.peersMutex.Lock()
+	defer ab.peersMutex.Unlock()
+
+	now := ab.now()
+
+	for _, peerID := range host.Network().Peers() {
+		if host.Network().Connectedness(peerID) != libp2pnet.Connected {
+			continue
+		}
+
+		addrs := host.Peerstore().Addrs(peerID)
+		if len(addrs) == 0 {
+			continue
+		}
+
+		knownPeer := &knownPeer{
+			id:       peerID,
+			addrs:    addrs,
+			lastSeen: now,
+		}
+
+		if publicKey, err := peerID.ExtractPublicKey(); err == nil {
+			operatorPublicKey, err := networkPublicKeyToOperatorPublicKey(
+				publicKey,
+			)
+			if err == nil {
+				knownPeer.operatorPublicKey = operator.MarshalUncompressed(
+					operatorPublicKey,
+				)
+			}
+		}
+
+		ab.peers[peerID] = knownPeer
+	}
+
+	for peerID, knownPeer := range ab.peers {
+		if now.Sub(knownPeer.lastSeen) > MaxKnownPeerAge {
+			delete(ab.peers, peerID)
+		}
+	}
+
+	if len(ab.peers) > MaxKnownPeers {
+		for _, knownPeer := range ab.sortedPeers()[MaxKnownPeers:] {
+			delete(ab.peers, knownPeer.id)
+		}
+	}
+}
+
+// sortedPeers returns known peers sorted by the last seen time starting
+// from the most recently seen. Must be called with the peers mutex held.
+func (ab *addressBook) sortedPeers() []*knownPeer {
+	peers := make([]*knownPeer, 0, len(ab.peers))
+	for _, knownPeer := range ab.peers {
+		peers = append(peers, knownPeer)
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		if peers[i].lastSeen.Equal(peers[j].lastSeen) {
+			return peers[i].id < peers[j].id
+		}
+		return peers[i].lastSeen.After(peers[j].lastSeen)
+	})
+
+	return peers
+}
+
+// lastKnownGoodPeers returns the most recently seen peers which are not
+// banned and do not have a negative reputation.
+func (ab *addressBook) lastKnownGoodPeers(
+	reputationStore *reputation.Store,
+) []peer.AddrInfo {
+	ab.peersMutex.Lock()
+	defer ab.peersMutex.Unlock()
+
+	peers := make([]peer.AddrInfo, 0)
+	for _, knownPeer := range ab.sortedPeers() {
+		if len(peers) == MaxLastKnownGoodPeers {
+			break
+		}
+
+		if reputationStore.IsBanned(knownPeer.id.String()) ||
+			reputationStore.Score(knownPeer.id.String()) < 0 {
+			continue
+		}
+
+		peers = append(peers, peer.AddrInfo{
+			ID:    knownPeer.id,
+			Addrs: knownPeer.addrs,
+		})
+	}
+
+	return peers
+}
+
+// save persists the address book along with the reputation of known peers
+// and all the entries of the given DHT datastore.
+func (ab *addressBook) save(
+	ctx context.Context,
+	reputationStore *reputation.Store,
+	datastore dstore.Datastore,
+) error {
+	addressBookBytes, err := ab.marshalAddressBook(reputationStore)
+	if err != nil {
+		return fmt.Errorf("cannot marshal address book: [%v]", err)
+	}
+
+	if err := ab.persistence.Save(
+		addressBookBytes,
+		ab.directory,
+		addressBookFileName,
+	); err != nil {
+		return fmt.Errorf("cannot save address book: [%v]", err)
+	}
+
+	datastoreBytes, err := marshalDatastore(ctx, datastore)
+	if err != nil {
+		return fmt.Errorf("cannot marshal DHT datastore: [%v]", err)
+	}
+
+	if err := ab.persistence.Save(
+		datastoreBytes,
+		ab.directory,
+		datastoreFileName,
+	); err != nil {
+		return fmt.Errorf("cannot save DHT datastore: [%v]", err)
+	}
+
+	return nil
+}
+
+func (ab *addressBook) marshalAddressBook(
+	reputationStore *reputation.Store,
+) ([]byte, error) {
+	reputations := make(map[string]net.PeerReputation)
+	for _, peerReputation := range reputationStore.Snapshot() {
+		reputations[peerReputation.Peer] = peerReputation
+	}
+
+	ab.peersMutex.Lock()
+	defer ab.peersMutex.Unlock()
+
+	pbAddressBook := &pb.AddressBook{
+		Peers: make([]*pb.PeerRecord, 0, len(ab.peers)),
+	}
+
+	for _, knownPeer := range ab.sortedPeers() {
+		peerIDBytes, err := knownPeer.id.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		multiaddrs := make([][]byte, len(knownPeer.addrs))
+		for i, addr := range knownPeer.addrs {
+			multiaddrs[i] = addr.Bytes()
+		}
+
+		pbPeer := &pb.PeerRecord{
+			PeerID:            peerIDBytes,
+			Multiaddrs:        multiaddrs,
+			OperatorPublicKey: knownPeer.operatorPublicKey,
+			LastSeen:          knownPeer.lastSeen.Unix(),
+		}
+
+		if peerReputation, ok := reputations[knownPeer.id.String()]; ok {
+			pbPeer.ReputationScore = peerReputation.Score
+			if !peerReputation.BannedUntil.IsZero() {
+				pbPeer.BannedUntil = peerReputation.BannedUntil.Unix()
+			}
+		}
+
+		pbAddressBook.Peers = append(pbAddressBook.Peers, pbPeer)
+	}
+
+	return proto.Marshal(pbAddressBook)
+}
+
+// persistPeriodically updates and persists the address book every
+// AddressBookPersistTick and once the context is done.
+func (ab *addressBook) persistPeriodically(
+	ctx context.Context,
+	host host.Host,
+	reputationStore *reputation.Store,
+	datastore dstore.Datastore,
+) {
+	ticker := time.NewTicker(AddressBookPersistTick)
+	defer ticker.Stop()
+
+	persist := func() {
+		ab.update(host)
+
+		// The context may be already done so the datastore is queried with
+		// a fresh one.
+		if err := ab.save(
+			context.Background(),
+			reputationStore,
+			datastore,
+		); err != nil {
+			logger.Errorf("failed to persist address book: [%v]", err)
+		}
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			persist()
+		case <-ctx.Done():
+			persist()
+			return
+		}
+	}
+}
+
+// connectLastKnownGoodPeers connects the host to the given peers. It
+// returns the number of peers the connection was established with.
+func connectLastKnownGoodPeers(
+	ctx context.Context,
+	host host.Host,
+	peers []peer.AddrInfo,
+) int {
+	ctx, cancel := context.WithTimeout(
+		ctx,
+		DefaultBootstrapConfig.ConnectionTimeout,
+	)
+	defer cancel()
+
+	var connected int
+	var connectedMutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, p := range peers {
+		wg.Add(1)
+		go func(p peer.AddrInfo) {
+			defer wg.Done()
+
+			host.Peerstore().AddAddrs(p.ID, p.Addrs, peerstore.RecentlyConnectedAddrTTL)
+
+			if err := host.Connect(ctx, p); err != nil {
+				logger.Debugf(
+					"could not connect last-known-good peer [%v]: [%v]",
+					p.ID,
+					err,
+				)
+				return
+			}
+
+			connectedMutex.Lock()
+			connected++
+			connectedMutex.Unlock()
+		}(p)
+	}
+
+	wg.Wait()
+
+	return connected
+}
+
+func marshalDatastore(
+	ctx context.Context,
+	datastore dstore.Datastore,
+) ([]byte, error) {
+	results, err := datastore.Query(ctx, query.Query{})
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := results.Rest()
+	if err != nil {
+		return nil, err
+	}
+
+	pbSnapshot := &pb.DatastoreSnapshot{
+		Entries: make(map[string][]byte, len(entries)),
+	}
+	for _, entry := range entries {
+		pbSnapshot.Entries[entry.Key] = entry.Value
+	}
+
+	return proto.Marshal(pbSnapshot)
+}
+
+func unmarshalDatastore(
+	ctx context.Context,
+	content []byte,
+	datastore dstore.Datastore,
+) error {
+	pbSnapshot := &pb.DatastoreSnapshot{}
+	if err := proto.Unmarshal(content, pbSnapshot); err != nil {
+		return err
+	}
+
+	for key, value := range pbSnapshot.Entries {
+		if err := datastore.Put(ctx, dstore.NewKey(key), value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package libp2p
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	dstore "github.com/ipfs/go-datastore"
+	"github.com/keep-network/keep-common/pkg/persistence"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestAddressBook_SaveLoad(t *testing.T) {
+	ctx := context.Background()
+	handle := newMockPersistenceHandle()
+	clientID := generatePeerID(t)
+
+	peer1 := newTestKnownPeer(t, "/ip4/10.0.0.1/tcp/3919", time.Unix(1000, 0))
+	peer2 := newTestKnownPeer(t, "/ip4/10.0.0.2/tcp/3919", time.Unix(2000, 0))
+
+	book := newAddressBook(handle, clientID)
+	book.peers[peer1.id] = peer1
+	book.peers[peer2.id] = peer2
+
+	reputationStore := reputation.NewStore(reputation.DefaultParameters())
+	reputationStore.Record(peer2.id.String(), net.InvalidMessageEvent)
+
+	datastore := dstore.NewMapDatastore()
+	if err := datastore.Put(ctx, dstore.NewKey("/providers/abc"), []byte{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := book.save(ctx, reputationStore, datastore); err != nil {
+		t.Fatal(err)
+	}
+
+	loadedBook := newAddressBook(handle, clientID)
+	loadedReputationStore := reputation.NewStore(reputation.DefaultParameters())
+	loadedDatastore := dstore.NewMapDatastore()
+
+	if err := loadedBook.load(
+		ctx,
+		loadedReputationStore,
+		loadedDatastore,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(book.peers, loadedBook.peers) {
+		t.Errorf(
+			"unexpected peers\nexpected: [%v]\nactual:   [%v]",
+			book.peers,
+			loadedBook.peers,
+		)
+	}
+
+	if score := loadedReputationStore.Score(peer2.id.String()); score >= 0 {
+		t.Errorf("unexpected reputation score: [%v]", score)
+	}
+
+	value, err := loadedDatastore.Get(ctx, dstore.NewKey("/providers/abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]byte{1, 2}, value) {
+		t.Errorf("unexpected datastore value: [%v]", value)
+	}
+
+	// Address book of another client sharing the persistence is empty.
+	otherBook := newAddressBook(handle, generatePeerID(t))
+	if err := otherBook.load(
+		ctx,
+		reputation.NewStore(reputation.DefaultParameters()),
+		dstore.NewMapDatastore(),
+	); err != nil {
+		t.Fatal(err)
+	}
+	if len(otherBook.peers) != 0 {
+		t.Errorf("unexpected number of peers: [%v]", len(otherBook.peers))
+	}
+}
+
+func TestAddressBook_LastKnownGoodPeers(t *testing.T) {
+	book := newAddressBook(newMockPersistenceHandle(), generatePeerID(t))
+
+	peer1 := newTestKnownPeer(t, "/ip4/10.0.0.1/tcp/3919", time.Unix(1000, 0))
+	peer2 := newTestKnownPeer(t, "/ip4/10.0.0.2/tcp/3919", time.Unix(3000, 0))
+	peer3 := newTestKnownPeer(t, "/ip4/10.0.0.3/tcp/3919", time.Unix(2000, 0))
+	peer4 := newTestKnownPeer(t, "/ip4/10.0.0.4/tcp/3919", time.Unix(4000, 0))
+
+	for _, knownPeer := range []*knownPeer{peer1, peer2, peer3, peer4} {
+		book.peers[knownPeer.id] = knownPeer
+	}
+
+	reputationStore := reputation.NewStore(reputation.DefaultParameters())
+	// Peer 3 has a bad reputation and peer 4 is banned.
+	reputationStore.Record(peer3.id.String(), net.InvalidMessageEvent)
+	reputationStore.Restore(net.PeerReputation{
+		Peer:        peer4.id.String(),
+		BannedUntil: time.Now().Add(time.Hour),
+	})
+
+	expectedPeers := []peer.AddrInfo{
+		{ID: peer2.id, Addrs: peer2.addrs},
+		{ID: peer1.id, Addrs: peer1.addrs},
+	}
+
+	peers := book.lastKnownGoodPeers(reputationStore)
+	if !reflect.DeepEqual(expectedPeers, peers) {
+		t.Errorf(
+			"unexpected peers\nexpected: [%v]\nactual:   [%v]",
+			expectedPeers,
+			peers,
+		)
+	}
+}
+
+func generatePeerID(t *testing.T) peer.ID {
+	_, operatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPublicKey, err := operatorPublicKeyToNetworkPublicKey(
+		operatorPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peerID, err := peer.IDFromPublicKey(networkPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return peerID
+}
+
+func newTestKnownPeer(t *testing.T, address string, lastSeen time.Time) *knownPeer {
+	addr, err := ma.NewMultiaddr(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &knownPeer{
+		id:                generatePeerID(t),
+		addrs:             []ma.Multiaddr{addr},
+		operatorPublicKey: []byte{4, 1, 2},
+		lastSeen:          lastSeen,
+	}
+}
+
+type mockPersistenceHandle struct {
+	files map[string]map[string][]byte
+}
+
+func newMockPersistenceHandle() *mockPersistenceHandle {
+	return &mockPersistenceHandle{
+		files: make(map[string]map[string][]byte),
+	}
+}
+
+func (mph *mockPersistenceHandle) Save(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	if _, ok := mph.files[directory]; !ok {
+		mph.files[directory] = make(map[string][]byte)
+	}
+
+	mph.files[directory][name] = data
+	return nil
+}
+
+func (mph *mockPersistenceHandle) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	dataChan := make(chan persistence.DataDescriptor, 10)
+	errorChan := make(chan error)
+
+	for directory, files := range mph.files {
+		for name, data := range files {
+			dataChan <- &mockDataDescriptor{directory, name, data}
+		}
+	}
+
+	close(dataChan)
+	close(errorChan)
+
+	return dataChan, errorChan
+}
+
+func (mph *mockPersistenceHandle) Delete(directory string, name string) error {
+	delete(mph.files[directory], name)
+	return nil
+}
+
+type mockDataDescriptor struct {
+	directory string
+	name      string
+	content   []byte
+}
+
+func (mdd *mockDataDescriptor) Name() string {
+	return mdd.name
+}
+
+func (mdd *mockDataDescriptor) Directory() string {
+	return mdd.directory
+}
+
+func (mdd *mockDataDescriptor) Content() ([]byte, error) {
+	return mdd.content, nil
+}
@@ -3,6 +3,7 @@ package libp2p
 import (
 	"bufio"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -38,6 +39,13 @@ type authenticatedConnection struct {
 	firewall keepNet.Firewall
 
 	protocol string
+	version  handshake.Version
+
+	// remoteVersion is the version announced by the remote peer and
+	// capabilities are the capabilities negotiated for the connection. Both
+	// are set once the handshake completes successfully.
+	remoteVersion handshake.Version
+	capabilities  []string
 
 	pipe pipe
 }
@@ -85,6 +93,7 @@ func newAuthenticatedInboundConnection(
 	privateKey libp2pcrypto.PrivKey,
 	firewall keepNet.Firewall,
 	protocol string,
+	version handshake.Version,
 ) (*authenticatedConnection, error) {
 	ac := &authenticatedConnection{
 		Conn:                unauthenticatedConn,
@@ -92,11 +101,14 @@ func newAuthenticatedInboundConnection(
 		localPeerPrivateKey: privateKey,
 		firewall:            firewall,
 		protocol:            protocol,
+		version:             version,
 	}
 
 	ac.initializePipe()
 
 	if err := ac.runHandshakeAsResponder(); err != nil {
+		ac.logIncompatibleVersion(err)
+
 		// close the conn before returning (if it hasn't already)
 		// otherwise we leak.
 		if closeErr := ac.Close(); closeErr != nil {
@@ -129,6 +141,7 @@ func newAuthenticatedOutboundConnection(
 	remotePeerID peer.ID,
 	firewall keepNet.Firewall,
 	protocol string,
+	version handshake.Version,
 ) (*authenticatedConnection, error) {
 	remotePublicKey, err := remotePeerID.ExtractPublicKey()
 	if err != nil {
@@ -146,11 +159,14 @@ func newAuthenticatedOutboundConnection(
 		remotePeerPublicKey: remotePublicKey,
 		firewall:            firewall,
 		protocol:            protocol,
+		version:             version,
 	}
 
 	ac.initializePipe()
 
 	if err := ac.runHandshakeAsInitiator(); err != nil {
+		ac.logIncompatibleVersion(err)
+
 		if closeErr := ac.Close(); closeErr != nil {
 			logger.Debugf("could not close the connection: [%v]", closeErr)
 		}
@@ -169,6 +185,19 @@ func newAuthenticatedOutboundConnection(
 	return ac, nil
 }
 
+// logIncompatibleVersion logs a warning if the handshake failed because
+// the remote peer runs an incompatible protocol version. Other handshake
+// errors are expected to happen frequently and are not logged.
+func (ac *authenticatedConnection) logIncompatibleVersion(err error) {
+	if errors.Is(err, handshake.ErrIncompatibleProtocolVersion) {
+		logger.Warnf(
+			"rejecting connection with [%v]: [%v]",
+			ac.RemoteAddr(),
+			err,
+		)
+	}
+}
+
 func (ac *authenticatedConnection) checkFirewallRules() error {
 	operatorPublicKey, err := networkPublicKeyToOperatorPublicKey(ac.remotePeerPublicKey)
 	if err != nil {
@@ -188,7 +217,7 @@ func (ac *authenticatedConnection) runHandshakeAsInitiator() error {
 	// Act 1
 	//
 
-	initiatorAct1, err := handshake.InitiateHandshake(ac.protocol)
+	initiatorAct1, err := handshake.InitiateHandshake(ac.protocol, ac.version)
 	if err != nil {
 		return err
 	}
@@ -231,6 +260,9 @@ func (ac *authenticatedConnection) runHandshakeAsInitiator() error {
 		return err
 	}
 
+	ac.remoteVersion = initiatorAct3.RemoteVersion()
+	ac.capabilities = initiatorAct3.Capabilities()
+
 	return nil
 }
 
@@ -311,7 +343,11 @@ func (ac *authenticatedConnection) runHandshakeAsResponder() error {
 		return err
 	}
 
-	responderAct2, err := handshake.AnswerHandshake(act1Message, ac.protocol)
+	responderAct2, err := handshake.AnswerHandshake(
+		act1Message,
+		ac.protocol,
+		ac.version,
+	)
 	if err != nil {
 		return err
 	}
@@ -343,6 +379,9 @@ func (ac *authenticatedConnection) runHandshakeAsResponder() error {
 		return err
 	}
 
+	ac.remoteVersion = responderAct3.RemoteVersion()
+	ac.capabilities = responderAct3.Capabilities()
+
 	return nil
 }
 
@@ -63,6 +63,7 @@ func TestPinnedAndMessageKeyMismatch(t *testing.T) {
 		responder.networkPrivateKey,
 		firewall,
 		protocolKeep,
+		localVersion(),
 	)
 	if err == nil {
 		t.Fatal("should not have successfully completed handshake")
@@ -74,7 +75,7 @@ func TestPinnedAndMessageKeyMismatch(t *testing.T) {
 // peer-pinning should ensure that a malicious peer can't hijack a connection
 // after the first act and sign subsequent messages.
 func maliciousInitiatorHijacksHonestRun(t *testing.T, ac *authenticatedConnection) {
-	initiatorAct1, err := handshake.InitiateHandshake(protocolKeep)
+	initiatorAct1, err := handshake.InitiateHandshake(protocolKeep, localVersion())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -168,6 +169,73 @@ func TestHandshake(t *testing.T) {
 	}
 }
 
+func TestHandshakeVersionNegotiation(t *testing.T) {
+	initiator := createTestConnectionConfig(t)
+	responder := createTestConnectionConfig(t)
+
+	firewall := newMockFirewall()
+	firewall.updatePeer(initiator.networkPublicKey, true)
+	firewall.updatePeer(responder.networkPublicKey, true)
+
+	authnInboundConn, authnOutboundConn, inboundError, outboundError :=
+		connectInitiatorAndResponder(initiator, responder, firewall, t)
+	if inboundError != nil {
+		t.Fatal(inboundError)
+	}
+	if outboundError != nil {
+		t.Fatal(outboundError)
+	}
+
+	for _, connection := range []*authenticatedConnection{
+		authnInboundConn,
+		authnOutboundConn,
+	} {
+		if !reflect.DeepEqual(localVersion(), connection.remoteVersion) {
+			t.Errorf(
+				"unexpected remote version\nexpected: [%v]\nactual:   [%v]",
+				localVersion(),
+				connection.remoteVersion,
+			)
+		}
+		if !reflect.DeepEqual(localCapabilities, connection.capabilities) {
+			t.Errorf(
+				"unexpected capabilities\nexpected: [%v]\nactual:   [%v]",
+				localCapabilities,
+				connection.capabilities,
+			)
+		}
+	}
+
+	versions := newPeerVersions()
+	versions.set(
+		authnOutboundConn.remotePeerID,
+		authnOutboundConn.remoteVersion,
+		authnOutboundConn.capabilities,
+	)
+
+	expectedPeerVersion := keepNet.PeerVersion{
+		ProtocolVersion:    ProtocolVersion,
+		MinProtocolVersion: MinProtocolVersion,
+		Capabilities:       localCapabilities,
+	}
+	peerVersion, ok := versions.get(responder.peerID)
+	if !ok {
+		t.Fatal("peer version is not known")
+	}
+	if !reflect.DeepEqual(expectedPeerVersion, peerVersion) {
+		t.Errorf(
+			"unexpected peer version\nexpected: [%v]\nactual:   [%v]",
+			expectedPeerVersion,
+			peerVersion,
+		)
+	}
+
+	versions.remove(responder.peerID)
+	if _, ok := versions.get(responder.peerID); ok {
+		t.Error("peer version should not be known")
+	}
+}
+
 func TestHandshakeInitiatorBlockedByFirewallRules(t *testing.T) {
 	_, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
@@ -257,6 +325,7 @@ func connectInitiatorAndResponder(
 			responderPeerID,
 			firewall,
 			protocolKeep,
+			localVersion(),
 		)
 		done <- struct{}{}
 	}(initiatorConn, initiator.peerID, initiator.networkPrivateKey, responder.peerID)
@@ -267,6 +336,7 @@ func connectInitiatorAndResponder(
 		responder.networkPrivateKey,
 		firewall,
 		protocolKeep,
+		localVersion(),
 	)
 
 	<-done // handshake is done
@@ -6,6 +6,7 @@ import (
 	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
@@ -43,6 +44,7 @@ type validator interface {
 
 type publisher interface {
 	Publish(ctx context.Context, data []byte, opts ...pubsub.PubOpt) error
+	ListPeers() []peer.ID
 }
 
 type channel struct {
@@ -51,6 +53,8 @@ type channel struct {
 	// Must be declared at the top of the struct!
 	// See: https://golang.org/pkg/sync/atomic/#pkg-note-BUG
 	counter uint64
+	// channel-scoped atomic counter of dropped messages
+	droppedMessages uint64
 
 	name string
 
@@ -66,6 +70,10 @@ type channel struct {
 	subscription         *pubsub.Subscription
 	incomingMessageQueue chan *pubsub.Message
 
+	// cancelWorkers stops the subscription and message workers of the
+	// channel once the channel is closed.
+	cancelWorkers context.CancelFunc
+
 	messageHandlersMutex sync.Mutex
 	messageHandlers      []*messageHandler
 
@@ -73,11 +81,71 @@ type channel struct {
 	unmarshalersByType map[string]func() net.TaggedUnmarshaler
 
 	retransmissionTicker *retransmission.Ticker
+	retransmissions      retransmission.Scheduler
+
+	reputation *peerReputation
+
+	// peerVersions holds versions of the connected peers. Payloads of
+	// outgoing messages above CompressionThreshold are compressed only if
+	// all peers subscribed to the channel negotiated compression.
+	peerVersions *peerVersions
+
+	sizeLimitsMutex sync.RWMutex
+	maxMessageSize  int
+	maxPayloadSizes map[string]int
+
+	rateLimiter *messageRateLimiter
+
+	// disableMessageSignatures determines whether messages published to
+	// the channel are sent without the operator signature.
+	disableMessageSignatures bool
+
+	traffic trafficStats
 }
 
 type messageHandler struct {
+	// handler-scoped atomic counter of dropped messages
+	//
+	// Must be declared at the top of the struct!
+	// See: https://golang.org/pkg/sync/atomic/#pkg-note-BUG
+	droppedMessages uint64
+
 	ctx     context.Context
 	channel chan net.Message
+	options *net.RecvOptions
+}
+
+// deliver passes the message to the handler according to the handler's
+// delivery mode. It returns false if the message was dropped.
+func (mh *messageHandler) deliver(message net.Message) bool {
+	select {
+	case mh.channel <- message:
+		return true
+	default:
+	}
+
+	if mh.options.DeliveryMode == net.BlockingDelivery {
+		timer := time.NewTimer(net.MaxBlockingDeliveryTime)
+		defer timer.Stop()
+
+		select {
+		case mh.channel <- message:
+			return true
+		case <-mh.ctx.Done():
+			// The handler is being removed and does not expect any more
+			// messages so the message is not considered dropped.
+			return true
+		case <-timer.C:
+		}
+	}
+
+	atomic.AddUint64(&mh.droppedMessages, 1)
+
+	if mh.options.OnDrop != nil {
+		mh.options.OnDrop(message)
+	}
+
+	return false
 }
 
 func (c *channel) nextSeqno() uint64 {
@@ -89,26 +157,37 @@ func (c *channel) Name() string {
 }
 
 func (c *channel) Send(ctx context.Context, message net.TaggedMarshaler) error {
-	messageProto, err := c.messageProto(message)
+	messageProto, err := c.messageProto(message, c.nextSeqno())
 	if err != nil {
 		return err
 	}
 
-	messageProto.SequenceNumber = c.nextSeqno()
-
 	doSend := func() error {
 		return c.publish(messageProto)
 	}
 
-	retransmission.ScheduleRetransmissions(ctx, logger, c.retransmissionTicker, doSend)
+	c.retransmissions.Schedule(ctx, logger, c.retransmissionTicker, doSend)
 
 	return doSend()
 }
 
-func (c *channel) Recv(ctx context.Context, handler func(m net.Message)) {
+// RetransmitNow retransmits all messages sent over the channel whose send
+// contexts are not done yet, without waiting for the next retransmission tick.
+func (c *channel) RetransmitNow() {
+	c.retransmissions.RetransmitNow()
+}
+
+func (c *channel) Recv(
+	ctx context.Context,
+	handler func(m net.Message),
+	options ...net.RecvOption,
+) {
+	recvOptions := net.NewRecvOptions(messageHandlerThrottle, options...)
+
 	messageHandler := &messageHandler{
 		ctx:     ctx,
-		channel: make(chan net.Message, messageHandlerThrottle),
+		channel: make(chan net.Message, recvOptions.BufferSize),
+		options: recvOptions,
 	}
 
 	c.messageHandlersMutex.Lock()
@@ -123,6 +202,16 @@ func (c *channel) Recv(ctx context.Context, handler func(m net.Message)) {
 			case <-ctx.Done():
 				logger.Debug("context is done; removing message handler")
 				c.removeHandler(messageHandler)
+
+				if dropped := atomic.LoadUint64(
+					&messageHandler.droppedMessages,
+				); dropped > 0 {
+					logger.Warningf(
+						"message handler of channel [%v] dropped [%v] messages",
+						c.name,
+						dropped,
+					)
+				}
 				return
 
 			case msg := <-messageHandler.channel:
@@ -167,36 +256,174 @@ func (c *channel) SetUnmarshaler(unmarshaler func() net.TaggedUnmarshaler) {
 	c.unmarshalersByType[tpe] = unmarshaler
 }
 
+// SetMaxMessageSize sets the maximum size in bytes of messages transmitted
+// over the channel, as sent over the wire. The limit can not exceed
+// DefaultMaxMessageSize enforced by the pubsub router.
+func (c *channel) SetMaxMessageSize(maxSize int) {
+	c.sizeLimitsMutex.Lock()
+	defer c.sizeLimitsMutex.Unlock()
+
+	c.maxMessageSize = maxSize
+}
+
+// SetMaxPayloadSize sets the maximum size in bytes of the uncompressed
+// payload of messages of the given type.
+func (c *channel) SetMaxPayloadSize(messageType string, maxSize int) {
+	c.sizeLimitsMutex.Lock()
+	defer c.sizeLimitsMutex.Unlock()
+
+	if c.maxPayloadSizes == nil {
+		c.maxPayloadSizes = make(map[string]int)
+	}
+
+	c.maxPayloadSizes[messageType] = maxSize
+}
+
+// SetRateLimit sets the rate limit of messages published to the channel by
+// every peer. Messages exceeding the limit are rejected by the topic
+// validator, before they reach the incoming message queue.
+func (c *channel) SetRateLimit(limit net.RateLimit) {
+	c.rateLimiter.setLimit(limit)
+}
+
+// SetMessageQuota sets the maximum number of distinct messages of the given
+// type a peer can publish within a single quota scope. Quota scopes are
+// determined by payloads implementing net.QuotaScopedMessage.
+func (c *channel) SetMessageQuota(messageType string, quota int) {
+	c.rateLimiter.setQuota(messageType, quota)
+}
+
+func (c *channel) messageSizeLimit() int {
+	c.sizeLimitsMutex.RLock()
+	defer c.sizeLimitsMutex.RUnlock()
+
+	if c.maxMessageSize <= 0 || c.maxMessageSize > DefaultMaxMessageSize {
+		return DefaultMaxMessageSize
+	}
+
+	return c.maxMessageSize
+}
+
+func (c *channel) payloadSizeLimit(messageType string) int {
+	c.sizeLimitsMutex.RLock()
+	defer c.sizeLimitsMutex.RUnlock()
+
+	if maxSize, ok := c.maxPayloadSizes[messageType]; ok && maxSize > 0 {
+		return maxSize
+	}
+
+	return DefaultMaxPayloadSize
+}
+
 func (c *channel) messageProto(
 	message net.TaggedMarshaler,
+	seqno uint64,
 ) (*pb.BroadcastNetworkMessage, error) {
 	payloadBytes, err := message.Marshal()
 	if err != nil {
 		return nil, err
 	}
 
+	if limit := c.payloadSizeLimit(message.Type()); len(payloadBytes) > limit {
+		return nil, fmt.Errorf(
+			"payload of message of type [%v] has [%v] bytes and exceeds "+
+				"the limit of [%v] bytes",
+			message.Type(),
+			len(payloadBytes),
+			limit,
+		)
+	}
+
+	// The uncompressed payload is signed so that the signature can be
+	// verified independently of the way the message was transmitted.
+	var signature []byte
+	if !c.disableMessageSignatures {
+		signature, err = c.clientIdentity.privKey.Sign(
+			net.MessageSignedBytes(
+				c.name,
+				message.Type(),
+				seqno,
+				payloadBytes,
+			),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not sign message: [%v]", err)
+		}
+	}
+
+	compressed := false
+	if len(payloadBytes) >= CompressionThreshold && c.compressionSupported() {
+		compressedPayloadBytes, err := compress(payloadBytes)
+		if err != nil {
+			return nil, fmt.Errorf("could not compress payload: [%v]", err)
+		}
+
+		if len(compressedPayloadBytes) < len(payloadBytes) {
+			payloadBytes = compressedPayloadBytes
+			compressed = true
+		}
+	}
+
 	senderIdentityBytes, err := c.clientIdentity.Marshal()
 	if err != nil {
 		return nil, err
 	}
 
 	return &pb.BroadcastNetworkMessage{
-		Payload: payloadBytes,
-		Sender:  senderIdentityBytes,
-		Type:    []byte(message.Type()),
+		Payload:        payloadBytes,
+		Sender:         senderIdentityBytes,
+		Type:           []byte(message.Type()),
+		SequenceNumber: seqno,
+		Compressed:     compressed,
+		Signature:      signature,
 	}, nil
 }
 
+// compressionSupported checks whether all peers subscribed to the channel
+// are able to decompress message payloads. Peers running older protocol
+// versions are still accepted in the network so the payload is compressed
+// only if every peer the message is published to negotiated the compression
+// capability. Since messages are flood-published to all the peers
+// subscribed to the channel and group members connect with each other
+// directly, this covers the recipients of protocol messages.
+func (c *channel) compressionSupported() bool {
+	if c.peerVersions == nil {
+		return false
+	}
+
+	c.publisherMutex.Lock()
+	peers := c.publisher.ListPeers()
+	c.publisherMutex.Unlock()
+
+	return c.peerVersions.supportCapability(peers, compressionCapability)
+}
+
 func (c *channel) publish(message *pb.BroadcastNetworkMessage) error {
 	messageBytes, err := proto.Marshal(message)
 	if err != nil {
 		return err
 	}
 
+	if limit := c.messageSizeLimit(); len(messageBytes) > limit {
+		return fmt.Errorf(
+			"message of type [%s] has [%v] bytes and exceeds "+
+				"the limit of [%v] bytes",
+			message.Type,
+			len(messageBytes),
+			limit,
+		)
+	}
+
 	c.publisherMutex.Lock()
 	defer c.publisherMutex.Unlock()
 
-	return c.publisher.Publish(context.TODO(), messageBytes)
+	if err := c.publisher.Publish(context.TODO(), messageBytes); err != nil {
+		return err
+	}
+
+	c.traffic.sent(c.name, string(message.Type), len(messageBytes))
+
+	return nil
 }
 
 func (c *channel) handleMessages(ctx context.Context) {
@@ -220,13 +447,20 @@ func (c *channel) subscriptionWorker(ctx context.Context) {
 		default:
 			message, err := c.subscription.Next(ctx)
 			if err != nil {
-				logger.Error(err)
+				// The error is expected when the channel is being closed.
+				if ctx.Err() == nil {
+					logger.Error(err)
+				}
 				continue
 			}
 
 			select {
 			case c.incomingMessageQueue <- message:
 			default:
+				// The queue is full because this client is not able to
+				// keep up with processing incoming messages. This is not
+				// the author's fault so the author is not reported.
+				atomic.AddUint64(&c.droppedMessages, 1)
 				logger.Warningf("message workers are too slow; dropping message")
 			}
 		}
@@ -240,19 +474,75 @@ func (c *channel) incomingMessageWorker(ctx context.Context) {
 			return
 		case msg := <-c.incomingMessageQueue:
 			if err := c.processPubsubMessage(msg); err != nil {
+				event, ok := reputationEventFor(err)
+				if !ok {
+					// Errors not attributable to the author, e.g.
+					// messages of types not registered yet, are
+					// expected during normal operation.
+					logger.Debug(err)
+					continue
+				}
+
 				logger.Error(err)
+				c.reportPeer(msg.GetFrom(), event)
 			}
 		}
 	}
 }
 
 func (c *channel) processPubsubMessage(pubsubMessage *pubsub.Message) error {
+	if limit := c.messageSizeLimit(); len(pubsubMessage.Data) > limit {
+		return fmt.Errorf(
+			"message has [%v] bytes and exceeds the limit of [%v] bytes",
+			len(pubsubMessage.Data),
+			limit,
+		)
+	}
+
 	var messageProto pb.BroadcastNetworkMessage
 	if err := proto.Unmarshal(pubsubMessage.Data, &messageProto); err != nil {
+		return &malformedMessageError{pubsubMessage.GetFrom(), err}
+	}
+
+	if err := c.processContainerMessage(
+		pubsubMessage.GetFrom(),
+		messageProto,
+	); err != nil {
 		return err
 	}
 
-	return c.processContainerMessage(pubsubMessage.GetFrom(), messageProto)
+	// Only messages of known types are recorded so that the statistics
+	// can not be flooded with arbitrary message types.
+	c.traffic.received(
+		c.name,
+		string(messageProto.Type),
+		len(pubsubMessage.Data),
+	)
+
+	return nil
+}
+
+// messagePayload returns the uncompressed payload of the message. It
+// returns an error if the uncompressed payload exceeds the limit for the
+// message type.
+func (c *channel) messagePayload(
+	message *pb.BroadcastNetworkMessage,
+) ([]byte, error) {
+	limit := c.payloadSizeLimit(string(message.Type))
+
+	if message.Compressed {
+		return decompress(message.Payload, limit)
+	}
+
+	if len(message.Payload) > limit {
+		return nil, fmt.Errorf(
+			"payload has [%v] bytes and exceeds the limit of [%v] bytes",
+			len(message.Payload),
+			limit,
+		)
+	}
+
+	return message.Payload, nil
 }
 
 func (c *channel) processContainerMessage(
@@ -266,8 +556,17 @@ func (c *channel) processContainerMessage(
 		return err
 	}
 
-	if err := unmarshaled.Unmarshal(message.GetPayload()); err != nil {
-		return err
+	payload, err := c.messagePayload(&message)
+	if err != nil {
+		return fmt.Errorf(
+			"invalid payload of message of type [%s]: [%v]",
+			message.Type,
+			err,
+		)
+	}
+
+	if err := unmarshaled.Unmarshal(payload); err != nil {
+		return &malformedMessageError{proposedSender, err}
 	}
 
 	// Construct an identifier from the sender.
@@ -280,11 +579,7 @@ func (c *channel) processContainerMessage(
 	//     Test that the proposed sender (outer layer) matches the
 	//     sender identifier we grab from the message (inner layer).
 	if proposedSender != senderIdentifier.id {
-		return fmt.Errorf(
-			"outer layer sender [%v] does not match inner layer sender [%v]",
-			proposedSender,
-			senderIdentifier,
-		)
+		return &senderMismatchError{proposedSender, senderIdentifier.id}
 	}
 
 	operatorPublicKey, err := networkPublicKeyToOperatorPublicKey(senderIdentifier.pubKey)
@@ -296,14 +591,41 @@ func (c *channel) processContainerMessage(
 		)
 	}
 
+	if err := c.checkQuota(
+		senderIdentifier.id,
+		unmarshaled,
+		message.SequenceNumber,
+	); err != nil {
+		return err
+	}
+
 	operatorPublicKeyBytes := operator.MarshalUncompressed(operatorPublicKey)
 
+	// Signatures are optional so that messages of peers not signing them
+	// are still accepted. Invalid signatures are never accepted.
+	var signature *net.MessageSignature
+	if len(message.Signature) > 0 {
+		signature = &net.MessageSignature{
+			Channel:         c.name,
+			Type:            string(message.Type),
+			Seqno:           message.SequenceNumber,
+			Payload:         payload,
+			SenderPublicKey: operatorPublicKeyBytes,
+			Signature:       message.Signature,
+		}
+
+		if err := signature.Verify(); err != nil {
+			return &invalidSignatureError{senderIdentifier.id, err}
+		}
+	}
+
 	netMessage := internal.BasicMessage(
 		senderIdentifier.id,
 		unmarshaled,
 		string(message.Type),
 		operatorPublicKeyBytes,
 		message.SequenceNumber,
+		signature,
 	)
 
 	c.deliver(netMessage)
@@ -311,16 +633,118 @@ func (c *channel) processContainerMessage(
 	return nil
 }
 
+// checkQuota counts the message against the quota of its type, within
+// the scope determined by the message payload.
+func (c *channel) checkQuota(
+	author peer.ID,
+	payload net.TaggedUnmarshaler,
+	seqno uint64,
+) error {
+	if c.rateLimiter == nil {
+		return nil
+	}
+
+	scope := ""
+	if scopedPayload, ok := payload.(net.QuotaScopedMessage); ok {
+		scope = scopedPayload.QuotaScope()
+	}
+
+	return c.rateLimiter.checkQuota(author, payload.Type(), scope, seqno)
+}
+
+// senderMismatchError is returned when the sender declared in the message
+// does not match the author of the pubsub message.
+type senderMismatchError struct {
+	proposedSender peer.ID
+	actualSender   peer.ID
+}
+
+func (sme *senderMismatchError) Error() string {
+	return fmt.Sprintf(
+		"outer layer sender [%v] does not match inner layer sender [%v]",
+		sme.proposedSender,
+		sme.actualSender,
+	)
+}
+
+// invalidSignatureError is returned when the operator signature of
+// the message is invalid.
+type invalidSignatureError struct {
+	sender peer.ID
+	err    error
+}
+
+func (ise *invalidSignatureError) Error() string {
+	return fmt.Sprintf(
+		"invalid signature of message from [%v]: [%v]",
+		ise.sender,
+		ise.err,
+	)
+}
+
+// malformedMessageError is returned when the message or the payload of
+// a message of a registered type can not be unmarshaled.
+type malformedMessageError struct {
+	sender peer.ID
+	err    error
+}
+
+func (mme *malformedMessageError) Error() string {
+	return fmt.Sprintf(
+		"malformed message from [%v]: [%v]",
+		mme.sender,
+		mme.err,
+	)
+}
+
+// unknownMessageTypeError is returned when there is no unmarshaler
+// registered for the type of the message.
+type unknownMessageTypeError struct {
+	messageType string
+}
+
+func (umte *unknownMessageTypeError) Error() string {
+	return fmt.Sprintf(
+		"couldn't find unmarshaler for type [%s]",
+		umte.messageType,
+	)
+}
+
+// reputationEventFor returns the reputation event that should be reported
+// for the author of a message which could not be processed due to the
+// given error. Only errors proving the misbehavior of the author are
+// reported. For other errors, such as messages of types not registered by
+// this client, the second return value is false.
+func reputationEventFor(err error) (net.ReputationEvent, bool) {
+	switch err.(type) {
+	case *senderMismatchError, *invalidSignatureError:
+		return net.SenderSpoofingEvent, true
+	case *quotaExceededError:
+		return net.MessageFloodingEvent, true
+	case *malformedMessageError:
+		return net.InvalidMessageEvent, true
+	}
+
+	return 0, false
+}
+
+// reportPeer reports the reputation event for the given peer if the channel
+// maintains peers reputation.
+func (c *channel) reportPeer(peerID peer.ID, event net.ReputationEvent) {
+	if c.reputation == nil {
+		return
+	}
+
+	c.reputation.report(peerID, event)
+}
+
 func (c *channel) getUnmarshalingContainerByType(messageType string) (net.TaggedUnmarshaler, error) {
 	c.unmarshalersMutex.Lock()
 	defer c.unmarshalersMutex.Unlock()
 
 	unmarshaler, found := c.unmarshalersByType[messageType]
 	if !found {
-		return nil, fmt.Errorf(
-			"couldn't find unmarshaler for type [%s]",
-			messageType,
-		)
+		return nil, &unknownMessageTypeError{messageType}
 	}
 
 	return unmarshaler(), nil
@@ -333,14 +757,33 @@ func (c *channel) deliver(message net.Message) {
 	c.messageHandlersMutex.Unlock()
 
 	for _, handler := range snapshot {
-		select {
-		case handler.channel <- message:
-		default:
-			logger.Warningf("message handler is too slow; dropping message")
+		if !handler.deliver(message) {
+			dropped := atomic.AddUint64(&c.droppedMessages, 1)
+			logger.Warningf(
+				"message handler is too slow; dropping message; "+
+					"[%v] messages dropped by channel [%v] so far",
+				dropped,
+				c.name,
+			)
 		}
 	}
 }
 
+func (c *channel) DroppedMessages() uint64 {
+	return atomic.LoadUint64(&c.droppedMessages)
+}
+
+// close stops processing of the incoming messages, cancels the subscription
+// of the channel's topic and unregisters the topic validator.
+func (c *channel) close() error {
+	c.cancelWorkers()
+
+	c.validatorMutex.Lock()
+	defer c.validatorMutex.Unlock()
+
+	return c.validator.UnregisterTopicValidator(c.name)
+}
+
 func (c *channel) SetFilter(filter net.BroadcastChannelFilter) error {
 	c.validatorMutex.Lock()
 	defer c.validatorMutex.Unlock()
@@ -356,7 +799,59 @@ func (c *channel) SetFilter(filter net.BroadcastChannelFilter) error {
 		)
 	}
 
-	return c.validator.RegisterTopicValidator(c.name, createTopicValidator(filter))
+	return c.validator.RegisterTopicValidator(c.name, c.topicValidator(filter))
+}
+
+// topicValidator returns the validator of the channel's topic. Messages are
+// accepted only if they pass the given filter and their author does not
+// exceed the rate limit. Retransmissions are recognized by the sequence
+// number of the message and are not counted against the rate limit.
+// Authors exceeding the rate limit are reported. The filter is optional.
+func (c *channel) topicValidator(
+	filter net.BroadcastChannelFilter,
+) pubsub.Validator {
+	var filterValidator pubsub.Validator
+	if filter != nil {
+		filterValidator = createTopicValidator(filter)
+	}
+
+	return func(ctx context.Context, from peer.ID, message *pubsub.Message) bool {
+		if filterValidator != nil && !filterValidator(ctx, from, message) {
+			return false
+		}
+
+		author := message.GetFrom()
+
+		// Messages published by the client are never limited.
+		if c.rateLimiter == nil || author == c.clientIdentity.id {
+			return true
+		}
+
+		var messageProto pb.BroadcastNetworkMessage
+		if err := proto.Unmarshal(message.Data, &messageProto); err != nil {
+			logger.Warningf(
+				"could not unmarshal message of author [%v] "+
+					"in channel [%v]: [%v]",
+				author,
+				c.name,
+				err,
+			)
+			c.reportPeer(author, net.InvalidMessageEvent)
+			return false
+		}
+
+		if !c.rateLimiter.allow(author, messageProto.SequenceNumber) {
+			logger.Warningf(
+				"author [%v] exceeded the message rate limit of channel [%v]",
+				author,
+				c.name,
+			)
+			c.reportPeer(author, net.MessageFloodingEvent)
+			return false
+		}
+
+		return true
+	}
 }
 
 func createTopicValidator(filter net.BroadcastChannelFilter) pubsub.Validator {
@@ -31,6 +31,14 @@ type channelManager struct {
 
 	retransmissionTicker *retransmission.Ticker
 
+	reputation *peerReputation
+
+	peerVersions *peerVersions
+
+	messageRateLimit net.RateLimit
+
+	disableMessageSignatures bool
+
 	forwardersMutex sync.Mutex
 	forwarders      map[string]pubsub.RelayCancelFunc
 
@@ -43,27 +51,43 @@ func newChannelManager(
 	identity *identity,
 	p2phost host.Host,
 	retransmissionTicker *retransmission.Ticker,
+	reputation *peerReputation,
+	peerVersions *peerVersions,
+	messageRateLimit net.RateLimit,
+	disableMessageSignatures bool,
 ) (*channelManager, error) {
-	floodsub, err := pubsub.NewFloodSub(
+	peerScoreParams, peerScoreThresholds := reputation.peerScoreParams()
+
+	gossipsub, err := pubsub.NewGossipSub(
 		ctx,
 		p2phost,
 		pubsub.WithMessageAuthor(identity.id),
 		pubsub.WithMessageSignaturePolicy(pubsub.StrictSign),
 		pubsub.WithPeerOutboundQueueSize(libp2pPeerOutboundQueueSize),
 		pubsub.WithValidateQueueSize(libp2pValidationQueueSize),
+		pubsub.WithBlacklist(reputation),
+		pubsub.WithPeerScore(peerScoreParams, peerScoreThresholds),
+		// Messages published by the client are sent to all peers subscribed
+		// to the topic, not only to the mesh peers, so that they are not
+		// lost before the mesh is built.
+		pubsub.WithFloodPublish(true),
 	)
 	if err != nil {
 		return nil, err
 	}
 	return &channelManager{
-		channels:             make(map[string]*channel),
-		pubsub:               floodsub,
-		peerStore:            p2phost.Peerstore(),
-		identity:             identity,
-		ctx:                  ctx,
-		retransmissionTicker: retransmissionTicker,
-		forwarders:           make(map[string]pubsub.RelayCancelFunc),
-		topics:               make(map[string]*pubsub.Topic),
+		channels:                 make(map[string]*channel),
+		pubsub:                   gossipsub,
+		peerStore:                p2phost.Peerstore(),
+		identity:                 identity,
+		ctx:                      ctx,
+		retransmissionTicker:     retransmissionTicker,
+		reputation:               reputation,
+		peerVersions:             peerVersions,
+		messageRateLimit:         messageRateLimit,
+		disableMessageSignatures: disableMessageSignatures,
+		forwarders:               make(map[string]pubsub.RelayCancelFunc),
+		topics:                   make(map[string]*pubsub.Topic),
 	}, nil
 }
 
@@ -118,24 +142,70 @@ func (cm *channelManager) newChannel(name string) (*channel, error) {
 		)
 	}
 
+	workersCtx, cancelWorkers := context.WithCancel(cm.ctx)
+
 	channel := &channel{
-		name:                 name,
-		clientIdentity:       cm.identity,
-		peerStore:            cm.peerStore,
-		validator:            cm.pubsub,
-		publisher:            topic,
-		subscription:         subscription,
-		incomingMessageQueue: make(chan *pubsub.Message, incomingMessageThrottle),
-		messageHandlers:      make([]*messageHandler, 0),
-		unmarshalersByType:   make(map[string]func() net.TaggedUnmarshaler),
-		retransmissionTicker: cm.retransmissionTicker,
+		name:                     name,
+		clientIdentity:           cm.identity,
+		peerStore:                cm.peerStore,
+		validator:                cm.pubsub,
+		publisher:                topic,
+		subscription:             subscription,
+		incomingMessageQueue:     make(chan *pubsub.Message, incomingMessageThrottle),
+		cancelWorkers:            cancelWorkers,
+		messageHandlers:          make([]*messageHandler, 0),
+		unmarshalersByType:       make(map[string]func() net.TaggedUnmarshaler),
+		retransmissionTicker:     cm.retransmissionTicker,
+		reputation:               cm.reputation,
+		peerVersions:             cm.peerVersions,
+		rateLimiter:              newMessageRateLimiter(cm.messageRateLimit),
+		disableMessageSignatures: cm.disableMessageSignatures,
 	}
 
-	go channel.handleMessages(cm.ctx)
+	// The validator is registered upfront so that the rate limit is enforced
+	// even if no filter is set for the channel.
+	if err := cm.pubsub.RegisterTopicValidator(
+		name,
+		channel.topicValidator(nil),
+	); err != nil {
+		cancelWorkers()
+		subscription.Cancel()
+		return nil, fmt.Errorf(
+			"could not register topic [%v] validator: [%v]",
+			name,
+			err,
+		)
+	}
+
+	go channel.handleMessages(workersCtx)
 
 	return channel, nil
 }
 
+// closeChannel closes the channel with the given name and removes it from
+// the cache of known channels. The topic handle is kept so the channel can
+// be opened again later.
+func (cm *channelManager) closeChannel(name string) error {
+	cm.channelsMutex.Lock()
+	channel, exists := cm.channels[name]
+	delete(cm.channels, name)
+	cm.channelsMutex.Unlock()
+
+	if !exists {
+		return nil
+	}
+
+	if err := channel.close(); err != nil {
+		return fmt.Errorf(
+			"could not unregister topic [%v] validator: [%v]",
+			name,
+			err,
+		)
+	}
+
+	return nil
+}
+
 func (cm *channelManager) newForwarder(name string, ttl time.Duration) error {
 	cm.forwardersMutex.Lock()
 	defer cm.forwardersMutex.Unlock()
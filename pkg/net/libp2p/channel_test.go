@@ -1,19 +1,26 @@
 package libp2p
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
+	"fmt"
 	"github.com/keep-network/keep-core/pkg/operator"
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/gen/pb"
+	"github.com/keep-network/keep-core/pkg/net/security/handshake"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestRegisterAndFireHandler(t *testing.T) {
@@ -149,6 +156,97 @@ func TestUnregisterWhenHandling(t *testing.T) {
 	}
 }
 
+func TestDeliver_DroppedMessages(t *testing.T) {
+	var tests = map[string]struct {
+		deliveryMode    net.DeliveryMode
+		releaseAfter    time.Duration
+		expectedDropped uint64
+	}{
+		"best effort delivery": {
+			deliveryMode:    net.BestEffortDelivery,
+			releaseAfter:    100 * time.Millisecond,
+			expectedDropped: 2,
+		},
+		"blocking delivery with handler catching up": {
+			deliveryMode:    net.BlockingDelivery,
+			releaseAfter:    100 * time.Millisecond,
+			expectedDropped: 0,
+		},
+		"blocking delivery with stalled handler": {
+			deliveryMode:    net.BlockingDelivery,
+			releaseAfter:    3 * net.MaxBlockingDeliveryTime,
+			expectedDropped: 2,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			channel := &channel{}
+
+			started := make(chan struct{})
+			release := make(chan struct{})
+
+			var droppedMutex sync.Mutex
+			var dropped []uint64
+
+			channel.Recv(
+				ctx,
+				func(msg net.Message) {
+					if msg.Seqno() == 0 {
+						close(started)
+						<-release
+					}
+				},
+				net.WithBufferSize(1),
+				net.WithDeliveryMode(test.deliveryMode),
+				net.WithDropHandler(func(msg net.Message) {
+					droppedMutex.Lock()
+					defer droppedMutex.Unlock()
+					dropped = append(dropped, msg.Seqno())
+				}),
+			)
+
+			// The first message blocks the handler.
+			channel.deliver(&mockNetMessage{seqno: 0})
+			<-started
+
+			go func() {
+				time.Sleep(test.releaseAfter)
+				close(release)
+			}()
+
+			// The second message fills the buffer and the next two
+			// messages cannot be delivered until the handler is released.
+			for i := 1; i <= 3; i++ {
+				channel.deliver(&mockNetMessage{seqno: uint64(i)})
+			}
+
+			if test.expectedDropped != channel.DroppedMessages() {
+				t.Errorf(
+					"unexpected number of dropped messages\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedDropped,
+					channel.DroppedMessages(),
+				)
+			}
+
+			droppedMutex.Lock()
+			defer droppedMutex.Unlock()
+			if int(test.expectedDropped) != len(dropped) {
+				t.Errorf(
+					"unexpected number of drop notifications\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedDropped,
+					len(dropped),
+				)
+			}
+		})
+	}
+}
+
 func TestCreateTopicValidator(t *testing.T) {
 	operatorPublicKeys := make([]*operator.PublicKey, 5)
 	for i := range operatorPublicKeys {
@@ -193,6 +291,526 @@ func TestCreateTopicValidator(t *testing.T) {
 	}
 }
 
+func TestTopicValidator_RateLimit(t *testing.T) {
+	channel := newTestChannel(t, &mockPublisher{}, nil)
+	channel.rateLimiter = newMessageRateLimiter(
+		net.RateLimit{MessagesPerSecond: 0.001, Burst: 2},
+	)
+
+	validator := channel.topicValidator(nil)
+
+	pubsubMessage := func(author peer.ID, seqno uint64) *pubsub.Message {
+		authorBytes, err := author.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := proto.Marshal(
+			&pb.BroadcastNetworkMessage{SequenceNumber: seqno},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &pubsub.Message{
+			Message: &pubsubpb.Message{From: authorBytes, Data: data},
+		}
+	}
+
+	author := generatePeerID(t)
+
+	var tests = []struct {
+		description    string
+		seqno          uint64
+		expectedResult bool
+	}{
+		{"first message", 1, true},
+		{"retransmission", 1, true},
+		{"second message", 2, true},
+		{"retransmission over burst", 2, true},
+		{"message over burst", 3, false},
+	}
+
+	for _, test := range tests {
+		actualResult := validator(nil, author, pubsubMessage(author, test.seqno))
+		if test.expectedResult != actualResult {
+			t.Errorf(
+				"unexpected result for %v\n"+
+					"expected: [%v]\nactual:   [%v]",
+				test.description,
+				test.expectedResult,
+				actualResult,
+			)
+		}
+	}
+
+	// Messages published by the client are never limited.
+	clientID := channel.clientIdentity.id
+	for i := 0; i < 3; i++ {
+		if !validator(nil, clientID, pubsubMessage(clientID, uint64(i))) {
+			t.Errorf("client's message [%v] was rejected", i)
+		}
+	}
+}
+
+func TestProcessPubsubMessage_Quota(t *testing.T) {
+	publisher := &mockPublisher{}
+
+	sender := newTestChannel(t, publisher, nil)
+	receiver := newTestChannel(t, publisher, nil)
+	receiver.rateLimiter = newMessageRateLimiter(net.RateLimit{})
+	receiver.SetMessageQuota((&fuzzingMessage{}).Type(), 1)
+
+	senderIDBytes, err := sender.clientIdentity.id.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	process := func(seqno uint64) error {
+		messageProto, err := sender.messageProto(
+			&fuzzingMessage{[]byte{1, 2, 3}},
+			seqno,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		messageBytes, err := proto.Marshal(messageProto)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return receiver.processPubsubMessage(&pubsub.Message{
+			Message: &pubsubpb.Message{
+				From: senderIDBytes,
+				Data: messageBytes,
+			},
+		})
+	}
+
+	if err := process(1); err != nil {
+		t.Fatalf("unexpected error for first message: [%v]", err)
+	}
+
+	// Retransmissions share the sequence number of the original message.
+	if err := process(1); err != nil {
+		t.Fatalf("unexpected error for retransmission: [%v]", err)
+	}
+
+	err = process(2)
+	if _, ok := err.(*quotaExceededError); !ok {
+		t.Fatalf("unexpected error for second message: [%v]", err)
+	}
+}
+
+func TestSendReceive_CompressionAndSizeLimits(t *testing.T) {
+	supportingPeer := []string{broadcastMessageCapability, compressionCapability}
+	legacyPeer := []string{broadcastMessageCapability}
+
+	var tests = map[string]struct {
+		payload            []byte
+		peerCapabilities   [][]string
+		unknownPeer        bool
+		maxMessageSize     int
+		maxPayloadSize     int
+		expectedCompressed bool
+		expectedSendErr    string
+	}{
+		"small payload": {
+			payload:            bytes.Repeat([]byte{1}, CompressionThreshold-1),
+			peerCapabilities:   [][]string{supportingPeer, supportingPeer},
+			expectedCompressed: false,
+		},
+		"large payload": {
+			payload:            bytes.Repeat([]byte{1}, CompressionThreshold),
+			peerCapabilities:   [][]string{supportingPeer, supportingPeer},
+			expectedCompressed: true,
+		},
+		"large payload and peer not supporting compression": {
+			payload:            bytes.Repeat([]byte{1}, CompressionThreshold),
+			peerCapabilities:   [][]string{supportingPeer, legacyPeer},
+			expectedCompressed: false,
+		},
+		"large payload and peer with unknown version": {
+			payload:            bytes.Repeat([]byte{1}, CompressionThreshold),
+			peerCapabilities:   [][]string{supportingPeer},
+			unknownPeer:        true,
+			expectedCompressed: false,
+		},
+		"payload exceeding the limit": {
+			payload:          bytes.Repeat([]byte{1}, 101),
+			peerCapabilities: [][]string{supportingPeer},
+			maxPayloadSize:   100,
+			expectedSendErr: "payload of message of type [test/fuzzing] " +
+				"has [101] bytes and exceeds the limit of [100] bytes",
+		},
+		"message exceeding the limit": {
+			payload:          bytes.Repeat([]byte{1}, 100),
+			peerCapabilities: [][]string{supportingPeer},
+			maxMessageSize:   100,
+			expectedSendErr:  "exceeds the limit of [100] bytes",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			publisher := &mockPublisher{}
+			peerVersions := newPeerVersions()
+
+			for i, capabilities := range test.peerCapabilities {
+				peerID := peer.ID(fmt.Sprintf("peer-%v", i))
+				publisher.peers = append(publisher.peers, peerID)
+				peerVersions.set(
+					peerID,
+					handshake.Version{Protocol: ProtocolVersion},
+					capabilities,
+				)
+			}
+			if test.unknownPeer {
+				publisher.peers = append(publisher.peers, peer.ID("unknown"))
+			}
+
+			sender := newTestChannel(t, publisher, peerVersions)
+			receiver := newTestChannel(t, publisher, peerVersions)
+
+			for _, channel := range []*channel{sender, receiver} {
+				channel.SetMaxMessageSize(test.maxMessageSize)
+				channel.SetMaxPayloadSize(
+					(&fuzzingMessage{}).Type(),
+					test.maxPayloadSize,
+				)
+			}
+
+			messageProto, err := sender.messageProto(
+				&fuzzingMessage{test.payload},
+				1,
+			)
+			if err == nil {
+				err = sender.publish(messageProto)
+			}
+			if test.expectedSendErr != "" {
+				if err == nil || !strings.Contains(
+					err.Error(),
+					test.expectedSendErr,
+				) {
+					t.Fatalf(
+						"unexpected error\nexpected: [%v]\nactual:   [%v]",
+						test.expectedSendErr,
+						err,
+					)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBoolsEqual(
+				t,
+				"compressed",
+				test.expectedCompressed,
+				messageProto.Compressed,
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			receivedChan := make(chan net.Message, 1)
+			receiver.Recv(ctx, func(msg net.Message) {
+				receivedChan <- msg
+			})
+
+			senderIDBytes, err := sender.clientIdentity.id.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = receiver.processPubsubMessage(&pubsub.Message{
+				Message: &pubsubpb.Message{
+					From: senderIDBytes,
+					Data: publisher.published[0],
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case msg := <-receivedChan:
+				payload := msg.Payload().(*fuzzingMessage).Bytes
+				if !bytes.Equal(test.payload, payload) {
+					t.Errorf("unexpected payload")
+				}
+			case <-time.After(time.Second):
+				t.Fatal("message not received")
+			}
+
+			expectedSentTraffic := []net.BroadcastTraffic{{
+				Channel:      "test-channel",
+				MessageType:  "test/fuzzing",
+				MessagesSent: 1,
+				BytesSent:    uint64(len(publisher.published[0])),
+			}}
+			if !reflect.DeepEqual(
+				expectedSentTraffic,
+				sender.traffic.snapshot(),
+			) {
+				t.Errorf(
+					"unexpected sent traffic\nexpected: [%+v]\nactual:   [%+v]",
+					expectedSentTraffic,
+					sender.traffic.snapshot(),
+				)
+			}
+
+			expectedReceivedTraffic := []net.BroadcastTraffic{{
+				Channel:          "test-channel",
+				MessageType:      "test/fuzzing",
+				MessagesReceived: 1,
+				BytesReceived:    uint64(len(publisher.published[0])),
+			}}
+			if !reflect.DeepEqual(
+				expectedReceivedTraffic,
+				receiver.traffic.snapshot(),
+			) {
+				t.Errorf(
+					"unexpected received traffic\nexpected: [%+v]\nactual:   [%+v]",
+					expectedReceivedTraffic,
+					receiver.traffic.snapshot(),
+				)
+			}
+		})
+	}
+}
+
+func TestProcessPubsubMessage_DecompressedPayloadLimit(t *testing.T) {
+	publisher := &mockPublisher{}
+	peerVersions := newPeerVersions()
+
+	sender := newTestChannel(t, publisher, peerVersions)
+	receiver := newTestChannel(t, publisher, peerVersions)
+	receiver.SetMaxPayloadSize((&fuzzingMessage{}).Type(), 2000)
+
+	messageProto, err := sender.messageProto(
+		&fuzzingMessage{bytes.Repeat([]byte{1}, 2001)},
+		1,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sender.publish(messageProto); err != nil {
+		t.Fatal(err)
+	}
+
+	senderIDBytes, err := sender.clientIdentity.id.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = receiver.processPubsubMessage(&pubsub.Message{
+		Message: &pubsubpb.Message{
+			From: senderIDBytes,
+			Data: publisher.published[0],
+		},
+	})
+
+	expectedErr := "invalid payload of message of type [test/fuzzing]: " +
+		"[decompressed payload exceeds the limit of [2000] bytes]"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+
+	if traffic := receiver.traffic.snapshot(); len(traffic) != 0 {
+		t.Errorf("unexpected received traffic: [%+v]", traffic)
+	}
+}
+
+func TestProcessPubsubMessage_Signature(t *testing.T) {
+	var tests = map[string]struct {
+		disableSignatures bool
+		modifyMessage     func(message *pb.BroadcastNetworkMessage)
+		expectedSigned    bool
+		expectedErr       bool
+	}{
+		"signed message": {
+			modifyMessage:  func(message *pb.BroadcastNetworkMessage) {},
+			expectedSigned: true,
+		},
+		"signatures disabled": {
+			disableSignatures: true,
+			modifyMessage:     func(message *pb.BroadcastNetworkMessage) {},
+			expectedSigned:    false,
+		},
+		"unsigned message": {
+			modifyMessage: func(message *pb.BroadcastNetworkMessage) {
+				message.Signature = nil
+			},
+			expectedSigned: false,
+		},
+		"message with modified sequence number": {
+			modifyMessage: func(message *pb.BroadcastNetworkMessage) {
+				message.SequenceNumber++
+			},
+			expectedErr: true,
+		},
+		"message with invalid signature": {
+			modifyMessage: func(message *pb.BroadcastNetworkMessage) {
+				message.Signature = []byte{1, 2, 3}
+			},
+			expectedErr: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			publisher := &mockPublisher{}
+
+			sender := newTestChannel(t, publisher, nil)
+			sender.disableMessageSignatures = test.disableSignatures
+			receiver := newTestChannel(t, publisher, nil)
+
+			messageProto, err := sender.messageProto(
+				&fuzzingMessage{[]byte{1, 2, 3}},
+				10,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			test.modifyMessage(messageProto)
+
+			messageBytes, err := proto.Marshal(messageProto)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			receivedChan := make(chan net.Message, 1)
+			receiver.Recv(ctx, func(msg net.Message) {
+				receivedChan <- msg
+			})
+
+			senderIDBytes, err := sender.clientIdentity.id.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = receiver.processPubsubMessage(&pubsub.Message{
+				Message: &pubsubpb.Message{
+					From: senderIDBytes,
+					Data: messageBytes,
+				},
+			})
+			if test.expectedErr {
+				if _, ok := err.(*invalidSignatureError); !ok {
+					t.Fatalf("unexpected error: [%v]", err)
+				}
+				if event, _ := reputationEventFor(err); event != net.SenderSpoofingEvent {
+					t.Errorf("unexpected reputation event: [%v]", event)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var msg net.Message
+			select {
+			case msg = <-receivedChan:
+			case <-time.After(time.Second):
+				t.Fatal("message not received")
+			}
+
+			signature := msg.Signature()
+			testutils.AssertBoolsEqual(
+				t,
+				"signed",
+				test.expectedSigned,
+				signature != nil,
+			)
+			if signature == nil {
+				return
+			}
+
+			if err := signature.Verify(); err != nil {
+				t.Fatal(err)
+			}
+
+			expectedSignedBytes := net.MessageSignedBytes(
+				"test-channel",
+				"test/fuzzing",
+				10,
+				[]byte{1, 2, 3},
+			)
+			testutils.AssertBytesEqual(
+				t,
+				expectedSignedBytes,
+				signature.SignedBytes(),
+			)
+		})
+	}
+}
+
+func newTestChannel(
+	t *testing.T,
+	publisher publisher,
+	peerVersions *peerVersions,
+) *channel {
+	operatorPrivateKey, _, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPrivateKey, _, err := operatorPrivateKeyToNetworkKeyPair(
+		operatorPrivateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := createIdentity(networkPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel := &channel{
+		name:               "test-channel",
+		clientIdentity:     identity,
+		publisher:          publisher,
+		peerVersions:       peerVersions,
+		unmarshalersByType: make(map[string]func() net.TaggedUnmarshaler),
+	}
+
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &fuzzingMessage{}
+	})
+
+	return channel
+}
+
+type mockPublisher struct {
+	peers     []peer.ID
+	published [][]byte
+}
+
+func (mp *mockPublisher) Publish(
+	ctx context.Context,
+	data []byte,
+	opts ...pubsub.PubOpt,
+) error {
+	mp.published = append(mp.published, data)
+	return nil
+}
+
+func (mp *mockPublisher) ListPeers() []peer.ID {
+	return mp.peers
+}
+
 func toEncodedBytes(t *testing.T, publicKey *operator.PublicKey) string {
 	publicKeyBytes := operator.MarshalUncompressed(publicKey)
 
@@ -223,6 +841,10 @@ func (mnm *mockNetMessage) Seqno() uint64 {
 	return mnm.seqno
 }
 
+func (mnm *mockNetMessage) Signature() *net.MessageSignature {
+	panic("not implemented in mock")
+}
+
 type mockTransportIdentifier struct {
 	transportID string
 }
@@ -0,0 +1,68 @@
+package libp2p
+
+import (
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"io"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+const (
+	// DefaultMaxMessageSize is the default maximum size in bytes of
+	// a broadcast channel message, as sent over the wire. It is the maximum
+	// size of a message accepted by the pubsub router.
+	DefaultMaxMessageSize = pubsub.DefaultMaxMessageSize
+	// DefaultMaxPayloadSize is the default maximum size in bytes of
+	// an uncompressed payload of a broadcast channel message.
+	DefaultMaxPayloadSize = 4 * DefaultMaxMessageSize
+	// CompressionThreshold is the minimum size in bytes of a payload of
+	// a broadcast channel message for which compression is applied.
+	CompressionThreshold = 1024
+)
+
+// compress compresses the payload using the DEFLATE algorithm.
+func compress(payload []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	writer, err := flate.NewWriter(&buffer, flate.DefaultCompression)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := writer.Write(payload); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// decompress decompresses the payload compressed using the DEFLATE
+// algorithm. The function returns an error if the decompressed payload is
+// larger than maxSize so that the decompression can not be abused to exhaust
+// the client's memory.
+func decompress(payload []byte, maxSize int) ([]byte, error) {
+	reader := flate.NewReader(bytes.NewReader(payload))
+	defer reader.Close()
+
+	decompressed, err := io.ReadAll(
+		io.LimitReader(reader, int64(maxSize)+1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress payload: [%v]", err)
+	}
+
+	if len(decompressed) > maxSize {
+		return nil, fmt.Errorf(
+			"decompressed payload exceeds the limit of [%v] bytes",
+			maxSize,
+		)
+	}
+
+	return decompressed, nil
+}
@@ -0,0 +1,161 @@
+package libp2p
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/gen/pb"
+)
+
+func TestCompressDecompress(t *testing.T) {
+	payload := bytes.Repeat([]byte("paillier proof "), 1000)
+
+	compressed, err := compress(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(compressed) >= len(payload) {
+		t.Errorf(
+			"payload was not compressed; original size: [%v], "+
+				"compressed size: [%v]",
+			len(payload),
+			len(compressed),
+		)
+	}
+
+	decompressed, err := decompress(compressed, len(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(payload, decompressed) {
+		t.Errorf("unexpected decompressed payload")
+	}
+}
+
+func TestDecompress_LimitExceeded(t *testing.T) {
+	payload := bytes.Repeat([]byte{0}, 10000)
+
+	compressed, err := compress(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = decompress(compressed, len(payload)-1)
+
+	expectedErr := fmt.Errorf(
+		"decompressed payload exceeds the limit of [9999] bytes",
+	)
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+}
+
+func TestCompressionBetweenConnectedPeers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	withNetwork(ctx, t, 7500, func(
+		identity1 *identity,
+		_ *identity,
+		provider1 net.Provider,
+		provider2 net.Provider,
+	) {
+		name := "compression-test"
+
+		senderChannel, err := provider1.BroadcastChannelFor(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		receiverChannel, err := provider2.BroadcastChannelFor(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, broadcastChannel := range []net.BroadcastChannel{
+			senderChannel,
+			receiverChannel,
+		} {
+			broadcastChannel.SetUnmarshaler(
+				func() net.TaggedUnmarshaler { return &testMessage{} },
+			)
+		}
+
+		// Observe the messages exactly as they were transmitted over
+		// the wire.
+		topic, ok := receiverChannel.(*channel).publisher.(*pubsub.Topic)
+		if !ok {
+			t.Fatal("unexpected type of channel publisher")
+		}
+		rawSubscription, err := topic.Subscribe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rawSubscription.Cancel()
+
+		receivedMessages := make(chan net.Message, 1)
+		receiverChannel.Recv(ctx, func(message net.Message) {
+			receivedMessages <- message
+		})
+
+		for len(senderChannel.(*channel).publisher.ListPeers()) == 0 {
+			select {
+			case <-ctx.Done():
+				t.Fatal("peers did not join the channel")
+			case <-time.After(50 * time.Millisecond):
+			}
+		}
+
+		payload := strings.Repeat("paillier proof ", 1000)
+		message := &testMessage{Sender: identity1, Payload: payload}
+		if err := senderChannel.Send(ctx, message); err != nil {
+			t.Fatal(err)
+		}
+
+		rawMessage, err := rawSubscription.Next(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		networkMessage := &pb.BroadcastNetworkMessage{}
+		if err := proto.Unmarshal(rawMessage.Data, networkMessage); err != nil {
+			t.Fatal(err)
+		}
+
+		if !networkMessage.Compressed {
+			t.Fatal("transmitted message is not compressed")
+		}
+		if len(networkMessage.Payload) >= len(payload) {
+			t.Errorf(
+				"transmitted payload was not compressed; payload size: "+
+					"[%v], transmitted size: [%v]",
+				len(payload),
+				len(networkMessage.Payload),
+			)
+		}
+
+		select {
+		case receivedMessage := <-receivedMessages:
+			receivedPayload := receivedMessage.Payload().(*testMessage).Payload
+			if receivedPayload != payload {
+				t.Errorf("unexpected payload of received message")
+			}
+		case <-ctx.Done():
+			t.Fatal("message was not received")
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	gonet "net"
+	"sync"
+	"time"
+
+	libp2pnet "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/swarm"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+const (
+	// PeerPingTimeout is the maximum time of measuring the latency to
+	// a connected peer.
+	PeerPingTimeout = 10 * time.Second
+	// AddressCheckTimeout is the maximum time of establishing a connection
+	// to the checked address.
+	AddressCheckTimeout = 10 * time.Second
+)
+
+// handshakeOutcomes records outcomes of outbound connection handshakes with
+// watched peers. Outcomes let to tell whether a failed connection was
+// rejected during the handshake or closed by the peer once the handshake was
+// completed.
+type handshakeOutcomes struct {
+	mutex    sync.Mutex
+	watched  map[peer.ID]bool
+	outcomes map[peer.ID]error
+}
+
+func newHandshakeOutcomes() *handshakeOutcomes {
+	return &handshakeOutcomes{
+		watched:  make(map[peer.ID]bool),
+		outcomes: make(map[peer.ID]error),
+	}
+}
+
+// watch starts recording outcomes of handshakes with the given peer.
+// Previously recorded outcome is discarded.
+func (ho *handshakeOutcomes) watch(peerID peer.ID) {
+	ho.mutex.Lock()
+	defer ho.mutex.Unlock()
+
+	ho.watched[peerID] = true
+	delete(ho.outcomes, peerID)
+}
+
+// unwatch stops recording outcomes of handshakes with the given peer.
+func (ho *handshakeOutcomes) unwatch(peerID peer.ID) {
+	ho.mutex.Lock()
+	defer ho.mutex.Unlock()
+
+	delete(ho.watched, peerID)
+	delete(ho.outcomes, peerID)
+}
+
+// record records the outcome of the handshake with the given peer if
+// the peer is watched.
+func (ho *handshakeOutcomes) record(peerID peer.ID, err error) {
+	ho.mutex.Lock()
+	defer ho.mutex.Unlock()
+
+	if !ho.watched[peerID] {
+		return
+	}
+
+	ho.outcomes[peerID] = err
+}
+
+// get returns the outcome of the last handshake with the given peer. The
+// second returned value is false if no handshake was recorded.
+func (ho *handshakeOutcomes) get(peerID peer.ID) (error, bool) {
+	ho.mutex.Lock()
+	defer ho.mutex.Unlock()
+
+	err, ok := ho.outcomes[peerID]
+	return err, ok
+}
+
+// DiagnosePeer connects the peer with the given multiaddress and measures
+// the latency to that peer. If the connection could not be established,
+// the stage at which it failed is determined, along with the reason.
+func (p *provider) DiagnosePeer(
+	ctx context.Context,
+	address string,
+) net.PeerDiagnosis {
+	diagnosis := net.PeerDiagnosis{Address: address}
+
+	addrInfo, err := extractMultiAddrFromPeers([]string{address})
+	if err != nil {
+		diagnosis.Failure = net.InvalidAddressFailure
+		diagnosis.FailureReason = err.Error()
+		return diagnosis
+	}
+
+	peerID := addrInfo[0].ID
+	diagnosis.PeerID = peerID.String()
+
+	operatorPublicKey, err := extractPublicKey(peerID)
+	if err != nil {
+		diagnosis.Failure = net.InvalidAddressFailure
+		diagnosis.FailureReason = fmt.Sprintf(
+			"could not extract public key of peer: [%v]",
+			err,
+		)
+		return diagnosis
+	}
+
+	if err := p.firewall.Validate(operatorPublicKey); err != nil {
+		diagnosis.Failure = net.LocalFirewallFailure
+		diagnosis.FailureReason = err.Error()
+		return diagnosis
+	}
+
+	p.handshakes.watch(peerID)
+	defer p.handshakes.unwatch(peerID)
+
+	// Previous failures would make the peer not dialed at all. Other known
+	// addresses of the peer would be dialed along with the diagnosed one,
+	// making the failure reason ambiguous.
+	if swarmNetwork, ok := p.host.Network().(*swarm.Swarm); ok {
+		swarmNetwork.Backoff().Clear(peerID)
+	}
+	if p.host.Network().Connectedness(peerID) != libp2pnet.Connected {
+		p.host.Peerstore().ClearAddrs(peerID)
+	}
+
+	if err := p.host.Connect(ctx, addrInfo[0]); err != nil {
+		handshakeErr, handshakeRecorded := p.handshakes.get(peerID)
+
+		switch {
+		case !handshakeRecorded:
+			diagnosis.Failure = net.DialFailure
+			diagnosis.FailureReason = err.Error()
+		case handshakeErr != nil:
+			diagnosis.Failure = net.HandshakeFailure
+			diagnosis.FailureReason = handshakeErr.Error()
+		default:
+			diagnosis.Failure = net.RemoteRejectionFailure
+			diagnosis.FailureReason = fmt.Sprintf(
+				"connection closed by the peer after the handshake; "+
+					"the client's operator may not meet the peer's "+
+					"firewall criteria: [%v]",
+				err,
+			)
+		}
+
+		return diagnosis
+	}
+
+	diagnosis.Connected = true
+
+	pingCtx, cancelPingCtx := context.WithTimeout(ctx, PeerPingTimeout)
+	defer cancelPingCtx()
+
+	result := <-ping.Ping(pingCtx, p.host, peerID)
+	if result.Error != nil {
+		logger.Warnf(
+			"could not measure latency to peer [%v]: [%v]",
+			peerID,
+			result.Error,
+		)
+	} else {
+		diagnosis.Latency = result.RTT
+	}
+
+	return diagnosis
+}
+
+// CheckAddress checks whether a TCP connection to the given multiaddress can
+// be established from the client's host. The result is meaningful for
+// the public addresses of the client only if the host can reach itself
+// through them, which may not be the case for some NAT devices.
+func (p *provider) CheckAddress(
+	ctx context.Context,
+	address string,
+) net.AddressCheck {
+	check := net.AddressCheck{Address: address}
+
+	multiaddress, err := ma.NewMultiaddr(address)
+	if err != nil {
+		check.FailureReason = fmt.Sprintf("invalid address: [%v]", err)
+		return check
+	}
+
+	dialAddress, err := tcpDialAddress(multiaddress)
+	if err != nil {
+		check.FailureReason = err.Error()
+		return check
+	}
+
+	dialCtx, cancelDialCtx := context.WithTimeout(ctx, AddressCheckTimeout)
+	defer cancelDialCtx()
+
+	connection, err := (&gonet.Dialer{}).DialContext(dialCtx, "tcp", dialAddress)
+	if err != nil {
+		check.FailureReason = err.Error()
+		return check
+	}
+
+	if err := connection.Close(); err != nil {
+		logger.Debugf("could not close the connection: [%v]", err)
+	}
+
+	check.Reachable = true
+
+	return check
+}
+
+// tcpDialAddress returns the host and port of the TCP multiaddress, in the
+// form accepted by the standard library dialer.
+func tcpDialAddress(address ma.Multiaddr) (string, error) {
+	port, err := address.ValueForProtocol(ma.P_TCP)
+	if err != nil {
+		return "", fmt.Errorf("address [%v] is not a TCP address", address)
+	}
+
+	for _, protocol := range []int{
+		ma.P_IP4,
+		ma.P_IP6,
+		ma.P_DNS4,
+		ma.P_DNS6,
+		ma.P_DNS,
+	} {
+		if host, err := address.ValueForProtocol(protocol); err == nil {
+			return gonet.JoinHostPort(host, port), nil
+		}
+	}
+
+	return "", fmt.Errorf("address [%v] has no host", address)
+}
+
+// RoutingTable returns peers in the DHT routing table of the client.
+func (p *provider) RoutingTable() []net.RoutingTablePeer {
+	peerInfos := p.routing.RoutingTable().GetPeerInfos()
+
+	peers := make([]net.RoutingTablePeer, 0, len(peerInfos))
+	for _, peerInfo := range peerInfos {
+		peers = append(peers, net.RoutingTablePeer{
+			PeerID: peerInfo.Id.String(),
+			Connected: p.host.Network().Connectedness(peerInfo.Id) ==
+				libp2pnet.Connected,
+			AddedAt:               peerInfo.AddedAt,
+			LastSuccessfulQueryAt: peerInfo.LastSuccessfulOutboundQueryAt,
+		})
+	}
+
+	return peers
+}
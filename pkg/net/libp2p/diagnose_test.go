@@ -0,0 +1,242 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+func TestDiagnosePeer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	diagnosing, _ := connectNATTestProvider(
+		ctx,
+		t,
+		Config{Port: 7411},
+		firewall.Disabled,
+	)
+	diagnosed, _ := connectNATTestProvider(
+		ctx,
+		t,
+		Config{Port: 7412},
+		firewall.Disabled,
+	)
+
+	address := fmt.Sprintf("/ip4/127.0.0.1/tcp/7412/ipfs/%v", diagnosed.ID())
+
+	diagnosis := diagnosing.DiagnosePeer(ctx, address)
+
+	if !diagnosis.Connected {
+		t.Fatalf(
+			"expected peer to be connected; failed with [%v]: [%v]",
+			diagnosis.Failure,
+			diagnosis.FailureReason,
+		)
+	}
+	if diagnosis.PeerID != diagnosed.ID().String() {
+		t.Errorf(
+			"unexpected peer id\nexpected: [%v]\nactual:   [%v]",
+			diagnosed.ID(),
+			diagnosis.PeerID,
+		)
+	}
+	if diagnosis.Latency <= 0 {
+		t.Errorf("expected latency to be measured")
+	}
+}
+
+func TestDiagnosePeer_Failures(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	diagnosing, diagnosingPublicKey := connectNATTestProvider(
+		ctx,
+		t,
+		Config{Port: 7413},
+		firewall.Disabled,
+	)
+	rejecting, _ := connectNATTestProvider(
+		ctx,
+		t,
+		Config{Port: 7414},
+		&rejectingFirewall{rejected: diagnosingPublicKey},
+	)
+	rejected, rejectedPublicKey := connectNATTestProvider(
+		ctx,
+		t,
+		Config{Port: 7415},
+		firewall.Disabled,
+	)
+	diagnosing.firewall = &rejectingFirewall{rejected: rejectedPublicKey}
+
+	var tests = map[string]struct {
+		address         string
+		expectedFailure net.ConnectionFailure
+	}{
+		"invalid address": {
+			address:         "/ip4/127.0.0.1/tcp/7414",
+			expectedFailure: net.InvalidAddressFailure,
+		},
+		"rejected by the local firewall": {
+			address: fmt.Sprintf(
+				"/ip4/127.0.0.1/tcp/7415/ipfs/%v",
+				rejected.ID(),
+			),
+			expectedFailure: net.LocalFirewallFailure,
+		},
+		"rejected by the peer's firewall": {
+			address: fmt.Sprintf(
+				"/ip4/127.0.0.1/tcp/7414/ipfs/%v",
+				rejecting.ID(),
+			),
+			expectedFailure: net.RemoteRejectionFailure,
+		},
+		"nobody listening": {
+			address: fmt.Sprintf(
+				"/ip4/127.0.0.1/tcp/7416/ipfs/%v",
+				rejecting.ID(),
+			),
+			expectedFailure: net.DialFailure,
+		},
+		"peer with different identity listening": {
+			address: fmt.Sprintf(
+				"/ip4/127.0.0.1/tcp/7415/ipfs/%v",
+				rejecting.ID(),
+			),
+			expectedFailure: net.HandshakeFailure,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			diagnosis := diagnosing.DiagnosePeer(ctx, test.address)
+
+			if diagnosis.Connected {
+				t.Fatal("expected peer not to be connected")
+			}
+			if diagnosis.Failure != test.expectedFailure {
+				t.Errorf(
+					"unexpected failure\nexpected: [%v]\nactual:   [%v]: [%v]",
+					test.expectedFailure,
+					diagnosis.Failure,
+					diagnosis.FailureReason,
+				)
+			}
+			if diagnosis.FailureReason == "" {
+				t.Errorf("expected failure reason")
+			}
+		})
+	}
+}
+
+func TestCheckAddress(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	provider, _ := connectNATTestProvider(
+		ctx,
+		t,
+		Config{Port: 7417},
+		firewall.Disabled,
+	)
+
+	var tests = map[string]struct {
+		address           string
+		expectedReachable bool
+	}{
+		"listening address": {
+			address:           "/ip4/127.0.0.1/tcp/7417",
+			expectedReachable: true,
+		},
+		"not listening address": {
+			address:           "/ip4/127.0.0.1/tcp/7418",
+			expectedReachable: false,
+		},
+		"not a TCP address": {
+			address:           "/ip4/127.0.0.1/udp/7417",
+			expectedReachable: false,
+		},
+		"invalid address": {
+			address:           "totallyBadAddress",
+			expectedReachable: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			check := provider.CheckAddress(ctx, test.address)
+
+			if check.Reachable != test.expectedReachable {
+				t.Errorf(
+					"unexpected reachability\nexpected: [%v]\nactual:   [%v]: [%v]",
+					test.expectedReachable,
+					check.Reachable,
+					check.FailureReason,
+				)
+			}
+			if !check.Reachable && check.FailureReason == "" {
+				t.Errorf("expected failure reason")
+			}
+		})
+	}
+}
+
+func TestTcpDialAddress(t *testing.T) {
+	var tests = map[string]struct {
+		address         string
+		expectedAddress string
+		expectedError   error
+	}{
+		"ip4": {
+			address:         "/ip4/100.20.50.30/tcp/3919",
+			expectedAddress: "100.20.50.30:3919",
+		},
+		"ip6": {
+			address:         "/ip6/::1/tcp/3919",
+			expectedAddress: "[::1]:3919",
+		},
+		"dns4": {
+			address:         "/dns4/address.com/tcp/3919",
+			expectedAddress: "address.com:3919",
+		},
+		"no tcp": {
+			address: "/ip4/100.20.50.30/udp/3919",
+			expectedError: fmt.Errorf(
+				"address [/ip4/100.20.50.30/udp/3919] is not a TCP address",
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			address, err := ma.NewMultiaddr(test.address)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dialAddress, err := tcpDialAddress(address)
+
+			if fmt.Sprint(err) != fmt.Sprint(test.expectedError) {
+				t.Fatalf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+			if dialAddress != test.expectedAddress {
+				t.Errorf(
+					"unexpected dial address\nexpected: [%v]\nactual:   [%v]",
+					test.expectedAddress,
+					dialAddress,
+				)
+			}
+		})
+	}
+}
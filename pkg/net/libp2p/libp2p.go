@@ -3,6 +3,7 @@ package libp2p
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -11,19 +12,23 @@ import (
 	"github.com/ipfs/go-log"
 
 	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
 	"github.com/keep-network/keep-core/pkg/net/watchtower"
 
 	dstore "github.com/ipfs/go-datastore"
 	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/keep-network/keep-common/pkg/persistence"
 	addrutil "github.com/libp2p/go-addr-util"
 	"github.com/libp2p/go-libp2p"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/host"
 	libp2pnet "github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	rhost "github.com/libp2p/go-libp2p/p2p/host/routed"
 	connmgr "github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
 
 	ma "github.com/multiformats/go-multiaddr"
 )
@@ -75,6 +80,112 @@ type Config struct {
 	Port               int
 	AnnouncedAddresses []string
 	DisseminationTime  int // TODO: Convert to time.Duration
+	// Relays are addresses of circuit relays used by the client if it is
+	// not publicly reachable, for example, when it is behind NAT.
+	Relays []string
+	// RelayService enables acting as a circuit relay and AutoNAT service
+	// for other peers, if the client is publicly reachable.
+	RelayService bool
+	// HolePunching enables establishing direct connections with peers
+	// behind NAT, coordinated over relayed connections.
+	HolePunching bool
+	// NATPortMap enables opening the listening port in the NAT device
+	// using UPnP or NAT-PMP.
+	NATPortMap bool
+	// MessageRateLimit is the sustained number of messages per second
+	// a single peer can publish to a broadcast channel. If not set,
+	// DefaultMessageRateLimit is used. Negative value disables the limit.
+	MessageRateLimit float64
+	// MessageRateBurst is the maximum number of messages a single peer can
+	// publish to a broadcast channel at once. If not set,
+	// DefaultMessageRateBurst is used.
+	MessageRateBurst int
+	// ReputationHalfLife is the time after which the reputation score of
+	// a peer decays by half. If not set, reputation.DefaultHalfLife is used.
+	ReputationHalfLife time.Duration
+	// ReputationBanThreshold is the negative reputation score at or below
+	// which a peer gets banned. It also determines the pubsub score
+	// thresholds: peers with a score below a quarter of the threshold are
+	// excluded from gossip, peers below a half of the threshold do not
+	// receive messages published by the client, and messages from banned
+	// peers are ignored. If not set, reputation.DefaultBanThreshold is used.
+	ReputationBanThreshold float64
+	// ReputationBanDuration is the duration of a peer ban. If not set,
+	// reputation.DefaultBanDuration is used.
+	ReputationBanDuration time.Duration
+	// ReputationEventWeights overrides the non-positive score change caused
+	// by reputation events, keyed by the event name, e.g. `invalid_message`.
+	// Events not listed keep their default weights.
+	ReputationEventWeights map[string]float64
+	// DisableMessageSignatures disables operator signatures of broadcast
+	// messages published by the client. Signatures let protocols present
+	// messages as evidence outside of the pubsub session but, if the operator
+	// key is held by a remote signer, every signature is a synchronous call
+	// to the remote signer delaying the publication of the message by the
+	// signer's round-trip time. Messages are still authenticated by pubsub.
+	DisableMessageSignatures bool
+}
+
+// messageRateLimit returns the rate limit of messages published by peers
+// to broadcast channels, as set in the config.
+func messageRateLimit(config Config) net.RateLimit {
+	limit := net.RateLimit{
+		MessagesPerSecond: config.MessageRateLimit,
+		Burst:             config.MessageRateBurst,
+	}
+
+	if limit.MessagesPerSecond == 0 {
+		limit.MessagesPerSecond = DefaultMessageRateLimit
+	}
+	if limit.Burst <= 0 {
+		limit.Burst = DefaultMessageRateBurst
+	}
+
+	return limit
+}
+
+// reputationParameters returns the parameters used to compute the reputation
+// of peers, as set in the config.
+func reputationParameters(config Config) (reputation.Parameters, error) {
+	parameters := reputation.DefaultParameters()
+
+	if config.ReputationHalfLife > 0 {
+		parameters.HalfLife = config.ReputationHalfLife
+	}
+
+	if config.ReputationBanThreshold > 0 {
+		return reputation.Parameters{}, fmt.Errorf(
+			"reputation ban threshold must be negative",
+		)
+	}
+	if config.ReputationBanThreshold < 0 {
+		parameters.BanThreshold = config.ReputationBanThreshold
+	}
+
+	if config.ReputationBanDuration > 0 {
+		parameters.BanDuration = config.ReputationBanDuration
+	}
+
+	for name, weight := range config.ReputationEventWeights {
+		event, ok := reputationEventByName(name)
+		if !ok {
+			return reputation.Parameters{}, fmt.Errorf(
+				"unknown reputation event [%v]",
+				name,
+			)
+		}
+
+		if weight > 0 {
+			return reputation.Parameters{}, fmt.Errorf(
+				"weight of reputation event [%v] must not be positive",
+				name,
+			)
+		}
+
+		parameters.EventWeights[event] = weight
+	}
+
+	return parameters, nil
 }
 
 type provider struct {
@@ -85,6 +196,10 @@ type provider struct {
 	host              host.Host
 	routing           *dht.IpfsDHT
 	disseminationTime int
+	reachability      *reachability
+
+	firewall   net.Firewall
+	handshakes *handshakeOutcomes
 
 	connectionManager *connectionManager
 }
@@ -95,6 +210,51 @@ func (p *provider) BroadcastChannelFor(name string) (net.BroadcastChannel, error
 	return p.broadcastChannelManager.getChannel(name)
 }
 
+func (p *provider) CloseBroadcastChannel(name string) error {
+	p.channelManagerMutex.Lock()
+	defer p.channelManagerMutex.Unlock()
+
+	logger.Infof("closing broadcast channel [%v]", name)
+
+	return p.broadcastChannelManager.closeChannel(name)
+}
+
+func (p *provider) BroadcastTraffic() []net.BroadcastTraffic {
+	p.broadcastChannelManager.channelsMutex.Lock()
+	channels := make([]*channel, 0, len(p.broadcastChannelManager.channels))
+	for _, channel := range p.broadcastChannelManager.channels {
+		channels = append(channels, channel)
+	}
+	p.broadcastChannelManager.channelsMutex.Unlock()
+
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].name < channels[j].name
+	})
+
+	traffic := make([]net.BroadcastTraffic, 0)
+	for _, channel := range channels {
+		traffic = append(traffic, channel.traffic.snapshot()...)
+	}
+
+	return traffic
+}
+
+func (p *provider) DroppedBroadcastMessages() map[string]uint64 {
+	p.broadcastChannelManager.channelsMutex.Lock()
+	defer p.broadcastChannelManager.channelsMutex.Unlock()
+
+	droppedMessages := make(map[string]uint64)
+	for name, channel := range p.broadcastChannelManager.channels {
+		droppedMessages[name] = channel.DroppedMessages()
+	}
+
+	return droppedMessages
+}
+
+func (p *provider) Reachability() net.Reachability {
+	return p.reachability.snapshot()
+}
+
 func (p *provider) Type() string {
 	return "libp2p"
 }
@@ -138,10 +298,25 @@ func (p *provider) BroadcastChannelForwarderFor(name string) {
 
 type connectionManager struct {
 	host.Host
+
+	reputation    *peerReputation
+	peerVersions  *peerVersions
+	operatorPeers *operatorPeers
 }
 
-func newConnectionManager(ctx context.Context, host host.Host) *connectionManager {
-	connectionManager := &connectionManager{host}
+func newConnectionManager(
+	ctx context.Context,
+	host host.Host,
+	reputation *peerReputation,
+	peerVersions *peerVersions,
+	operatorPeers *operatorPeers,
+) *connectionManager {
+	connectionManager := &connectionManager{
+		host,
+		reputation,
+		peerVersions,
+		operatorPeers,
+	}
 
 	go connectionManager.monitorConnectedPeers(ctx)
 
@@ -214,6 +389,66 @@ func (cm *connectionManager) IsConnected(address string) bool {
 	return cm.Network().Connectedness(peerInfos[0].ID) == libp2pnet.Connected
 }
 
+func (cm *connectionManager) ReportPeer(
+	connectedPeer string,
+	event net.ReputationEvent,
+) {
+	peerID, err := peer.Decode(connectedPeer)
+	if err != nil {
+		logger.Errorf("failed to decode peer ID from [%v]: [%v]", connectedPeer, err)
+		return
+	}
+
+	cm.reputation.report(peerID, event)
+}
+
+func (cm *connectionManager) PeersReputation() []net.PeerReputation {
+	return cm.reputation.store.Snapshot()
+}
+
+func (cm *connectionManager) GetPeerVersion(
+	connectedPeer string,
+) (net.PeerVersion, bool) {
+	peerID, err := peer.Decode(connectedPeer)
+	if err != nil {
+		logger.Errorf(
+			"failed to decode peer hash [%v]: [%v]",
+			connectedPeer,
+			err,
+		)
+		return net.PeerVersion{}, false
+	}
+
+	return cm.peerVersions.get(peerID)
+}
+
+func (cm *connectionManager) FindOperatorPeer(
+	ctx context.Context,
+	operatorAddress string,
+) (*net.OperatorPeer, error) {
+	if cm.operatorPeers == nil {
+		return nil, fmt.Errorf("operator peer lookup is not enabled")
+	}
+
+	addrInfo, err := cm.operatorPeers.find(ctx, operatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return operatorPeer(operatorAddress, addrInfo), nil
+}
+
+func (cm *connectionManager) ConnectOperators(
+	ctx context.Context,
+	operatorAddresses []string,
+) ([]string, error) {
+	if cm.operatorPeers == nil {
+		return nil, fmt.Errorf("operator peer lookup is not enabled")
+	}
+
+	return cm.operatorPeers.connect(ctx, operatorAddresses), nil
+}
+
 func (cm *connectionManager) monitorConnectedPeers(ctx context.Context) {
 	ticker := time.NewTicker(ConnectedPeersCheckTick)
 	defer ticker.Stop()
@@ -234,6 +469,8 @@ func (cm *connectionManager) monitorConnectedPeers(ctx context.Context) {
 // ConnectOptions allows to set various options used by libp2p.
 type ConnectOptions struct {
 	RoutingTableRefreshPeriod time.Duration
+	PeerPersistence           persistence.BasicHandle
+	OperatorAddressDerivation OperatorAddressDerivation
 }
 
 func defaultConnectOptions() *ConnectOptions {
@@ -261,6 +498,28 @@ func WithRoutingTableRefreshPeriod(period time.Duration) ConnectOption {
 	}
 }
 
+// WithPeerPersistence sets the persistence used to keep the address book of
+// known peers, their reputation and the DHT datastore across client restarts.
+// On startup, the client connects to the last-known-good peers before the
+// bootstrap peers.
+func WithPeerPersistence(handle persistence.BasicHandle) ConnectOption {
+	return func(options *ConnectOptions) {
+		options.PeerPersistence = handle
+	}
+}
+
+// WithOperatorPeerLookup enables resolving chain addresses of operators into
+// peers run by those operators. The client announces its own peer in the DHT
+// under the chain address of its operator. The provided function derives
+// the chain address of the operator from the operator's public key.
+func WithOperatorPeerLookup(
+	deriveAddress OperatorAddressDerivation,
+) ConnectOption {
+	return func(options *ConnectOptions) {
+		options.OperatorAddressDerivation = deriveAddress
+	}
+}
+
 // Connect connects to a libp2p network based on the provided config. The
 // connection is managed in part by the passed context, and provides access to
 // the functionality specified in the net.Provider interface.
@@ -274,6 +533,43 @@ func Connect(
 	firewall net.Firewall,
 	ticker *retransmission.Ticker,
 	options ...ConnectOption,
+) (net.Provider, error) {
+	networkPrivateKey, _, err := operatorPrivateKeyToNetworkKeyPair(operatorPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect(ctx, config, networkPrivateKey, firewall, ticker, options...)
+}
+
+// ConnectWithIdentitySigner connects to a libp2p network the same way as
+// Connect does but the network identity is backed by the operator's key held
+// outside of the process. All the network-level signatures, including the
+// connection handshake and pubsub messages, are produced by the provided
+// identity signer.
+func ConnectWithIdentitySigner(
+	ctx context.Context,
+	config Config,
+	identitySigner IdentitySigner,
+	firewall net.Firewall,
+	ticker *retransmission.Ticker,
+	options ...ConnectOption,
+) (net.Provider, error) {
+	networkPrivateKey, err := newRemoteNetworkKey(identitySigner)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect(ctx, config, networkPrivateKey, firewall, ticker, options...)
+}
+
+func connect(
+	ctx context.Context,
+	config Config,
+	networkPrivateKey libp2pcrypto.PrivKey,
+	firewall net.Firewall,
+	ticker *retransmission.Ticker,
+	options ...ConnectOption,
 ) (net.Provider, error) {
 	if config.DisseminationTime < 0 || config.DisseminationTime > MaximumDisseminationTime {
 		return nil, fmt.Errorf(
@@ -285,35 +581,74 @@ func Connect(
 	connectOptions := defaultConnectOptions()
 	connectOptions.apply(options...)
 
-	networkPrivateKey, _, err := operatorPrivateKeyToNetworkKeyPair(operatorPrivateKey)
+	identity, err := createIdentity(networkPrivateKey)
 	if err != nil {
 		return nil, err
 	}
 
-	identity, err := createIdentity(networkPrivateKey)
+	// Peers banned due to their bad reputation are rejected by the firewall
+	// until the ban expires.
+	reputationParameters, err := reputationParameters(config)
 	if err != nil {
 		return nil, err
 	}
 
+	reputationStore := reputation.NewStore(reputationParameters)
+	firewall = newReputationFirewall(reputationStore, firewall)
+
+	peerVersions := newPeerVersions()
+	handshakes := newHandshakeOutcomes()
+
 	host, err := discoverAndListen(
 		ctx,
 		identity,
-		config.Port,
-		config.AnnouncedAddresses,
+		config,
 		firewall,
+		peerVersions,
+		handshakes,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	reachability, err := newReachability(ctx, host, config)
+	if err != nil {
+		return nil, err
+	}
+
 	host.Network().Notify(buildNotifiee())
+	host.Network().Notify(peerVersions.notifiee())
 
-	broadcastChannelManager, err := newChannelManager(ctx, identity, host, ticker)
+	peerReputation := newPeerReputation(reputationStore, host)
+
+	broadcastChannelManager, err := newChannelManager(
+		ctx,
+		identity,
+		host,
+		ticker,
+		peerReputation,
+		peerVersions,
+		messageRateLimit(config),
+		config.DisableMessageSignatures,
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	dhtDatastore := dssync.MutexWrap(dstore.NewMapDatastore())
+
+	var addressBook *addressBook
+	if connectOptions.PeerPersistence != nil {
+		addressBook = newAddressBook(connectOptions.PeerPersistence, identity.id)
+		if err := addressBook.load(
+			ctx,
+			reputationStore,
+			dhtDatastore,
+		); err != nil {
+			logger.Warnf("could not load address book: [%v]", err)
+		}
+	}
+
 	router, err := dht.New(
 		ctx,
 		host,
@@ -333,6 +668,35 @@ func Connect(
 		host:                    rhost.Wrap(host, router),
 		routing:                 router,
 		disseminationTime:       config.DisseminationTime,
+		reachability:            reachability,
+		firewall:                firewall,
+		handshakes:              handshakes,
+	}
+
+	if addressBook != nil {
+		// Last-known-good peers are connected first so that the client can
+		// rejoin the network even if the bootstrap peers are unavailable.
+		lastKnownGoodPeers := addressBook.lastKnownGoodPeers(reputationStore)
+		if len(lastKnownGoodPeers) > 0 {
+			connected := connectLastKnownGoodPeers(
+				ctx,
+				provider.host,
+				lastKnownGoodPeers,
+			)
+
+			logger.Infof(
+				"connected to [%v] out of [%v] last-known-good peers",
+				connected,
+				len(lastKnownGoodPeers),
+			)
+		}
+
+		go addressBook.persistPeriodically(
+			ctx,
+			provider.host,
+			reputationStore,
+			dhtDatastore,
+		)
 	}
 
 	if len(config.Peers) == 0 {
@@ -343,7 +707,24 @@ func Connect(
 		return nil, fmt.Errorf("bootstrap failed: [%v]", err)
 	}
 
-	provider.connectionManager = newConnectionManager(ctx, provider.host)
+	var operatorPeers *operatorPeers
+	if connectOptions.OperatorAddressDerivation != nil {
+		operatorPeers = newOperatorPeers(
+			provider.host,
+			router,
+			connectOptions.OperatorAddressDerivation,
+		)
+
+		go operatorPeers.publishPeriodically(ctx)
+	}
+
+	provider.connectionManager = newConnectionManager(
+		ctx,
+		provider.host,
+		peerReputation,
+		peerVersions,
+		operatorPeers,
+	)
 
 	// Instantiates and starts the connection management background process.
 	watchtower.NewGuard(
@@ -360,14 +741,15 @@ func Connect(
 func discoverAndListen(
 	ctx context.Context,
 	identity *identity,
-	port int,
-	announcedAddresses []string,
+	config Config,
 	firewall net.Firewall,
+	peerVersions *peerVersions,
+	handshakes *handshakeOutcomes,
 ) (host.Host, error) {
 	var err error
 
 	// Get available network ifaces, for a specific port, as multiaddrs
-	addrs, err := getListenAddrs(port)
+	addrs, err := getListenAddrs(config.Port)
 	if err != nil {
 		return nil, err
 	}
@@ -375,7 +757,10 @@ func discoverAndListen(
 	transport, err := newEncryptedAuthenticatedTransport(
 		identity.privKey,
 		protocolKeep,
+		localVersion(),
 		firewall,
+		peerVersions,
+		handshakes,
 	)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -403,7 +788,14 @@ func discoverAndListen(
 		libp2p.ConnectionManager(connectionManager),
 	}
 
-	if addresses := parseMultiaddresses(announcedAddresses); len(addresses) > 0 {
+	if _, isRemote := identity.privKey.(*remoteNetworkKey); isRemote {
+		// The client listens only on TCP. Other default transports, like
+		// QUIC, require the raw private key bytes that are not available
+		// for a remote key.
+		options = append(options, libp2p.Transport(tcp.NewTCPTransport))
+	}
+
+	if addresses := parseMultiaddresses(config.AnnouncedAddresses); len(addresses) > 0 {
 		addressFactory := func(addrs []ma.Multiaddr) []ma.Multiaddr {
 			logger.Debugf(
 				"replacing default announced addresses [%v] with [%v]",
@@ -415,6 +807,12 @@ func discoverAndListen(
 		options = append(options, libp2p.AddrsFactory(addressFactory))
 	}
 
+	natOptions, err := natTraversalOptions(config)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, natOptions...)
+
 	return libp2p.New(options...)
 }
 
@@ -154,6 +154,57 @@ func TestSendReceive(t *testing.T) {
 	}
 }
 
+func TestCloseBroadcastChannel(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	name := "testchannel"
+
+	operatorPrivateKey, _, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := Connect(
+		ctx,
+		generateDeterministicNetworkConfig(),
+		operatorPrivateKey,
+		firewall.Disabled,
+		idleTicker(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broadcastChannel, err := provider.BroadcastChannelFor(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closer, ok := provider.(net.BroadcastChannelCloser)
+	if !ok {
+		t.Fatal("provider does not close broadcast channels")
+	}
+
+	if err := closer.CloseBroadcastChannel(name); err != nil {
+		t.Fatal(err)
+	}
+
+	// Closing the channel for the second time is a no-op.
+	if err := closer.CloseBroadcastChannel(name); err != nil {
+		t.Fatal(err)
+	}
+
+	reopenedChannel, err := provider.BroadcastChannelFor(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reopenedChannel == broadcastChannel {
+		t.Errorf("expected a new channel after closing the previous one")
+	}
+}
+
 func TestProviderSetAnnouncedAddresses(t *testing.T) {
 	ctx, cancel := newTestContext()
 	defer cancel()
@@ -0,0 +1,179 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/event"
+	"github.com/libp2p/go-libp2p-core/host"
+	libp2pnet "github.com/libp2p/go-libp2p-core/network"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// natTraversalOptions returns libp2p options enabling NAT traversal features
+// turned on in the config. Connections established through relays and hole
+// punching are secured with the same authenticated transport as direct
+// connections so the firewall is applied to them as well.
+func natTraversalOptions(config Config) ([]libp2p.Option, error) {
+	options := make([]libp2p.Option, 0)
+
+	if len(config.Relays) > 0 {
+		relays, err := extractMultiAddrFromPeers(config.Relays)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse relays: [%v]", err)
+		}
+
+		// Reservations on the static relays are made once the client
+		// detects it is not publicly reachable.
+		options = append(
+			options,
+			libp2p.EnableAutoRelay(),
+			libp2p.StaticRelays(relays),
+		)
+	}
+
+	if config.RelayService {
+		// Both services are started only if the client detects it is
+		// publicly reachable.
+		options = append(
+			options,
+			libp2p.EnableRelayService(),
+			libp2p.EnableNATService(),
+		)
+	}
+
+	if config.HolePunching {
+		options = append(options, libp2p.EnableHolePunching())
+	}
+
+	if config.NATPortMap {
+		options = append(options, libp2p.NATPortMap())
+	}
+
+	return options, nil
+}
+
+// reachability tracks the reachability of the client from the outside
+// network, as detected by the AutoNAT subsystem.
+type reachability struct {
+	host   host.Host
+	config Config
+
+	mutex  sync.RWMutex
+	status libp2pnet.Reachability
+}
+
+// newReachability creates the reachability tracker of the given host and
+// starts observing reachability changes until the context is done.
+func newReachability(
+	ctx context.Context,
+	host host.Host,
+	config Config,
+) (*reachability, error) {
+	subscription, err := host.EventBus().Subscribe(
+		new(event.EvtLocalReachabilityChanged),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not subscribe for reachability changes: [%v]",
+			err,
+		)
+	}
+
+	reachability := &reachability{
+		host:   host,
+		config: config,
+		status: libp2pnet.ReachabilityUnknown,
+	}
+
+	go func() {
+		defer subscription.Close()
+
+		for {
+			select {
+			case e, ok := <-subscription.Out():
+				if !ok {
+					return
+				}
+
+				reachabilityChanged, ok := e.(event.EvtLocalReachabilityChanged)
+				if !ok {
+					continue
+				}
+
+				reachability.setStatus(reachabilityChanged.Reachability)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return reachability, nil
+}
+
+func (r *reachability) setStatus(status libp2pnet.Reachability) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	logger.Infof("network reachability changed to [%v]", status)
+
+	r.status = status
+}
+
+// snapshot returns the current reachability of the client.
+func (r *reachability) snapshot() net.Reachability {
+	r.mutex.RLock()
+	status := r.status
+	r.mutex.RUnlock()
+
+	listenAddresses := make([]string, 0)
+	for _, address := range r.host.Network().ListenAddresses() {
+		listenAddresses = append(listenAddresses, address.String())
+	}
+
+	announcedAddresses := make([]string, 0)
+	relayAddresses := make([]string, 0)
+	for _, address := range r.host.Addrs() {
+		if isRelayAddress(address) {
+			relayAddresses = append(relayAddresses, address.String())
+			continue
+		}
+
+		announcedAddresses = append(announcedAddresses, address.String())
+	}
+
+	return net.Reachability{
+		Status:             reachabilityStatus(status),
+		ListenAddresses:    listenAddresses,
+		AnnouncedAddresses: announcedAddresses,
+		RelayAddresses:     relayAddresses,
+		Relays:             r.config.Relays,
+		RelayService:       r.config.RelayService,
+		HolePunching:       r.config.HolePunching,
+		NATPortMap:         r.config.NATPortMap,
+	}
+}
+
+// isRelayAddress checks whether the address is an address of a circuit
+// relay.
+func isRelayAddress(address ma.Multiaddr) bool {
+	_, err := address.ValueForProtocol(ma.P_CIRCUIT)
+	return err == nil
+}
+
+// reachabilityStatus converts the libp2p reachability into its network-level
+// representation.
+func reachabilityStatus(status libp2pnet.Reachability) net.ReachabilityStatus {
+	switch status {
+	case libp2pnet.ReachabilityPublic:
+		return net.ReachabilityPublic
+	case libp2pnet.ReachabilityPrivate:
+		return net.ReachabilityPrivate
+	default:
+		return net.ReachabilityUnknown
+	}
+}
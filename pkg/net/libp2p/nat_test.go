@@ -0,0 +1,247 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	eventbus "github.com/libp2p/go-eventbus"
+	"github.com/libp2p/go-libp2p-core/event"
+	libp2pnet "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/client"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestRelayedConnection(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	relay, _ := connectNATTestProvider(
+		ctx,
+		t,
+		Config{Port: 7401, RelayService: true},
+		firewall.Disabled,
+	)
+	private, privatePublicKey := connectNATTestProvider(
+		ctx,
+		t,
+		Config{Port: 7402, HolePunching: true},
+		firewall.Disabled,
+	)
+
+	// The relay service is started once the relay detects it is publicly
+	// reachable.
+	emitReachability(t, relay, libp2pnet.ReachabilityPublic)
+
+	relayAddress, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/7401")
+	if err != nil {
+		t.Fatal(err)
+	}
+	relayInfo := peer.AddrInfo{
+		ID:    relay.identity.id,
+		Addrs: []ma.Multiaddr{relayAddress},
+	}
+
+	reserveRelaySlot(ctx, t, private, relayInfo)
+
+	circuitAddress, err := ma.NewMultiaddr(
+		fmt.Sprintf("/ip4/127.0.0.1/tcp/7401/p2p/%s/p2p-circuit", relay.identity.id),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateInfo := peer.AddrInfo{
+		ID:    private.identity.id,
+		Addrs: []ma.Multiaddr{circuitAddress},
+	}
+
+	t.Run("connection approved by firewall", func(t *testing.T) {
+		dialer, _ := connectNATTestProvider(
+			ctx,
+			t,
+			Config{Port: 7403},
+			firewall.Disabled,
+		)
+
+		if err := dialer.host.Connect(ctx, privateInfo); err != nil {
+			t.Fatal(err)
+		}
+
+		// Once the relayed connection is established, hole punching may
+		// establish direct connections as well.
+		relayed := false
+		for _, connection := range dialer.host.Network().ConnsToPeer(
+			private.identity.id,
+		) {
+			if isRelayAddress(connection.RemoteMultiaddr()) {
+				relayed = true
+			}
+		}
+		if !relayed {
+			t.Errorf("expected relayed connection")
+		}
+
+		// The version of the peer is recorded only after the connection
+		// handshake is completed.
+		if _, ok := dialer.connectionManager.GetPeerVersion(
+			private.identity.id.String(),
+		); !ok {
+			t.Errorf("relayed connection was not authenticated")
+		}
+	})
+
+	t.Run("connection rejected by firewall", func(t *testing.T) {
+		dialer, _ := connectNATTestProvider(
+			ctx,
+			t,
+			Config{Port: 7404},
+			&rejectingFirewall{privatePublicKey},
+		)
+
+		if err := dialer.host.Connect(ctx, privateInfo); err == nil {
+			t.Fatal("expected relayed connection to be rejected")
+		}
+	})
+
+	status := relay.Reachability().Status
+	if status != net.ReachabilityPublic {
+		t.Errorf("unexpected relay reachability: [%v]", status)
+	}
+}
+
+func TestReachability(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	relays := []string{
+		"/ip4/127.0.0.1/tcp/7411/ipfs/16Uiu2HAmFRJtCWfdXhZEZHWb4tUpH1QMMgzH1oiamCfUuK6NgqWX",
+	}
+
+	provider, _ := connectNATTestProvider(
+		ctx,
+		t,
+		Config{Port: 7410, Relays: relays, NATPortMap: true},
+		firewall.Disabled,
+	)
+
+	reachability := provider.Reachability()
+	if reachability.Status != net.ReachabilityUnknown {
+		t.Errorf("unexpected reachability: [%v]", reachability.Status)
+	}
+	if len(reachability.ListenAddresses) == 0 {
+		t.Errorf("expected listen addresses")
+	}
+	if len(reachability.Relays) != 1 || !reachability.NATPortMap {
+		t.Errorf("unexpected NAT traversal features: [%+v]", reachability)
+	}
+
+	emitReachability(t, provider, libp2pnet.ReachabilityPrivate)
+
+	waitFor(ctx, t, func() bool {
+		return provider.Reachability().Status == net.ReachabilityPrivate
+	})
+}
+
+func TestNATTraversalOptions_InvalidRelay(t *testing.T) {
+	_, err := natTraversalOptions(Config{Relays: []string{"/ip4/127.0.0.1"}})
+	if err == nil {
+		t.Fatal("expected error for relay without network id")
+	}
+}
+
+func connectNATTestProvider(
+	ctx context.Context,
+	t *testing.T,
+	config Config,
+	firewall net.Firewall,
+) (*provider, *operator.PublicKey) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(
+		DefaultCurve,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	netProvider, err := Connect(
+		ctx,
+		config,
+		operatorPrivateKey,
+		firewall,
+		idleTicker(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return netProvider.(*provider), operatorPublicKey
+}
+
+// emitReachability emits the reachability change event on the provider's
+// host, as the AutoNAT subsystem does.
+func emitReachability(
+	t *testing.T,
+	provider *provider,
+	reachability libp2pnet.Reachability,
+) {
+	emitter, err := provider.host.EventBus().Emitter(
+		new(event.EvtLocalReachabilityChanged),
+		eventbus.Stateful,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer emitter.Close()
+
+	if err := emitter.Emit(event.EvtLocalReachabilityChanged{
+		Reachability: reachability,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// reserveRelaySlot reserves a slot on the relay for the provider. Attempts are
+// repeated until the relay service is started.
+func reserveRelaySlot(
+	ctx context.Context,
+	t *testing.T,
+	provider *provider,
+	relayInfo peer.AddrInfo,
+) {
+	waitFor(ctx, t, func() bool {
+		_, err := client.Reserve(ctx, provider.host, relayInfo)
+		return err == nil
+	})
+}
+
+func waitFor(ctx context.Context, t *testing.T, condition func() bool) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for !condition() {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			t.Fatal("condition not met before timeout")
+		}
+	}
+}
+
+type rejectingFirewall struct {
+	rejected *operator.PublicKey
+}
+
+func (rf *rejectingFirewall) Validate(
+	remotePeerPublicKey *operator.PublicKey,
+) error {
+	if remotePeerPublicKey.X.Cmp(rf.rejected.X) == 0 {
+		return fmt.Errorf("remote peer rejected")
+	}
+
+	return nil
+}
@@ -0,0 +1,334 @@
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/host"
+	libp2pnet "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	"github.com/libp2p/go-libp2p-core/routing"
+	"github.com/multiformats/go-multihash"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+const (
+	// operatorRecordNamespace is the namespace of DHT provider records
+	// announced by operators under their chain addresses.
+	operatorRecordNamespace = "keep-operator"
+	// OperatorRecordPublishPeriod is the period of announcing the client's
+	// peer as the provider of the record of the client's operator in the DHT.
+	// Provider records are republished well before they expire in the DHT
+	// so that the peer of the operator is always resolvable.
+	OperatorRecordPublishPeriod = 1 * time.Hour
+	// operatorRecordFirstPublishDelay is the delay of the first announcement
+	// of the client's peer in the DHT, after the client is connected.
+	operatorRecordFirstPublishDelay = 1 * time.Minute
+	// OperatorDialTimeout is the maximum time of dialing the peer of a single
+	// operator.
+	OperatorDialTimeout = 30 * time.Second
+)
+
+// OperatorAddressDerivation derives the chain address of the operator from
+// the operator's public key.
+type OperatorAddressDerivation func(publicKey *operator.PublicKey) (string, error)
+
+// operatorRecordKey returns the DHT key under which the peer of the operator
+// with the given chain address is announced.
+func operatorRecordKey(operatorAddress string) (cid.Cid, error) {
+	hash, err := multihash.Sum(
+		[]byte(fmt.Sprintf("/%s/%s", operatorRecordNamespace, operatorAddress)),
+		multihash.SHA2_256,
+		-1,
+	)
+	if err != nil {
+		return cid.Undef, fmt.Errorf("could not hash record key: [%v]", err)
+	}
+
+	return cid.NewCidV1(cid.Raw, hash), nil
+}
+
+// operatorPeers resolves chain addresses of operators into peers run by
+// those operators. Peers are looked up in the peer store first, based on
+// public keys embedded in their identifiers. Operators whose peers are not
+// known yet are looked up in the DHT, where every client announces its peer
+// as the provider of the record of its operator. Since peer identifiers
+// embed public keys, a peer announced in the DHT is accepted only if the
+// chain address derived from its public key matches the operator's address.
+// The connection handshake ensures the dialed peer holds the operator's key.
+type operatorPeers struct {
+	host          host.Host
+	routing       routing.ContentRouting
+	deriveAddress OperatorAddressDerivation
+
+	cacheMutex sync.Mutex
+	cache      map[string]peer.ID
+}
+
+func newOperatorPeers(
+	host host.Host,
+	routing routing.ContentRouting,
+	deriveAddress OperatorAddressDerivation,
+) *operatorPeers {
+	return &operatorPeers{
+		host:          host,
+		routing:       routing,
+		deriveAddress: deriveAddress,
+		cache:         make(map[string]peer.ID),
+	}
+}
+
+// operatorAddress derives the chain address of the operator running
+// the given peer.
+func (op *operatorPeers) operatorAddress(peerID peer.ID) (string, error) {
+	networkPublicKey, err := peerID.ExtractPublicKey()
+	if err != nil {
+		return "", fmt.Errorf(
+			"could not extract public key of peer [%v]: [%v]",
+			peerID,
+			err,
+		)
+	}
+
+	operatorPublicKey, err := networkPublicKeyToOperatorPublicKey(
+		networkPublicKey,
+	)
+	if err != nil {
+		return "", fmt.Errorf(
+			"could not convert public key of peer [%v]: [%v]",
+			peerID,
+			err,
+		)
+	}
+
+	return op.deriveAddress(operatorPublicKey)
+}
+
+// publish announces the client's peer as the provider of the record of
+// the client's operator in the DHT.
+func (op *operatorPeers) publish(ctx context.Context) error {
+	operatorAddress, err := op.operatorAddress(op.host.ID())
+	if err != nil {
+		return err
+	}
+
+	key, err := operatorRecordKey(operatorAddress)
+	if err != nil {
+		return err
+	}
+
+	return op.routing.Provide(ctx, key, true)
+}
+
+// publishPeriodically announces the client's peer in the DHT every
+// OperatorRecordPublishPeriod, until the context is done. The first
+// announcement is delayed so that the routing table can be populated.
+func (op *operatorPeers) publishPeriodically(ctx context.Context) {
+	timer := time.NewTimer(operatorRecordFirstPublishDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if err := op.publish(ctx); err != nil {
+				logger.Warnf("could not publish operator record: [%v]", err)
+			}
+
+			timer.Reset(OperatorRecordPublishPeriod)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// find resolves the chain address of the operator into the peer run by that
+// operator.
+func (op *operatorPeers) find(
+	ctx context.Context,
+	operatorAddress string,
+) (peer.AddrInfo, error) {
+	op.cacheMutex.Lock()
+	peerID, ok := op.cache[operatorAddress]
+	op.cacheMutex.Unlock()
+
+	if !ok {
+		peerID, ok = op.findInPeerstore(operatorAddress)
+	}
+
+	if !ok {
+		var err error
+		peerID, err = op.findInRouting(ctx, operatorAddress)
+		if err != nil {
+			return peer.AddrInfo{}, err
+		}
+	}
+
+	op.cacheMutex.Lock()
+	op.cache[operatorAddress] = peerID
+	op.cacheMutex.Unlock()
+
+	return op.host.Peerstore().PeerInfo(peerID), nil
+}
+
+// findInPeerstore looks for the peer run by the operator with the given chain
+// address among the peers known to the client.
+func (op *operatorPeers) findInPeerstore(
+	operatorAddress string,
+) (peer.ID, bool) {
+	for _, peerID := range op.host.Peerstore().Peers() {
+		peerOperatorAddress, err := op.operatorAddress(peerID)
+		if err != nil {
+			continue
+		}
+
+		if peerOperatorAddress == operatorAddress {
+			return peerID, true
+		}
+	}
+
+	return "", false
+}
+
+// findInRouting looks for the peer announced in the DHT as the provider of
+// the record of the operator with the given chain address. Addresses of
+// the found peer are added to the peer store.
+func (op *operatorPeers) findInRouting(
+	ctx context.Context,
+	operatorAddress string,
+) (peer.ID, error) {
+	key, err := operatorRecordKey(operatorAddress)
+	if err != nil {
+		return "", err
+	}
+
+	findCtx, cancelFindCtx := context.WithCancel(ctx)
+	defer cancelFindCtx()
+
+	for provider := range op.routing.FindProvidersAsync(findCtx, key, 0) {
+		providerOperatorAddress, err := op.operatorAddress(provider.ID)
+		if err != nil {
+			logger.Debugf(
+				"could not get operator address of provider [%v]: [%v]",
+				provider.ID,
+				err,
+			)
+			continue
+		}
+
+		// Anyone can announce itself as the provider of any record so
+		// providers not run by the operator are skipped.
+		if providerOperatorAddress != operatorAddress {
+			continue
+		}
+
+		op.host.Peerstore().AddAddrs(
+			provider.ID,
+			provider.Addrs,
+			peerstore.AddressTTL,
+		)
+
+		return provider.ID, nil
+	}
+
+	return "", fmt.Errorf(
+		"could not find peer of operator [%v]",
+		operatorAddress,
+	)
+}
+
+// connect dials peers of the given operators the client is not connected
+// with yet. Operators are dialed concurrently, each with the
+// OperatorDialTimeout. Returns chain addresses of operators whose peers could
+// not be reached, in the order they were passed.
+func (op *operatorPeers) connect(
+	ctx context.Context,
+	operatorAddresses []string,
+) []string {
+	reachable := make([]bool, len(operatorAddresses))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(operatorAddresses))
+
+	for i, operatorAddress := range operatorAddresses {
+		i := i
+		operatorAddress := operatorAddress
+
+		go func() {
+			defer wg.Done()
+
+			dialCtx, cancelDialCtx := context.WithTimeout(
+				ctx,
+				OperatorDialTimeout,
+			)
+			defer cancelDialCtx()
+
+			if err := op.dial(dialCtx, operatorAddress); err != nil {
+				logger.Warnf(
+					"could not connect operator [%v]: [%v]",
+					operatorAddress,
+					err,
+				)
+				return
+			}
+
+			reachable[i] = true
+		}()
+	}
+
+	wg.Wait()
+
+	unreachable := make([]string, 0)
+	for i, operatorAddress := range operatorAddresses {
+		if !reachable[i] {
+			unreachable = append(unreachable, operatorAddress)
+		}
+	}
+
+	return unreachable
+}
+
+// dial connects the peer run by the operator with the given chain address
+// unless the client is already connected with it.
+func (op *operatorPeers) dial(
+	ctx context.Context,
+	operatorAddress string,
+) error {
+	addrInfo, err := op.find(ctx, operatorAddress)
+	if err != nil {
+		return err
+	}
+
+	if addrInfo.ID == op.host.ID() ||
+		op.host.Network().Connectedness(addrInfo.ID) == libp2pnet.Connected {
+		return nil
+	}
+
+	return op.host.Connect(ctx, addrInfo)
+}
+
+// operatorPeer converts the peer run by the operator with the given chain
+// address into its network-level representation.
+func operatorPeer(
+	operatorAddress string,
+	addrInfo peer.AddrInfo,
+) *net.OperatorPeer {
+	multiaddrs := make([]string, 0, len(addrInfo.Addrs))
+	for _, multiaddr := range addrInfo.Addrs {
+		multiaddrs = append(
+			multiaddrs,
+			multiaddressWithIdentity(multiaddr, addrInfo.ID),
+		)
+	}
+
+	return &net.OperatorPeer{
+		OperatorAddress: operatorAddress,
+		PeerID:          addrInfo.ID.String(),
+		Multiaddrs:      multiaddrs,
+	}
+}
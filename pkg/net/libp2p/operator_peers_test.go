@@ -0,0 +1,298 @@
+package libp2p
+
+import (
+	"context"
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestOperatorPeers_FindInRouting(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	netProvider := connectTestProvider(ctx, t)
+	host := netProvider.(*provider).host
+
+	privateKey, operatorAddress := generateTestOperator(t)
+	otherPrivateKey, _ := generateTestOperator(t)
+
+	peerID, err := peer.IDFromPrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPeerID, err := peer.IDFromPrivateKey(otherPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	multiaddr, err := ma.NewMultiaddr("/ip4/10.0.0.1/tcp/3919")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherMultiaddr, err := ma.NewMultiaddr("/ip4/10.0.0.2/tcp/3919")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := operatorRecordKey(operatorAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	routing := &mockContentRouting{
+		providers: map[string][]peer.AddrInfo{
+			key.KeyString(): {
+				// Peer not run by the operator, announcing itself as
+				// the operator's peer.
+				{ID: otherPeerID, Addrs: []ma.Multiaddr{otherMultiaddr}},
+				{ID: peerID, Addrs: []ma.Multiaddr{multiaddr}},
+			},
+		},
+	}
+
+	operatorPeers := newOperatorPeers(
+		host,
+		routing,
+		testOperatorAddressDerivation,
+	)
+
+	addrInfo, err := operatorPeers.find(ctx, operatorAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedAddrInfo := peer.AddrInfo{
+		ID:    peerID,
+		Addrs: []ma.Multiaddr{multiaddr},
+	}
+	if !reflect.DeepEqual(expectedAddrInfo, addrInfo) {
+		t.Errorf(
+			"unexpected peer\nexpected: [%v]\nactual:   [%v]",
+			expectedAddrInfo,
+			addrInfo,
+		)
+	}
+
+	if len(host.Peerstore().Addrs(otherPeerID)) != 0 {
+		t.Errorf("addresses of the spoofing peer should not be stored")
+	}
+
+	_, unknownOperatorAddress := generateTestOperator(t)
+	if _, err := operatorPeers.find(ctx, unknownOperatorAddress); err == nil {
+		t.Errorf("expected error for unknown operator")
+	}
+}
+
+func TestOperatorPeers_Publish(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	netProvider := connectTestProvider(ctx, t)
+	host := netProvider.(*provider).host
+
+	routing := &mockContentRouting{}
+
+	operatorPeers := newOperatorPeers(
+		host,
+		routing,
+		testOperatorAddressDerivation,
+	)
+
+	if err := operatorPeers.publish(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	operatorAddress, err := operatorPeers.operatorAddress(host.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := operatorRecordKey(operatorAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedProvided := []string{key.KeyString()}
+	if !reflect.DeepEqual(expectedProvided, routing.provided) {
+		t.Errorf(
+			"unexpected provided keys\nexpected: [%v]\nactual:   [%v]",
+			expectedProvided,
+			routing.provided,
+		)
+	}
+}
+
+func TestFindOperatorPeer(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	netProvider := connectTestProvider(ctx, t)
+
+	resolver, ok := netProvider.ConnectionManager().(net.OperatorPeerResolver)
+	if !ok {
+		t.Fatal("connection manager is not an operator peer resolver")
+	}
+
+	privateKey, operatorAddress := generateTestOperator(t)
+	peerID, err := peer.IDFromPrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	multiaddr, err := ma.NewMultiaddr("/ip4/10.0.0.1/tcp/3919")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	netProvider.(*provider).host.Peerstore().AddAddr(
+		peerID,
+		multiaddr,
+		peerstore.PermanentAddrTTL,
+	)
+
+	operatorPeer, err := resolver.FindOperatorPeer(ctx, operatorAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedOperatorPeer := &net.OperatorPeer{
+		OperatorAddress: operatorAddress,
+		PeerID:          peerID.String(),
+		Multiaddrs: []string{
+			multiaddressWithIdentity(multiaddr, peerID),
+		},
+	}
+	if !reflect.DeepEqual(expectedOperatorPeer, operatorPeer) {
+		t.Errorf(
+			"unexpected operator peer\nexpected: [%+v]\nactual:   [%+v]",
+			expectedOperatorPeer,
+			operatorPeer,
+		)
+	}
+}
+
+func TestFindOperatorPeer_NotEnabled(t *testing.T) {
+	ctx, cancel := newTestContext()
+	defer cancel()
+
+	operatorPrivateKey, _, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := Connect(
+		ctx,
+		generateDeterministicNetworkConfig(),
+		operatorPrivateKey,
+		firewall.Disabled,
+		idleTicker(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := provider.ConnectionManager().(net.OperatorPeerResolver)
+
+	if _, err := resolver.FindOperatorPeer(ctx, "0xAA"); err == nil {
+		t.Errorf("expected error for disabled operator peer lookup")
+	}
+	if _, err := resolver.ConnectOperators(
+		ctx,
+		[]string{"0xAA"},
+	); err == nil {
+		t.Errorf("expected error for disabled operator peer lookup")
+	}
+}
+
+// testOperatorAddressDerivation derives the operator address as the hex
+// encoding of the compressed operator public key.
+func testOperatorAddressDerivation(
+	publicKey *operator.PublicKey,
+) (string, error) {
+	return hex.EncodeToString(operator.MarshalCompressed(publicKey)), nil
+}
+
+func generateTestOperator(t *testing.T) (libp2pcrypto.PrivKey, string) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(
+		DefaultCurve,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPrivateKey, _, err := operatorPrivateKeyToNetworkKeyPair(
+		operatorPrivateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operatorAddress, err := testOperatorAddressDerivation(operatorPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return networkPrivateKey, operatorAddress
+}
+
+func connectTestProvider(ctx context.Context, t *testing.T) net.Provider {
+	operatorPrivateKey, _, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	netProvider, err := Connect(
+		ctx,
+		generateDeterministicNetworkConfig(),
+		operatorPrivateKey,
+		firewall.Disabled,
+		idleTicker(),
+		WithOperatorPeerLookup(testOperatorAddressDerivation),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return netProvider
+}
+
+type mockContentRouting struct {
+	providers map[string][]peer.AddrInfo
+	provided  []string
+}
+
+func (mcr *mockContentRouting) Provide(
+	ctx context.Context,
+	key cid.Cid,
+	announce bool,
+) error {
+	mcr.provided = append(mcr.provided, key.KeyString())
+	return nil
+}
+
+func (mcr *mockContentRouting) FindProvidersAsync(
+	ctx context.Context,
+	key cid.Cid,
+	count int,
+) <-chan peer.AddrInfo {
+	providers := mcr.providers[key.KeyString()]
+
+	providersChan := make(chan peer.AddrInfo, len(providers))
+	for _, provider := range providers {
+		providersChan <- provider
+	}
+	close(providersChan)
+
+	return providersChan
+}
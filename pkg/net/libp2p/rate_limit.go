@@ -0,0 +1,247 @@
+package libp2p
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"golang.org/x/time/rate"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+const (
+	// DefaultMessageRateLimit is the default sustained number of messages
+	// per second a single peer can publish to a broadcast channel.
+	DefaultMessageRateLimit = 100
+	// DefaultMessageRateBurst is the default maximum number of messages
+	// a single peer can publish to a broadcast channel at once.
+	DefaultMessageRateBurst = 1000
+
+	// messageLimitsRetention is the time after which the state of limits
+	// of a peer or a quota scope is discarded if no messages from the peer
+	// or within the quota scope were received.
+	messageLimitsRetention = 10 * time.Minute
+	// messageLimitsPruningPeriod is the minimum period between subsequent
+	// discards of the state of limits.
+	messageLimitsPruningPeriod = 1 * time.Minute
+)
+
+// quotaKey identifies messages of the given type published by the given
+// author within the given quota scope.
+type quotaKey struct {
+	author      peer.ID
+	messageType string
+	scope       string
+}
+
+// quotaUsage holds sequence numbers of distinct messages counted against
+// the quota.
+type quotaUsage struct {
+	seqnos   map[uint64]bool
+	lastSeen time.Time
+}
+
+// rateLimiterEntry holds the token bucket of a single peer along with
+// sequence numbers of messages already counted against it.
+type rateLimiterEntry struct {
+	limiter  *rate.Limiter
+	seqnos   map[uint64]time.Time
+	lastSeen time.Time
+}
+
+// messageRateLimiter enforces limits of messages published by peers to
+// a single broadcast channel. The rate of messages is limited per author
+// using a token bucket. Additionally, the number of distinct messages of
+// a given type an author can publish within a quota scope can be limited.
+// Retransmissions of a message share the sequence number of the original
+// message so they are counted neither against the rate limit nor against
+// the quota.
+type messageRateLimiter struct {
+	mutex sync.Mutex
+
+	limit    net.RateLimit
+	limiters map[peer.ID]*rateLimiterEntry
+
+	quotas     map[string]int
+	quotaUsage map[quotaKey]*quotaUsage
+
+	lastPruning time.Time
+	now         func() time.Time
+}
+
+func newMessageRateLimiter(limit net.RateLimit) *messageRateLimiter {
+	return &messageRateLimiter{
+		limit:       limit,
+		limiters:    make(map[peer.ID]*rateLimiterEntry),
+		quotas:      make(map[string]int),
+		quotaUsage:  make(map[quotaKey]*quotaUsage),
+		lastPruning: time.Now(),
+		now:         time.Now,
+	}
+}
+
+// setLimit sets the rate limit of messages published by every peer. Limits
+// of peers already tracked are updated as well.
+func (mrl *messageRateLimiter) setLimit(limit net.RateLimit) {
+	mrl.mutex.Lock()
+	defer mrl.mutex.Unlock()
+
+	mrl.limit = limit
+
+	now := mrl.now()
+	for _, entry := range mrl.limiters {
+		entry.limiter.SetLimitAt(now, rate.Limit(limit.MessagesPerSecond))
+		entry.limiter.SetBurstAt(now, limit.Burst)
+	}
+}
+
+// setQuota sets the maximum number of distinct messages of the given type
+// an author can publish within a single quota scope. Non-positive quota
+// removes the limit.
+func (mrl *messageRateLimiter) setQuota(messageType string, quota int) {
+	mrl.mutex.Lock()
+	defer mrl.mutex.Unlock()
+
+	if quota <= 0 {
+		delete(mrl.quotas, messageType)
+		return
+	}
+
+	mrl.quotas[messageType] = quota
+}
+
+// allow checks whether the message with the given sequence number published
+// by the given author fits in the rate limit. Only the first message with
+// the given sequence number is counted so retransmissions are always allowed.
+// Non-positive rate disables the limit.
+func (mrl *messageRateLimiter) allow(author peer.ID, seqno uint64) bool {
+	mrl.mutex.Lock()
+	defer mrl.mutex.Unlock()
+
+	if mrl.limit.MessagesPerSecond <= 0 {
+		return true
+	}
+
+	now := mrl.now()
+	mrl.prune(now)
+
+	entry, ok := mrl.limiters[author]
+	if !ok {
+		entry = &rateLimiterEntry{
+			limiter: rate.NewLimiter(
+				rate.Limit(mrl.limit.MessagesPerSecond),
+				mrl.limit.Burst,
+			),
+			seqnos: make(map[uint64]time.Time),
+		}
+		mrl.limiters[author] = entry
+	}
+
+	entry.lastSeen = now
+
+	if _, ok := entry.seqnos[seqno]; ok {
+		return true
+	}
+
+	if !entry.limiter.AllowN(now, 1) {
+		return false
+	}
+
+	entry.seqnos[seqno] = now
+
+	return true
+}
+
+// checkQuota counts the message with the given sequence number against
+// the quota of its type within the given scope. It returns an error if
+// the message exceeds the quota.
+func (mrl *messageRateLimiter) checkQuota(
+	author peer.ID,
+	messageType string,
+	scope string,
+	seqno uint64,
+) error {
+	mrl.mutex.Lock()
+	defer mrl.mutex.Unlock()
+
+	quota, ok := mrl.quotas[messageType]
+	if !ok {
+		return nil
+	}
+
+	now := mrl.now()
+	mrl.prune(now)
+
+	key := quotaKey{author, messageType, scope}
+
+	usage, ok := mrl.quotaUsage[key]
+	if !ok {
+		usage = &quotaUsage{seqnos: make(map[uint64]bool)}
+		mrl.quotaUsage[key] = usage
+	}
+
+	usage.lastSeen = now
+
+	if usage.seqnos[seqno] {
+		return nil
+	}
+
+	if len(usage.seqnos) >= quota {
+		return &quotaExceededError{author, messageType, scope, quota}
+	}
+
+	usage.seqnos[seqno] = true
+
+	return nil
+}
+
+// prune discards the state of limits of peers and quota scopes not seen
+// for messageLimitsRetention. Must be called with the mutex held.
+func (mrl *messageRateLimiter) prune(now time.Time) {
+	if now.Sub(mrl.lastPruning) < messageLimitsPruningPeriod {
+		return
+	}
+
+	mrl.lastPruning = now
+
+	for author, entry := range mrl.limiters {
+		if now.Sub(entry.lastSeen) > messageLimitsRetention {
+			delete(mrl.limiters, author)
+			continue
+		}
+
+		for seqno, counted := range entry.seqnos {
+			if now.Sub(counted) > messageLimitsRetention {
+				delete(entry.seqnos, seqno)
+			}
+		}
+	}
+
+	for key, usage := range mrl.quotaUsage {
+		if now.Sub(usage.lastSeen) > messageLimitsRetention {
+			delete(mrl.quotaUsage, key)
+		}
+	}
+}
+
+// quotaExceededError is returned when the author published more distinct
+// messages of the given type within the quota scope than the quota allows.
+type quotaExceededError struct {
+	author      peer.ID
+	messageType string
+	scope       string
+	quota       int
+}
+
+func (qee *quotaExceededError) Error() string {
+	return fmt.Sprintf(
+		"author [%v] exceeded the quota of [%v] messages of type [%v] "+
+			"within scope [%v]",
+		qee.author,
+		qee.quota,
+		qee.messageType,
+		qee.scope,
+	)
+}
@@ -0,0 +1,127 @@
+package libp2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+func TestMessageRateLimiter_Allow(t *testing.T) {
+	now := time.Now()
+
+	rateLimiter := newMessageRateLimiter(
+		net.RateLimit{MessagesPerSecond: 1, Burst: 2},
+	)
+	rateLimiter.now = func() time.Time { return now }
+
+	author := generatePeerID(t)
+	otherAuthor := generatePeerID(t)
+
+	assertAllowed := func(message string, expected bool, actual bool) {
+		if expected != actual {
+			t.Errorf(
+				"unexpected result for %v\nexpected: [%v]\nactual:   [%v]",
+				message,
+				expected,
+				actual,
+			)
+		}
+	}
+
+	assertAllowed("first message", true, rateLimiter.allow(author, 1))
+	assertAllowed("second message", true, rateLimiter.allow(author, 2))
+	assertAllowed("retransmission", true, rateLimiter.allow(author, 1))
+	assertAllowed("message over burst", false, rateLimiter.allow(author, 3))
+	assertAllowed(
+		"message of other author",
+		true,
+		rateLimiter.allow(otherAuthor, 1),
+	)
+
+	now = now.Add(1 * time.Second)
+	assertAllowed("message after refill", true, rateLimiter.allow(author, 3))
+	assertAllowed("retransmission", true, rateLimiter.allow(author, 3))
+	assertAllowed("message over rate", false, rateLimiter.allow(author, 4))
+
+	rateLimiter.setLimit(net.RateLimit{MessagesPerSecond: -1})
+	assertAllowed("message with no limit", true, rateLimiter.allow(author, 4))
+}
+
+func TestMessageRateLimiter_CheckQuota(t *testing.T) {
+	rateLimiter := newMessageRateLimiter(net.RateLimit{})
+	rateLimiter.setQuota("round_one", 1)
+
+	author := generatePeerID(t)
+	otherAuthor := generatePeerID(t)
+
+	var tests = []struct {
+		description string
+		author      string
+		messageType string
+		scope       string
+		seqno       uint64
+		expectedErr bool
+	}{
+		{"first message", "author", "round_one", "session-1/1", 1, false},
+		{"retransmission", "author", "round_one", "session-1/1", 1, false},
+		{"second message", "author", "round_one", "session-1/1", 2, true},
+		{"other sender", "author", "round_one", "session-1/2", 3, false},
+		{"other session", "author", "round_one", "session-2/1", 4, false},
+		{"other author", "other", "round_one", "session-1/1", 1, false},
+		{"type with no quota", "author", "round_two", "session-1/1", 5, false},
+		{"type with no quota", "author", "round_two", "session-1/1", 6, false},
+	}
+
+	for _, test := range tests {
+		messageAuthor := author
+		if test.author == "other" {
+			messageAuthor = otherAuthor
+		}
+
+		err := rateLimiter.checkQuota(
+			messageAuthor,
+			test.messageType,
+			test.scope,
+			test.seqno,
+		)
+		if test.expectedErr != (err != nil) {
+			t.Errorf(
+				"unexpected error for %v: [%v]",
+				test.description,
+				err,
+			)
+		}
+	}
+}
+
+func TestMessageRateLimiter_Prune(t *testing.T) {
+	now := time.Now()
+
+	rateLimiter := newMessageRateLimiter(
+		net.RateLimit{MessagesPerSecond: 1, Burst: 1},
+	)
+	rateLimiter.now = func() time.Time { return now }
+	rateLimiter.setQuota("round_one", 1)
+
+	author := generatePeerID(t)
+
+	if !rateLimiter.allow(author, 1) {
+		t.Fatal("expected message to be allowed")
+	}
+	if err := rateLimiter.checkQuota(author, "round_one", "", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := rateLimiter.checkQuota(author, "round_one", "", 2); err == nil {
+		t.Fatal("expected quota to be exceeded")
+	}
+
+	now = now.Add(messageLimitsRetention + messageLimitsPruningPeriod)
+
+	if err := rateLimiter.checkQuota(author, "round_one", "", 2); err != nil {
+		t.Errorf("unexpected error after pruning: [%v]", err)
+	}
+	if _, ok := rateLimiter.limiters[author]; ok {
+		t.Errorf("expected limiter of inactive author to be pruned")
+	}
+}
@@ -0,0 +1,95 @@
+package libp2p
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcececdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+	pb "github.com/libp2p/go-libp2p-core/crypto/pb"
+
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+// IdentitySigner signs network-level data with the operator's key held
+// outside of the client process, for example by a remote signer.
+type IdentitySigner interface {
+	// OperatorPublicKey returns the operator's public key.
+	OperatorPublicKey() *operator.PublicKey
+	// SignNetwork signs the SHA-256 digest of the provided data with the
+	// operator's key. The signature is returned in the [R || S || V] format.
+	SignNetwork(data []byte) ([]byte, error)
+}
+
+// remoteNetworkKey is a libp2p secp256k1 private key delegating signing to
+// the IdentitySigner. The raw key bytes are never available.
+type remoteNetworkKey struct {
+	signer    IdentitySigner
+	publicKey *libp2pcrypto.Secp256k1PublicKey
+}
+
+func newRemoteNetworkKey(signer IdentitySigner) (*remoteNetworkKey, error) {
+	publicKey, err := operatorPublicKeyToNetworkPublicKey(
+		signer.OperatorPublicKey(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteNetworkKey{
+		signer:    signer,
+		publicKey: publicKey,
+	}, nil
+}
+
+// Type returns the private key type.
+func (rnk *remoteNetworkKey) Type() pb.KeyType {
+	return pb.KeyType_Secp256k1
+}
+
+// Raw always fails as the key is held remotely.
+func (rnk *remoteNetworkKey) Raw() ([]byte, error) {
+	return nil, fmt.Errorf("remote network key cannot be exported")
+}
+
+// Equals compares two private keys.
+func (rnk *remoteNetworkKey) Equals(other libp2pcrypto.Key) bool {
+	otherKey, ok := other.(*remoteNetworkKey)
+	if !ok {
+		return false
+	}
+
+	return rnk.publicKey.Equals(otherKey.publicKey)
+}
+
+// Sign signs the data the same way the libp2p secp256k1 private key does,
+// that is, it signs the SHA-256 digest of the data and returns the signature
+// in the DER format.
+func (rnk *remoteNetworkKey) Sign(data []byte) ([]byte, error) {
+	signature, err := rnk.signer.SignNetwork(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot sign network data: [%v]", err)
+	}
+
+	if len(signature) < 64 {
+		return nil, fmt.Errorf(
+			"invalid signature length [%v]",
+			len(signature),
+		)
+	}
+
+	var r, s btcec.ModNScalar
+	if overflow := r.SetByteSlice(signature[:32]); overflow {
+		return nil, fmt.Errorf("invalid signature R value")
+	}
+	if overflow := s.SetByteSlice(signature[32:64]); overflow {
+		return nil, fmt.Errorf("invalid signature S value")
+	}
+
+	return btcececdsa.NewSignature(&r, &s).Serialize(), nil
+}
+
+// GetPublic returns the public key.
+func (rnk *remoteNetworkKey) GetPublic() libp2pcrypto.PubKey {
+	return rnk.publicKey
+}
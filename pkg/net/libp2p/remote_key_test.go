@@ -0,0 +1,170 @@
+package libp2p
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcececdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/ethereum/go-ethereum/crypto"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+
+	"github.com/keep-network/keep-core/pkg/chain/ethereum/remotesigner"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestRemoteNetworkKey_Sign(t *testing.T) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(
+		DefaultCurve,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remoteKey, err := newRemoteNetworkKey(&localIdentitySigner{
+		privateKey: operatorPrivateKey,
+		publicKey:  operatorPublicKey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, networkPublicKey, err := operatorPrivateKeyToNetworkKeyPair(
+		operatorPrivateKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !remoteKey.GetPublic().Equals(networkPublicKey) {
+		t.Fatal("remote key has a wrong public key")
+	}
+
+	data := []byte("handshake act")
+
+	signature, err := remoteKey.Sign(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := networkPublicKey.Verify(data, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("signature verification failed")
+	}
+
+	if _, err := remoteKey.Raw(); err == nil {
+		t.Fatal("expected error when exporting the remote key")
+	}
+}
+
+// localIdentitySigner is the IdentitySigner holding the key locally.
+type localIdentitySigner struct {
+	privateKey *operator.PrivateKey
+	publicKey  *operator.PublicKey
+}
+
+func (lis *localIdentitySigner) OperatorPublicKey() *operator.PublicKey {
+	return lis.publicKey
+}
+
+func (lis *localIdentitySigner) SignNetwork(data []byte) ([]byte, error) {
+	privateKey, _ := btcec.PrivKeyFromBytes(lis.privateKey.D.Bytes())
+	digest := sha256.Sum256(data)
+
+	compact, err := btcececdsa.SignCompact(privateKey, digest[:], false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert [V || R || S] to [R || S || V].
+	return append(compact[1:], compact[0]-27), nil
+}
+
+// BenchmarkChannel_MessageProto measures the time of preparing a broadcast
+// message for publication depending on how the message is signed. With the
+// remote signer, the signature takes a round trip to the signer; the signer
+// used here runs in the same process so the result is the lower bound of
+// the latency added to every published message.
+func BenchmarkChannel_MessageProto(b *testing.B) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	localKey, _, err := operatorPrivateKeyToNetworkKeyPair(
+		&operator.PrivateKey{
+			PublicKey: operator.PublicKey{
+				Curve: operator.Secp256k1,
+				X:     privateKey.X,
+				Y:     privateKey.Y,
+			},
+			D: privateKey.D,
+		},
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	server := httptest.NewServer(remotesigner.NewServer(privateKey))
+	defer server.Close()
+
+	signerClient, err := remotesigner.Dial(
+		context.Background(),
+		remotesigner.Config{URL: server.URL},
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	remoteKey, err := newRemoteNetworkKey(signerClient.NetworkSigner())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var benchmarks = map[string]struct {
+		privateKey        libp2pcrypto.PrivKey
+		disableSignatures bool
+	}{
+		"local key": {
+			privateKey: localKey,
+		},
+		"remote key": {
+			privateKey: remoteKey,
+		},
+		"remote key with signatures disabled": {
+			privateKey:        remoteKey,
+			disableSignatures: true,
+		},
+	}
+
+	for benchmarkName, benchmark := range benchmarks {
+		b.Run(benchmarkName, func(b *testing.B) {
+			identity, err := createIdentity(benchmark.privateKey)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			channel := &channel{
+				name:                     "benchmark-channel",
+				clientIdentity:           identity,
+				disableMessageSignatures: benchmark.disableSignatures,
+			}
+
+			message := &fuzzingMessage{bytes.Repeat([]byte{1}, 512)}
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := channel.messageProto(message, uint64(i)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package libp2p
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+// reputationTag is the connection manager tag holding the reputation score
+// of the peer. Peers with lower scores are pruned first once the connection
+// manager's high water mark is reached.
+const reputationTag = "keep-reputation"
+
+const (
+	// peerScoreDecayInterval is the interval of decaying the pubsub score
+	// counters. The counters are not used as the score of peers is fed by
+	// the reputation store, decaying on its own, but pubsub requires
+	// the interval to be set.
+	peerScoreDecayInterval = time.Second
+	// peerScoreDecayToZero is the value of pubsub score counters below
+	// which they are considered zero.
+	peerScoreDecayToZero = 0.01
+)
+
+// peerReputation maintains the reputation of peers and applies it to the
+// host: updates the connection manager pruning priority and disconnects
+// banned peers.
+type peerReputation struct {
+	store *reputation.Store
+	host  host.Host
+}
+
+func newPeerReputation(
+	store *reputation.Store,
+	host host.Host,
+) *peerReputation {
+	return &peerReputation{
+		store: store,
+		host:  host,
+	}
+}
+
+// report records the event for the given peer.
+func (pr *peerReputation) report(peerID peer.ID, event net.ReputationEvent) {
+	if peerID == pr.host.ID() {
+		return
+	}
+
+	score, banned := pr.store.Record(peerID.String(), event)
+
+	logger.Debugf(
+		"reported [%v] event for peer [%v]; current score: [%.2f]",
+		event,
+		peerID,
+		score,
+	)
+
+	pr.host.ConnManager().TagPeer(peerID, reputationTag, int(score))
+
+	if banned {
+		logger.Warningf(
+			"banning peer [%v] due to bad reputation; last event: [%v]",
+			peerID,
+			event,
+		)
+
+		if err := pr.host.Network().ClosePeer(peerID); err != nil {
+			logger.Errorf("failed to disconnect banned peer [%v]: [%v]", peerID, err)
+		}
+	}
+}
+
+// score returns the pubsub score of the given peer, equal to its reputation
+// score. Banned peers are given the ban threshold score so that their
+// messages are ignored by the pubsub router.
+func (pr *peerReputation) score(peerID peer.ID) float64 {
+	if pr.store.IsBanned(peerID.String()) {
+		return pr.store.Parameters().BanThreshold
+	}
+
+	return pr.store.Score(peerID.String())
+}
+
+// peerScoreParams returns the pubsub peer score parameters and thresholds
+// based on the reputation of peers. The pubsub thresholds are derived from
+// the reputation ban threshold: peers with a score below a quarter of the
+// ban threshold are excluded from gossip, peers with a score below a half of
+// the ban threshold do not receive messages published by the client, and
+// messages from banned peers are ignored.
+func (pr *peerReputation) peerScoreParams() (
+	*pubsub.PeerScoreParams,
+	*pubsub.PeerScoreThresholds,
+) {
+	banThreshold := pr.store.Parameters().BanThreshold
+
+	params := &pubsub.PeerScoreParams{
+		AppSpecificScore:  pr.score,
+		AppSpecificWeight: 1,
+		DecayInterval:     peerScoreDecayInterval,
+		DecayToZero:       peerScoreDecayToZero,
+	}
+
+	thresholds := &pubsub.PeerScoreThresholds{
+		GossipThreshold:   banThreshold / 4,
+		PublishThreshold:  banThreshold / 2,
+		GraylistThreshold: banThreshold,
+	}
+
+	return params, thresholds
+}
+
+// Add implements pubsub.Blacklist. Peers cannot be banned directly by the
+// pubsub router; bans are driven by the reputation score only.
+func (pr *peerReputation) Add(peerID peer.ID) bool {
+	return false
+}
+
+// Contains implements pubsub.Blacklist. Messages authored or propagated by
+// banned peers are rejected by the pubsub router.
+func (pr *peerReputation) Contains(peerID peer.ID) bool {
+	return pr.store.IsBanned(peerID.String())
+}
+
+// reputationFirewall rejects peers banned due to their bad reputation and
+// delegates the validation of other peers to the wrapped firewall.
+type reputationFirewall struct {
+	store    *reputation.Store
+	firewall net.Firewall
+}
+
+func newReputationFirewall(
+	store *reputation.Store,
+	firewall net.Firewall,
+) *reputationFirewall {
+	return &reputationFirewall{
+		store:    store,
+		firewall: firewall,
+	}
+}
+
+func (rf *reputationFirewall) Validate(
+	remotePeerPublicKey *operator.PublicKey,
+) error {
+	networkPublicKey, err := operatorPublicKeyToNetworkPublicKey(
+		remotePeerPublicKey,
+	)
+	if err != nil {
+		return err
+	}
+
+	peerID, err := peer.IDFromPublicKey(networkPublicKey)
+	if err != nil {
+		return err
+	}
+
+	if rf.store.IsBanned(peerID.String()) {
+		return fmt.Errorf("peer [%v] is banned due to bad reputation", peerID)
+	}
+
+	return rf.firewall.Validate(remotePeerPublicKey)
+}
+
+// reputationEventByName returns the reputation event with the given name.
+// The second returned value is false if there is no such event.
+func reputationEventByName(name string) (net.ReputationEvent, bool) {
+	for event := net.InvalidMessageEvent; event <= net.ProtocolMisbehaviorEvent; event++ {
+		if event.String() == name {
+			return event, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,244 @@
+package libp2p
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/reputation"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestReputationFirewall(t *testing.T) {
+	_, operatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPublicKey, err := operatorPublicKeyToNetworkPublicKey(
+		operatorPublicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peerID, err := peer.IDFromPublicKey(networkPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := reputation.NewStore(reputation.DefaultParameters())
+	reputationFirewall := newReputationFirewall(store, firewall.Disabled)
+
+	if err := reputationFirewall.Validate(operatorPublicKey); err != nil {
+		t.Fatal(err)
+	}
+
+	for !store.IsBanned(peerID.String()) {
+		store.Record(peerID.String(), net.SenderSpoofingEvent)
+	}
+
+	err = reputationFirewall.Validate(operatorPublicKey)
+	expectedErr := fmt.Errorf(
+		"peer [%v] is banned due to bad reputation",
+		peerID,
+	)
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+}
+
+func TestReputationEventFor(t *testing.T) {
+	var tests = map[string]struct {
+		err            error
+		expectedEvent  net.ReputationEvent
+		expectedReport bool
+	}{
+		"sender mismatch": {
+			err:            &senderMismatchError{},
+			expectedEvent:  net.SenderSpoofingEvent,
+			expectedReport: true,
+		},
+		"invalid signature": {
+			err:            &invalidSignatureError{},
+			expectedEvent:  net.SenderSpoofingEvent,
+			expectedReport: true,
+		},
+		"quota exceeded": {
+			err:            &quotaExceededError{},
+			expectedEvent:  net.MessageFloodingEvent,
+			expectedReport: true,
+		},
+		"malformed message": {
+			err:            &malformedMessageError{},
+			expectedEvent:  net.InvalidMessageEvent,
+			expectedReport: true,
+		},
+		"unknown message type": {
+			err:            &unknownMessageTypeError{"tbtc/inactivity_claim"},
+			expectedReport: false,
+		},
+		"other error": {
+			err:            fmt.Errorf("message exceeds the limit"),
+			expectedReport: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			event, report := reputationEventFor(test.err)
+			if test.expectedReport != report {
+				t.Fatalf(
+					"unexpected report decision\nexpected: [%v]\nactual:   [%v]",
+					test.expectedReport,
+					report,
+				)
+			}
+			if report && test.expectedEvent != event {
+				t.Errorf(
+					"unexpected event\nexpected: [%v]\nactual:   [%v]",
+					test.expectedEvent,
+					event,
+				)
+			}
+		})
+	}
+}
+
+func TestPeerReputation_PeerScore(t *testing.T) {
+	// The score does not decay so that it can be compared exactly.
+	parameters := reputation.DefaultParameters()
+	parameters.HalfLife = 0
+
+	store := reputation.NewStore(parameters)
+	peerReputation := &peerReputation{store: store}
+
+	params, thresholds := peerReputation.peerScoreParams()
+
+	expectedThresholds := &pubsub.PeerScoreThresholds{
+		GossipThreshold:   -25,
+		PublishThreshold:  -50,
+		GraylistThreshold: -100,
+	}
+	if !reflect.DeepEqual(expectedThresholds, thresholds) {
+		t.Errorf(
+			"unexpected thresholds\nexpected: [%+v]\nactual:   [%+v]",
+			expectedThresholds,
+			thresholds,
+		)
+	}
+
+	peerID := peer.ID("peer-1")
+
+	if score := params.AppSpecificScore(peerID); score != 0 {
+		t.Errorf("unexpected score of unknown peer: [%v]", score)
+	}
+
+	store.Record(peerID.String(), net.SenderSpoofingEvent)
+	if score := params.AppSpecificScore(peerID); score != -50 {
+		t.Errorf("unexpected score: [%v]", score)
+	}
+	if params.AppSpecificScore(peerID) > thresholds.PublishThreshold {
+		t.Errorf("peer is expected to be below the publish threshold")
+	}
+
+	store.Record(peerID.String(), net.SenderSpoofingEvent)
+	if !store.IsBanned(peerID.String()) {
+		t.Fatal("peer is expected to be banned")
+	}
+	if params.AppSpecificScore(peerID) > thresholds.GraylistThreshold {
+		t.Errorf("banned peer is expected to be graylisted")
+	}
+}
+
+func TestReputationParameters(t *testing.T) {
+	var tests = map[string]struct {
+		config             Config
+		expectedParameters reputation.Parameters
+		expectedErr        error
+	}{
+		"defaults": {
+			config:             Config{},
+			expectedParameters: reputation.DefaultParameters(),
+		},
+		"configured": {
+			config: Config{
+				ReputationHalfLife:     time.Minute,
+				ReputationBanThreshold: -40,
+				ReputationBanDuration:  2 * time.Hour,
+				ReputationEventWeights: map[string]float64{
+					"invalid_message": -20,
+				},
+			},
+			expectedParameters: reputation.Parameters{
+				HalfLife:     time.Minute,
+				BanThreshold: -40,
+				BanDuration:  2 * time.Hour,
+				EventWeights: map[net.ReputationEvent]float64{
+					net.InvalidMessageEvent:      -20,
+					net.SenderSpoofingEvent:      -50,
+					net.MessageFloodingEvent:     -1,
+					net.ProtocolInactivityEvent:  -5,
+					net.ProtocolMisbehaviorEvent: -25,
+				},
+			},
+		},
+		"positive ban threshold": {
+			config: Config{ReputationBanThreshold: 10},
+			expectedErr: fmt.Errorf(
+				"reputation ban threshold must be negative",
+			),
+		},
+		"unknown event": {
+			config: Config{
+				ReputationEventWeights: map[string]float64{"unknown": -1},
+			},
+			expectedErr: fmt.Errorf("unknown reputation event [unknown]"),
+		},
+		"positive event weight": {
+			config: Config{
+				ReputationEventWeights: map[string]float64{
+					"message_flooding": 1,
+				},
+			},
+			expectedErr: fmt.Errorf(
+				"weight of reputation event [message_flooding] must not " +
+					"be positive",
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			parameters, err := reputationParameters(test.config)
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Fatalf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedErr,
+					err,
+				)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(test.expectedParameters, parameters) {
+				t.Errorf(
+					"unexpected parameters\nexpected: [%+v]\nactual:   [%+v]",
+					test.expectedParameters,
+					parameters,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package libp2p
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// trafficStats gathers statistics of the traffic of a broadcast channel,
+// per message type.
+type trafficStats struct {
+	mutex  sync.Mutex
+	byType map[string]*net.BroadcastTraffic
+}
+
+// sent records a message of the given type and size sent over the channel.
+func (ts *trafficStats) sent(channel string, messageType string, size int) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	traffic := ts.trafficFor(channel, messageType)
+	traffic.MessagesSent++
+	traffic.BytesSent += uint64(size)
+}
+
+// received records a message of the given type and size received over
+// the channel.
+func (ts *trafficStats) received(channel string, messageType string, size int) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	traffic := ts.trafficFor(channel, messageType)
+	traffic.MessagesReceived++
+	traffic.BytesReceived += uint64(size)
+}
+
+// trafficFor returns the traffic statistics of the given message type.
+// Must be called with the mutex held.
+func (ts *trafficStats) trafficFor(
+	channel string,
+	messageType string,
+) *net.BroadcastTraffic {
+	if ts.byType == nil {
+		ts.byType = make(map[string]*net.BroadcastTraffic)
+	}
+
+	traffic, ok := ts.byType[messageType]
+	if !ok {
+		traffic = &net.BroadcastTraffic{
+			Channel:     channel,
+			MessageType: messageType,
+		}
+		ts.byType[messageType] = traffic
+	}
+
+	return traffic
+}
+
+// snapshot returns the current traffic statistics sorted by message type.
+func (ts *trafficStats) snapshot() []net.BroadcastTraffic {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	snapshot := make([]net.BroadcastTraffic, 0, len(ts.byType))
+	for _, traffic := range ts.byType {
+		snapshot = append(snapshot, *traffic)
+	}
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		return snapshot[i].MessageType < snapshot[j].MessageType
+	})
+
+	return snapshot
+}
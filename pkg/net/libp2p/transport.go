@@ -10,6 +10,8 @@ import (
 	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/sec"
+
+	"github.com/keep-network/keep-core/pkg/net/security/handshake"
 )
 
 // ID is the multistream-select protocol ID that should be used when identifying
@@ -25,14 +27,20 @@ type transport struct {
 	localPeerID     peer.ID
 	privateKey      libp2pcrypto.PrivKey
 	protocol        string
+	version         handshake.Version
 	firewall        keepNet.Firewall
 	encryptionLayer sec.SecureTransport
+	peerVersions    *peerVersions
+	handshakes      *handshakeOutcomes
 }
 
 func newEncryptedAuthenticatedTransport(
 	pk libp2pcrypto.PrivKey,
 	protocol string,
+	version handshake.Version,
 	firewall keepNet.Firewall,
+	peerVersions *peerVersions,
+	handshakes *handshakeOutcomes,
 ) (*transport, error) {
 	id, err := peer.IDFromPrivateKey(pk)
 	if err != nil {
@@ -50,6 +58,9 @@ func newEncryptedAuthenticatedTransport(
 		firewall:        firewall,
 		encryptionLayer: encryptionLayer,
 		protocol:        protocol,
+		version:         version,
+		peerVersions:    peerVersions,
+		handshakes:      handshakes,
 	}, nil
 }
 
@@ -64,13 +75,21 @@ func (t *transport) SecureInbound(
 		return nil, err
 	}
 
-	return newAuthenticatedInboundConnection(
+	authenticatedConnection, err := newAuthenticatedInboundConnection(
 		encryptedConnection,
 		t.localPeerID,
 		t.privateKey,
 		t.firewall,
 		t.protocol,
+		t.version,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	t.recordPeerVersion(authenticatedConnection)
+
+	return authenticatedConnection, nil
 }
 
 // SecureOutbound secures an outbound connection.
@@ -85,15 +104,44 @@ func (t *transport) SecureOutbound(
 		remotePeerID,
 	)
 	if err != nil {
+		if t.handshakes != nil {
+			t.handshakes.record(remotePeerID, err)
+		}
+
 		return nil, err
 	}
 
-	return newAuthenticatedOutboundConnection(
+	authenticatedConnection, err := newAuthenticatedOutboundConnection(
 		encryptedConnection,
 		t.localPeerID,
 		t.privateKey,
 		remotePeerID,
 		t.firewall,
 		t.protocol,
+		t.version,
+	)
+
+	if t.handshakes != nil {
+		t.handshakes.record(remotePeerID, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	t.recordPeerVersion(authenticatedConnection)
+
+	return authenticatedConnection, nil
+}
+
+func (t *transport) recordPeerVersion(connection *authenticatedConnection) {
+	if t.peerVersions == nil {
+		return
+	}
+
+	t.peerVersions.set(
+		connection.remotePeerID,
+		connection.remoteVersion,
+		connection.capabilities,
 	)
 }
@@ -0,0 +1,147 @@
+package libp2p
+
+import (
+	"sync"
+
+	libp2pnet "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/security/handshake"
+)
+
+const (
+	// ProtocolVersion is the version of the network protocol executed by
+	// the client. It is announced to peers during the connection handshake.
+	ProtocolVersion uint32 = 2
+	// MinProtocolVersion is the minimum version of the network protocol
+	// a peer must run to be connected. Peers not announcing their version
+	// during the handshake are considered as running version 0.
+	MinProtocolVersion uint32 = 0
+)
+
+// Capabilities announced to peers during the connection handshake.
+const (
+	// broadcastMessageCapability denotes the support of the broadcast
+	// channel message format.
+	broadcastMessageCapability = "broadcast-message/1"
+	// compressionCapability denotes the support of broadcast channel
+	// messages with payloads compressed using the DEFLATE algorithm.
+	compressionCapability = "compression/deflate"
+	// messageSignatureCapability denotes the support of operator signatures
+	// of broadcast channel messages.
+	messageSignatureCapability = "message-signature/1"
+)
+
+// localCapabilities is the set of capabilities supported by the client.
+var localCapabilities = []string{
+	broadcastMessageCapability,
+	compressionCapability,
+	messageSignatureCapability,
+}
+
+// localVersion returns the version announced by the client during the
+// connection handshake.
+func localVersion() handshake.Version {
+	return handshake.Version{
+		Protocol:     ProtocolVersion,
+		MinProtocol:  MinProtocolVersion,
+		Capabilities: localCapabilities,
+	}
+}
+
+// peerVersions keeps versions of the connected peers, as announced during
+// the connection handshake.
+type peerVersions struct {
+	mutex    sync.RWMutex
+	versions map[peer.ID]net.PeerVersion
+}
+
+func newPeerVersions() *peerVersions {
+	return &peerVersions{
+		versions: make(map[peer.ID]net.PeerVersion),
+	}
+}
+
+// set records the version announced by the given peer and the capabilities
+// negotiated with that peer.
+func (pv *peerVersions) set(
+	peerID peer.ID,
+	version handshake.Version,
+	capabilities []string,
+) {
+	pv.mutex.Lock()
+	defer pv.mutex.Unlock()
+
+	pv.versions[peerID] = net.PeerVersion{
+		ProtocolVersion:    version.Protocol,
+		MinProtocolVersion: version.MinProtocol,
+		Capabilities:       capabilities,
+	}
+}
+
+// get returns the version of the given peer. The second returned value is
+// false if the version of the peer is not known.
+func (pv *peerVersions) get(peerID peer.ID) (net.PeerVersion, bool) {
+	pv.mutex.RLock()
+	defer pv.mutex.RUnlock()
+
+	version, ok := pv.versions[peerID]
+	return version, ok
+}
+
+// supportCapability checks whether the given capability was negotiated
+// with all the given peers. Peers with unknown versions are considered as
+// not supporting any capability.
+func (pv *peerVersions) supportCapability(
+	peers []peer.ID,
+	capability string,
+) bool {
+	pv.mutex.RLock()
+	defer pv.mutex.RUnlock()
+
+	for _, peerID := range peers {
+		version, ok := pv.versions[peerID]
+		if !ok || !hasCapability(version.Capabilities, capability) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// remove forgets the version of the given peer.
+func (pv *peerVersions) remove(peerID peer.ID) {
+	pv.mutex.Lock()
+	defer pv.mutex.Unlock()
+
+	delete(pv.versions, peerID)
+}
+
+// notifiee returns a network notifiee forgetting versions of peers
+// the client is no longer connected with.
+func (pv *peerVersions) notifiee() libp2pnet.Notifiee {
+	notifyBundle := &libp2pnet.NotifyBundle{}
+
+	notifyBundle.DisconnectedF = func(
+		network libp2pnet.Network,
+		connection libp2pnet.Conn,
+	) {
+		remotePeer := connection.RemotePeer()
+		if len(network.ConnsToPeer(remotePeer)) == 0 {
+			pv.remove(remotePeer)
+		}
+	}
+
+	return notifyBundle
+}
+
+func hasCapability(capabilities []string, capability string) bool {
+	for _, c := range capabilities {
+		if c == capability {
+			return true
+		}
+	}
+
+	return false
+}
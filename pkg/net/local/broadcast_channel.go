@@ -6,6 +6,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/operator"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/net/internal"
@@ -15,20 +16,63 @@ import (
 const messageHandlerThrottle = 256
 
 type messageHandler struct {
+	// handler-scoped atomic counter of dropped messages
+	//
+	// Must be declared at the top of the struct!
+	// See: https://golang.org/pkg/sync/atomic/#pkg-note-BUG
+	droppedMessages uint64
+
 	ctx     context.Context
 	channel chan net.Message
+	options *net.RecvOptions
+}
+
+// deliver passes the message to the handler according to the handler's
+// delivery mode. It returns false if the message was dropped.
+func (mh *messageHandler) deliver(message net.Message) bool {
+	select {
+	case mh.channel <- message:
+		return true
+	default:
+	}
+
+	if mh.options.DeliveryMode == net.BlockingDelivery {
+		timer := time.NewTimer(net.MaxBlockingDeliveryTime)
+		defer timer.Stop()
+
+		select {
+		case mh.channel <- message:
+			return true
+		case <-mh.ctx.Done():
+			// The handler is being removed and does not expect any more
+			// messages so the message is not considered dropped.
+			return true
+		case <-timer.C:
+		}
+	}
+
+	atomic.AddUint64(&mh.droppedMessages, 1)
+
+	if mh.options.OnDrop != nil {
+		mh.options.OnDrop(message)
+	}
+
+	return false
 }
 
 type localChannel struct {
 	counter              uint64
+	droppedMessages      uint64
 	name                 string
 	identifier           net.TransportIdentifier
 	operatorPublicKey    *operator.PublicKey
+	operatorPrivateKey   *operator.PrivateKey
 	messageHandlersMutex sync.Mutex
 	messageHandlers      []*messageHandler
 	unmarshalersMutex    sync.Mutex
 	unmarshalersByType   map[string]func() net.TaggedUnmarshaler
 	retransmissionTicker *retransmission.Ticker
+	retransmissions      retransmission.Scheduler
 }
 
 func (lc *localChannel) nextSeqno() uint64 {
@@ -58,15 +102,37 @@ func (lc *localChannel) Send(ctx context.Context, message net.TaggedMarshaler) e
 
 	operatorPublicKeyBytes := operator.MarshalUncompressed(lc.operatorPublicKey)
 
+	seqno := lc.nextSeqno()
+
+	var signature *net.MessageSignature
+	if lc.operatorPrivateKey != nil {
+		signature = &net.MessageSignature{
+			Channel:         lc.name,
+			Type:            message.Type(),
+			Seqno:           seqno,
+			Payload:         bytes,
+			SenderPublicKey: operatorPublicKeyBytes,
+		}
+
+		signature.Signature, err = sign(
+			lc.operatorPrivateKey,
+			signature.SignedBytes(),
+		)
+		if err != nil {
+			return fmt.Errorf("could not sign message: [%v]", err)
+		}
+	}
+
 	netMessage := internal.BasicMessage(
 		lc.identifier,
 		unmarshaled,
 		"local",
 		operatorPublicKeyBytes,
-		lc.nextSeqno(),
+		seqno,
+		signature,
 	)
 
-	retransmission.ScheduleRetransmissions(
+	lc.retransmissions.Schedule(
 		ctx,
 		logger,
 		lc.retransmissionTicker,
@@ -78,6 +144,12 @@ func (lc *localChannel) Send(ctx context.Context, message net.TaggedMarshaler) e
 	return broadcastMessage(lc.name, netMessage)
 }
 
+// RetransmitNow retransmits all messages sent over the channel whose send
+// contexts are not done yet, without waiting for the next retransmission tick.
+func (lc *localChannel) RetransmitNow() {
+	lc.retransmissions.RetransmitNow()
+}
+
 func (lc *localChannel) deliver(message net.Message) {
 	lc.messageHandlersMutex.Lock()
 	snapshot := make([]*messageHandler, len(lc.messageHandlers))
@@ -85,18 +157,28 @@ func (lc *localChannel) deliver(message net.Message) {
 	lc.messageHandlersMutex.Unlock()
 
 	for _, handler := range snapshot {
-		select {
-		case handler.channel <- message:
-		default:
+		if !handler.deliver(message) {
+			atomic.AddUint64(&lc.droppedMessages, 1)
 			logger.Warningf("handler too slow, dropping message")
 		}
 	}
 }
 
-func (lc *localChannel) Recv(ctx context.Context, handler func(m net.Message)) {
+func (lc *localChannel) DroppedMessages() uint64 {
+	return atomic.LoadUint64(&lc.droppedMessages)
+}
+
+func (lc *localChannel) Recv(
+	ctx context.Context,
+	handler func(m net.Message),
+	options ...net.RecvOption,
+) {
+	recvOptions := net.NewRecvOptions(messageHandlerThrottle, options...)
+
 	messageHandler := &messageHandler{
 		ctx:     ctx,
-		channel: make(chan net.Message, messageHandlerThrottle),
+		channel: make(chan net.Message, recvOptions.BufferSize),
+		options: recvOptions,
 	}
 
 	lc.messageHandlersMutex.Lock()
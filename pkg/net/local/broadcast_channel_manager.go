@@ -2,6 +2,7 @@ package local
 
 import (
 	"context"
+	"fmt"
 	"github.com/keep-network/keep-core/pkg/operator"
 	"sync"
 	"time"
@@ -21,7 +22,8 @@ var broadcastChannels map[string][]*localChannel
 func getBroadcastChannel(
 	name string,
 	operatorPublicKey *operator.PublicKey,
-) net.BroadcastChannel {
+	operatorPrivateKey *operator.PrivateKey,
+) *localChannel {
 	broadcastChannelsMutex.Lock()
 	defer broadcastChannelsMutex.Unlock()
 	if broadcastChannels == nil {
@@ -39,6 +41,7 @@ func getBroadcastChannel(
 		name:                 name,
 		identifier:           &identifier,
 		operatorPublicKey:    operatorPublicKey,
+		operatorPrivateKey:   operatorPrivateKey,
 		messageHandlersMutex: sync.Mutex{},
 		messageHandlers:      make([]*messageHandler, 0),
 		unmarshalersMutex:    sync.Mutex{},
@@ -52,7 +55,40 @@ func getBroadcastChannel(
 	return channel
 }
 
+// removeBroadcastChannels removes the given channels from the channels
+// mediating between local participants under the given name. Removed channels
+// no longer receive messages sent to the given name.
+func removeBroadcastChannels(name string, channels []*localChannel) {
+	broadcastChannelsMutex.Lock()
+	defer broadcastChannelsMutex.Unlock()
+
+	isRemoved := make(map[*localChannel]bool, len(channels))
+	for _, channel := range channels {
+		isRemoved[channel] = true
+	}
+
+	remainingChannels := make([]*localChannel, 0)
+	for _, channel := range broadcastChannels[name] {
+		if !isRemoved[channel] {
+			remainingChannels = append(remainingChannels, channel)
+		}
+	}
+
+	if len(remainingChannels) == 0 {
+		delete(broadcastChannels, name)
+		return
+	}
+
+	broadcastChannels[name] = remainingChannels
+}
+
 func broadcastMessage(name string, message net.Message) error {
+	if signature := message.Signature(); signature != nil {
+		if err := signature.Verify(); err != nil {
+			return fmt.Errorf("invalid message signature: [%v]", err)
+		}
+	}
+
 	broadcastChannelsMutex.Lock()
 	targetChannels := broadcastChannels[name]
 	broadcastChannelsMutex.Unlock()
@@ -232,6 +232,144 @@ loop:
 	}
 }
 
+func TestSendAndDeliver_Signature(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	channelName := "signed channel name"
+
+	operatorPrivateKey, _, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signingChannel, err := ConnectWithPrivateKey(
+		operatorPrivateKey,
+	).BroadcastChannelFor(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &mockNetMessage{}
+	})
+
+	_, nonSigningChannel, err := initTestChannel(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inMsgChan := make(chan net.Message, 2)
+	signingChannel.Recv(ctx, func(msg net.Message) {
+		inMsgChan <- msg
+	})
+
+	if err := signingChannel.Send(ctx, &mockNetMessage{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nonSigningChannel.Send(ctx, &mockNetMessage{}); err != nil {
+		t.Fatal(err)
+	}
+
+	signedMessage := <-inMsgChan
+	unsignedMessage := <-inMsgChan
+
+	signature := signedMessage.Signature()
+	if signature == nil {
+		t.Fatal("message is not signed")
+	}
+	if err := signature.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSignedBytes := net.MessageSignedBytes(
+		channelName,
+		"mock_message",
+		signedMessage.Seqno(),
+		[]byte("some mocked bytes"),
+	)
+	testutils.AssertBytesEqual(t, expectedSignedBytes, signature.SignedBytes())
+
+	if unsignedMessage.Signature() != nil {
+		t.Errorf("message should not be signed")
+	}
+
+	// Signature does not verify once the signed data is modified.
+	signature.Seqno++
+	if err := signature.Verify(); err == nil {
+		t.Errorf("expected signature verification failure")
+	}
+}
+
+func TestCloseBroadcastChannel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	channelName := "closed channel"
+
+	_, senderPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, receiverPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender := ConnectWithKey(senderPublicKey)
+	receiver := ConnectWithKey(receiverPublicKey)
+
+	senderChannel, err := sender.BroadcastChannelFor(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiverChannel, err := receiver.BroadcastChannelFor(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, channel := range []net.BroadcastChannel{senderChannel, receiverChannel} {
+		channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &mockNetMessage{}
+		})
+	}
+
+	var receivedMutex sync.Mutex
+	received := make(map[string]int)
+	recordHandler := func(provider string) func(net.Message) {
+		return func(msg net.Message) {
+			receivedMutex.Lock()
+			received[provider]++
+			receivedMutex.Unlock()
+		}
+	}
+
+	senderChannel.Recv(ctx, recordHandler("sender"))
+	receiverChannel.Recv(ctx, recordHandler("receiver"))
+
+	err = receiver.(net.BroadcastChannelCloser).CloseBroadcastChannel(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := senderChannel.Send(ctx, &mockNetMessage{}); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	receivedMutex.Lock()
+	defer receivedMutex.Unlock()
+
+	expectedReceived := map[string]int{"sender": 1}
+	if !reflect.DeepEqual(expectedReceived, received) {
+		t.Errorf(
+			"unexpected received messages\nexpected: [%v]\nactual:   [%v]",
+			expectedReceived,
+			received,
+		)
+	}
+}
+
 func initTestChannel(channelName string) (*operator.PublicKey, net.BroadcastChannel, error) {
 	_, operatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
 	if err != nil {
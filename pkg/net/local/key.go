@@ -1,11 +1,38 @@
 package local
 
 import (
+	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/sha256"
+
 	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/keep-network/keep-core/pkg/operator"
 )
 
 // DefaultCurve is the default elliptic curve implementation used in the
 // net/local package. Local network uses the secp256k1 curve and the specific
 // implementation is provided by the btcec package.
 var DefaultCurve elliptic.Curve = btcec.S256()
+
+// sign signs the SHA-256 hash of the given bytes with the operator private key
+// and returns the DER-encoded signature.
+func sign(operatorPrivateKey *operator.PrivateKey, bytes []byte) ([]byte, error) {
+	privateKey := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: DefaultCurve,
+			X:     operatorPrivateKey.X,
+			Y:     operatorPrivateKey.Y,
+		},
+		D: operatorPrivateKey.D,
+	}
+
+	hash := sha256.Sum256(bytes)
+
+	signature, err := (*btcec.PrivateKey)(privateKey).Sign(hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return signature.Serialize(), nil
+}
@@ -24,9 +24,13 @@ type Provider interface {
 }
 
 type localProvider struct {
-	id                localIdentifier
-	operatorPublicKey *operator.PublicKey
-	connectionManager *localConnectionManager
+	id                 localIdentifier
+	operatorPublicKey  *operator.PublicKey
+	operatorPrivateKey *operator.PrivateKey
+	connectionManager  *localConnectionManager
+
+	channelsMutex sync.Mutex
+	channels      map[string][]*localChannel
 }
 
 func (lp *localProvider) ID() net.TransportIdentifier {
@@ -34,7 +38,28 @@ func (lp *localProvider) ID() net.TransportIdentifier {
 }
 
 func (lp *localProvider) BroadcastChannelFor(name string) (net.BroadcastChannel, error) {
-	return getBroadcastChannel(name, lp.operatorPublicKey), nil
+	channel := getBroadcastChannel(
+		name,
+		lp.operatorPublicKey,
+		lp.operatorPrivateKey,
+	)
+
+	lp.channelsMutex.Lock()
+	lp.channels[name] = append(lp.channels[name], channel)
+	lp.channelsMutex.Unlock()
+
+	return channel, nil
+}
+
+func (lp *localProvider) CloseBroadcastChannel(name string) error {
+	lp.channelsMutex.Lock()
+	channels := lp.channels[name]
+	delete(lp.channels, name)
+	lp.channelsMutex.Unlock()
+
+	removeBroadcastChannels(name, channels)
+
+	return nil
 }
 
 func (lp *localProvider) Type() string {
@@ -71,12 +96,28 @@ func Connect() Provider {
 
 // ConnectWithKey returns a local instance of net provider that does not go
 // over the network. The returned instance uses the provided network key to
-// identify network messages.
+// identify network messages. Messages sent by the returned instance are not
+// signed.
 func ConnectWithKey(operatorPublicKey *operator.PublicKey) Provider {
 	return &localProvider{
 		id:                randomLocalIdentifier(),
 		operatorPublicKey: operatorPublicKey,
 		connectionManager: &localConnectionManager{peers: make(map[string]*operator.PublicKey)},
+		channels:          make(map[string][]*localChannel),
+	}
+}
+
+// ConnectWithPrivateKey returns a local instance of net provider that does
+// not go over the network. The returned instance uses the public key
+// corresponding to the provided private key to identify network messages and
+// signs them with the provided private key.
+func ConnectWithPrivateKey(operatorPrivateKey *operator.PrivateKey) Provider {
+	return &localProvider{
+		id:                 randomLocalIdentifier(),
+		operatorPublicKey:  &operatorPrivateKey.PublicKey,
+		operatorPrivateKey: operatorPrivateKey,
+		connectionManager:  &localConnectionManager{peers: make(map[string]*operator.PublicKey)},
+		channels:           make(map[string][]*localChannel),
 	}
 }
 
@@ -2,6 +2,7 @@ package net
 
 import (
 	"context"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/operator"
 
@@ -25,6 +26,10 @@ type Message interface {
 
 	Type() string
 	Seqno() uint64
+
+	// Signature returns the sender's operator signature of the message or
+	// nil if the message was not signed.
+	Signature() *MessageSignature
 }
 
 // TaggedMarshaler is an interface that includes the proto.Marshaler interface,
@@ -103,8 +108,9 @@ type BroadcastChannel interface {
 	// channel for the entire lifetime of the provided context.
 	// When the context is done, handler is automatically unregistered and
 	// receives no more messages. Already received message retransmissions are
-	// filtered out before calling the handler.
-	Recv(ctx context.Context, handler func(m Message))
+	// filtered out before calling the handler. Options determine how messages
+	// are delivered to the handler when it cannot keep up with them.
+	Recv(ctx context.Context, handler func(m Message), options ...RecvOption)
 	// SetUnmarshaler set an unmarshaler that will unmarshal a given
 	// type to a concrete object that can be passed to and understood by any
 	// registered message handling functions. The unmarshaler should be a
@@ -120,6 +126,97 @@ type BroadcastChannel interface {
 	SetFilter(filter BroadcastChannelFilter) error
 }
 
+// DeliveryMode determines how messages are delivered to a message handler
+// whose buffer is full.
+type DeliveryMode int
+
+const (
+	// BestEffortDelivery drops the message if the handler's buffer is full.
+	BestEffortDelivery DeliveryMode = iota
+	// BlockingDelivery waits for the room in the handler's buffer for at
+	// most MaxBlockingDeliveryTime and drops the message only if the buffer
+	// is still full. It should be used only by handlers which are expected
+	// to process messages promptly as it slows down the delivery of messages
+	// to all other handlers of the channel.
+	BlockingDelivery
+)
+
+// MaxBlockingDeliveryTime is the maximum time the delivery of a message to
+// a handler using the BlockingDelivery mode can take.
+const MaxBlockingDeliveryTime = 1 * time.Second
+
+// RecvOptions determine how messages are delivered to a message handler
+// installed with BroadcastChannel.Recv.
+type RecvOptions struct {
+	// DeliveryMode is the mode of delivery used once the handler's buffer
+	// is full.
+	DeliveryMode DeliveryMode
+	// BufferSize is the number of messages buffered for the handler.
+	BufferSize int
+	// OnDrop, if set, is called with every message dropped before it
+	// reached the handler. It must not block.
+	OnDrop func(m Message)
+}
+
+// RecvOption sets an option of a message handler.
+type RecvOption func(options *RecvOptions)
+
+// WithDeliveryMode sets the delivery mode of the message handler.
+func WithDeliveryMode(mode DeliveryMode) RecvOption {
+	return func(options *RecvOptions) {
+		options.DeliveryMode = mode
+	}
+}
+
+// WithBufferSize sets the number of messages buffered for the message
+// handler.
+func WithBufferSize(size int) RecvOption {
+	return func(options *RecvOptions) {
+		options.BufferSize = size
+	}
+}
+
+// WithDropHandler sets the function called with every message dropped before
+// it reached the message handler.
+func WithDropHandler(onDrop func(m Message)) RecvOption {
+	return func(options *RecvOptions) {
+		options.OnDrop = onDrop
+	}
+}
+
+// NewRecvOptions returns the message handler options with the given
+// options applied. Broadcast channel implementations use it to resolve
+// options passed to Recv.
+func NewRecvOptions(defaultBufferSize int, options ...RecvOption) *RecvOptions {
+	recvOptions := &RecvOptions{
+		DeliveryMode: BestEffortDelivery,
+		BufferSize:   defaultBufferSize,
+	}
+
+	for _, option := range options {
+		option(recvOptions)
+	}
+
+	return recvOptions
+}
+
+// DroppedMessagesCounter is implemented by broadcast channels keeping track
+// of messages they could not deliver.
+type DroppedMessagesCounter interface {
+	// DroppedMessages returns the number of messages dropped by the channel
+	// since it was created, either because the channel could not keep up with
+	// incoming messages or because some of its handlers were too slow.
+	DroppedMessages() uint64
+}
+
+// DroppedMessagesProvider is implemented by network providers keeping track
+// of messages dropped by their broadcast channels.
+type DroppedMessagesProvider interface {
+	// DroppedBroadcastMessages returns the number of messages dropped by
+	// every broadcast channel, keyed by the channel name.
+	DroppedBroadcastMessages() map[string]uint64
+}
+
 // BroadcastChannelFilter represents a filter which determine if the incoming
 // message should be processed by the receivers. It takes the message author's
 // public key as its argument and returns true if the message should be
@@ -137,3 +234,334 @@ type Firewall interface {
 	// describing what is wrong.
 	Validate(remotePeerPublicKey *operator.PublicKey) error
 }
+
+// ReputationEvent represents an event affecting the reputation of a peer.
+type ReputationEvent int
+
+const (
+	// InvalidMessageEvent is reported when the peer sent a message that
+	// could not be parsed.
+	InvalidMessageEvent ReputationEvent = iota
+	// SenderSpoofingEvent is reported when the peer sent a message with
+	// a sender identity that does not match the actual sender.
+	SenderSpoofingEvent
+	// MessageFloodingEvent is reported when messages from the peer had to
+	// be dropped because they were delivered faster than they could be
+	// processed.
+	MessageFloodingEvent
+	// ProtocolInactivityEvent is reported when the peer was marked as
+	// inactive during a protocol execution.
+	ProtocolInactivityEvent
+	// ProtocolMisbehaviorEvent is reported when the peer was disqualified
+	// during a protocol execution.
+	ProtocolMisbehaviorEvent
+)
+
+func (re ReputationEvent) String() string {
+	switch re {
+	case InvalidMessageEvent:
+		return "invalid_message"
+	case SenderSpoofingEvent:
+		return "sender_spoofing"
+	case MessageFloodingEvent:
+		return "message_flooding"
+	case ProtocolInactivityEvent:
+		return "protocol_inactivity"
+	case ProtocolMisbehaviorEvent:
+		return "protocol_misbehavior"
+	default:
+		return "unknown"
+	}
+}
+
+// PeerReputation holds the current reputation of a peer.
+type PeerReputation struct {
+	// Peer is the transport identifier of the peer.
+	Peer string
+	// Score is the current reputation score of the peer. Negative values
+	// denote a bad reputation.
+	Score float64
+	// BannedUntil is the time until which the peer is banned. It is
+	// the zero time if the peer is not banned.
+	BannedUntil time.Time
+}
+
+// ReputationManager is implemented by connection managers maintaining the
+// reputation of peers. Peers with a bad reputation are pruned first and are
+// temporarily banned once their reputation drops below a certain level.
+type ReputationManager interface {
+	// ReportPeer reports an event affecting the reputation of the connected
+	// peer with the given transport identifier.
+	ReportPeer(connectedPeer string, event ReputationEvent)
+
+	// PeersReputation returns the current reputation of all peers
+	// for which any event was reported.
+	PeersReputation() []PeerReputation
+}
+
+// PeerVersion holds the version of the network protocol run by a peer, as
+// announced during the connection handshake.
+type PeerVersion struct {
+	// ProtocolVersion is the version of the protocol the peer is executing.
+	ProtocolVersion uint32
+	// MinProtocolVersion is the minimum version of the protocol the peer is
+	// compatible with.
+	MinProtocolVersion uint32
+	// Capabilities is the set of capabilities negotiated with the peer.
+	Capabilities []string
+}
+
+// PeerVersionProvider is implemented by connection managers negotiating
+// the protocol version with connected peers.
+type PeerVersionProvider interface {
+	// GetPeerVersion returns the version of the connected peer with the given
+	// transport identifier. The second returned value is false if the version
+	// of the peer is not known.
+	GetPeerVersion(connectedPeer string) (PeerVersion, bool)
+}
+
+// MessageSizeLimiter is implemented by broadcast channels enforcing maximum
+// sizes of messages. Limits are enforced both when sending and receiving
+// messages; messages exceeding them are rejected.
+type MessageSizeLimiter interface {
+	// SetMaxMessageSize sets the maximum size in bytes of messages
+	// transmitted over the channel, as sent over the wire.
+	SetMaxMessageSize(maxSize int)
+
+	// SetMaxPayloadSize sets the maximum size in bytes of the uncompressed
+	// payload of messages of the given type.
+	SetMaxPayloadSize(messageType string, maxSize int)
+}
+
+// RateLimit determines how many messages a single peer can publish to
+// a broadcast channel.
+type RateLimit struct {
+	// MessagesPerSecond is the sustained rate of messages. Non-positive
+	// value disables the limit.
+	MessagesPerSecond float64
+	// Burst is the maximum number of messages published at once.
+	Burst int
+}
+
+// MessageRateLimiter is implemented by broadcast channels limiting messages
+// published by peers. Messages exceeding limits are rejected and their
+// authors are reported to the reputation layer.
+type MessageRateLimiter interface {
+	// SetRateLimit sets the rate limit of messages published by every peer.
+	SetRateLimit(limit RateLimit)
+
+	// SetMessageQuota sets the maximum number of distinct messages of
+	// the given type a peer can publish within a single quota scope.
+	// Retransmissions of a message are not counted against the quota.
+	SetMessageQuota(messageType string, quota int)
+}
+
+// QuotaScopedMessage is implemented by message payloads counted against
+// per-type quotas within a scope, for example, a single attempt of a protocol
+// executed by a single group member. Messages not implementing it are counted
+// within a single scope of their author.
+type QuotaScopedMessage interface {
+	// QuotaScope returns the identifier of the scope the message is counted
+	// against.
+	QuotaScope() string
+}
+
+// Retransmitter is implemented by broadcast channels able to retransmit
+// messages on demand, in addition to their periodic retransmissions.
+type Retransmitter interface {
+	// RetransmitNow retransmits all messages sent over the channel whose
+	// send contexts are not done yet.
+	RetransmitNow()
+}
+
+// BroadcastTraffic holds statistics of the traffic of messages of the given
+// type transmitted over the given broadcast channel. Sizes are the sizes
+// of messages as transmitted over the wire.
+type BroadcastTraffic struct {
+	Channel          string
+	MessageType      string
+	MessagesSent     uint64
+	BytesSent        uint64
+	MessagesReceived uint64
+	BytesReceived    uint64
+}
+
+// BroadcastTrafficProvider is implemented by network providers gathering
+// statistics of the broadcast channels traffic.
+type BroadcastTrafficProvider interface {
+	// BroadcastTraffic returns the statistics of the traffic of all
+	// broadcast channels, per channel and message type.
+	BroadcastTraffic() []BroadcastTraffic
+}
+
+// BroadcastChannelCloser is implemented by network providers able to close
+// broadcast channels which are no longer needed.
+type BroadcastChannelCloser interface {
+	// CloseBroadcastChannel stops receiving messages from the broadcast
+	// channel with the given name and releases its resources. Handlers
+	// registered for the channel are no longer called. A subsequent
+	// BroadcastChannelFor call with the same name opens a new channel.
+	// Closing a channel that is not open is a no-op.
+	CloseBroadcastChannel(name string) error
+}
+
+// OperatorPeer is a peer run by the operator with the given chain address.
+type OperatorPeer struct {
+	// OperatorAddress is the chain address of the operator.
+	OperatorAddress string
+	// PeerID is the transport identifier of the peer.
+	PeerID string
+	// Multiaddrs are the known addresses of the peer.
+	Multiaddrs []string
+}
+
+// OperatorPeerResolver is implemented by connection managers able to resolve
+// chain addresses of operators into peers run by those operators and to dial
+// those peers on demand.
+type OperatorPeerResolver interface {
+	// FindOperatorPeer resolves the chain address of the operator into
+	// the peer run by that operator.
+	FindOperatorPeer(
+		ctx context.Context,
+		operatorAddress string,
+	) (*OperatorPeer, error)
+
+	// ConnectOperators dials peers of the given operators the client is not
+	// connected with yet. Returns chain addresses of operators whose peers
+	// could not be reached.
+	ConnectOperators(
+		ctx context.Context,
+		operatorAddresses []string,
+	) ([]string, error)
+}
+
+// ReachabilityStatus denotes whether the client is reachable from the outside
+// network.
+type ReachabilityStatus string
+
+const (
+	// ReachabilityUnknown means the reachability of the client has not been
+	// determined yet.
+	ReachabilityUnknown ReachabilityStatus = "unknown"
+	// ReachabilityPublic means the client accepts inbound connections from
+	// the outside network.
+	ReachabilityPublic ReachabilityStatus = "public"
+	// ReachabilityPrivate means the client is not reachable from the outside
+	// network, for example, because it is behind NAT.
+	ReachabilityPrivate ReachabilityStatus = "private"
+)
+
+// Reachability describes the reachability of the client from the outside
+// network along with the enabled NAT traversal features.
+type Reachability struct {
+	// Status is the reachability of the client, as detected by the network
+	// layer.
+	Status ReachabilityStatus
+	// ListenAddresses are the addresses the client listens on.
+	ListenAddresses []string
+	// AnnouncedAddresses are the addresses announced to peers, except relay
+	// addresses.
+	AnnouncedAddresses []string
+	// RelayAddresses are the addresses announced to peers through which
+	// the client is reachable over circuit relays.
+	RelayAddresses []string
+	// Relays are the configured circuit relays.
+	Relays []string
+	// RelayService denotes whether the client acts as a circuit relay for
+	// other peers.
+	RelayService bool
+	// HolePunching denotes whether hole punching is enabled.
+	HolePunching bool
+	// NATPortMap denotes whether port mapping in the NAT device is enabled.
+	NATPortMap bool
+}
+
+// ReachabilityProvider is implemented by network providers detecting their
+// reachability from the outside network.
+type ReachabilityProvider interface {
+	// Reachability returns the current reachability of the client.
+	Reachability() Reachability
+}
+
+// ConnectionFailure denotes the stage at which the connection with a peer
+// failed.
+type ConnectionFailure string
+
+const (
+	// InvalidAddressFailure denotes the address of the peer could not be
+	// parsed.
+	InvalidAddressFailure ConnectionFailure = "invalid_address"
+	// LocalFirewallFailure denotes the peer was rejected by the firewall of
+	// the client.
+	LocalFirewallFailure ConnectionFailure = "local_firewall"
+	// DialFailure denotes the peer could not be dialed.
+	DialFailure ConnectionFailure = "dial"
+	// HandshakeFailure denotes the connection handshake with the peer
+	// failed, for example, due to incompatible protocol versions.
+	HandshakeFailure ConnectionFailure = "handshake"
+	// RemoteRejectionFailure denotes the connection was closed by the peer
+	// once the handshake was completed, most likely because the client was
+	// rejected by the firewall of the peer.
+	RemoteRejectionFailure ConnectionFailure = "remote_rejection"
+)
+
+// PeerDiagnosis holds the result of checking the connectivity with a peer.
+type PeerDiagnosis struct {
+	// Address is the multiaddress of the peer.
+	Address string
+	// PeerID is the transport identifier of the peer. It is empty if
+	// the address could not be parsed.
+	PeerID string
+	// Connected determines whether the connection with the peer was
+	// established.
+	Connected bool
+	// Failure is the stage at which the connection failed. It is empty if
+	// the connection was established.
+	Failure ConnectionFailure
+	// FailureReason describes why the connection failed.
+	FailureReason string
+	// Latency is the round-trip time to the peer. It is zero if the latency
+	// could not be measured.
+	Latency time.Duration
+}
+
+// AddressCheck holds the result of checking whether the client accepts
+// connections on the given address.
+type AddressCheck struct {
+	// Address is the checked multiaddress.
+	Address string
+	// Reachable determines whether a connection to the address was
+	// established.
+	Reachable bool
+	// FailureReason describes why the address is not reachable.
+	FailureReason string
+}
+
+// RoutingTablePeer holds information about a peer in the routing table of
+// the client.
+type RoutingTablePeer struct {
+	// PeerID is the transport identifier of the peer.
+	PeerID string
+	// Connected determines whether the client is connected with the peer.
+	Connected bool
+	// AddedAt is the time the peer was added to the routing table.
+	AddedAt time.Time
+	// LastSuccessfulQueryAt is the time of the last successful query to
+	// the peer. It is the zero time if the peer was never queried.
+	LastSuccessfulQueryAt time.Time
+}
+
+// ConnectivityDiagnoser is implemented by network providers able to diagnose
+// their connectivity with the network.
+type ConnectivityDiagnoser interface {
+	// DiagnosePeer connects the peer with the given multiaddress and
+	// measures the latency to that peer.
+	DiagnosePeer(ctx context.Context, address string) PeerDiagnosis
+
+	// CheckAddress checks whether a connection to the given multiaddress can
+	// be established from the client's host.
+	CheckAddress(ctx context.Context, address string) AddressCheck
+
+	// RoutingTable returns peers in the routing table of the client.
+	RoutingTable() []RoutingTablePeer
+}
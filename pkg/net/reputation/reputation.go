@@ -0,0 +1,208 @@
+// Package reputation keeps track of the reputation of network peers. The
+// reputation is fed by events reported by the network and protocol layers and
+// decays over time so that peers are not penalized forever for past
+// misbehavior.
+package reputation
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+const (
+	// DefaultHalfLife is the default time after which the reputation score
+	// of a peer decays by half.
+	DefaultHalfLife = 1 * time.Hour
+	// DefaultBanThreshold is the default reputation score at or below which
+	// a peer gets banned.
+	DefaultBanThreshold = -100
+	// DefaultBanDuration is the default duration of a peer ban.
+	DefaultBanDuration = 1 * time.Hour
+)
+
+// DefaultEventWeights returns the default score change caused by each event.
+func DefaultEventWeights() map[net.ReputationEvent]float64 {
+	return map[net.ReputationEvent]float64{
+		net.InvalidMessageEvent:      -10,
+		net.SenderSpoofingEvent:      -50,
+		net.MessageFloodingEvent:     -1,
+		net.ProtocolInactivityEvent:  -5,
+		net.ProtocolMisbehaviorEvent: -25,
+	}
+}
+
+// Parameters determine how the reputation is computed.
+type Parameters struct {
+	// HalfLife is the time after which the reputation score decays by half.
+	HalfLife time.Duration
+	// BanThreshold is the reputation score at or below which a peer gets
+	// banned.
+	BanThreshold float64
+	// BanDuration is the duration of a peer ban.
+	BanDuration time.Duration
+	// EventWeights holds the score change caused by each event. Events
+	// without a weight do not change the score.
+	EventWeights map[net.ReputationEvent]float64
+}
+
+// DefaultParameters returns the default reputation parameters.
+func DefaultParameters() Parameters {
+	return Parameters{
+		HalfLife:     DefaultHalfLife,
+		BanThreshold: DefaultBanThreshold,
+		BanDuration:  DefaultBanDuration,
+		EventWeights: DefaultEventWeights(),
+	}
+}
+
+type entry struct {
+	score       float64
+	updatedAt   time.Time
+	bannedUntil time.Time
+}
+
+// Store holds the reputation of peers.
+type Store struct {
+	parameters Parameters
+
+	entriesMutex sync.Mutex
+	entries      map[string]*entry
+
+	now func() time.Time
+}
+
+// NewStore creates a new reputation store with the given parameters.
+func NewStore(parameters Parameters) *Store {
+	return &Store{
+		parameters: parameters,
+		entries:    make(map[string]*entry),
+		now:        time.Now,
+	}
+}
+
+// Record records the given event for the given peer. It returns the updated
+// score of the peer and a flag indicating whether the peer got banned as
+// a result of this event.
+func (s *Store) Record(peer string, event net.ReputationEvent) (float64, bool) {
+	s.entriesMutex.Lock()
+	defer s.entriesMutex.Unlock()
+
+	now := s.now()
+
+	e, ok := s.entries[peer]
+	if !ok {
+		e = &entry{updatedAt: now}
+		s.entries[peer] = e
+	}
+
+	e.score = s.decayed(e, now) + s.parameters.EventWeights[event]
+	e.updatedAt = now
+
+	if e.score <= s.parameters.BanThreshold && !now.Before(e.bannedUntil) {
+		e.bannedUntil = now.Add(s.parameters.BanDuration)
+		// Start over once the ban is lifted so that the peer is not banned
+		// again right after the ban expires.
+		e.score = 0
+		return e.score, true
+	}
+
+	return e.score, false
+}
+
+// Parameters returns the parameters the reputation is computed with.
+func (s *Store) Parameters() Parameters {
+	return s.parameters
+}
+
+// Restore sets the reputation of the peer to the given one. It is used to
+// restore the reputation of peers persisted before the client restart.
+func (s *Store) Restore(reputation net.PeerReputation) {
+	s.entriesMutex.Lock()
+	defer s.entriesMutex.Unlock()
+
+	s.entries[reputation.Peer] = &entry{
+		score:       reputation.Score,
+		updatedAt:   s.now(),
+		bannedUntil: reputation.BannedUntil,
+	}
+}
+
+// Score returns the current reputation score of the given peer.
+func (s *Store) Score(peer string) float64 {
+	s.entriesMutex.Lock()
+	defer s.entriesMutex.Unlock()
+
+	e, ok := s.entries[peer]
+	if !ok {
+		return 0
+	}
+
+	return s.decayed(e, s.now())
+}
+
+// IsBanned returns true if the given peer is currently banned.
+func (s *Store) IsBanned(peer string) bool {
+	s.entriesMutex.Lock()
+	defer s.entriesMutex.Unlock()
+
+	e, ok := s.entries[peer]
+	if !ok {
+		return false
+	}
+
+	return s.now().Before(e.bannedUntil)
+}
+
+// Snapshot returns the current reputation of all known peers sorted
+// by score in ascending order. Entries with a negligible score which are not
+// banned are removed from the store.
+func (s *Store) Snapshot() []net.PeerReputation {
+	s.entriesMutex.Lock()
+	defer s.entriesMutex.Unlock()
+
+	now := s.now()
+
+	snapshot := make([]net.PeerReputation, 0, len(s.entries))
+	for peer, e := range s.entries {
+		score := s.decayed(e, now)
+		banned := now.Before(e.bannedUntil)
+
+		if !banned && math.Abs(score) < 0.01 {
+			delete(s.entries, peer)
+			continue
+		}
+
+		reputation := net.PeerReputation{
+			Peer:  peer,
+			Score: score,
+		}
+		if banned {
+			reputation.BannedUntil = e.bannedUntil
+		}
+
+		snapshot = append(snapshot, reputation)
+	}
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		if snapshot[i].Score == snapshot[j].Score {
+			return snapshot[i].Peer < snapshot[j].Peer
+		}
+		return snapshot[i].Score < snapshot[j].Score
+	})
+
+	return snapshot
+}
+
+// decayed returns the score of the given entry decayed up to the given time.
+func (s *Store) decayed(e *entry, now time.Time) float64 {
+	elapsed := now.Sub(e.updatedAt)
+	if elapsed <= 0 || s.parameters.HalfLife <= 0 {
+		return e.score
+	}
+
+	return e.score * math.Pow(0.5, float64(elapsed)/float64(s.parameters.HalfLife))
+}
@@ -0,0 +1,177 @@
+package reputation
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+func TestStore_Record(t *testing.T) {
+	store, _ := newTestStore()
+
+	score, banned := store.Record("peer-1", net.InvalidMessageEvent)
+	assertScore(t, -10, score)
+	testutils.AssertBoolsEqual(t, "banned", false, banned)
+
+	score, banned = store.Record("peer-1", net.ProtocolInactivityEvent)
+	assertScore(t, -15, score)
+	testutils.AssertBoolsEqual(t, "banned", false, banned)
+
+	assertScore(t, -15, store.Score("peer-1"))
+	assertScore(t, 0, store.Score("peer-2"))
+}
+
+func TestStore_Decay(t *testing.T) {
+	store, clock := newTestStore()
+
+	store.Record("peer-1", net.SenderSpoofingEvent)
+	assertScore(t, -50, store.Score("peer-1"))
+
+	clock.advance(DefaultHalfLife)
+	assertScore(t, -25, store.Score("peer-1"))
+
+	clock.advance(DefaultHalfLife)
+	assertScore(t, -12.5, store.Score("peer-1"))
+
+	score, _ := store.Record("peer-1", net.InvalidMessageEvent)
+	assertScore(t, -22.5, score)
+}
+
+func TestStore_Ban(t *testing.T) {
+	store, clock := newTestStore()
+
+	store.Record("peer-1", net.SenderSpoofingEvent)
+	testutils.AssertBoolsEqual(t, "banned", false, store.IsBanned("peer-1"))
+
+	_, banned := store.Record("peer-1", net.SenderSpoofingEvent)
+	testutils.AssertBoolsEqual(t, "newly banned", true, banned)
+	testutils.AssertBoolsEqual(t, "banned", true, store.IsBanned("peer-1"))
+	testutils.AssertBoolsEqual(t, "banned", false, store.IsBanned("peer-2"))
+
+	// Events reported during the ban do not extend it.
+	_, banned = store.Record("peer-1", net.SenderSpoofingEvent)
+	testutils.AssertBoolsEqual(t, "newly banned", false, banned)
+	_, banned = store.Record("peer-1", net.SenderSpoofingEvent)
+	testutils.AssertBoolsEqual(t, "newly banned", false, banned)
+
+	clock.advance(DefaultBanDuration - time.Second)
+	testutils.AssertBoolsEqual(t, "banned", true, store.IsBanned("peer-1"))
+
+	clock.advance(time.Second)
+	testutils.AssertBoolsEqual(t, "banned", false, store.IsBanned("peer-1"))
+}
+
+func TestStore_CustomParameters(t *testing.T) {
+	clock := &testClock{current: time.Unix(1000000, 0)}
+
+	store := NewStore(Parameters{
+		HalfLife:     DefaultHalfLife,
+		BanThreshold: -20,
+		BanDuration:  time.Minute,
+		EventWeights: map[net.ReputationEvent]float64{
+			net.InvalidMessageEvent: -15,
+		},
+	})
+	store.now = clock.now
+
+	score, banned := store.Record("peer-1", net.InvalidMessageEvent)
+	assertScore(t, -15, score)
+	testutils.AssertBoolsEqual(t, "banned", false, banned)
+
+	// Events without a weight do not change the score.
+	score, banned = store.Record("peer-1", net.SenderSpoofingEvent)
+	assertScore(t, -15, score)
+	testutils.AssertBoolsEqual(t, "banned", false, banned)
+
+	_, banned = store.Record("peer-1", net.InvalidMessageEvent)
+	testutils.AssertBoolsEqual(t, "newly banned", true, banned)
+}
+
+func TestStore_Snapshot(t *testing.T) {
+	store, clock := newTestStore()
+
+	store.Record("peer-1", net.InvalidMessageEvent)
+	store.Record("peer-2", net.SenderSpoofingEvent)
+	store.Record("peer-3", net.SenderSpoofingEvent)
+	store.Record("peer-3", net.SenderSpoofingEvent)
+
+	expectedSnapshot := []net.PeerReputation{
+		{Peer: "peer-2", Score: -50},
+		{Peer: "peer-1", Score: -10},
+		{
+			Peer:        "peer-3",
+			Score:       0,
+			BannedUntil: clock.now().Add(DefaultBanDuration),
+		},
+	}
+	snapshot := store.Snapshot()
+	if !reflect.DeepEqual(expectedSnapshot, snapshot) {
+		t.Errorf(
+			"unexpected snapshot\nexpected: [%+v]\nactual:   [%+v]",
+			expectedSnapshot,
+			snapshot,
+		)
+	}
+
+	// Scores decay below the negligible level and the ban expires.
+	clock.advance(20 * DefaultHalfLife)
+
+	if snapshot := store.Snapshot(); len(snapshot) != 0 {
+		t.Errorf("unexpected snapshot: [%+v]", snapshot)
+	}
+}
+
+func TestStore_Restore(t *testing.T) {
+	store, clock := newTestStore()
+
+	bannedUntil := clock.now().Add(time.Minute)
+
+	store.Restore(net.PeerReputation{
+		Peer:        "peer-1",
+		Score:       -40,
+		BannedUntil: bannedUntil,
+	})
+
+	assertScore(t, -40, store.Score("peer-1"))
+	testutils.AssertBoolsEqual(t, "banned", true, store.IsBanned("peer-1"))
+
+	clock.advance(time.Minute)
+	testutils.AssertBoolsEqual(t, "banned", false, store.IsBanned("peer-1"))
+}
+
+type testClock struct {
+	current time.Time
+}
+
+func (tc *testClock) now() time.Time {
+	return tc.current
+}
+
+func (tc *testClock) advance(duration time.Duration) {
+	tc.current = tc.current.Add(duration)
+}
+
+func newTestStore() (*Store, *testClock) {
+	clock := &testClock{current: time.Unix(1000000, 0)}
+
+	store := NewStore(DefaultParameters())
+	store.now = clock.now
+
+	return store, clock
+}
+
+func assertScore(t *testing.T, expected float64, actual float64) {
+	t.Helper()
+
+	if math.Abs(expected-actual) > 1e-9 {
+		t.Errorf(
+			"unexpected score\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			actual,
+		)
+	}
+}
@@ -101,6 +101,10 @@ func (mnm *mockNetworkMessage) Seqno() uint64 {
 	return mnm.seqno
 }
 
+func (mnm *mockNetworkMessage) Signature() *net.MessageSignature {
+	panic("not implemented")
+}
+
 type mockTransportIdentifier struct {
 	senderID string
 }
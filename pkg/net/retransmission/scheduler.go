@@ -0,0 +1,62 @@
+package retransmission
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ipfs/go-log"
+)
+
+// Scheduler schedules retransmissions of messages sent over a single
+// broadcast channel and allows to retransmit them on demand, without waiting
+// for the next tick. The zero value is ready to use.
+type Scheduler struct {
+	mutex       sync.Mutex
+	nextID      uint64
+	retransmits map[uint64]func()
+}
+
+// Schedule schedules retransmissions of a message using the provided Ticker,
+// just like ScheduleRetransmissions does. Additionally, the message is
+// retransmitted on every RetransmitNow call for the entire lifetime of
+// the provided Context.
+func (s *Scheduler) Schedule(
+	ctx context.Context,
+	logger log.StandardLogger,
+	ticker *Ticker,
+	retransmit func() error,
+) {
+	ScheduleRetransmissions(ctx, logger, ticker, retransmit)
+
+	s.mutex.Lock()
+	if s.retransmits == nil {
+		s.retransmits = make(map[uint64]func())
+	}
+	id := s.nextID
+	s.nextID++
+	s.retransmits[id] = func() {
+		if err := retransmit(); err != nil {
+			logger.Errorf("could not retransmit message: [%v]", err)
+		}
+	}
+	s.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		s.mutex.Lock()
+		delete(s.retransmits, id)
+		s.mutex.Unlock()
+	}()
+}
+
+// RetransmitNow retransmits all messages whose retransmission contexts are
+// not done yet. Retransmissions are performed in the background.
+func (s *Scheduler) RetransmitNow() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, retransmit := range s.retransmits {
+		go retransmit()
+	}
+}
@@ -0,0 +1,57 @@
+package retransmission
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestSchedulerRetransmitNow(t *testing.T) {
+	ticker := NewTicker(make(chan uint64))
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+
+	var retransmissionsCount1, retransmissionsCount2 uint64
+
+	scheduler := &Scheduler{}
+	scheduler.Schedule(
+		ctx1,
+		&testutils.MockLogger{},
+		ticker,
+		func() error {
+			atomic.AddUint64(&retransmissionsCount1, 1)
+			return nil
+		},
+	)
+	scheduler.Schedule(
+		ctx2,
+		&testutils.MockLogger{},
+		ticker,
+		func() error {
+			atomic.AddUint64(&retransmissionsCount2, 1)
+			return nil
+		},
+	)
+
+	scheduler.RetransmitNow()
+	time.Sleep(10 * time.Millisecond)
+
+	cancel2()
+	time.Sleep(10 * time.Millisecond)
+
+	scheduler.RetransmitNow()
+	time.Sleep(10 * time.Millisecond)
+
+	if count := atomic.LoadUint64(&retransmissionsCount1); count != 2 {
+		t.Errorf("expected [2] retransmissions of the first message, has [%v]", count)
+	}
+	if count := atomic.LoadUint64(&retransmissionsCount2); count != 1 {
+		t.Errorf("expected [1] retransmission of the second message, has [%v]", count)
+	}
+}
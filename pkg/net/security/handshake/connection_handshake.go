@@ -18,16 +18,23 @@
 //
 // [Act 1]
 // nonce1 = random_nonce()
-// act1Message{nonce1, protocol_id1} ---->
+// act1Message{nonce1, protocol_id1, version1} ---->
 //                                       [Act 2]
 //                                       nonce2 = random_nonce()
 //                                       challenge = sha256(nonce1 || nonce2)
-//                                       <---- act2Message{challenge, nonce2, protocol_id2}
+//                                       <---- act2Message{challenge, nonce2, protocol_id2, version2}
 // [Act 3]
 // challenge = sha256(nonce1 || nonce2)
-// act3Message{challenge} ---->
+// capabilities = version1.capabilities ∩ version2.capabilities
+// act3Message{challenge, capabilities} ---->
 //
 //
+// Both parties announce the version of the protocol they are executing, the
+// minimum version they are compatible with, and the set of capabilities they
+// support. Each party rejects the other one if their versions are
+// incompatible. The initiator announces the capabilities negotiated for the
+// connection in the third act and the responder validates them.
+//
 // act1Message, act2Message, and act3Message are messages exchanged between
 // initiator and responder in acts one, two, and three of the handshake,
 // respectively.
@@ -50,37 +57,124 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"sort"
 )
 
+// ErrIncompatibleProtocolVersion is returned when the protocol version of the
+// remote peer is not compatible with the local one.
+var ErrIncompatibleProtocolVersion = errors.New("incompatible protocol version")
+
+// Version describes the version of the protocol executed by a peer and the
+// capabilities supported by that peer.
+type Version struct {
+	// Protocol is the version of the protocol the peer is executing.
+	Protocol uint32
+	// MinProtocol is the minimum version of the protocol the peer is
+	// compatible with.
+	MinProtocol uint32
+	// Capabilities is the set of optional features supported by the peer,
+	// for example, supported message types or compression algorithms.
+	Capabilities []string
+}
+
+// checkCompatibility validates if the remote peer's version is compatible
+// with the local one. Versions are compatible if each of them is at least
+// the minimum version required by the other party. Peers not announcing
+// their version are considered as running version 0.
+func checkCompatibility(local Version, remote Version) error {
+	if remote.Protocol < local.MinProtocol {
+		return fmt.Errorf(
+			"%w; peer runs version [%v] but at least version [%v] is required",
+			ErrIncompatibleProtocolVersion,
+			remote.Protocol,
+			local.MinProtocol,
+		)
+	}
+
+	if local.Protocol < remote.MinProtocol {
+		return fmt.Errorf(
+			"%w; peer requires at least version [%v] but version [%v] is run",
+			ErrIncompatibleProtocolVersion,
+			remote.MinProtocol,
+			local.Protocol,
+		)
+	}
+
+	return nil
+}
+
+// negotiateCapabilities returns the sorted set of capabilities supported by
+// both parties.
+func negotiateCapabilities(local []string, remote []string) []string {
+	remoteCapabilities := make(map[string]bool, len(remote))
+	for _, capability := range remote {
+		remoteCapabilities[capability] = true
+	}
+
+	negotiated := make([]string, 0)
+	for _, capability := range local {
+		if remoteCapabilities[capability] {
+			negotiated = append(negotiated, capability)
+			// Make sure duplicates are not included.
+			delete(remoteCapabilities, capability)
+		}
+	}
+
+	sort.Strings(negotiated)
+
+	return negotiated
+}
+
+// equalCapabilities checks if both sorted capability sets are equal.
+func equalCapabilities(capabilities1 []string, capabilities2 []string) bool {
+	if len(capabilities1) != len(capabilities2) {
+		return false
+	}
+
+	for i := range capabilities1 {
+		if capabilities1[i] != capabilities2[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Act1Message is sent in the first handshake act by the initiator to the
 // responder. It contains randomly generated `nonce1`, an 8-byte (64-bit)
-// unsigned integer as well as the protocol identifier.
+// unsigned integer as well as the protocol identifier and the initiator's
+// protocol version.
 //
 // act1Message should be signed with initiator's static private key.
 type Act1Message struct {
 	nonce1    uint64
 	protocol1 string
+	version1  Version
 }
 
 // Act2Message is sent in the second handshake act by the responder to the
 // initiator. It contains randomly generated `nonce2`, which is an 8-byte
 // unsigned integer, `challenge`, which is the result of SHA256 on the
-// concatenated bytes of `nonce1` and `nonce2`, and the protocol identifier.
+// concatenated bytes of `nonce1` and `nonce2`, the protocol identifier, and
+// the responder's protocol version.
 //
 // act2Message should be signed with responder's static private key.
 type Act2Message struct {
 	nonce2    uint64
 	challenge [sha256.Size]byte
 	protocol2 string
+	version2  Version
 }
 
 // Act3Message is sent in the third handshake act by the initiator to the
 // responder. It contains the challenge that has been recomputed by the
-// initiator as a SHA256 of the concatenated bytes of `nonce1` and `nonce2`.
+// initiator as a SHA256 of the concatenated bytes of `nonce1` and `nonce2`
+// and the capabilities negotiated for the connection.
 //
 // act3Message should be signed with initiator's static private key.
 type Act3Message struct {
-	challenge [sha256.Size]byte
+	challenge    [sha256.Size]byte
+	capabilities []string
 }
 
 // InitiatorAct1 represents the state of the initiator in the first act of the
@@ -88,42 +182,60 @@ type Act3Message struct {
 type InitiatorAct1 struct {
 	nonce1    uint64
 	protocol1 string
+	version1  Version
 }
 
 // InitiateHandshake function allows to initiate a handshake by creating
 // and initializing a state machine representing initiator in the first round
 // of the handshake, ready to execute the protocol.
-func InitiateHandshake(protocol string) (*InitiatorAct1, error) {
+func InitiateHandshake(protocol string, version Version) (*InitiatorAct1, error) {
 	nonce1, err := randomNonce()
 	if err != nil {
 		return nil, fmt.Errorf("could not initiate the handshake: [%v]", err)
 	}
 
-	return &InitiatorAct1{nonce1, protocol}, nil
+	return &InitiatorAct1{nonce1, protocol, version}, nil
 }
 
 // Message returns the message sent by initiator to the responder in the first
 // act of the handshake protocol.
 func (ia1 *InitiatorAct1) Message() *Act1Message {
-	return &Act1Message{nonce1: ia1.nonce1, protocol1: ia1.protocol1}
+	return &Act1Message{
+		nonce1:    ia1.nonce1,
+		protocol1: ia1.protocol1,
+		version1:  ia1.version1,
+	}
 }
 
 // Next performs a state transition and returns initiator in a state ready to
 // execute the second act of the handshake protocol.
 func (ia1 *InitiatorAct1) Next() *InitiatorAct2 {
-	return &InitiatorAct2{nonce1: ia1.nonce1, protocol1: ia1.protocol1}
+	return &InitiatorAct2{
+		nonce1:    ia1.nonce1,
+		protocol1: ia1.protocol1,
+		version1:  ia1.version1,
+	}
 }
 
 // AnswerHandshake is used to initiate a responder as a result of receiving
 // message from initiator in the first act of the handshake protocol.
 // The returned responder is in a state ready to execute the second act of the
 // handshake protocol.
-// The function also validates if both parties run the same protocol.
-func AnswerHandshake(message *Act1Message, protocol string) (*ResponderAct2, error) {
+// The function also validates if both parties run the same protocol and if
+// their protocol versions are compatible.
+func AnswerHandshake(
+	message *Act1Message,
+	protocol string,
+	version Version,
+) (*ResponderAct2, error) {
 	if message.protocol1 != protocol {
 		return nil, fmt.Errorf("unsupported protocol: [%v]", message.protocol1)
 	}
 
+	if err := checkCompatibility(version, message.version1); err != nil {
+		return nil, err
+	}
+
 	nonce1 := message.nonce1
 	nonce2, err := randomNonce()
 	if err != nil {
@@ -131,7 +243,13 @@ func AnswerHandshake(message *Act1Message, protocol string) (*ResponderAct2, err
 	}
 	challenge := hashToChallenge(nonce1, nonce2)
 
-	return &ResponderAct2{nonce2, challenge, protocol}, nil
+	return &ResponderAct2{
+		nonce2:    nonce2,
+		challenge: challenge,
+		protocol2: protocol,
+		version2:  version,
+		version1:  message.version1,
+	}, nil
 }
 
 // InitiatorAct2 represents the state of the initiator in the second act of the
@@ -139,6 +257,7 @@ func AnswerHandshake(message *Act1Message, protocol string) (*ResponderAct2, err
 type InitiatorAct2 struct {
 	nonce1    uint64
 	protocol1 string
+	version1  Version
 }
 
 // ResponderAct2 represents the state of the responder in the second act of the
@@ -147,6 +266,10 @@ type ResponderAct2 struct {
 	nonce2    uint64
 	challenge [sha256.Size]byte
 	protocol2 string
+	version2  Version
+
+	// version of the initiator received in the first act
+	version1 Version
 }
 
 // Message returns the message sent by responder to the initiator in the second
@@ -156,13 +279,21 @@ func (ra2 *ResponderAct2) Message() *Act2Message {
 		nonce2:    ra2.nonce2,
 		challenge: ra2.challenge,
 		protocol2: ra2.protocol2,
+		version2:  ra2.version2,
 	}
 }
 
 // Next performs a state transition and returns responder in a state ready to
 // execute the third act of the handshake protocol.
 func (ra2 *ResponderAct2) Next() *ResponderAct3 {
-	return &ResponderAct3{challenge: ra2.challenge}
+	return &ResponderAct3{
+		challenge: ra2.challenge,
+		capabilities: negotiateCapabilities(
+			ra2.version2.Capabilities,
+			ra2.version1.Capabilities,
+		),
+		remoteVersion: ra2.version1,
+	}
 }
 
 // Next performs a state transition and returns initiator in a state ready to
@@ -173,36 +304,75 @@ func (ra2 *ResponderAct2) Next() *ResponderAct3 {
 // initiator is returned. Otherwise, function reports an error and handshake
 // protocol should be immediately aborted.
 //
-// The function also validates if both parties run the same protocol.
+// The function also validates if both parties run the same protocol and if
+// their protocol versions are compatible.
 func (ia2 *InitiatorAct2) Next(message *Act2Message) (*InitiatorAct3, error) {
 	if message.protocol2 != ia2.protocol1 {
 		return nil, fmt.Errorf("unsupported protocol: [%v]", message.protocol2)
 	}
 
+	if err := checkCompatibility(ia2.version1, message.version2); err != nil {
+		return nil, err
+	}
+
 	expectedChallenge := hashToChallenge(ia2.nonce1, message.nonce2)
 	if expectedChallenge != message.challenge {
 		return nil, fmt.Errorf("unexpected responder's challenge")
 	}
 
-	return &InitiatorAct3{challenge: message.challenge}, nil
+	return &InitiatorAct3{
+		challenge: message.challenge,
+		capabilities: negotiateCapabilities(
+			ia2.version1.Capabilities,
+			message.version2.Capabilities,
+		),
+		remoteVersion: message.version2,
+	}, nil
 }
 
 // InitiatorAct3 represents the state of the initiator in the third act of the
 // handshake protocol.
 type InitiatorAct3 struct {
-	challenge [sha256.Size]byte
+	challenge     [sha256.Size]byte
+	capabilities  []string
+	remoteVersion Version
 }
 
 // ResponderAct3 represents the state of the responder in the third act of the
 // handshake protocol.
 type ResponderAct3 struct {
-	challenge [sha256.Size]byte
+	challenge     [sha256.Size]byte
+	capabilities  []string
+	remoteVersion Version
 }
 
 // Message returns the message sent by initiator to the responder in the third
 // act of the handshake protocol.
 func (ia3 *InitiatorAct3) Message() *Act3Message {
-	return &Act3Message{challenge: ia3.challenge}
+	return &Act3Message{
+		challenge:    ia3.challenge,
+		capabilities: ia3.capabilities,
+	}
+}
+
+// RemoteVersion returns the version announced by the responder.
+func (ia3 *InitiatorAct3) RemoteVersion() Version {
+	return ia3.remoteVersion
+}
+
+// Capabilities returns the capabilities negotiated for the connection.
+func (ia3 *InitiatorAct3) Capabilities() []string {
+	return ia3.capabilities
+}
+
+// RemoteVersion returns the version announced by the initiator.
+func (ra3 *ResponderAct3) RemoteVersion() Version {
+	return ra3.remoteVersion
+}
+
+// Capabilities returns the capabilities negotiated for the connection.
+func (ra3 *ResponderAct3) Capabilities() []string {
+	return ra3.capabilities
 }
 
 // FinalizeHandshake is used in the third act of the handshake protocol to
@@ -211,11 +381,21 @@ func (ia3 *InitiatorAct3) Message() *Act3Message {
 // If both challenges are equal, handshake has completed successfully and
 // function returns nil. Otherwise, if challenge is not as expected, function
 // returns an error and it means the handshake protocol failed.
+// The function also validates if the capabilities negotiated by the initiator
+// are the same as the ones negotiated by the responder.
 func (ra3 *ResponderAct3) FinalizeHandshake(message *Act3Message) error {
 	if ra3.challenge != message.challenge {
 		return errors.New("unexpected initiator's challenge")
 	}
 
+	if !equalCapabilities(ra3.capabilities, message.capabilities) {
+		return fmt.Errorf(
+			"unexpected initiator's capabilities; expected [%v], got [%v]",
+			ra3.capabilities,
+			message.capabilities,
+		)
+	}
+
 	return nil
 }
 
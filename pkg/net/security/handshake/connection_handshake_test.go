@@ -12,12 +12,18 @@ const (
 	protocol2 = "keep-ecdsa"
 )
 
+var version = Version{
+	Protocol:     2,
+	MinProtocol:  1,
+	Capabilities: []string{"compression/1", "broadcast-message/1"},
+}
+
 func TestInitiateHanshakeWithUniqueNonce(t *testing.T) {
-	initiator1, err := InitiateHandshake(protocol)
+	initiator1, err := InitiateHandshake(protocol, version)
 	if err != nil {
 		t.Fatal(err)
 	}
-	initiator2, err := InitiateHandshake(protocol)
+	initiator2, err := InitiateHandshake(protocol, version)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -38,14 +44,14 @@ func TestAnswerHandshakeWithChallenge(t *testing.T) {
 	//
 
 	// initiator station
-	initiator, err := InitiateHandshake(protocol)
+	initiator, err := InitiateHandshake(protocol, version)
 	if err != nil {
 		t.Fatal(err)
 	}
 	act1Msg := initiator.Message()
 
 	// responder station
-	responder, err := AnswerHandshake(act1Msg, protocol)
+	responder, err := AnswerHandshake(act1Msg, protocol, version)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -79,10 +85,19 @@ func TestRepeatChallengeToFinalize(t *testing.T) {
 	//
 
 	// responder station
-	act2Msg := &Act2Message{nonce2, expectedChallenge, protocol}
+	act2Msg := &Act2Message{
+		nonce2:    nonce2,
+		challenge: expectedChallenge,
+		protocol2: protocol,
+		version2:  version,
+	}
 
 	// initiator station
-	initiatorAct2 := &InitiatorAct2{nonce1, protocol}
+	initiatorAct2 := &InitiatorAct2{
+		nonce1:    nonce1,
+		protocol1: protocol,
+		version1:  version,
+	}
 	initiatorAct3, err := initiatorAct2.Next(act2Msg)
 	if err != nil {
 		t.Fatal(err)
@@ -112,14 +127,14 @@ func TestFailAct1ForUnexpectedProtocol(t *testing.T) {
 	//
 
 	// initiator station
-	initiator, err := InitiateHandshake(protocol)
+	initiator, err := InitiateHandshake(protocol, version)
 	if err != nil {
 		t.Fatal(err)
 	}
 	act1Msg := initiator.Message()
 
 	// responder station
-	_, err = AnswerHandshake(act1Msg, protocol2)
+	_, err = AnswerHandshake(act1Msg, protocol2, version)
 
 	expectedErr := "unsupported protocol: [keep-beacon]"
 	if err.Error() != expectedErr {
@@ -141,10 +156,19 @@ func TestFailAct2ForUnexpectedProtocol(t *testing.T) {
 	//
 
 	// responder station
-	act2Msg := &Act2Message{nonce2, expectedChallenge, protocol2}
+	act2Msg := &Act2Message{
+		nonce2:    nonce2,
+		challenge: expectedChallenge,
+		protocol2: protocol2,
+		version2:  version,
+	}
 
 	// initiator station
-	initiatorAct2 := &InitiatorAct2{nonce1, protocol}
+	initiatorAct2 := &InitiatorAct2{
+		nonce1:    nonce1,
+		protocol1: protocol,
+		version1:  version,
+	}
 	_, err := initiatorAct2.Next(act2Msg)
 
 	expectedErr := "unsupported protocol: [keep-ecdsa]"
@@ -167,10 +191,19 @@ func TestFailAct2ForInvalidChallenge(t *testing.T) {
 
 	// responder station
 	invalidChallenge := [32]byte{0xff, 0xfa}
-	act2Msg := &Act2Message{nonce2, invalidChallenge, protocol}
+	act2Msg := &Act2Message{
+		nonce2:    nonce2,
+		challenge: invalidChallenge,
+		protocol2: protocol,
+		version2:  version,
+	}
 
 	// initiator station
-	initiatorAct2 := &InitiatorAct2{nonce1, protocol}
+	initiatorAct2 := &InitiatorAct2{
+		nonce1:    nonce1,
+		protocol1: protocol,
+		version1:  version,
+	}
 	_, err := initiatorAct2.Next(act2Msg)
 
 	// assert if initiator detects invalid challenge sent by responder
@@ -186,10 +219,10 @@ func TestFailAct2ForInvalidChallenge(t *testing.T) {
 
 func TestFailAct3ForInvalidChallenge(t *testing.T) {
 	expectedChallenge := hashToChallenge(rand.Uint64(), rand.Uint64())
-	responderAct3 := &ResponderAct3{expectedChallenge}
+	responderAct3 := &ResponderAct3{challenge: expectedChallenge}
 
 	invalidChallenge := hashToChallenge(rand.Uint64(), rand.Uint64())
-	initiatorAct3 := &InitiatorAct3{invalidChallenge}
+	initiatorAct3 := &InitiatorAct3{challenge: invalidChallenge}
 
 	//
 	// Act 3
@@ -214,7 +247,7 @@ func TestFullHandshake(t *testing.T) {
 	//
 
 	// initiator station
-	initiatorAct1, err := InitiateHandshake(protocol)
+	initiatorAct1, err := InitiateHandshake(protocol, version)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -222,7 +255,7 @@ func TestFullHandshake(t *testing.T) {
 	initiatorAct2 := initiatorAct1.Next()
 
 	// responder station
-	responderAct2, err := AnswerHandshake(act1Message, protocol)
+	responderAct2, err := AnswerHandshake(act1Message, protocol, version)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -253,4 +286,192 @@ func TestFullHandshake(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+
+	if !reflect.DeepEqual(version, initiatorAct3.RemoteVersion()) {
+		t.Errorf(
+			"unexpected responder's version\nexpected: [%v]\nactual:   [%v]",
+			version,
+			initiatorAct3.RemoteVersion(),
+		)
+	}
+	if !reflect.DeepEqual(version, responderAct3.RemoteVersion()) {
+		t.Errorf(
+			"unexpected initiator's version\nexpected: [%v]\nactual:   [%v]",
+			version,
+			responderAct3.RemoteVersion(),
+		)
+	}
+}
+
+func TestFullHandshakeCapabilitiesNegotiation(t *testing.T) {
+	initiatorVersion := Version{
+		Protocol:     2,
+		MinProtocol:  1,
+		Capabilities: []string{"signature/1", "compression/1", "broadcast-message/1"},
+	}
+	responderVersion := Version{
+		Protocol:     1,
+		MinProtocol:  0,
+		Capabilities: []string{"broadcast-message/1", "compression/1", "relay/1"},
+	}
+
+	initiatorAct1, err := InitiateHandshake(protocol, initiatorVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	responderAct2, err := AnswerHandshake(
+		initiatorAct1.Message(),
+		protocol,
+		responderVersion,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	initiatorAct3, err := initiatorAct1.Next().Next(responderAct2.Message())
+	if err != nil {
+		t.Fatal(err)
+	}
+	responderAct3 := responderAct2.Next()
+	err = responderAct3.FinalizeHandshake(initiatorAct3.Message())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCapabilities := []string{"broadcast-message/1", "compression/1"}
+	if !reflect.DeepEqual(expectedCapabilities, initiatorAct3.Capabilities()) {
+		t.Errorf(
+			"unexpected initiator's capabilities\nexpected: [%v]\nactual:   [%v]",
+			expectedCapabilities,
+			initiatorAct3.Capabilities(),
+		)
+	}
+	if !reflect.DeepEqual(expectedCapabilities, responderAct3.Capabilities()) {
+		t.Errorf(
+			"unexpected responder's capabilities\nexpected: [%v]\nactual:   [%v]",
+			expectedCapabilities,
+			responderAct3.Capabilities(),
+		)
+	}
+}
+
+func TestFailAct1ForIncompatibleVersion(t *testing.T) {
+	var tests = map[string]struct {
+		initiatorVersion Version
+		responderVersion Version
+		expectedErr      string
+	}{
+		"initiator runs too old version": {
+			initiatorVersion: Version{Protocol: 1, MinProtocol: 1},
+			responderVersion: Version{Protocol: 3, MinProtocol: 2},
+			expectedErr: "incompatible protocol version; peer runs " +
+				"version [1] but at least version [2] is required",
+		},
+		"initiator requires newer version": {
+			initiatorVersion: Version{Protocol: 3, MinProtocol: 3},
+			responderVersion: Version{Protocol: 2, MinProtocol: 1},
+			expectedErr: "incompatible protocol version; peer requires " +
+				"at least version [3] but version [2] is run",
+		},
+		"legacy initiator": {
+			initiatorVersion: Version{},
+			responderVersion: Version{Protocol: 2, MinProtocol: 1},
+			expectedErr: "incompatible protocol version; peer runs " +
+				"version [0] but at least version [1] is required",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			initiator, err := InitiateHandshake(protocol, test.initiatorVersion)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = AnswerHandshake(
+				initiator.Message(),
+				protocol,
+				test.responderVersion,
+			)
+			if !errors.Is(err, ErrIncompatibleProtocolVersion) {
+				t.Fatalf("unexpected error: [%v]", err)
+			}
+			if err.Error() != test.expectedErr {
+				t.Fatalf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedErr,
+					err.Error(),
+				)
+			}
+		})
+	}
+}
+
+func TestAct1ForLegacyInitiator(t *testing.T) {
+	initiator, err := InitiateHandshake(protocol, Version{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = AnswerHandshake(
+		initiator.Message(),
+		protocol,
+		Version{Protocol: 1, MinProtocol: 0},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFailAct2ForIncompatibleVersion(t *testing.T) {
+	nonce1 := rand.Uint64()
+	nonce2 := rand.Uint64()
+
+	act2Msg := &Act2Message{
+		nonce2:    nonce2,
+		challenge: hashToChallenge(nonce1, nonce2),
+		protocol2: protocol,
+		version2:  Version{Protocol: 0},
+	}
+
+	initiatorAct2 := &InitiatorAct2{
+		nonce1:    nonce1,
+		protocol1: protocol,
+		version1:  version,
+	}
+	_, err := initiatorAct2.Next(act2Msg)
+
+	expectedErr := "incompatible protocol version; peer runs " +
+		"version [0] but at least version [1] is required"
+	if err == nil || err.Error() != expectedErr {
+		t.Fatalf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+}
+
+func TestFailAct3ForUnexpectedCapabilities(t *testing.T) {
+	challenge := hashToChallenge(rand.Uint64(), rand.Uint64())
+	responderAct3 := &ResponderAct3{
+		challenge:    challenge,
+		capabilities: []string{"broadcast-message/1"},
+	}
+
+	initiatorAct3 := &InitiatorAct3{
+		challenge:    challenge,
+		capabilities: []string{"broadcast-message/1", "compression/1"},
+	}
+
+	err := responderAct3.FinalizeHandshake(initiatorAct3.Message())
+
+	expectedErr := "unexpected initiator's capabilities; expected " +
+		"[[broadcast-message/1]], got [[broadcast-message/1 compression/1]]"
+	if err == nil || err.Error() != expectedErr {
+		t.Fatalf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
 }
@@ -19,7 +19,13 @@ const (
 func (am *Act1Message) Marshal() ([]byte, error) {
 	nonceBytes := make([]byte, nonceByteLength)
 	binary.LittleEndian.PutUint64(nonceBytes, am.nonce1)
-	return proto.Marshal(&pb.Act1Message{Nonce: nonceBytes, Protocol: am.protocol1})
+	return proto.Marshal(&pb.Act1Message{
+		Nonce:              nonceBytes,
+		Protocol:           am.protocol1,
+		ProtocolVersion:    am.version1.Protocol,
+		MinProtocolVersion: am.version1.MinProtocol,
+		Capabilities:       am.version1.Capabilities,
+	})
 }
 
 // Unmarshal converts a byte array produced by Marshal to a Act1Message.
@@ -38,6 +44,12 @@ func (am *Act1Message) Unmarshal(bytes []byte) error {
 
 	am.protocol1 = pbAct1.Protocol
 
+	am.version1 = Version{
+		Protocol:     pbAct1.ProtocolVersion,
+		MinProtocol:  pbAct1.MinProtocolVersion,
+		Capabilities: pbAct1.Capabilities,
+	}
+
 	return nil
 }
 
@@ -47,9 +59,12 @@ func (am *Act2Message) Marshal() ([]byte, error) {
 	nonceBytes := make([]byte, nonceByteLength)
 	binary.LittleEndian.PutUint64(nonceBytes, am.nonce2)
 	return proto.Marshal(&pb.Act2Message{
-		Nonce:     nonceBytes,
-		Challenge: am.challenge[:],
-		Protocol:  am.protocol2,
+		Nonce:              nonceBytes,
+		Challenge:          am.challenge[:],
+		Protocol:           am.protocol2,
+		ProtocolVersion:    am.version2.Protocol,
+		MinProtocolVersion: am.version2.MinProtocol,
+		Capabilities:       am.version2.Capabilities,
 	})
 }
 
@@ -76,13 +91,22 @@ func (am *Act2Message) Unmarshal(bytes []byte) error {
 
 	am.protocol2 = pbAct2.Protocol
 
+	am.version2 = Version{
+		Protocol:     pbAct2.ProtocolVersion,
+		MinProtocol:  pbAct2.MinProtocolVersion,
+		Capabilities: pbAct2.Capabilities,
+	}
+
 	return nil
 }
 
 // Marshal converts this Act3Message to a byte array suitable for network
 // communication.
 func (am *Act3Message) Marshal() ([]byte, error) {
-	return proto.Marshal(&pb.Act3Message{Challenge: am.challenge[:]})
+	return proto.Marshal(&pb.Act3Message{
+		Challenge:    am.challenge[:],
+		Capabilities: am.capabilities,
+	})
 }
 
 // Unmarshal converts a byte array produced by Marshal to a Act3Message.
@@ -99,5 +123,7 @@ func (am *Act3Message) Unmarshal(bytes []byte) error {
 
 	copy(am.challenge[:], pbAct3.Challenge[:challengeByteLength])
 
+	am.capabilities = pbAct3.Capabilities
+
 	return nil
 }
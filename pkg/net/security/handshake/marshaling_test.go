@@ -13,6 +13,11 @@ func TestAct1MessageRoundTrip(t *testing.T) {
 	message := &Act1Message{
 		nonce1:    100,
 		protocol1: "keep-beacon",
+		version1: Version{
+			Protocol:     2,
+			MinProtocol:  1,
+			Capabilities: []string{"broadcast-message/1"},
+		},
 	}
 
 	unmarshaler := &Act1Message{}
@@ -63,6 +68,11 @@ func TestAct2MessageRoundTrip(t *testing.T) {
 		nonce2:    100,
 		challenge: challenge,
 		protocol2: "keep-ecdsa",
+		version2: Version{
+			Protocol:     2,
+			MinProtocol:  1,
+			Capabilities: []string{"broadcast-message/1", "compression/1"},
+		},
 	}
 
 	unmarshaler := &Act2Message{}
@@ -113,7 +123,8 @@ func TestAct3MessageRoundTrip(t *testing.T) {
 	}
 
 	message := &Act3Message{
-		challenge: challenge,
+		challenge:    challenge,
+		capabilities: []string{"broadcast-message/1"},
 	}
 
 	unmarshaler := &Act3Message{}
@@ -0,0 +1,92 @@
+package net
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+// messageSignatureDomain separates signatures of broadcast messages from
+// other signatures made with the operator key.
+const messageSignatureDomain = "keep-broadcast-message"
+
+// MessageSignature is the operator signature of a broadcast channel message
+// along with all the signed data. It can be presented as evidence that
+// the message was sent by the given operator, outside of the network session
+// in which the message was received, for example, in a misbehavior report.
+type MessageSignature struct {
+	// Channel is the name of the broadcast channel the message was sent to.
+	Channel string
+	// Type is the type of the message.
+	Type string
+	// Seqno is the sequence number of the message.
+	Seqno uint64
+	// Payload is the marshaled, uncompressed payload of the message.
+	Payload []byte
+	// SenderPublicKey is the uncompressed operator public key of the sender.
+	SenderPublicKey []byte
+	// Signature is the DER-encoded secp256k1 ECDSA signature over the SHA-256
+	// hash of the signed bytes.
+	Signature []byte
+}
+
+// MessageSignedBytes returns the bytes signed by the sender of a broadcast
+// channel message with the given channel name, type, sequence number, and
+// payload.
+func MessageSignedBytes(
+	channel string,
+	messageType string,
+	seqno uint64,
+	payload []byte,
+) []byte {
+	var bytes []byte
+
+	appendWithLength := func(value []byte) {
+		length := make([]byte, 4)
+		binary.BigEndian.PutUint32(length, uint32(len(value)))
+
+		bytes = append(bytes, length...)
+		bytes = append(bytes, value...)
+	}
+
+	appendWithLength([]byte(messageSignatureDomain))
+	appendWithLength([]byte(channel))
+	appendWithLength([]byte(messageType))
+
+	seqnoBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(seqnoBytes, seqno)
+	bytes = append(bytes, seqnoBytes...)
+
+	appendWithLength(payload)
+
+	return bytes
+}
+
+// SignedBytes returns the bytes signed by the sender of the message.
+func (ms *MessageSignature) SignedBytes() []byte {
+	return MessageSignedBytes(ms.Channel, ms.Type, ms.Seqno, ms.Payload)
+}
+
+// Verify checks whether the signature is a valid signature of the signed
+// bytes made with the sender's operator key.
+func (ms *MessageSignature) Verify() error {
+	publicKey, err := btcec.ParsePubKey(ms.SenderPublicKey)
+	if err != nil {
+		return fmt.Errorf("could not parse sender public key: [%v]", err)
+	}
+
+	signature, err := ecdsa.ParseDERSignature(ms.Signature)
+	if err != nil {
+		return fmt.Errorf("could not parse signature: [%v]", err)
+	}
+
+	hash := sha256.Sum256(ms.SignedBytes())
+	if !signature.Verify(hash[:], publicKey) {
+		return fmt.Errorf("invalid signature")
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package state
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/ipfs/go-log/v2"
 	"github.com/keep-network/keep-core/pkg/chain"
@@ -20,6 +21,13 @@ const receiveBuffer = 128
 // Machine is a state machine that executes states implementing the State
 // interface.
 type Machine struct {
+	// atomic counter of messages dropped by the broadcast channel during
+	// the execution
+	//
+	// Must be declared at the top of the struct!
+	// See: https://golang.org/pkg/sync/atomic/#pkg-note-BUG
+	droppedMessages uint64
+
 	logger       log.StandardLogger
 	channel      net.BroadcastChannel
 	blockCounter chain.BlockCounter
@@ -51,9 +59,28 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 		recvChan <- msg
 	}
 
+	// The channel waits for the room in the handler's buffer before dropping
+	// a message so that short stalls of the machine, like state transitions,
+	// do not lead to losing protocol messages. Messages that had to be
+	// dropped anyway are reported to the current state if it implements
+	// DropAwareState; they are recovered by retransmissions or their senders
+	// are eventually considered inactive.
+	droppedChan := make(chan struct{}, 1)
+	recvOptions := []net.RecvOption{
+		net.WithDeliveryMode(net.BlockingDelivery),
+		net.WithDropHandler(func(msg net.Message) {
+			atomic.AddUint64(&m.droppedMessages, 1)
+
+			select {
+			case droppedChan <- struct{}{}:
+			default:
+			}
+		}),
+	}
+
 	currentState := m.initialState
 	ctx, cancelCtx := context.WithCancel(context.Background())
-	m.channel.Recv(ctx, handler)
+	m.channel.Recv(ctx, handler, recvOptions...)
 
 	m.logger.Infof(
 		"[member:%v] waiting for block [%v] to start execution",
@@ -67,6 +94,7 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 	}
 
 	lastStateEndBlockHeight := startBlockHeight
+	reportedDroppedMessages := uint64(0)
 
 	blockWaiter, err := stateTransition(
 		ctx,
@@ -93,6 +121,24 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 				)
 			}
 
+		case <-droppedChan:
+			droppedMessages := atomic.LoadUint64(&m.droppedMessages)
+			dropped := droppedMessages - reportedDroppedMessages
+			reportedDroppedMessages = droppedMessages
+
+			m.logger.Warnf(
+				"[member:%v,state:%T] [%v] messages were dropped "+
+					"before reaching the state; [%v] so far",
+				currentState.MemberIndex(),
+				currentState,
+				dropped,
+				droppedMessages,
+			)
+
+			if dropAwareState, ok := currentState.(DropAwareState); ok {
+				dropAwareState.MessagesDropped(dropped)
+			}
+
 		case lastStateEndBlockHeight := <-blockWaiter:
 			cancelCtx()
 
@@ -117,7 +163,7 @@ func (m *Machine) Execute(startBlockHeight uint64) (State, uint64, error) {
 
 			currentState = nextState
 			ctx, cancelCtx = context.WithCancel(context.Background())
-			m.channel.Recv(ctx, handler)
+			m.channel.Recv(ctx, handler, recvOptions...)
 
 			blockWaiter, err = stateTransition(
 				ctx,
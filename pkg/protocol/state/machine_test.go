@@ -93,6 +93,51 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+func TestExecute_DroppedMessages(t *testing.T) {
+	localChain := local_v1.Connect(10, 5)
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := netLocal.Connect()
+	channel, err := provider.BroadcastChannelFor("dropped_messages_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initialState := &dropAwareTestState{memberIndex: group.MemberIndex(1)}
+
+	stateMachine := NewMachine(
+		&testutils.MockLogger{},
+		&droppingChannel{BroadcastChannel: channel, dropCount: 3},
+		blockCounter,
+		initialState,
+	)
+
+	if _, _, err := stateMachine.Execute(1); err != nil {
+		t.Fatal(err)
+	}
+
+	if stateMachine.droppedMessages != 3 {
+		t.Errorf(
+			"unexpected number of dropped messages\n"+
+				"expected: [%v]\nactual:   [%v]",
+			3,
+			stateMachine.droppedMessages,
+		)
+	}
+
+	if initialState.droppedMessages != 3 {
+		t.Errorf(
+			"unexpected number of messages reported to the state\n"+
+				"expected: [%v]\nactual:   [%v]",
+			3,
+			initialState.droppedMessages,
+		)
+	}
+}
+
 func addToTestLog(testState State, functionName string) {
 	currentBlock, _ := blockCounter.CurrentBlock()
 	testLog[currentBlock] = append(
@@ -210,6 +255,43 @@ func (ts testState5) Receive(msg net.Message) error {
 func (ts testState5) Next() (State, error)           { return nil, nil }
 func (ts testState5) MemberIndex() group.MemberIndex { return ts.memberIndex }
 
+type dropAwareTestState struct {
+	memberIndex     group.MemberIndex
+	droppedMessages uint64
+}
+
+func (dats *dropAwareTestState) DelayBlocks() uint64                { return 0 }
+func (dats *dropAwareTestState) ActiveBlocks() uint64               { return 2 }
+func (dats *dropAwareTestState) Initiate(ctx context.Context) error { return nil }
+func (dats *dropAwareTestState) Receive(msg net.Message) error      { return nil }
+func (dats *dropAwareTestState) Next() (State, error)               { return nil, nil }
+func (dats *dropAwareTestState) MemberIndex() group.MemberIndex     { return dats.memberIndex }
+func (dats *dropAwareTestState) MessagesDropped(count uint64)       { dats.droppedMessages += count }
+
+// droppingChannel reports the given number of dropped messages to every
+// handler installed on the channel.
+type droppingChannel struct {
+	net.BroadcastChannel
+	dropCount int
+}
+
+func (dc *droppingChannel) Recv(
+	ctx context.Context,
+	handler func(m net.Message),
+	options ...net.RecvOption,
+) {
+	recvOptions := net.NewRecvOptions(0, options...)
+	for i := 0; i < dc.dropCount; i++ {
+		recvOptions.OnDrop(&mockNetMessage{})
+	}
+
+	dc.BroadcastChannel.Recv(ctx, handler, options...)
+}
+
+type mockNetMessage struct {
+	net.Message
+}
+
 type TestMessage struct {
 	content string
 }
@@ -48,6 +48,28 @@ type State interface {
 	MemberIndex() group.MemberIndex
 }
 
+// DropAwareState is an optional interface of a State which wants to know
+// that messages were dropped by the broadcast channel before they reached the
+// state machine. Dropped messages are not lost for good as their senders
+// retransmit them periodically but the state can speed up the recovery, for
+// example, by retransmitting its own messages using RetransmitNow.
+type DropAwareState interface {
+	State
+
+	// MessagesDropped is called when messages were dropped while the state
+	// was active. The count is the number of messages dropped since the last
+	// notification.
+	MessagesDropped(count uint64)
+}
+
+// RetransmitNow retransmits messages sent over the given channel without
+// waiting for the next retransmission tick, if the channel supports it.
+func RetransmitNow(channel net.BroadcastChannel) {
+	if retransmitter, ok := channel.(net.Retransmitter); ok {
+		retransmitter.RetransmitNow()
+	}
+}
+
 // SilentStateDelayBlocks is a delay in blocks for a state that do not
 // exchange any network messages as a part of its execution.
 //
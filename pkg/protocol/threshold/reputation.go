@@ -0,0 +1,97 @@
+package threshold
+
+import (
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// ReportMembers reports the given reputation event for all connected peers
+// run by operators controlling the given members, so that protocol-level
+// misbehavior affects the reputation of those peers. Members controlled by
+// the local operator are never reported. It is a no-op if the network
+// provider does not maintain the reputation of peers.
+func ReportMembers(
+	logger log.StandardLogger,
+	netProvider net.Provider,
+	signing chain.Signing,
+	operators chain.Addresses,
+	membersIndexes []group.MemberIndex,
+	event net.ReputationEvent,
+) {
+	connectionManager := netProvider.ConnectionManager()
+
+	reputationManager, ok := connectionManager.(net.ReputationManager)
+	if !ok {
+		return
+	}
+
+	reportedOperators := membersOperators(
+		operators,
+		membersIndexes,
+		signing.Address(),
+	)
+	if len(reportedOperators) == 0 {
+		return
+	}
+
+	for _, connectedPeer := range connectionManager.ConnectedPeers() {
+		peerPublicKey, err := connectionManager.GetPeerPublicKey(connectedPeer)
+		if err != nil {
+			logger.Warnf(
+				"cannot get public key of peer [%v]: [%v]",
+				connectedPeer,
+				err,
+			)
+			continue
+		}
+
+		peerOperator, err := signing.PublicKeyToAddress(peerPublicKey)
+		if err != nil {
+			logger.Warnf(
+				"cannot get operator address of peer [%v]: [%v]",
+				connectedPeer,
+				err,
+			)
+			continue
+		}
+
+		if reportedOperators[peerOperator] {
+			logger.Infof(
+				"reporting [%v] of operator [%v] using peer [%v]",
+				event,
+				peerOperator,
+				connectedPeer,
+			)
+
+			reputationManager.ReportPeer(connectedPeer, event)
+		}
+	}
+}
+
+// membersOperators returns the set of operators controlling the given
+// members. Members controlled by the excluded operator are skipped.
+func membersOperators(
+	operators chain.Addresses,
+	membersIndexes []group.MemberIndex,
+	excludedOperator chain.Address,
+) map[chain.Address]bool {
+	membersOperators := make(map[chain.Address]bool)
+
+	for _, memberIndex := range membersIndexes {
+		if memberIndex < 1 || int(memberIndex) > len(operators) {
+			continue
+		}
+
+		operator := operators[memberIndex-1]
+		if operator == excludedOperator {
+			continue
+		}
+
+		membersOperators[operator] = true
+	}
+
+	return membersOperators
+}
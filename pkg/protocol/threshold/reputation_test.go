@@ -0,0 +1,37 @@
+package threshold
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+func TestMembersOperators(t *testing.T) {
+	operators := chain.Addresses{
+		"address-1",
+		"address-2",
+		"address-1",
+		"address-3",
+		"address-4",
+	}
+
+	actual := membersOperators(
+		operators,
+		[]group.MemberIndex{1, 2, 3, 4, 6},
+		"address-3",
+	)
+
+	expected := map[chain.Address]bool{
+		"address-1": true,
+		"address-2": true,
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf(
+			"unexpected operators\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			actual,
+		)
+	}
+}
@@ -0,0 +1,235 @@
+package threshold
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// Names of the supported exclusion strategies.
+const (
+	// RandomExclusionStrategy excludes operators using the deterministic
+	// random retry algorithm seeded with the seed of the given protocol
+	// execution.
+	RandomExclusionStrategy = "random"
+	// InactivityExclusionStrategy excludes operators observed as inactive
+	// during previous attempts as long as the quorum can be maintained.
+	// Once the quorum can no longer be maintained or the limit of
+	// inactivity-based attempts is reached, the strategy falls back to the
+	// deterministic random retry algorithm.
+	InactivityExclusionStrategy = "inactivity"
+)
+
+// inactivityExclusionAttempts is the number of first attempts during which
+// the inactivity exclusion strategy excludes operators observed as inactive.
+// Later attempts always use the deterministic random retry algorithm so that
+// members with different views on the inactive operators eventually agree
+// on the qualified set.
+const inactivityExclusionAttempts = 5
+
+// RetryPolicy determines how a retry loop schedules its attempts and which
+// operators are qualified to participate in them.
+//
+// The attempt delay and the exclusion strategy influence the attempt
+// schedule and the qualified operators set. Both must be the same for all
+// the members of the group, otherwise members will not agree on the attempt
+// parameters and the retry loop will not produce a result. That is why they
+// should be protocol constants or be derived from parameters all members
+// agree on, such as the chain configuration, and never be configured by
+// individual operators.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts. Zero means there is no
+	// limit and the loop is bounded only by the timeout.
+	MaxAttempts uint
+	// DelayBlocks is the number of blocks added between consecutive attempts.
+	DelayBlocks uint64
+	// Timeout is the timeout of the whole retry loop.
+	Timeout time.Duration
+	// ExclusionStrategy is the name of the exclusion strategy.
+	ExclusionStrategy string
+}
+
+// AttemptsExhausted returns true if the given attempt exceeds the maximum
+// number of attempts.
+func (rp *RetryPolicy) AttemptsExhausted(attempt uint) bool {
+	return rp.MaxAttempts > 0 && attempt > rp.MaxAttempts
+}
+
+// NextAttemptStartBlock returns the start block of the attempt following
+// the attempt started at the given block and lasting the given number of
+// blocks.
+//
+// In order to start attempts >1 in the right place, the retry loop needs to
+// determine how many blocks were taken by previous attempts. We assume the
+// worst case that each attempt failed at the end of the protocol. That said,
+// the previous attempt start block is incremented by the number of blocks
+// equal to the attempt duration and by the delay blocks defined by the
+// policy. The delay mitigates all corner cases where the actual attempt
+// duration was slightly longer than expected. For example, the attempt may
+// fail at the end of the protocol but the error is returned after some time
+// and more blocks than expected are mined in the meantime.
+func (rp *RetryPolicy) NextAttemptStartBlock(
+	previousAttemptStartBlock uint64,
+	attemptBlocks uint64,
+) uint64 {
+	return previousAttemptStartBlock + attemptBlocks + rp.DelayBlocks
+}
+
+// RandomSelectionFn selects the operators qualified for the given retry
+// using the deterministic random retry algorithm.
+type RandomSelectionFn func(
+	operators []chain.Address,
+	seed int64,
+	retryCount uint,
+	participantsCount uint,
+) ([]chain.Address, error)
+
+// ExclusionStrategy decides which operators are qualified to participate in
+// the next attempt of the retry loop. Implementations must be deterministic:
+// all members evaluating the strategy with the same inputs must end up with
+// the same qualified operators set.
+type ExclusionStrategy interface {
+	// QualifiedOperators returns the operators qualified for the next
+	// attempt given the operators observed as inactive so far.
+	QualifiedOperators(
+		inactiveOperatorsSet map[chain.Address]bool,
+	) (chain.Addresses, error)
+}
+
+// NewExclusionStrategy creates the exclusion strategy with the given name
+// for a single retry loop. The initialRetryCounter is the number of runs of
+// the random retry algorithm the retry loop performed on its own before
+// the first evaluation of the strategy.
+func NewExclusionStrategy(
+	name string,
+	operators chain.Addresses,
+	seed int64,
+	quorum uint,
+	randomSelection RandomSelectionFn,
+	initialRetryCounter uint,
+) (ExclusionStrategy, error) {
+	random := &randomExclusion{
+		operators:       operators,
+		seed:            seed,
+		quorum:          quorum,
+		randomSelection: randomSelection,
+		retryCounter:    initialRetryCounter,
+	}
+
+	switch name {
+	case RandomExclusionStrategy:
+		return random, nil
+	case InactivityExclusionStrategy:
+		return &inactivityExclusion{
+			operators:           operators,
+			quorum:              quorum,
+			random:              random,
+			initialRetryCounter: initialRetryCounter,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown exclusion strategy [%s]", name)
+	}
+}
+
+// randomExclusion is the exclusion strategy using the deterministic random
+// retry algorithm.
+type randomExclusion struct {
+	operators       chain.Addresses
+	seed            int64
+	quorum          uint
+	randomSelection RandomSelectionFn
+
+	// retryCounter is the number of runs of the random retry algorithm.
+	retryCounter uint
+}
+
+func (re *randomExclusion) QualifiedOperators(
+	_ map[chain.Address]bool,
+) (chain.Addresses, error) {
+	qualifiedOperators, err := re.randomSelection(
+		re.operators,
+		re.seed,
+		re.retryCounter,
+		re.quorum,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"random operator selection failed: [%w]",
+			err,
+		)
+	}
+
+	re.retryCounter++
+	return qualifiedOperators, nil
+}
+
+// inactivityExclusion is the exclusion strategy excluding operators observed
+// as inactive before falling back to the random exclusion.
+type inactivityExclusion struct {
+	operators chain.Addresses
+	quorum    uint
+	random    *randomExclusion
+
+	initialRetryCounter uint
+
+	// attemptCounter is the number of evaluations of the strategy.
+	attemptCounter uint
+}
+
+func (ie *inactivityExclusion) QualifiedOperators(
+	inactiveOperatorsSet map[chain.Address]bool,
+) (chain.Addresses, error) {
+	ie.attemptCounter++
+
+	// If this is one of the first attempts and random retries were not started
+	// yet, check if there are known inactive operators. If the quorum can be
+	// maintained, just exclude the inactive operators from the qualified set.
+	if ie.attemptCounter <= inactivityExclusionAttempts &&
+		ie.random.retryCounter == ie.initialRetryCounter &&
+		len(inactiveOperatorsSet) > 0 {
+		qualifiedOperators := make(chain.Addresses, 0)
+		for _, operator := range ie.operators {
+			if !inactiveOperatorsSet[operator] {
+				qualifiedOperators = append(qualifiedOperators, operator)
+			}
+		}
+
+		// If this attempt pushes us below the quorum we are falling back to
+		// the random retry algorithm that excludes specific members from the
+		// original group.
+		if uint(len(qualifiedOperators)) >= ie.quorum {
+			return qualifiedOperators, nil
+		}
+	}
+
+	return ie.random.QualifiedOperators(inactiveOperatorsSet)
+}
+
+// AttemptMembers splits the members of the group formed by the given
+// operators into members included in and excluded from the attempt. A member
+// is included if the operator controlling it is qualified for the attempt.
+// Both returned slices are sorted in ascending order.
+func AttemptMembers(
+	operators chain.Addresses,
+	qualifiedOperatorsSet map[chain.Address]bool,
+) (
+	includedMembersIndexes []group.MemberIndex,
+	excludedMembersIndexes []group.MemberIndex,
+) {
+	includedMembersIndexes = make([]group.MemberIndex, 0)
+	excludedMembersIndexes = make([]group.MemberIndex, 0)
+
+	for i, operator := range operators {
+		memberIndex := group.MemberIndex(i + 1)
+
+		if qualifiedOperatorsSet[operator] {
+			includedMembersIndexes = append(includedMembersIndexes, memberIndex)
+		} else {
+			excludedMembersIndexes = append(excludedMembersIndexes, memberIndex)
+		}
+	}
+
+	return includedMembersIndexes, excludedMembersIndexes
+}
@@ -0,0 +1,225 @@
+package threshold
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// Attempt holds parameters of a single attempt of the retry loop.
+type Attempt struct {
+	// Number is the number of the attempt, starting from 1.
+	Number uint
+	// StartBlock is the block at which the attempt starts.
+	StartBlock uint64
+	// ExcludedMembersIndexes holds members excluded from the attempt,
+	// sorted in ascending order.
+	ExcludedMembersIndexes []group.MemberIndex
+}
+
+// AttemptFn performs the given attempt of the protocol. The retry loop
+// terminates once the function returns no error.
+type AttemptFn func(attempt *Attempt) error
+
+// InactiveMembersFn returns members which should be considered inactive
+// after the given failed attempt. The attemptErr parameter is the error
+// returned by the attempt function or nil if the member was excluded from
+// the attempt and did not execute it. Operators controlling the returned
+// members are passed to the exclusion strategy when selecting operators
+// qualified for subsequent attempts so all members of the group must return
+// the same members, for example the ones agreed by the group.
+type InactiveMembersFn func(
+	attempt *Attempt,
+	attemptErr error,
+) []group.MemberIndex
+
+// RetryLoop executes attempts of the protocol by a single member of the group
+// until one of them succeeds. The attempt schedule and the members taking
+// part in each attempt are determined by the retry policy and the exclusion
+// strategy so that all members of the group agree on them without any
+// communication.
+type RetryLoop struct {
+	memberIndex group.MemberIndex
+	operators   chain.Addresses
+
+	attemptBlocks     uint64
+	maxAttemptMembers int
+	seed              int64
+
+	policy            *RetryPolicy
+	exclusionStrategy ExclusionStrategy
+
+	inactiveOperatorsSet  map[chain.Address]bool
+	qualifiedOperatorsSet map[chain.Address]bool
+
+	attemptCounter    uint
+	attemptStartBlock uint64
+}
+
+// NewRetryLoop creates the retry loop of the member with the given index in
+// the group formed by the given operators. The qualifiedOperators parameter
+// holds operators qualified for the first attempt; operators qualified for
+// subsequent attempts are selected by the given exclusion strategy.
+//
+// The first attempt starts at the given block and each subsequent attempt
+// starts after the given number of blocks of the previous attempt and the
+// delay defined by the policy. If the maxAttemptMembers parameter is greater
+// than zero and more members are qualified for an attempt, the surplus
+// members are excluded from the attempt using a shuffle seeded with the given
+// seed and the attempt number.
+func NewRetryLoop(
+	memberIndex group.MemberIndex,
+	operators chain.Addresses,
+	qualifiedOperators chain.Addresses,
+	maxAttemptMembers int,
+	seed int64,
+	initialStartBlock uint64,
+	attemptBlocks uint64,
+	policy *RetryPolicy,
+	exclusionStrategy ExclusionStrategy,
+) *RetryLoop {
+	return &RetryLoop{
+		memberIndex:           memberIndex,
+		operators:             operators,
+		attemptBlocks:         attemptBlocks,
+		maxAttemptMembers:     maxAttemptMembers,
+		seed:                  seed,
+		policy:                policy,
+		exclusionStrategy:     exclusionStrategy,
+		inactiveOperatorsSet:  make(map[chain.Address]bool),
+		qualifiedOperatorsSet: qualifiedOperators.Set(),
+		attemptCounter:        0,
+		attemptStartBlock:     initialStartBlock,
+	}
+}
+
+// Start begins the retry loop using the given attempt function. The loop
+// terminates when an attempt succeeds, the maximum number of attempts defined
+// by the policy is exceeded or the ctx parameter is done, whatever comes
+// first. The successful attempt is returned.
+//
+// After each failed attempt, including the ones the member was excluded
+// from, the given inactive members function is executed and the returned
+// members are excluded from subsequent attempts according to the exclusion
+// strategy. The inactive members function can be nil if inactive members are
+// not tracked.
+func (rl *RetryLoop) Start(
+	ctx context.Context,
+	attemptFn AttemptFn,
+	inactiveMembersFn InactiveMembersFn,
+) (*Attempt, error) {
+	for {
+		rl.attemptCounter++
+
+		// Check the loop stop signal.
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf(
+				"retry loop received stop signal on attempt [%v]",
+				rl.attemptCounter,
+			)
+		}
+
+		if rl.policy.AttemptsExhausted(rl.attemptCounter) {
+			return nil, fmt.Errorf(
+				"retry loop exhausted the maximum number of [%v] attempts",
+				rl.policy.MaxAttempts,
+			)
+		}
+
+		// Attempts >1 start after the previous attempt and the delay defined
+		// by the retry policy. We assume the worst case that each attempt
+		// failed at its very end.
+		if rl.attemptCounter > 1 {
+			rl.attemptStartBlock = rl.policy.NextAttemptStartBlock(
+				rl.attemptStartBlock,
+				rl.attemptBlocks,
+			)
+		}
+
+		attempt := &Attempt{
+			Number:                 rl.attemptCounter,
+			StartBlock:             rl.attemptStartBlock,
+			ExcludedMembersIndexes: rl.excludedMembersIndexes(),
+		}
+
+		// If the given member was not qualified for the given attempt, skip
+		// the execution and set up the next attempt properly.
+		attemptSkipped := slices.Contains(
+			attempt.ExcludedMembersIndexes,
+			rl.memberIndex,
+		)
+
+		var attemptErr error
+		if !attemptSkipped {
+			attemptErr = attemptFn(attempt)
+			if attemptErr == nil {
+				return attempt, nil
+			}
+		}
+
+		if inactiveMembersFn != nil {
+			inactiveMembersIndexes := inactiveMembersFn(attempt, attemptErr)
+			for _, memberIndex := range inactiveMembersIndexes {
+				operator := rl.operators[memberIndex-1]
+				rl.inactiveOperatorsSet[operator] = true
+			}
+		}
+
+		qualifiedOperators, err := rl.exclusionStrategy.QualifiedOperators(
+			rl.inactiveOperatorsSet,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot get qualified operators for attempt [%v]: [%w]",
+				rl.attemptCounter+1,
+				err,
+			)
+		}
+
+		rl.qualifiedOperatorsSet = qualifiedOperators.Set()
+	}
+}
+
+// excludedMembersIndexes returns members excluded from the current attempt.
+// All members controlled by operators not qualified for the attempt are
+// excluded along with the surplus of members above the maximum number of
+// attempt members.
+func (rl *RetryLoop) excludedMembersIndexes() []group.MemberIndex {
+	includedMembersIndexes, excludedMembersIndexes := AttemptMembers(
+		rl.operators,
+		rl.qualifiedOperatorsSet,
+	)
+
+	if rl.maxAttemptMembers <= 0 ||
+		len(includedMembersIndexes) <= rl.maxAttemptMembers {
+		return excludedMembersIndexes
+	}
+
+	// #nosec G404 (insecure random number source (rand))
+	// Shuffling does not require secure randomness.
+	rng := rand.New(rand.NewSource(rl.seed + int64(rl.attemptCounter)))
+	// Shuffle the included members slice to randomize the selection of
+	// additionally excluded members.
+	rng.Shuffle(len(includedMembersIndexes), func(i, j int) {
+		includedMembersIndexes[i], includedMembersIndexes[j] =
+			includedMembersIndexes[j], includedMembersIndexes[i]
+	})
+	// Get the surplus of included members and add them to the excluded
+	// members list.
+	excludedMembersIndexes = append(
+		excludedMembersIndexes,
+		includedMembersIndexes[rl.maxAttemptMembers:]...,
+	)
+	// Sort the resulting excluded members list in ascending order.
+	sort.Slice(excludedMembersIndexes, func(i, j int) bool {
+		return excludedMembersIndexes[i] < excludedMembersIndexes[j]
+	})
+
+	return excludedMembersIndexes
+}
@@ -0,0 +1,302 @@
+package threshold
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+var retryLoopOperators = chain.Addresses{
+	"address-1",
+	"address-2",
+	"address-3",
+	"address-4",
+	"address-5",
+}
+
+// excludeOperatorByRetryCount is a random selection function excluding
+// the operator determined by the retry count.
+func excludeOperatorByRetryCount(
+	operators []chain.Address,
+	seed int64,
+	retryCount uint,
+	participantsCount uint,
+) ([]chain.Address, error) {
+	qualifiedOperators := make([]chain.Address, 0)
+	for i, operator := range operators {
+		if uint(i) != retryCount%uint(len(operators)) {
+			qualifiedOperators = append(qualifiedOperators, operator)
+		}
+	}
+	return qualifiedOperators, nil
+}
+
+func newTestRetryLoop(
+	t *testing.T,
+	memberIndex group.MemberIndex,
+	qualifiedOperators chain.Addresses,
+	maxAttemptMembers int,
+	policy *RetryPolicy,
+) *RetryLoop {
+	exclusionStrategy, err := NewExclusionStrategy(
+		policy.ExclusionStrategy,
+		retryLoopOperators,
+		100,
+		3,
+		excludeOperatorByRetryCount,
+		0,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewRetryLoop(
+		memberIndex,
+		retryLoopOperators,
+		qualifiedOperators,
+		maxAttemptMembers,
+		100,
+		200,
+		10,
+		policy,
+		exclusionStrategy,
+	)
+}
+
+func TestRetryLoop(t *testing.T) {
+	policy := &RetryPolicy{
+		DelayBlocks:       5,
+		ExclusionStrategy: InactivityExclusionStrategy,
+	}
+
+	var tests = map[string]struct {
+		memberIndex        group.MemberIndex
+		qualifiedOperators chain.Addresses
+		failingAttempts    uint
+		inactiveMembers    []group.MemberIndex
+		expectedAttempts   []*Attempt
+		// expectedInactiveMembersFnCalls holds the attempt numbers and
+		// errors passed to the inactive members function.
+		expectedInactiveMembersFnCalls map[uint]error
+	}{
+		"first attempt succeeds": {
+			memberIndex:        1,
+			qualifiedOperators: retryLoopOperators,
+			failingAttempts:    0,
+			expectedAttempts: []*Attempt{
+				{
+					Number:                 1,
+					StartBlock:             200,
+					ExcludedMembersIndexes: []group.MemberIndex{},
+				},
+			},
+			expectedInactiveMembersFnCalls: map[uint]error{},
+		},
+		"inactive member excluded from the second attempt": {
+			memberIndex:        1,
+			qualifiedOperators: retryLoopOperators,
+			failingAttempts:    1,
+			inactiveMembers:    []group.MemberIndex{4},
+			expectedAttempts: []*Attempt{
+				{
+					Number:                 1,
+					StartBlock:             200,
+					ExcludedMembersIndexes: []group.MemberIndex{},
+				},
+				{
+					Number:                 2,
+					StartBlock:             215, // 200 + 10 + 5
+					ExcludedMembersIndexes: []group.MemberIndex{4},
+				},
+			},
+			expectedInactiveMembersFnCalls: map[uint]error{
+				1: fmt.Errorf("attempt [1] failed"),
+			},
+		},
+		"member not qualified for the first attempt": {
+			memberIndex: 5,
+			qualifiedOperators: chain.Addresses{
+				"address-1",
+				"address-2",
+				"address-3",
+				"address-4",
+			},
+			failingAttempts: 0,
+			inactiveMembers: []group.MemberIndex{2},
+			expectedAttempts: []*Attempt{
+				{
+					Number:                 2,
+					StartBlock:             215,
+					ExcludedMembersIndexes: []group.MemberIndex{2},
+				},
+			},
+			// The member did not execute the first attempt so no error
+			// is passed.
+			expectedInactiveMembersFnCalls: map[uint]error{
+				1: nil,
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			retryLoop := newTestRetryLoop(
+				t,
+				test.memberIndex,
+				test.qualifiedOperators,
+				0,
+				policy,
+			)
+
+			executedAttempts := make([]*Attempt, 0)
+			inactiveMembersFnCalls := make(map[uint]error)
+
+			attempt, err := retryLoop.Start(
+				context.Background(),
+				func(attempt *Attempt) error {
+					executedAttempts = append(executedAttempts, attempt)
+
+					if attempt.Number <= test.failingAttempts {
+						return fmt.Errorf("attempt [%v] failed", attempt.Number)
+					}
+
+					return nil
+				},
+				func(attempt *Attempt, attemptErr error) []group.MemberIndex {
+					inactiveMembersFnCalls[attempt.Number] = attemptErr
+					return test.inactiveMembers
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedAttempts, executedAttempts) {
+				t.Errorf(
+					"unexpected attempts\nexpected: [%+v]\nactual:   [%+v]",
+					test.expectedAttempts,
+					executedAttempts,
+				)
+			}
+
+			lastAttempt := test.expectedAttempts[len(test.expectedAttempts)-1]
+			if !reflect.DeepEqual(lastAttempt, attempt) {
+				t.Errorf(
+					"unexpected successful attempt\n"+
+						"expected: [%+v]\nactual:   [%+v]",
+					lastAttempt,
+					attempt,
+				)
+			}
+
+			if !reflect.DeepEqual(
+				test.expectedInactiveMembersFnCalls,
+				inactiveMembersFnCalls,
+			) {
+				t.Errorf(
+					"unexpected inactive members function calls\n"+
+						"expected: [%v]\nactual:   [%v]",
+					test.expectedInactiveMembersFnCalls,
+					inactiveMembersFnCalls,
+				)
+			}
+		})
+	}
+}
+
+func TestRetryLoop_MaxAttemptMembers(t *testing.T) {
+	policy := &RetryPolicy{ExclusionStrategy: RandomExclusionStrategy}
+
+	excludedMembers := func() []group.MemberIndex {
+		retryLoop := newTestRetryLoop(t, 1, retryLoopOperators, 3, policy)
+		retryLoop.attemptCounter = 1
+		return retryLoop.excludedMembersIndexes()
+	}
+
+	excludedMembersIndexes := excludedMembers()
+
+	if len(excludedMembersIndexes) != 2 {
+		t.Fatalf(
+			"unexpected number of excluded members: [%v]",
+			len(excludedMembersIndexes),
+		)
+	}
+
+	if excludedMembersIndexes[0] >= excludedMembersIndexes[1] {
+		t.Errorf(
+			"excluded members are not sorted: [%v]",
+			excludedMembersIndexes,
+		)
+	}
+
+	// All members must exclude the same surplus members.
+	if !reflect.DeepEqual(excludedMembersIndexes, excludedMembers()) {
+		t.Errorf("surplus members are not selected deterministically")
+	}
+}
+
+func TestRetryLoop_MaxAttempts(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:       3,
+		ExclusionStrategy: RandomExclusionStrategy,
+	}
+
+	retryLoop := newTestRetryLoop(t, 1, retryLoopOperators, 0, policy)
+
+	attempts := 0
+	_, err := retryLoop.Start(
+		context.Background(),
+		func(attempt *Attempt) error {
+			attempts++
+			return fmt.Errorf("invalid data")
+		},
+		nil,
+	)
+
+	expectedErr := fmt.Errorf(
+		"retry loop exhausted the maximum number of [3] attempts",
+	)
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+
+	// Member 1 is excluded from the second attempt by the random selection.
+	if attempts != 2 {
+		t.Errorf("unexpected number of executed attempts: [%v]", attempts)
+	}
+}
+
+func TestRetryLoop_StopSignal(t *testing.T) {
+	policy := &RetryPolicy{ExclusionStrategy: RandomExclusionStrategy}
+
+	retryLoop := newTestRetryLoop(t, 1, retryLoopOperators, 0, policy)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+
+	_, err := retryLoop.Start(
+		ctx,
+		func(attempt *Attempt) error {
+			t.Fatal("unexpected attempt")
+			return nil
+		},
+		nil,
+	)
+
+	expectedErr := fmt.Errorf("retry loop received stop signal on attempt [1]")
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+}
@@ -0,0 +1,229 @@
+package threshold
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+func TestRetryPolicy_AttemptsExhausted(t *testing.T) {
+	var tests = map[string]struct {
+		maxAttempts uint
+		attempt     uint
+		expected    bool
+	}{
+		"unlimited attempts": {
+			maxAttempts: 0,
+			attempt:     1000,
+			expected:    false,
+		},
+		"attempt below the limit": {
+			maxAttempts: 3,
+			attempt:     2,
+			expected:    false,
+		},
+		"attempt equal to the limit": {
+			maxAttempts: 3,
+			attempt:     3,
+			expected:    false,
+		},
+		"attempt above the limit": {
+			maxAttempts: 3,
+			attempt:     4,
+			expected:    true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			policy := &RetryPolicy{MaxAttempts: test.maxAttempts}
+			testutils.AssertBoolsEqual(
+				t,
+				"attempts exhausted",
+				test.expected,
+				policy.AttemptsExhausted(test.attempt),
+			)
+		})
+	}
+}
+
+func TestInactivityExclusion(t *testing.T) {
+	operators := chain.Addresses{
+		"address-1",
+		"address-2",
+		"address-3",
+		"address-4",
+		"address-5",
+	}
+
+	randomSelection := func(
+		operators []chain.Address,
+		seed int64,
+		retryCount uint,
+		participantsCount uint,
+	) ([]chain.Address, error) {
+		// Exclude the operator determined by the retry count.
+		qualifiedOperators := make([]chain.Address, 0)
+		for i, operator := range operators {
+			if uint(i) != retryCount%uint(len(operators)) {
+				qualifiedOperators = append(qualifiedOperators, operator)
+			}
+		}
+		return qualifiedOperators, nil
+	}
+
+	strategy, err := NewExclusionStrategy(
+		InactivityExclusionStrategy,
+		operators,
+		100,
+		3,
+		randomSelection,
+		0,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The quorum can be maintained so inactive operators are excluded.
+	qualifiedOperators, err := strategy.QualifiedOperators(
+		map[chain.Address]bool{"address-2": true},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedOperators := chain.Addresses{
+		"address-1",
+		"address-3",
+		"address-4",
+		"address-5",
+	}
+	if !reflect.DeepEqual(expectedOperators, qualifiedOperators) {
+		t.Errorf(
+			"unexpected qualified operators\nexpected: [%v]\nactual:   [%v]",
+			expectedOperators,
+			qualifiedOperators,
+		)
+	}
+
+	// The quorum cannot be maintained so the random selection is used.
+	qualifiedOperators, err = strategy.QualifiedOperators(
+		map[chain.Address]bool{
+			"address-2": true,
+			"address-3": true,
+			"address-4": true,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedOperators = chain.Addresses{
+		"address-2",
+		"address-3",
+		"address-4",
+		"address-5",
+	}
+	if !reflect.DeepEqual(expectedOperators, qualifiedOperators) {
+		t.Errorf(
+			"unexpected qualified operators\nexpected: [%v]\nactual:   [%v]",
+			expectedOperators,
+			qualifiedOperators,
+		)
+	}
+
+	// Once the random selection was used, it is used for all subsequent
+	// attempts, even if the quorum could be maintained.
+	qualifiedOperators, err = strategy.QualifiedOperators(
+		map[chain.Address]bool{"address-2": true},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedOperators = chain.Addresses{
+		"address-1",
+		"address-3",
+		"address-4",
+		"address-5",
+	}
+	if !reflect.DeepEqual(expectedOperators, qualifiedOperators) {
+		t.Errorf(
+			"unexpected qualified operators\nexpected: [%v]\nactual:   [%v]",
+			expectedOperators,
+			qualifiedOperators,
+		)
+	}
+}
+
+func TestNewExclusionStrategy_Unknown(t *testing.T) {
+	_, err := NewExclusionStrategy(
+		"unknown",
+		chain.Addresses{},
+		100,
+		3,
+		nil,
+		0,
+	)
+	expectedErr := fmt.Errorf("unknown exclusion strategy [unknown]")
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+}
+
+func TestRetryPolicy_NextAttemptStartBlock(t *testing.T) {
+	policy := &RetryPolicy{DelayBlocks: 5}
+
+	expectedStartBlock := uint64(135)
+	startBlock := policy.NextAttemptStartBlock(100, 30)
+	if expectedStartBlock != startBlock {
+		t.Errorf(
+			"unexpected start block\nexpected: [%v]\nactual:   [%v]",
+			expectedStartBlock,
+			startBlock,
+		)
+	}
+}
+
+func TestAttemptMembers(t *testing.T) {
+	operators := chain.Addresses{
+		"address-1",
+		"address-2",
+		"address-1",
+		"address-3",
+	}
+
+	includedMembersIndexes, excludedMembersIndexes := AttemptMembers(
+		operators,
+		map[chain.Address]bool{"address-1": true, "address-3": true},
+	)
+
+	expectedIncludedMembersIndexes := []group.MemberIndex{1, 3, 4}
+	if !reflect.DeepEqual(
+		expectedIncludedMembersIndexes,
+		includedMembersIndexes,
+	) {
+		t.Errorf(
+			"unexpected included members\nexpected: [%v]\nactual:   [%v]",
+			expectedIncludedMembersIndexes,
+			includedMembersIndexes,
+		)
+	}
+
+	expectedExcludedMembersIndexes := []group.MemberIndex{2}
+	if !reflect.DeepEqual(
+		expectedExcludedMembersIndexes,
+		excludedMembersIndexes,
+	) {
+		t.Errorf(
+			"unexpected excluded members\nexpected: [%v]\nactual:   [%v]",
+			expectedExcludedMembersIndexes,
+			excludedMembersIndexes,
+		)
+	}
+}
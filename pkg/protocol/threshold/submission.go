@@ -0,0 +1,42 @@
+package threshold
+
+// SubmissionQueueIndex calculates the position in the submission queue for
+// the given member. The submission queue consists of the
+// firstSubmitterMemberIndex followed by subsequent member indexes according
+// to the modulus groupSize. The submission queue position for the given
+// member can be computed as:
+// - if memberIndex >= firstSubmitterMemberIndex: memberIndex - firstSubmitterMemberIndex
+// - otherwise: memberIndex + groupSize - firstSubmitterMemberIndex
+//
+// For example, for `groupSize = 5` and `firstSubmitterMemberIndex = 2`, the
+// submission queue is [2, 3, 4, 0, 1]. We compute the submission queue
+// position for each member as:
+// - member 0: 0 + 5 - 2 = 3
+// - member 1: 1 + 5 - 2 = 4
+// - member 2:     2 - 2 = 0
+// - member 3:     3 - 2 = 1
+// - member 4:     4 - 2 = 2
+func SubmissionQueueIndex(
+	memberIndex uint64,
+	firstSubmitterMemberIndex uint64,
+	groupSize uint64,
+) uint64 {
+	if memberIndex >= firstSubmitterMemberIndex {
+		return memberIndex - firstSubmitterMemberIndex
+	}
+
+	return memberIndex + groupSize - firstSubmitterMemberIndex
+}
+
+// SubmissionEligibilityBlock calculates the block at which the member at
+// the given position in the submission queue becomes eligible to submit
+// the result. The member at the first position (index `0`) is eligible
+// straight away, the member at the second position (index `1`) waits
+// `1 * blockStep` blocks, and so on.
+func SubmissionEligibilityBlock(
+	startBlock uint64,
+	submissionQueueIndex uint64,
+	blockStep uint64,
+) uint64 {
+	return startBlock + submissionQueueIndex*blockStep
+}
@@ -1,8 +1,8 @@
-package entry
+package threshold
 
 import "testing"
 
-func TestCalculateSubmissionQueueIndex(t *testing.T) {
+func TestSubmissionQueueIndex(t *testing.T) {
 	groupSize := uint64(64)
 
 	var tests = map[string]struct {
@@ -29,7 +29,7 @@ func TestCalculateSubmissionQueueIndex(t *testing.T) {
 
 	for testName, test := range tests {
 		t.Run(testName, func(t *testing.T) {
-			actualSubmissionQueueIndex := calculateSubmissionQueueIndex(
+			actualSubmissionQueueIndex := SubmissionQueueIndex(
 				test.memberIndex,
 				test.firstSubmitterMemberIndex,
 				groupSize,
@@ -45,3 +45,37 @@ func TestCalculateSubmissionQueueIndex(t *testing.T) {
 		})
 	}
 }
+
+func TestSubmissionEligibilityBlock(t *testing.T) {
+	var tests = map[string]struct {
+		submissionQueueIndex uint64
+		expectedBlock        uint64
+	}{
+		"first position in the queue": {
+			submissionQueueIndex: 0,
+			expectedBlock:        100,
+		},
+		"third position in the queue": {
+			submissionQueueIndex: 2,
+			expectedBlock:        106,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actualBlock := SubmissionEligibilityBlock(
+				100,
+				test.submissionQueueIndex,
+				3,
+			)
+
+			if test.expectedBlock != actualBlock {
+				t.Errorf(
+					"unexpected block\nexpected: %v\nactual:   %v\n",
+					test.expectedBlock,
+					actualBlock,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Package threshold contains the building blocks shared by threshold signing
+// protocols executed by a group of operators, such as the relay entry
+// signing of the random beacon or the tECDSA key generation and signing.
+//
+// The package does not implement any cryptographic scheme on its own. It
+// determines how members of a group agree on the broadcast channel and the
+// session of the given protocol execution, how they retry failed attempts,
+// and in which order they submit the protocol result to the chain. A new
+// threshold scheme is expected to reuse these building blocks instead of
+// re-implementing them.
+package threshold
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// ChannelName returns the name of the broadcast channel used by the group
+// executing the given protocol. The suffix identifies the protocol
+// execution, e.g. it can be the group selection seed or the group public
+// key, and must be the same for all members of the group.
+func ChannelName(protocolName string, suffix string) string {
+	return fmt.Sprintf("%s-%s", protocolName, suffix)
+}
+
+// SessionID returns the identifier of the given attempt of the protocol
+// execution identified by the given value, e.g. the group selection seed or
+// the signed message. Session identifiers are different for each attempt so
+// messages from previous attempts are not accepted in subsequent ones.
+func SessionID(value *big.Int, attempt uint) string {
+	return fmt.Sprintf("%v-%v", value.Text(16), attempt)
+}
+
+// MessageQuotaScope returns the scope of quotas of messages sent by the given
+// member within the given session. Since the session is unique for every
+// protocol attempt, a member can send at most one message of every type in
+// a single attempt.
+func MessageQuotaScope(sessionID string, senderID group.MemberIndex) string {
+	return fmt.Sprintf("%v/%v", sessionID, senderID)
+}
+
+// RetrySeed computes the 8-byte seed of the deterministic random retry
+// algorithm from the value identifying the protocol execution. We take the
+// first 8 bytes of the hash of the value. This allows us to not care about
+// the length of the value and how this value is proposed.
+func RetrySeed(value *big.Int) int64 {
+	valueSha256 := sha256.Sum256(value.Bytes())
+	return int64(binary.BigEndian.Uint64(valueSha256[:8]))
+}
+
+// JoinBroadcastChannel returns the broadcast channel with the given name
+// along with the membership validator of the group formed by the given
+// operators. The channel filter is set to accept only messages sent by
+// members of the group. Failure to set the filter is logged but does not
+// prevent the channel from being returned. If the channel supports size
+// limits, messages larger than the given maximum size are rejected by the
+// channel. The maximum size should be chosen according to the largest
+// message of protocols executed over the channel.
+func JoinBroadcastChannel(
+	logger log.StandardLogger,
+	netProvider net.Provider,
+	channelName string,
+	maxMessageSize int,
+	operators chain.Addresses,
+	signing chain.Signing,
+) (net.BroadcastChannel, *group.MembershipValidator, error) {
+	broadcastChannel, err := netProvider.BroadcastChannelFor(channelName)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed to get broadcast channel: [%v]",
+			err,
+		)
+	}
+
+	if sizeLimiter, ok := broadcastChannel.(net.MessageSizeLimiter); ok {
+		sizeLimiter.SetMaxMessageSize(maxMessageSize)
+	}
+
+	membershipValidator := group.NewMembershipValidator(
+		logger,
+		operators,
+		signing,
+	)
+
+	err = broadcastChannel.SetFilter(membershipValidator.IsInGroup)
+	if err != nil {
+		logger.Errorf(
+			"could not set filter for channel [%v]: [%v]",
+			broadcastChannel.Name(),
+			err,
+		)
+	}
+
+	return broadcastChannel, membershipValidator, nil
+}
@@ -0,0 +1,96 @@
+package threshold
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestChannelName(t *testing.T) {
+	testutils.AssertStringsEqual(
+		t,
+		"channel name",
+		"protocol-1f",
+		ChannelName("protocol", big.NewInt(31).Text(16)),
+	)
+}
+
+func TestSessionID(t *testing.T) {
+	testutils.AssertStringsEqual(
+		t,
+		"session ID",
+		"1f-3",
+		SessionID(big.NewInt(31), 3),
+	)
+}
+
+func TestMessageQuotaScope(t *testing.T) {
+	testutils.AssertStringsEqual(
+		t,
+		"message quota scope",
+		"1f-3/5",
+		MessageQuotaScope(SessionID(big.NewInt(31), 3), 5),
+	)
+}
+
+func TestRetrySeed(t *testing.T) {
+	seed1 := RetrySeed(big.NewInt(100))
+	seed2 := RetrySeed(big.NewInt(100))
+	seed3 := RetrySeed(big.NewInt(101))
+
+	if seed1 != seed2 {
+		t.Errorf("retry seeds for the same value are not equal")
+	}
+	if seed1 == seed3 {
+		t.Errorf("retry seeds for different values are equal")
+	}
+}
+
+func TestJoinBroadcastChannel(t *testing.T) {
+	signing := local_v1.Connect(3, 3).Signing()
+
+	_, memberPublicKey, err := operator.GenerateKeyPair(local_v1.DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, outsiderPublicKey, err := operator.GenerateKeyPair(local_v1.DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	memberAddress, err := signing.PublicKeyToAddress(memberPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel, membershipValidator, err := JoinBroadcastChannel(
+		&testutils.MockLogger{},
+		local.ConnectWithKey(memberPublicKey),
+		"protocol-1f",
+		1024,
+		chain.Addresses{memberAddress},
+		signing,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"channel name",
+		"protocol-1f",
+		channel.Name(),
+	)
+
+	if !membershipValidator.IsInGroup(memberPublicKey) {
+		t.Errorf("group member is not in the group")
+	}
+	if membershipValidator.IsInGroup(outsiderPublicKey) {
+		t.Errorf("outsider is in the group")
+	}
+}
@@ -0,0 +1,285 @@
+package storage
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/keep-network/keep-common/pkg/encryption"
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+const (
+	// rekeyStagingDirName is the directory under the storage root where the
+	// re-encrypted copy of the storage is prepared.
+	rekeyStagingDirName = ".rekey"
+	// rekeyBackupDirName is the directory under the storage root where the
+	// original content is moved while the re-encrypted copy is swapped in.
+	rekeyBackupDirName = ".rekey-backup"
+	// rekeyCommitFileName marks the staging directory as complete. Once the
+	// marker exists, an interrupted rekey is rolled forward; otherwise it is
+	// rolled back.
+	rekeyCommitFileName = "commit"
+)
+
+// rekeyedItems are the entries of the storage root directory replaced by
+// the rekey procedure.
+var rekeyedItems = []string{keyStoreDirName, workDirName, secretFileName}
+
+// Rekey re-encrypts all the data persisted in the storage under a secret
+// derived from `newPassword`. The `oldPassword` is used to decrypt the
+// existing data. If the storage is encrypted with the legacy scheme, the
+// `oldPassword` is expected to be the password used directly as the
+// encryption secret, usually the operator's key file password.
+//
+// The storage is re-encrypted into a staging directory first and swapped
+// in only once all the data has been processed successfully. If the
+// procedure is interrupted, it is rolled back or completed on the next
+// Initialize or Rekey call. The client must not be running against the
+// storage while it is rekeyed.
+func Rekey(config Config, oldPassword string, newPassword string) error {
+	storageRootDir := filepath.Clean(config.Dir)
+
+	if err := recoverRekey(storageRootDir); err != nil {
+		return fmt.Errorf(
+			"cannot recover interrupted storage rekey: [%w]",
+			err,
+		)
+	}
+
+	oldParameters, err := readSecretParameters(storageRootDir)
+	if err != nil {
+		return err
+	}
+
+	oldSecret := oldPassword
+	if oldParameters != nil {
+		oldSecret, err = oldParameters.unlock(oldPassword)
+		if err != nil {
+			return fmt.Errorf("cannot unlock storage: [%w]", err)
+		}
+	} else {
+		logger.Warnf(
+			"storage [%s] has no key derivation parameters; "+
+				"treating it as encrypted with the legacy scheme",
+			storageRootDir,
+		)
+	}
+
+	newParameters, err := newSecretParameters(newPassword)
+	if err != nil {
+		return fmt.Errorf("cannot create storage secret: [%w]", err)
+	}
+
+	newSecret, err := newParameters.unlock(newPassword)
+	if err != nil {
+		return err
+	}
+
+	stagingDir := filepath.Join(storageRootDir, rekeyStagingDirName)
+	if err := os.Mkdir(stagingDir, 0700); err != nil {
+		return fmt.Errorf("cannot create staging directory: [%w]", err)
+	}
+
+	err = stageRekey(
+		storageRootDir,
+		stagingDir,
+		newBox(oldSecret),
+		newBox(newSecret),
+		newParameters,
+	)
+	if err != nil {
+		if removeErr := os.RemoveAll(stagingDir); removeErr != nil {
+			logger.Errorf(
+				"cannot remove staging directory [%s]: [%v]",
+				stagingDir,
+				removeErr,
+			)
+		}
+
+		return err
+	}
+
+	return commitRekey(storageRootDir)
+}
+
+// stageRekey re-encrypts all the files from the storage root directory into
+// the staging directory and marks the staging directory as complete.
+func stageRekey(
+	storageRootDir string,
+	stagingDir string,
+	oldBox encryption.Box,
+	newBox encryption.Box,
+	newParameters *secretParameters,
+) error {
+	for _, dirName := range []string{keyStoreDirName, workDirName} {
+		sourceDir := filepath.Join(storageRootDir, dirName)
+		targetDir := filepath.Join(stagingDir, dirName)
+
+		if _, err := os.Stat(sourceDir); os.IsNotExist(err) {
+			continue
+		}
+
+		err := filepath.WalkDir(
+			sourceDir,
+			func(path string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+
+				relativePath, err := filepath.Rel(sourceDir, path)
+				if err != nil {
+					return err
+				}
+				targetPath := filepath.Join(targetDir, relativePath)
+
+				if entry.IsDir() {
+					return os.MkdirAll(targetPath, os.ModePerm)
+				}
+
+				if !entry.Type().IsRegular() {
+					return nil
+				}
+
+				content, err := persistence.Read(path)
+				if err != nil {
+					return fmt.Errorf("cannot read [%s]: [%w]", path, err)
+				}
+
+				decrypted, err := oldBox.Decrypt(content)
+				if err != nil {
+					return fmt.Errorf("cannot decrypt [%s]: [%w]", path, err)
+				}
+
+				encrypted, err := newBox.Encrypt(decrypted)
+				if err != nil {
+					return fmt.Errorf("cannot encrypt [%s]: [%w]", path, err)
+				}
+
+				return persistence.Write(targetPath, encrypted)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("cannot re-encrypt [%s]: [%w]", sourceDir, err)
+		}
+	}
+
+	if err := writeSecretParameters(stagingDir, newParameters); err != nil {
+		return err
+	}
+
+	return persistence.Write(
+		filepath.Join(stagingDir, rekeyCommitFileName),
+		[]byte{},
+	)
+}
+
+// commitRekey swaps the content of a complete staging directory into the
+// storage root directory. The swap is idempotent so it can be resumed after
+// an interruption.
+func commitRekey(storageRootDir string) error {
+	stagingDir := filepath.Join(storageRootDir, rekeyStagingDirName)
+	backupDir := filepath.Join(storageRootDir, rekeyBackupDirName)
+
+	if err := persistence.EnsureDirectoryExists(
+		storageRootDir,
+		rekeyBackupDirName,
+	); err != nil {
+		return fmt.Errorf("cannot create backup directory: [%w]", err)
+	}
+
+	for _, item := range rekeyedItems {
+		stagedPath := filepath.Join(stagingDir, item)
+		if _, err := os.Stat(stagedPath); os.IsNotExist(err) {
+			// Already swapped in before the interruption.
+			continue
+		}
+
+		currentPath := filepath.Join(storageRootDir, item)
+		if _, err := os.Stat(currentPath); err == nil {
+			backupPath := filepath.Join(backupDir, item)
+			if err := os.RemoveAll(backupPath); err != nil {
+				return fmt.Errorf("cannot clean up [%s]: [%w]", backupPath, err)
+			}
+
+			if err := os.Rename(currentPath, backupPath); err != nil {
+				return fmt.Errorf("cannot back up [%s]: [%w]", currentPath, err)
+			}
+		}
+
+		if err := os.Rename(stagedPath, currentPath); err != nil {
+			return fmt.Errorf("cannot swap in [%s]: [%w]", stagedPath, err)
+		}
+	}
+
+	if err := os.RemoveAll(stagingDir); err != nil {
+		return fmt.Errorf("cannot remove staging directory: [%w]", err)
+	}
+
+	if err := os.RemoveAll(backupDir); err != nil {
+		return fmt.Errorf("cannot remove backup directory: [%w]", err)
+	}
+
+	return nil
+}
+
+// recoverRekey completes or rolls back a rekey interrupted before it
+// finished. A complete staging directory is swapped in; an incomplete one is
+// discarded leaving the original content intact.
+func recoverRekey(storageRootDir string) error {
+	stagingDir := filepath.Join(storageRootDir, rekeyStagingDirName)
+
+	if _, err := os.Stat(stagingDir); os.IsNotExist(err) {
+		return nil
+	}
+
+	commitFile := filepath.Join(stagingDir, rekeyCommitFileName)
+	if _, err := os.Stat(commitFile); os.IsNotExist(err) {
+		logger.Warnf(
+			"rolling back incomplete storage rekey in [%s]",
+			storageRootDir,
+		)
+		return os.RemoveAll(stagingDir)
+	}
+
+	logger.Warnf(
+		"completing interrupted storage rekey in [%s]",
+		storageRootDir,
+	)
+
+	return commitRekey(storageRootDir)
+}
+
+// containsFiles checks if any of the given directories contains at least
+// one regular file, at any depth.
+func containsFiles(dirs ...string) (bool, error) {
+	found := false
+
+	for _, dir := range dirs {
+		err := filepath.WalkDir(
+			dir,
+			func(path string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+
+				if entry.Type().IsRegular() {
+					found = true
+					return filepath.SkipDir
+				}
+
+				return nil
+			},
+		)
+		if err != nil {
+			return false, err
+		}
+
+		if found {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
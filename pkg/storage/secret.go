@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/keep-network/keep-common/pkg/encryption"
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+const (
+	// secretFileName is the name of the file kept in the storage root
+	// directory that holds key derivation parameters of the storage
+	// encryption secret. The file does not contain any sensitive data.
+	secretFileName = "secret.json"
+
+	// secretVersion is the current version of the key derivation parameters
+	// format.
+	secretVersion = 1
+
+	// The scrypt parameters recommended for interactive logins as of 2017.
+	// See https://pkg.go.dev/golang.org/x/crypto/scrypt#Key.
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+
+	saltLength = 32
+
+	// verifierLabel is the message authenticated with the derived key to
+	// produce the verifier stored along the key derivation parameters. The
+	// verifier lets us detect a wrong passphrase before any data is read.
+	verifierLabel = "keep-storage-secret-verifier"
+)
+
+// secretParameters holds the key derivation parameters used to turn the
+// storage passphrase into the key encrypting all the data persisted in the
+// storage.
+type secretParameters struct {
+	Version  int    `json:"version"`
+	Salt     string `json:"salt"`
+	N        int    `json:"n"`
+	R        int    `json:"r"`
+	P        int    `json:"p"`
+	Verifier string `json:"verifier"`
+}
+
+// newSecretParameters generates fresh key derivation parameters for the
+// given passphrase.
+func newSecretParameters(passphrase string) (*secretParameters, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("cannot generate salt: [%w]", err)
+	}
+
+	parameters := &secretParameters{
+		Version: secretVersion,
+		Salt:    hex.EncodeToString(salt),
+		N:       scryptN,
+		R:       scryptR,
+		P:       scryptP,
+	}
+
+	key, err := parameters.deriveKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters.Verifier = hex.EncodeToString(verifier(key))
+
+	return parameters, nil
+}
+
+// deriveKey derives the storage encryption key from the passphrase.
+func (sp *secretParameters) deriveKey(passphrase string) ([]byte, error) {
+	if sp.Version != secretVersion {
+		return nil, fmt.Errorf(
+			"unsupported storage secret version [%v]",
+			sp.Version,
+		)
+	}
+
+	salt, err := hex.DecodeString(sp.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode salt: [%w]", err)
+	}
+
+	key, err := scrypt.Key(
+		[]byte(passphrase),
+		salt,
+		sp.N,
+		sp.R,
+		sp.P,
+		persistence.KeyLength,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot derive storage key: [%w]", err)
+	}
+
+	return key, nil
+}
+
+// unlock derives the storage encryption key from the passphrase and checks
+// it against the verifier. It returns the encryption secret that should be
+// passed to the encrypted persistence.
+func (sp *secretParameters) unlock(passphrase string) (string, error) {
+	key, err := sp.deriveKey(passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	expectedVerifier, err := hex.DecodeString(sp.Verifier)
+	if err != nil {
+		return "", fmt.Errorf("cannot decode verifier: [%w]", err)
+	}
+
+	if !hmac.Equal(expectedVerifier, verifier(key)) {
+		return "", fmt.Errorf("invalid storage password")
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
+func verifier(key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(verifierLabel))
+	return mac.Sum(nil)
+}
+
+// readSecretParameters reads the key derivation parameters from the given
+// storage root directory. It returns nil without an error if the parameters
+// file does not exist.
+func readSecretParameters(rootDir string) (*secretParameters, error) {
+	content, err := persistence.Read(filepath.Join(rootDir, secretFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read storage secret file: [%w]", err)
+	}
+
+	parameters := &secretParameters{}
+	if err := json.Unmarshal(content, parameters); err != nil {
+		return nil, fmt.Errorf("cannot parse storage secret file: [%w]", err)
+	}
+
+	return parameters, nil
+}
+
+// writeSecretParameters writes the key derivation parameters to the given
+// directory.
+func writeSecretParameters(dir string, parameters *secretParameters) error {
+	content, err := json.MarshalIndent(parameters, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal storage secret file: [%w]", err)
+	}
+
+	return persistence.Write(filepath.Join(dir, secretFileName), content)
+}
+
+// newBox creates an encryption box for the given encryption secret, the same
+// way the encrypted persistence does it.
+func newBox(secret string) encryption.Box {
+	return encryption.NewBox(sha256.Sum256([]byte(secret)))
+}
@@ -5,13 +5,23 @@ import (
 	"path"
 	"path/filepath"
 
+	"github.com/ipfs/go-log"
+
 	"github.com/keep-network/keep-common/pkg/persistence"
 )
 
+var logger = log.Logger("keep-storage")
+
 // Config stores meta-info about keeping data on disk
 type Config struct {
 	// Path to the persistent storage directory on disk.
 	Dir string
+	// Password used to derive the key encrypting the data persisted to the
+	// storage. It is independent of the operator's key file password.
+	Password string
+	// Path to a file holding the storage password. Used when the password is
+	// not set directly.
+	PasswordFile string
 }
 
 const (
@@ -25,6 +35,10 @@ const (
 	// lead to losing rewards as a result of inactivity but is not
 	// a protocol violation.
 	workDirName = "work"
+
+	// The operators directory is placed in the key store directory and holds
+	// separate key store namespaces of additional operators run by the client.
+	operatorsDirName = "operators"
 )
 
 // Storage is a disk persistent storage for the client.
@@ -35,13 +49,24 @@ type Storage struct {
 }
 
 // Initialize initializes a disk storage with `keystore` and `work` directories.
-// The provided `encryptionPassword` will be used to encrypt the work persisted
-// to the storage.
-func Initialize(config Config, encryptionPassword string) (Storage, error) {
+// The provided `password` is used to derive the key encrypting all the data
+// persisted to the storage. The password is independent of the operator's
+// key file password. Key derivation parameters are created on the first run
+// and the password is verified against them on every subsequent run.
+// A storage encrypted with the legacy scheme, where the password was used
+// directly, has to be migrated first with Rekey.
+func Initialize(config Config, password string) (Storage, error) {
 	storage := Storage{}
 
 	storageRootDir := filepath.Clean(config.Dir)
 
+	if err := recoverRekey(storageRootDir); err != nil {
+		return storage, fmt.Errorf(
+			"cannot recover interrupted storage rekey: [%w]",
+			err,
+		)
+	}
+
 	if err := persistence.EnsureDirectoryExists(
 		storageRootDir,
 		keyStoreDirName,
@@ -64,11 +89,86 @@ func Initialize(config Config, encryptionPassword string) (Storage, error) {
 	}
 	storage.workDir = filepath.Join(storageRootDir, workDirName)
 
+	parameters, err := readSecretParameters(storageRootDir)
+	if err != nil {
+		return storage, err
+	}
+
+	if parameters == nil {
+		hasData, err := containsFiles(storage.keystoreDir, storage.workDir)
+		if err != nil {
+			return storage, fmt.Errorf(
+				"cannot inspect storage content: [%w]",
+				err,
+			)
+		}
+
+		if hasData {
+			return storage, fmt.Errorf(
+				"storage [%s] is encrypted with the legacy scheme; "+
+					"run the `keystore rekey` command to migrate it",
+				storageRootDir,
+			)
+		}
+
+		parameters, err = newSecretParameters(password)
+		if err != nil {
+			return storage, fmt.Errorf(
+				"cannot create storage secret: [%w]",
+				err,
+			)
+		}
+
+		if err := writeSecretParameters(storageRootDir, parameters); err != nil {
+			return storage, err
+		}
+	}
+
+	encryptionPassword, err := parameters.unlock(password)
+	if err != nil {
+		return storage, err
+	}
+
 	storage.encryptionPassword = encryptionPassword
 
 	return storage, nil
 }
 
+// ForOperator returns the storage keeping the key store data of the given
+// operator in a separate namespace under `keystore/operators/<operator>`.
+// The work directory is shared by all the operators. It is used to run
+// several operators in a single client without mixing their key shares.
+func (s *Storage) ForOperator(operator string) (Storage, error) {
+	if err := persistence.EnsureDirectoryExists(
+		s.keystoreDir,
+		operatorsDirName,
+	); err != nil {
+		return Storage{}, fmt.Errorf(
+			"cannot create storage directory for operators: [%w]",
+			err,
+		)
+	}
+
+	operatorsDir := filepath.Join(s.keystoreDir, operatorsDirName)
+
+	if err := persistence.EnsureDirectoryExists(
+		operatorsDir,
+		operator,
+	); err != nil {
+		return Storage{}, fmt.Errorf(
+			"cannot create storage directory for operator [%s]: [%w]",
+			operator,
+			err,
+		)
+	}
+
+	return Storage{
+		keystoreDir:        filepath.Join(operatorsDir, operator),
+		workDir:            s.workDir,
+		encryptionPassword: s.encryptionPassword,
+	}, nil
+}
+
 // InitializeKeyStorePersistence initializes a disk persistence under keystore parent.
 func (s *Storage) InitializeKeyStorePersistence(dir string) (
 	persistence.ProtectedHandle,
@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+func TestInitialize_VerifiesPassword(t *testing.T) {
+	config := Config{Dir: t.TempDir()}
+
+	if _, err := Initialize(config, "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Initialize(config, "password"); err != nil {
+		t.Fatalf("unexpected error for the correct password: [%v]", err)
+	}
+
+	_, err := Initialize(config, "wrong-password")
+	if err == nil || err.Error() != "invalid storage password" {
+		t.Fatalf("unexpected error for a wrong password: [%v]", err)
+	}
+}
+
+func TestInitialize_RejectsLegacyStorage(t *testing.T) {
+	config := Config{Dir: t.TempDir()}
+
+	saveLegacyData(t, config, "legacy-password", []byte("share"))
+
+	_, err := Initialize(config, "password")
+	if err == nil {
+		t.Fatal("expected error for a legacy storage")
+	}
+}
+
+func TestRekey(t *testing.T) {
+	config := Config{Dir: t.TempDir()}
+
+	saveLegacyData(t, config, "legacy-password", []byte("share"))
+
+	if err := Rekey(config, "legacy-password", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertData(t, config, "password", []byte("share"))
+
+	if err := Rekey(config, "password", "new-password"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertData(t, config, "new-password", []byte("share"))
+
+	if _, err := Initialize(config, "password"); err == nil {
+		t.Fatal("expected error for the old password")
+	}
+
+	for _, dir := range []string{rekeyStagingDirName, rekeyBackupDirName} {
+		if _, err := os.Stat(filepath.Join(config.Dir, dir)); !os.IsNotExist(err) {
+			t.Errorf("directory [%s] was not cleaned up", dir)
+		}
+	}
+}
+
+func TestRekey_WrongOldPassword(t *testing.T) {
+	config := Config{Dir: t.TempDir()}
+
+	saveLegacyData(t, config, "legacy-password", []byte("share"))
+
+	if err := Rekey(config, "wrong-password", "password"); err == nil {
+		t.Fatal("expected error for a wrong old password")
+	}
+
+	// The storage must be left intact.
+	if err := Rekey(config, "legacy-password", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertData(t, config, "password", []byte("share"))
+}
+
+func TestInitialize_RecoversInterruptedRekey(t *testing.T) {
+	config := Config{Dir: t.TempDir()}
+
+	storage, err := Initialize(config, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	saveData(t, &storage, []byte("share"))
+
+	oldParameters, err := readSecretParameters(config.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldSecret, err := oldParameters.unlock("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newParameters, err := newSecretParameters("new-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newSecret, err := newParameters.unlock("new-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate the rekey interrupted right after the staging directory has
+	// been completed.
+	stagingDir := filepath.Join(config.Dir, rekeyStagingDirName)
+	if err := os.Mkdir(stagingDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := stageRekey(
+		config.Dir,
+		stagingDir,
+		newBox(oldSecret),
+		newBox(newSecret),
+		newParameters,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	assertData(t, config, "new-password", []byte("share"))
+}
+
+func saveLegacyData(t *testing.T, config Config, password string, data []byte) {
+	dir := filepath.Join(config.Dir, keyStoreDirName)
+	if err := os.MkdirAll(filepath.Join(dir, "beacon"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	handle, err := persistence.NewProtectedDiskHandle(filepath.Join(dir, "beacon"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = persistence.NewEncryptedProtectedPersistence(handle, password).
+		Save(data, "group", "membership")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func saveData(t *testing.T, storage *Storage, data []byte) {
+	handle, err := storage.InitializeKeyStorePersistence("beacon")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handle.Save(data, "group", "membership"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertData(t *testing.T, config Config, password string, expected []byte) {
+	storage, err := Initialize(config, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handle, err := storage.InitializeKeyStorePersistence("beacon")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	descriptors, errs := handle.ReadAll()
+
+	count := 0
+	for descriptor := range descriptors {
+		content, err := descriptor.Content()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(expected, content) {
+			t.Errorf(
+				"unexpected content\nexpected: %s\nactual:   %s",
+				expected,
+				content,
+			)
+		}
+		count++
+	}
+
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	if count != 1 {
+		t.Errorf("unexpected number of entries\nexpected: 1\nactual:   %v", count)
+	}
+}
+
+func TestForOperator(t *testing.T) {
+	config := Config{Dir: t.TempDir()}
+
+	storage, err := Initialize(config, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operatorStorage, err := storage.ForOperator("0x6299496199d99941193Fdd2d717ef585F431eA05")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saveData(t, &operatorStorage, []byte("operator-share"))
+
+	// The data of the operator must not be visible in the main namespace.
+	handle, err := storage.InitializeKeyStorePersistence("beacon")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	descriptors, errs := handle.ReadAll()
+	for descriptor := range descriptors {
+		t.Errorf("unexpected entry [%s]", descriptor.Name())
+	}
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	if operatorStorage.workDir != storage.workDir {
+		t.Errorf("work directory should be shared")
+	}
+}
@@ -118,7 +118,8 @@ type Chain interface {
 	// Signing returns the chain's signer.
 	Signing() chain.Signing
 	// OperatorKeyPair returns the key pair of the operator assigned to this
-	// chain handle.
+	// chain handle. The private key is nil if the operator key is held by
+	// a remote signer.
 	OperatorKeyPair() (*operator.PrivateKey, *operator.PublicKey, error)
 
 	sortition.Chain
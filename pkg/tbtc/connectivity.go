@@ -0,0 +1,83 @@
+package tbtc
+
+import (
+	"context"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+)
+
+// connectGroupMembers dials peers of operators controlling members of the
+// given group the node is not connected with yet, so that all the members
+// can communicate once the protocol starts. Peers are dialed in the background
+// and the function returns immediately so that dialing, bounded by the dial
+// timeout of the network provider, never delays the protocol execution.
+// Operators whose peers could not be reached are reported in the logs. It is
+// a no-op if the network provider can not resolve chain addresses of
+// operators into peers.
+func (n *node) connectGroupMembers(
+	ctx context.Context,
+	logger log.StandardLogger,
+	operators chain.Addresses,
+) {
+	connectionManager := n.netProvider.ConnectionManager()
+
+	operatorPeerResolver, ok := connectionManager.(net.OperatorPeerResolver)
+	if !ok {
+		return
+	}
+
+	groupOperators := otherOperators(operators, n.chain.Signing().Address())
+	if len(groupOperators) == 0 {
+		return
+	}
+
+	go func() {
+		unreachableOperators, err := operatorPeerResolver.ConnectOperators(
+			ctx,
+			groupOperators,
+		)
+		if err != nil {
+			logger.Warnf("cannot connect group members: [%v]", err)
+			return
+		}
+
+		if len(unreachableOperators) > 0 {
+			logger.Warnf(
+				"[%v] out of [%v] group members' operators are unreachable: [%v]",
+				len(unreachableOperators),
+				len(groupOperators),
+				unreachableOperators,
+			)
+			return
+		}
+
+		logger.Infof(
+			"connected peers of all [%v] group members' operators",
+			len(groupOperators),
+		)
+	}()
+}
+
+// otherOperators returns unique chain addresses of the given operators, in
+// the order of their first occurrence. The excluded operator is skipped.
+func otherOperators(
+	operators chain.Addresses,
+	excludedOperator chain.Address,
+) []string {
+	seen := make(map[chain.Address]bool)
+	otherOperators := make([]string, 0)
+
+	for _, operator := range operators {
+		if operator == excludedOperator || seen[operator] {
+			continue
+		}
+
+		seen[operator] = true
+		otherOperators = append(otherOperators, operator.String())
+	}
+
+	return otherOperators
+}
@@ -0,0 +1,30 @@
+package tbtc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+func TestOtherOperators(t *testing.T) {
+	operators := chain.Addresses{
+		"0xAA",
+		"0xBB",
+		"0xAA",
+		"0xCC",
+		"0xDD",
+		"0xCC",
+	}
+
+	actual := otherOperators(operators, "0xCC")
+
+	expected := []string{"0xAA", "0xBB", "0xDD"}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf(
+			"unexpected operators\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			actual,
+		)
+	}
+}
@@ -3,8 +3,6 @@ package tbtc
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
-	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
@@ -13,29 +11,14 @@ import (
 	"github.com/ipfs/go-log/v2"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/threshold"
 	"github.com/keep-network/keep-core/pkg/tecdsa/dkg"
 	"github.com/keep-network/keep-core/pkg/tecdsa/retry"
 )
 
 // dkgRetryLoop is a struct that encapsulates the DKG retry logic.
 type dkgRetryLoop struct {
-	memberIndex          group.MemberIndex
-	selectedOperators    chain.Addresses
-	inactiveOperatorsSet map[chain.Address]bool
-
-	chainConfig *ChainConfig
-
-	attemptCounter    uint
-	attemptStartBlock uint64
-
-	// We use a separate counter for the random retry algorithm because we
-	// try to exclude inactive members in the first attempts and then switch
-	// to the random retry mechanism. In result, an attempt is not always
-	// the same as one run of the random retry algorithm.
-	randomRetryCounter uint
-	randomRetrySeed    int64
-
-	delayBlocks uint64
+	loop *threshold.RetryLoop
 }
 
 func newDkgRetryLoop(
@@ -44,25 +27,40 @@ func newDkgRetryLoop(
 	memberIndex group.MemberIndex,
 	selectedOperators chain.Addresses,
 	chainConfig *ChainConfig,
-) *dkgRetryLoop {
-	// Compute the 8-byte seed needed for the random retry algorithm. We take
-	// the first 8 bytes of the hash of the DKG seed. This allows us to not
-	// care in this piece of the code about the length of the seed and how this
-	// seed is proposed.
-	seedSha256 := sha256.Sum256(seed.Bytes())
-	randomRetrySeed := int64(binary.BigEndian.Uint64(seedSha256[:8]))
-
-	return &dkgRetryLoop{
-		memberIndex:          memberIndex,
-		selectedOperators:    selectedOperators,
-		inactiveOperatorsSet: make(map[chain.Address]bool),
-		chainConfig:          chainConfig,
-		attemptCounter:       0,
-		attemptStartBlock:    initialStartBlock,
-		randomRetryCounter:   0,
-		randomRetrySeed:      randomRetrySeed,
-		delayBlocks:          5,
+	policy *threshold.RetryPolicy,
+) (*dkgRetryLoop, error) {
+	attemptSeed := threshold.RetrySeed(seed)
+
+	// All selected operators are qualified for the first attempt so the
+	// random retry algorithm is not run before the strategy is evaluated.
+	exclusionStrategy, err := threshold.NewExclusionStrategy(
+		policy.ExclusionStrategy,
+		selectedOperators,
+		attemptSeed,
+		uint(chainConfig.GroupQuorum),
+		retry.EvaluateRetryParticipantsForKeyGeneration,
+		0,
+	)
+	if err != nil {
+		return nil, err
 	}
+
+	// We assume the worst case that each attempt failed at the end of the
+	// DKG protocol whose duration is determined by the dkg.ProtocolBlocks
+	// function.
+	loop := threshold.NewRetryLoop(
+		memberIndex,
+		selectedOperators,
+		selectedOperators,
+		0,
+		attemptSeed,
+		initialStartBlock,
+		dkg.ProtocolBlocks(),
+		policy,
+		exclusionStrategy,
+	)
+
+	return &dkgRetryLoop{loop: loop}, nil
 }
 
 // dkgAttemptParams represents parameters of a DKG attempt.
@@ -78,148 +76,41 @@ type dkgAttemptFn func(*dkgAttemptParams) (*dkg.Result, uint64, error)
 // start begins the DKG retry loop using the given DKG attempt function.
 // The retry loop terminates when the DKG result is produced or the ctx
 // parameter is done, whatever comes first.
+//
+// Inactive members observed by the member during a failed attempt are
+// excluded from subsequent attempts according to the exclusion strategy.
 func (drl *dkgRetryLoop) start(
 	ctx context.Context,
 	dkgAttemptFn dkgAttemptFn,
 ) (*dkg.Result, uint64, error) {
-	// All selected operators should be qualified for the first attempt.
-	qualifiedOperatorsSet := drl.selectedOperators.Set()
-
-	for {
-		drl.attemptCounter++
-
-		// Check the loop stop signal.
-		if ctx.Err() != nil {
-			return nil, 0, fmt.Errorf(
-				"dkg retry loop received stop signal on attempt [%v]",
-				drl.attemptCounter,
-			)
-		}
-
-		// In order to start attempts >1 in the right place, we need to
-		// determine how many blocks were taken by previous attempts. We assume
-		// the worst case that each attempt failed at the end of the DKG
-		// protocol.
-		//
-		// That said, we need to increment the previous attempt start
-		// block by the number of blocks equal to the protocol duration and
-		// by some additional delay blocks. We need a small fixed delay in
-		// order to mitigate all corner cases where the actual attempt duration
-		// was slightly longer than the expected duration determined by the
-		// dkg.ProtocolBlocks function.
-		//
-		// For example, the attempt may fail at
-		// the end of the protocol but the error is returned after some time
-		// and more blocks than expected are mined in the meantime.
-		if drl.attemptCounter > 1 {
-			drl.attemptStartBlock = drl.attemptStartBlock +
-				dkg.ProtocolBlocks() +
-				drl.delayBlocks
-		}
-
-		// Exclude all members controlled by the operators that were not
-		// qualified for the current attempt.
-		excludedMembersIndexes := make([]group.MemberIndex, 0)
-		attemptSkipped := false
-		for i, operator := range drl.selectedOperators {
-			if !qualifiedOperatorsSet[operator] {
-				memberIndex := group.MemberIndex(i + 1)
-				excludedMembersIndexes = append(
-					excludedMembersIndexes,
-					memberIndex,
-				)
-
-				// If the given member was not qualified for the given attempt,
-				// mark this attempt as skipped in order to skip the execution
-				// and set up the next attempt properly.
-				if memberIndex == drl.memberIndex {
-					attemptSkipped = true
-					break
-				}
-			}
-		}
-
-		var result *dkg.Result
-		var executionEndBlock uint64
-		var attemptErr error
-
-		if !attemptSkipped {
-			result, executionEndBlock, attemptErr = dkgAttemptFn(&dkgAttemptParams{
-				number:                 drl.attemptCounter,
-				startBlock:             drl.attemptStartBlock,
-				excludedMembersIndexes: excludedMembersIndexes,
-			})
-			if attemptErr != nil {
-				var imErr *dkg.InactiveMembersError
-				if errors.As(attemptErr, &imErr) {
-					for _, memberIndex := range imErr.InactiveMembersIndexes {
-						operator := drl.selectedOperators[memberIndex-1]
-						drl.inactiveOperatorsSet[operator] = true
-					}
-				}
-			}
-		}
+	var result *dkg.Result
+	var executionEndBlock uint64
 
-		if attemptSkipped || attemptErr != nil {
+	_, err := drl.loop.Start(
+		ctx,
+		func(attempt *threshold.Attempt) error {
 			var err error
-			qualifiedOperatorsSet, err = drl.qualifiedOperatorsSet()
-			if err != nil {
-				return nil, 0, fmt.Errorf(
-					"cannot get qualified operators for attempt [%v]: [%w]",
-					drl.attemptCounter+1,
-					err,
-				)
-			}
-
-			continue
-		}
-
-		return result, executionEndBlock, nil
-	}
-}
-
-// qualifiedOperatorsSet returns a set of operators qualified to participate
-// in the given DKG attempt.
-func (drl *dkgRetryLoop) qualifiedOperatorsSet() (map[chain.Address]bool, error) {
-	// If this is one of the first attempts and random retries were not started
-	// yet, check if there are known inactive operators. If the group quorum
-	// can be maintained, just exclude the members controlled by the inactive
-	// operators from the qualified set.
-	if drl.attemptCounter <= 5 &&
-		drl.randomRetryCounter == 0 &&
-		len(drl.inactiveOperatorsSet) > 0 {
-		qualifiedOperators := make(chain.Addresses, 0)
-		for _, operator := range drl.selectedOperators {
-			if !drl.inactiveOperatorsSet[operator] {
-				qualifiedOperators = append(qualifiedOperators, operator)
+			result, executionEndBlock, err = dkgAttemptFn(&dkgAttemptParams{
+				number:                 attempt.Number,
+				startBlock:             attempt.StartBlock,
+				excludedMembersIndexes: attempt.ExcludedMembersIndexes,
+			})
+			return err
+		},
+		func(_ *threshold.Attempt, attemptErr error) []group.MemberIndex {
+			var imErr *dkg.InactiveMembersError
+			if errors.As(attemptErr, &imErr) {
+				return imErr.InactiveMembersIndexes
 			}
-		}
-
-		// If this attempt pushes us below the group quorum we are falling
-		// back to the random retry algorithm that excludes specific members
-		// from the original group selection result returned by the sortition
-		// pool.
-		if len(qualifiedOperators) >= drl.chainConfig.GroupQuorum {
-			return qualifiedOperators.Set(), nil
-		}
-	}
 
-	// In any other case, try to make a random retry.
-	qualifiedOperators, err := retry.EvaluateRetryParticipantsForKeyGeneration(
-		drl.selectedOperators,
-		drl.randomRetrySeed,
-		drl.randomRetryCounter,
-		uint(drl.chainConfig.GroupQuorum),
+			return nil
+		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"random operator selection failed: [%w]",
-			err,
-		)
+		return nil, 0, err
 	}
 
-	drl.randomRetryCounter++
-	return chain.Addresses(qualifiedOperators).Set(), nil
+	return result, executionEndBlock, nil
 }
 
 // decideSigningGroupMemberFate decides what the member will do in case it
@@ -549,10 +440,19 @@ func (drs *dkgResultSubmitter) setupEligibilityQueue(
 	startBlockNumber uint64,
 	memberIndex group.MemberIndex,
 ) (<-chan uint64, error) {
-	blockWaitTime := (uint64(memberIndex) - 1) *
-		drs.chain.GetConfig().ResultPublicationBlockStep
+	chainConfig := drs.chain.GetConfig()
 
-	eligibleBlockHeight := startBlockNumber + blockWaitTime
+	// Members submit in the order of their indexes, starting from the
+	// member with index 1.
+	eligibleBlockHeight := threshold.SubmissionEligibilityBlock(
+		startBlockNumber,
+		threshold.SubmissionQueueIndex(
+			uint64(memberIndex),
+			1,
+			uint64(chainConfig.GroupSize),
+		),
+		chainConfig.ResultPublicationBlockStep,
+	)
 
 	drs.dkgLogger.Infof(
 		"[member:%v] waiting for block [%v] to submit",
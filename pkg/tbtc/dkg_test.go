@@ -308,7 +308,7 @@ func TestDkgRetryLoop(t *testing.T) {
 			dkgAttemptFn: func(attempt *dkgAttemptParams) (*dkg.Result, uint64, error) {
 				return nil, 0, fmt.Errorf("invalid data")
 			},
-			expectedErr:               fmt.Errorf("dkg retry loop received stop signal on attempt [1]"),
+			expectedErr:               fmt.Errorf("retry loop received stop signal on attempt [1]"),
 			expectedResult:            nil,
 			expectedExecutionEndBlock: 0,
 			expectedLastAttempt:       nil,
@@ -317,13 +317,17 @@ func TestDkgRetryLoop(t *testing.T) {
 
 	for testName, test := range tests {
 		t.Run(testName, func(t *testing.T) {
-			retryLoop := newDkgRetryLoop(
+			retryLoop, err := newDkgRetryLoop(
 				big.NewInt(100),
 				200,
 				test.memberIndex,
 				selectedOperators,
 				chainConfig,
+				Config{}.dkgRetryPolicy(),
 			)
+			if err != nil {
+				t.Fatal(err)
+			}
 
 			ctx, cancelCtx := test.ctxFn()
 			defer cancelCtx()
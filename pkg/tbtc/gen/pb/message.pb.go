@@ -0,0 +1,366 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: pkg/tbtc/gen/pb/message.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type InactivityClaimMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SenderID               uint32   `protobuf:"varint,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	InactiveMembersIndexes []uint32 `protobuf:"varint,2,rep,packed,name=inactiveMembersIndexes,proto3" json:"inactiveMembersIndexes,omitempty"`
+	Signature              []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	PublicKey              []byte   `protobuf:"bytes,4,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	SessionID              string   `protobuf:"bytes,5,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+}
+
+func (x *InactivityClaimMessage) Reset() {
+	*x = InactivityClaimMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InactivityClaimMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InactivityClaimMessage) ProtoMessage() {}
+
+func (x *InactivityClaimMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InactivityClaimMessage.ProtoReflect.Descriptor instead.
+func (*InactivityClaimMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *InactivityClaimMessage) GetSenderID() uint32 {
+	if x != nil {
+		return x.SenderID
+	}
+	return 0
+}
+
+func (x *InactivityClaimMessage) GetInactiveMembersIndexes() []uint32 {
+	if x != nil {
+		return x.InactiveMembersIndexes
+	}
+	return nil
+}
+
+func (x *InactivityClaimMessage) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *InactivityClaimMessage) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *InactivityClaimMessage) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+type InactivityCertificateMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SenderID               uint32                                         `protobuf:"varint,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	InactiveMembersIndexes []uint32                                       `protobuf:"varint,2,rep,packed,name=inactiveMembersIndexes,proto3" json:"inactiveMembersIndexes,omitempty"`
+	Signatures             []*InactivityCertificateMessage_ClaimSignature `protobuf:"bytes,3,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	SessionID              string                                         `protobuf:"bytes,4,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+}
+
+func (x *InactivityCertificateMessage) Reset() {
+	*x = InactivityCertificateMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InactivityCertificateMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InactivityCertificateMessage) ProtoMessage() {}
+
+func (x *InactivityCertificateMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InactivityCertificateMessage.ProtoReflect.Descriptor instead.
+func (*InactivityCertificateMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *InactivityCertificateMessage) GetSenderID() uint32 {
+	if x != nil {
+		return x.SenderID
+	}
+	return 0
+}
+
+func (x *InactivityCertificateMessage) GetInactiveMembersIndexes() []uint32 {
+	if x != nil {
+		return x.InactiveMembersIndexes
+	}
+	return nil
+}
+
+func (x *InactivityCertificateMessage) GetSignatures() []*InactivityCertificateMessage_ClaimSignature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+func (x *InactivityCertificateMessage) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+type InactivityCertificateMessage_ClaimSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MemberID  uint32 `protobuf:"varint,1,opt,name=memberID,proto3" json:"memberID,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	PublicKey []byte `protobuf:"bytes,3,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+}
+
+func (x *InactivityCertificateMessage_ClaimSignature) Reset() {
+	*x = InactivityCertificateMessage_ClaimSignature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InactivityCertificateMessage_ClaimSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InactivityCertificateMessage_ClaimSignature) ProtoMessage() {}
+
+func (x *InactivityCertificateMessage_ClaimSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InactivityCertificateMessage_ClaimSignature.ProtoReflect.Descriptor instead.
+func (*InactivityCertificateMessage_ClaimSignature) Descriptor() ([]byte, []int) {
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *InactivityCertificateMessage_ClaimSignature) GetMemberID() uint32 {
+	if x != nil {
+		return x.MemberID
+	}
+	return 0
+}
+
+func (x *InactivityCertificateMessage_ClaimSignature) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *InactivityCertificateMessage_ClaimSignature) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+var File_pkg_tbtc_gen_pb_message_proto protoreflect.FileDescriptor
+
+var file_pkg_tbtc_gen_pb_message_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x70, 0x6b, 0x67, 0x2f, 0x74, 0x62, 0x74, 0x63, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70,
+	0x62, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x04, 0x74, 0x62, 0x74, 0x63, 0x22, 0xc6, 0x01, 0x0a, 0x16, 0x49, 0x6e, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x69, 0x74, 0x79, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x12, 0x36, 0x0a, 0x16,
+	0x69, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x16, 0x69, 0x6e,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0xcd,
+	0x02, 0x0a, 0x1c, 0x49, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x43, 0x65, 0x72,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x12, 0x36, 0x0a, 0x16, 0x69,
+	0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x16, 0x69, 0x6e, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x65, 0x73, 0x12, 0x51, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x74, 0x62, 0x74, 0x63, 0x2e, 0x49,
+	0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6c, 0x61, 0x69,
+	0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x44, 0x1a, 0x68, 0x0a, 0x0e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x42, 0x06,
+	0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_tbtc_gen_pb_message_proto_rawDescOnce sync.Once
+	file_pkg_tbtc_gen_pb_message_proto_rawDescData = file_pkg_tbtc_gen_pb_message_proto_rawDesc
+)
+
+func file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP() []byte {
+	file_pkg_tbtc_gen_pb_message_proto_rawDescOnce.Do(func() {
+		file_pkg_tbtc_gen_pb_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_tbtc_gen_pb_message_proto_rawDescData)
+	})
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescData
+}
+
+var file_pkg_tbtc_gen_pb_message_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pkg_tbtc_gen_pb_message_proto_goTypes = []interface{}{
+	(*InactivityClaimMessage)(nil),                      // 0: tbtc.InactivityClaimMessage
+	(*InactivityCertificateMessage)(nil),                // 1: tbtc.InactivityCertificateMessage
+	(*InactivityCertificateMessage_ClaimSignature)(nil), // 2: tbtc.InactivityCertificateMessage.ClaimSignature
+}
+var file_pkg_tbtc_gen_pb_message_proto_depIdxs = []int32{
+	2, // 0: tbtc.InactivityCertificateMessage.signatures:type_name -> tbtc.InactivityCertificateMessage.ClaimSignature
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pkg_tbtc_gen_pb_message_proto_init() }
+func file_pkg_tbtc_gen_pb_message_proto_init() {
+	if File_pkg_tbtc_gen_pb_message_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_tbtc_gen_pb_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InactivityClaimMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_tbtc_gen_pb_message_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InactivityCertificateMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_tbtc_gen_pb_message_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InactivityCertificateMessage_ClaimSignature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_tbtc_gen_pb_message_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_tbtc_gen_pb_message_proto_goTypes,
+		DependencyIndexes: file_pkg_tbtc_gen_pb_message_proto_depIdxs,
+		MessageInfos:      file_pkg_tbtc_gen_pb_message_proto_msgTypes,
+	}.Build()
+	File_pkg_tbtc_gen_pb_message_proto = out.File
+	file_pkg_tbtc_gen_pb_message_proto_rawDesc = nil
+	file_pkg_tbtc_gen_pb_message_proto_goTypes = nil
+	file_pkg_tbtc_gen_pb_message_proto_depIdxs = nil
+}
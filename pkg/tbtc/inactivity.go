@@ -0,0 +1,838 @@
+package tbtc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/state"
+)
+
+const (
+	inactivityClaimStateDelayBlocks        = 1
+	inactivityClaimStateActiveBlocks       = 3
+	inactivityEndorsementStateDelayBlocks  = 1
+	inactivityEndorsementStateActiveBlocks = 3
+	inactivityCertificateStateDelayBlocks  = 1
+	inactivityCertificateStateActiveBlocks = 3
+)
+
+// inactivityClaimBlocks returns the total number of blocks it takes to
+// agree on the inactivity claim at the end of a failed attempt.
+func inactivityClaimBlocks() uint64 {
+	return inactivityClaimStateDelayBlocks +
+		inactivityClaimStateActiveBlocks +
+		inactivityEndorsementStateDelayBlocks +
+		inactivityEndorsementStateActiveBlocks +
+		inactivityCertificateStateDelayBlocks +
+		inactivityCertificateStateActiveBlocks
+}
+
+// inactivityClaim is a statement that the given members were inactive during
+// the protocol attempt identified by the session ID.
+type inactivityClaim struct {
+	sessionID              string
+	inactiveMembersIndexes []group.MemberIndex
+}
+
+// newInactivityClaim creates a claim with the given inactive members. The
+// members indexes are sorted so that all members end up with the same claim
+// for the same set of inactive members.
+func newInactivityClaim(
+	sessionID string,
+	inactiveMembersIndexes []group.MemberIndex,
+) *inactivityClaim {
+	sorted := make([]group.MemberIndex, len(inactiveMembersIndexes))
+	copy(sorted, inactiveMembersIndexes)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	return &inactivityClaim{
+		sessionID:              sessionID,
+		inactiveMembersIndexes: sorted,
+	}
+}
+
+// hash computes the hash of the claim which is signed by the members
+// supporting the claim. The session ID is a part of the hash so that
+// the claim signatures cannot be replayed for another attempt. Each field
+// is prefixed with its length so that different claims can not have
+// the same hash input.
+func (ic *inactivityClaim) hash() [sha256.Size]byte {
+	var bytes []byte
+
+	appendWithLength := func(value []byte) {
+		length := make([]byte, 4)
+		binary.BigEndian.PutUint32(length, uint32(len(value)))
+
+		bytes = append(bytes, length...)
+		bytes = append(bytes, value...)
+	}
+
+	inactiveMembersBytes := make([]byte, len(ic.inactiveMembersIndexes))
+	for i, memberIndex := range ic.inactiveMembersIndexes {
+		inactiveMembersBytes[i] = byte(memberIndex)
+	}
+
+	appendWithLength([]byte(ic.sessionID))
+	appendWithLength(inactiveMembersBytes)
+
+	return sha256.Sum256(bytes)
+}
+
+// inactivityClaimMessage is a message payload that carries an inactivity
+// claim along with the sender's signature over the claim hash and sender's
+// public key which can be used to verify the signature.
+//
+// It is expected to be broadcast within the signing group.
+type inactivityClaimMessage struct {
+	senderID group.MemberIndex
+
+	inactiveMembersIndexes []group.MemberIndex
+	signature              []byte
+	publicKey              []byte
+	sessionID              string
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (icm *inactivityClaimMessage) SenderID() group.MemberIndex {
+	return icm.senderID
+}
+
+// Type returns a string describing an inactivityClaimMessage type for
+// marshaling purposes.
+func (icm *inactivityClaimMessage) Type() string {
+	return "tbtc/inactivity_claim_message"
+}
+
+func (icm *inactivityClaimMessage) claim() *inactivityClaim {
+	return newInactivityClaim(icm.sessionID, icm.inactiveMembersIndexes)
+}
+
+// inactivityClaimSignature is a signature of the given member over
+// the inactivity claim hash along with the member's public key which can be
+// used to verify the signature.
+type inactivityClaimSignature struct {
+	memberIndex group.MemberIndex
+	signature   []byte
+	publicKey   []byte
+}
+
+// inactivityCertificateMessage is a message payload that carries an agreed
+// inactivity claim along with signatures of at least the certificate threshold
+// of members supporting the claim. The certificate can be verified by any
+// member, regardless of the claim messages the member received.
+//
+// It is expected to be broadcast within the signing group.
+type inactivityCertificateMessage struct {
+	senderID group.MemberIndex
+
+	inactiveMembersIndexes []group.MemberIndex
+	signatures             []*inactivityClaimSignature
+	sessionID              string
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (icm *inactivityCertificateMessage) SenderID() group.MemberIndex {
+	return icm.senderID
+}
+
+// Type returns a string describing an inactivityCertificateMessage type for
+// marshaling purposes.
+func (icm *inactivityCertificateMessage) Type() string {
+	return "tbtc/inactivity_certificate_message"
+}
+
+func (icm *inactivityCertificateMessage) claim() *inactivityClaim {
+	return newInactivityClaim(icm.sessionID, icm.inactiveMembersIndexes)
+}
+
+// inactivityCertificateThreshold returns the minimum number of members
+// whose signatures make the certificate of an inactivity claim valid. Any two
+// sets of signers of that size have more than the dishonest threshold of
+// members in common. Certificates of two different claims would therefore
+// require at least one honest member to sign both claims. Honest members sign
+// only one claim per attempt, so at most one claim can be certified as long
+// as no more than the dishonest threshold of members misbehave.
+func inactivityCertificateThreshold(groupSize int, honestThreshold int) int {
+	dishonestThreshold := groupSize - honestThreshold
+	return (groupSize+dishonestThreshold)/2 + 1
+}
+
+// inactivityClaimMember represents a member agreeing on the inactivity claim
+// at the end of a failed attempt. The member collects signatures over claims
+// from other members and considers a claim agreed once it is supported by at
+// least the certificate threshold of members, see
+// inactivityCertificateThreshold. Members which agreed on a claim broadcast
+// its certificate and all members adopt the only verified certificate so that
+// they exclude the same members from the next attempt.
+//
+// Members which participated in the attempt and observed inactive members
+// sign their own claim. Members which have no evidence of their own, for
+// example because they were excluded from the attempt, endorse a claim
+// supported by all attempt participants not marked as inactive by that claim.
+type inactivityClaimMember struct {
+	logger               log.StandardLogger
+	memberIndex          group.MemberIndex
+	certificateThreshold int
+	membershipValidator  *group.MembershipValidator
+	signing              chain.Signing
+	sessionID            string
+
+	// participants holds members taking part in the attempt.
+	participants map[group.MemberIndex]bool
+	// observedInactiveMembersIndexes holds the inactive members observed by
+	// this member during the attempt. It is nil if the member has no evidence.
+	observedInactiveMembersIndexes []group.MemberIndex
+
+	claims     map[[sha256.Size]byte]*inactivityClaim
+	supporters map[[sha256.Size]byte]map[group.MemberIndex]*inactivityClaimSignature
+	// supported holds members which already supported a claim. Each member
+	// is allowed to support only one claim.
+	supported map[group.MemberIndex]bool
+	// certificates holds claims with verified certificates.
+	certificates map[[sha256.Size]byte]*inactivityClaim
+}
+
+func newInactivityClaimMember(
+	logger log.StandardLogger,
+	memberIndex group.MemberIndex,
+	groupSize int,
+	honestThreshold int,
+	membershipValidator *group.MembershipValidator,
+	signing chain.Signing,
+	sessionID string,
+	excludedMembersIndexes []group.MemberIndex,
+	observedInactiveMembersIndexes []group.MemberIndex,
+) *inactivityClaimMember {
+	excluded := make(map[group.MemberIndex]bool)
+	for _, excludedMemberIndex := range excludedMembersIndexes {
+		excluded[excludedMemberIndex] = true
+	}
+
+	participants := make(map[group.MemberIndex]bool)
+	for i := 1; i <= groupSize; i++ {
+		memberIndex := group.MemberIndex(i)
+		if !excluded[memberIndex] {
+			participants[memberIndex] = true
+		}
+	}
+
+	return &inactivityClaimMember{
+		logger:                         logger,
+		memberIndex:                    memberIndex,
+		certificateThreshold:           inactivityCertificateThreshold(groupSize, honestThreshold),
+		membershipValidator:            membershipValidator,
+		signing:                        signing,
+		sessionID:                      sessionID,
+		participants:                   participants,
+		observedInactiveMembersIndexes: observedInactiveMembersIndexes,
+		claims:                         make(map[[sha256.Size]byte]*inactivityClaim),
+		supporters:                     make(map[[sha256.Size]byte]map[group.MemberIndex]*inactivityClaimSignature),
+		supported:                      make(map[group.MemberIndex]bool),
+		certificates:                   make(map[[sha256.Size]byte]*inactivityClaim),
+	}
+}
+
+// signClaim signs the given claim, registers the member's own support and
+// prepares the message that should be broadcast to other members.
+func (icm *inactivityClaimMember) signClaim(
+	claim *inactivityClaim,
+) (*inactivityClaimMessage, error) {
+	claimHash := claim.hash()
+
+	signature, err := icm.signing.Sign(claimHash[:])
+	if err != nil {
+		return nil, fmt.Errorf("inactivity claim signing failed: [%w]", err)
+	}
+
+	publicKey := icm.signing.PublicKey()
+
+	icm.registerSupport(icm.memberIndex, claim, signature, publicKey)
+
+	return &inactivityClaimMessage{
+		senderID:               icm.memberIndex,
+		inactiveMembersIndexes: claim.inactiveMembersIndexes,
+		signature:              signature,
+		publicKey:              publicKey,
+		sessionID:              icm.sessionID,
+	}, nil
+}
+
+// ownClaim returns the claim based on the member's own observation or nil
+// if the member has no evidence of inactivity.
+func (icm *inactivityClaimMember) ownClaim() *inactivityClaim {
+	if len(icm.observedInactiveMembersIndexes) == 0 ||
+		!icm.participants[icm.memberIndex] {
+		return nil
+	}
+
+	return newInactivityClaim(icm.sessionID, icm.observedInactiveMembersIndexes)
+}
+
+// claimToEndorse returns the claim the member should endorse or nil if
+// there is no such claim or the member already supports a claim. A claim is
+// endorsed if all attempt participants not marked as inactive by that claim
+// support it.
+func (icm *inactivityClaimMember) claimToEndorse() *inactivityClaim {
+	if icm.supported[icm.memberIndex] {
+		return nil
+	}
+
+	for _, claimHash := range icm.sortedClaimHashes() {
+		claim := icm.claims[claimHash]
+
+		inactive := make(map[group.MemberIndex]bool)
+		for _, memberIndex := range claim.inactiveMembersIndexes {
+			inactive[memberIndex] = true
+		}
+
+		unanimous := true
+		for participant := range icm.participants {
+			if inactive[participant] {
+				continue
+			}
+			if _, ok := icm.supporters[claimHash][participant]; !ok {
+				unanimous = false
+				break
+			}
+		}
+
+		if unanimous {
+			return claim
+		}
+	}
+
+	return nil
+}
+
+// verifyClaimMessage validates the claim carried by the given message and
+// registers the sender's support if the claim and the signature are valid.
+func (icm *inactivityClaimMember) verifyClaimMessage(
+	message *inactivityClaimMessage,
+) error {
+	if icm.supported[message.senderID] {
+		return fmt.Errorf(
+			"member [%v] already supports an inactivity claim",
+			message.senderID,
+		)
+	}
+
+	claim := message.claim()
+
+	if err := icm.validateClaim(
+		message.senderID,
+		message.inactiveMembersIndexes,
+	); err != nil {
+		return err
+	}
+
+	if err := icm.verifyClaimSignature(claim, &inactivityClaimSignature{
+		memberIndex: message.senderID,
+		signature:   message.signature,
+		publicKey:   message.publicKey,
+	}); err != nil {
+		return err
+	}
+
+	icm.registerSupport(
+		message.senderID,
+		claim,
+		message.signature,
+		message.publicKey,
+	)
+
+	return nil
+}
+
+// validateClaim checks whether the inactive members of the claim received
+// from the given sender are distinct attempt participants.
+func (icm *inactivityClaimMember) validateClaim(
+	senderID group.MemberIndex,
+	inactiveMembersIndexes []group.MemberIndex,
+) error {
+	if len(inactiveMembersIndexes) == 0 {
+		return fmt.Errorf(
+			"inactivity claim from member [%v] is empty",
+			senderID,
+		)
+	}
+
+	seen := make(map[group.MemberIndex]bool)
+	for _, memberIndex := range inactiveMembersIndexes {
+		if !icm.participants[memberIndex] || seen[memberIndex] {
+			return fmt.Errorf(
+				"inactivity claim from member [%v] contains invalid "+
+					"member [%v]",
+				senderID,
+				memberIndex,
+			)
+		}
+		seen[memberIndex] = true
+	}
+
+	return nil
+}
+
+// verifyClaimSignature checks whether the given signature over the claim
+// hash was produced by the member the signature is attributed to.
+func (icm *inactivityClaimMember) verifyClaimSignature(
+	claim *inactivityClaim,
+	signature *inactivityClaimSignature,
+) error {
+	// The public key used to sign the claim must belong to the operator
+	// controlling the signer's position in the group. This makes the claim
+	// verifiable independently of the network layer.
+	if !icm.membershipValidator.IsValidMembership(
+		signature.memberIndex,
+		signature.publicKey,
+	) {
+		return fmt.Errorf(
+			"inactivity claim from member [%v] signed with invalid key",
+			signature.memberIndex,
+		)
+	}
+
+	claimHash := claim.hash()
+
+	isValid, err := icm.signing.VerifyWithPublicKey(
+		claimHash[:],
+		signature.signature,
+		signature.publicKey,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"verification of inactivity claim signature from member [%v] "+
+				"failed: [%w]",
+			signature.memberIndex,
+			err,
+		)
+	}
+	if !isValid {
+		return fmt.Errorf(
+			"member [%v] provided invalid inactivity claim signature",
+			signature.memberIndex,
+		)
+	}
+
+	return nil
+}
+
+func (icm *inactivityClaimMember) registerSupport(
+	memberIndex group.MemberIndex,
+	claim *inactivityClaim,
+	signature []byte,
+	publicKey []byte,
+) {
+	claimHash := claim.hash()
+
+	if _, ok := icm.claims[claimHash]; !ok {
+		icm.claims[claimHash] = claim
+		icm.supporters[claimHash] = make(
+			map[group.MemberIndex]*inactivityClaimSignature,
+		)
+	}
+
+	icm.supporters[claimHash][memberIndex] = &inactivityClaimSignature{
+		memberIndex: memberIndex,
+		signature:   signature,
+		publicKey:   publicKey,
+	}
+	icm.supported[memberIndex] = true
+}
+
+// agreedClaim returns the claim supported by at least the certificate
+// threshold of members or nil if there is no such claim. The member accepts
+// only one signature from each member so it can see at most one agreed claim.
+// Malicious members can still sign different claims and send them to
+// different members, so members may agree on different claims. Such
+// competing claims are never adopted, see adoptedClaim.
+func (icm *inactivityClaimMember) agreedClaim() *inactivityClaim {
+	for _, claimHash := range icm.sortedClaimHashes() {
+		if len(icm.supporters[claimHash]) >= icm.certificateThreshold {
+			return icm.claims[claimHash]
+		}
+	}
+
+	return nil
+}
+
+// certificateMessage prepares the certificate of the claim agreed by
+// the member that should be broadcast to other members or returns nil if
+// the member did not agree on any claim. The member's own certificate is
+// considered verified as it is built from verified signatures.
+func (icm *inactivityClaimMember) certificateMessage() *inactivityCertificateMessage {
+	claim := icm.agreedClaim()
+	if claim == nil {
+		return nil
+	}
+
+	claimHash := claim.hash()
+	icm.certificates[claimHash] = claim
+
+	supporters := make([]group.MemberIndex, 0, len(icm.supporters[claimHash]))
+	for memberIndex := range icm.supporters[claimHash] {
+		supporters = append(supporters, memberIndex)
+	}
+	sort.Slice(supporters, func(i, j int) bool {
+		return supporters[i] < supporters[j]
+	})
+
+	signatures := make([]*inactivityClaimSignature, len(supporters))
+	for i, memberIndex := range supporters {
+		signatures[i] = icm.supporters[claimHash][memberIndex]
+	}
+
+	return &inactivityCertificateMessage{
+		senderID:               icm.memberIndex,
+		inactiveMembersIndexes: claim.inactiveMembersIndexes,
+		signatures:             signatures,
+		sessionID:              icm.sessionID,
+	}
+}
+
+// verifyCertificateMessage validates the certificate carried by the given
+// message and registers the certified claim if the certificate contains
+// valid signatures of at least the certificate threshold of distinct members.
+func (icm *inactivityClaimMember) verifyCertificateMessage(
+	message *inactivityCertificateMessage,
+) error {
+	if err := icm.validateClaim(
+		message.senderID,
+		message.inactiveMembersIndexes,
+	); err != nil {
+		return err
+	}
+
+	claim := message.claim()
+
+	signers := make(map[group.MemberIndex]bool)
+	for _, signature := range message.signatures {
+		if signers[signature.memberIndex] {
+			return fmt.Errorf(
+				"inactivity certificate from member [%v] contains "+
+					"duplicate signature of member [%v]",
+				message.senderID,
+				signature.memberIndex,
+			)
+		}
+
+		if err := icm.verifyClaimSignature(claim, signature); err != nil {
+			return fmt.Errorf(
+				"inactivity certificate from member [%v] contains "+
+					"invalid signature: [%w]",
+				message.senderID,
+				err,
+			)
+		}
+
+		signers[signature.memberIndex] = true
+	}
+
+	if len(signers) < icm.certificateThreshold {
+		return fmt.Errorf(
+			"inactivity certificate from member [%v] is signed by [%v] "+
+				"members while at least [%v] are required",
+			message.senderID,
+			len(signers),
+			icm.certificateThreshold,
+		)
+	}
+
+	icm.certificates[claim.hash()] = claim
+
+	return nil
+}
+
+// adoptedClaim returns the claim with a verified certificate or nil if
+// the member knows no such claim. Competing certificates of different claims
+// can only be produced if more than the dishonest threshold of members sign
+// several claims. In such a case, no claim is adopted and the member selects
+// the members of the next attempt without new inactive members, as does every
+// other member seeing the competing certificates.
+func (icm *inactivityClaimMember) adoptedClaim() *inactivityClaim {
+	if len(icm.certificates) > 1 {
+		icm.logger.Warnf(
+			"[member:%v] received [%v] competing inactivity certificates; "+
+				"no inactivity claim is adopted",
+			icm.memberIndex,
+			len(icm.certificates),
+		)
+		return nil
+	}
+
+	for _, claim := range icm.certificates {
+		return claim
+	}
+
+	return nil
+}
+
+// sortedClaimHashes returns hashes of the known claims in a deterministic
+// order.
+func (icm *inactivityClaimMember) sortedClaimHashes() [][sha256.Size]byte {
+	hashes := make([][sha256.Size]byte, 0, len(icm.claims))
+	for claimHash := range icm.claims {
+		hashes = append(hashes, claimHash)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return string(hashes[i][:]) < string(hashes[j][:])
+	})
+	return hashes
+}
+
+// shouldAcceptMessage indicates whether the given member should accept
+// a message from the given sender.
+func (icm *inactivityClaimMember) shouldAcceptMessage(
+	senderID group.MemberIndex,
+	senderPublicKey []byte,
+	sessionID string,
+) bool {
+	isMessageFromSelf := senderID == icm.memberIndex
+	isSenderValid := icm.membershipValidator.IsValidMembership(
+		senderID,
+		senderPublicKey,
+	)
+	isSessionValid := sessionID == icm.sessionID
+
+	return !isMessageFromSelf && isSenderValid && isSessionValid
+}
+
+// receiveClaimMessage handles the given network message if it carries
+// an inactivity claim.
+func (icm *inactivityClaimMember) receiveClaimMessage(msg net.Message) error {
+	switch message := msg.Payload().(type) {
+	case *inactivityClaimMessage:
+		if icm.shouldAcceptMessage(
+			message.senderID,
+			msg.SenderPublicKey(),
+			message.sessionID,
+		) {
+			return icm.verifyClaimMessage(message)
+		}
+	}
+
+	return nil
+}
+
+// receiveCertificateMessage handles the given network message if it carries
+// an inactivity certificate.
+func (icm *inactivityClaimMember) receiveCertificateMessage(
+	msg net.Message,
+) error {
+	switch message := msg.Payload().(type) {
+	case *inactivityCertificateMessage:
+		if icm.shouldAcceptMessage(
+			message.senderID,
+			msg.SenderPublicKey(),
+			message.sessionID,
+		) {
+			return icm.verifyCertificateMessage(message)
+		}
+	}
+
+	return nil
+}
+
+// inactivityClaimState is the state during which members which observed
+// inactive members during the attempt broadcast their signed claims.
+// `inactivityClaimMessage`s are valid in this state.
+type inactivityClaimState struct {
+	channel net.BroadcastChannel
+	member  *inactivityClaimMember
+}
+
+func (ics *inactivityClaimState) DelayBlocks() uint64 {
+	return inactivityClaimStateDelayBlocks
+}
+
+func (ics *inactivityClaimState) ActiveBlocks() uint64 {
+	return inactivityClaimStateActiveBlocks
+}
+
+func (ics *inactivityClaimState) Initiate(ctx context.Context) error {
+	claim := ics.member.ownClaim()
+	if claim == nil {
+		return nil
+	}
+
+	message, err := ics.member.signClaim(claim)
+	if err != nil {
+		return err
+	}
+
+	return ics.channel.Send(ctx, message)
+}
+
+func (ics *inactivityClaimState) Receive(msg net.Message) error {
+	return ics.member.receiveClaimMessage(msg)
+}
+
+func (ics *inactivityClaimState) Next() (state.State, error) {
+	return &inactivityEndorsementState{
+		channel: ics.channel,
+		member:  ics.member,
+	}, nil
+}
+
+func (ics *inactivityClaimState) MemberIndex() group.MemberIndex {
+	return ics.member.memberIndex
+}
+
+// inactivityEndorsementState is the state during which members without
+// evidence of their own endorse the claim supported by all active attempt
+// participants. `inactivityClaimMessage`s are valid in this state.
+type inactivityEndorsementState struct {
+	channel net.BroadcastChannel
+	member  *inactivityClaimMember
+}
+
+func (ies *inactivityEndorsementState) DelayBlocks() uint64 {
+	return inactivityEndorsementStateDelayBlocks
+}
+
+func (ies *inactivityEndorsementState) ActiveBlocks() uint64 {
+	return inactivityEndorsementStateActiveBlocks
+}
+
+func (ies *inactivityEndorsementState) Initiate(ctx context.Context) error {
+	claim := ies.member.claimToEndorse()
+	if claim == nil {
+		return nil
+	}
+
+	message, err := ies.member.signClaim(claim)
+	if err != nil {
+		return err
+	}
+
+	return ies.channel.Send(ctx, message)
+}
+
+func (ies *inactivityEndorsementState) Receive(msg net.Message) error {
+	return ies.member.receiveClaimMessage(msg)
+}
+
+func (ies *inactivityEndorsementState) Next() (state.State, error) {
+	return &inactivityCertificateState{
+		channel: ies.channel,
+		member:  ies.member,
+	}, nil
+}
+
+func (ies *inactivityEndorsementState) MemberIndex() group.MemberIndex {
+	return ies.member.memberIndex
+}
+
+// inactivityCertificateState is the state during which members which agreed
+// on a claim broadcast its certificate and all members verify certificates
+// received from others. `inactivityCertificateMessage`s are valid in this
+// state.
+type inactivityCertificateState struct {
+	channel net.BroadcastChannel
+	member  *inactivityClaimMember
+}
+
+func (ics *inactivityCertificateState) DelayBlocks() uint64 {
+	return inactivityCertificateStateDelayBlocks
+}
+
+func (ics *inactivityCertificateState) ActiveBlocks() uint64 {
+	return inactivityCertificateStateActiveBlocks
+}
+
+func (ics *inactivityCertificateState) Initiate(ctx context.Context) error {
+	message := ics.member.certificateMessage()
+	if message == nil {
+		return nil
+	}
+
+	return ics.channel.Send(ctx, message)
+}
+
+func (ics *inactivityCertificateState) Receive(msg net.Message) error {
+	return ics.member.receiveCertificateMessage(msg)
+}
+
+func (ics *inactivityCertificateState) Next() (state.State, error) {
+	// The final state.
+	return nil, nil
+}
+
+func (ics *inactivityCertificateState) MemberIndex() group.MemberIndex {
+	return ics.member.memberIndex
+}
+
+// agreeOnInactivityClaim runs the inactivity claim protocol at the end of
+// a failed attempt identified by the given session ID. The protocol starts
+// at the given block and lasts for inactivityClaimBlocks. The function returns
+// the claim adopted from the verified certificates or nil if members did not
+// agree on any claim.
+//
+// The certificate is broadcast by every member which agreed on the claim so
+// a single certificate delivered to the member is enough to adopt the claim.
+// A member which receives no certificate adopts no claim and selects
+// the members of the next attempt without new inactive members.
+//
+// The observedInactiveMembersIndexes parameter holds the inactive members
+// observed by the member during the attempt and should be nil if the member
+// has no evidence, for example because it did not participate in the attempt.
+func agreeOnInactivityClaim(
+	logger log.StandardLogger,
+	sessionID string,
+	startBlockNumber uint64,
+	memberIndex group.MemberIndex,
+	groupSize int,
+	honestThreshold int,
+	excludedMembersIndexes []group.MemberIndex,
+	observedInactiveMembersIndexes []group.MemberIndex,
+	blockCounter chain.BlockCounter,
+	channel net.BroadcastChannel,
+	membershipValidator *group.MembershipValidator,
+	signing chain.Signing,
+) (*inactivityClaim, error) {
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &inactivityClaimMessage{}
+	})
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &inactivityCertificateMessage{}
+	})
+
+	member := newInactivityClaimMember(
+		logger,
+		memberIndex,
+		groupSize,
+		honestThreshold,
+		membershipValidator,
+		signing,
+		sessionID,
+		excludedMembersIndexes,
+		observedInactiveMembersIndexes,
+	)
+
+	initialState := &inactivityClaimState{
+		channel: channel,
+		member:  member,
+	}
+
+	stateMachine := state.NewMachine(logger, channel, blockCounter, initialState)
+
+	lastState, _, err := stateMachine.Execute(startBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := lastState.(*inactivityCertificateState); !ok {
+		return nil, fmt.Errorf("execution ended on state: %T", lastState)
+	}
+
+	return member.adoptedClaim(), nil
+}
@@ -0,0 +1,619 @@
+package tbtc
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+func TestInactivityClaim_Hash(t *testing.T) {
+	claim := newInactivityClaim("1f-1", []group.MemberIndex{2})
+
+	// Without length prefixes, both claims would have the same hash input.
+	ambiguousClaim := newInactivityClaim("1f-1\x02", []group.MemberIndex{})
+	if claim.hash() == ambiguousClaim.hash() {
+		t.Errorf("different claims have the same hash")
+	}
+
+	sameClaim := newInactivityClaim("1f-1", []group.MemberIndex{2})
+	if claim.hash() != sameClaim.hash() {
+		t.Errorf("same claims have different hashes")
+	}
+}
+
+func TestInactivityCertificateThreshold(t *testing.T) {
+	var tests = map[string]struct {
+		groupSize         int
+		honestThreshold   int
+		expectedThreshold int
+	}{
+		"tbtc group": {
+			groupSize:         100,
+			honestThreshold:   51,
+			expectedThreshold: 75,
+		},
+		"small group": {
+			groupSize:         5,
+			honestThreshold:   3,
+			expectedThreshold: 4,
+		},
+		"honest threshold equal to group size": {
+			groupSize:         5,
+			honestThreshold:   5,
+			expectedThreshold: 3,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			threshold := inactivityCertificateThreshold(
+				test.groupSize,
+				test.honestThreshold,
+			)
+			if threshold != test.expectedThreshold {
+				t.Errorf(
+					"unexpected threshold\nexpected: [%v]\nactual:   [%v]",
+					test.expectedThreshold,
+					threshold,
+				)
+			}
+
+			// Two sets of signers of the threshold size must have more than
+			// the dishonest threshold of members in common.
+			dishonestThreshold := test.groupSize - test.honestThreshold
+			if 2*threshold-test.groupSize <= dishonestThreshold {
+				t.Errorf("threshold allows competing certificates")
+			}
+		})
+	}
+}
+
+func TestInactivityClaimMember_Agreement(t *testing.T) {
+	groupSize := 5
+	honestThreshold := 3
+	sessionID := "session-1"
+
+	// Members 4 and 5 are excluded from the attempt and member 3 is inactive.
+	excludedMembersIndexes := []group.MemberIndex{4, 5}
+	inactiveMembersIndexes := []group.MemberIndex{3}
+
+	members := setupInactivityClaimMembers(
+		t,
+		groupSize,
+		honestThreshold,
+		sessionID,
+		excludedMembersIndexes,
+		map[group.MemberIndex][]group.MemberIndex{
+			1: inactiveMembersIndexes,
+			2: inactiveMembersIndexes,
+		},
+	)
+
+	// Members which observed the inactive member broadcast their claims.
+	claimMessages := make([]*inactivityClaimMessage, 0)
+	for _, member := range members {
+		claim := member.ownClaim()
+		if claim == nil {
+			continue
+		}
+
+		message, err := member.signClaim(claim)
+		if err != nil {
+			t.Fatal(err)
+		}
+		claimMessages = append(claimMessages, message)
+	}
+
+	if len(claimMessages) != 2 {
+		t.Fatalf("unexpected number of claims: [%v]", len(claimMessages))
+	}
+
+	deliverInactivityClaimMessages(t, members, claimMessages)
+
+	// Two claims are not enough to reach the certificate threshold.
+	for _, member := range members {
+		if claim := member.agreedClaim(); claim != nil {
+			t.Fatalf(
+				"member [%v] unexpectedly agreed on claim [%v]",
+				member.memberIndex,
+				claim.inactiveMembersIndexes,
+			)
+		}
+	}
+
+	// Excluded members endorse the claim supported by all active
+	// participants.
+	endorsementMessages := make([]*inactivityClaimMessage, 0)
+	for _, member := range members {
+		claim := member.claimToEndorse()
+		if claim == nil {
+			continue
+		}
+
+		message, err := member.signClaim(claim)
+		if err != nil {
+			t.Fatal(err)
+		}
+		endorsementMessages = append(endorsementMessages, message)
+	}
+
+	// Members 3 (inactive), 4 and 5 have no claim of their own.
+	if len(endorsementMessages) != 3 {
+		t.Fatalf(
+			"unexpected number of endorsements: [%v]",
+			len(endorsementMessages),
+		)
+	}
+
+	deliverInactivityClaimMessages(t, members, endorsementMessages)
+
+	for _, member := range members {
+		claim := member.agreedClaim()
+		if claim == nil {
+			t.Fatalf("member [%v] did not agree on claim", member.memberIndex)
+		}
+
+		if !reflect.DeepEqual(
+			inactiveMembersIndexes,
+			claim.inactiveMembersIndexes,
+		) {
+			t.Errorf(
+				"unexpected inactive members\nexpected: [%v]\nactual:   [%v]",
+				inactiveMembersIndexes,
+				claim.inactiveMembersIndexes,
+			)
+		}
+	}
+}
+
+func TestInactivityClaimMember_VerifyClaimMessage(t *testing.T) {
+	groupSize := 5
+	honestThreshold := 3
+	sessionID := "session-1"
+
+	members := setupInactivityClaimMembers(
+		t,
+		groupSize,
+		honestThreshold,
+		sessionID,
+		[]group.MemberIndex{4, 5},
+		map[group.MemberIndex][]group.MemberIndex{
+			1: {3},
+			2: {4},
+		},
+	)
+
+	validMessage, err := members[0].signClaim(members[0].ownClaim())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Member 2 claims member 4 is inactive but member 4 did not participate.
+	nonParticipantMessage, err := members[1].signClaim(
+		newInactivityClaim(sessionID, []group.MemberIndex{4}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Member 2 claims member 3 is inactive but signs with the key
+	// of member 1.
+	wrongKeyMessage := &inactivityClaimMessage{
+		senderID:               2,
+		inactiveMembersIndexes: validMessage.inactiveMembersIndexes,
+		signature:              validMessage.signature,
+		publicKey:              validMessage.publicKey,
+		sessionID:              sessionID,
+	}
+
+	// Member 1 signs claim for another session.
+	otherSessionMessage := &inactivityClaimMessage{
+		senderID:               1,
+		inactiveMembersIndexes: validMessage.inactiveMembersIndexes,
+		signature:              validMessage.signature,
+		publicKey:              validMessage.publicKey,
+		sessionID:              "session-2",
+	}
+
+	var tests = map[string]struct {
+		member      *inactivityClaimMember
+		message     *inactivityClaimMessage
+		expectedErr error
+	}{
+		"valid claim": {
+			member:      members[2],
+			message:     validMessage,
+			expectedErr: nil,
+		},
+		"claim marking non-participant": {
+			member:  members[2],
+			message: nonParticipantMessage,
+			expectedErr: fmt.Errorf(
+				"inactivity claim from member [2] contains invalid member [4]",
+			),
+		},
+		"claim signed with invalid key": {
+			member:  members[2],
+			message: wrongKeyMessage,
+			expectedErr: fmt.Errorf(
+				"inactivity claim from member [2] signed with invalid key",
+			),
+		},
+		"claim signature for another session": {
+			member:  members[3],
+			message: otherSessionMessage,
+			expectedErr: fmt.Errorf(
+				"member [1] provided invalid inactivity claim signature",
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := test.member.verifyClaimMessage(test.message)
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedErr,
+					err,
+				)
+			}
+		})
+	}
+
+	// Each member is allowed to support only one claim.
+	expectedErr := fmt.Errorf("member [1] already supports an inactivity claim")
+	err = members[2].verifyClaimMessage(validMessage)
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+}
+
+func TestInactivityClaimMember_Certificate(t *testing.T) {
+	groupSize := 5
+	honestThreshold := 3
+	sessionID := "session-1"
+
+	// Member 5 is inactive and all other members observed it.
+	inactiveMembersIndexes := []group.MemberIndex{5}
+
+	members := setupInactivityClaimMembers(
+		t,
+		groupSize,
+		honestThreshold,
+		sessionID,
+		nil,
+		map[group.MemberIndex][]group.MemberIndex{
+			1: inactiveMembersIndexes,
+			2: inactiveMembersIndexes,
+			3: inactiveMembersIndexes,
+			4: inactiveMembersIndexes,
+		},
+	)
+
+	claimMessages := make(map[group.MemberIndex]*inactivityClaimMessage)
+	for _, member := range members {
+		claim := member.ownClaim()
+		if claim == nil {
+			continue
+		}
+
+		message, err := member.signClaim(claim)
+		if err != nil {
+			t.Fatal(err)
+		}
+		claimMessages[member.memberIndex] = message
+	}
+
+	// Only members 1 and 2 receive enough claims to agree on the claim.
+	// Other members receive no claims and do not agree on any claim.
+	deliveries := map[group.MemberIndex][]group.MemberIndex{
+		1: {2, 3, 4},
+		2: {1, 3, 4},
+	}
+	for receiver, senders := range deliveries {
+		for _, sender := range senders {
+			err := members[receiver-1].verifyClaimMessage(claimMessages[sender])
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	certificateMessages := make([]*inactivityCertificateMessage, 0)
+	for _, member := range members {
+		message := member.certificateMessage()
+		if message == nil {
+			continue
+		}
+		certificateMessages = append(certificateMessages, message)
+	}
+
+	if len(certificateMessages) != 2 {
+		t.Fatalf(
+			"unexpected number of certificates: [%v]",
+			len(certificateMessages),
+		)
+	}
+
+	deliverInactivityCertificateMessages(t, members, certificateMessages)
+
+	for _, member := range members {
+		claim := member.adoptedClaim()
+		if claim == nil {
+			t.Fatalf("member [%v] did not adopt claim", member.memberIndex)
+		}
+
+		if !reflect.DeepEqual(
+			inactiveMembersIndexes,
+			claim.inactiveMembersIndexes,
+		) {
+			t.Errorf(
+				"unexpected inactive members of member [%v]\n"+
+					"expected: [%v]\n"+
+					"actual:   [%v]",
+				member.memberIndex,
+				inactiveMembersIndexes,
+				claim.inactiveMembersIndexes,
+			)
+		}
+	}
+
+	// Members 2, 3, 4 and 5 certify a competing claim. This requires members
+	// 2, 3 and 4 to sign both claims, which exceeds the dishonest threshold.
+	// Members seeing both certificates adopt no claim.
+	competingClaim := newInactivityClaim(sessionID, []group.MemberIndex{1})
+	competingMessage := signInactivityCertificate(
+		t,
+		members[1:],
+		competingClaim,
+	)
+
+	for _, member := range members {
+		if err := member.verifyCertificateMessage(competingMessage); err != nil {
+			t.Fatal(err)
+		}
+
+		if claim := member.adoptedClaim(); claim != nil {
+			t.Errorf(
+				"member [%v] adopted claim [%v] despite competing "+
+					"certificates",
+				member.memberIndex,
+				claim.inactiveMembersIndexes,
+			)
+		}
+	}
+}
+
+func TestInactivityClaimMember_VerifyCertificateMessage(t *testing.T) {
+	groupSize := 5
+	honestThreshold := 3
+	sessionID := "session-1"
+
+	members := setupInactivityClaimMembers(
+		t,
+		groupSize,
+		honestThreshold,
+		sessionID,
+		nil,
+		nil,
+	)
+
+	claim := newInactivityClaim(sessionID, []group.MemberIndex{5})
+
+	validMessage := signInactivityCertificate(t, members[:4], claim)
+
+	// Signatures of the honest threshold of members are not enough.
+	insufficientMessage := signInactivityCertificate(t, members[:3], claim)
+
+	duplicateMessage := signInactivityCertificate(t, members[:3], claim)
+	duplicateMessage.signatures = append(
+		duplicateMessage.signatures,
+		duplicateMessage.signatures[1],
+	)
+
+	// Member 4 signs another claim.
+	invalidSignatureMessage := signInactivityCertificate(t, members[:4], claim)
+	invalidSignatureMessage.signatures[3] = signInactivityCertificate(
+		t,
+		members[3:4],
+		newInactivityClaim(sessionID, []group.MemberIndex{4}),
+	).signatures[0]
+
+	// Signature of member 4 is attributed to member 5.
+	wrongKeyMessage := signInactivityCertificate(t, members[:4], claim)
+	wrongKeyMessage.signatures[3].memberIndex = 5
+
+	var tests = map[string]struct {
+		message     *inactivityCertificateMessage
+		expectedErr error
+	}{
+		"valid certificate": {
+			message:     validMessage,
+			expectedErr: nil,
+		},
+		"insufficient number of signatures": {
+			message: insufficientMessage,
+			expectedErr: fmt.Errorf(
+				"inactivity certificate from member [1] is signed by [3] " +
+					"members while at least [4] are required",
+			),
+		},
+		"duplicate signature": {
+			message: duplicateMessage,
+			expectedErr: fmt.Errorf(
+				"inactivity certificate from member [1] contains " +
+					"duplicate signature of member [2]",
+			),
+		},
+		"signature over another claim": {
+			message: invalidSignatureMessage,
+			expectedErr: fmt.Errorf(
+				"inactivity certificate from member [1] contains "+
+					"invalid signature: [%w]",
+				fmt.Errorf(
+					"member [4] provided invalid inactivity claim signature",
+				),
+			),
+		},
+		"signature with invalid key": {
+			message: wrongKeyMessage,
+			expectedErr: fmt.Errorf(
+				"inactivity certificate from member [1] contains "+
+					"invalid signature: [%w]",
+				fmt.Errorf(
+					"inactivity claim from member [5] signed with invalid key",
+				),
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			member := members[4]
+
+			err := member.verifyCertificateMessage(test.message)
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedErr,
+					err,
+				)
+			}
+
+			isAdopted := member.adoptedClaim() != nil
+			if isAdopted != (test.expectedErr == nil) {
+				t.Errorf("unexpected adoption of the claim: [%v]", isAdopted)
+			}
+
+			member.certificates = make(map[[sha256.Size]byte]*inactivityClaim)
+		})
+	}
+}
+
+func setupInactivityClaimMembers(
+	t *testing.T,
+	groupSize int,
+	honestThreshold int,
+	sessionID string,
+	excludedMembersIndexes []group.MemberIndex,
+	observedInactiveMembersIndexes map[group.MemberIndex][]group.MemberIndex,
+) []*inactivityClaimMember {
+	signings := make([]chain.Signing, groupSize)
+	operators := make([]chain.Address, groupSize)
+	for i := range signings {
+		privateKey, _, err := operator.GenerateKeyPair(local_v1.DefaultCurve)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signing := local_v1.ConnectWithKey(
+			groupSize,
+			honestThreshold,
+			privateKey,
+		).Signing()
+
+		signings[i] = signing
+		operators[i] = signing.Address()
+	}
+
+	logger := log.Logger("keep-tbtc-test")
+
+	members := make([]*inactivityClaimMember, groupSize)
+	for i := range members {
+		memberIndex := group.MemberIndex(i + 1)
+
+		members[i] = newInactivityClaimMember(
+			logger,
+			memberIndex,
+			groupSize,
+			honestThreshold,
+			group.NewMembershipValidator(logger, operators, signings[i]),
+			signings[i],
+			sessionID,
+			excludedMembersIndexes,
+			observedInactiveMembersIndexes[memberIndex],
+		)
+	}
+
+	return members
+}
+
+func deliverInactivityClaimMessages(
+	t *testing.T,
+	members []*inactivityClaimMember,
+	messages []*inactivityClaimMessage,
+) {
+	for _, member := range members {
+		for _, message := range messages {
+			if message.senderID == member.memberIndex {
+				continue
+			}
+
+			if err := member.verifyClaimMessage(message); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func deliverInactivityCertificateMessages(
+	t *testing.T,
+	members []*inactivityClaimMember,
+	messages []*inactivityCertificateMessage,
+) {
+	for _, member := range members {
+		for _, message := range messages {
+			if message.senderID == member.memberIndex {
+				continue
+			}
+
+			if err := member.verifyCertificateMessage(message); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+// signInactivityCertificate prepares a certificate of the given claim signed
+// by the given members and sent by the first of them.
+func signInactivityCertificate(
+	t *testing.T,
+	signers []*inactivityClaimMember,
+	claim *inactivityClaim,
+) *inactivityCertificateMessage {
+	claimHash := claim.hash()
+
+	signatures := make([]*inactivityClaimSignature, len(signers))
+	for i, signer := range signers {
+		signature, err := signer.signing.Sign(claimHash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signatures[i] = &inactivityClaimSignature{
+			memberIndex: signer.memberIndex,
+			signature:   signature,
+			publicKey:   signer.signing.PublicKey(),
+		}
+	}
+
+	return &inactivityCertificateMessage{
+		senderID:               signers[0].memberIndex,
+		inactiveMembersIndexes: claim.inactiveMembersIndexes,
+		signatures:             signatures,
+		sessionID:              claim.sessionID,
+	}
+}
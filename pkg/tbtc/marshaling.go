@@ -83,6 +83,135 @@ func (s *signer) Unmarshal(bytes []byte) error {
 	return nil
 }
 
+// Marshal converts this inactivityClaimMessage to a byte array suitable
+// for network communication.
+func (icm *inactivityClaimMessage) Marshal() ([]byte, error) {
+	inactiveMembersIndexes := make([]uint32, len(icm.inactiveMembersIndexes))
+	for i, memberIndex := range icm.inactiveMembersIndexes {
+		inactiveMembersIndexes[i] = uint32(memberIndex)
+	}
+
+	return proto.Marshal(&pb.InactivityClaimMessage{
+		SenderID:               uint32(icm.senderID),
+		InactiveMembersIndexes: inactiveMembersIndexes,
+		Signature:              icm.signature,
+		PublicKey:              icm.publicKey,
+		SessionID:              icm.sessionID,
+	})
+}
+
+// Unmarshal converts a byte array produced by Marshal to
+// an inactivityClaimMessage.
+func (icm *inactivityClaimMessage) Unmarshal(bytes []byte) error {
+	pbMsg := pb.InactivityClaimMessage{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return err
+	}
+
+	if err := validateMemberIndex(pbMsg.SenderID); err != nil {
+		return err
+	}
+	icm.senderID = group.MemberIndex(pbMsg.SenderID)
+
+	inactiveMembersIndexes := make(
+		[]group.MemberIndex,
+		len(pbMsg.InactiveMembersIndexes),
+	)
+	for i, memberIndex := range pbMsg.InactiveMembersIndexes {
+		if err := validateMemberIndex(memberIndex); err != nil {
+			return err
+		}
+		inactiveMembersIndexes[i] = group.MemberIndex(memberIndex)
+	}
+	icm.inactiveMembersIndexes = inactiveMembersIndexes
+
+	icm.signature = pbMsg.Signature
+	icm.publicKey = pbMsg.PublicKey
+	icm.sessionID = pbMsg.SessionID
+
+	return nil
+}
+
+// Marshal converts this inactivityCertificateMessage to a byte array suitable
+// for network communication.
+func (icm *inactivityCertificateMessage) Marshal() ([]byte, error) {
+	inactiveMembersIndexes := make([]uint32, len(icm.inactiveMembersIndexes))
+	for i, memberIndex := range icm.inactiveMembersIndexes {
+		inactiveMembersIndexes[i] = uint32(memberIndex)
+	}
+
+	signatures := make(
+		[]*pb.InactivityCertificateMessage_ClaimSignature,
+		len(icm.signatures),
+	)
+	for i, signature := range icm.signatures {
+		signatures[i] = &pb.InactivityCertificateMessage_ClaimSignature{
+			MemberID:  uint32(signature.memberIndex),
+			Signature: signature.signature,
+			PublicKey: signature.publicKey,
+		}
+	}
+
+	return proto.Marshal(&pb.InactivityCertificateMessage{
+		SenderID:               uint32(icm.senderID),
+		InactiveMembersIndexes: inactiveMembersIndexes,
+		Signatures:             signatures,
+		SessionID:              icm.sessionID,
+	})
+}
+
+// Unmarshal converts a byte array produced by Marshal to
+// an inactivityCertificateMessage.
+func (icm *inactivityCertificateMessage) Unmarshal(bytes []byte) error {
+	pbMsg := pb.InactivityCertificateMessage{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return err
+	}
+
+	if err := validateMemberIndex(pbMsg.SenderID); err != nil {
+		return err
+	}
+	icm.senderID = group.MemberIndex(pbMsg.SenderID)
+
+	inactiveMembersIndexes := make(
+		[]group.MemberIndex,
+		len(pbMsg.InactiveMembersIndexes),
+	)
+	for i, memberIndex := range pbMsg.InactiveMembersIndexes {
+		if err := validateMemberIndex(memberIndex); err != nil {
+			return err
+		}
+		inactiveMembersIndexes[i] = group.MemberIndex(memberIndex)
+	}
+	icm.inactiveMembersIndexes = inactiveMembersIndexes
+
+	signatures := make([]*inactivityClaimSignature, len(pbMsg.Signatures))
+	for i, signature := range pbMsg.Signatures {
+		if err := validateMemberIndex(signature.MemberID); err != nil {
+			return err
+		}
+		signatures[i] = &inactivityClaimSignature{
+			memberIndex: group.MemberIndex(signature.MemberID),
+			signature:   signature.Signature,
+			publicKey:   signature.PublicKey,
+		}
+	}
+	icm.signatures = signatures
+
+	icm.sessionID = pbMsg.SessionID
+
+	return nil
+}
+
+func validateMemberIndex(protoIndex uint32) error {
+	// Protobuf does not have uint8 type, so we are using uint32. When
+	// unmarshalling message, we need to make sure we do not overflow.
+	if protoIndex > group.MaxMemberIndex {
+		return fmt.Errorf("invalid member index value: [%v]", protoIndex)
+	}
+	return nil
+}
+
 // marshalPublicKey converts an ECDSA public key to a byte
 // array (uncompressed).
 func marshalPublicKey(publicKey *ecdsa.PublicKey) ([]byte, error) {
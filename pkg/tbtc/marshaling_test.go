@@ -3,6 +3,7 @@ package tbtc
 import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	fuzz "github.com/google/gofuzz"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/internal/pbutils"
 	"github.com/keep-network/keep-core/pkg/internal/tecdsatest"
@@ -77,3 +78,129 @@ func sampleSigner(t *testing.T) *signer {
 	}
 	return marshaled
 }
+
+func TestInactivityClaimMessage_MarshalingRoundtrip(t *testing.T) {
+	msg := &inactivityClaimMessage{
+		senderID:               123,
+		inactiveMembersIndexes: []group.MemberIndex{1, 5, 255},
+		signature:              []byte("signature"),
+		publicKey:              []byte("pubkey"),
+		sessionID:              "session-1",
+	}
+	unmarshaled := &inactivityClaimMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzInactivityClaimMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID               group.MemberIndex
+			inactiveMembersIndexes []group.MemberIndex
+			signature              []byte
+			publicKey              []byte
+			sessionID              string
+		)
+
+		f := fuzz.New().NilChance(0.1).NumElements(0, 512)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&inactiveMembersIndexes)
+		f.Fuzz(&signature)
+		f.Fuzz(&publicKey)
+		f.Fuzz(&sessionID)
+
+		message := &inactivityClaimMessage{
+			senderID:               senderID,
+			inactiveMembersIndexes: inactiveMembersIndexes,
+			signature:              signature,
+			publicKey:              publicKey,
+			sessionID:              sessionID,
+		}
+
+		_ = pbutils.RoundTrip(message, &inactivityClaimMessage{})
+	}
+}
+
+func TestFuzzInactivityClaimMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&inactivityClaimMessage{})
+}
+
+func TestInactivityCertificateMessage_MarshalingRoundtrip(t *testing.T) {
+	msg := &inactivityCertificateMessage{
+		senderID:               123,
+		inactiveMembersIndexes: []group.MemberIndex{1, 5, 255},
+		signatures: []*inactivityClaimSignature{
+			{
+				memberIndex: 2,
+				signature:   []byte("signature-2"),
+				publicKey:   []byte("pubkey-2"),
+			},
+			{
+				memberIndex: 3,
+				signature:   []byte("signature-3"),
+				publicKey:   []byte("pubkey-3"),
+			},
+		},
+		sessionID: "session-1",
+	}
+	unmarshaled := &inactivityCertificateMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzInactivityCertificateMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID               group.MemberIndex
+			inactiveMembersIndexes []group.MemberIndex
+			signaturesBytes        [][]byte
+			publicKey              []byte
+			sessionID              string
+		)
+
+		f := fuzz.New().NilChance(0.1).NumElements(0, 512)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&inactiveMembersIndexes)
+		f.Fuzz(&signaturesBytes)
+		f.Fuzz(&publicKey)
+		f.Fuzz(&sessionID)
+
+		signatures := make([]*inactivityClaimSignature, len(signaturesBytes))
+		for j, signature := range signaturesBytes {
+			signatures[j] = &inactivityClaimSignature{
+				memberIndex: group.MemberIndex(j),
+				signature:   signature,
+				publicKey:   publicKey,
+			}
+		}
+
+		message := &inactivityCertificateMessage{
+			senderID:               senderID,
+			inactiveMembersIndexes: inactiveMembersIndexes,
+			signatures:             signatures,
+			sessionID:              sessionID,
+		}
+
+		_ = pbutils.RoundTrip(message, &inactivityCertificateMessage{})
+	}
+}
+
+func TestFuzzInactivityCertificateMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&inactivityCertificateMessage{})
+}
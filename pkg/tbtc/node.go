@@ -4,9 +4,8 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
-	"fmt"
+	"errors"
 	"math/big"
-	"time"
 
 	"go.uber.org/zap"
 
@@ -14,6 +13,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/generator"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/threshold"
 	"github.com/keep-network/keep-core/pkg/tecdsa/dkg"
 	"github.com/keep-network/keep-core/pkg/tecdsa/signing"
 )
@@ -27,29 +27,19 @@ type node struct {
 	walletRegistry *walletRegistry
 	dkgExecutor    *dkg.Executor
 	protocolLatch  *generator.ProtocolLatch
+	config         Config
 }
 
 func newNode(
 	chain Chain,
 	netProvider net.Provider,
 	keyStorePersistance persistence.ProtectedHandle,
-	workPersistence persistence.BasicHandle,
+	dkgExecutor *dkg.Executor,
 	scheduler *generator.Scheduler,
 	config Config,
 ) *node {
 	walletRegistry := newWalletRegistry(keyStorePersistance)
 
-	dkgExecutor := dkg.NewExecutor(
-		logger,
-		scheduler,
-		workPersistence,
-		config.PreParamsPoolSize,
-		config.PreParamsGenerationTimeout,
-		config.PreParamsGenerationDelay,
-		config.PreParamsGenerationConcurrency,
-		config.KeyGenerationConcurrency,
-	)
-
 	latch := generator.NewProtocolLatch()
 	scheduler.RegisterProtocol(latch)
 
@@ -59,6 +49,7 @@ func newNode(
 		walletRegistry: walletRegistry,
 		dkgExecutor:    dkgExecutor,
 		protocolLatch:  latch,
+		config:         config,
 	}
 }
 
@@ -131,27 +122,19 @@ func (n *node) joinDKGIfEligible(seed *big.Int, startBlockNumber uint64) {
 
 		// Create temporary broadcast channel name for DKG using the
 		// group selection seed with the protocol name as prefix.
-		channelName := fmt.Sprintf("%s-%s", ProtocolName, seed.Text(16))
+		channelName := threshold.ChannelName(ProtocolName, seed.Text(16))
 
-		broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
-		if err != nil {
-			dkgLogger.Errorf("failed to get broadcast channel: [%v]", err)
-			return
-		}
-
-		membershipValidator := group.NewMembershipValidator(
+		broadcastChannel, membershipValidator, err := threshold.JoinBroadcastChannel(
 			dkgLogger,
+			n.netProvider,
+			channelName,
+			dkgMaxMessageSize,
 			selectedSigningGroupOperators,
 			signing,
 		)
-
-		err = broadcastChannel.SetFilter(membershipValidator.IsInGroup)
 		if err != nil {
-			dkgLogger.Errorf(
-				"could not set filter for channel [%v]: [%v]",
-				broadcastChannel.Name(),
-				err,
-			)
+			dkgLogger.Errorf("cannot join broadcast channel: [%v]", err)
+			return
 		}
 
 		blockCounter, err := n.chain.BlockCounter()
@@ -160,6 +143,12 @@ func (n *node) joinDKGIfEligible(seed *big.Int, startBlockNumber uint64) {
 			return
 		}
 
+		n.connectGroupMembers(
+			context.Background(),
+			dkgLogger,
+			selectedSigningGroupOperators,
+		)
+
 		for _, index := range indexes {
 			// Capture the member index for the goroutine. The group member
 			// index should be in range [1, groupSize] so we need to add 1.
@@ -169,21 +158,33 @@ func (n *node) joinDKGIfEligible(seed *big.Int, startBlockNumber uint64) {
 				n.protocolLatch.Lock()
 				defer n.protocolLatch.Unlock()
 
-				retryLoop := newDkgRetryLoop(
+				retryPolicy := n.config.dkgRetryPolicy()
+
+				retryLoop, err := newDkgRetryLoop(
 					seed,
 					startBlockNumber,
 					memberIndex,
 					selectedSigningGroupOperators,
 					chainConfig,
+					retryPolicy,
 				)
+				if err != nil {
+					dkgLogger.Errorf(
+						"[member:%v] cannot create dkg retry loop: [%v]",
+						memberIndex,
+						err,
+					)
+					return
+				}
 
 				// TODO: For this client iteration, the retry loop is started
-				//       with a 168h timeout. Once the WalletRegistry is
-				//       integrated, the stop signal should be generated
-				//       by observing the DKG result submission or timeout.
+				//       with a timeout defined by the retry policy. Once the
+				//       WalletRegistry is integrated, the stop signal should
+				//       be generated by observing the DKG result submission
+				//       or timeout.
 				loopCtx, cancelLoopCtx := context.WithTimeout(
 					context.Background(),
-					7*24*time.Hour,
+					retryPolicy.Timeout,
 				)
 				defer cancelLoopCtx()
 
@@ -204,11 +205,7 @@ func (n *node) joinDKGIfEligible(seed *big.Int, startBlockNumber uint64) {
 						)
 
 						// sessionID must be different for each attempt.
-						sessionID := fmt.Sprintf(
-							"%v-%v",
-							seed.Text(16),
-							attempt.number,
-						)
+						sessionID := threshold.SessionID(seed, attempt.number)
 
 						result, executionEndBlock, err := n.dkgExecutor.Execute(
 							dkgAttemptLogger,
@@ -230,6 +227,21 @@ func (n *node) joinDKGIfEligible(seed *big.Int, startBlockNumber uint64) {
 								err,
 							)
 
+							// All members controlled by this client observe
+							// the same inactive members so report them only
+							// once.
+							var imErr *dkg.InactiveMembersError
+							if errors.As(err, &imErr) && memberIndex == indexes[0]+1 {
+								threshold.ReportMembers(
+									dkgAttemptLogger,
+									n.netProvider,
+									n.chain.Signing(),
+									selectedSigningGroupOperators,
+									imErr.InactiveMembersIndexes,
+									net.ProtocolInactivityEvent,
+								)
+							}
+
 							return nil, 0, err
 						}
 
@@ -411,31 +423,22 @@ func (n *node) joinSigningIfEligible(
 		signingGroupDishonestThreshold := signingGroupSize -
 			n.chain.GetConfig().HonestThreshold
 
-		channelName := fmt.Sprintf(
-			"%s-%s",
+		channelName := threshold.ChannelName(
 			ProtocolName,
 			hex.EncodeToString(walletPublicKeyBytes),
 		)
 
-		broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
-		if err != nil {
-			signingLogger.Errorf("failed to get broadcast channel: [%v]", err)
-			return
-		}
-
-		membershipValidator := group.NewMembershipValidator(
+		broadcastChannel, membershipValidator, err := threshold.JoinBroadcastChannel(
 			signingLogger,
+			n.netProvider,
+			channelName,
+			signingMaxMessageSize,
 			wallet.signingGroupOperators,
 			n.chain.Signing(),
 		)
-
-		err = broadcastChannel.SetFilter(membershipValidator.IsInGroup)
 		if err != nil {
-			signingLogger.Errorf(
-				"could not set filter for channel [%v]: [%v]",
-				broadcastChannel.Name(),
-				err,
-			)
+			signingLogger.Errorf("cannot join broadcast channel: [%v]", err)
+			return
 		}
 
 		blockCounter, err := n.chain.BlockCounter()
@@ -444,25 +447,44 @@ func (n *node) joinSigningIfEligible(
 			return
 		}
 
+		n.connectGroupMembers(
+			context.Background(),
+			signingLogger,
+			wallet.signingGroupOperators,
+		)
+
 		for _, currentSigner := range signers {
 			go func(signer *signer) {
 				n.protocolLatch.Lock()
 				defer n.protocolLatch.Unlock()
 
-				retryLoop := newSigningRetryLoop(
+				chainConfig := n.chain.GetConfig()
+				retryPolicy := n.config.signingRetryPolicy()
+
+				retryLoop, err := newSigningRetryLoop(
 					message,
 					startBlockNumber,
 					signer.signingGroupMemberIndex,
 					wallet.signingGroupOperators,
-					n.chain.GetConfig(),
+					chainConfig,
+					retryPolicy,
 				)
+				if err != nil {
+					signingLogger.Errorf(
+						"[member:%v] cannot create signing retry loop: [%v]",
+						signer.signingGroupMemberIndex,
+						err,
+					)
+					return
+				}
 
 				// TODO: For this client iteration, the signing loop is started
-				//       with a 24h timeout. Another cancel signal should
-				//       be used in the final implementation.
+				//       with a timeout defined by the retry policy. Another
+				//       cancel signal should be used in the final
+				//       implementation.
 				loopCtx, cancelLoopCtx := context.WithTimeout(
 					context.Background(),
-					24*time.Hour,
+					retryPolicy.Timeout,
 				)
 				defer cancelLoopCtx()
 
@@ -482,11 +504,7 @@ func (n *node) joinSigningIfEligible(
 							attempt.excludedMembersIndexes,
 						)
 
-						sessionID := fmt.Sprintf(
-							"%v-%v",
-							message.Text(16),
-							attempt.number,
-						)
+						sessionID := threshold.SessionID(message, attempt.number)
 
 						result, err := signing.Execute(
 							signingAttemptLogger,
@@ -509,11 +527,79 @@ func (n *node) joinSigningIfEligible(
 								err,
 							)
 
+							// All signers controlled by this client observe
+							// the same inactive members so report them only
+							// once.
+							var imErr *signing.InactiveMembersError
+							if errors.As(err, &imErr) && signer == signers[0] {
+								threshold.ReportMembers(
+									signingAttemptLogger,
+									n.netProvider,
+									n.chain.Signing(),
+									wallet.signingGroupOperators,
+									imErr.InactiveMembersIndexes,
+									net.ProtocolInactivityEvent,
+								)
+							}
+
 							return nil, err
 						}
 
 						return result, nil
 					},
+					func(
+						attempt *signingAttemptParams,
+						observedInactiveMembersIndexes []group.MemberIndex,
+					) []group.MemberIndex {
+						inactivityClaimLogger := signingLogger.With(
+							zap.Uint("attempt", attempt.number),
+							zap.Uint64(
+								"inactivityClaimStartBlock",
+								attempt.startBlock+signing.ProtocolBlocks(),
+							),
+						)
+
+						sessionID := threshold.SessionID(message, attempt.number)
+
+						claim, err := agreeOnInactivityClaim(
+							inactivityClaimLogger,
+							sessionID,
+							attempt.startBlock+signing.ProtocolBlocks(),
+							signer.signingGroupMemberIndex,
+							signingGroupSize,
+							n.chain.GetConfig().HonestThreshold,
+							attempt.excludedMembersIndexes,
+							observedInactiveMembersIndexes,
+							blockCounter,
+							broadcastChannel,
+							membershipValidator,
+							n.chain.Signing(),
+						)
+						if err != nil {
+							inactivityClaimLogger.Errorf(
+								"[member:%v] inactivity claim failed: [%v]",
+								signer.signingGroupMemberIndex,
+								err,
+							)
+							return nil
+						}
+
+						if claim == nil {
+							inactivityClaimLogger.Infof(
+								"[member:%v] no inactivity claim agreed",
+								signer.signingGroupMemberIndex,
+							)
+							return nil
+						}
+
+						inactivityClaimLogger.Infof(
+							"[member:%v] agreed on inactive members [%v]",
+							signer.signingGroupMemberIndex,
+							claim.inactiveMembersIndexes,
+						)
+
+						return claim.inactiveMembersIndexes
+					},
 				)
 				if err != nil {
 					signingLogger.Errorf(
@@ -0,0 +1,59 @@
+package tbtc
+
+import (
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/protocol/threshold"
+)
+
+// Defaults of the local retry settings. The maximum number of attempts and
+// the timeout only bound how long this client keeps retrying; see Config.
+const (
+	DefaultDKGMaxAttempts     = 0
+	DefaultDKGTimeout         = 7 * 24 * time.Hour
+	DefaultSigningMaxAttempts = 0
+	DefaultSigningTimeout     = 24 * time.Hour
+)
+
+// Protocol parameters of the retry loops. The attempt delay and the exclusion
+// strategy determine the attempt schedule and the operators qualified for
+// subsequent attempts. All members of the group must use the same values so
+// they are not configurable.
+const (
+	dkgAttemptDelayBlocks     = 5
+	dkgExclusionStrategy      = threshold.InactivityExclusionStrategy
+	signingAttemptDelayBlocks = 5
+	signingExclusionStrategy  = threshold.InactivityExclusionStrategy
+)
+
+// dkgRetryPolicy returns the retry policy of the DKG retry loop. Unset local
+// values are replaced with defaults.
+func (c Config) dkgRetryPolicy() *threshold.RetryPolicy {
+	timeout := c.DKGTimeout
+	if timeout == 0 {
+		timeout = DefaultDKGTimeout
+	}
+
+	return &threshold.RetryPolicy{
+		MaxAttempts:       c.DKGMaxAttempts,
+		DelayBlocks:       dkgAttemptDelayBlocks,
+		Timeout:           timeout,
+		ExclusionStrategy: dkgExclusionStrategy,
+	}
+}
+
+// signingRetryPolicy returns the retry policy of the signing retry loop.
+// Unset local values are replaced with defaults.
+func (c Config) signingRetryPolicy() *threshold.RetryPolicy {
+	timeout := c.SigningTimeout
+	if timeout == 0 {
+		timeout = DefaultSigningTimeout
+	}
+
+	return &threshold.RetryPolicy{
+		MaxAttempts:       c.SigningMaxAttempts,
+		DelayBlocks:       signingAttemptDelayBlocks,
+		Timeout:           timeout,
+		ExclusionStrategy: signingExclusionStrategy,
+	}
+}
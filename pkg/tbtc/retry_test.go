@@ -0,0 +1,131 @@
+package tbtc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/threshold"
+	"github.com/keep-network/keep-core/pkg/tecdsa/signing"
+)
+
+func TestRetryPolicy_Defaults(t *testing.T) {
+	config := Config{}
+
+	expectedDkgPolicy := &threshold.RetryPolicy{
+		MaxAttempts:       DefaultDKGMaxAttempts,
+		DelayBlocks:       dkgAttemptDelayBlocks,
+		Timeout:           DefaultDKGTimeout,
+		ExclusionStrategy: threshold.InactivityExclusionStrategy,
+	}
+	dkgPolicy := config.dkgRetryPolicy()
+	if !reflect.DeepEqual(expectedDkgPolicy, dkgPolicy) {
+		t.Errorf(
+			"unexpected dkg retry policy\nexpected: [%+v]\nactual:   [%+v]",
+			expectedDkgPolicy,
+			dkgPolicy,
+		)
+	}
+
+	expectedSigningPolicy := &threshold.RetryPolicy{
+		MaxAttempts:       DefaultSigningMaxAttempts,
+		DelayBlocks:       signingAttemptDelayBlocks,
+		Timeout:           DefaultSigningTimeout,
+		ExclusionStrategy: threshold.InactivityExclusionStrategy,
+	}
+	signingPolicy := config.signingRetryPolicy()
+	if !reflect.DeepEqual(expectedSigningPolicy, signingPolicy) {
+		t.Errorf(
+			"unexpected signing retry policy\nexpected: [%+v]\nactual:   [%+v]",
+			expectedSigningPolicy,
+			signingPolicy,
+		)
+	}
+}
+
+func TestRetryPolicy_Configured(t *testing.T) {
+	config := Config{
+		SigningMaxAttempts: 3,
+		SigningTimeout:     time.Hour,
+	}
+
+	expectedPolicy := &threshold.RetryPolicy{
+		MaxAttempts:       3,
+		DelayBlocks:       signingAttemptDelayBlocks,
+		Timeout:           time.Hour,
+		ExclusionStrategy: threshold.InactivityExclusionStrategy,
+	}
+	policy := config.signingRetryPolicy()
+	if !reflect.DeepEqual(expectedPolicy, policy) {
+		t.Errorf(
+			"unexpected signing retry policy\nexpected: [%+v]\nactual:   [%+v]",
+			expectedPolicy,
+			policy,
+		)
+	}
+}
+
+func TestSigningRetryLoop_MaxAttempts(t *testing.T) {
+	chainConfig := &ChainConfig{
+		GroupSize:       10,
+		HonestThreshold: 6,
+	}
+
+	signingGroupOperators := chain.Addresses{
+		"address-1",
+		"address-2",
+		"address-3",
+		"address-4",
+		"address-5",
+		"address-6",
+		"address-7",
+		"address-8",
+		"address-9",
+		"address-10",
+	}
+
+	retryLoop, err := newSigningRetryLoop(
+		big.NewInt(100),
+		200,
+		group.MemberIndex(1),
+		signingGroupOperators,
+		chainConfig,
+		Config{SigningMaxAttempts: 3}.signingRetryPolicy(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attempts := uint(0)
+	_, err = retryLoop.start(
+		context.Background(),
+		func(params *signingAttemptParams) (*signing.Result, error) {
+			attempts++
+			return nil, fmt.Errorf("invalid data")
+		},
+		func(
+			attempt *signingAttemptParams,
+			observedInactiveMembersIndexes []group.MemberIndex,
+		) []group.MemberIndex {
+			return nil
+		},
+	)
+
+	expectedErr := fmt.Errorf("retry loop exhausted the maximum number of [3] attempts")
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+
+	if attempts > 3 {
+		t.Errorf("unexpected number of executed attempts: [%v]", attempts)
+	}
+}
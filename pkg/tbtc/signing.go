@@ -2,31 +2,20 @@ package tbtc
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/binary"
+	"errors"
 	"fmt"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/threshold"
 	"github.com/keep-network/keep-core/pkg/tecdsa/retry"
 	"github.com/keep-network/keep-core/pkg/tecdsa/signing"
-	"golang.org/x/exp/slices"
 	"math/big"
-	"math/rand"
-	"sort"
 )
 
 // signingRetryLoop is a struct that encapsulates the signing retry logic.
 type signingRetryLoop struct {
-	signingGroupMemberIndex group.MemberIndex
-	signingGroupOperators   chain.Addresses
-
-	chainConfig *ChainConfig
-
-	attemptCounter    uint
-	attemptStartBlock uint64
-	attemptSeed       int64
-
-	delayBlocks uint64
+	loop   *threshold.RetryLoop
+	policy *threshold.RetryPolicy
 }
 
 func newSigningRetryLoop(
@@ -35,23 +24,67 @@ func newSigningRetryLoop(
 	signingGroupMemberIndex group.MemberIndex,
 	signingGroupOperators chain.Addresses,
 	chainConfig *ChainConfig,
-) *signingRetryLoop {
-	// Compute the 8-byte seed needed for the random retry algorithm. We take
-	// the first 8 bytes of the hash of the signed message. This allows us to
-	// not care in this piece of the code about the length of the message and
-	// how this message is proposed.
-	messageSha256 := sha256.Sum256(message.Bytes())
-	attemptSeed := int64(binary.BigEndian.Uint64(messageSha256[:8]))
+	policy *threshold.RetryPolicy,
+) (*signingRetryLoop, error) {
+	attemptSeed := threshold.RetrySeed(message)
 
-	return &signingRetryLoop{
-		signingGroupMemberIndex: signingGroupMemberIndex,
-		signingGroupOperators:   signingGroupOperators,
-		chainConfig:             chainConfig,
-		attemptCounter:          0,
-		attemptStartBlock:       initialStartBlock,
-		attemptSeed:             attemptSeed,
-		delayBlocks:             5,
+	// We want to take the random subset right away for the first attempt.
+	// The qualified operators for the first attempt are selected by the
+	// random retry algorithm before the strategy is evaluated for the first
+	// time.
+	qualifiedOperators, err := retry.EvaluateRetryParticipantsForSigning(
+		signingGroupOperators,
+		attemptSeed,
+		0,
+		uint(chainConfig.HonestThreshold),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"random operator selection failed: [%w]",
+			err,
+		)
 	}
+
+	exclusionStrategy, err := threshold.NewExclusionStrategy(
+		policy.ExclusionStrategy,
+		signingGroupOperators,
+		attemptSeed,
+		uint(chainConfig.HonestThreshold),
+		retry.EvaluateRetryParticipantsForSigning,
+		1,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// We assume the worst case that each attempt failed at the end of the
+	// signing protocol whose duration is determined by the
+	// signing.ProtocolBlocks function. If inactivity claims are used,
+	// the attempt is followed by the inactivity claim protocol so its
+	// duration must be taken into account as well.
+	attemptBlocks := signing.ProtocolBlocks()
+	if policy.ExclusionStrategy == threshold.InactivityExclusionStrategy {
+		attemptBlocks += inactivityClaimBlocks()
+	}
+
+	// Make sure we always use just the smallest required count of signing
+	// members for performance reasons.
+	loop := threshold.NewRetryLoop(
+		signingGroupMemberIndex,
+		signingGroupOperators,
+		qualifiedOperators,
+		chainConfig.HonestThreshold,
+		attemptSeed,
+		initialStartBlock,
+		attemptBlocks,
+		policy,
+		exclusionStrategy,
+	)
+
+	return &signingRetryLoop{
+		loop:   loop,
+		policy: policy,
+	}, nil
 }
 
 // signingAttemptParams represents parameters of a signing attempt.
@@ -64,157 +97,80 @@ type signingAttemptParams struct {
 // signingAttemptFn represents a function performing a signing attempt.
 type signingAttemptFn func(*signingAttemptParams) (*signing.Result, error)
 
+// signingInactivityClaimFn represents a function agreeing on the inactivity
+// claim at the end of a failed signing attempt. The function receives
+// the inactive members observed by the member during the attempt, nil if
+// the member has no such evidence, and returns the inactive members agreed
+// by the signing group or nil if the group did not agree on any claim.
+type signingInactivityClaimFn func(
+	attempt *signingAttemptParams,
+	observedInactiveMembersIndexes []group.MemberIndex,
+) []group.MemberIndex
+
 // start begins the signing retry loop using the given signing attempt function.
 // The retry loop terminates when the signing result is produced or the ctx
 // parameter is done, whatever comes first.
+//
+// If the loop uses the inactivity exclusion strategy, the given inactivity
+// claim function is executed at the end of each failed attempt and
+// the inactive members agreed by the signing group are excluded from
+// subsequent attempts. Otherwise, the inactivity claim function is not used
+// and can be nil.
 func (srl *signingRetryLoop) start(
 	ctx context.Context,
 	signingAttemptFn signingAttemptFn,
+	inactivityClaimFn signingInactivityClaimFn,
 ) (*signing.Result, error) {
-	// We want to take the random subset right away for the first attempt.
-	qualifiedOperatorsSet, err := srl.qualifiedOperatorsSet()
-	if err != nil {
-		return nil, fmt.Errorf(
-			"cannot get qualified operators for attempt [%v]: [%w]",
-			srl.attemptCounter+1,
-			err,
-		)
-	}
-
-	for {
-		srl.attemptCounter++
-
-		// Check the loop stop signal.
-		if ctx.Err() != nil {
-			return nil, fmt.Errorf(
-				"signing retry loop received stop signal on attempt [%v]",
-				srl.attemptCounter,
-			)
-		}
-
-		// In order to start attempts >1 in the right place, we need to
-		// determine how many blocks were taken by previous attempts. We assume
-		// the worst case that each attempt failed at the end of the signing
-		// protocol.
-		//
-		// That said, we need to increment the previous attempt start
-		// block by the number of blocks equal to the protocol duration and
-		// by some additional delay blocks. We need a small fixed delay in
-		// order to mitigate all corner cases where the actual attempt duration
-		// was slightly longer than the expected duration determined by the
-		// signing.ProtocolBlocks function.
-		//
-		// For example, the attempt may fail at the end of the protocol but the
-		// error is returned after some time and more blocks than expected are
-		// mined in the meantime.
-		if srl.attemptCounter > 1 {
-			srl.attemptStartBlock = srl.attemptStartBlock +
-				signing.ProtocolBlocks() +
-				srl.delayBlocks
-		}
-
-		// Exclude all members controlled by the operators that were not
-		// qualified for the current attempt.
-		includedMembersIndexes := make([]group.MemberIndex, 0)
-		excludedMembersIndexes := make([]group.MemberIndex, 0)
-		for i, operator := range srl.signingGroupOperators {
-			memberIndex := group.MemberIndex(i + 1)
-
-			if qualifiedOperatorsSet[operator] {
-				includedMembersIndexes = append(
-					includedMembersIndexes,
-					memberIndex,
-				)
-			} else {
-				excludedMembersIndexes = append(
-					excludedMembersIndexes,
-					memberIndex,
-				)
+	var result *signing.Result
+
+	var inactiveMembersFn threshold.InactiveMembersFn
+	if srl.inactivityClaimsEnabled() {
+		inactiveMembersFn = func(
+			attempt *threshold.Attempt,
+			attemptErr error,
+		) []group.MemberIndex {
+			// Only inactive members agreed by the signing group are taken
+			// into account. Relying on the local observation could lead to
+			// different qualified operators sets on different members.
+			var observedInactiveMembersIndexes []group.MemberIndex
+			var imErr *signing.InactiveMembersError
+			if errors.As(attemptErr, &imErr) {
+				observedInactiveMembersIndexes = imErr.InactiveMembersIndexes
 			}
-		}
 
-		// Make sure we always use just the smallest required count of
-		// signing members for performance reasons
-		if len(includedMembersIndexes) > srl.chainConfig.HonestThreshold {
-			// #nosec G404 (insecure random number source (rand))
-			// Shuffling does not require secure randomness.
-			rng := rand.New(rand.NewSource(
-				srl.attemptSeed + int64(srl.attemptCounter),
-			))
-			// Sort in ascending order just in case.
-			sort.Slice(includedMembersIndexes, func(i, j int) bool {
-				return includedMembersIndexes[i] < includedMembersIndexes[j]
-			})
-			// Shuffle the included members slice to randomize the
-			// selection of additionally excluded members.
-			rng.Shuffle(len(includedMembersIndexes), func(i, j int) {
-				includedMembersIndexes[i], includedMembersIndexes[j] =
-					includedMembersIndexes[j], includedMembersIndexes[i]
-			})
-			// Get the surplus of included members and add them to
-			// the excluded members list.
-			excludedMembersIndexes = append(
-				excludedMembersIndexes,
-				includedMembersIndexes[srl.chainConfig.HonestThreshold:]...,
+			return inactivityClaimFn(
+				newSigningAttemptParams(attempt),
+				observedInactiveMembersIndexes,
 			)
-			// Sort the resulting excluded members list in ascending order.
-			sort.Slice(excludedMembersIndexes, func(i, j int) bool {
-				return excludedMembersIndexes[i] < excludedMembersIndexes[j]
-			})
-		}
-
-		attemptSkipped := slices.Contains(
-			excludedMembersIndexes,
-			srl.signingGroupMemberIndex,
-		)
-
-		var result *signing.Result
-		var attemptErr error
-
-		if !attemptSkipped {
-			result, attemptErr = signingAttemptFn(&signingAttemptParams{
-				number:                 srl.attemptCounter,
-				startBlock:             srl.attemptStartBlock,
-				excludedMembersIndexes: excludedMembersIndexes,
-			})
 		}
+	}
 
-		if attemptSkipped || attemptErr != nil {
+	_, err := srl.loop.Start(
+		ctx,
+		func(attempt *threshold.Attempt) error {
 			var err error
-			qualifiedOperatorsSet, err = srl.qualifiedOperatorsSet()
-			if err != nil {
-				return nil, fmt.Errorf(
-					"cannot get qualified operators for attempt [%v]: [%w]",
-					srl.attemptCounter+1,
-					err,
-				)
-			}
+			result, err = signingAttemptFn(newSigningAttemptParams(attempt))
+			return err
+		},
+		inactiveMembersFn,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-			continue
-		}
+	return result, nil
+}
 
-		return result, nil
-	}
+// inactivityClaimsEnabled returns true if the inactivity claim protocol is
+// executed at the end of each failed attempt.
+func (srl *signingRetryLoop) inactivityClaimsEnabled() bool {
+	return srl.policy.ExclusionStrategy == threshold.InactivityExclusionStrategy
 }
 
-// qualifiedOperatorsSet returns a set of operators qualified to participate
-// in the given signing attempt.
-func (srl *signingRetryLoop) qualifiedOperatorsSet() (
-	map[chain.Address]bool,
-	error,
-) {
-	qualifiedOperators, err := retry.EvaluateRetryParticipantsForSigning(
-		srl.signingGroupOperators,
-		srl.attemptSeed,
-		srl.attemptCounter,
-		uint(srl.chainConfig.HonestThreshold),
-	)
-	if err != nil {
-		return nil, fmt.Errorf(
-			"random operator selection failed: [%w]",
-			err,
-		)
+func newSigningAttemptParams(attempt *threshold.Attempt) *signingAttemptParams {
+	return &signingAttemptParams{
+		number:                 attempt.Number,
+		startBlock:             attempt.StartBlock,
+		excludedMembersIndexes: attempt.ExcludedMembersIndexes,
 	}
-
-	return chain.Addresses(qualifiedOperators).Set(), nil
 }
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/threshold"
 	"github.com/keep-network/keep-core/pkg/tecdsa"
 	"github.com/keep-network/keep-core/pkg/tecdsa/signing"
+	"golang.org/x/exp/slices"
 	"math/big"
 	"reflect"
 	"testing"
@@ -127,21 +129,30 @@ func TestSigningRetryLoop(t *testing.T) {
 			signingAttemptFn: func(attempt *signingAttemptParams) (*signing.Result, error) {
 				return nil, fmt.Errorf("invalid data")
 			},
-			expectedErr:         fmt.Errorf("signing retry loop received stop signal on attempt [1]"),
+			expectedErr:         fmt.Errorf("retry loop received stop signal on attempt [1]"),
 			expectedResult:      nil,
 			expectedLastAttempt: nil,
 		},
 	}
 
+	// The test cases exercise the random retry algorithm so inactivity
+	// claims are not used.
+	policy := Config{}.signingRetryPolicy()
+	policy.ExclusionStrategy = threshold.RandomExclusionStrategy
+
 	for testName, test := range tests {
 		t.Run(testName, func(t *testing.T) {
-			retryLoop := newSigningRetryLoop(
+			retryLoop, err := newSigningRetryLoop(
 				big.NewInt(100),
 				200,
 				test.signingGroupMemberIndex,
 				signingGroupOperators,
 				chainConfig,
+				policy,
 			)
+			if err != nil {
+				t.Fatal(err)
+			}
 
 			ctx, cancelCtx := test.ctxFn()
 			defer cancelCtx()
@@ -154,6 +165,7 @@ func TestSigningRetryLoop(t *testing.T) {
 					lastAttempt = params
 					return test.signingAttemptFn(params)
 				},
+				nil,
 			)
 
 			if !reflect.DeepEqual(test.expectedErr, err) {
@@ -188,3 +200,110 @@ func TestSigningRetryLoop(t *testing.T) {
 		})
 	}
 }
+
+func TestSigningRetryLoop_InactivityClaims(t *testing.T) {
+	chainConfig := &ChainConfig{
+		GroupSize:       10,
+		HonestThreshold: 6,
+	}
+
+	signingGroupOperators := chain.Addresses{
+		"address-1",
+		"address-2",
+		"address-3",
+		"address-4",
+		"address-5",
+		"address-6",
+		"address-7",
+		"address-8",
+		"address-9",
+		"address-10",
+	}
+
+	testResult := &signing.Result{
+		Signature: &tecdsa.Signature{
+			R:          big.NewInt(300),
+			S:          big.NewInt(400),
+			RecoveryID: 2,
+		},
+	}
+
+	retryLoop, err := newSigningRetryLoop(
+		big.NewInt(100),
+		200,
+		group.MemberIndex(1),
+		signingGroupOperators,
+		chainConfig,
+		Config{}.signingRetryPolicy(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var inactiveMemberIndex group.MemberIndex
+	var lastAttempt *signingAttemptParams
+
+	result, err := retryLoop.start(
+		context.Background(),
+		func(attempt *signingAttemptParams) (*signing.Result, error) {
+			lastAttempt = attempt
+			if attempt.number == 1 {
+				return nil, fmt.Errorf("unexpected error")
+			}
+			return testResult, nil
+		},
+		func(
+			attempt *signingAttemptParams,
+			observedInactiveMembersIndexes []group.MemberIndex,
+		) []group.MemberIndex {
+			if attempt.number != 1 {
+				return nil
+			}
+
+			// Mark the first participant other than the current member
+			// as inactive.
+			excluded := make(map[group.MemberIndex]bool)
+			for _, memberIndex := range attempt.excludedMembersIndexes {
+				excluded[memberIndex] = true
+			}
+			for i := 2; i <= chainConfig.GroupSize; i++ {
+				if !excluded[group.MemberIndex(i)] {
+					inactiveMemberIndex = group.MemberIndex(i)
+					break
+				}
+			}
+
+			return []group.MemberIndex{inactiveMemberIndex}
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(testResult, result) {
+		t.Errorf("unexpected result")
+	}
+
+	if lastAttempt.number < 2 {
+		t.Fatalf("unexpected last attempt number: [%v]", lastAttempt.number)
+	}
+
+	if !slices.Contains(lastAttempt.excludedMembersIndexes, inactiveMemberIndex) {
+		t.Errorf(
+			"inactive member [%v] was not excluded from attempt [%v]",
+			inactiveMemberIndex,
+			lastAttempt.number,
+		)
+	}
+
+	expectedStartBlock := 200 + uint64(lastAttempt.number-1)*(signing.ProtocolBlocks()+
+		inactivityClaimBlocks()+
+		signingAttemptDelayBlocks)
+	if expectedStartBlock != lastAttempt.startBlock {
+		t.Errorf(
+			"unexpected start block\nexpected: [%v]\nactual:   [%v]",
+			expectedStartBlock,
+			lastAttempt.startBlock,
+		)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/generator"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/sortition"
+	"github.com/keep-network/keep-core/pkg/tecdsa/dkg"
 )
 
 // TODO: Unit tests for `tbtc.go`.
@@ -22,6 +23,19 @@ var logger = log.Logger("keep-tbtc")
 // ProtocolName denotes the name of the protocol defined by this package.
 const ProtocolName = "tbtc"
 
+const (
+	// dkgMaxMessageSize is the maximum size in bytes of messages sent over
+	// the DKG broadcast channel. The largest DKG message is the first TSS
+	// round message carrying the Paillier public key along with the
+	// discrete logarithm proofs of about 130 KiB.
+	dkgMaxMessageSize = 512 * 1024
+	// signingMaxMessageSize is the maximum size in bytes of messages sent
+	// over the wallet signing broadcast channel. The largest signing
+	// messages are the first and second TSS round messages carrying MtA
+	// range proofs for every other member of the signing group.
+	signingMaxMessageSize = 1024 * 1024
+)
+
 const (
 	DefaultPreParamsPoolSize              = 3000
 	DefaultPreParamsGenerationTimeout     = 2 * time.Minute
@@ -43,6 +57,30 @@ type Config struct {
 	PreParamsGenerationConcurrency int
 	// Concurrency level for key-generation for tECDSA.
 	KeyGenerationConcurrency int
+	// Maximum number of DKG attempts. Zero means no limit.
+	//
+	// The maximum number of attempts and the timeout of a retry loop are
+	// local settings and members of the group may use different values.
+	// Neither the attempt schedule nor the operators qualified for an attempt
+	// depend on them so a member that stops retrying earlier than others
+	// does not break the agreement of the rest of the group; it is just
+	// treated as inactive in subsequent attempts.
+	DKGMaxAttempts uint
+	// Timeout of the whole DKG retry loop.
+	DKGTimeout time.Duration
+	// Maximum number of signing attempts. Zero means no limit. See
+	// DKGMaxAttempts.
+	SigningMaxAttempts uint
+	// Timeout of the whole signing retry loop.
+	SigningTimeout time.Duration
+}
+
+// Operator groups the dependencies of a single operator taking part in the
+// TBTC protocol.
+type Operator struct {
+	Chain               Chain
+	NetProvider         net.Provider
+	KeyStorePersistence persistence.ProtectedHandle
 }
 
 // Initialize kicks off the TBTC by initializing internal state, ensuring
@@ -58,18 +96,88 @@ func Initialize(
 	config Config,
 	registry *diagnostics.Registry,
 ) error {
-	node := newNode(chain, netProvider, keyStorePersistence, workPersistence, scheduler, config)
-	deduplicator := newDeduplicator()
+	return InitializeOperators(
+		ctx,
+		[]*Operator{{
+			Chain:               chain,
+			NetProvider:         netProvider,
+			KeyStorePersistence: keyStorePersistence,
+		}},
+		workPersistence,
+		scheduler,
+		config,
+		registry,
+	)
+}
+
+// InitializeOperators kicks off the TBTC for all the given operators run by
+// the client. Every operator gets its own node, sortition pool monitoring and
+// event handlers. The DKG pre-parameters pool, persisted in the work
+// persistence, is shared by all the operators so the expensive pre-parameters
+// generation is done once for the whole client.
+func InitializeOperators(
+	ctx context.Context,
+	operators []*Operator,
+	workPersistence persistence.BasicHandle,
+	scheduler *generator.Scheduler,
+	config Config,
+	registry *diagnostics.Registry,
+) error {
+	dkgExecutor := dkg.NewExecutor(
+		logger,
+		scheduler,
+		workPersistence,
+		config.PreParamsPoolSize,
+		config.PreParamsGenerationTimeout,
+		config.PreParamsGenerationDelay,
+		config.PreParamsGenerationConcurrency,
+		config.KeyGenerationConcurrency,
+	)
 
 	registry.RegisterApplicationSource(
 		"tbtc",
 		func() map[string]interface{} {
 			return map[string]interface{}{
-				"preParamsPoolSize": node.dkgExecutor.PreParamsCount(),
+				"preParamsPoolSize": dkgExecutor.PreParamsCount(),
+				"operatorsCount":    len(operators),
 			}
 		},
 	)
 
+	for _, operator := range operators {
+		if err := initializeOperator(
+			ctx,
+			operator,
+			dkgExecutor,
+			scheduler,
+			config,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func initializeOperator(
+	ctx context.Context,
+	operator *Operator,
+	dkgExecutor *dkg.Executor,
+	scheduler *generator.Scheduler,
+	config Config,
+) error {
+	chain := operator.Chain
+
+	node := newNode(
+		chain,
+		operator.NetProvider,
+		operator.KeyStorePersistence,
+		dkgExecutor,
+		scheduler,
+		config,
+	)
+	deduplicator := newDeduplicator()
+
 	err := sortition.MonitorPool(
 		ctx,
 		logger,
@@ -73,7 +73,7 @@ func (e *Executor) Execute(
 ) (*Result, uint64, error) {
 	logger.Debugf("[member:%v] initializing member", memberIndex)
 
-	registerUnmarshallers(channel)
+	registerUnmarshallers(channel, groupSize)
 
 	member := newMember(
 		logger,
@@ -198,10 +198,26 @@ func Publish(
 	return nil
 }
 
+// Maximum sizes in bytes of payloads of DKG protocol messages. Limits of
+// messages carrying data for every other group member grow with the size of
+// the group.
+const (
+	// maxBroadcastPayloadSize is the limit of the part of a message
+	// addressed to all group members. The biggest one is the first TSS round
+	// message carrying the Paillier public key along with the discrete
+	// logarithm proofs.
+	maxBroadcastPayloadSize = 256 * 1024
+	// maxPeerPayloadSize is the limit of the part of a message addressed to
+	// a single group member, such as an encrypted ephemeral public key or
+	// an encrypted TSS share.
+	maxPeerPayloadSize = 1024
+)
+
 // registerUnmarshallers initializes the given broadcast channel to be able to
 // perform DKG protocol interactions by registering all the required protocol
-// message unmarshallers.
-func registerUnmarshallers(channel net.BroadcastChannel) {
+// message unmarshallers. Channels supporting size limits are configured to
+// reject messages exceeding the size expected for a group of the given size.
+func registerUnmarshallers(channel net.BroadcastChannel, groupSize int) {
 	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
 		return &ephemeralPublicKeyMessage{}
 	})
@@ -217,4 +233,31 @@ func registerUnmarshallers(channel net.BroadcastChannel) {
 	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
 		return &resultSignatureMessage{}
 	})
+
+	// Every member sends a single message of every round in a protocol
+	// attempt. Further messages are rejected by channels supporting quotas.
+	if rateLimiter, ok := channel.(net.MessageRateLimiter); ok {
+		for _, message := range []net.TaggedUnmarshaler{
+			&ephemeralPublicKeyMessage{},
+			&tssRoundOneMessage{},
+			&tssRoundTwoMessage{},
+			&tssRoundThreeMessage{},
+		} {
+			rateLimiter.SetMessageQuota(message.Type(), 1)
+		}
+	}
+
+	if sizeLimiter, ok := channel.(net.MessageSizeLimiter); ok {
+		peersPayloadSize := groupSize * maxPeerPayloadSize
+
+		for message, maxSize := range map[net.TaggedUnmarshaler]int{
+			&ephemeralPublicKeyMessage{}: peersPayloadSize,
+			&tssRoundOneMessage{}:        maxBroadcastPayloadSize,
+			&tssRoundTwoMessage{}:        maxBroadcastPayloadSize + peersPayloadSize,
+			&tssRoundThreeMessage{}:      maxBroadcastPayloadSize,
+			&resultSignatureMessage{}:    maxPeerPayloadSize,
+		} {
+			sizeLimiter.SetMaxPayloadSize(message.Type(), maxSize)
+		}
+	}
 }
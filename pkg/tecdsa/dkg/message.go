@@ -3,6 +3,7 @@ package dkg
 import (
 	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/threshold"
 )
 
 const messageTypePrefix = "tecdsa_dkg/"
@@ -31,6 +32,11 @@ func (epkm *ephemeralPublicKeyMessage) Type() string {
 	return messageTypePrefix + "ephemeral_public_key_message"
 }
 
+// QuotaScope returns the message quota scope of the sender's session.
+func (epkm *ephemeralPublicKeyMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(epkm.sessionID, epkm.senderID)
+}
+
 // tssRoundOneMessage is a message payload that carries the sender's TSS
 // commitments and the Paillier public key.
 type tssRoundOneMessage struct {
@@ -51,6 +57,11 @@ func (trom *tssRoundOneMessage) Type() string {
 	return messageTypePrefix + "tss_round_one_message"
 }
 
+// QuotaScope returns the message quota scope of the sender's session.
+func (trom *tssRoundOneMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(trom.sessionID, trom.senderID)
+}
+
 // tssRoundTwoMessage is a message payload that carries the sender's TSS
 // shares and de-commitments.
 type tssRoundTwoMessage struct {
@@ -72,6 +83,11 @@ func (trtm *tssRoundTwoMessage) Type() string {
 	return messageTypePrefix + "tss_round_two_message"
 }
 
+// QuotaScope returns the message quota scope of the sender's session.
+func (trtm *tssRoundTwoMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(trtm.sessionID, trtm.senderID)
+}
+
 // tssRoundThreeMessage is a message payload that carries the sender's TSS
 // Paillier proof.
 type tssRoundThreeMessage struct {
@@ -92,6 +108,11 @@ func (trtm *tssRoundThreeMessage) Type() string {
 	return messageTypePrefix + "tss_round_three_message"
 }
 
+// QuotaScope returns the message quota scope of the sender's session.
+func (trtm *tssRoundThreeMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(trtm.sessionID, trtm.senderID)
+}
+
 // resultSignatureMessage is a message payload that carries a hash of
 // the DKG result and a signature over this hash for a DKG result.
 //
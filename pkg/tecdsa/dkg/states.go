@@ -113,6 +113,14 @@ func (ekpgs *ephemeralKeyPairGenerationState) MemberIndex() group.MemberIndex {
 	return ekpgs.member.id
 }
 
+// MessagesDropped retransmits messages of the member right away when the
+// member could not keep up with messages of other members. All members
+// receive the same burst of messages at the same time so the member's own
+// messages were likely dropped by other members as well.
+func (ekpgs *ephemeralKeyPairGenerationState) MessagesDropped(count uint64) {
+	state.RetransmitNow(ekpgs.channel)
+}
+
 // symmetricKeyGenerationState is the state during which members compute
 // symmetric keys from the previously exchanged ephemeral public keys.
 // No messages are valid in this state.
@@ -242,6 +250,10 @@ func (tros *tssRoundOneState) MemberIndex() group.MemberIndex {
 	return tros.member.id
 }
 
+func (tros *tssRoundOneState) MessagesDropped(count uint64) {
+	state.RetransmitNow(tros.channel)
+}
+
 // tssRoundTwoState is the state during which members broadcast TSS
 // shares and de-commitments.
 // `tssRoundTwoMessage`s are valid in this state.
@@ -327,6 +339,10 @@ func (trts *tssRoundTwoState) MemberIndex() group.MemberIndex {
 	return trts.member.id
 }
 
+func (trts *tssRoundTwoState) MessagesDropped(count uint64) {
+	state.RetransmitNow(trts.channel)
+}
+
 // tssRoundThreeState is the state during which members broadcast the TSS Paillier
 // proof.
 // `tssRoundThreeMessage`s are valid in this state.
@@ -412,6 +428,10 @@ func (trts *tssRoundThreeState) MemberIndex() group.MemberIndex {
 	return trts.member.id
 }
 
+func (trts *tssRoundThreeState) MessagesDropped(count uint64) {
+	state.RetransmitNow(trts.channel)
+}
+
 // finalizationState is the last state of the DKG protocol - in this state,
 // distributed key generation is completed. No messages are valid in this state.
 //
@@ -476,6 +496,10 @@ func (fs *finalizationState) MemberIndex() group.MemberIndex {
 	return fs.member.id
 }
 
+func (fs *finalizationState) MessagesDropped(count uint64) {
+	state.RetransmitNow(fs.channel)
+}
+
 func (fs *finalizationState) result() *Result {
 	return fs.member.Result()
 }
@@ -572,6 +596,10 @@ func (rss *resultSigningState) MemberIndex() group.MemberIndex {
 	return rss.member.memberIndex
 }
 
+func (rss *resultSigningState) MessagesDropped(count uint64) {
+	state.RetransmitNow(rss.channel)
+}
+
 // signaturesVerificationState is the state during which group members verify
 // all validSignatures that valid submitters sent over the broadcast channel in
 // the previous state. Valid validSignatures are added to the state.
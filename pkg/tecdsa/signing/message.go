@@ -3,6 +3,7 @@ package signing
 import (
 	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/threshold"
 )
 
 const messageTypePrefix = "tecdsa_signing/"
@@ -31,6 +32,11 @@ func (epkm *ephemeralPublicKeyMessage) Type() string {
 	return messageTypePrefix + "ephemeral_public_key_message"
 }
 
+// QuotaScope returns the message quota scope of the sender's session.
+func (epkm *ephemeralPublicKeyMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(epkm.sessionID, epkm.senderID)
+}
+
 // tssRoundOneMessage is a message payload that carries the sender's
 // TSS round one components.
 type tssRoundOneMessage struct {
@@ -52,6 +58,11 @@ func (trom *tssRoundOneMessage) Type() string {
 	return messageTypePrefix + "tss_round_one_message"
 }
 
+// QuotaScope returns the message quota scope of the sender's session.
+func (trom *tssRoundOneMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(trom.sessionID, trom.senderID)
+}
+
 // tssRoundTwoMessage is a message payload that carries the sender's
 // TSS round two components.
 type tssRoundTwoMessage struct {
@@ -72,6 +83,11 @@ func (trtm *tssRoundTwoMessage) Type() string {
 	return messageTypePrefix + "tss_round_two_message"
 }
 
+// QuotaScope returns the message quota scope of the sender's session.
+func (trtm *tssRoundTwoMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(trtm.sessionID, trtm.senderID)
+}
+
 // tssRoundThreeMessage is a message payload that carries the sender's
 // TSS round three components.
 type tssRoundThreeMessage struct {
@@ -92,6 +108,11 @@ func (trtm *tssRoundThreeMessage) Type() string {
 	return messageTypePrefix + "tss_round_three_message"
 }
 
+// QuotaScope returns the message quota scope of the sender's session.
+func (trtm *tssRoundThreeMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(trtm.sessionID, trtm.senderID)
+}
+
 // tssRoundFourMessage is a message payload that carries the sender's
 // TSS round four components.
 type tssRoundFourMessage struct {
@@ -112,6 +133,11 @@ func (trfm *tssRoundFourMessage) Type() string {
 	return messageTypePrefix + "tss_round_four_message"
 }
 
+// QuotaScope returns the message quota scope of the sender's session.
+func (trfm *tssRoundFourMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(trfm.sessionID, trfm.senderID)
+}
+
 // tssRoundFiveMessage is a message payload that carries the sender's
 // TSS round five components.
 type tssRoundFiveMessage struct {
@@ -132,6 +158,11 @@ func (trfm *tssRoundFiveMessage) Type() string {
 	return messageTypePrefix + "tss_round_five_message"
 }
 
+// QuotaScope returns the message quota scope of the sender's session.
+func (trfm *tssRoundFiveMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(trfm.sessionID, trfm.senderID)
+}
+
 // tssRoundSixMessage is a message payload that carries the sender's
 // TSS round six components.
 type tssRoundSixMessage struct {
@@ -152,6 +183,11 @@ func (trfm *tssRoundSixMessage) Type() string {
 	return messageTypePrefix + "tss_round_six_message"
 }
 
+// QuotaScope returns the message quota scope of the sender's session.
+func (trfm *tssRoundSixMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(trfm.sessionID, trfm.senderID)
+}
+
 // tssRoundSevenMessage is a message payload that carries the sender's
 // TSS round seven components.
 type tssRoundSevenMessage struct {
@@ -172,6 +208,11 @@ func (trfm *tssRoundSevenMessage) Type() string {
 	return messageTypePrefix + "tss_round_seven_message"
 }
 
+// QuotaScope returns the message quota scope of the sender's session.
+func (trfm *tssRoundSevenMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(trfm.sessionID, trfm.senderID)
+}
+
 // tssRoundEightMessage is a message payload that carries the sender's
 // TSS round eight components.
 type tssRoundEightMessage struct {
@@ -192,6 +233,11 @@ func (trem *tssRoundEightMessage) Type() string {
 	return messageTypePrefix + "tss_round_eight_message"
 }
 
+// QuotaScope returns the message quota scope of the sender's session.
+func (trem *tssRoundEightMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(trem.sessionID, trem.senderID)
+}
+
 // tssRoundNineMessage is a message payload that carries the sender's
 // TSS round nine components.
 type tssRoundNineMessage struct {
@@ -211,3 +257,8 @@ func (trnm *tssRoundNineMessage) SenderID() group.MemberIndex {
 func (trnm *tssRoundNineMessage) Type() string {
 	return messageTypePrefix + "tss_round_nine_message"
 }
+
+// QuotaScope returns the message quota scope of the sender's session.
+func (trnm *tssRoundNineMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(trnm.sessionID, trnm.senderID)
+}
@@ -36,7 +36,7 @@ func Execute(
 ) (*Result, error) {
 	logger.Debugf("[member:%v] initializing member", memberIndex)
 
-	registerUnmarshallers(channel)
+	registerUnmarshallers(channel, groupSize)
 
 	member := newMember(
 		logger,
@@ -77,10 +77,26 @@ func Execute(
 	return finalizationState.result(), nil
 }
 
+// Maximum sizes in bytes of payloads of signing protocol messages. Limits of
+// messages carrying data for every other group member grow with the size of
+// the group.
+const (
+	// maxBroadcastPayloadSize is the limit of the part of a message
+	// addressed to all group members, such as TSS commitments and
+	// decommitments along with their proofs.
+	maxBroadcastPayloadSize = 16 * 1024
+	// maxPeerPayloadSize is the limit of the part of a message addressed to
+	// a single group member. The biggest ones are encrypted MtA messages
+	// along with their range proofs exchanged in the first two TSS rounds.
+	maxPeerPayloadSize = 16 * 1024
+)
+
 // registerUnmarshallers initializes the given broadcast channel to be able to
 // perform signing protocol interactions by registering all the required
-// protocol message unmarshallers.
-func registerUnmarshallers(channel net.BroadcastChannel) {
+// protocol message unmarshallers. Channels supporting size limits are
+// configured to reject messages exceeding the size expected for a group of
+// the given size.
+func registerUnmarshallers(channel net.BroadcastChannel, groupSize int) {
 	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
 		return &ephemeralPublicKeyMessage{}
 	})
@@ -111,4 +127,42 @@ func registerUnmarshallers(channel net.BroadcastChannel) {
 	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
 		return &tssRoundNineMessage{}
 	})
+
+	// Every member sends a single message of every round in a protocol
+	// attempt. Further messages are rejected by channels supporting quotas.
+	if rateLimiter, ok := channel.(net.MessageRateLimiter); ok {
+		for _, message := range []net.TaggedUnmarshaler{
+			&ephemeralPublicKeyMessage{},
+			&tssRoundOneMessage{},
+			&tssRoundTwoMessage{},
+			&tssRoundThreeMessage{},
+			&tssRoundFourMessage{},
+			&tssRoundFiveMessage{},
+			&tssRoundSixMessage{},
+			&tssRoundSevenMessage{},
+			&tssRoundEightMessage{},
+			&tssRoundNineMessage{},
+		} {
+			rateLimiter.SetMessageQuota(message.Type(), 1)
+		}
+	}
+
+	if sizeLimiter, ok := channel.(net.MessageSizeLimiter); ok {
+		peersPayloadSize := groupSize * maxPeerPayloadSize
+
+		for message, maxSize := range map[net.TaggedUnmarshaler]int{
+			&ephemeralPublicKeyMessage{}: peersPayloadSize,
+			&tssRoundOneMessage{}:        maxBroadcastPayloadSize + peersPayloadSize,
+			&tssRoundTwoMessage{}:        peersPayloadSize,
+			&tssRoundThreeMessage{}:      maxBroadcastPayloadSize,
+			&tssRoundFourMessage{}:       maxBroadcastPayloadSize,
+			&tssRoundFiveMessage{}:       maxBroadcastPayloadSize,
+			&tssRoundSixMessage{}:        maxBroadcastPayloadSize,
+			&tssRoundSevenMessage{}:      maxBroadcastPayloadSize,
+			&tssRoundEightMessage{}:      maxBroadcastPayloadSize,
+			&tssRoundNineMessage{}:       maxBroadcastPayloadSize,
+		} {
+			sizeLimiter.SetMaxPayloadSize(message.Type(), maxSize)
+		}
+	}
 }
@@ -0,0 +1,102 @@
+package dkg
+
+import (
+	"fmt"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/state"
+)
+
+// Execute runs the FROST distributed key generation protocol, given a
+// broadcast channel to mediate with, a block counter used for time tracking,
+// a member index to use in the group, dishonest threshold, and block height
+// when DKG protocol should start.
+//
+// This function also supports DKG execution with a subset of the selected
+// group by passing a non-empty excludedMembers slice holding the members that
+// should be excluded.
+func Execute(
+	logger log.StandardLogger,
+	sessionID string,
+	startBlockNumber uint64,
+	memberIndex group.MemberIndex,
+	groupSize int,
+	dishonestThreshold int,
+	excludedMembersIndexes []group.MemberIndex,
+	blockCounter chain.BlockCounter,
+	channel net.BroadcastChannel,
+	membershipValidator *group.MembershipValidator,
+) (*Result, uint64, error) {
+	logger.Debugf("[member:%v] initializing member", memberIndex)
+
+	registerUnmarshallers(channel)
+
+	member := newMember(
+		logger,
+		memberIndex,
+		groupSize,
+		dishonestThreshold,
+		membershipValidator,
+		sessionID,
+	)
+
+	// Mark excluded members as disqualified in order to not exchange messages
+	// with them and have them recorded as misbehaving in the final result.
+	for _, excludedMemberIndex := range excludedMembersIndexes {
+		if excludedMemberIndex != member.id {
+			member.group.MarkMemberAsDisqualified(excludedMemberIndex)
+		}
+	}
+
+	initialState := &ephemeralKeyPairGenerationState{
+		channel: channel,
+		member:  member.initializeEphemeralKeysGeneration(),
+	}
+
+	stateMachine := state.NewMachine(logger, channel, blockCounter, initialState)
+
+	lastState, endBlockNumber, err := stateMachine.Execute(startBlockNumber)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	finalizationState, ok := lastState.(*finalizationState)
+	if !ok {
+		return nil, 0, fmt.Errorf("execution ended on state: %T", lastState)
+	}
+
+	return finalizationState.result(), endBlockNumber, nil
+}
+
+// registerUnmarshallers initializes the given broadcast channel to be able to
+// perform DKG protocol interactions by registering all the required protocol
+// message unmarshallers.
+func registerUnmarshallers(channel net.BroadcastChannel) {
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &ephemeralPublicKeyMessage{}
+	})
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &commitmentMessage{}
+	})
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &shareMessage{}
+	})
+
+	// The ephemeral key exchange and both FROST DKG rounds are broadcasts:
+	// shares for all receivers travel encrypted in one shareMessage. Each
+	// member therefore publishes exactly one message of every type within
+	// the session-scoped quota, see threshold.MessageQuotaScope.
+	if rateLimiter, ok := channel.(net.MessageRateLimiter); ok {
+		for _, message := range []net.TaggedUnmarshaler{
+			&ephemeralPublicKeyMessage{},
+			&commitmentMessage{},
+			&shareMessage{},
+		} {
+			rateLimiter.SetMessageQuota(message.Type(), 1)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package dkg
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/internal/interception"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/net"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+const (
+	groupSize          = 5
+	dishonestThreshold = 2
+)
+
+func TestExecute(t *testing.T) {
+	results, errs := runExecute(t, "tschnorr-dkg-test", noInterception)
+
+	for memberIndex, err := range errs {
+		if err != nil {
+			t.Fatalf("member [%v] failed: [%v]", memberIndex, err)
+		}
+	}
+
+	expectedPublicKey := results[1].PrivateKeyShare.PublicKey()
+	if !expectedPublicKey.HasEvenY() {
+		t.Errorf("group public key has an odd Y coordinate")
+	}
+
+	for memberIndex, result := range results {
+		privateKeyShare := result.PrivateKeyShare
+
+		if !privateKeyShare.PublicKey().Equal(expectedPublicKey) {
+			t.Errorf("member [%v] has a different public key", memberIndex)
+		}
+
+		for verifiedMemberIndex, verifiedResult := range results {
+			verificationShare := tschnorr.ScalarBaseMult(
+				verifiedResult.PrivateKeyShare.SecretShare(),
+			)
+
+			if !verificationShare.Equal(
+				privateKeyShare.VerificationShare(verifiedMemberIndex),
+			) {
+				t.Errorf(
+					"member [%v] has a wrong verification share of member [%v]",
+					memberIndex,
+					verifiedMemberIndex,
+				)
+			}
+		}
+
+		if len(result.MisbehavedMembersIndexes()) != 0 {
+			t.Errorf(
+				"member [%v] reported misbehaved members: [%v]",
+				memberIndex,
+				result.MisbehavedMembersIndexes(),
+			)
+		}
+	}
+}
+
+func TestExecute_InactiveMember(t *testing.T) {
+	inactiveMemberIndex := group.MemberIndex(5)
+
+	_, errs := runExecute(
+		t,
+		"tschnorr-dkg-inactive-test",
+		func(msg net.TaggedMarshaler) net.TaggedMarshaler {
+			if publicKeyMessage, ok := msg.(*ephemeralPublicKeyMessage); ok &&
+				publicKeyMessage.senderID == inactiveMemberIndex {
+				return nil
+			}
+			return msg
+		},
+	)
+
+	for memberIndex, err := range errs {
+		if memberIndex == inactiveMemberIndex {
+			continue
+		}
+
+		var inactiveMembersErr *InactiveMembersError
+		if !errors.As(err, &inactiveMembersErr) {
+			t.Fatalf(
+				"member [%v] returned unexpected error: [%v]",
+				memberIndex,
+				err,
+			)
+		}
+
+		expectedInactiveMembers := []group.MemberIndex{inactiveMemberIndex}
+		if !reflect.DeepEqual(
+			expectedInactiveMembers,
+			inactiveMembersErr.InactiveMembersIndexes,
+		) {
+			t.Errorf(
+				"unexpected inactive members\nexpected: %v\nactual:   %v\n",
+				expectedInactiveMembers,
+				inactiveMembersErr.InactiveMembersIndexes,
+			)
+		}
+	}
+}
+
+func noInterception(msg net.TaggedMarshaler) net.TaggedMarshaler {
+	return msg
+}
+
+func runExecute(
+	t *testing.T,
+	sessionID string,
+	rules interception.Rules,
+) (map[group.MemberIndex]*Result, map[group.MemberIndex]error) {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(
+		local_v1.DefaultCurve,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localChain := local_v1.ConnectWithKey(
+		groupSize,
+		groupSize-dishonestThreshold,
+		operatorPrivateKey,
+	)
+
+	address, err := localChain.Signing().PublicKeyToAddress(operatorPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operators := make(chain.Addresses, groupSize)
+	for i := range operators {
+		operators[i] = address
+	}
+
+	network := interception.NewNetwork(
+		netLocal.ConnectWithPrivateKey(operatorPrivateKey),
+		rules,
+	)
+
+	channel, err := network.BroadcastChannelFor(sessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	membershipValidator := group.NewMembershipValidator(
+		&testutils.MockLogger{},
+		operators,
+		localChain.Signing(),
+	)
+
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for 3 blocks before starting DKG to make sure all members are up.
+	startBlock := currentBlock + 3
+
+	var mutex sync.Mutex
+	results := make(map[group.MemberIndex]*Result)
+	errs := make(map[group.MemberIndex]error)
+
+	var wg sync.WaitGroup
+	wg.Add(groupSize)
+
+	for i := 1; i <= groupSize; i++ {
+		memberIndex := group.MemberIndex(i)
+
+		go func() {
+			defer wg.Done()
+
+			result, _, err := Execute(
+				&testutils.MockLogger{},
+				sessionID,
+				startBlock,
+				memberIndex,
+				groupSize,
+				dishonestThreshold,
+				nil,
+				blockCounter,
+				channel,
+				membershipValidator,
+			)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if err != nil {
+				errs[memberIndex] = fmt.Errorf("execution failed: [%w]", err)
+				return
+			}
+
+			results[memberIndex] = result
+		}()
+	}
+
+	wg.Wait()
+
+	return results, errs
+}
@@ -0,0 +1,373 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: pkg/tschnorr/dkg/gen/pb/message.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EphemeralPublicKeyMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SenderID            uint32            `protobuf:"varint,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	EphemeralPublicKeys map[uint32][]byte `protobuf:"bytes,2,rep,name=ephemeralPublicKeys,proto3" json:"ephemeralPublicKeys,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SessionID           string            `protobuf:"bytes,3,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+}
+
+func (x *EphemeralPublicKeyMessage) Reset() {
+	*x = EphemeralPublicKeyMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tschnorr_dkg_gen_pb_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EphemeralPublicKeyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EphemeralPublicKeyMessage) ProtoMessage() {}
+
+func (x *EphemeralPublicKeyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tschnorr_dkg_gen_pb_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EphemeralPublicKeyMessage.ProtoReflect.Descriptor instead.
+func (*EphemeralPublicKeyMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EphemeralPublicKeyMessage) GetSenderID() uint32 {
+	if x != nil {
+		return x.SenderID
+	}
+	return 0
+}
+
+func (x *EphemeralPublicKeyMessage) GetEphemeralPublicKeys() map[uint32][]byte {
+	if x != nil {
+		return x.EphemeralPublicKeys
+	}
+	return nil
+}
+
+func (x *EphemeralPublicKeyMessage) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+type CommitmentMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SenderID        uint32   `protobuf:"varint,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	Commitments     [][]byte `protobuf:"bytes,2,rep,name=commitments,proto3" json:"commitments,omitempty"`
+	ProofNoncePoint []byte   `protobuf:"bytes,3,opt,name=proofNoncePoint,proto3" json:"proofNoncePoint,omitempty"`
+	ProofResponse   []byte   `protobuf:"bytes,4,opt,name=proofResponse,proto3" json:"proofResponse,omitempty"`
+	SessionID       string   `protobuf:"bytes,5,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+}
+
+func (x *CommitmentMessage) Reset() {
+	*x = CommitmentMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tschnorr_dkg_gen_pb_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitmentMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitmentMessage) ProtoMessage() {}
+
+func (x *CommitmentMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tschnorr_dkg_gen_pb_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitmentMessage.ProtoReflect.Descriptor instead.
+func (*CommitmentMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CommitmentMessage) GetSenderID() uint32 {
+	if x != nil {
+		return x.SenderID
+	}
+	return 0
+}
+
+func (x *CommitmentMessage) GetCommitments() [][]byte {
+	if x != nil {
+		return x.Commitments
+	}
+	return nil
+}
+
+func (x *CommitmentMessage) GetProofNoncePoint() []byte {
+	if x != nil {
+		return x.ProofNoncePoint
+	}
+	return nil
+}
+
+func (x *CommitmentMessage) GetProofResponse() []byte {
+	if x != nil {
+		return x.ProofResponse
+	}
+	return nil
+}
+
+func (x *CommitmentMessage) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+type ShareMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SenderID        uint32            `protobuf:"varint,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	EncryptedShares map[uint32][]byte `protobuf:"bytes,2,rep,name=encryptedShares,proto3" json:"encryptedShares,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SessionID       string            `protobuf:"bytes,3,opt,name=sessionID,proto3" json:"sessionID,omitempty"`
+}
+
+func (x *ShareMessage) Reset() {
+	*x = ShareMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tschnorr_dkg_gen_pb_message_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShareMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareMessage) ProtoMessage() {}
+
+func (x *ShareMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tschnorr_dkg_gen_pb_message_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareMessage.ProtoReflect.Descriptor instead.
+func (*ShareMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ShareMessage) GetSenderID() uint32 {
+	if x != nil {
+		return x.SenderID
+	}
+	return 0
+}
+
+func (x *ShareMessage) GetEncryptedShares() map[uint32][]byte {
+	if x != nil {
+		return x.EncryptedShares
+	}
+	return nil
+}
+
+func (x *ShareMessage) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+var File_pkg_tschnorr_dkg_gen_pb_message_proto protoreflect.FileDescriptor
+
+var file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x70, 0x6b, 0x67, 0x2f, 0x74, 0x73, 0x63, 0x68, 0x6e, 0x6f, 0x72, 0x72, 0x2f, 0x64,
+	0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x74, 0x73, 0x63, 0x68, 0x6e, 0x6f, 0x72,
+	0x72, 0x2e, 0x64, 0x6b, 0x67, 0x22, 0x91, 0x02, 0x0a, 0x19, 0x45, 0x70, 0x68, 0x65, 0x6d, 0x65,
+	0x72, 0x61, 0x6c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x12,
+	0x72, 0x0a, 0x13, 0x65, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x40, 0x2e, 0x74,
+	0x73, 0x63, 0x68, 0x6e, 0x6f, 0x72, 0x72, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x45, 0x70, 0x68, 0x65,
+	0x6d, 0x65, 0x72, 0x61, 0x6c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x45, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13,
+	0x65, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b,
+	0x65, 0x79, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x1a, 0x46, 0x0a, 0x18, 0x45, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xbf, 0x01, 0x0a, 0x11, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a,
+	0x0f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x4e, 0x6f, 0x6e,
+	0x63, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0xe7, 0x01, 0x0a, 0x0c,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x12, 0x59, 0x0a, 0x0f, 0x65, 0x6e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x65, 0x64, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2f, 0x2e, 0x74, 0x73, 0x63, 0x68, 0x6e, 0x6f, 0x72, 0x72, 0x2e, 0x64, 0x6b, 0x67,
+	0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x45, 0x6e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0f, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x1a, 0x42, 0x0a, 0x14, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDescOnce sync.Once
+	file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDescData = file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDesc
+)
+
+func file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDescGZIP() []byte {
+	file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDescOnce.Do(func() {
+		file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDescData)
+	})
+	return file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDescData
+}
+
+var file_pkg_tschnorr_dkg_gen_pb_message_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_pkg_tschnorr_dkg_gen_pb_message_proto_goTypes = []interface{}{
+	(*EphemeralPublicKeyMessage)(nil), // 0: tschnorr.dkg.EphemeralPublicKeyMessage
+	(*CommitmentMessage)(nil),         // 1: tschnorr.dkg.CommitmentMessage
+	(*ShareMessage)(nil),              // 2: tschnorr.dkg.ShareMessage
+	nil,                               // 3: tschnorr.dkg.EphemeralPublicKeyMessage.EphemeralPublicKeysEntry
+	nil,                               // 4: tschnorr.dkg.ShareMessage.EncryptedSharesEntry
+}
+var file_pkg_tschnorr_dkg_gen_pb_message_proto_depIdxs = []int32{
+	3, // 0: tschnorr.dkg.EphemeralPublicKeyMessage.ephemeralPublicKeys:type_name -> tschnorr.dkg.EphemeralPublicKeyMessage.EphemeralPublicKeysEntry
+	4, // 1: tschnorr.dkg.ShareMessage.encryptedShares:type_name -> tschnorr.dkg.ShareMessage.EncryptedSharesEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_pkg_tschnorr_dkg_gen_pb_message_proto_init() }
+func file_pkg_tschnorr_dkg_gen_pb_message_proto_init() {
+	if File_pkg_tschnorr_dkg_gen_pb_message_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_tschnorr_dkg_gen_pb_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EphemeralPublicKeyMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_tschnorr_dkg_gen_pb_message_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitmentMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_tschnorr_dkg_gen_pb_message_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShareMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_tschnorr_dkg_gen_pb_message_proto_goTypes,
+		DependencyIndexes: file_pkg_tschnorr_dkg_gen_pb_message_proto_depIdxs,
+		MessageInfos:      file_pkg_tschnorr_dkg_gen_pb_message_proto_msgTypes,
+	}.Build()
+	File_pkg_tschnorr_dkg_gen_pb_message_proto = out.File
+	file_pkg_tschnorr_dkg_gen_pb_message_proto_rawDesc = nil
+	file_pkg_tschnorr_dkg_gen_pb_message_proto_goTypes = nil
+	file_pkg_tschnorr_dkg_gen_pb_message_proto_depIdxs = nil
+}
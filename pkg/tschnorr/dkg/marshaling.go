@@ -0,0 +1,214 @@
+package dkg
+
+import (
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+	"github.com/keep-network/keep-core/pkg/tschnorr/dkg/gen/pb"
+)
+
+// Marshal converts this ephemeralPublicKeyMessage to a byte array suitable for
+// network communication.
+func (epkm *ephemeralPublicKeyMessage) Marshal() ([]byte, error) {
+	ephemeralPublicKeys, err := marshalPublicKeyMap(epkm.ephemeralPublicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(&pb.EphemeralPublicKeyMessage{
+		SenderID:            uint32(epkm.senderID),
+		EphemeralPublicKeys: ephemeralPublicKeys,
+		SessionID:           epkm.sessionID,
+	})
+}
+
+// Unmarshal converts a byte array produced by Marshal to
+// an ephemeralPublicKeyMessage
+func (epkm *ephemeralPublicKeyMessage) Unmarshal(bytes []byte) error {
+	pbMsg := pb.EphemeralPublicKeyMessage{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return err
+	}
+
+	if err := validateMemberIndex(pbMsg.SenderID); err != nil {
+		return err
+	}
+	epkm.senderID = group.MemberIndex(pbMsg.SenderID)
+
+	ephemeralPublicKeys, err := unmarshalPublicKeyMap(pbMsg.EphemeralPublicKeys)
+	if err != nil {
+		return err
+	}
+
+	epkm.ephemeralPublicKeys = ephemeralPublicKeys
+	epkm.sessionID = pbMsg.SessionID
+
+	return nil
+}
+
+// Marshal converts this commitmentMessage to a byte array suitable for
+// network communication.
+func (cm *commitmentMessage) Marshal() ([]byte, error) {
+	commitments := make([][]byte, len(cm.commitments))
+	for i, commitment := range cm.commitments {
+		commitmentBytes, err := commitment.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot marshal commitment [%v]: [%v]",
+				i,
+				err,
+			)
+		}
+		commitments[i] = commitmentBytes
+	}
+
+	if cm.proof == nil {
+		return nil, fmt.Errorf("nil proof of knowledge")
+	}
+
+	proofNoncePoint, err := cm.proof.noncePoint.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal proof nonce point: [%v]", err)
+	}
+
+	return proto.Marshal(&pb.CommitmentMessage{
+		SenderID:        uint32(cm.senderID),
+		Commitments:     commitments,
+		ProofNoncePoint: proofNoncePoint,
+		ProofResponse:   cm.proof.response.Bytes(),
+		SessionID:       cm.sessionID,
+	})
+}
+
+// Unmarshal converts a byte array produced by Marshal to a commitmentMessage.
+func (cm *commitmentMessage) Unmarshal(bytes []byte) error {
+	pbMsg := pb.CommitmentMessage{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return err
+	}
+
+	if err := validateMemberIndex(pbMsg.SenderID); err != nil {
+		return err
+	}
+
+	commitments := make([]*tschnorr.Point, len(pbMsg.Commitments))
+	for i, commitmentBytes := range pbMsg.Commitments {
+		commitment, err := tschnorr.UnmarshalPoint(commitmentBytes)
+		if err != nil {
+			return fmt.Errorf(
+				"cannot unmarshal commitment [%v]: [%v]",
+				i,
+				err,
+			)
+		}
+		commitments[i] = commitment
+	}
+
+	proofNoncePoint, err := tschnorr.UnmarshalPoint(pbMsg.ProofNoncePoint)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal proof nonce point: [%v]", err)
+	}
+
+	cm.senderID = group.MemberIndex(pbMsg.SenderID)
+	cm.commitments = commitments
+	cm.proof = &knowledgeProof{
+		noncePoint: proofNoncePoint,
+		response:   new(big.Int).SetBytes(pbMsg.ProofResponse),
+	}
+	cm.sessionID = pbMsg.SessionID
+
+	return nil
+}
+
+// Marshal converts this shareMessage to a byte array suitable for network
+// communication.
+func (sm *shareMessage) Marshal() ([]byte, error) {
+	encryptedShares := make(map[uint32][]byte, len(sm.encryptedShares))
+	for receiverID, encryptedShare := range sm.encryptedShares {
+		encryptedShares[uint32(receiverID)] = encryptedShare
+	}
+
+	return proto.Marshal(&pb.ShareMessage{
+		SenderID:        uint32(sm.senderID),
+		EncryptedShares: encryptedShares,
+		SessionID:       sm.sessionID,
+	})
+}
+
+// Unmarshal converts a byte array produced by Marshal to a shareMessage.
+func (sm *shareMessage) Unmarshal(bytes []byte) error {
+	pbMsg := pb.ShareMessage{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return err
+	}
+
+	if err := validateMemberIndex(pbMsg.SenderID); err != nil {
+		return err
+	}
+
+	encryptedShares := make(
+		map[group.MemberIndex][]byte,
+		len(pbMsg.EncryptedShares),
+	)
+	for receiverID, encryptedShare := range pbMsg.EncryptedShares {
+		if err := validateMemberIndex(receiverID); err != nil {
+			return err
+		}
+
+		encryptedShares[group.MemberIndex(receiverID)] = encryptedShare
+	}
+
+	sm.senderID = group.MemberIndex(pbMsg.SenderID)
+	sm.encryptedShares = encryptedShares
+	sm.sessionID = pbMsg.SessionID
+
+	return nil
+}
+
+func validateMemberIndex(protoIndex uint32) error {
+	// Protobuf does not have uint8 type, so we are using uint32. When
+	// unmarshalling message, we need to make sure we do not overflow.
+	if protoIndex > group.MaxMemberIndex {
+		return fmt.Errorf("invalid member index value: [%v]", protoIndex)
+	}
+	return nil
+}
+
+func marshalPublicKeyMap(
+	publicKeys map[group.MemberIndex]*ephemeral.PublicKey,
+) (map[uint32][]byte, error) {
+	marshalled := make(map[uint32][]byte, len(publicKeys))
+	for id, publicKey := range publicKeys {
+		if publicKey == nil {
+			return nil, fmt.Errorf("nil public key for member [%v]", id)
+		}
+
+		marshalled[uint32(id)] = publicKey.Marshal()
+	}
+	return marshalled, nil
+}
+
+func unmarshalPublicKeyMap(
+	publicKeys map[uint32][]byte,
+) (map[group.MemberIndex]*ephemeral.PublicKey, error) {
+	var unmarshalled = make(map[group.MemberIndex]*ephemeral.PublicKey, len(publicKeys))
+	for memberID, publicKeyBytes := range publicKeys {
+		if err := validateMemberIndex(memberID); err != nil {
+			return nil, err
+		}
+
+		publicKey, err := ephemeral.UnmarshalPublicKey(publicKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("could not unmarshal public key [%v]", err)
+		}
+
+		unmarshalled[group.MemberIndex(memberID)] = publicKey
+	}
+
+	return unmarshalled, nil
+}
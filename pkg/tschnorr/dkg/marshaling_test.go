@@ -0,0 +1,194 @@
+package dkg
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+
+	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
+	"github.com/keep-network/keep-core/pkg/internal/pbutils"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+func TestEphemeralPublicKeyMessage_MarshalingRoundtrip(t *testing.T) {
+	keyPair1, err := ephemeral.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPair2, err := ephemeral.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKeys := make(map[group.MemberIndex]*ephemeral.PublicKey)
+	publicKeys[group.MemberIndex(211)] = keyPair1.PublicKey
+	publicKeys[group.MemberIndex(19)] = keyPair2.PublicKey
+
+	msg := &ephemeralPublicKeyMessage{
+		senderID:            group.MemberIndex(38),
+		ephemeralPublicKeys: publicKeys,
+		sessionID:           "session-1",
+	}
+	unmarshaled := &ephemeralPublicKeyMessage{}
+
+	err = pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzEphemeralPublicKeyMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID            group.MemberIndex
+			ephemeralPublicKeys map[group.MemberIndex]*ephemeral.PublicKey
+			sessionID           string
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&ephemeralPublicKeys)
+		f.Fuzz(&sessionID)
+
+		message := &ephemeralPublicKeyMessage{
+			senderID:            senderID,
+			ephemeralPublicKeys: ephemeralPublicKeys,
+			sessionID:           sessionID,
+		}
+
+		_ = pbutils.RoundTrip(message, &ephemeralPublicKeyMessage{})
+	}
+}
+
+func TestFuzzEphemeralPublicKeyMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&ephemeralPublicKeyMessage{})
+}
+
+func TestCommitmentMessage_MarshalingRoundtrip(t *testing.T) {
+	msg := &commitmentMessage{
+		senderID: group.MemberIndex(50),
+		commitments: []*tschnorr.Point{
+			tschnorr.ScalarBaseMult(big.NewInt(11)),
+			tschnorr.ScalarBaseMult(big.NewInt(12)),
+			tschnorr.ScalarBaseMult(big.NewInt(13)),
+		},
+		proof: &knowledgeProof{
+			noncePoint: tschnorr.ScalarBaseMult(big.NewInt(14)),
+			response:   big.NewInt(15),
+		},
+		sessionID: "session-1",
+	}
+	unmarshaled := &commitmentMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzCommitmentMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID      group.MemberIndex
+			scalars       []*big.Int
+			proofResponse *big.Int
+			sessionID     string
+		)
+
+		f := fuzz.New().NilChance(0).
+			NumElements(1, 64).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&scalars)
+		f.Fuzz(&proofResponse)
+		f.Fuzz(&sessionID)
+
+		commitments := make([]*tschnorr.Point, len(scalars))
+		for i, scalar := range scalars {
+			commitments[i] = tschnorr.ScalarBaseMult(scalar)
+		}
+
+		message := &commitmentMessage{
+			senderID:    senderID,
+			commitments: commitments,
+			proof: &knowledgeProof{
+				noncePoint: tschnorr.ScalarBaseMult(scalars[0]),
+				response:   proofResponse,
+			},
+			sessionID: sessionID,
+		}
+
+		_ = pbutils.RoundTrip(message, &commitmentMessage{})
+	}
+}
+
+func TestFuzzCommitmentMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&commitmentMessage{})
+}
+
+func TestShareMessage_MarshalingRoundtrip(t *testing.T) {
+	msg := &shareMessage{
+		senderID: group.MemberIndex(50),
+		encryptedShares: map[group.MemberIndex][]byte{
+			1: {1, 2, 3},
+			2: {4, 5, 6},
+		},
+		sessionID: "session-1",
+	}
+	unmarshaled := &shareMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzShareMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID        group.MemberIndex
+			encryptedShares map[group.MemberIndex][]byte
+			sessionID       string
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&encryptedShares)
+		f.Fuzz(&sessionID)
+
+		message := &shareMessage{
+			senderID:        senderID,
+			encryptedShares: encryptedShares,
+			sessionID:       sessionID,
+		}
+
+		_ = pbutils.RoundTrip(message, &shareMessage{})
+	}
+}
+
+func TestFuzzShareMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&shareMessage{})
+}
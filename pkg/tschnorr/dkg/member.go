@@ -0,0 +1,216 @@
+package dkg
+
+import (
+	"math/big"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+// Member represents a DKG protocol member.
+type member struct {
+	// Logger used to produce log messages.
+	logger log.StandardLogger
+	// id of this group member.
+	id group.MemberIndex
+	// Group to which this member belongs.
+	group *group.Group
+	// Validator allowing to check public key and member index against
+	// group members.
+	membershipValidator *group.MembershipValidator
+	// Identifier of the particular DKG session this member is part of.
+	sessionID string
+}
+
+// newMember creates a new member in an initial state
+func newMember(
+	logger log.StandardLogger,
+	memberID group.MemberIndex,
+	groupSize,
+	dishonestThreshold int,
+	membershipValidator *group.MembershipValidator,
+	sessionID string,
+) *member {
+	return &member{
+		logger:              logger,
+		id:                  memberID,
+		group:               group.NewGroup(dishonestThreshold, groupSize),
+		membershipValidator: membershipValidator,
+		sessionID:           sessionID,
+	}
+}
+
+// inactiveMemberFilter returns a new instance of the inactive member filter.
+func (m *member) inactiveMemberFilter() *group.InactiveMemberFilter {
+	return group.NewInactiveMemberFilter(m.logger, m.id, m.group)
+}
+
+// shouldAcceptMessage indicates whether the given member should accept
+// a message from the given sender.
+func (m *member) shouldAcceptMessage(
+	senderID group.MemberIndex,
+	senderPublicKey []byte,
+) bool {
+	isMessageFromSelf := senderID == m.id
+	isSenderValid := m.membershipValidator.IsValidMembership(
+		senderID,
+		senderPublicKey,
+	)
+	isSenderAccepted := m.group.IsOperating(senderID)
+
+	return !isMessageFromSelf && isSenderValid && isSenderAccepted
+}
+
+// initializeEphemeralKeysGeneration performs a transition of a member state
+// from the initial state to the first phase of the protocol.
+func (m *member) initializeEphemeralKeysGeneration() *ephemeralKeyPairGeneratingMember {
+	return &ephemeralKeyPairGeneratingMember{
+		member:            m,
+		ephemeralKeyPairs: make(map[group.MemberIndex]*ephemeral.KeyPair),
+	}
+}
+
+// ephemeralKeyPairGeneratingMember represents one member in a distributed key
+// generating group performing ephemeral key pair generation. It has a full list
+// of `memberIDs` that belong to its threshold group.
+type ephemeralKeyPairGeneratingMember struct {
+	*member
+
+	// Ephemeral key pairs used to create symmetric keys,
+	// generated individually for each other group member.
+	ephemeralKeyPairs map[group.MemberIndex]*ephemeral.KeyPair
+}
+
+// initializeSymmetricKeyGeneration performs a transition of the member state
+// to the next phase. It returns a member instance ready to execute the
+// next phase of the protocol.
+func (ekpgm *ephemeralKeyPairGeneratingMember) initializeSymmetricKeyGeneration() *symmetricKeyGeneratingMember {
+	return &symmetricKeyGeneratingMember{
+		ephemeralKeyPairGeneratingMember: ekpgm,
+		symmetricKeys:                    make(map[group.MemberIndex]ephemeral.SymmetricKey),
+	}
+}
+
+// symmetricKeyGeneratingMember represents one member in a distributed key
+// generating group performing ephemeral symmetric key generation.
+type symmetricKeyGeneratingMember struct {
+	*ephemeralKeyPairGeneratingMember
+
+	// Symmetric keys used to encrypt confidential information,
+	// generated individually for each other group member by ECDH'ing the
+	// broadcasted ephemeral public key intended for this member and the
+	// ephemeral private key generated for the other member.
+	symmetricKeys map[group.MemberIndex]ephemeral.SymmetricKey
+}
+
+// markInactiveMembers takes all messages from the previous DKG protocol
+// execution phase and marks all member who did not send a message as IA.
+func (skgm *symmetricKeyGeneratingMember) markInactiveMembers(
+	ephemeralPubKeyMessages []*ephemeralPublicKeyMessage,
+) {
+	markInactiveMembers(skgm.inactiveMemberFilter(), ephemeralPubKeyMessages)
+}
+
+// initializeCommitment performs a transition of the member state to the
+// next phase. It returns a member instance ready to execute the next phase
+// of the protocol.
+func (skgm *symmetricKeyGeneratingMember) initializeCommitment() *committingMember {
+	return &committingMember{
+		symmetricKeyGeneratingMember: skgm,
+	}
+}
+
+// committingMember represents one member in a distributed key generating
+// group performing the first round of the FROST DKG. The member generates
+// its secret polynomial and broadcasts commitments to its coefficients.
+type committingMember struct {
+	*symmetricKeyGeneratingMember
+
+	// Coefficients of the secret polynomial of degree t-1, where t is
+	// the honest threshold of the group. The constant term is the member's
+	// contribution to the group private key.
+	coefficients []*big.Int
+	// Commitments to the coefficients of the secret polynomial.
+	commitments []*tschnorr.Point
+}
+
+// initializeSharing performs a transition of the member state to the next
+// phase. It returns a member instance ready to execute the next phase of
+// the protocol.
+func (cm *committingMember) initializeSharing() *sharingMember {
+	return &sharingMember{
+		committingMember:    cm,
+		receivedCommitments: make(map[group.MemberIndex][]*tschnorr.Point),
+	}
+}
+
+// sharingMember represents one member in a distributed key generating
+// group performing the second round of the FROST DKG. The member validates
+// commitments received from other members and distributes secret shares
+// to them.
+type sharingMember struct {
+	*committingMember
+
+	// Commitments to coefficients of secret polynomials of all operating
+	// members, including the member itself.
+	receivedCommitments map[group.MemberIndex][]*tschnorr.Point
+}
+
+// markInactiveMembers takes all messages from the previous DKG protocol
+// execution phase and marks all member who did not send a message as IA.
+func (sm *sharingMember) markInactiveMembers(
+	commitmentMessages []*commitmentMessage,
+) {
+	markInactiveMembers(sm.inactiveMemberFilter(), commitmentMessages)
+}
+
+// initializeFinalization performs a transition of the member state to the
+// last phase of the protocol.
+func (sm *sharingMember) initializeFinalization() *finalizingMember {
+	return &finalizingMember{
+		sharingMember: sm,
+	}
+}
+
+// finalizingMember represents one member of the given group, after it
+// completed the distributed key generation process.
+//
+// Prepares a result to publish in the last phase of the protocol.
+type finalizingMember struct {
+	*sharingMember
+
+	privateKeyShare *tschnorr.PrivateKeyShare
+}
+
+// markInactiveMembers takes all messages from the previous DKG protocol
+// execution phase and marks all member who did not send a message as IA.
+func (fm *finalizingMember) markInactiveMembers(
+	shareMessages []*shareMessage,
+) {
+	markInactiveMembers(fm.inactiveMemberFilter(), shareMessages)
+}
+
+// Result can be either the successful computation of the distributed key
+// generation process or a notification of failure.
+func (fm *finalizingMember) Result() *Result {
+	return &Result{
+		Group:           fm.group,
+		PrivateKeyShare: fm.privateKeyShare,
+	}
+}
+
+// markInactiveMembers marks all members who did not send any of the given
+// messages as inactive using the given filter.
+func markInactiveMembers[T interface{ SenderID() group.MemberIndex }](
+	filter *group.InactiveMemberFilter,
+	messages []T,
+) {
+	for _, message := range messages {
+		filter.MarkMemberAsActive(message.SenderID())
+	}
+
+	filter.FlushInactiveMembers()
+}
@@ -0,0 +1,94 @@
+package dkg
+
+import (
+	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/threshold"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+const messageTypePrefix = "tschnorr_dkg/"
+
+// ephemeralPublicKeyMessage is a message payload that carries the sender's
+// ephemeral public keys generated for all other group members.
+//
+// The receiver performs ECDH on a sender's ephemeral public key intended for
+// the receiver and on the receiver's private ephemeral key, creating a symmetric
+// key used for encrypting a conversation between the sender and the receiver.
+type ephemeralPublicKeyMessage struct {
+	senderID group.MemberIndex
+
+	ephemeralPublicKeys map[group.MemberIndex]*ephemeral.PublicKey
+	sessionID           string
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (epkm *ephemeralPublicKeyMessage) SenderID() group.MemberIndex {
+	return epkm.senderID
+}
+
+// Type returns a string describing an ephemeralPublicKeyMessage type for
+// marshaling purposes.
+func (epkm *ephemeralPublicKeyMessage) Type() string {
+	return messageTypePrefix + "ephemeral_public_key_message"
+}
+
+// QuotaScope returns the message quota scope of the sender's session.
+func (epkm *ephemeralPublicKeyMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(epkm.sessionID, epkm.senderID)
+}
+
+// commitmentMessage is a message payload that carries the sender's
+// commitments to the coefficients of its secret polynomial along with
+// the proof of knowledge of the secret polynomial constant term. This is
+// the message of the first round of the FROST DKG.
+type commitmentMessage struct {
+	senderID group.MemberIndex
+
+	commitments []*tschnorr.Point
+	proof       *knowledgeProof
+	sessionID   string
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (cm *commitmentMessage) SenderID() group.MemberIndex {
+	return cm.senderID
+}
+
+// Type returns a string describing a commitmentMessage type for
+// marshaling purposes.
+func (cm *commitmentMessage) Type() string {
+	return messageTypePrefix + "commitment_message"
+}
+
+// QuotaScope returns the message quota scope of the sender's session.
+func (cm *commitmentMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(cm.sessionID, cm.senderID)
+}
+
+// shareMessage is a message payload that carries the sender's secret
+// shares for all other group members. Each share is encrypted with the
+// symmetric key established with the receiver. This is the message of the
+// second round of the FROST DKG.
+type shareMessage struct {
+	senderID group.MemberIndex
+
+	encryptedShares map[group.MemberIndex][]byte
+	sessionID       string
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (sm *shareMessage) SenderID() group.MemberIndex {
+	return sm.senderID
+}
+
+// Type returns a string describing a shareMessage type for marshaling
+// purposes.
+func (sm *shareMessage) Type() string {
+	return messageTypePrefix + "share_message"
+}
+
+// QuotaScope returns the message quota scope of the sender's session.
+func (sm *shareMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(sm.sessionID, sm.senderID)
+}
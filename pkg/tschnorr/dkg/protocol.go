@@ -0,0 +1,547 @@
+package dkg
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/keep-network/keep-core/pkg/crypto/ephemeral"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+// knowledgeProofTag is the tag of the hash used to compute the challenge of
+// the proof of knowledge of the secret polynomial constant term.
+const knowledgeProofTag = "FROST/DKG/PoK"
+
+// shareLength is the length of a serialized secret share.
+const shareLength = 32
+
+// knowledgeProof is a Schnorr proof of knowledge of the constant term of
+// the secret polynomial. The proof binds the commitments of a member to
+// the member and the DKG session and protects against rogue key attacks.
+type knowledgeProof struct {
+	noncePoint *tschnorr.Point
+	response   *big.Int
+}
+
+// generateEphemeralKeyPair takes the group member list and generates an
+// ephemeral ECDH keypair for every other group member. Generated public
+// ephemeral keys are broadcasted within the group.
+func (ekpgm *ephemeralKeyPairGeneratingMember) generateEphemeralKeyPair() (
+	*ephemeralPublicKeyMessage,
+	error,
+) {
+	ephemeralKeys := make(map[group.MemberIndex]*ephemeral.PublicKey)
+
+	// Calculate ephemeral key pair for every other group member
+	for _, member := range ekpgm.group.MemberIDs() {
+		if member == ekpgm.id {
+			// don’t actually generate a key with ourselves
+			continue
+		}
+
+		ephemeralKeyPair, err := ephemeral.GenerateKeyPair()
+		if err != nil {
+			return nil, err
+		}
+
+		// save the generated ephemeral key to our state
+		ekpgm.ephemeralKeyPairs[member] = ephemeralKeyPair
+
+		// store the public key to the map for the message
+		ephemeralKeys[member] = ephemeralKeyPair.PublicKey
+	}
+
+	return &ephemeralPublicKeyMessage{
+		senderID:            ekpgm.id,
+		ephemeralPublicKeys: ephemeralKeys,
+		sessionID:           ekpgm.sessionID,
+	}, nil
+}
+
+// generateSymmetricKeys attempts to generate symmetric keys for all remote group
+// members via ECDH. It generates this symmetric key for each remote group member
+// by doing an ECDH between the ephemeral private key generated for a remote
+// group member, and the public key for this member, generated and broadcasted by
+// the remote group member.
+func (skgm *symmetricKeyGeneratingMember) generateSymmetricKeys(
+	ephemeralPubKeyMessages []*ephemeralPublicKeyMessage,
+) error {
+	for _, ephemeralPubKeyMessage := range deduplicateBySender(ephemeralPubKeyMessages) {
+		otherMember := ephemeralPubKeyMessage.senderID
+
+		if !skgm.isValidEphemeralPublicKeyMessage(ephemeralPubKeyMessage) {
+			return fmt.Errorf(
+				"member [%v] sent invalid ephemeral public key message",
+				otherMember,
+			)
+		}
+
+		// Find the ephemeral key pair generated by this group member for
+		// the other group member.
+		ephemeralKeyPair, ok := skgm.ephemeralKeyPairs[otherMember]
+		if !ok {
+			return fmt.Errorf(
+				"ephemeral key pair does not exist for member [%v]",
+				otherMember,
+			)
+		}
+
+		// Create symmetric key for the current group member and the other
+		// group member by ECDH'ing the ephemeral private key generated by
+		// this member for the other member and the ephemeral public key
+		// broadcasted by the other member for this member.
+		symmetricKey := ephemeralKeyPair.PrivateKey.Ecdh(
+			ephemeralPubKeyMessage.ephemeralPublicKeys[skgm.id],
+		)
+		skgm.symmetricKeys[otherMember] = symmetricKey
+	}
+
+	return nil
+}
+
+// isValidEphemeralPublicKeyMessage validates a given EphemeralPublicKeyMessage.
+// Message is considered valid if it contains ephemeral public keys for
+// all other group members.
+func (skgm *symmetricKeyGeneratingMember) isValidEphemeralPublicKeyMessage(
+	message *ephemeralPublicKeyMessage,
+) bool {
+	for _, memberID := range skgm.group.MemberIDs() {
+		if memberID == message.senderID {
+			// Message contains ephemeral public keys only for other group members
+			continue
+		}
+
+		if _, ok := message.ephemeralPublicKeys[memberID]; !ok {
+			skgm.logger.Warnf(
+				"[member:%v] ephemeral public key message from member [%v] "+
+					"does not contain public key for member [%v]",
+				skgm.id,
+				message.senderID,
+				memberID,
+			)
+			return false
+		}
+	}
+
+	return true
+}
+
+// generateCommitments generates the secret polynomial of the member and
+// commits to its coefficients. The commitments are broadcasted within the
+// group along with the proof of knowledge of the polynomial constant term.
+func (cm *committingMember) generateCommitments() (*commitmentMessage, error) {
+	// The polynomial has degree t-1 so any t shares can be used to
+	// reconstruct the secret, where t is the honest threshold.
+	coefficientsCount := cm.group.HonestThreshold()
+
+	cm.coefficients = make([]*big.Int, coefficientsCount)
+	cm.commitments = make([]*tschnorr.Point, coefficientsCount)
+	for i := range cm.coefficients {
+		coefficient, err := tschnorr.RandomScalar()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot generate polynomial coefficient: [%v]",
+				err,
+			)
+		}
+
+		cm.coefficients[i] = coefficient
+		cm.commitments[i] = tschnorr.ScalarBaseMult(coefficient)
+	}
+
+	nonce, err := tschnorr.RandomScalar()
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate proof nonce: [%v]", err)
+	}
+
+	noncePoint := tschnorr.ScalarBaseMult(nonce)
+
+	challenge, err := knowledgeProofChallenge(
+		cm.id,
+		cm.sessionID,
+		cm.commitments[0],
+		noncePoint,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// response = nonce + secret * challenge
+	response := new(big.Int).Mul(cm.coefficients[0], challenge)
+	response.Add(response, nonce)
+	response.Mod(response, tschnorr.Curve.Params().N)
+
+	return &commitmentMessage{
+		senderID:    cm.id,
+		commitments: cm.commitments,
+		proof: &knowledgeProof{
+			noncePoint: noncePoint,
+			response:   response,
+		},
+		sessionID: cm.sessionID,
+	}, nil
+}
+
+// verifyCommitments validates commitments received from other group
+// members. Members who sent an invalid number of commitments or an invalid
+// proof of knowledge are marked as disqualified. Commitments of all members
+// who remain operating are recorded for the verification of shares.
+func (sm *sharingMember) verifyCommitments(
+	commitmentMessages []*commitmentMessage,
+) {
+	sm.receivedCommitments[sm.id] = sm.commitments
+
+	for _, message := range deduplicateBySender(commitmentMessages) {
+		if !sm.isValidCommitmentMessage(message) {
+			sm.group.MarkMemberAsDisqualified(message.senderID)
+			continue
+		}
+
+		sm.receivedCommitments[message.senderID] = message.commitments
+	}
+}
+
+// isValidCommitmentMessage validates a given commitmentMessage. Message is
+// considered valid if it contains exactly t commitments, where t is the
+// honest threshold, and a valid proof of knowledge of the secret
+// polynomial constant term.
+func (sm *sharingMember) isValidCommitmentMessage(
+	message *commitmentMessage,
+) bool {
+	if len(message.commitments) != sm.group.HonestThreshold() {
+		sm.logger.Warnf(
+			"[member:%v] commitment message from member [%v] "+
+				"contains [%v] commitments instead of [%v]",
+			sm.id,
+			message.senderID,
+			len(message.commitments),
+			sm.group.HonestThreshold(),
+		)
+		return false
+	}
+
+	if err := verifyKnowledgeProof(
+		message.senderID,
+		sm.sessionID,
+		message.commitments[0],
+		message.proof,
+	); err != nil {
+		sm.logger.Warnf(
+			"[member:%v] commitment message from member [%v] "+
+				"contains invalid proof of knowledge: [%v]",
+			sm.id,
+			message.senderID,
+			err,
+		)
+		return false
+	}
+
+	return true
+}
+
+// generateShares evaluates the secret polynomial of the member for every
+// other operating member and encrypts each share using the symmetric key
+// established with the given member.
+func (sm *sharingMember) generateShares() (*shareMessage, error) {
+	encryptedShares := make(map[group.MemberIndex][]byte)
+
+	for _, receiverID := range sm.group.OperatingMemberIDs() {
+		if receiverID == sm.id {
+			continue
+		}
+
+		symmetricKey, ok := sm.symmetricKeys[receiverID]
+		if !ok {
+			return nil, fmt.Errorf(
+				"cannot get symmetric key with member [%v]",
+				receiverID,
+			)
+		}
+
+		share := evaluatePolynomial(sm.coefficients, receiverID)
+
+		encryptedShare, err := symmetricKey.Encrypt(
+			share.FillBytes(make([]byte, shareLength)),
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot encrypt share for member [%v]: [%v]",
+				receiverID,
+				err,
+			)
+		}
+
+		encryptedShares[receiverID] = encryptedShare
+	}
+
+	return &shareMessage{
+		senderID:        sm.id,
+		encryptedShares: encryptedShares,
+		sessionID:       sm.sessionID,
+	}, nil
+}
+
+// computePrivateKeyShare decrypts and verifies shares received from other
+// operating members and combines them into the final private key share.
+// The group public key is the sum of the constant term commitments of all
+// operating members. If the group public key has an odd Y coordinate, the
+// group private key and all the shares are negated so that the group
+// public key can be used as a BIP-340 x-only public key.
+func (fm *finalizingMember) computePrivateKeyShare(
+	shareMessages []*shareMessage,
+) error {
+	operatingMembers := fm.group.OperatingMemberIDs()
+	if len(operatingMembers) < fm.group.HonestThreshold() {
+		return fmt.Errorf(
+			"[%v] operating members is less than the honest threshold [%v]",
+			len(operatingMembers),
+			fm.group.HonestThreshold(),
+		)
+	}
+
+	curveOrder := tschnorr.Curve.Params().N
+
+	secretShare := evaluatePolynomial(fm.coefficients, fm.id)
+
+	for _, message := range deduplicateBySender(shareMessages) {
+		share, err := fm.decryptShare(message)
+		if err != nil {
+			return err
+		}
+
+		secretShare.Add(secretShare, share)
+	}
+	secretShare.Mod(secretShare, curveOrder)
+
+	// Aggregate commitments of all operating members. The aggregated
+	// commitments are commitments to the coefficients of the group
+	// polynomial whose constant term is the group private key.
+	groupCommitments := make([]*tschnorr.Point, fm.group.HonestThreshold())
+	for _, memberID := range operatingMembers {
+		commitments, ok := fm.receivedCommitments[memberID]
+		if !ok {
+			return fmt.Errorf(
+				"no commitments received from member [%v]",
+				memberID,
+			)
+		}
+
+		for k, commitment := range commitments {
+			if groupCommitments[k] == nil {
+				groupCommitments[k] = commitment
+			} else {
+				groupCommitments[k] = groupCommitments[k].Add(commitment)
+			}
+		}
+	}
+
+	publicKey := groupCommitments[0]
+	if publicKey.IsInfinity() {
+		return fmt.Errorf("group public key is the point at infinity")
+	}
+
+	verificationShares := make(map[group.MemberIndex]*tschnorr.Point)
+	for _, memberID := range operatingMembers {
+		verificationShares[memberID] = evaluateCommitments(
+			groupCommitments,
+			memberID,
+		)
+	}
+
+	if !publicKey.HasEvenY() {
+		secretShare.Sub(curveOrder, secretShare)
+		publicKey = publicKey.Negate()
+		for memberID, verificationShare := range verificationShares {
+			verificationShares[memberID] = verificationShare.Negate()
+		}
+	}
+
+	fm.privateKeyShare = tschnorr.NewPrivateKeyShare(
+		fm.id,
+		secretShare,
+		publicKey,
+		verificationShares,
+	)
+
+	return nil
+}
+
+// decryptShare decrypts the share sent by the given message sender to this
+// member and verifies it against the sender's commitments.
+func (fm *finalizingMember) decryptShare(
+	message *shareMessage,
+) (*big.Int, error) {
+	senderID := message.senderID
+
+	encryptedShare, ok := message.encryptedShares[fm.id]
+	if !ok {
+		return nil, fmt.Errorf(
+			"no share for this member in the message from member [%v]",
+			senderID,
+		)
+	}
+
+	symmetricKey, ok := fm.symmetricKeys[senderID]
+	if !ok {
+		return nil, fmt.Errorf(
+			"cannot get symmetric key with member [%v]",
+			senderID,
+		)
+	}
+
+	shareBytes, err := symmetricKey.Decrypt(encryptedShare)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot decrypt share from member [%v]: [%v]",
+			senderID,
+			err,
+		)
+	}
+
+	share := new(big.Int).SetBytes(shareBytes)
+	if len(shareBytes) != shareLength ||
+		share.Cmp(tschnorr.Curve.Params().N) >= 0 {
+		return nil, fmt.Errorf("member [%v] sent malformed share", senderID)
+	}
+
+	commitments, ok := fm.receivedCommitments[senderID]
+	if !ok {
+		return nil, fmt.Errorf(
+			"no commitments received from member [%v]",
+			senderID,
+		)
+	}
+
+	expectedSharePoint := evaluateCommitments(commitments, fm.id)
+	if !tschnorr.ScalarBaseMult(share).Equal(expectedSharePoint) {
+		return nil, fmt.Errorf(
+			"member [%v] sent share inconsistent with commitments",
+			senderID,
+		)
+	}
+
+	return share, nil
+}
+
+// knowledgeProofChallenge computes the challenge of the proof of knowledge
+// of the secret polynomial constant term of the given member.
+func knowledgeProofChallenge(
+	memberID group.MemberIndex,
+	sessionID string,
+	secretCommitment *tschnorr.Point,
+	noncePoint *tschnorr.Point,
+) (*big.Int, error) {
+	secretCommitmentBytes, err := secretCommitment.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal secret commitment: [%v]", err)
+	}
+
+	noncePointBytes, err := noncePoint.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal nonce point: [%v]", err)
+	}
+
+	return tschnorr.HashToScalar(
+		knowledgeProofTag,
+		[]byte{byte(memberID)},
+		[]byte(sessionID),
+		secretCommitmentBytes,
+		noncePointBytes,
+	), nil
+}
+
+// verifyKnowledgeProof verifies the proof of knowledge of the secret
+// polynomial constant term of the given member.
+func verifyKnowledgeProof(
+	memberID group.MemberIndex,
+	sessionID string,
+	secretCommitment *tschnorr.Point,
+	proof *knowledgeProof,
+) error {
+	if proof == nil {
+		return fmt.Errorf("nil proof")
+	}
+
+	if proof.response.Cmp(tschnorr.Curve.Params().N) >= 0 {
+		return fmt.Errorf("response out of range")
+	}
+
+	challenge, err := knowledgeProofChallenge(
+		memberID,
+		sessionID,
+		secretCommitment,
+		proof.noncePoint,
+	)
+	if err != nil {
+		return err
+	}
+
+	// response * G == noncePoint + challenge * secretCommitment
+	expected := proof.noncePoint.Add(secretCommitment.ScalarMult(challenge))
+	if !tschnorr.ScalarBaseMult(proof.response).Equal(expected) {
+		return fmt.Errorf("proof verification failed")
+	}
+
+	return nil
+}
+
+// evaluatePolynomial evaluates the polynomial with the given coefficients
+// at the given member index.
+func evaluatePolynomial(
+	coefficients []*big.Int,
+	memberID group.MemberIndex,
+) *big.Int {
+	curveOrder := tschnorr.Curve.Params().N
+	x := big.NewInt(int64(memberID))
+
+	// Horner's method.
+	result := new(big.Int)
+	for k := len(coefficients) - 1; k >= 0; k-- {
+		result.Mul(result, x)
+		result.Add(result, coefficients[k])
+		result.Mod(result, curveOrder)
+	}
+
+	return result
+}
+
+// evaluateCommitments computes the commitment to the value of the polynomial
+// at the given member index, given commitments to the polynomial
+// coefficients.
+func evaluateCommitments(
+	commitments []*tschnorr.Point,
+	memberID group.MemberIndex,
+) *tschnorr.Point {
+	curveOrder := tschnorr.Curve.Params().N
+	x := big.NewInt(int64(memberID))
+
+	result := commitments[0]
+	power := big.NewInt(1)
+	for k := 1; k < len(commitments); k++ {
+		power.Mul(power, x)
+		power.Mod(power, curveOrder)
+		result = result.Add(commitments[k].ScalarMult(power))
+	}
+
+	return result
+}
+
+// deduplicateBySender removes duplicated items for the given sender.
+// It always takes the first item that occurs for the given sender
+// and ignores the subsequent ones.
+func deduplicateBySender[T interface{ SenderID() group.MemberIndex }](
+	list []T,
+) []T {
+	senders := make(map[group.MemberIndex]bool)
+	result := make([]T, 0)
+
+	for _, item := range list {
+		if _, exists := senders[item.SenderID()]; !exists {
+			senders[item.SenderID()] = true
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
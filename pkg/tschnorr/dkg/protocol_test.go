@@ -0,0 +1,156 @@
+package dkg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+func TestEvaluatePolynomial(t *testing.T) {
+	// f(x) = 3 + 2x + 5x^2
+	coefficients := []*big.Int{big.NewInt(3), big.NewInt(2), big.NewInt(5)}
+
+	var tests = map[string]struct {
+		memberID      group.MemberIndex
+		expectedValue int64
+	}{
+		"member 1": {
+			memberID:      1,
+			expectedValue: 10,
+		},
+		"member 2": {
+			memberID:      2,
+			expectedValue: 27,
+		},
+		"member 7": {
+			memberID:      7,
+			expectedValue: 262,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			value := evaluatePolynomial(coefficients, test.memberID)
+			if value.Cmp(big.NewInt(test.expectedValue)) != 0 {
+				t.Errorf(
+					"unexpected value\nexpected: %v\nactual:   %v\n",
+					test.expectedValue,
+					value,
+				)
+			}
+		})
+	}
+}
+
+func TestEvaluateCommitments(t *testing.T) {
+	coefficients := []*big.Int{big.NewInt(3), big.NewInt(2), big.NewInt(5)}
+
+	commitments := make([]*tschnorr.Point, len(coefficients))
+	for i, coefficient := range coefficients {
+		commitments[i] = tschnorr.ScalarBaseMult(coefficient)
+	}
+
+	for memberID := group.MemberIndex(1); memberID <= 5; memberID++ {
+		expected := tschnorr.ScalarBaseMult(
+			evaluatePolynomial(coefficients, memberID),
+		)
+		actual := evaluateCommitments(commitments, memberID)
+
+		if !expected.Equal(actual) {
+			t.Errorf(
+				"commitment to the value for member [%v] does not match",
+				memberID,
+			)
+		}
+	}
+}
+
+func TestVerifyKnowledgeProof(t *testing.T) {
+	secret := big.NewInt(123)
+	nonce := big.NewInt(456)
+
+	memberID := group.MemberIndex(3)
+	sessionID := "session-1"
+	secretCommitment := tschnorr.ScalarBaseMult(secret)
+	noncePoint := tschnorr.ScalarBaseMult(nonce)
+
+	challenge, err := knowledgeProofChallenge(
+		memberID,
+		sessionID,
+		secretCommitment,
+		noncePoint,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := new(big.Int).Mul(secret, challenge)
+	response.Add(response, nonce)
+	response.Mod(response, tschnorr.Curve.Params().N)
+
+	proof := &knowledgeProof{noncePoint: noncePoint, response: response}
+
+	var tests = map[string]struct {
+		memberID         group.MemberIndex
+		sessionID        string
+		secretCommitment *tschnorr.Point
+		proof            *knowledgeProof
+		expectValid      bool
+	}{
+		"valid proof": {
+			memberID:         memberID,
+			sessionID:        sessionID,
+			secretCommitment: secretCommitment,
+			proof:            proof,
+			expectValid:      true,
+		},
+		"proof for another member": {
+			memberID:         memberID + 1,
+			sessionID:        sessionID,
+			secretCommitment: secretCommitment,
+			proof:            proof,
+			expectValid:      false,
+		},
+		"proof for another session": {
+			memberID:         memberID,
+			sessionID:        "session-2",
+			secretCommitment: secretCommitment,
+			proof:            proof,
+			expectValid:      false,
+		},
+		"proof for another secret": {
+			memberID:         memberID,
+			sessionID:        sessionID,
+			secretCommitment: tschnorr.ScalarBaseMult(big.NewInt(124)),
+			proof:            proof,
+			expectValid:      false,
+		},
+		"nil proof": {
+			memberID:         memberID,
+			sessionID:        sessionID,
+			secretCommitment: secretCommitment,
+			proof:            nil,
+			expectValid:      false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := verifyKnowledgeProof(
+				test.memberID,
+				test.sessionID,
+				test.secretCommitment,
+				test.proof,
+			)
+
+			if test.expectValid && err != nil {
+				t.Errorf("unexpected error: [%v]", err)
+			}
+			if !test.expectValid && err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
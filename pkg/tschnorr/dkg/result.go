@@ -0,0 +1,56 @@
+package dkg
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+// Result of distributed key generation protocol.
+type Result struct {
+	// Group represents the group state, including members, disqualified,
+	// and inactive members.
+	Group *group.Group
+	// PrivateKeyShare is the threshold Schnorr private key share required
+	// to operate in the signing group generated as result of the DKG
+	// protocol.
+	PrivateKeyShare *tschnorr.PrivateKeyShare
+}
+
+// GroupPublicKeyBytes returns the BIP-340 x-only public key corresponding
+// to the private key share generated during the DKG protocol execution.
+func (r *Result) GroupPublicKeyBytes() ([]byte, error) {
+	if r.PrivateKeyShare == nil {
+		return nil, fmt.Errorf(
+			"cannot retrieve group public key as private key share is nil",
+		)
+	}
+
+	return r.PrivateKeyShare.XOnlyPublicKey(), nil
+}
+
+// MisbehavedMembersIndexes returns the indexes of group members that misbehaved
+// during the DKG procedure. The indexes are sorted.
+func (r *Result) MisbehavedMembersIndexes() []group.MemberIndex {
+	// Merge inactive and disqualified member indexes into 'misbehaved' set.
+	misbehaving := make(map[group.MemberIndex]bool)
+	for _, inactiveMemberIndex := range r.Group.InactiveMemberIDs() {
+		misbehaving[inactiveMemberIndex] = true
+	}
+	for _, disqualifiedMemberIndex := range r.Group.DisqualifiedMemberIDs() {
+		misbehaving[disqualifiedMemberIndex] = true
+	}
+
+	// Convert misbehaving member indexes set into sorted list.
+	var sorted []group.MemberIndex
+	for m := range misbehaving {
+		sorted = append(sorted, m)
+	}
+	sort.Slice(sorted[:], func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	return sorted
+}
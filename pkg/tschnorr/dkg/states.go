@@ -0,0 +1,301 @@
+package dkg
+
+import (
+	"context"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/state"
+)
+
+const (
+	silentStateDelayBlocks  = 0
+	silentStateActiveBlocks = 0
+
+	ephemeralKeyPairStateDelayBlocks  = 1
+	ephemeralKeyPairStateActiveBlocks = 5
+
+	commitmentStateDelayBlocks  = 1
+	commitmentStateActiveBlocks = 5
+
+	shareStateDelayBlocks  = 1
+	shareStateActiveBlocks = 5
+)
+
+// ProtocolBlocks returns the total number of blocks it takes to execute
+// all the required work defined by the DKG protocol.
+func ProtocolBlocks() uint64 {
+	return ephemeralKeyPairStateDelayBlocks +
+		ephemeralKeyPairStateActiveBlocks +
+		commitmentStateDelayBlocks +
+		commitmentStateActiveBlocks +
+		shareStateDelayBlocks +
+		shareStateActiveBlocks
+}
+
+// ephemeralKeyPairGenerationState is the state during which members broadcast
+// public ephemeral keys generated for other members of the group.
+// `ephemeralPublicKeyMessage`s are valid in this state.
+type ephemeralKeyPairGenerationState struct {
+	channel net.BroadcastChannel
+	member  *ephemeralKeyPairGeneratingMember
+
+	phaseMessages []*ephemeralPublicKeyMessage
+}
+
+func (ekpgs *ephemeralKeyPairGenerationState) DelayBlocks() uint64 {
+	return ephemeralKeyPairStateDelayBlocks
+}
+
+func (ekpgs *ephemeralKeyPairGenerationState) ActiveBlocks() uint64 {
+	return ephemeralKeyPairStateActiveBlocks
+}
+
+func (ekpgs *ephemeralKeyPairGenerationState) Initiate(ctx context.Context) error {
+	message, err := ekpgs.member.generateEphemeralKeyPair()
+	if err != nil {
+		return err
+	}
+
+	if err := ekpgs.channel.Send(ctx, message); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ekpgs *ephemeralKeyPairGenerationState) Receive(msg net.Message) error {
+	switch phaseMessage := msg.Payload().(type) {
+	case *ephemeralPublicKeyMessage:
+		if ekpgs.member.shouldAcceptMessage(
+			phaseMessage.SenderID(),
+			msg.SenderPublicKey(),
+		) && ekpgs.member.sessionID == phaseMessage.sessionID {
+			ekpgs.phaseMessages = append(ekpgs.phaseMessages, phaseMessage)
+		}
+	}
+
+	return nil
+}
+
+func (ekpgs *ephemeralKeyPairGenerationState) Next() (state.State, error) {
+	return &symmetricKeyGenerationState{
+		channel:               ekpgs.channel,
+		member:                ekpgs.member.initializeSymmetricKeyGeneration(),
+		previousPhaseMessages: ekpgs.phaseMessages,
+	}, nil
+}
+
+func (ekpgs *ephemeralKeyPairGenerationState) MemberIndex() group.MemberIndex {
+	return ekpgs.member.id
+}
+
+// symmetricKeyGenerationState is the state during which members compute
+// symmetric keys from the previously exchanged ephemeral public keys.
+// No messages are valid in this state.
+type symmetricKeyGenerationState struct {
+	channel net.BroadcastChannel
+	member  *symmetricKeyGeneratingMember
+
+	previousPhaseMessages []*ephemeralPublicKeyMessage
+}
+
+func (skgs *symmetricKeyGenerationState) DelayBlocks() uint64 {
+	return silentStateDelayBlocks
+}
+
+func (skgs *symmetricKeyGenerationState) ActiveBlocks() uint64 {
+	return silentStateActiveBlocks
+}
+
+func (skgs *symmetricKeyGenerationState) Initiate(ctx context.Context) error {
+	skgs.member.markInactiveMembers(skgs.previousPhaseMessages)
+
+	if len(skgs.member.group.InactiveMemberIDs()) > 0 {
+		return newInactiveMembersError(skgs.member.group.InactiveMemberIDs())
+	}
+
+	return skgs.member.generateSymmetricKeys(skgs.previousPhaseMessages)
+}
+
+func (skgs *symmetricKeyGenerationState) Receive(msg net.Message) error {
+	return nil
+}
+
+func (skgs *symmetricKeyGenerationState) Next() (state.State, error) {
+	return &commitmentState{
+		channel: skgs.channel,
+		member:  skgs.member.initializeCommitment(),
+	}, nil
+}
+
+func (skgs *symmetricKeyGenerationState) MemberIndex() group.MemberIndex {
+	return skgs.member.id
+}
+
+// commitmentState is the state during which members broadcast commitments
+// to their secret polynomials along with proofs of knowledge of the
+// polynomials' constant terms.
+// `commitmentMessage`s are valid in this state.
+type commitmentState struct {
+	channel net.BroadcastChannel
+	member  *committingMember
+
+	phaseMessages []*commitmentMessage
+}
+
+func (cs *commitmentState) DelayBlocks() uint64 {
+	return commitmentStateDelayBlocks
+}
+
+func (cs *commitmentState) ActiveBlocks() uint64 {
+	return commitmentStateActiveBlocks
+}
+
+func (cs *commitmentState) Initiate(ctx context.Context) error {
+	message, err := cs.member.generateCommitments()
+	if err != nil {
+		return err
+	}
+
+	if err := cs.channel.Send(ctx, message); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cs *commitmentState) Receive(msg net.Message) error {
+	switch phaseMessage := msg.Payload().(type) {
+	case *commitmentMessage:
+		if cs.member.shouldAcceptMessage(
+			phaseMessage.SenderID(),
+			msg.SenderPublicKey(),
+		) && cs.member.sessionID == phaseMessage.sessionID {
+			cs.phaseMessages = append(cs.phaseMessages, phaseMessage)
+		}
+	}
+
+	return nil
+}
+
+func (cs *commitmentState) Next() (state.State, error) {
+	return &shareState{
+		channel:               cs.channel,
+		member:                cs.member.initializeSharing(),
+		previousPhaseMessages: cs.phaseMessages,
+	}, nil
+}
+
+func (cs *commitmentState) MemberIndex() group.MemberIndex {
+	return cs.member.id
+}
+
+// shareState is the state during which members validate received
+// commitments and send encrypted secret shares to other operating members.
+// `shareMessage`s are valid in this state.
+type shareState struct {
+	channel net.BroadcastChannel
+	member  *sharingMember
+
+	previousPhaseMessages []*commitmentMessage
+
+	phaseMessages []*shareMessage
+}
+
+func (ss *shareState) DelayBlocks() uint64 {
+	return shareStateDelayBlocks
+}
+
+func (ss *shareState) ActiveBlocks() uint64 {
+	return shareStateActiveBlocks
+}
+
+func (ss *shareState) Initiate(ctx context.Context) error {
+	ss.member.markInactiveMembers(ss.previousPhaseMessages)
+
+	if len(ss.member.group.InactiveMemberIDs()) > 0 {
+		return newInactiveMembersError(ss.member.group.InactiveMemberIDs())
+	}
+
+	ss.member.verifyCommitments(ss.previousPhaseMessages)
+
+	message, err := ss.member.generateShares()
+	if err != nil {
+		return err
+	}
+
+	if err := ss.channel.Send(ctx, message); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ss *shareState) Receive(msg net.Message) error {
+	switch phaseMessage := msg.Payload().(type) {
+	case *shareMessage:
+		if ss.member.shouldAcceptMessage(
+			phaseMessage.SenderID(),
+			msg.SenderPublicKey(),
+		) && ss.member.sessionID == phaseMessage.sessionID {
+			ss.phaseMessages = append(ss.phaseMessages, phaseMessage)
+		}
+	}
+
+	return nil
+}
+
+func (ss *shareState) Next() (state.State, error) {
+	return &finalizationState{
+		channel:               ss.channel,
+		member:                ss.member.initializeFinalization(),
+		previousPhaseMessages: ss.phaseMessages,
+	}, nil
+}
+
+func (ss *shareState) MemberIndex() group.MemberIndex {
+	return ss.member.id
+}
+
+// finalizationState is the last state of the DKG protocol - in this state,
+// distributed key generation is completed. No messages are valid in this state.
+//
+// State prepares a result to that is returned to the caller.
+type finalizationState struct {
+	channel net.BroadcastChannel
+	member  *finalizingMember
+
+	previousPhaseMessages []*shareMessage
+}
+
+func (fs *finalizationState) DelayBlocks() uint64 {
+	return silentStateDelayBlocks
+}
+
+func (fs *finalizationState) ActiveBlocks() uint64 {
+	return silentStateActiveBlocks
+}
+
+func (fs *finalizationState) Initiate(ctx context.Context) error {
+	fs.member.markInactiveMembers(fs.previousPhaseMessages)
+
+	if len(fs.member.group.InactiveMemberIDs()) > 0 {
+		return newInactiveMembersError(fs.member.group.InactiveMemberIDs())
+	}
+
+	return fs.member.computePrivateKeyShare(fs.previousPhaseMessages)
+}
+
+func (fs *finalizationState) Receive(msg net.Message) error {
+	return nil
+}
+
+func (fs *finalizationState) Next() (state.State, error) {
+	return nil, nil
+}
+
+func (fs *finalizationState) MemberIndex() group.MemberIndex {
+	return fs.member.id
+}
+
+func (fs *finalizationState) result() *Result {
+	return fs.member.Result()
+}
@@ -0,0 +1,184 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: pkg/tschnorr/gen/pb/key.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PrivateKeyShare struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SecretShare        []byte            `protobuf:"bytes,1,opt,name=secretShare,proto3" json:"secretShare,omitempty"`
+	PublicKey          []byte            `protobuf:"bytes,2,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	VerificationShares map[uint32][]byte `protobuf:"bytes,3,rep,name=verificationShares,proto3" json:"verificationShares,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	MemberIndex        uint32            `protobuf:"varint,4,opt,name=memberIndex,proto3" json:"memberIndex,omitempty"`
+}
+
+func (x *PrivateKeyShare) Reset() {
+	*x = PrivateKeyShare{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tschnorr_gen_pb_key_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrivateKeyShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrivateKeyShare) ProtoMessage() {}
+
+func (x *PrivateKeyShare) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tschnorr_gen_pb_key_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrivateKeyShare.ProtoReflect.Descriptor instead.
+func (*PrivateKeyShare) Descriptor() ([]byte, []int) {
+	return file_pkg_tschnorr_gen_pb_key_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PrivateKeyShare) GetSecretShare() []byte {
+	if x != nil {
+		return x.SecretShare
+	}
+	return nil
+}
+
+func (x *PrivateKeyShare) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *PrivateKeyShare) GetVerificationShares() map[uint32][]byte {
+	if x != nil {
+		return x.VerificationShares
+	}
+	return nil
+}
+
+func (x *PrivateKeyShare) GetMemberIndex() uint32 {
+	if x != nil {
+		return x.MemberIndex
+	}
+	return 0
+}
+
+var File_pkg_tschnorr_gen_pb_key_proto protoreflect.FileDescriptor
+
+var file_pkg_tschnorr_gen_pb_key_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x70, 0x6b, 0x67, 0x2f, 0x74, 0x73, 0x63, 0x68, 0x6e, 0x6f, 0x72, 0x72, 0x2f, 0x67,
+	0x65, 0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x6b, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x08, 0x74, 0x73, 0x63, 0x68, 0x6e, 0x6f, 0x72, 0x72, 0x22, 0x9d, 0x02, 0x0a, 0x0f, 0x50, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0b, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x61, 0x0a,
+	0x12, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x74, 0x73, 0x63, 0x68,
+	0x6e, 0x6f, 0x72, 0x72, 0x2e, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x12, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x1a, 0x45, 0x0a, 0x17, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_tschnorr_gen_pb_key_proto_rawDescOnce sync.Once
+	file_pkg_tschnorr_gen_pb_key_proto_rawDescData = file_pkg_tschnorr_gen_pb_key_proto_rawDesc
+)
+
+func file_pkg_tschnorr_gen_pb_key_proto_rawDescGZIP() []byte {
+	file_pkg_tschnorr_gen_pb_key_proto_rawDescOnce.Do(func() {
+		file_pkg_tschnorr_gen_pb_key_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_tschnorr_gen_pb_key_proto_rawDescData)
+	})
+	return file_pkg_tschnorr_gen_pb_key_proto_rawDescData
+}
+
+var file_pkg_tschnorr_gen_pb_key_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pkg_tschnorr_gen_pb_key_proto_goTypes = []interface{}{
+	(*PrivateKeyShare)(nil), // 0: tschnorr.PrivateKeyShare
+	nil,                     // 1: tschnorr.PrivateKeyShare.VerificationSharesEntry
+}
+var file_pkg_tschnorr_gen_pb_key_proto_depIdxs = []int32{
+	1, // 0: tschnorr.PrivateKeyShare.verificationShares:type_name -> tschnorr.PrivateKeyShare.VerificationSharesEntry
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pkg_tschnorr_gen_pb_key_proto_init() }
+func file_pkg_tschnorr_gen_pb_key_proto_init() {
+	if File_pkg_tschnorr_gen_pb_key_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_tschnorr_gen_pb_key_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrivateKeyShare); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_tschnorr_gen_pb_key_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_tschnorr_gen_pb_key_proto_goTypes,
+		DependencyIndexes: file_pkg_tschnorr_gen_pb_key_proto_depIdxs,
+		MessageInfos:      file_pkg_tschnorr_gen_pb_key_proto_msgTypes,
+	}.Build()
+	File_pkg_tschnorr_gen_pb_key_proto = out.File
+	file_pkg_tschnorr_gen_pb_key_proto_rawDesc = nil
+	file_pkg_tschnorr_gen_pb_key_proto_goTypes = nil
+	file_pkg_tschnorr_gen_pb_key_proto_depIdxs = nil
+}
@@ -0,0 +1,51 @@
+package tschnorr
+
+import (
+	"crypto/sha256"
+	"math/big"
+)
+
+// bip340ChallengeTag is the tag of the BIP-340 challenge hash.
+const bip340ChallengeTag = "BIP0340/challenge"
+
+// TaggedHash computes the tagged hash of the given data as defined by
+// BIP-340, that is SHA256(SHA256(tag) || SHA256(tag) || data...).
+func TaggedHash(tag string, data ...[]byte) [sha256.Size]byte {
+	tagHash := sha256.Sum256([]byte(tag))
+
+	hash := sha256.New()
+	hash.Write(tagHash[:])
+	hash.Write(tagHash[:])
+	for _, d := range data {
+		hash.Write(d)
+	}
+
+	var result [sha256.Size]byte
+	copy(result[:], hash.Sum(nil))
+	return result
+}
+
+// HashToScalar computes the tagged hash of the given data and interprets it
+// as a scalar modulo the curve order.
+func HashToScalar(tag string, data ...[]byte) *big.Int {
+	hash := TaggedHash(tag, data...)
+	return modN(new(big.Int).SetBytes(hash[:]))
+}
+
+// Challenge computes the BIP-340 challenge for the given nonce point,
+// public key and message. Both points are expected to have even Y
+// coordinates and are encoded using their X coordinates only.
+func Challenge(noncePoint *Point, publicKey *Point, message [32]byte) *big.Int {
+	return HashToScalar(
+		bip340ChallengeTag,
+		xOnlyBytes(noncePoint.X),
+		xOnlyBytes(publicKey.X),
+		message[:],
+	)
+}
+
+// xOnlyBytes returns the 32-byte big-endian representation of the given
+// coordinate.
+func xOnlyBytes(x *big.Int) []byte {
+	return x.FillBytes(make([]byte, 32))
+}
@@ -0,0 +1,84 @@
+// Package tschnorr contains the primitives of the threshold Schnorr
+// signature scheme over the secp256k1 curve. Signatures produced by the
+// scheme are BIP-340 compatible and can be used, for example, by Bitcoin
+// Taproot wallets.
+//
+// The distributed key generation and the signing protocols are implemented
+// in the dkg and signing subpackages respectively. Both follow the FROST
+// protocol described in https://eprint.iacr.org/2020/852.
+//
+// The scheme is not used by any node flow yet. Wallets are still controlled
+// by threshold ECDSA keys, see the tecdsa package, and the protocols
+// implemented here are exercised only by their tests.
+package tschnorr
+
+import (
+	"math/big"
+
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// PrivateKeyShare represents a private key share used to produce threshold
+// Schnorr signatures. Private key shares are generated as result of the
+// FROST distributed key generation (DKG) process.
+//
+// The group public key always has an even Y coordinate so it can be used
+// directly as a BIP-340 x-only public key.
+type PrivateKeyShare struct {
+	// memberIndex is the index of the group member holding the share.
+	memberIndex group.MemberIndex
+	// secretShare is the member's share of the group private key.
+	secretShare *big.Int
+	// publicKey is the group public key.
+	publicKey *Point
+	// verificationShares are public counterparts of secret shares of
+	// all group members holding a share.
+	verificationShares map[group.MemberIndex]*Point
+}
+
+// NewPrivateKeyShare constructs a new instance of the threshold Schnorr
+// private key share based on the DKG result.
+func NewPrivateKeyShare(
+	memberIndex group.MemberIndex,
+	secretShare *big.Int,
+	publicKey *Point,
+	verificationShares map[group.MemberIndex]*Point,
+) *PrivateKeyShare {
+	return &PrivateKeyShare{
+		memberIndex:        memberIndex,
+		secretShare:        secretShare,
+		publicKey:          publicKey,
+		verificationShares: verificationShares,
+	}
+}
+
+// MemberIndex returns the index of the group member holding the share.
+// The same member index must be used when signing with the share.
+func (pks *PrivateKeyShare) MemberIndex() group.MemberIndex {
+	return pks.memberIndex
+}
+
+// SecretShare returns the member's share of the group private key.
+func (pks *PrivateKeyShare) SecretShare() *big.Int {
+	return pks.secretShare
+}
+
+// PublicKey returns the group public key corresponding to the given private
+// key share.
+func (pks *PrivateKeyShare) PublicKey() *Point {
+	return pks.publicKey
+}
+
+// XOnlyPublicKey returns the 32-byte BIP-340 x-only form of the group
+// public key.
+func (pks *PrivateKeyShare) XOnlyPublicKey() []byte {
+	return xOnlyBytes(pks.publicKey.X)
+}
+
+// VerificationShare returns the public counterpart of the secret share of
+// the given member or nil if the member does not hold a share.
+func (pks *PrivateKeyShare) VerificationShare(
+	memberIndex group.MemberIndex,
+) *Point {
+	return pks.verificationShares[memberIndex]
+}
@@ -0,0 +1,92 @@
+package tschnorr
+
+import (
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tschnorr/gen/pb"
+)
+
+// Marshal converts the PrivateKeyShare to a byte array.
+func (pks *PrivateKeyShare) Marshal() ([]byte, error) {
+	publicKey, err := pks.publicKey.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal public key: [%v]", err)
+	}
+
+	verificationShares := make(
+		map[uint32][]byte,
+		len(pks.verificationShares),
+	)
+	for memberIndex, verificationShare := range pks.verificationShares {
+		verificationShareBytes, err := verificationShare.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot marshal verification share of member [%v]: [%v]",
+				memberIndex,
+				err,
+			)
+		}
+
+		verificationShares[uint32(memberIndex)] = verificationShareBytes
+	}
+
+	return proto.Marshal(&pb.PrivateKeyShare{
+		MemberIndex:        uint32(pks.memberIndex),
+		SecretShare:        pks.secretShare.Bytes(),
+		PublicKey:          publicKey,
+		VerificationShares: verificationShares,
+	})
+}
+
+// Unmarshal converts a byte array back to the PrivateKeyShare.
+func (pks *PrivateKeyShare) Unmarshal(bytes []byte) error {
+	pbPrivateKeyShare := pb.PrivateKeyShare{}
+	if err := proto.Unmarshal(bytes, &pbPrivateKeyShare); err != nil {
+		return fmt.Errorf("failed to unmarshal private key share: [%v]", err)
+	}
+
+	if pbPrivateKeyShare.MemberIndex == 0 ||
+		pbPrivateKeyShare.MemberIndex > group.MaxMemberIndex {
+		return fmt.Errorf(
+			"invalid member index value: [%v]",
+			pbPrivateKeyShare.MemberIndex,
+		)
+	}
+
+	publicKey, err := UnmarshalPoint(pbPrivateKeyShare.PublicKey)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal public key: [%v]", err)
+	}
+
+	verificationShares := make(
+		map[group.MemberIndex]*Point,
+		len(pbPrivateKeyShare.VerificationShares),
+	)
+	for memberIndex, verificationShareBytes := range pbPrivateKeyShare.VerificationShares {
+		if memberIndex > group.MaxMemberIndex {
+			return fmt.Errorf("invalid member index value: [%v]", memberIndex)
+		}
+
+		verificationShare, err := UnmarshalPoint(verificationShareBytes)
+		if err != nil {
+			return fmt.Errorf(
+				"cannot unmarshal verification share of member [%v]: [%v]",
+				memberIndex,
+				err,
+			)
+		}
+
+		verificationShares[group.MemberIndex(memberIndex)] = verificationShare
+	}
+
+	pks.memberIndex = group.MemberIndex(pbPrivateKeyShare.MemberIndex)
+	pks.secretShare = new(big.Int).SetBytes(pbPrivateKeyShare.SecretShare)
+	pks.publicKey = publicKey
+	pks.verificationShares = verificationShares
+
+	return nil
+}
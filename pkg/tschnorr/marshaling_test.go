@@ -0,0 +1,40 @@
+package tschnorr
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/internal/pbutils"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+func TestPrivateKeyShareMarshalling(t *testing.T) {
+	privateKeyShare := newTestPrivateKeyShare()
+
+	unmarshaled := &PrivateKeyShare{}
+
+	if err := pbutils.RoundTrip(privateKeyShare, unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(privateKeyShare, unmarshaled) {
+		t.Fatal("unexpected content of unmarshaled private key share")
+	}
+}
+
+func TestFuzzPrivateKeyShare_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&PrivateKeyShare{})
+}
+
+func newTestPrivateKeyShare() *PrivateKeyShare {
+	return NewPrivateKeyShare(
+		group.MemberIndex(2),
+		big.NewInt(1001),
+		ScalarBaseMult(big.NewInt(2002)),
+		map[group.MemberIndex]*Point{
+			1: ScalarBaseMult(big.NewInt(1001)),
+			2: ScalarBaseMult(big.NewInt(3003)),
+			3: ScalarBaseMult(big.NewInt(4004)),
+		},
+	)
+}
@@ -0,0 +1,123 @@
+package tschnorr
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// Curve is the curve implementation used across the tschnorr package.
+var Curve = btcec.S256()
+
+// compressedPointLength is the length of a point in the compressed form.
+const compressedPointLength = 33
+
+// Point represents a point on the secp256k1 curve. The point at infinity is
+// represented by zero coordinates, according to the convention used by the
+// crypto/elliptic package.
+type Point struct {
+	X *big.Int
+	Y *big.Int
+}
+
+// ScalarBaseMult computes k*G, where G is the generator of the curve.
+func ScalarBaseMult(k *big.Int) *Point {
+	x, y := Curve.ScalarBaseMult(modN(k).Bytes())
+	return &Point{x, y}
+}
+
+// ScalarMult computes k*P, where P is the given point.
+func (p *Point) ScalarMult(k *big.Int) *Point {
+	x, y := Curve.ScalarMult(p.X, p.Y, modN(k).Bytes())
+	return &Point{x, y}
+}
+
+// Add computes P+Q, where P is the given point and Q is the other point.
+func (p *Point) Add(other *Point) *Point {
+	x, y := Curve.Add(p.X, p.Y, other.X, other.Y)
+	return &Point{x, y}
+}
+
+// Negate computes -P, where P is the given point.
+func (p *Point) Negate() *Point {
+	if p.IsInfinity() {
+		return &Point{new(big.Int), new(big.Int)}
+	}
+
+	return &Point{
+		new(big.Int).Set(p.X),
+		new(big.Int).Sub(Curve.Params().P, p.Y),
+	}
+}
+
+// IsInfinity returns true if the given point is the point at infinity.
+func (p *Point) IsInfinity() bool {
+	return p.X.Sign() == 0 && p.Y.Sign() == 0
+}
+
+// HasEvenY returns true if the Y coordinate of the given point is even.
+// BIP-340 requires both the public key and the signature nonce point to have
+// an even Y coordinate.
+func (p *Point) HasEvenY() bool {
+	return p.Y.Bit(0) == 0
+}
+
+// Equal returns true if both points are the same.
+func (p *Point) Equal(other *Point) bool {
+	return p.X.Cmp(other.X) == 0 && p.Y.Cmp(other.Y) == 0
+}
+
+// Marshal converts the point to its 33-byte compressed form. The point at
+// infinity cannot be marshalled.
+func (p *Point) Marshal() ([]byte, error) {
+	if p.IsInfinity() {
+		return nil, fmt.Errorf("cannot marshal point at infinity")
+	}
+
+	var x, y btcec.FieldVal
+	x.SetByteSlice(p.X.Bytes())
+	y.SetByteSlice(p.Y.Bytes())
+
+	return btcec.NewPublicKey(&x, &y).SerializeCompressed(), nil
+}
+
+// UnmarshalPoint converts the 33-byte compressed form of a point back to
+// the point. It returns an error if the bytes do not represent a point on
+// the curve.
+func UnmarshalPoint(bytes []byte) (*Point, error) {
+	if len(bytes) != compressedPointLength {
+		return nil, fmt.Errorf(
+			"invalid point length: [%v]",
+			len(bytes),
+		)
+	}
+
+	publicKey, err := btcec.ParsePubKey(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid point: [%v]", err)
+	}
+
+	return &Point{publicKey.X(), publicKey.Y()}, nil
+}
+
+// RandomScalar returns a uniformly random, non-zero scalar modulo the curve
+// order.
+func RandomScalar() (*big.Int, error) {
+	for {
+		k, err := rand.Int(rand.Reader, Curve.Params().N)
+		if err != nil {
+			return nil, fmt.Errorf("cannot generate random scalar: [%v]", err)
+		}
+
+		if k.Sign() != 0 {
+			return k, nil
+		}
+	}
+}
+
+// modN reduces the given scalar modulo the curve order.
+func modN(k *big.Int) *big.Int {
+	return new(big.Int).Mod(k, Curve.Params().N)
+}
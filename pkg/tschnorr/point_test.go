@@ -0,0 +1,82 @@
+package tschnorr
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestPointMarshalling(t *testing.T) {
+	point := ScalarBaseMult(big.NewInt(12345))
+
+	bytes, err := point.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "marshaled point length", 33, len(bytes))
+
+	unmarshaled, err := UnmarshalPoint(bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !point.Equal(unmarshaled) {
+		t.Errorf("unexpected unmarshaled point")
+	}
+}
+
+func TestPointMarshalling_Infinity(t *testing.T) {
+	infinity := ScalarBaseMult(big.NewInt(0))
+
+	if !infinity.IsInfinity() {
+		t.Fatalf("expected point at infinity")
+	}
+
+	_, err := infinity.Marshal()
+	if err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestUnmarshalPoint_Invalid(t *testing.T) {
+	var tests = map[string]struct {
+		bytes []byte
+	}{
+		"empty": {
+			bytes: []byte{},
+		},
+		"too short": {
+			bytes: []byte{0x02, 0x01},
+		},
+		"not on curve": {
+			bytes: append([]byte{0x02}, make([]byte, 32)...),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := UnmarshalPoint(test.bytes)
+			if err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func TestPointNegate(t *testing.T) {
+	point := ScalarBaseMult(big.NewInt(777))
+
+	sum := point.Add(point.Negate())
+	if !sum.IsInfinity() {
+		t.Errorf("P + (-P) is not the point at infinity")
+	}
+
+	testutils.AssertBoolsEqual(
+		t,
+		"negated point Y parity",
+		!point.HasEvenY(),
+		point.Negate().HasEvenY(),
+	)
+}
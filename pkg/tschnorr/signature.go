@@ -0,0 +1,59 @@
+package tschnorr
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+// Signature holds a BIP-340 Schnorr signature in a form of the X coordinate
+// of the nonce point `R` and the `S` value.
+type Signature struct {
+	R *big.Int
+	S *big.Int
+}
+
+// Serialize returns the 64-byte BIP-340 encoding of the signature.
+func (s *Signature) Serialize() []byte {
+	return append(xOnlyBytes(s.R), xOnlyBytes(s.S)...)
+}
+
+// Verify checks the signature against the given message and the x-only
+// public key using the BIP-340 verification algorithm.
+func (s *Signature) Verify(message [32]byte, xOnlyPublicKey []byte) error {
+	signature, err := schnorr.ParseSignature(s.Serialize())
+	if err != nil {
+		return fmt.Errorf("invalid signature: [%v]", err)
+	}
+
+	publicKey, err := schnorr.ParsePubKey(xOnlyPublicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: [%v]", err)
+	}
+
+	if !signature.Verify(message[:], publicKey) {
+		return fmt.Errorf("signature verification failed")
+	}
+
+	return nil
+}
+
+// String formats Signature to a string that contains R and S values
+// as hexadecimals.
+func (s *Signature) String() string {
+	return fmt.Sprintf("R: %#x, S: %#x", s.R, s.S)
+}
+
+// MessageBytes converts the message to sign to the 32-byte form required by
+// BIP-340. It returns an error if the message does not fit in 32 bytes.
+func MessageBytes(message *big.Int) ([32]byte, error) {
+	var result [32]byte
+
+	if message.Sign() < 0 || message.BitLen() > 256 {
+		return result, fmt.Errorf("message does not fit in 32 bytes")
+	}
+
+	message.FillBytes(result[:])
+	return result, nil
+}
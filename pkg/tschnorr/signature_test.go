@@ -0,0 +1,125 @@
+package tschnorr
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+)
+
+func TestSignatureVerify(t *testing.T) {
+	privateKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := sha256.Sum256([]byte("message"))
+
+	btcecSignature, err := schnorr.Sign(privateKey, message[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serialized := btcecSignature.Serialize()
+	signature := &Signature{
+		R: new(big.Int).SetBytes(serialized[:32]),
+		S: new(big.Int).SetBytes(serialized[32:]),
+	}
+
+	testutils.AssertBytesEqual(t, serialized, signature.Serialize())
+
+	publicKey := schnorr.SerializePubKey(privateKey.PubKey())
+	otherMessage := sha256.Sum256([]byte("other message"))
+
+	var tests = map[string]struct {
+		message     [32]byte
+		publicKey   []byte
+		expectValid bool
+	}{
+		"valid signature": {
+			message:     message,
+			publicKey:   publicKey,
+			expectValid: true,
+		},
+		"other message": {
+			message:     otherMessage,
+			publicKey:   publicKey,
+			expectValid: false,
+		},
+		"other public key": {
+			message: message,
+			publicKey: xOnlyBytes(
+				ScalarBaseMult(big.NewInt(3)).X,
+			),
+			expectValid: false,
+		},
+		"malformed public key": {
+			message:     message,
+			publicKey:   []byte{0x01, 0x02},
+			expectValid: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := signature.Verify(test.message, test.publicKey)
+
+			if test.expectValid && err != nil {
+				t.Errorf("unexpected error: [%v]", err)
+			}
+			if !test.expectValid && err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func TestMessageBytes(t *testing.T) {
+	var tests = map[string]struct {
+		message       *big.Int
+		expectedError bool
+	}{
+		"small message": {
+			message: big.NewInt(100),
+		},
+		"32-byte message": {
+			message: new(big.Int).Sub(
+				new(big.Int).Lsh(big.NewInt(1), 256),
+				big.NewInt(1),
+			),
+		},
+		"too long message": {
+			message:       new(big.Int).Lsh(big.NewInt(1), 256),
+			expectedError: true,
+		},
+		"negative message": {
+			message:       big.NewInt(-1),
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			bytes, err := MessageBytes(test.message)
+
+			if test.expectedError {
+				if err == nil {
+					t.Errorf("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if new(big.Int).SetBytes(bytes[:]).Cmp(test.message) != 0 {
+				t.Errorf("unexpected message bytes")
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package signing
+
+import (
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+	"github.com/keep-network/keep-core/pkg/tschnorr/signing/gen/pb"
+)
+
+// Marshal converts this nonceCommitmentMessage to a byte array suitable for
+// network communication.
+func (ncm *nonceCommitmentMessage) Marshal() ([]byte, error) {
+	hidingNonceCommitment, err := ncm.hidingNonceCommitment.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot marshal hiding nonce commitment: [%v]",
+			err,
+		)
+	}
+
+	bindingNonceCommitment, err := ncm.bindingNonceCommitment.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot marshal binding nonce commitment: [%v]",
+			err,
+		)
+	}
+
+	return proto.Marshal(&pb.NonceCommitmentMessage{
+		SenderID:               uint32(ncm.senderID),
+		HidingNonceCommitment:  hidingNonceCommitment,
+		BindingNonceCommitment: bindingNonceCommitment,
+		SessionID:              ncm.sessionID,
+	})
+}
+
+// Unmarshal converts a byte array produced by Marshal to
+// a nonceCommitmentMessage.
+func (ncm *nonceCommitmentMessage) Unmarshal(bytes []byte) error {
+	pbMsg := pb.NonceCommitmentMessage{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return err
+	}
+
+	if err := validateMemberIndex(pbMsg.SenderID); err != nil {
+		return err
+	}
+
+	hidingNonceCommitment, err := tschnorr.UnmarshalPoint(
+		pbMsg.HidingNonceCommitment,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot unmarshal hiding nonce commitment: [%v]",
+			err,
+		)
+	}
+
+	bindingNonceCommitment, err := tschnorr.UnmarshalPoint(
+		pbMsg.BindingNonceCommitment,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot unmarshal binding nonce commitment: [%v]",
+			err,
+		)
+	}
+
+	ncm.senderID = group.MemberIndex(pbMsg.SenderID)
+	ncm.hidingNonceCommitment = hidingNonceCommitment
+	ncm.bindingNonceCommitment = bindingNonceCommitment
+	ncm.sessionID = pbMsg.SessionID
+
+	return nil
+}
+
+// Marshal converts this signatureShareMessage to a byte array suitable for
+// network communication.
+func (ssm *signatureShareMessage) Marshal() ([]byte, error) {
+	return proto.Marshal(&pb.SignatureShareMessage{
+		SenderID:       uint32(ssm.senderID),
+		SignatureShare: ssm.signatureShare.Bytes(),
+		SessionID:      ssm.sessionID,
+	})
+}
+
+// Unmarshal converts a byte array produced by Marshal to
+// a signatureShareMessage.
+func (ssm *signatureShareMessage) Unmarshal(bytes []byte) error {
+	pbMsg := pb.SignatureShareMessage{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return err
+	}
+
+	if err := validateMemberIndex(pbMsg.SenderID); err != nil {
+		return err
+	}
+
+	ssm.senderID = group.MemberIndex(pbMsg.SenderID)
+	ssm.signatureShare = new(big.Int).SetBytes(pbMsg.SignatureShare)
+	ssm.sessionID = pbMsg.SessionID
+
+	return nil
+}
+
+func validateMemberIndex(protoIndex uint32) error {
+	// Protobuf does not have uint8 type, so we are using uint32. When
+	// unmarshalling message, we need to make sure we do not overflow.
+	if protoIndex > group.MaxMemberIndex {
+		return fmt.Errorf("invalid member index value: [%v]", protoIndex)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package signing
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+
+	"github.com/keep-network/keep-core/pkg/internal/pbutils"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+func TestNonceCommitmentMessage_MarshalingRoundtrip(t *testing.T) {
+	msg := &nonceCommitmentMessage{
+		senderID:               group.MemberIndex(50),
+		hidingNonceCommitment:  tschnorr.ScalarBaseMult(big.NewInt(100)),
+		bindingNonceCommitment: tschnorr.ScalarBaseMult(big.NewInt(200)),
+		sessionID:              "session-1",
+	}
+	unmarshaled := &nonceCommitmentMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzNonceCommitmentMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID     group.MemberIndex
+			hidingNonce  *big.Int
+			bindingNonce *big.Int
+			sessionID    string
+		)
+
+		f := fuzz.New().NilChance(0).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&hidingNonce)
+		f.Fuzz(&bindingNonce)
+		f.Fuzz(&sessionID)
+
+		message := &nonceCommitmentMessage{
+			senderID:               senderID,
+			hidingNonceCommitment:  tschnorr.ScalarBaseMult(hidingNonce),
+			bindingNonceCommitment: tschnorr.ScalarBaseMult(bindingNonce),
+			sessionID:              sessionID,
+		}
+
+		_ = pbutils.RoundTrip(message, &nonceCommitmentMessage{})
+	}
+}
+
+func TestFuzzNonceCommitmentMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&nonceCommitmentMessage{})
+}
+
+func TestSignatureShareMessage_MarshalingRoundtrip(t *testing.T) {
+	msg := &signatureShareMessage{
+		senderID:       group.MemberIndex(50),
+		signatureShare: big.NewInt(300),
+		sessionID:      "session-1",
+	}
+	unmarshaled := &signatureShareMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzSignatureShareMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID       group.MemberIndex
+			signatureShare *big.Int
+			sessionID      string
+		)
+
+		f := fuzz.New().NilChance(0).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&signatureShare)
+		f.Fuzz(&sessionID)
+
+		message := &signatureShareMessage{
+			senderID:       senderID,
+			signatureShare: signatureShare,
+			sessionID:      sessionID,
+		}
+
+		_ = pbutils.RoundTrip(message, &signatureShareMessage{})
+	}
+}
+
+func TestFuzzSignatureShareMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&signatureShareMessage{})
+}
@@ -0,0 +1,188 @@
+package signing
+
+import (
+	"math/big"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+// Member represents a signing protocol member.
+type member struct {
+	// Logger used to produce log messages.
+	logger log.StandardLogger
+	// id of this group member.
+	id group.MemberIndex
+	// Group to which this member belongs.
+	group *group.Group
+	// Validator allowing to check public key and member index against
+	// group members
+	membershipValidator *group.MembershipValidator
+	// Identifier of the particular signing session this member is part of.
+	sessionID string
+	// Message that is the subject of the signing process, in the 32-byte
+	// form required by BIP-340.
+	message [32]byte
+	// Threshold Schnorr private key share of the member.
+	privateKeyShare *tschnorr.PrivateKeyShare
+}
+
+// newMember creates a new member in an initial state
+func newMember(
+	logger log.StandardLogger,
+	memberID group.MemberIndex,
+	groupSize,
+	dishonestThreshold int,
+	membershipValidator *group.MembershipValidator,
+	sessionID string,
+	message [32]byte,
+	privateKeyShare *tschnorr.PrivateKeyShare,
+) *member {
+	return &member{
+		logger:              logger,
+		id:                  memberID,
+		group:               group.NewGroup(dishonestThreshold, groupSize),
+		membershipValidator: membershipValidator,
+		sessionID:           sessionID,
+		message:             message,
+		privateKeyShare:     privateKeyShare,
+	}
+}
+
+// inactiveMemberFilter returns a new instance of the inactive member filter.
+func (m *member) inactiveMemberFilter() *group.InactiveMemberFilter {
+	return group.NewInactiveMemberFilter(m.logger, m.id, m.group)
+}
+
+// shouldAcceptMessage indicates whether the given member should accept
+// a message from the given sender.
+func (m *member) shouldAcceptMessage(
+	senderID group.MemberIndex,
+	senderPublicKey []byte,
+) bool {
+	isMessageFromSelf := senderID == m.id
+	isSenderValid := m.membershipValidator.IsValidMembership(
+		senderID,
+		senderPublicKey,
+	)
+	isSenderAccepted := m.group.IsOperating(senderID)
+
+	return !isMessageFromSelf && isSenderValid && isSenderAccepted
+}
+
+// initializeNonceCommitment performs a transition of a member state
+// from the initial state to the first phase of the protocol.
+func (m *member) initializeNonceCommitment() *nonceCommittingMember {
+	return &nonceCommittingMember{
+		member: m,
+	}
+}
+
+// nonceCommittingMember represents one member in a signing group performing
+// the first round of the FROST signing. The member generates its hiding and
+// binding nonces and broadcasts commitments to them.
+type nonceCommittingMember struct {
+	*member
+
+	hidingNonce  *big.Int
+	bindingNonce *big.Int
+
+	hidingNonceCommitment  *tschnorr.Point
+	bindingNonceCommitment *tschnorr.Point
+}
+
+// initializeSignatureSharing performs a transition of the member state to
+// the next phase. It returns a member instance ready to execute the next
+// phase of the protocol.
+func (ncm *nonceCommittingMember) initializeSignatureSharing() *signatureSharingMember {
+	return &signatureSharingMember{
+		nonceCommittingMember: ncm,
+		nonceCommitments:      make(map[group.MemberIndex]*nonceCommitment),
+		bindingFactors:        make(map[group.MemberIndex]*big.Int),
+	}
+}
+
+// nonceCommitment holds commitments to nonces of a single signing group
+// member.
+type nonceCommitment struct {
+	hiding  *tschnorr.Point
+	binding *tschnorr.Point
+}
+
+// signatureSharingMember represents one member in a signing group performing
+// the second round of the FROST signing. The member computes the group
+// nonce point and broadcasts its signature share.
+type signatureSharingMember struct {
+	*nonceCommittingMember
+
+	// Nonce commitments of all signing participants, including the member
+	// itself.
+	nonceCommitments map[group.MemberIndex]*nonceCommitment
+	// Binding factors of all signing participants.
+	bindingFactors map[group.MemberIndex]*big.Int
+	// Signature nonce point with an even Y coordinate.
+	noncePoint *tschnorr.Point
+	// Whether nonces must be negated so that they correspond to the nonce
+	// point with an even Y coordinate.
+	negateNonces bool
+	// BIP-340 challenge of the signature.
+	challenge *big.Int
+	// Signature share produced by the member.
+	signatureShare *big.Int
+}
+
+// markInactiveMembers takes all messages from the previous signing protocol
+// execution phase and marks all member who did not send a message as IA.
+func (ssm *signatureSharingMember) markInactiveMembers(
+	nonceCommitmentMessages []*nonceCommitmentMessage,
+) {
+	markInactiveMembers(ssm.inactiveMemberFilter(), nonceCommitmentMessages)
+}
+
+// initializeFinalization performs a transition of the member state to the
+// last phase of the protocol.
+func (ssm *signatureSharingMember) initializeFinalization() *finalizingMember {
+	return &finalizingMember{
+		signatureSharingMember: ssm,
+	}
+}
+
+// finalizingMember represents one member of the given group, after it
+// completed the signing process.
+//
+// Prepares a result to publish in the last phase of the protocol.
+type finalizingMember struct {
+	*signatureSharingMember
+
+	signature *tschnorr.Signature
+}
+
+// markInactiveMembers takes all messages from the previous signing protocol
+// execution phase and marks all member who did not send a message as IA.
+func (fm *finalizingMember) markInactiveMembers(
+	signatureShareMessages []*signatureShareMessage,
+) {
+	markInactiveMembers(fm.inactiveMemberFilter(), signatureShareMessages)
+}
+
+// Result returns the result of the signing protocol.
+func (fm *finalizingMember) Result() *Result {
+	return &Result{
+		Signature: fm.signature,
+	}
+}
+
+// markInactiveMembers marks all members who did not send any of the given
+// messages as inactive using the given filter.
+func markInactiveMembers[T interface{ SenderID() group.MemberIndex }](
+	filter *group.InactiveMemberFilter,
+	messages []T,
+) {
+	for _, message := range messages {
+		filter.MarkMemberAsActive(message.SenderID())
+	}
+
+	filter.FlushInactiveMembers()
+}
@@ -0,0 +1,65 @@
+package signing
+
+import (
+	"math/big"
+
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/threshold"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+const messageTypePrefix = "tschnorr_signing/"
+
+// nonceCommitmentMessage is a message payload that carries the sender's
+// commitments to the hiding and binding nonces used to produce the
+// signature share. This is the message of the first round of the FROST
+// signing.
+type nonceCommitmentMessage struct {
+	senderID group.MemberIndex
+
+	hidingNonceCommitment  *tschnorr.Point
+	bindingNonceCommitment *tschnorr.Point
+	sessionID              string
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (ncm *nonceCommitmentMessage) SenderID() group.MemberIndex {
+	return ncm.senderID
+}
+
+// Type returns a string describing a nonceCommitmentMessage type for
+// marshaling purposes.
+func (ncm *nonceCommitmentMessage) Type() string {
+	return messageTypePrefix + "nonce_commitment_message"
+}
+
+// QuotaScope returns the message quota scope of the sender's session.
+func (ncm *nonceCommitmentMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(ncm.sessionID, ncm.senderID)
+}
+
+// signatureShareMessage is a message payload that carries the sender's
+// signature share. This is the message of the second round of the FROST
+// signing.
+type signatureShareMessage struct {
+	senderID group.MemberIndex
+
+	signatureShare *big.Int
+	sessionID      string
+}
+
+// SenderID returns protocol-level identifier of the message sender.
+func (ssm *signatureShareMessage) SenderID() group.MemberIndex {
+	return ssm.senderID
+}
+
+// Type returns a string describing a signatureShareMessage type for
+// marshaling purposes.
+func (ssm *signatureShareMessage) Type() string {
+	return messageTypePrefix + "signature_share_message"
+}
+
+// QuotaScope returns the message quota scope of the sender's session.
+func (ssm *signatureShareMessage) QuotaScope() string {
+	return threshold.MessageQuotaScope(ssm.sessionID, ssm.senderID)
+}
@@ -0,0 +1,380 @@
+package signing
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+// bindingFactorTag is the tag of the hash used to compute binding factors
+// of signing participants.
+const bindingFactorTag = "FROST/rho"
+
+// participantEncodingLength is the length in bytes of the encoded identifier
+// of a signing participant.
+const participantEncodingLength = 32
+
+// generateNonceCommitments generates the hiding and binding nonces of the
+// member and commits to them. The commitments are broadcasted within the
+// group.
+func (ncm *nonceCommittingMember) generateNonceCommitments() (
+	*nonceCommitmentMessage,
+	error,
+) {
+	hidingNonce, err := tschnorr.RandomScalar()
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate hiding nonce: [%v]", err)
+	}
+
+	bindingNonce, err := tschnorr.RandomScalar()
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate binding nonce: [%v]", err)
+	}
+
+	ncm.hidingNonce = hidingNonce
+	ncm.bindingNonce = bindingNonce
+	ncm.hidingNonceCommitment = tschnorr.ScalarBaseMult(hidingNonce)
+	ncm.bindingNonceCommitment = tschnorr.ScalarBaseMult(bindingNonce)
+
+	return &nonceCommitmentMessage{
+		senderID:               ncm.id,
+		hidingNonceCommitment:  ncm.hidingNonceCommitment,
+		bindingNonceCommitment: ncm.bindingNonceCommitment,
+		sessionID:              ncm.sessionID,
+	}, nil
+}
+
+// generateSignatureShare computes the signature nonce point and the
+// challenge based on nonce commitments of all signing participants and
+// produces the signature share of the member.
+func (ssm *signatureSharingMember) generateSignatureShare(
+	nonceCommitmentMessages []*nonceCommitmentMessage,
+) (*signatureShareMessage, error) {
+	ssm.nonceCommitments[ssm.id] = &nonceCommitment{
+		hiding:  ssm.hidingNonceCommitment,
+		binding: ssm.bindingNonceCommitment,
+	}
+	for _, message := range deduplicateBySender(nonceCommitmentMessages) {
+		ssm.nonceCommitments[message.senderID] = &nonceCommitment{
+			hiding:  message.hidingNonceCommitment,
+			binding: message.bindingNonceCommitment,
+		}
+	}
+
+	participants := ssm.group.OperatingMemberIDs()
+	if len(participants) < ssm.group.HonestThreshold() {
+		return nil, fmt.Errorf(
+			"[%v] signing participants is less than the honest threshold [%v]",
+			len(participants),
+			ssm.group.HonestThreshold(),
+		)
+	}
+
+	for _, participant := range participants {
+		if ssm.privateKeyShare.VerificationShare(participant) == nil {
+			return nil, fmt.Errorf(
+				"member [%v] does not hold a private key share",
+				participant,
+			)
+		}
+	}
+
+	secretShare := ssm.privateKeyShare.SecretShare()
+	if !tschnorr.ScalarBaseMult(secretShare).Equal(
+		ssm.privateKeyShare.VerificationShare(ssm.id),
+	) {
+		return nil, fmt.Errorf(
+			"secret share does not match the verification share",
+		)
+	}
+
+	groupPublicKey, err := ssm.privateKeyShare.PublicKey().Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal group public key: [%v]", err)
+	}
+
+	encodedCommitments, err := ssm.encodeNonceCommitments(participants)
+	if err != nil {
+		return nil, err
+	}
+
+	noncePoint := &tschnorr.Point{X: new(big.Int), Y: new(big.Int)}
+	for _, participant := range participants {
+		ssm.bindingFactors[participant] = bindingFactor(
+			groupPublicKey,
+			ssm.message,
+			encodedCommitments,
+			participant,
+		)
+
+		noncePoint = noncePoint.Add(ssm.participantNoncePoint(participant))
+	}
+
+	if noncePoint.IsInfinity() {
+		return nil, fmt.Errorf("nonce point is the point at infinity")
+	}
+
+	// BIP-340 requires the nonce point to have an even Y coordinate. If
+	// the aggregated nonce point has an odd one, all participants negate
+	// their nonces.
+	if !noncePoint.HasEvenY() {
+		noncePoint = noncePoint.Negate()
+		ssm.negateNonces = true
+	}
+
+	ssm.noncePoint = noncePoint
+	ssm.challenge = tschnorr.Challenge(
+		noncePoint,
+		ssm.privateKeyShare.PublicKey(),
+		ssm.message,
+	)
+
+	curveOrder := tschnorr.Curve.Params().N
+
+	// nonce = hidingNonce + bindingFactor * bindingNonce
+	nonce := new(big.Int).Mul(ssm.bindingFactors[ssm.id], ssm.bindingNonce)
+	nonce.Add(nonce, ssm.hidingNonce)
+	nonce.Mod(nonce, curveOrder)
+	if ssm.negateNonces {
+		nonce.Sub(curveOrder, nonce)
+	}
+
+	// signatureShare = nonce + lagrangeCoefficient * secretShare * challenge
+	signatureShare := lagrangeCoefficient(ssm.id, participants)
+	signatureShare.Mul(signatureShare, secretShare)
+	signatureShare.Mul(signatureShare, ssm.challenge)
+	signatureShare.Add(signatureShare, nonce)
+	signatureShare.Mod(signatureShare, curveOrder)
+
+	ssm.signatureShare = signatureShare
+
+	return &signatureShareMessage{
+		senderID:       ssm.id,
+		signatureShare: signatureShare,
+		sessionID:      ssm.sessionID,
+	}, nil
+}
+
+// encodeNonceCommitments encodes the list of nonce commitments of the given
+// signing participants. The encoded list is used to compute binding factors.
+func (ssm *signatureSharingMember) encodeNonceCommitments(
+	participants []group.MemberIndex,
+) ([]byte, error) {
+	var encoded []byte
+	for _, participant := range participants {
+		commitment, ok := ssm.nonceCommitments[participant]
+		if !ok {
+			return nil, fmt.Errorf(
+				"no nonce commitments received from member [%v]",
+				participant,
+			)
+		}
+
+		hiding, err := commitment.hiding.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot marshal hiding nonce commitment of member [%v]: [%v]",
+				participant,
+				err,
+			)
+		}
+
+		binding, err := commitment.binding.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot marshal binding nonce commitment of member [%v]: [%v]",
+				participant,
+				err,
+			)
+		}
+
+		encoded = append(encoded, encodeParticipant(participant)...)
+		encoded = append(encoded, hiding...)
+		encoded = append(encoded, binding...)
+	}
+
+	return encoded, nil
+}
+
+// bindingFactor computes the binding factor of the given signing participant.
+// The hash input starts with the group public key, the message and the
+// encoded nonce commitments of all participants, which are the same for all
+// participants, and ends with the participant identifier, following the order
+// used by RFC 9591. Including the group public key binds nonce commitments to
+// the key so they cannot be reused across groups.
+func bindingFactor(
+	groupPublicKey []byte,
+	message [32]byte,
+	encodedCommitments []byte,
+	participant group.MemberIndex,
+) *big.Int {
+	return tschnorr.HashToScalar(
+		bindingFactorTag,
+		groupPublicKey,
+		message[:],
+		encodedCommitments,
+		encodeParticipant(participant),
+	)
+}
+
+// encodeParticipant encodes the identifier of the given participant as
+// a 32-byte big-endian scalar so that the encoding has a fixed width
+// regardless of the member index type.
+func encodeParticipant(participant group.MemberIndex) []byte {
+	return new(big.Int).SetUint64(uint64(participant)).FillBytes(
+		make([]byte, participantEncodingLength),
+	)
+}
+
+// participantNoncePoint computes the nonce point contribution of the given
+// participant, based on its nonce commitments and the binding factor.
+func (ssm *signatureSharingMember) participantNoncePoint(
+	participant group.MemberIndex,
+) *tschnorr.Point {
+	commitment := ssm.nonceCommitments[participant]
+
+	return commitment.hiding.Add(
+		commitment.binding.ScalarMult(ssm.bindingFactors[participant]),
+	)
+}
+
+// aggregateSignatureShares verifies signature shares received from other
+// signing participants and aggregates them into the final signature.
+func (fm *finalizingMember) aggregateSignatureShares(
+	signatureShareMessages []*signatureShareMessage,
+) error {
+	curveOrder := tschnorr.Curve.Params().N
+	participants := fm.group.OperatingMemberIDs()
+
+	signatureShares := map[group.MemberIndex]*big.Int{
+		fm.id: fm.signatureShare,
+	}
+	for _, message := range deduplicateBySender(signatureShareMessages) {
+		signatureShares[message.senderID] = message.signatureShare
+	}
+
+	s := new(big.Int)
+	for _, participant := range participants {
+		signatureShare, ok := signatureShares[participant]
+		if !ok {
+			return fmt.Errorf(
+				"no signature share received from member [%v]",
+				participant,
+			)
+		}
+
+		if err := fm.verifySignatureShare(
+			participant,
+			signatureShare,
+			participants,
+		); err != nil {
+			return fmt.Errorf(
+				"member [%v] sent invalid signature share: [%v]",
+				participant,
+				err,
+			)
+		}
+
+		s.Add(s, signatureShare)
+	}
+	s.Mod(s, curveOrder)
+
+	signature := &tschnorr.Signature{
+		R: fm.noncePoint.X,
+		S: s,
+	}
+
+	if err := signature.Verify(
+		fm.message,
+		fm.privateKeyShare.XOnlyPublicKey(),
+	); err != nil {
+		return fmt.Errorf("invalid aggregated signature: [%v]", err)
+	}
+
+	fm.signature = signature
+
+	return nil
+}
+
+// verifySignatureShare verifies the signature share of the given
+// participant against its nonce commitments and verification share.
+func (fm *finalizingMember) verifySignatureShare(
+	participant group.MemberIndex,
+	signatureShare *big.Int,
+	participants []group.MemberIndex,
+) error {
+	if signatureShare.Cmp(tschnorr.Curve.Params().N) >= 0 {
+		return fmt.Errorf("signature share out of range")
+	}
+
+	noncePoint := fm.participantNoncePoint(participant)
+	if fm.negateNonces {
+		noncePoint = noncePoint.Negate()
+	}
+
+	// signatureShare * G ==
+	//     noncePoint + challenge * lagrangeCoefficient * verificationShare
+	scalar := lagrangeCoefficient(participant, participants)
+	scalar.Mul(scalar, fm.challenge)
+
+	expected := noncePoint.Add(
+		fm.privateKeyShare.VerificationShare(participant).ScalarMult(scalar),
+	)
+
+	if !tschnorr.ScalarBaseMult(signatureShare).Equal(expected) {
+		return fmt.Errorf("signature share verification failed")
+	}
+
+	return nil
+}
+
+// lagrangeCoefficient computes the Lagrange coefficient of the given member
+// evaluated at zero, over the given set of participants.
+func lagrangeCoefficient(
+	memberID group.MemberIndex,
+	participants []group.MemberIndex,
+) *big.Int {
+	curveOrder := tschnorr.Curve.Params().N
+
+	numerator := big.NewInt(1)
+	denominator := big.NewInt(1)
+	for _, participant := range participants {
+		if participant == memberID {
+			continue
+		}
+
+		numerator.Mul(numerator, big.NewInt(int64(participant)))
+		numerator.Mod(numerator, curveOrder)
+
+		denominator.Mul(
+			denominator,
+			big.NewInt(int64(participant)-int64(memberID)),
+		)
+		denominator.Mod(denominator, curveOrder)
+	}
+
+	denominator.ModInverse(denominator, curveOrder)
+
+	return numerator.Mul(numerator, denominator).Mod(numerator, curveOrder)
+}
+
+// deduplicateBySender removes duplicated items for the given sender.
+// It always takes the first item that occurs for the given sender
+// and ignores the subsequent ones.
+func deduplicateBySender[T interface{ SenderID() group.MemberIndex }](
+	list []T,
+) []T {
+	senders := make(map[group.MemberIndex]bool)
+	result := make([]T, 0)
+
+	for _, item := range list {
+		if _, exists := senders[item.SenderID()]; !exists {
+			senders[item.SenderID()] = true
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,117 @@
+package signing
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+func TestLagrangeCoefficient(t *testing.T) {
+	curveOrder := tschnorr.Curve.Params().N
+
+	// f(x) = 7 + 3x + 11x^2, so any 3 points determine f(0) = 7.
+	polynomial := func(x int64) *big.Int {
+		return big.NewInt(7 + 3*x + 11*x*x)
+	}
+
+	var tests = map[string]struct {
+		participants []group.MemberIndex
+	}{
+		"consecutive participants": {
+			participants: []group.MemberIndex{1, 2, 3},
+		},
+		"non-consecutive participants": {
+			participants: []group.MemberIndex{2, 5, 9},
+		},
+		"more participants than needed": {
+			participants: []group.MemberIndex{1, 3, 4, 6},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			secret := new(big.Int)
+			for _, participant := range test.participants {
+				term := lagrangeCoefficient(participant, test.participants)
+				term.Mul(term, polynomial(int64(participant)))
+				secret.Add(secret, term)
+			}
+			secret.Mod(secret, curveOrder)
+
+			if secret.Cmp(big.NewInt(7)) != 0 {
+				t.Errorf(
+					"unexpected interpolated secret\nexpected: %v\nactual:   %v\n",
+					7,
+					secret,
+				)
+			}
+		})
+	}
+}
+
+func TestBindingFactor(t *testing.T) {
+	groupPublicKey, err := tschnorr.ScalarBaseMult(big.NewInt(11)).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherGroupPublicKey, err := tschnorr.ScalarBaseMult(big.NewInt(12)).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := [32]byte{1, 2, 3}
+	encodedCommitments := []byte{4, 5, 6}
+
+	factor := bindingFactor(groupPublicKey, message, encodedCommitments, 1)
+
+	var tests = map[string]struct {
+		groupPublicKey []byte
+		participant    group.MemberIndex
+	}{
+		"other group public key": {
+			groupPublicKey: otherGroupPublicKey,
+			participant:    1,
+		},
+		"other participant": {
+			groupPublicKey: groupPublicKey,
+			participant:    2,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			otherFactor := bindingFactor(
+				test.groupPublicKey,
+				message,
+				encodedCommitments,
+				test.participant,
+			)
+
+			if factor.Cmp(otherFactor) == 0 {
+				t.Errorf("binding factors are equal")
+			}
+		})
+	}
+}
+
+func TestEncodeParticipant(t *testing.T) {
+	for _, participant := range []group.MemberIndex{1, 100, 255} {
+		encoded := encodeParticipant(participant)
+
+		if len(encoded) != participantEncodingLength {
+			t.Errorf(
+				"unexpected length of encoded participant [%v]\n"+
+					"expected: %v\nactual:   %v\n",
+				participant,
+				participantEncodingLength,
+				len(encoded),
+			)
+		}
+
+		if new(big.Int).SetBytes(encoded).Uint64() != uint64(participant) {
+			t.Errorf("unexpected encoding of participant [%v]", participant)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package signing
+
+import (
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+// Result of the threshold Schnorr signing protocol.
+type Result struct {
+	// Signature is the BIP-340 Schnorr signature produced as result of the
+	// threshold Schnorr signing process.
+	Signature *tschnorr.Signature
+}
@@ -0,0 +1,121 @@
+// Package signing implements the FROST threshold Schnorr signing protocol
+// producing BIP-340 signatures with private key shares generated by the dkg
+// package. It is not used by any node flow yet.
+package signing
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/state"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+)
+
+// Execute runs the FROST threshold Schnorr signing protocol, given a message
+// to sign, broadcast channel to mediate with, a block counter used for time
+// tracking, a member index to use in the group, private key share, dishonest
+// threshold, and block height when signing protocol should start. The
+// produced signature is BIP-340 compatible.
+//
+// This function also supports signing execution with a subset of the signing
+// group by passing a non-empty excludedMembers slice holding the members that
+// should be excluded.
+func Execute(
+	logger log.StandardLogger,
+	message *big.Int,
+	sessionID string,
+	startBlockNumber uint64,
+	memberIndex group.MemberIndex,
+	privateKeyShare *tschnorr.PrivateKeyShare,
+	groupSize int,
+	dishonestThreshold int,
+	excludedMembersIndexes []group.MemberIndex,
+	blockCounter chain.BlockCounter,
+	channel net.BroadcastChannel,
+	membershipValidator *group.MembershipValidator,
+) (*Result, error) {
+	logger.Debugf("[member:%v] initializing member", memberIndex)
+
+	if privateKeyShare.MemberIndex() != memberIndex {
+		return nil, fmt.Errorf(
+			"private key share belongs to member [%v], not [%v]",
+			privateKeyShare.MemberIndex(),
+			memberIndex,
+		)
+	}
+
+	messageBytes, err := tschnorr.MessageBytes(message)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message to sign: [%v]", err)
+	}
+
+	registerUnmarshallers(channel)
+
+	member := newMember(
+		logger,
+		memberIndex,
+		groupSize,
+		dishonestThreshold,
+		membershipValidator,
+		sessionID,
+		messageBytes,
+		privateKeyShare,
+	)
+
+	// Mark excluded members as disqualified in order to not exchange messages
+	// with them.
+	for _, excludedMemberIndex := range excludedMembersIndexes {
+		if excludedMemberIndex != member.id {
+			member.group.MarkMemberAsDisqualified(excludedMemberIndex)
+		}
+	}
+
+	initialState := &nonceCommitmentState{
+		channel: channel,
+		member:  member.initializeNonceCommitment(),
+	}
+
+	stateMachine := state.NewMachine(logger, channel, blockCounter, initialState)
+
+	lastState, _, err := stateMachine.Execute(startBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	finalizationState, ok := lastState.(*finalizationState)
+	if !ok {
+		return nil, fmt.Errorf("execution ended on state: %T", lastState)
+	}
+
+	return finalizationState.result(), nil
+}
+
+// registerUnmarshallers initializes the given broadcast channel to be able to
+// perform signing protocol interactions by registering all the required
+// protocol message unmarshallers.
+func registerUnmarshallers(channel net.BroadcastChannel) {
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &nonceCommitmentMessage{}
+	})
+	channel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &signatureShareMessage{}
+	})
+
+	// FROST signing has two broadcast rounds: a member commits to its nonce
+	// pair once and publishes its signature share once. Any repeated message
+	// within the session-scoped quota, see threshold.MessageQuotaScope, is
+	// an attempt to equivocate and gets rejected.
+	if rateLimiter, ok := channel.(net.MessageRateLimiter); ok {
+		for _, message := range []net.TaggedUnmarshaler{
+			&nonceCommitmentMessage{},
+			&signatureShareMessage{},
+		} {
+			rateLimiter.SetMessageQuota(message.Type(), 1)
+		}
+	}
+}
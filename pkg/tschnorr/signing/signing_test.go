@@ -0,0 +1,597 @@
+package signing
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/net"
+	netLocal "github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/threshold"
+	"github.com/keep-network/keep-core/pkg/storage"
+	"github.com/keep-network/keep-core/pkg/tecdsa/retry"
+	"github.com/keep-network/keep-core/pkg/tschnorr"
+	"github.com/keep-network/keep-core/pkg/tschnorr/dkg"
+)
+
+const (
+	groupSize          = 5
+	dishonestThreshold = 2
+)
+
+// testEnvironment holds the local chain and network primitives shared by
+// all members executing the protocols in tests.
+type testEnvironment struct {
+	blockCounter        chain.BlockCounter
+	netProvider         net.Provider
+	membershipValidator *group.MembershipValidator
+}
+
+func TestExecute(t *testing.T) {
+	env := newTestEnvironment(t)
+
+	privateKeyShares := executeDKG(t, env)
+
+	message := big.NewInt(100)
+	messageBytes, err := tschnorr.MessageBytes(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		excludedMembersIndexes []group.MemberIndex
+	}{
+		"all members": {
+			excludedMembersIndexes: nil,
+		},
+		"subset of members": {
+			excludedMembersIndexes: []group.MemberIndex{2, 4},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			sessionID := fmt.Sprintf("tschnorr-signing-test-%v", testName)
+
+			results := executeSigning(
+				t,
+				env,
+				sessionID,
+				message,
+				privateKeyShares,
+				test.excludedMembersIndexes,
+			)
+
+			expectedSignature := results[1].Signature.Serialize()
+
+			for memberIndex, result := range results {
+				if err := result.Signature.Verify(
+					messageBytes,
+					privateKeyShares[memberIndex].XOnlyPublicKey(),
+				); err != nil {
+					t.Errorf(
+						"member [%v] produced invalid signature: [%v]",
+						memberIndex,
+						err,
+					)
+				}
+
+				testutils.AssertBytesEqual(
+					t,
+					expectedSignature,
+					result.Signature.Serialize(),
+				)
+			}
+		})
+	}
+}
+
+func TestExecute_ReloadedPrivateKeyShares(t *testing.T) {
+	env := newTestEnvironment(t)
+
+	generatedShares := executeDKG(t, env)
+
+	keyStorage, err := storage.Initialize(
+		storage.Config{Dir: t.TempDir()},
+		"password",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handle, err := keyStorage.InitializeKeyStorePersistence("tschnorr")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyShareStorage := tschnorr.NewKeyShareStorage(handle)
+
+	for _, privateKeyShare := range generatedShares {
+		if err := keyShareStorage.Save(privateKeyShare); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loadedShares, errs := keyShareStorage.LoadAll()
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: [%v]", errs)
+	}
+
+	groupPublicKey := generatedShares[1].XOnlyPublicKey()
+
+	groupShares := loadedShares[hex.EncodeToString(groupPublicKey)]
+	if len(groupShares) != groupSize {
+		t.Fatalf(
+			"unexpected number of loaded shares\nexpected: [%v]\nactual:   [%v]",
+			groupSize,
+			len(groupShares),
+		)
+	}
+
+	// The member index is not known from anywhere else than the loaded
+	// share itself.
+	privateKeyShares := make(map[group.MemberIndex]*tschnorr.PrivateKeyShare)
+	for _, privateKeyShare := range groupShares {
+		privateKeyShares[privateKeyShare.MemberIndex()] = privateKeyShare
+	}
+
+	message := big.NewInt(200)
+	messageBytes, err := tschnorr.MessageBytes(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := executeSigning(
+		t,
+		env,
+		"tschnorr-signing-test-reloaded",
+		message,
+		privateKeyShares,
+		nil,
+	)
+
+	for memberIndex, result := range results {
+		if err := result.Signature.Verify(
+			messageBytes,
+			groupPublicKey,
+		); err != nil {
+			t.Errorf(
+				"member [%v] produced invalid signature: [%v]",
+				memberIndex,
+				err,
+			)
+		}
+	}
+}
+
+// TestExecute_ThresholdFramework drives FROST signing with the building
+// blocks of the threshold package, the same way tECDSA signing is driven by
+// the tBTC node: members join the group channel, run attempts identified by
+// separate sessions, exclude operators observed as inactive from subsequent
+// attempts and determine the order of the result submission.
+func TestExecute_ThresholdFramework(t *testing.T) {
+	env := newTestEnvironment(t)
+
+	privateKeyShares := executeDKG(t, env)
+
+	groupPublicKey := privateKeyShares[1].XOnlyPublicKey()
+
+	localSigning := local_v1.Connect(
+		groupSize,
+		groupSize-dishonestThreshold,
+	).Signing()
+
+	operators := make(chain.Addresses, groupSize)
+	netProviders := make(map[group.MemberIndex]net.Provider)
+	for i := range operators {
+		operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(
+			local_v1.DefaultCurve,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		operators[i], err = localSigning.PublicKeyToAddress(operatorPublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		netProviders[group.MemberIndex(i+1)] = netLocal.ConnectWithPrivateKey(
+			operatorPrivateKey,
+		)
+	}
+
+	// Member 4 is offline during the whole signing. The remaining members
+	// observe it as inactive in the first attempt and exclude it from
+	// the second one.
+	offlineMemberIndex := group.MemberIndex(4)
+
+	message := big.NewInt(300)
+	messageBytes, err := tschnorr.MessageBytes(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channelName := threshold.ChannelName(
+		"tschnorr-signing",
+		hex.EncodeToString(groupPublicKey),
+	)
+
+	policy := &threshold.RetryPolicy{
+		MaxAttempts:       2,
+		DelayBlocks:       1,
+		ExclusionStrategy: threshold.InactivityExclusionStrategy,
+	}
+
+	initialStartBlock := env.startBlock(t)
+
+	type memberOutcome struct {
+		result  *Result
+		attempt *threshold.Attempt
+	}
+
+	var mutex sync.Mutex
+	outcomes := make(map[group.MemberIndex]*memberOutcome)
+
+	var wg sync.WaitGroup
+
+	for memberIndex, privateKeyShare := range privateKeyShares {
+		if memberIndex == offlineMemberIndex {
+			continue
+		}
+
+		wg.Add(1)
+		go func(
+			memberIndex group.MemberIndex,
+			privateKeyShare *tschnorr.PrivateKeyShare,
+		) {
+			defer wg.Done()
+
+			channel, membershipValidator, err := threshold.JoinBroadcastChannel(
+				&testutils.MockLogger{},
+				netProviders[memberIndex],
+				channelName,
+				1024*1024,
+				operators,
+				localSigning,
+			)
+			if err != nil {
+				t.Errorf("member [%v] failed to join channel: [%v]", memberIndex, err)
+				return
+			}
+
+			exclusionStrategy, err := threshold.NewExclusionStrategy(
+				policy.ExclusionStrategy,
+				operators,
+				threshold.RetrySeed(message),
+				uint(groupSize-dishonestThreshold),
+				retry.EvaluateRetryParticipantsForSigning,
+				0,
+			)
+			if err != nil {
+				t.Errorf("member [%v] failed to create strategy: [%v]", memberIndex, err)
+				return
+			}
+
+			// All operators are qualified for the first attempt. All members
+			// observe the same inactive member here so, unlike the tBTC
+			// retry loop, they do not need to agree on the inactivity claim.
+			retryLoop := threshold.NewRetryLoop(
+				memberIndex,
+				operators,
+				operators,
+				0,
+				threshold.RetrySeed(message),
+				initialStartBlock,
+				ProtocolBlocks(),
+				policy,
+				exclusionStrategy,
+			)
+
+			var result *Result
+			attempt, err := retryLoop.Start(
+				context.Background(),
+				func(attempt *threshold.Attempt) error {
+					var err error
+					result, err = Execute(
+						&testutils.MockLogger{},
+						message,
+						threshold.SessionID(message, attempt.Number),
+						attempt.StartBlock,
+						memberIndex,
+						privateKeyShare,
+						groupSize,
+						dishonestThreshold,
+						attempt.ExcludedMembersIndexes,
+						env.blockCounter,
+						channel,
+						membershipValidator,
+					)
+					return err
+				},
+				func(_ *threshold.Attempt, attemptErr error) []group.MemberIndex {
+					var inactiveMembersErr *InactiveMembersError
+					if errors.As(attemptErr, &inactiveMembersErr) {
+						return inactiveMembersErr.InactiveMembersIndexes
+					}
+					return nil
+				},
+			)
+			if err != nil {
+				t.Errorf("member [%v] signing failed: [%v]", memberIndex, err)
+				return
+			}
+
+			mutex.Lock()
+			outcomes[memberIndex] = &memberOutcome{
+				result:  result,
+				attempt: attempt,
+			}
+			mutex.Unlock()
+		}(memberIndex, privateKeyShare)
+	}
+
+	wg.Wait()
+
+	if len(outcomes) != groupSize-1 {
+		t.Fatalf(
+			"unexpected number of results\nexpected: [%v]\nactual:   [%v]",
+			groupSize-1,
+			len(outcomes),
+		)
+	}
+
+	expectedStartBlock := policy.NextAttemptStartBlock(
+		initialStartBlock,
+		ProtocolBlocks(),
+	)
+
+	submissionBlocks := make(map[uint64]group.MemberIndex)
+
+	for memberIndex, outcome := range outcomes {
+		if outcome.attempt.Number != 2 {
+			t.Errorf(
+				"member [%v] finished on unexpected attempt [%v]",
+				memberIndex,
+				outcome.attempt.Number,
+			)
+		}
+
+		if outcome.attempt.StartBlock != expectedStartBlock {
+			t.Errorf(
+				"member [%v] started the attempt at unexpected block\n"+
+					"expected: [%v]\nactual:   [%v]",
+				memberIndex,
+				expectedStartBlock,
+				outcome.attempt.StartBlock,
+			)
+		}
+
+		if !reflect.DeepEqual(
+			[]group.MemberIndex{offlineMemberIndex},
+			outcome.attempt.ExcludedMembersIndexes,
+		) {
+			t.Errorf(
+				"member [%v] excluded unexpected members: [%v]",
+				memberIndex,
+				outcome.attempt.ExcludedMembersIndexes,
+			)
+		}
+
+		if err := outcome.result.Signature.Verify(
+			messageBytes,
+			groupPublicKey,
+		); err != nil {
+			t.Errorf(
+				"member [%v] produced invalid signature: [%v]",
+				memberIndex,
+				err,
+			)
+		}
+
+		// Every member is eligible to submit the signature at a different
+		// block so members do not compete with each other.
+		submissionBlock := threshold.SubmissionEligibilityBlock(
+			outcome.attempt.StartBlock+ProtocolBlocks(),
+			threshold.SubmissionQueueIndex(uint64(memberIndex), 1, groupSize),
+			1,
+		)
+		if otherMemberIndex, ok := submissionBlocks[submissionBlock]; ok {
+			t.Errorf(
+				"members [%v] and [%v] are eligible to submit at the same block",
+				otherMemberIndex,
+				memberIndex,
+			)
+		}
+		submissionBlocks[submissionBlock] = memberIndex
+	}
+}
+
+func newTestEnvironment(t *testing.T) *testEnvironment {
+	operatorPrivateKey, operatorPublicKey, err := operator.GenerateKeyPair(
+		local_v1.DefaultCurve,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localChain := local_v1.ConnectWithKey(
+		groupSize,
+		groupSize-dishonestThreshold,
+		operatorPrivateKey,
+	)
+
+	address, err := localChain.Signing().PublicKeyToAddress(operatorPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operators := make(chain.Addresses, groupSize)
+	for i := range operators {
+		operators[i] = address
+	}
+
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testEnvironment{
+		blockCounter: blockCounter,
+		netProvider:  netLocal.ConnectWithPrivateKey(operatorPrivateKey),
+		membershipValidator: group.NewMembershipValidator(
+			&testutils.MockLogger{},
+			operators,
+			localChain.Signing(),
+		),
+	}
+}
+
+func (te *testEnvironment) startBlock(t *testing.T) uint64 {
+	currentBlock, err := te.blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for 3 blocks before starting the protocol to make sure all
+	// members are up.
+	return currentBlock + 3
+}
+
+func executeDKG(
+	t *testing.T,
+	env *testEnvironment,
+) map[group.MemberIndex]*tschnorr.PrivateKeyShare {
+	sessionID := "tschnorr-signing-test-dkg"
+
+	channel, err := env.netProvider.BroadcastChannelFor(sessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	startBlock := env.startBlock(t)
+
+	var mutex sync.Mutex
+	privateKeyShares := make(map[group.MemberIndex]*tschnorr.PrivateKeyShare)
+
+	var wg sync.WaitGroup
+	wg.Add(groupSize)
+
+	for i := 1; i <= groupSize; i++ {
+		memberIndex := group.MemberIndex(i)
+
+		go func() {
+			defer wg.Done()
+
+			result, _, err := dkg.Execute(
+				&testutils.MockLogger{},
+				sessionID,
+				startBlock,
+				memberIndex,
+				groupSize,
+				dishonestThreshold,
+				nil,
+				env.blockCounter,
+				channel,
+				env.membershipValidator,
+			)
+			if err != nil {
+				t.Errorf("member [%v] DKG failed: [%v]", memberIndex, err)
+				return
+			}
+
+			mutex.Lock()
+			privateKeyShares[memberIndex] = result.PrivateKeyShare
+			mutex.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	if len(privateKeyShares) != groupSize {
+		t.Fatalf("DKG failed")
+	}
+
+	return privateKeyShares
+}
+
+func executeSigning(
+	t *testing.T,
+	env *testEnvironment,
+	sessionID string,
+	message *big.Int,
+	privateKeyShares map[group.MemberIndex]*tschnorr.PrivateKeyShare,
+	excludedMembersIndexes []group.MemberIndex,
+) map[group.MemberIndex]*Result {
+	channel, err := env.netProvider.BroadcastChannelFor(sessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	startBlock := env.startBlock(t)
+
+	isExcluded := make(map[group.MemberIndex]bool)
+	for _, excludedMemberIndex := range excludedMembersIndexes {
+		isExcluded[excludedMemberIndex] = true
+	}
+
+	var mutex sync.Mutex
+	results := make(map[group.MemberIndex]*Result)
+
+	var wg sync.WaitGroup
+
+	for memberIndex, privateKeyShare := range privateKeyShares {
+		if isExcluded[memberIndex] {
+			continue
+		}
+
+		wg.Add(1)
+		go func(
+			memberIndex group.MemberIndex,
+			privateKeyShare *tschnorr.PrivateKeyShare,
+		) {
+			defer wg.Done()
+
+			result, err := Execute(
+				&testutils.MockLogger{},
+				message,
+				sessionID,
+				startBlock,
+				memberIndex,
+				privateKeyShare,
+				groupSize,
+				dishonestThreshold,
+				excludedMembersIndexes,
+				env.blockCounter,
+				channel,
+				env.membershipValidator,
+			)
+			if err != nil {
+				t.Errorf("member [%v] signing failed: [%v]", memberIndex, err)
+				return
+			}
+
+			mutex.Lock()
+			results[memberIndex] = result
+			mutex.Unlock()
+		}(memberIndex, privateKeyShare)
+	}
+
+	wg.Wait()
+
+	if len(results) != groupSize-len(excludedMembersIndexes) {
+		t.Fatalf("signing failed")
+	}
+
+	return results
+}
@@ -0,0 +1,194 @@
+package signing
+
+import (
+	"context"
+
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/protocol/state"
+)
+
+const (
+	silentStateDelayBlocks  = 0
+	silentStateActiveBlocks = 0
+
+	nonceCommitmentStateDelayBlocks  = 1
+	nonceCommitmentStateActiveBlocks = 5
+
+	signatureShareStateDelayBlocks  = 1
+	signatureShareStateActiveBlocks = 5
+)
+
+// ProtocolBlocks returns the total number of blocks it takes to execute
+// all the required work defined by the signing protocol.
+func ProtocolBlocks() uint64 {
+	return nonceCommitmentStateDelayBlocks +
+		nonceCommitmentStateActiveBlocks +
+		signatureShareStateDelayBlocks +
+		signatureShareStateActiveBlocks
+}
+
+// nonceCommitmentState is the state during which members broadcast
+// commitments to their hiding and binding nonces.
+// `nonceCommitmentMessage`s are valid in this state.
+type nonceCommitmentState struct {
+	channel net.BroadcastChannel
+	member  *nonceCommittingMember
+
+	phaseMessages []*nonceCommitmentMessage
+}
+
+func (ncs *nonceCommitmentState) DelayBlocks() uint64 {
+	return nonceCommitmentStateDelayBlocks
+}
+
+func (ncs *nonceCommitmentState) ActiveBlocks() uint64 {
+	return nonceCommitmentStateActiveBlocks
+}
+
+func (ncs *nonceCommitmentState) Initiate(ctx context.Context) error {
+	message, err := ncs.member.generateNonceCommitments()
+	if err != nil {
+		return err
+	}
+
+	if err := ncs.channel.Send(ctx, message); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ncs *nonceCommitmentState) Receive(msg net.Message) error {
+	switch phaseMessage := msg.Payload().(type) {
+	case *nonceCommitmentMessage:
+		if ncs.member.shouldAcceptMessage(
+			phaseMessage.SenderID(),
+			msg.SenderPublicKey(),
+		) && ncs.member.sessionID == phaseMessage.sessionID {
+			ncs.phaseMessages = append(ncs.phaseMessages, phaseMessage)
+		}
+	}
+
+	return nil
+}
+
+func (ncs *nonceCommitmentState) Next() (state.State, error) {
+	return &signatureShareState{
+		channel:               ncs.channel,
+		member:                ncs.member.initializeSignatureSharing(),
+		previousPhaseMessages: ncs.phaseMessages,
+	}, nil
+}
+
+func (ncs *nonceCommitmentState) MemberIndex() group.MemberIndex {
+	return ncs.member.id
+}
+
+// signatureShareState is the state during which members compute the
+// signature nonce point and broadcast their signature shares.
+// `signatureShareMessage`s are valid in this state.
+type signatureShareState struct {
+	channel net.BroadcastChannel
+	member  *signatureSharingMember
+
+	previousPhaseMessages []*nonceCommitmentMessage
+
+	phaseMessages []*signatureShareMessage
+}
+
+func (sss *signatureShareState) DelayBlocks() uint64 {
+	return signatureShareStateDelayBlocks
+}
+
+func (sss *signatureShareState) ActiveBlocks() uint64 {
+	return signatureShareStateActiveBlocks
+}
+
+func (sss *signatureShareState) Initiate(ctx context.Context) error {
+	sss.member.markInactiveMembers(sss.previousPhaseMessages)
+
+	if len(sss.member.group.InactiveMemberIDs()) > 0 {
+		return newInactiveMembersError(sss.member.group.InactiveMemberIDs())
+	}
+
+	message, err := sss.member.generateSignatureShare(sss.previousPhaseMessages)
+	if err != nil {
+		return err
+	}
+
+	if err := sss.channel.Send(ctx, message); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (sss *signatureShareState) Receive(msg net.Message) error {
+	switch phaseMessage := msg.Payload().(type) {
+	case *signatureShareMessage:
+		if sss.member.shouldAcceptMessage(
+			phaseMessage.SenderID(),
+			msg.SenderPublicKey(),
+		) && sss.member.sessionID == phaseMessage.sessionID {
+			sss.phaseMessages = append(sss.phaseMessages, phaseMessage)
+		}
+	}
+
+	return nil
+}
+
+func (sss *signatureShareState) Next() (state.State, error) {
+	return &finalizationState{
+		channel:               sss.channel,
+		member:                sss.member.initializeFinalization(),
+		previousPhaseMessages: sss.phaseMessages,
+	}, nil
+}
+
+func (sss *signatureShareState) MemberIndex() group.MemberIndex {
+	return sss.member.id
+}
+
+// finalizationState is the last state of the signing protocol - in this state,
+// signing is completed. No messages are valid in this state.
+//
+// State prepares a result that is returned to the caller.
+type finalizationState struct {
+	channel net.BroadcastChannel
+	member  *finalizingMember
+
+	previousPhaseMessages []*signatureShareMessage
+}
+
+func (fs *finalizationState) DelayBlocks() uint64 {
+	return silentStateDelayBlocks
+}
+
+func (fs *finalizationState) ActiveBlocks() uint64 {
+	return silentStateActiveBlocks
+}
+
+func (fs *finalizationState) Initiate(ctx context.Context) error {
+	fs.member.markInactiveMembers(fs.previousPhaseMessages)
+
+	if len(fs.member.group.InactiveMemberIDs()) > 0 {
+		return newInactiveMembersError(fs.member.group.InactiveMemberIDs())
+	}
+
+	return fs.member.aggregateSignatureShares(fs.previousPhaseMessages)
+}
+
+func (fs *finalizationState) Receive(msg net.Message) error {
+	return nil
+}
+
+func (fs *finalizationState) Next() (state.State, error) {
+	return nil, nil
+}
+
+func (fs *finalizationState) MemberIndex() group.MemberIndex {
+	return fs.member.id
+}
+
+func (fs *finalizationState) result() *Result {
+	return fs.member.Result()
+}
@@ -0,0 +1,120 @@
+package tschnorr
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+// KeyShareStorage persists private key shares using the underlying
+// persistence layer. It is meant to be used with a key store persistence
+// handle obtained from storage.Storage, for example:
+//
+//	handle, err := storage.InitializeKeyStorePersistence("tschnorr")
+//	keyShareStorage := tschnorr.NewKeyShareStorage(handle)
+type KeyShareStorage struct {
+	persistence persistence.ProtectedHandle
+}
+
+// NewKeyShareStorage creates a new instance of the KeyShareStorage.
+func NewKeyShareStorage(persistence persistence.ProtectedHandle) *KeyShareStorage {
+	return &KeyShareStorage{persistence}
+}
+
+// Save persists the given private key share. Shares are grouped in
+// directories named after the group public key. The index of the member
+// holding the share is a part of the persisted share.
+func (kss *KeyShareStorage) Save(privateKeyShare *PrivateKeyShare) error {
+	privateKeyShareBytes, err := privateKeyShare.Marshal()
+	if err != nil {
+		return fmt.Errorf("could not marshal private key share: [%w]", err)
+	}
+
+	err = kss.persistence.Save(
+		privateKeyShareBytes,
+		hex.EncodeToString(privateKeyShare.XOnlyPublicKey()),
+		fmt.Sprintf("/share_%v", privateKeyShare.MemberIndex()),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"could not save private key share using the "+
+				"underlying persistence layer: [%w]",
+			err,
+		)
+	}
+
+	return nil
+}
+
+// LoadAll loads all private key shares stored using the underlying
+// persistence layer. Shares are grouped by the hexadecimal representation of
+// the x-only group public key. Shares that could not be loaded are skipped
+// and the corresponding errors are returned.
+func (kss *KeyShareStorage) LoadAll() (map[string][]*PrivateKeyShare, []error) {
+	privateKeyShares := make(map[string][]*PrivateKeyShare)
+	errors := make([]error, 0)
+
+	descriptorsChan, errorsChan := kss.persistence.ReadAll()
+
+	// Channels do not have to be buffered, and we do not know in what order
+	// the information is written to them, so both are read concurrently.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	var errorsMutex sync.Mutex
+	appendError := func(err error) {
+		errorsMutex.Lock()
+		defer errorsMutex.Unlock()
+		errors = append(errors, err)
+	}
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range descriptorsChan {
+			content, err := descriptor.Content()
+			if err != nil {
+				appendError(fmt.Errorf(
+					"could not get content from file [%v] "+
+						"in directory [%v]: [%w]",
+					descriptor.Name(),
+					descriptor.Directory(),
+					err,
+				))
+				continue
+			}
+
+			privateKeyShare := &PrivateKeyShare{}
+			if err := privateKeyShare.Unmarshal(content); err != nil {
+				appendError(fmt.Errorf(
+					"could not unmarshal private key share from file [%v] "+
+						"in directory [%v]: [%w]",
+					descriptor.Name(),
+					descriptor.Directory(),
+					err,
+				))
+				continue
+			}
+
+			publicKey := hex.EncodeToString(privateKeyShare.XOnlyPublicKey())
+			privateKeyShares[publicKey] = append(
+				privateKeyShares[publicKey],
+				privateKeyShare,
+			)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChan {
+			appendError(err)
+		}
+	}()
+
+	wg.Wait()
+
+	return privateKeyShares, errors
+}
@@ -0,0 +1,45 @@
+package tschnorr
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/storage"
+)
+
+func TestKeyShareStorage_SaveLoadAll(t *testing.T) {
+	keyStorage, err := storage.Initialize(
+		storage.Config{Dir: t.TempDir()},
+		"password",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handle, err := keyStorage.InitializeKeyStorePersistence("tschnorr")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyShareStorage := NewKeyShareStorage(handle)
+
+	privateKeyShare := newTestPrivateKeyShare()
+	if err := keyShareStorage.Save(privateKeyShare); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, errs := keyShareStorage.LoadAll()
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: [%v]", errs)
+	}
+
+	expected := map[string][]*PrivateKeyShare{
+		hex.EncodeToString(privateKeyShare.XOnlyPublicKey()): {
+			privateKeyShare,
+		},
+	}
+	if !reflect.DeepEqual(expected, loaded) {
+		t.Errorf("unexpected loaded private key shares")
+	}
+}
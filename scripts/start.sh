@@ -6,6 +6,7 @@ LOG_LEVEL_DEFAULT="info"
 KEEP_CORE_PATH=$PWD
 CONFIG_DIR_DEFAULT="$KEEP_CORE_PATH/configs"
 KEEP_ETHEREUM_PASSWORD=${KEEP_ETHEREUM_PASSWORD:-"password"}
+KEEP_STORAGE_PASSWORD=${KEEP_STORAGE_PASSWORD:-"password"}
 
 help() {
     echo -e "\nUsage: ENV_VAR(S) $0" \
@@ -13,6 +14,8 @@ help() {
     echo -e "\nEnvironment variables:\n"
     echo -e "\tKEEP_ETHEREUM_PASSWORD: Ethereum account password." \
         "Required only for 'local' network. Default value is 'password'"
+    echo -e "\tKEEP_STORAGE_PASSWORD: Client storage password." \
+        "Default value is 'password'"
     echo -e "\nCommand line arguments:\n"
     echo -e "\t--config-dir: Path to a client configuration files\n"
     exit 1 # Exit script after printing help
@@ -103,5 +106,6 @@ KEEP_CORE_CONFIG_FILE_PATH=$CONFIG_FILE_PATH
 printf "${LOG_START}Starting keep-core client...${LOG_END}"
 cd $KEEP_CORE_PATH
 KEEP_ETHEREUM_PASSWORD=$KEEP_ETHEREUM_PASSWORD \
+    KEEP_STORAGE_PASSWORD=$KEEP_STORAGE_PASSWORD \
     LOG_LEVEL=${LOG_LEVEL} \
     ./keep-client --config $KEEP_CORE_CONFIG_FILE_PATH start --developer